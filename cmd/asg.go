@@ -3,6 +3,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
@@ -10,15 +11,20 @@ import (
 
 	awsconfig "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/johnlam90/aws-ssm/pkg/config"
 	"github.com/johnlam90/aws-ssm/pkg/ui/fuzzy"
 	"github.com/spf13/cobra"
 )
 
+// defaultScalingMaxStep is used when the config cannot be loaded
+const defaultScalingMaxStep = 50
+
 var (
 	asgMinSize         int32
 	asgMaxSize         int32
 	asgDesiredCapacity int32
 	asgSkipConfirm     bool
+	asgForce           bool
 )
 
 var asgCmd = &cobra.Command{
@@ -70,7 +76,13 @@ Examples:
   aws-ssm asg scale my-asg --desired 0
 
   # Skip confirmation prompt
-  aws-ssm asg scale my-asg --desired 5 --skip-confirm`,
+  aws-ssm asg scale my-asg --desired 5 --skip-confirm
+
+  # Override the max-step guard for a large capacity change
+  aws-ssm asg scale my-asg --desired 200 --force
+
+Large changes to desired capacity are blocked by the scaling.max_step and
+scaling.max_step_percent config settings unless --force is given.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runASGScale,
 }
@@ -87,6 +99,7 @@ func init() {
 	asgScaleCmd.Flags().Int32Var(&asgMaxSize, "max", -1, "Maximum size (optional - defaults to current or desired)")
 	asgScaleCmd.Flags().Int32Var(&asgDesiredCapacity, "desired", -1, "Desired capacity (required when ASG name is specified)")
 	asgScaleCmd.Flags().BoolVar(&asgSkipConfirm, "skip-confirm", false, "Skip confirmation prompt")
+	asgScaleCmd.Flags().BoolVar(&asgForce, "force", false, "Allow desired capacity changes larger than the configured max step")
 }
 
 func runASGScale(_ *cobra.Command, args []string) error {
@@ -144,6 +157,12 @@ func runASGScaleOnce(ctx context.Context, client *aws.Client, args []string) (bo
 	// Calculate final scaling parameters
 	finalParams := calculateScalingParameters(asg, asgInfo)
 
+	// Guard against catastrophic jumps in desired capacity
+	if err := checkScalingStep(loadScalingStepGuard(), asg.DesiredCapacity, finalParams.Desired, asgForce); err != nil {
+		return false, err
+	}
+	showBlastRadius(ctx, client, scalingBlastRadius("scale Auto Scaling Group", selectedASG, asg.DesiredCapacity, finalParams.Desired).withARN(asg.ARN))
+
 	// Display configuration and confirm
 	shouldRetry, confirmed := confirmASGScalingActionWithRetry(selectedASG, asg, finalParams)
 	if !confirmed {
@@ -166,7 +185,7 @@ func resolveASGAndParameters(ctx context.Context, client *aws.Client, args []str
 		// Command-line mode
 		selectedASG := args[0]
 		if asgDesiredCapacity == -1 {
-			return "", nil, fmt.Errorf("--desired flag is required when ASG name is provided")
+			return "", nil, usageErrorf("--desired flag is required when ASG name is provided")
 		}
 		return selectedASG, &fuzzy.ASGInfo{}, nil
 	}
@@ -184,14 +203,13 @@ func selectASGInteractively(ctx context.Context, client *aws.Client) (string, *f
 	colors := fuzzy.NewDefaultColorManager(noColor)
 	adapter := &asgClientAdapter{client: client}
 	loader := fuzzy.NewAWSASGLoader(adapter)
-	finder := fuzzy.NewASGFinder(loader, colors)
+	finder := fuzzy.NewASGFinder(loader, colors, sensitiveTagMask(client))
 
 	asgInfo, findErr := finder.SelectASGInteractive(ctx)
 	if findErr != nil {
 		// Check if it's a context cancellation (Ctrl+C)
-		if findErr == context.Canceled {
-			printSelectionCancelled()
-			return "", nil, nil
+		if errors.Is(findErr, context.Canceled) {
+			return "", nil, errCancelled
 		}
 		return "", nil, fmt.Errorf("failed to select ASG: %w", findErr)
 	}
@@ -291,12 +309,39 @@ func calculateScalingParameters(asg *aws.AutoScalingGroup, _ *fuzzy.ASGInfo) ASG
 	}
 }
 
+// loadScalingStepGuard builds the step guard from the application config
+func loadScalingStepGuard() aws.ScalingStepGuard {
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		// Fall back to the built-in default rather than disabling the guard
+		return aws.ScalingStepGuard{MaxStep: defaultScalingMaxStep}
+	}
+	return aws.ScalingStepGuard{
+		MaxStep:        int32(cfg.Scaling.MaxStep),
+		MaxStepPercent: int32(cfg.Scaling.MaxStepPercent),
+	}
+}
+
+// checkScalingStep blocks changes beyond the guard unless forced, warning when forced
+func checkScalingStep(guard aws.ScalingStepGuard, current, desired int32, force bool) error {
+	err := guard.Check(current, desired)
+	if err == nil {
+		return nil
+	}
+	if force {
+		fmt.Printf("Warning: %v (continuing because --force was given)\n", err)
+		return nil
+	}
+	return fmt.Errorf("%w; re-run with --force to override", err)
+}
+
 // confirmASGScalingAction displays configuration and gets user confirmation
 //
 //nolint:unused // Kept for backward compatibility
 func confirmASGScalingAction(selectedASG string, asg *aws.AutoScalingGroup, params ASGScalingParameters) bool {
 	// Display current and new configuration
 	fmt.Printf("\nAuto Scaling Group: %s\n", selectedASG)
+	fmt.Printf("ARN:                %s\n", valueOrDash(asg.ARN))
 	fmt.Printf("\nCurrent configuration:\n")
 	fmt.Printf("  Min Size:          %d\n", asg.MinSize)
 	fmt.Printf("  Max Size:          %d\n", asg.MaxSize)
@@ -308,8 +353,8 @@ func confirmASGScalingAction(selectedASG string, asg *aws.AutoScalingGroup, para
 	fmt.Printf("  Max Size:          %d\n", params.Max)
 	fmt.Printf("  Desired Capacity:  %d\n", params.Desired)
 
-	// Confirm before scaling (unless skip-confirm is set)
-	if asgSkipConfirm {
+	// Confirm before scaling (unless skip-confirm or dry-run is set)
+	if asgSkipConfirm || dryRun {
 		return true
 	}
 
@@ -333,6 +378,7 @@ func confirmASGScalingAction(selectedASG string, asg *aws.AutoScalingGroup, para
 func confirmASGScalingActionWithRetry(selectedASG string, asg *aws.AutoScalingGroup, params ASGScalingParameters) (bool, bool) {
 	// Display current and new configuration
 	fmt.Printf("\nAuto Scaling Group: %s\n", selectedASG)
+	fmt.Printf("ARN:                %s\n", valueOrDash(asg.ARN))
 	fmt.Printf("\nCurrent configuration:\n")
 	fmt.Printf("  Min Size:          %d\n", asg.MinSize)
 	fmt.Printf("  Max Size:          %d\n", asg.MaxSize)
@@ -344,8 +390,8 @@ func confirmASGScalingActionWithRetry(selectedASG string, asg *aws.AutoScalingGr
 	fmt.Printf("  Max Size:          %d\n", params.Max)
 	fmt.Printf("  Desired Capacity:  %d\n", params.Desired)
 
-	// Confirm before scaling (unless skip-confirm is set)
-	if asgSkipConfirm {
+	// Confirm before scaling (unless skip-confirm or dry-run is set)
+	if asgSkipConfirm || dryRun {
 		return false, true
 	}
 
@@ -370,7 +416,10 @@ func confirmASGScalingActionWithRetry(selectedASG string, asg *aws.AutoScalingGr
 }
 
 // executeASGScaling performs the actual scaling operation
-func executeASGScaling(ctx context.Context, client *aws.Client, selectedASG string, params ASGScalingParameters) error {
+func executeASGScaling(ctx context.Context, client resourceMutator, selectedASG string, params ASGScalingParameters) error {
+	if reportDryRun("scale Auto Scaling Group %s to min %d, max %d, desired %d", selectedASG, params.Min, params.Max, params.Desired) {
+		return nil
+	}
 	fmt.Printf("\nScaling Auto Scaling Group %s...\n", selectedASG)
 
 	err := client.UpdateAutoScalingGroupCapacity(ctx, selectedASG, params.Min, params.Max, params.Desired)
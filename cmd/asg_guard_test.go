@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+)
+
+func TestCheckScalingStep(t *testing.T) {
+	guard := aws.ScalingStepGuard{MaxStep: 50}
+
+	tests := []struct {
+		name             string
+		current, desired int32
+		force            bool
+		wantErr          bool
+	}{
+		{name: "within limit", current: 2, desired: 10},
+		{name: "blocked without force", current: 2, desired: 200, wantErr: true},
+		{name: "allowed with force", current: 2, desired: 200, force: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkScalingStep(guard, tt.current, tt.desired, tt.force)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkScalingStep() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				return
+			}
+			var stepErr *aws.ScalingStepError
+			if !errors.As(err, &stepErr) {
+				t.Errorf("error %v does not wrap *aws.ScalingStepError", err)
+			}
+			if !strings.Contains(err.Error(), "--force") {
+				t.Errorf("error %q should mention --force", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/config"
+	"github.com/johnlam90/aws-ssm/pkg/security"
+	"github.com/spf13/cobra"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Work with the local audit log",
+	Long: `Work with the audit log written when AWS_SSM_AUDIT_LOG_FILE is set.
+
+Examples:
+  # Export the last day of audit events as JSON
+  aws-ssm audit export --since 24h --output json`,
+}
+
+var auditExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export audit events, filtered by time range and event type",
+	Long: `Export events from the audit log file (AWS_SSM_AUDIT_LOG_FILE, or --file).
+Numbered rotations of the log (audit.log.1, audit.log.2, ...) are included, and a
+missing log exports no events.
+
+--since and --until accept a duration before now (30m, 24h, 7d) or an RFC3339 time.
+
+Examples:
+  # Events from the last 24 hours
+  aws-ssm audit export --since 24h --output json
+
+  # Only rejected commands during a window
+  aws-ssm audit export --since 2026-01-01T00:00:00Z --until 2026-01-08T00:00:00Z --type command_rejected`,
+	Args: cobra.NoArgs,
+	RunE: runAuditExport,
+}
+
+var (
+	auditFile  string
+	auditSince string
+	auditUntil string
+	auditTypes []string
+)
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+	auditCmd.AddCommand(auditExportCmd)
+	auditExportCmd.Flags().StringVar(&auditFile, "file", "", "Audit log file (defaults to AWS_SSM_AUDIT_LOG_FILE)")
+	auditExportCmd.Flags().StringVar(&auditSince, "since", "", "Only events at or after this time (duration ago or RFC3339)")
+	auditExportCmd.Flags().StringVar(&auditUntil, "until", "", "Only events before this time (duration ago or RFC3339)")
+	auditExportCmd.Flags().StringSliceVar(&auditTypes, "type", nil, "Only events of this type, e.g. command_rejected (repeatable)")
+}
+
+func runAuditExport(_ *cobra.Command, _ []string) error {
+	if err := validateOutputFlags(); err != nil {
+		return err
+	}
+
+	path := auditFile
+	if path == "" {
+		path = os.Getenv(security.AuditLogFileEnv)
+	}
+	if path == "" {
+		return usageErrorf("no audit log configured: set %s or pass --file", security.AuditLogFileEnv)
+	}
+
+	filter, err := buildAuditFilter(auditSince, auditUntil, auditTypes, time.Now())
+	if err != nil {
+		return err
+	}
+
+	events, skipped, err := security.ReadAuditEvents(path, filter)
+	if err != nil {
+		return err
+	}
+	if _, statErr := os.Stat(path); errors.Is(statErr, os.ErrNotExist) {
+		fmt.Fprintf(os.Stderr, "Warning: audit log %s does not exist (rotated away or not written yet)\n", path)
+	}
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: skipped %d unreadable line(s) in the audit log\n", skipped)
+	}
+
+	if events == nil {
+		events = []security.AuditEvent{}
+	}
+	if isJSONOutput() {
+		return printJSON(map[string]interface{}{"events": events})
+	}
+	// The config only supplies the default --max-rows here, so a broken one is not fatal
+	cfg, _ := config.LoadConfig(configPath)
+	return printAuditEvents(os.Stdout, events, tableMaxRows(cfg))
+}
+
+// buildAuditFilter parses the export flags into a filter
+func buildAuditFilter(since, until string, types []string, now time.Time) (security.AuditFilter, error) {
+	filter := security.AuditFilter{Types: types}
+	var err error
+	if filter.Since, err = parseAuditTime(since, now); err != nil {
+		return filter, usageErrorf("invalid --since: %v", err)
+	}
+	if filter.Until, err = parseAuditTime(until, now); err != nil {
+		return filter, usageErrorf("invalid --until: %v", err)
+	}
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && !filter.Until.After(filter.Since) {
+		return filter, usageErrorf("--until must be after --since")
+	}
+	return filter, nil
+}
+
+// parseAuditTime parses a duration before now (with a "d" suffix for days) or
+// an RFC3339 time; empty yields the zero time
+func parseAuditTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.Add(-time.Duration(n) * 24 * time.Hour), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("%q is not a duration (24h, 7d) or RFC3339 time", value)
+}
+
+// printAuditEvents renders up to maxRows events as a table (0 = all)
+func printAuditEvents(out io.Writer, events []security.AuditEvent, maxRows int) error {
+	if len(events) == 0 {
+		_, err := fmt.Fprintln(out, "No audit events found")
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	if _, err := fmt.Fprintln(w, "TIME\tEVENT\tDATA"); err != nil {
+		return fmt.Errorf("failed to write table header: %w", err)
+	}
+	shown := cappedRows(len(events), maxRows)
+	for _, event := range events[:shown] {
+		data := "-"
+		if len(event.Data) > 0 {
+			raw, err := json.Marshal(event.Data)
+			if err != nil {
+				return fmt.Errorf("failed to encode audit data: %w", err)
+			}
+			data = string(raw)
+		}
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", event.Time.UTC().Format(time.RFC3339), event.Event, data); err != nil {
+			return fmt.Errorf("failed to write table row: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to flush table writer: %w", err)
+	}
+	return writeTruncationFooter(out, shown, len(events))
+}
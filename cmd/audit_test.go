@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAuditTime(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "", want: time.Time{}},
+		{value: "24h", want: now.Add(-24 * time.Hour)},
+		{value: "30m", want: now.Add(-30 * time.Minute)},
+		{value: "7d", want: now.Add(-7 * 24 * time.Hour)},
+		{value: "2026-03-01T00:00:00Z", want: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "-1h", wantErr: true},
+		{value: "yesterday", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseAuditTime(tt.value, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAuditTime(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("parseAuditTime(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildAuditFilterRejectsInvertedRange(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	if _, err := buildAuditFilter("1h", "24h", nil, now); err == nil {
+		t.Fatal("expected --until before --since to fail")
+	}
+	filter, err := buildAuditFilter("24h", "1h", []string{"command_rejected"}, now)
+	if err != nil {
+		t.Fatalf("buildAuditFilter() error = %v", err)
+	}
+	if !filter.Since.Equal(now.Add(-24*time.Hour)) || !filter.Until.Equal(now.Add(-time.Hour)) || len(filter.Types) != 1 {
+		t.Errorf("unexpected filter %+v", filter)
+	}
+}
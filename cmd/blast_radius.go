@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/johnlam90/aws-ssm/pkg/config"
+)
+
+// blastRadius summarizes the scope of a mutating operation before it is confirmed
+type blastRadius struct {
+	Operation         string
+	Account           string
+	Region            string
+	Resource          string
+	ARN               string // Full ARN or identifier of Resource, when known
+	InstancesAffected int
+	CapacityChange    bool
+	CapacityBefore    int32
+	CapacityAfter     int32
+	Notes             []string
+}
+
+// withARN returns b with the resource's ARN set
+func (b blastRadius) withARN(arn string) blastRadius {
+	b.ARN = arn
+	return b
+}
+
+// nodeGroupIdentifier returns the node group's ARN, or an identifier in the
+// ARN's resource form when EKS did not return one
+func nodeGroupIdentifier(clusterName string, ng *aws.NodeGroup) string {
+	if ng.NodeGroupARN != "" {
+		return ng.NodeGroupARN
+	}
+	return fmt.Sprintf("nodegroup/%s/%s", clusterName, ng.Name)
+}
+
+// capacityDelta returns the signed change in desired capacity
+func (b blastRadius) capacityDelta() int32 {
+	return b.CapacityAfter - b.CapacityBefore
+}
+
+// scalingBlastRadius describes a desired capacity change. Instances affected are
+// the ones launched or terminated to reach the new capacity.
+func scalingBlastRadius(operation, resource string, current, desired int32) blastRadius {
+	b := blastRadius{
+		Operation:         operation,
+		Resource:          resource,
+		InstancesAffected: int(aws.CapacityDelta(current, desired)),
+		CapacityChange:    true,
+		CapacityBefore:    current,
+		CapacityAfter:     desired,
+	}
+	if desired < current {
+		b.Notes = append(b.Notes, fmt.Sprintf("%d instance(s) will be terminated", current-desired))
+	}
+	return b
+}
+
+// launchTemplateBlastRadius describes a node group launch template update, which
+// replaces every node in the group
+func launchTemplateBlastRadius(resource string, nodes int32, fromVersion, toVersion string) blastRadius {
+	return blastRadius{
+		Operation:         "update launch template",
+		Resource:          resource,
+		InstancesAffected: int(nodes),
+		Notes:             []string{fmt.Sprintf("Version %s → %s; nodes are replaced by a rolling update", fromVersion, toVersion)},
+	}
+}
+
+// amiReleaseBlastRadius describes a node group AMI release update, which
+// replaces every node in the group
+func amiReleaseBlastRadius(resource string, nodes int32, fromVersion, toVersion string) blastRadius {
+	return blastRadius{
+		Operation:         "update AMI release version",
+		Resource:          resource,
+		InstancesAffected: int(nodes),
+		Notes:             []string{fmt.Sprintf("Release %s → %s; nodes are replaced by a rolling update", valueOrDash(fromVersion), toVersion)},
+	}
+}
+
+// nodeGroupConfigBlastRadius describes a node group label and taint update,
+// which changes every node in the group
+func nodeGroupConfigBlastRadius(resource string, nodes int32, plan *aws.NodeGroupConfigPlan) blastRadius {
+	b := blastRadius{
+		Operation:         "update labels and taints",
+		Resource:          resource,
+		InstancesAffected: int(nodes),
+	}
+	for _, t := range plan.AddOrUpdateTaints {
+		if t.Effect == "NO_EXECUTE" {
+			b.Notes = append(b.Notes, fmt.Sprintf("Taint %s evicts running pods that do not tolerate it", formatTaint(t)))
+		}
+	}
+	return b
+}
+
+// taggingBlastRadius describes a bulk tagging operation
+func taggingBlastRadius(instanceIDs []string, tags map[string]string) blastRadius {
+	return blastRadius{
+		Operation:         "tag instances",
+		Resource:          fmt.Sprintf("%d instance(s)", len(instanceIDs)),
+		InstancesAffected: len(instanceIDs),
+		Notes:             []string{"Tags: " + formatTagPairs(tags)},
+	}
+}
+
+// formatBlastRadius renders the pre-flight summary
+func formatBlastRadius(b blastRadius) string {
+	var sb strings.Builder
+	sb.WriteString("\n")
+	fmt.Fprintf(&sb, "Blast radius: %s\n", b.Operation)
+	fmt.Fprintf(&sb, "  Account:            %s\n", valueOrDash(b.Account))
+	fmt.Fprintf(&sb, "  Region:             %s\n", valueOrDash(b.Region))
+	fmt.Fprintf(&sb, "  Resource:           %s\n", valueOrDash(b.Resource))
+	if b.ARN != "" {
+		fmt.Fprintf(&sb, "  ARN:                %s\n", b.ARN)
+	}
+	fmt.Fprintf(&sb, "  Instances affected: %d\n", b.InstancesAffected)
+	if b.CapacityChange {
+		fmt.Fprintf(&sb, "  Capacity:           %d → %d (%+d)\n", b.CapacityBefore, b.CapacityAfter, b.capacityDelta())
+	}
+	for _, note := range b.Notes {
+		fmt.Fprintf(&sb, "  %s\n", note)
+	}
+	return sb.String()
+}
+
+// showBlastRadius fills in account and region from the client and prints the
+// summary, unless disabled via confirmations.blast_radius
+func showBlastRadius(ctx context.Context, client *aws.Client, b blastRadius) {
+	cfg, err := config.LoadConfig(configPath)
+	if err == nil && !cfg.Confirmations.BlastRadius {
+		return
+	}
+
+	b.Region = client.GetRegion()
+	b.Account = "unknown"
+	// Ask STS rather than the status cache, whose entries are keyed by profile
+	// and region and so can name the wrong account under --assume-role-arn
+	identityCtx, cancel := context.WithTimeout(ctx, statusIdentityTimeout)
+	defer cancel()
+	if identity, idErr := client.GetCallerIdentity(identityCtx); idErr == nil {
+		b.Account = identity.Account
+	}
+
+	fmt.Print(formatBlastRadius(b))
+}
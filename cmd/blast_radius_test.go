@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+)
+
+func TestScalingBlastRadius(t *testing.T) {
+	tests := []struct {
+		name             string
+		current, desired int32
+		wantAffected     int
+		wantDelta        int32
+		wantTerminate    bool
+	}{
+		{name: "scale out", current: 2, desired: 5, wantAffected: 3, wantDelta: 3},
+		{name: "scale in", current: 10, desired: 4, wantAffected: 6, wantDelta: -6, wantTerminate: true},
+		{name: "scale to zero", current: 3, desired: 0, wantAffected: 3, wantDelta: -3, wantTerminate: true},
+		{name: "no change", current: 4, desired: 4, wantAffected: 0, wantDelta: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := scalingBlastRadius("scale", "asg/web", tt.current, tt.desired)
+			if b.InstancesAffected != tt.wantAffected {
+				t.Errorf("InstancesAffected = %d, want %d", b.InstancesAffected, tt.wantAffected)
+			}
+			if b.capacityDelta() != tt.wantDelta {
+				t.Errorf("capacityDelta() = %d, want %d", b.capacityDelta(), tt.wantDelta)
+			}
+			if got := len(b.Notes) > 0; got != tt.wantTerminate {
+				t.Errorf("termination note present = %v, want %v", got, tt.wantTerminate)
+			}
+		})
+	}
+}
+
+func TestTaggingAndLaunchTemplateBlastRadius(t *testing.T) {
+	tagging := taggingBlastRadius([]string{"i-1", "i-2", "i-3"}, map[string]string{"Owner": "platform"})
+	if tagging.InstancesAffected != 3 || tagging.CapacityChange {
+		t.Errorf("tagging blast radius = %+v", tagging)
+	}
+
+	lt := launchTemplateBlastRadius("cluster/ng", 7, "3", "4")
+	if lt.InstancesAffected != 7 {
+		t.Errorf("launch template InstancesAffected = %d, want 7", lt.InstancesAffected)
+	}
+}
+
+func TestFormatBlastRadius(t *testing.T) {
+	b := scalingBlastRadius("scale", "asg/web", 10, 4)
+	b.Account = "123456789012"
+	b.Region = "us-west-2"
+
+	out := formatBlastRadius(b)
+
+	for _, want := range []string{
+		"Blast radius: scale",
+		"Account:            123456789012",
+		"Region:             us-west-2",
+		"Resource:           asg/web",
+		"Instances affected: 6",
+		"Capacity:           10 → 4 (-6)",
+		"6 instance(s) will be terminated",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("summary missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestAMIReleaseBlastRadius(t *testing.T) {
+	b := amiReleaseBlastRadius("cluster/ng", 5, "1.29.0-20240213", "1.29.0-20240227")
+	if b.InstancesAffected != 5 || b.CapacityChange {
+		t.Errorf("AMI release blast radius = %+v", b)
+	}
+	if len(b.Notes) != 1 || !strings.Contains(b.Notes[0], "1.29.0-20240213 → 1.29.0-20240227") {
+		t.Errorf("notes = %v", b.Notes)
+	}
+}
+
+func TestBlastRadiusShowsResourceARN(t *testing.T) {
+	const asgARN = "arn:aws:autoscaling:us-west-2:123456789012:autoScalingGroup:uuid:autoScalingGroupName/web"
+	const ngARN = "arn:aws:eks:us-west-2:123456789012:nodegroup/prod/workers/uuid"
+
+	tests := []struct {
+		name string
+		b    blastRadius
+		want string
+	}{
+		{"asg scaling", scalingBlastRadius("scale Auto Scaling Group", "web", 2, 4).withARN(asgARN), "ARN:                " + asgARN},
+		{"node group scaling", scalingBlastRadius("scale node group", "prod/workers", 2, 4).withARN(nodeGroupIdentifier("prod", &aws.NodeGroup{Name: "workers", NodeGroupARN: ngARN})), "ARN:                " + ngARN},
+		{"node group without ARN", launchTemplateBlastRadius("prod/workers", 3, "1", "2").withARN(nodeGroupIdentifier("prod", &aws.NodeGroup{Name: "workers"})), "ARN:                nodegroup/prod/workers"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if out := formatBlastRadius(tt.b); !strings.Contains(out, tt.want) {
+				t.Errorf("summary missing %q:\n%s", tt.want, out)
+			}
+		})
+	}
+
+	if out := formatBlastRadius(taggingBlastRadius([]string{"i-1"}, nil)); strings.Contains(out, "ARN:") {
+		t.Errorf("summary without an ARN shows an ARN line:\n%s", out)
+	}
+}
+
+func TestConfirmationSummariesShowARN(t *testing.T) {
+	const asgARN = "arn:aws:autoscaling:us-east-1:123456789012:autoScalingGroup:uuid:autoScalingGroupName/web"
+	const ngARN = "arn:aws:eks:us-east-1:123456789012:nodegroup/prod/workers/uuid"
+
+	oldASGSkip, oldSkip := asgSkipConfirm, skipConfirm
+	asgSkipConfirm, skipConfirm = true, true
+	defer func() { asgSkipConfirm, skipConfirm = oldASGSkip, oldSkip }()
+
+	asgOut := captureStdout(t, func() error {
+		confirmASGScalingActionWithRetry("web", &aws.AutoScalingGroup{Name: "web", ARN: asgARN}, ASGScalingParameters{Desired: 3})
+		return nil
+	})
+	if !strings.Contains(asgOut, asgARN) {
+		t.Errorf("ASG confirmation missing ARN:\n%s", asgOut)
+	}
+
+	ng := &aws.NodeGroup{Name: "workers", NodeGroupARN: ngARN}
+	ngOut := captureStdout(t, func() error {
+		displayScalingConfiguration("prod", "workers", ng, ScalingParameters{Desired: 3})
+		displayLTUpdateConfiguration("prod", "workers", ng, "5")
+		displayAMIUpdateConfiguration("prod", "workers", ng, "1.29.0-20240227")
+		return nil
+	})
+	if got := strings.Count(ngOut, ngARN); got != 3 {
+		t.Errorf("node group summaries show the ARN %d times, want 3:\n%s", got, ngOut)
+	}
+
+	plan := &aws.NodeGroupConfigPlan{AddOrUpdateLabels: map[string]string{"tier": "web"}}
+	if out := formatNodeGroupConfigUpdate("prod", "workers", &aws.NodeGroup{Name: "workers"}, plan); !strings.Contains(out, "nodegroup/prod/workers") {
+		t.Errorf("config update summary missing node group identifier:\n%s", out)
+	}
+}
@@ -0,0 +1,343 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/cache"
+	"github.com/johnlam90/aws-ssm/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect the local resource cache",
+	Long: `Inspect the local resource cache stored under ~/.aws-ssm/cache.
+
+Examples:
+  # Compare two cached instance snapshots
+  aws-ssm cache diff instances_us-east-1_abc instances_us-east-1_def
+
+  # Drop cached data for one region
+  aws-ssm cache clear --region us-east-1
+
+  # Cache size and freshness for monitoring
+  aws-ssm cache stats --output json`,
+}
+
+var cacheDiffCmd = &cobra.Command{
+	Use:   "diff <keyA> <keyB>",
+	Short: "Show instances added, removed, or changed between two cache snapshots",
+	Long: `Load two cache entries, treat them as instance lists, and report which
+instances were added, removed, or changed between the first and second snapshot.
+
+This is useful for spotting drift between two captures of the environment.
+Expired entries can still be compared.
+
+Examples:
+  # Diff two snapshots by cache key
+  aws-ssm cache diff instances_us-east-1_abc instances_us-east-1_def`,
+	Args: cobra.ExactArgs(2),
+	RunE: runCacheDiff,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove cached data, optionally for a single region",
+	Long: `Remove cached resource data. With --region only that region's entries are
+removed, which is handy after switching accounts; other regions stay cached.
+
+Examples:
+  # Clear one region
+  aws-ssm cache clear --region us-east-1
+
+  # Clear everything
+  aws-ssm cache clear`,
+	Args: cobra.NoArgs,
+	RunE: runCacheClear,
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show cache size and how many entries have expired",
+	Long: `Show the number of cache files, how many have expired, and their total size.
+JSON and YAML output also list every entry.
+
+Examples:
+  aws-ssm cache stats
+  aws-ssm cache stats --output json
+  aws-ssm cache stats --output yaml`,
+	Args: cobra.NoArgs,
+	RunE: runCacheStats,
+}
+
+var cacheListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List cache entries with their region, age and expiry",
+	Long: `List every cache entry with its region, resource type, size and expiry.
+With --region only that region's entries are listed.
+
+Examples:
+  aws-ssm cache list
+  aws-ssm cache list --region us-east-1
+  aws-ssm cache list --output json --select 'entries[?expired].key'`,
+	Args: cobra.NoArgs,
+	RunE: runCacheList,
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheDiffCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	cacheCmd.AddCommand(cacheStatsCmd)
+	cacheCmd.AddCommand(cacheListCmd)
+}
+
+// cacheReport is the machine-readable form of cache stats and cache list
+type cacheReport struct {
+	TotalFiles       int               `json:"total_files"`
+	ExpiredFiles     int               `json:"expired_files"`
+	TotalSizeBytes   int64             `json:"total_size_bytes"`
+	LogicalSizeBytes int64             `json:"logical_size_bytes"`
+	Entries          []cache.EntryInfo `json:"entries"`
+}
+
+// newCacheServiceFromConfig builds a cache service using the application config
+func newCacheServiceFromConfig() (*cache.Service, error) {
+	svc, _, err := loadCacheService()
+	return svc, err
+}
+
+// loadCacheService builds a cache service and returns the config it came from
+func loadCacheService() (*cache.Service, *config.Config, error) {
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load application config: %w", err)
+	}
+
+	svc, err := cache.NewCacheServiceFromConfig(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open cache: %w", err)
+	}
+	return svc, cfg, nil
+}
+
+func runCacheDiff(_ *cobra.Command, args []string) error {
+	svc, err := newCacheServiceFromConfig()
+	if err != nil {
+		return err
+	}
+
+	before, err := svc.Peek(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", args[0], err)
+	}
+	after, err := svc.Peek(args[1])
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", args[1], err)
+	}
+
+	diff, err := cache.DiffInstanceSnapshots(before.Data, after.Data)
+	if err != nil {
+		return fmt.Errorf("failed to diff snapshots: %w", err)
+	}
+
+	printSnapshotDiff(args[0], args[1], diff)
+	return nil
+}
+
+// cacheRegionFilter returns the region to scope a cache command to, or "" for
+// every region. Only an explicit --region counts: the global region is also
+// filled from AWS_REGION and config defaults, which must not narrow the cache.
+func cacheRegionFilter(cmd *cobra.Command) string {
+	if !cmd.Flags().Changed("region") {
+		return ""
+	}
+	return region
+}
+
+func runCacheClear(cmd *cobra.Command, _ []string) error {
+	svc, err := newCacheServiceFromConfig()
+	if err != nil {
+		return err
+	}
+
+	only := cacheRegionFilter(cmd)
+	if only == "" {
+		if err := svc.Clear(); err != nil {
+			return fmt.Errorf("failed to clear cache: %w", err)
+		}
+		fmt.Println("Cleared all cached data")
+		return nil
+	}
+
+	removed, err := svc.ClearRegion(only)
+	if err != nil {
+		return fmt.Errorf("failed to clear cache for %s: %w", only, err)
+	}
+	fmt.Printf("Removed %d cache entries for %s\n", removed, only)
+	return nil
+}
+
+func runCacheStats(cmd *cobra.Command, _ []string) error {
+	return runCacheReport(cmd, func(out io.Writer, report cacheReport, _ *config.Config) error {
+		return printCacheStatsTable(out, report)
+	})
+}
+
+func runCacheList(cmd *cobra.Command, _ []string) error {
+	return runCacheReport(cmd, func(out io.Writer, report cacheReport, cfg *config.Config) error {
+		return printCacheEntriesTable(out, report, tableMaxRows(cfg))
+	})
+}
+
+// runCacheReport prints the cache report in the --output format, using table for the default
+func runCacheReport(cmd *cobra.Command, table func(io.Writer, cacheReport, *config.Config) error) error {
+	if err := validateTableOutputFlags(); err != nil {
+		return err
+	}
+	svc, cfg, err := loadCacheService()
+	if err != nil {
+		return err
+	}
+	report, err := buildCacheReport(svc, cacheRegionFilter(cmd))
+	if err != nil {
+		return err
+	}
+	return writeCacheReport(os.Stdout, outputFormat, report, func(out io.Writer, r cacheReport) error {
+		return table(out, r, cfg)
+	})
+}
+
+// buildCacheReport collects the entries for region and their totals, or every
+// entry and the totals of the whole cache when region is empty
+func buildCacheReport(svc *cache.Service, region string) (cacheReport, error) {
+	if region != "" {
+		entries, err := svc.GetByRegion(region)
+		if err != nil {
+			return cacheReport{}, fmt.Errorf("failed to list cache entries: %w", err)
+		}
+		return regionCacheReport(entries), nil
+	}
+
+	stats, err := svc.Stats()
+	if err != nil {
+		return cacheReport{}, fmt.Errorf("failed to read cache stats: %w", err)
+	}
+	entries, err := svc.ListEntries()
+	if err != nil {
+		return cacheReport{}, fmt.Errorf("failed to list cache entries: %w", err)
+	}
+	return cacheReport{
+		TotalFiles:       stats.TotalFiles,
+		ExpiredFiles:     stats.ExpiredFiles,
+		TotalSizeBytes:   stats.DiskSize,
+		LogicalSizeBytes: stats.LogicalSize,
+		Entries:          entries,
+	}, nil
+}
+
+// regionCacheReport totals the entries of a single region
+func regionCacheReport(entries []cache.EntryInfo) cacheReport {
+	report := cacheReport{TotalFiles: len(entries), Entries: entries}
+	for _, entry := range entries {
+		if entry.Expired {
+			report.ExpiredFiles++
+		}
+		report.TotalSizeBytes += entry.SizeBytes
+		report.LogicalSizeBytes += entry.LogicalSizeBytes
+	}
+	return report
+}
+
+// writeCacheReport writes report to out as JSON, YAML or the given table
+func writeCacheReport(out io.Writer, format string, report cacheReport, table func(io.Writer, cacheReport) error) error {
+	switch format {
+	case outputFormatJSON:
+		return writeJSON(out, report)
+	case outputFormatYAML:
+		return writeYAML(out, report)
+	default:
+		return table(out, report)
+	}
+}
+
+// printCacheStatsTable renders the cache totals
+func printCacheStatsTable(out io.Writer, report cacheReport) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	rows := [][2]string{
+		{"Total files", fmt.Sprintf("%d", report.TotalFiles)},
+		{"Expired files", fmt.Sprintf("%d", report.ExpiredFiles)},
+		{"Total size", fmt.Sprintf("%d bytes", report.TotalSizeBytes)},
+		{"Uncompressed size", fmt.Sprintf("%d bytes", report.LogicalSizeBytes)},
+	}
+	for _, row := range rows {
+		if _, err := fmt.Fprintf(w, "%s:\t%s\n", row[0], row[1]); err != nil {
+			return fmt.Errorf("failed to write table row: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to flush table writer: %w", err)
+	}
+	return nil
+}
+
+// printCacheEntriesTable renders up to maxRows cache entries (0 = all)
+func printCacheEntriesTable(out io.Writer, report cacheReport, maxRows int) error {
+	if len(report.Entries) == 0 {
+		_, err := fmt.Fprintln(out, "Cache is empty")
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	if _, err := fmt.Fprintln(w, "KEY\tREGION\tTYPE\tSIZE\tCACHED AT\tSTATUS"); err != nil {
+		return fmt.Errorf("failed to write table header: %w", err)
+	}
+	shown := cappedRows(len(report.Entries), maxRows)
+	for _, entry := range report.Entries[:shown] {
+		status := "fresh"
+		if entry.Expired {
+			status = "expired"
+		}
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\n",
+			entry.Key, valueOrDash(entry.Region), valueOrDash(string(entry.ResourceType)), entry.SizeBytes,
+			entry.CachedAt.Local().Format(time.DateTime), status); err != nil {
+			return fmt.Errorf("failed to write table row: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to flush table writer: %w", err)
+	}
+	return writeTruncationFooter(out, shown, len(report.Entries))
+}
+
+// printSnapshotDiff renders a snapshot diff grouped by category
+func printSnapshotDiff(keyA, keyB string, diff *cache.SnapshotDiff) {
+	fmt.Printf("Comparing %s → %s\n", keyA, keyB)
+
+	if diff.IsEmpty() {
+		fmt.Println("\nNo differences found.")
+		return
+	}
+
+	fmt.Printf("\nAdded (%d):\n", len(diff.Added))
+	for _, id := range diff.Added {
+		fmt.Printf("  + %s\n", id)
+	}
+
+	fmt.Printf("\nRemoved (%d):\n", len(diff.Removed))
+	for _, id := range diff.Removed {
+		fmt.Printf("  - %s\n", id)
+	}
+
+	fmt.Printf("\nChanged (%d):\n", len(diff.Changed))
+	for _, change := range diff.Changed {
+		fmt.Printf("  ~ %s\n", change.InstanceID)
+		for _, field := range change.Changes {
+			fmt.Printf("      %s: %v → %v\n", field.Field, field.Old, field.New)
+		}
+	}
+}
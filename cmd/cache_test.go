@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/johnlam90/aws-ssm/pkg/cache"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v3"
+)
+
+func seededCacheReport(t *testing.T) cacheReport {
+	t.Helper()
+	svc, err := cache.NewCacheService(t.TempDir(), 10)
+	if err != nil {
+		t.Fatalf("new cache service: %v", err)
+	}
+	if err := svc.Set("instances_us-east-1_all", []string{"i-1"}, "us-east-1", "all"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if err := svc.Set("instances_eu-west-1_all", []string{"i-2"}, "eu-west-1", "all"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	report, err := buildCacheReport(svc, "")
+	if err != nil {
+		t.Fatalf("buildCacheReport() error = %v", err)
+	}
+	return report
+}
+
+func TestWriteCacheReportFormats(t *testing.T) {
+	origSelect := selectExpr
+	defer func() { selectExpr = origSelect }()
+	selectExpr = ""
+
+	report := seededCacheReport(t)
+	if report.TotalFiles != 2 || len(report.Entries) != 2 || report.TotalSizeBytes <= 0 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	decoders := map[string]func([]byte, interface{}) error{
+		outputFormatJSON: json.Unmarshal,
+		outputFormatYAML: yaml.Unmarshal,
+	}
+	for format, decode := range decoders {
+		t.Run(format, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := writeCacheReport(&out, format, report, printCacheStatsTable); err != nil {
+				t.Fatalf("writeCacheReport() error = %v", err)
+			}
+
+			var got map[string]interface{}
+			if err := decode(out.Bytes(), &got); err != nil {
+				t.Fatalf("output is not valid %s: %v\n%s", format, err, out.String())
+			}
+			for _, key := range []string{"total_files", "expired_files", "total_size_bytes", "entries"} {
+				if _, ok := got[key]; !ok {
+					t.Errorf("%s output missing %q: %s", format, key, out.String())
+				}
+			}
+			if n, ok := got["total_files"].(int); format == outputFormatYAML && (!ok || n != 2) {
+				t.Errorf("yaml total_files = %v, want integer 2", got["total_files"])
+			}
+			entries, _ := got["entries"].([]interface{})
+			if len(entries) != 2 {
+				t.Fatalf("entries = %v, want 2", got["entries"])
+			}
+			first, _ := entries[0].(map[string]interface{})
+			if first["key"] != "instances_eu-west-1_all" || first["region"] != "eu-west-1" {
+				t.Errorf("unexpected first entry: %v", first)
+			}
+		})
+	}
+
+	t.Run("table", func(t *testing.T) {
+		var stats, list bytes.Buffer
+		if err := writeCacheReport(&stats, outputFormatTable, report, printCacheStatsTable); err != nil {
+			t.Fatalf("stats table error = %v", err)
+		}
+		if !strings.Contains(stats.String(), "Total files:") || !strings.Contains(stats.String(), "2") {
+			t.Errorf("unexpected stats table:\n%s", stats.String())
+		}
+		if err := writeCacheReport(&list, "", report, func(out io.Writer, r cacheReport) error { return printCacheEntriesTable(out, r, 0) }); err != nil {
+			t.Fatalf("list table error = %v", err)
+		}
+		for _, want := range []string{"KEY", "instances_us-east-1_all", "eu-west-1", "fresh"} {
+			if !strings.Contains(list.String(), want) {
+				t.Errorf("list table missing %q:\n%s", want, list.String())
+			}
+		}
+	})
+}
+
+func TestPrintCacheEntriesTableEmpty(t *testing.T) {
+	var out bytes.Buffer
+	if err := printCacheEntriesTable(&out, cacheReport{Entries: []cache.EntryInfo{}}, 0); err != nil {
+		t.Fatalf("printCacheEntriesTable() error = %v", err)
+	}
+	if strings.TrimSpace(out.String()) != "Cache is empty" {
+		t.Errorf("output = %q", out.String())
+	}
+}
+
+func TestCacheRegionFilterIgnoresAmbientRegion(t *testing.T) {
+	t.Setenv("AWS_REGION", "eu-west-1")
+	originalRegion := region
+	defer func() { region = originalRegion }()
+	// The global region is filled from AWS_REGION, config defaults and
+	// .aws-ssm.yaml before the command runs
+	region = "eu-west-1"
+
+	c := &cobra.Command{Use: "list"}
+	c.Flags().StringVar(&region, "region", region, "")
+	if got := cacheRegionFilter(c); got != "" {
+		t.Errorf("cacheRegionFilter() without --region = %q, want every region", got)
+	}
+
+	svc, err := cache.NewCacheService(t.TempDir(), 10)
+	if err != nil {
+		t.Fatalf("new cache service: %v", err)
+	}
+	for _, r := range []string{"us-east-1", "eu-west-1"} {
+		if err := svc.Set("instances_"+r+"_all", []string{"i-1"}, r, "all"); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+	}
+	report, err := buildCacheReport(svc, cacheRegionFilter(c))
+	if err != nil {
+		t.Fatalf("buildCacheReport() error = %v", err)
+	}
+	if len(report.Entries) != 2 {
+		t.Errorf("report lists %d entries, want both regions", len(report.Entries))
+	}
+
+	regional, err := buildCacheReport(svc, "US-EAST-1")
+	if err != nil {
+		t.Fatalf("buildCacheReport() error = %v", err)
+	}
+	if len(regional.Entries) != 1 || regional.TotalFiles != 1 {
+		t.Fatalf("regional report = %d entries, %d files; want only us-east-1", len(regional.Entries), regional.TotalFiles)
+	}
+	if regional.TotalSizeBytes != regional.Entries[0].SizeBytes || regional.TotalSizeBytes >= report.TotalSizeBytes {
+		t.Errorf("regional size = %d, want the us-east-1 entry's %d bytes, not the whole cache's %d",
+			regional.TotalSizeBytes, regional.Entries[0].SizeBytes, report.TotalSizeBytes)
+	}
+	if regional.LogicalSizeBytes <= 0 {
+		t.Errorf("regional logical size = %d, want the decompressed entry size", regional.LogicalSizeBytes)
+	}
+
+	if err := c.Flags().Set("region", "us-east-1"); err != nil {
+		t.Fatal(err)
+	}
+	if got := cacheRegionFilter(c); got != "us-east-1" {
+		t.Errorf("cacheRegionFilter() with --region = %q, want us-east-1", got)
+	}
+}
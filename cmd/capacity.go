@@ -0,0 +1,298 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/johnlam90/aws-ssm/pkg/validation"
+	"github.com/spf13/cobra"
+)
+
+var capacityCmd = &cobra.Command{
+	Use:   "capacity",
+	Short: "Report scaling capacity across regions",
+	Long: `Report Auto Scaling Group and EKS node group capacity for fleet reviews.
+
+Examples:
+  # Capacity in the current region
+  aws-ssm capacity report
+
+  # Aggregate several regions
+  aws-ssm capacity report --regions us-east-1,us-west-2,eu-west-1 --output json`,
+}
+
+var capacityReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Summarize ASG and node group capacity per region",
+	Long: `Summarize desired and current capacity of Auto Scaling Groups and EKS node groups
+per region, plus a total across regions. Regions are queried concurrently; a region
+that fails is reported without aborting the others.
+
+Managed node groups are backed by Auto Scaling Groups, so their capacity is also
+included in the ASG columns. Compare the two rather than adding them.
+
+Examples:
+  # Capacity in the current region
+  aws-ssm capacity report
+
+  # Aggregate several regions
+  aws-ssm capacity report --regions us-east-1,us-west-2
+
+  # Machine-readable summary
+  aws-ssm capacity report --regions us-east-1,us-west-2 --output json`,
+	Args: cobra.NoArgs,
+	RunE: runCapacityReport,
+}
+
+var capacityRegions []string
+
+func init() {
+	rootCmd.AddCommand(capacityCmd)
+	capacityCmd.AddCommand(capacityReportCmd)
+	capacityReportCmd.Flags().StringSliceVar(&capacityRegions, "regions", nil, "Comma-separated regions to aggregate (default: the current region)")
+}
+
+// capacitySource lists the scaling groups counted in a capacity report; *aws.Client implements it
+type capacitySource interface {
+	ListAutoScalingGroups(ctx context.Context) ([]string, error)
+	DescribeAutoScalingGroup(ctx context.Context, asgName string) (*aws.AutoScalingGroup, error)
+	ListClusters(ctx context.Context) ([]string, error)
+	ListNodeGroupsForCluster(ctx context.Context, clusterName string) ([]string, error)
+	DescribeNodeGroupPublic(ctx context.Context, clusterName, nodeGroupName string) (*aws.NodeGroup, error)
+}
+
+// capacitySourceFactory creates the source for one region
+type capacitySourceFactory func(ctx context.Context, region string) (capacitySource, error)
+
+// capacityTotals is the aggregated capacity of a region or of the whole report
+type capacityTotals struct {
+	ASGs             int   `json:"asgs"`
+	ASGDesired       int32 `json:"asg_desired"`
+	ASGCurrent       int32 `json:"asg_current"`
+	NodeGroups       int   `json:"node_groups"`
+	NodeGroupDesired int32 `json:"node_group_desired"`
+	NodeGroupCurrent int32 `json:"node_group_current"`
+}
+
+// add accumulates other into t
+func (t *capacityTotals) add(other capacityTotals) {
+	t.ASGs += other.ASGs
+	t.ASGDesired += other.ASGDesired
+	t.ASGCurrent += other.ASGCurrent
+	t.NodeGroups += other.NodeGroups
+	t.NodeGroupDesired += other.NodeGroupDesired
+	t.NodeGroupCurrent += other.NodeGroupCurrent
+}
+
+// regionCapacity is the capacity of one region; Errors lists what could not be counted
+type regionCapacity struct {
+	Region string `json:"region"`
+	capacityTotals
+	Errors []string `json:"errors,omitempty"`
+}
+
+// capacityReport is the data reported by capacity report
+type capacityReport struct {
+	Regions       []regionCapacity `json:"regions"`
+	Total         capacityTotals   `json:"total"`
+	FailedRegions []string         `json:"failed_regions,omitempty"`
+}
+
+func runCapacityReport(_ *cobra.Command, _ []string) error {
+	if err := validateOutputFlags(); err != nil {
+		return err
+	}
+
+	// Create a context that can be cancelled with Ctrl+C
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	regions, err := normalizeCapacityRegions(capacityRegions)
+	if err != nil {
+		return usageErrorf("invalid --regions: %v", err)
+	}
+	if len(regions) == 0 {
+		client, err := aws.NewClient(ctx, region, profile, configPath)
+		if err != nil {
+			return fmt.Errorf("failed to create AWS client: %w", err)
+		}
+		regions = []string{client.GetRegion()}
+	}
+
+	newSource := func(ctx context.Context, r string) (capacitySource, error) {
+		return aws.NewClient(ctx, r, profile, configPath)
+	}
+	report := buildCapacityReport(ctx, regions, newSource)
+
+	if len(report.FailedRegions) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: capacity is incomplete for %s\n", strings.Join(report.FailedRegions, ", "))
+	}
+	if isJSONOutput() {
+		return printJSON(report)
+	}
+	return printCapacityReport(os.Stdout, report, configuredTableMaxRows())
+}
+
+// normalizeCapacityRegions validates, normalizes and de-duplicates the --regions values
+func normalizeCapacityRegions(values []string) ([]string, error) {
+	seen := make(map[string]bool, len(values))
+	regions := make([]string, 0, len(values))
+	for _, value := range values {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		normalized, err := validation.NormalizeRegion(value)
+		if err != nil {
+			return nil, err
+		}
+		if seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+		regions = append(regions, normalized)
+	}
+	return regions, nil
+}
+
+// buildCapacityReport collects every region concurrently and totals the results.
+// Regions keep the order they were given in.
+func buildCapacityReport(ctx context.Context, regions []string, newSource capacitySourceFactory) capacityReport {
+	results := make([]regionCapacity, len(regions))
+	var wg sync.WaitGroup
+	for i, r := range regions {
+		wg.Add(1)
+		go func(i int, r string) {
+			defer wg.Done()
+			results[i] = collectRegionCapacity(ctx, r, newSource)
+		}(i, r)
+	}
+	wg.Wait()
+
+	report := capacityReport{Regions: results}
+	for _, result := range results {
+		report.Total.add(result.capacityTotals)
+		if len(result.Errors) > 0 {
+			report.FailedRegions = append(report.FailedRegions, result.Region)
+		}
+	}
+	return report
+}
+
+// collectRegionCapacity totals one region's ASGs and node groups. Failures are
+// recorded on the result so the rest of the region is still counted.
+func collectRegionCapacity(ctx context.Context, r string, newSource capacitySourceFactory) regionCapacity {
+	result := regionCapacity{Region: r}
+	src, err := newSource(ctx, r)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("failed to create AWS client: %v", err))
+		return result
+	}
+
+	if err := addASGCapacity(ctx, src, &result); err != nil {
+		result.Errors = append(result.Errors, err.Error())
+	}
+	if err := addNodeGroupCapacity(ctx, src, &result); err != nil {
+		result.Errors = append(result.Errors, err.Error())
+	}
+	return result
+}
+
+// addASGCapacity adds every Auto Scaling Group in the region to result
+func addASGCapacity(ctx context.Context, src capacitySource, result *regionCapacity) error {
+	names, err := src.ListAutoScalingGroups(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list auto scaling groups: %w", err)
+	}
+	var failed []string
+	for _, name := range names {
+		asg, err := src.DescribeAutoScalingGroup(ctx, name)
+		if err != nil {
+			failed = append(failed, name)
+			continue
+		}
+		result.ASGs++
+		result.ASGDesired += asg.DesiredCapacity
+		result.ASGCurrent += asg.CurrentSize
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to describe auto scaling groups: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// addNodeGroupCapacity adds every EKS node group in the region to result
+func addNodeGroupCapacity(ctx context.Context, src capacitySource, result *regionCapacity) error {
+	clusters, err := src.ListClusters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list EKS clusters: %w", err)
+	}
+	var failed []string
+	for _, cluster := range clusters {
+		names, err := src.ListNodeGroupsForCluster(ctx, cluster)
+		if err != nil {
+			failed = append(failed, cluster)
+			continue
+		}
+		for _, name := range names {
+			ng, err := src.DescribeNodeGroupPublic(ctx, cluster, name)
+			if err != nil {
+				failed = append(failed, cluster+"/"+name)
+				continue
+			}
+			result.NodeGroups++
+			result.NodeGroupDesired += ng.DesiredSize
+			result.NodeGroupCurrent += ng.CurrentSize
+		}
+	}
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return fmt.Errorf("failed to describe node groups: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// printCapacityReport renders the report as a table of at most maxRows regions
+// (0 = no cap) followed by a total row over every region
+func printCapacityReport(out io.Writer, report capacityReport, maxRows int) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	if _, err := fmt.Fprintln(w, "REGION\tASGS\tASG DESIRED\tASG CURRENT\tNODE GROUPS\tNG DESIRED\tNG CURRENT\tSTATUS"); err != nil {
+		return fmt.Errorf("failed to write table header: %w", err)
+	}
+
+	shown := cappedRows(len(report.Regions), maxRows)
+	for _, r := range report.Regions[:shown] {
+		status := "ok"
+		if len(r.Errors) > 0 {
+			status = strings.Join(r.Errors, "; ")
+		}
+		if err := writeCapacityRow(w, r.Region, r.capacityTotals, status); err != nil {
+			return err
+		}
+	}
+	if err := writeCapacityRow(w, "TOTAL", report.Total, ""); err != nil {
+		return err
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to flush table writer: %w", err)
+	}
+	return writeTruncationFooter(out, shown, len(report.Regions))
+}
+
+// writeCapacityRow writes one table row
+func writeCapacityRow(w io.Writer, label string, t capacityTotals, status string) error {
+	if _, err := fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%d\t%s\n",
+		label, t.ASGs, t.ASGDesired, t.ASGCurrent, t.NodeGroups, t.NodeGroupDesired, t.NodeGroupCurrent, status); err != nil {
+		return fmt.Errorf("failed to write table row: %w", err)
+	}
+	return nil
+}
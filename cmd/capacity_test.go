@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+)
+
+// fakeCapacitySource serves one region's ASGs and node groups
+type fakeCapacitySource struct {
+	asgs       map[string]*aws.AutoScalingGroup
+	nodeGroups map[string]map[string]*aws.NodeGroup
+	listErr    error
+}
+
+func (f *fakeCapacitySource) ListAutoScalingGroups(context.Context) ([]string, error) {
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+	names := make([]string, 0, len(f.asgs))
+	for name := range f.asgs {
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func (f *fakeCapacitySource) DescribeAutoScalingGroup(_ context.Context, name string) (*aws.AutoScalingGroup, error) {
+	if asg, ok := f.asgs[name]; ok && asg != nil {
+		return asg, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (f *fakeCapacitySource) ListClusters(context.Context) ([]string, error) {
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+	clusters := make([]string, 0, len(f.nodeGroups))
+	for cluster := range f.nodeGroups {
+		clusters = append(clusters, cluster)
+	}
+	return clusters, nil
+}
+
+func (f *fakeCapacitySource) ListNodeGroupsForCluster(_ context.Context, cluster string) ([]string, error) {
+	names := make([]string, 0, len(f.nodeGroups[cluster]))
+	for name := range f.nodeGroups[cluster] {
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func (f *fakeCapacitySource) DescribeNodeGroupPublic(_ context.Context, cluster, name string) (*aws.NodeGroup, error) {
+	return f.nodeGroups[cluster][name], nil
+}
+
+func fakeCapacityFactory(sources map[string]capacitySource) capacitySourceFactory {
+	return func(_ context.Context, region string) (capacitySource, error) {
+		src, ok := sources[region]
+		if !ok {
+			return nil, errors.New("no credentials for region")
+		}
+		return src, nil
+	}
+}
+
+func TestBuildCapacityReportAggregatesRegions(t *testing.T) {
+	sources := map[string]capacitySource{
+		"us-east-1": &fakeCapacitySource{
+			asgs: map[string]*aws.AutoScalingGroup{
+				"web": {DesiredCapacity: 4, CurrentSize: 3},
+				"api": {DesiredCapacity: 2, CurrentSize: 2},
+			},
+			nodeGroups: map[string]map[string]*aws.NodeGroup{
+				"prod": {"general": {DesiredSize: 5, CurrentSize: 5}},
+			},
+		},
+		"us-west-2": &fakeCapacitySource{
+			asgs: map[string]*aws.AutoScalingGroup{"batch": {DesiredCapacity: 10, CurrentSize: 8}},
+		},
+		"eu-west-1": &fakeCapacitySource{listErr: errors.New("AccessDenied")},
+	}
+	regions := []string{"us-east-1", "us-west-2", "eu-west-1", "ap-south-1"}
+
+	report := buildCapacityReport(context.Background(), regions, fakeCapacityFactory(sources))
+
+	if len(report.Regions) != len(regions) {
+		t.Fatalf("got %d regions, want %d", len(report.Regions), len(regions))
+	}
+	for i, r := range regions {
+		if report.Regions[i].Region != r {
+			t.Errorf("Regions[%d] = %s, want %s (input order)", i, report.Regions[i].Region, r)
+		}
+	}
+
+	east := report.Regions[0]
+	wantEast := capacityTotals{ASGs: 2, ASGDesired: 6, ASGCurrent: 5, NodeGroups: 1, NodeGroupDesired: 5, NodeGroupCurrent: 5}
+	if east.capacityTotals != wantEast || len(east.Errors) != 0 {
+		t.Errorf("us-east-1 = %+v, want %+v without errors", east, wantEast)
+	}
+
+	wantTotal := capacityTotals{ASGs: 3, ASGDesired: 16, ASGCurrent: 13, NodeGroups: 1, NodeGroupDesired: 5, NodeGroupCurrent: 5}
+	if report.Total != wantTotal {
+		t.Errorf("Total = %+v, want %+v", report.Total, wantTotal)
+	}
+
+	wantFailed := []string{"eu-west-1", "ap-south-1"}
+	if strings.Join(report.FailedRegions, ",") != strings.Join(wantFailed, ",") {
+		t.Errorf("FailedRegions = %v, want %v", report.FailedRegions, wantFailed)
+	}
+	if errs := report.Regions[2].Errors; len(errs) != 2 || !strings.Contains(errs[0], "AccessDenied") {
+		t.Errorf("eu-west-1 errors = %v, want ASG and EKS list failures", errs)
+	}
+}
+
+func TestBuildCapacityReportCountsRestOfRegionOnDescribeFailure(t *testing.T) {
+	sources := map[string]capacitySource{
+		"us-east-1": &fakeCapacitySource{
+			asgs: map[string]*aws.AutoScalingGroup{
+				"web":  {DesiredCapacity: 3, CurrentSize: 3},
+				"gone": nil,
+			},
+		},
+	}
+
+	report := buildCapacityReport(context.Background(), []string{"us-east-1"}, fakeCapacityFactory(sources))
+
+	got := report.Regions[0]
+	if got.ASGs != 1 || got.ASGDesired != 3 {
+		t.Errorf("us-east-1 = %+v, want the describable ASG counted", got)
+	}
+	if len(got.Errors) != 1 || !strings.Contains(got.Errors[0], "gone") {
+		t.Errorf("errors = %v, want the failing ASG named", got.Errors)
+	}
+}
+
+func TestPrintCapacityReport(t *testing.T) {
+	report := capacityReport{
+		Regions: []regionCapacity{
+			{Region: "us-east-1", capacityTotals: capacityTotals{ASGs: 2, ASGDesired: 6, ASGCurrent: 5}},
+			{Region: "eu-west-1", Errors: []string{"failed to list auto scaling groups: AccessDenied"}},
+		},
+		Total: capacityTotals{ASGs: 2, ASGDesired: 6, ASGCurrent: 5},
+	}
+
+	var out bytes.Buffer
+	if err := printCapacityReport(&out, report, 0); err != nil {
+		t.Fatalf("printCapacityReport() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want header, 2 regions and total:\n%s", len(lines), out.String())
+	}
+	if !strings.Contains(lines[2], "AccessDenied") {
+		t.Errorf("failed region row = %q, want the error shown", lines[2])
+	}
+	if !strings.HasPrefix(lines[3], "TOTAL") || !strings.Contains(lines[3], "6") {
+		t.Errorf("total row = %q", lines[3])
+	}
+}
+
+func TestNormalizeCapacityRegions(t *testing.T) {
+	got, err := normalizeCapacityRegions([]string{" us-east-1", "", "EU-WEST-1", "us-east-1"})
+	if err != nil || strings.Join(got, ",") != "us-east-1,eu-west-1" {
+		t.Errorf("normalizeCapacityRegions() = %v, %v", got, err)
+	}
+	if _, err := normalizeCapacityRegions([]string{"us-east1"}); err == nil || !strings.Contains(err.Error(), "us-east-1") {
+		t.Errorf("normalizeCapacityRegions(us-east1) error = %v, want a suggestion", err)
+	}
+}
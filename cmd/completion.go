@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/johnlam90/aws-ssm/pkg/cache"
+	"github.com/spf13/cobra"
+)
+
+// completionRefreshInterval is the least time between background refreshes of
+// one completion list
+const completionRefreshInterval = time.Minute
+
+// completionKind is a list of names offered by shell completion
+type completionKind string
+
+const (
+	completionInstances completionKind = "instances"
+	completionClusters  completionKind = "clusters"
+)
+
+// completionRefreshCmd refreshes a completion list in the background; shell
+// completion starts it and returns without waiting
+var completionRefreshCmd = &cobra.Command{
+	Use:    "__refresh-completion <instances|clusters>",
+	Hidden: true,
+	Args:   cobra.ExactArgs(1),
+	RunE:   runCompletionRefresh,
+}
+
+func init() {
+	rootCmd.AddCommand(completionRefreshCmd)
+
+	for _, c := range []*cobra.Command{sessionCmd, runCmd, portForwardCmd, interfacesCmd} {
+		c.ValidArgsFunction = completeFirstArg(completionInstances)
+	}
+	for _, c := range []*cobra.Command{eksCmd, scaleCmd, updateLTCmd, updateAMICmd, updateConfigCmd} {
+		c.ValidArgsFunction = completeFirstArg(completionClusters)
+	}
+}
+
+// completionSource lists the names offered by completion; *aws.Client implements it
+type completionSource interface {
+	ListInstances(ctx context.Context, tagFilters map[string]string) ([]aws.Instance, error)
+	ListClusters(ctx context.Context) ([]string, error)
+}
+
+// completer serves completion lists from the cache and keeps them fresh by
+// starting a background refresh when a list is older than refreshInterval
+type completer struct {
+	svc             *cache.Service
+	now             func() time.Time
+	refreshInterval time.Duration
+	// startRefresh launches the refresh and must return without waiting for it
+	startRefresh func(kind completionKind, region, profile string) error
+}
+
+// completeFirstArg completes the first positional argument from a cached list
+func completeFirstArg(kind completionKind) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		// Completion skips PersistentPreRunE, so pick up directory-local defaults
+		// here to use the same list as the commands themselves
+		if cwd, err := os.Getwd(); err == nil {
+			_, _ = applyLocalConfig(cwd, io.Discard)
+		}
+		svc, err := newCacheServiceFromConfig()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		c := &completer{svc: svc, now: time.Now, refreshInterval: completionRefreshInterval, startRefresh: spawnCompletionRefresh}
+		return c.complete(kind, region, profile, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// complete returns the cached names starting with toComplete, even when they
+// are stale or missing, and starts a refresh when the list is due for one
+func (c *completer) complete(kind completionKind, region, profile, toComplete string) []string {
+	key := completionCacheKey(kind, region, profile)
+	var names []string
+	var fetchedAt time.Time
+	if entry, err := c.svc.Peek(key); err == nil {
+		names = completionNames(entry.Data)
+		fetchedAt = entry.Timestamp
+	}
+
+	if c.now().Sub(fetchedAt) >= c.refreshInterval && c.claimRefresh(key, region) {
+		// A failed start is retried on a later completion once the claim expires
+		_ = c.startRefresh(kind, region, profile)
+	}
+
+	matches := make([]string, 0, len(names))
+	for _, name := range names {
+		if strings.HasPrefix(name, toComplete) {
+			matches = append(matches, name)
+		}
+	}
+	return matches
+}
+
+// claimRefresh records that a refresh of key is starting, and reports false
+// when one already started within refreshInterval
+func (c *completer) claimRefresh(key, region string) bool {
+	claimKey := key + "_refresh"
+	if entry, err := c.svc.Peek(claimKey); err == nil {
+		claimed, _ := entry.Data.(string)
+		if at, err := time.Parse(time.RFC3339Nano, claimed); err == nil && c.now().Sub(at) < c.refreshInterval {
+			return false
+		}
+	}
+	return c.svc.Set(claimKey, c.now().Format(time.RFC3339Nano), region, "completion-refresh") == nil
+}
+
+// completionCacheKey returns the cache key of a completion list
+func completionCacheKey(kind completionKind, region, profile string) string {
+	if region == "" {
+		region = "default"
+	}
+	if profile == "" {
+		profile = "default"
+	}
+	return fmt.Sprintf("completion_%s_%s_%s", kind, strings.ToLower(profile), strings.ToLower(region))
+}
+
+// completionNames decodes a cached completion list
+func completionNames(data interface{}) []string {
+	items, ok := data.([]interface{})
+	if !ok {
+		return nil
+	}
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		if name, ok := item.(string); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// spawnCompletionRefresh starts a detached aws-ssm process that refreshes the
+// list, so the completion itself returns immediately
+func spawnCompletionRefresh(kind completionKind, region, profile string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate executable: %w", err)
+	}
+	args := []string{completionRefreshCmd.Name(), string(kind)}
+	if region != "" {
+		args = append(args, "--region", region)
+	}
+	if profile != "" {
+		args = append(args, "--profile", profile)
+	}
+	if configPath != "" {
+		args = append(args, "--config", configPath)
+	}
+
+	// #nosec G204 -- re-executes this binary with fixed arguments
+	refresh := exec.Command(executable, args...)
+	if err := refresh.Start(); err != nil {
+		return fmt.Errorf("failed to start completion refresh: %w", err)
+	}
+	return refresh.Process.Release()
+}
+
+func runCompletionRefresh(_ *cobra.Command, args []string) error {
+	kind := completionKind(args[0])
+	if kind != completionInstances && kind != completionClusters {
+		return usageErrorf("unknown completion list %q", args[0])
+	}
+
+	// Create a context that can be cancelled with Ctrl+C
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	svc, err := newCacheServiceFromConfig()
+	if err != nil {
+		return err
+	}
+	client, err := aws.NewClient(ctx, region, profile, configPath)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client: %w", err)
+	}
+	return refreshCompletionCache(ctx, svc, client, kind, region, profile)
+}
+
+// refreshCompletionCache fetches a completion list from AWS and caches it
+func refreshCompletionCache(ctx context.Context, svc *cache.Service, src completionSource, kind completionKind, region, profile string) error {
+	var names []string
+	resourceType := cache.ResourceEC2
+	switch kind {
+	case completionInstances:
+		instances, err := src.ListInstances(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to list instances: %w", err)
+		}
+		seen := make(map[string]bool, 2*len(instances))
+		for _, inst := range instances {
+			for _, name := range []string{inst.Name, inst.InstanceID} {
+				if name != "" && !seen[name] {
+					seen[name] = true
+					names = append(names, name)
+				}
+			}
+		}
+	case completionClusters:
+		resourceType = cache.ResourceEKS
+		clusters, err := src.ListClusters(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list EKS clusters: %w", err)
+		}
+		names = append(names, clusters...)
+	}
+	sort.Strings(names)
+
+	if err := svc.SetWithResourceType(completionCacheKey(kind, region, profile), names, resourceType, region, "completion"); err != nil {
+		return fmt.Errorf("failed to cache completion list: %w", err)
+	}
+	return nil
+}
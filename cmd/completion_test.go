@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/johnlam90/aws-ssm/pkg/cache"
+)
+
+// refreshRecorder counts refreshes started by a completer
+type refreshRecorder struct {
+	started []completionKind
+}
+
+func (r *refreshRecorder) start(kind completionKind, _, _ string) error {
+	r.started = append(r.started, kind)
+	return nil
+}
+
+func newTestCompleter(t *testing.T, now time.Time) (*completer, *refreshRecorder) {
+	t.Helper()
+	svc, err := cache.NewCacheService(t.TempDir(), 60)
+	if err != nil {
+		t.Fatalf("NewCacheService() error = %v", err)
+	}
+	rec := &refreshRecorder{}
+	return &completer{svc: svc, now: func() time.Time { return now }, refreshInterval: time.Minute, startRefresh: rec.start}, rec
+}
+
+// fakeCompletionSource serves fixed instances and clusters
+type fakeCompletionSource struct {
+	instances []aws.Instance
+	clusters  []string
+}
+
+func (f fakeCompletionSource) ListInstances(context.Context, map[string]string) ([]aws.Instance, error) {
+	return f.instances, nil
+}
+
+func (f fakeCompletionSource) ListClusters(context.Context) ([]string, error) {
+	return f.clusters, nil
+}
+
+func TestCompleterServesCacheAndRefreshesInBackground(t *testing.T) {
+	c, rec := newTestCompleter(t, time.Now().Add(2*time.Minute))
+	src := fakeCompletionSource{clusters: []string{"prod", "staging", "prod-eu"}}
+	if err := refreshCompletionCache(context.Background(), c.svc, src, completionClusters, "us-east-1", ""); err != nil {
+		t.Fatalf("refreshCompletionCache() error = %v", err)
+	}
+
+	// The cached list is two minutes old: served as-is, with a refresh started
+	start := time.Now()
+	got := c.complete(completionClusters, "us-east-1", "", "prod")
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("completion took %v, expected it not to wait for a refresh", elapsed)
+	}
+	if want := []string{"prod", "prod-eu"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("complete() = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(rec.started, []completionKind{completionClusters}) {
+		t.Errorf("refreshes started = %v, want one clusters refresh", rec.started)
+	}
+
+	// A second completion within the interval does not start another refresh
+	c.complete(completionClusters, "us-east-1", "", "")
+	if len(rec.started) != 1 {
+		t.Errorf("refreshes started = %d, want refresh frequency bounded to 1", len(rec.started))
+	}
+
+	// Once the interval has passed, the next completion may refresh again
+	later := c.now().Add(time.Minute)
+	c.now = func() time.Time { return later }
+	c.complete(completionClusters, "us-east-1", "", "")
+	if len(rec.started) != 2 {
+		t.Errorf("refreshes started = %d, want 2 after the interval", len(rec.started))
+	}
+}
+
+func TestCompleterFreshCacheSkipsRefresh(t *testing.T) {
+	c, rec := newTestCompleter(t, time.Now())
+	src := fakeCompletionSource{instances: []aws.Instance{
+		{InstanceID: "i-0abc", Name: "web-1"},
+		{InstanceID: "i-0def", Name: "web-1"},
+		{InstanceID: "i-0123"},
+	}}
+	if err := refreshCompletionCache(context.Background(), c.svc, src, completionInstances, "", "dev"); err != nil {
+		t.Fatalf("refreshCompletionCache() error = %v", err)
+	}
+
+	got := c.complete(completionInstances, "", "dev", "")
+	if want := []string{"i-0123", "i-0abc", "i-0def", "web-1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("complete() = %v, want %v", got, want)
+	}
+	if len(rec.started) != 0 {
+		t.Errorf("fresh list should not be refreshed, started %v", rec.started)
+	}
+}
+
+func TestCompleterColdCache(t *testing.T) {
+	c, rec := newTestCompleter(t, time.Now())
+	if got := c.complete(completionInstances, "eu-west-1", "", "web"); len(got) != 0 {
+		t.Errorf("complete() = %v, want no candidates on a cold cache", got)
+	}
+	if !reflect.DeepEqual(rec.started, []completionKind{completionInstances}) {
+		t.Errorf("refreshes started = %v, want one instances refresh", rec.started)
+	}
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/johnlam90/aws-ssm/pkg/config"
+	"github.com/johnlam90/aws-ssm/pkg/security"
+)
+
+// sessionShell is the command that starts the interactive shell, set from a
+// connection override
+var sessionShell string
+
+// instanceConnectionOverride returns the configured override for instance, or nil
+func instanceConnectionOverride(client *aws.Client, instance *aws.Instance) *config.ConnectionOverride {
+	if client.AppConfig == nil {
+		return nil
+	}
+	return client.AppConfig.ConnectionOverride(instance.InstanceID, instance.Name, instance.Tags)
+}
+
+// sessionOverrideSettings returns the user, document and shell an interactive
+// session takes from override. Any --as-user or --document flag wins over the
+// whole override, as does an explicit --native.
+func sessionOverrideSettings(override *config.ConnectionOverride, asUser, document string, explicitNative bool) (user, doc, shell string) {
+	if override == nil || asUser != "" || document != "" || explicitNative {
+		return asUser, document, ""
+	}
+	if override.Document != "" {
+		return "", override.Document, ""
+	}
+	if override.User != "" {
+		return override.User, "", ""
+	}
+	return "", "", override.Shell
+}
+
+// applySessionOverride applies the connection override of instance to an
+// interactive session
+func applySessionOverride(client *aws.Client, instance *aws.Instance, explicitNative bool) error {
+	override := instanceConnectionOverride(client, instance)
+	user, doc, shell := sessionOverrideSettings(override, sessionAsUser, sessionDocument, explicitNative)
+	if user == sessionAsUser && doc == sessionDocument && shell == "" {
+		return nil
+	}
+
+	if shell != "" {
+		sm := security.InitializeSecurityWithLevel(configuredSecurityLevel(client))
+		if err := sm.ValidateCommand(shell); err != nil {
+			return fmt.Errorf("connection override shell blocked by security policy: %w", err)
+		}
+	}
+	fmt.Printf("Applying connection override for %s\n", instance.InstanceID)
+	sessionAsUser, sessionDocument, sessionShell = user, doc, shell
+	useNative = false
+	return nil
+}
+
+// portForwardPorts returns the ports to forward, filling ports not given as
+// flags from the connection override
+func portForwardPorts(override *config.ConnectionOverride, remote, local int) (int, int, error) {
+	if override != nil && override.PortForward != nil {
+		if remote == 0 {
+			remote = override.PortForward.RemotePort
+		}
+		if local == 0 {
+			local = override.PortForward.LocalPort
+		}
+		if local == 0 {
+			local = remote
+		}
+	}
+	if remote == 0 || local == 0 {
+		return 0, 0, usageErrorf("--remote-port and --local-port are required unless a connection override sets port_forward")
+	}
+	if remote < 1 || remote > 65535 {
+		return 0, 0, fmt.Errorf("invalid remote port: %d (must be between 1 and 65535)", remote)
+	}
+	if local < 1 || local > 65535 {
+		return 0, 0, fmt.Errorf("invalid local port: %d (must be between 1 and 65535)", local)
+	}
+	return remote, local, nil
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/johnlam90/aws-ssm/pkg/config"
+)
+
+func TestSessionOverrideSettings(t *testing.T) {
+	override := &config.ConnectionOverride{User: "postgres", Shell: "bash -l"}
+	tests := []struct {
+		name           string
+		override       *config.ConnectionOverride
+		asUser, doc    string
+		explicitNative bool
+		wantUser       string
+		wantDoc        string
+		wantShell      string
+	}{
+		{name: "no override"},
+		{name: "user from override", override: override, wantUser: "postgres"},
+		{name: "shell from override", override: &config.ConnectionOverride{Shell: "bash -l"}, wantShell: "bash -l"},
+		{name: "document wins over user", override: &config.ConnectionOverride{User: "postgres", Document: "DbShell"}, wantDoc: "DbShell"},
+		{name: "flag wins", override: override, asUser: "admin", wantUser: "admin"},
+		{name: "document flag wins", override: override, doc: "Custom", wantDoc: "Custom"},
+		{name: "explicit native ignores override", override: override, explicitNative: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, doc, shell := sessionOverrideSettings(tt.override, tt.asUser, tt.doc, tt.explicitNative)
+			if user != tt.wantUser || doc != tt.wantDoc || shell != tt.wantShell {
+				t.Errorf("sessionOverrideSettings() = %q, %q, %q; want %q, %q, %q", user, doc, shell, tt.wantUser, tt.wantDoc, tt.wantShell)
+			}
+		})
+	}
+}
+
+func TestPortForwardPorts(t *testing.T) {
+	override := &config.ConnectionOverride{PortForward: &config.PortForwardDefault{RemotePort: 5432}}
+	tests := []struct {
+		name          string
+		override      *config.ConnectionOverride
+		remote, local int
+		wantRemote    int
+		wantLocal     int
+		wantUsage     bool
+		wantErr       bool
+	}{
+		{name: "flags", remote: 80, local: 8080, wantRemote: 80, wantLocal: 8080},
+		{name: "missing without override", remote: 80, wantUsage: true, wantErr: true},
+		{name: "from override", override: override, wantRemote: 5432, wantLocal: 5432},
+		{name: "local flag with override", override: override, local: 15432, wantRemote: 5432, wantLocal: 15432},
+		{name: "flags win", override: override, remote: 3306, local: 3306, wantRemote: 3306, wantLocal: 3306},
+		{name: "out of range", remote: 70000, local: 80, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remote, local, err := portForwardPorts(tt.override, tt.remote, tt.local)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("portForwardPorts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantUsage && ExitCode(err) != exitCodeUsage {
+				t.Errorf("ExitCode() = %d, want %d", ExitCode(err), exitCodeUsage)
+			}
+			if err == nil && (remote != tt.wantRemote || local != tt.wantLocal) {
+				t.Errorf("portForwardPorts() = %d, %d; want %d, %d", remote, local, tt.wantRemote, tt.wantLocal)
+			}
+		})
+	}
+}
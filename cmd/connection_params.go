@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/johnlam90/aws-ssm/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var reuseParams bool
+
+// addReuseParamsFlag registers --reuse-params on commands that connect to an instance
+func addReuseParamsFlag(cmd *cobra.Command, params string) {
+	cmd.Flags().BoolVar(&reuseParams, "reuse-params", false,
+		fmt.Sprintf("Reuse the %s last used with this instance; explicit flags still win", params))
+}
+
+// storedConnectionParams returns the parameters last used with instanceID
+func storedConnectionParams(instanceID string) (config.ConnectionParams, error) {
+	sel, err := loadLastSelection()
+	if err != nil {
+		return config.ConnectionParams{}, err
+	}
+	params, ok := sel.Connections[instanceID]
+	if !ok {
+		return config.ConnectionParams{}, fmt.Errorf("%w: no connection parameters stored for %s", errNoLastSelection, instanceID)
+	}
+	return params, nil
+}
+
+// rememberConnectionParams applies fn to the parameters stored for instanceID
+func rememberConnectionParams(instanceID string, fn func(*config.ConnectionParams)) {
+	updateLastSelection(func(sel *config.LastSelection) {
+		if sel.Connections == nil {
+			sel.Connections = make(map[string]config.ConnectionParams)
+		}
+		params := sel.Connections[instanceID]
+		fn(&params)
+		sel.Connections[instanceID] = params
+	})
+}
+
+// rememberSessionParams stores the user and document of an interactive session
+func rememberSessionParams(instanceID, asUser, document string) {
+	rememberConnectionParams(instanceID, func(params *config.ConnectionParams) {
+		params.AsUser, params.Document = asUser, document
+	})
+}
+
+// rememberPortForwardParams stores the ports of a port forward
+func rememberPortForwardParams(instanceID string, remote, local int) {
+	rememberConnectionParams(instanceID, func(params *config.ConnectionParams) {
+		params.RemotePort, params.LocalPort = remote, local
+	})
+}
+
+// applyStoredSessionParams fills --as-user and --document from the parameters
+// last used with instanceID, unless given as flags, and checks them again as
+// if they had been typed: stored values may no longer suit this invocation.
+func applyStoredSessionParams(instanceID string, nArgs int, explicitNative bool) error {
+	params, err := storedConnectionParams(instanceID)
+	if err != nil {
+		return err
+	}
+	if sessionAsUser != "" || sessionDocument != "" || (params.AsUser == "" && params.Document == "") {
+		return nil
+	}
+
+	sessionAsUser, sessionDocument = params.AsUser, params.Document
+	if nArgs > 1 {
+		return usageErrorf("stored session parameters only apply to interactive sessions, not remote commands")
+	}
+	if explicitNative {
+		return usageErrorf("stored session parameters are not supported with --native; they require the session-manager-plugin")
+	}
+	if sessionDocument != "" {
+		if err := validateSessionDocumentFlags(nArgs, explicitNative); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Reusing session parameters for %s\n", instanceID)
+	useNative = false
+	return nil
+}
+
+// storedPortForwardPorts fills ports not given as flags from the ports last
+// used with instanceID; the result is validated by portForwardPorts
+func storedPortForwardPorts(instanceID string, remote, local int) (int, int, error) {
+	params, err := storedConnectionParams(instanceID)
+	if err != nil {
+		return 0, 0, err
+	}
+	if remote == 0 {
+		remote = params.RemotePort
+	}
+	if local == 0 {
+		local = params.LocalPort
+	}
+	return remote, local, nil
+}
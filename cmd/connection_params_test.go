@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// resetSessionFlags restores the session flag globals after a test
+func resetSessionFlags(t *testing.T) {
+	t.Helper()
+	asUser, document, native := sessionAsUser, sessionDocument, useNative
+	t.Cleanup(func() { sessionAsUser, sessionDocument, useNative = asUser, document, native })
+	sessionAsUser, sessionDocument, useNative = "", "", true
+}
+
+func TestStoredSessionParamsReplayed(t *testing.T) {
+	useTempSelectionState(t)
+	resetSessionFlags(t)
+	rememberSessionParams("i-1", "deploy", "")
+
+	if err := applyStoredSessionParams("i-1", 1, false); err != nil {
+		t.Fatalf("applyStoredSessionParams() error = %v", err)
+	}
+	if sessionAsUser != "deploy" || sessionDocument != "" {
+		t.Errorf("session params = %q/%q, want the stored user", sessionAsUser, sessionDocument)
+	}
+	if useNative {
+		t.Errorf("useNative still set; stored parameters need the plugin")
+	}
+}
+
+func TestStoredSessionParamsExplicitFlagWins(t *testing.T) {
+	useTempSelectionState(t)
+	resetSessionFlags(t)
+	rememberSessionParams("i-1", "deploy", "")
+	sessionAsUser = "admin"
+
+	if err := applyStoredSessionParams("i-1", 1, false); err != nil {
+		t.Fatalf("applyStoredSessionParams() error = %v", err)
+	}
+	if sessionAsUser != "admin" {
+		t.Errorf("sessionAsUser = %q, want the explicit flag", sessionAsUser)
+	}
+}
+
+func TestStoredSessionParamsRevalidated(t *testing.T) {
+	tests := []struct {
+		name           string
+		asUser         string
+		document       string
+		nArgs          int
+		explicitNative bool
+		wantErr        string
+	}{
+		{name: "remote command", asUser: "deploy", nArgs: 2, wantErr: "interactive sessions"},
+		{name: "explicit native", asUser: "deploy", nArgs: 1, explicitNative: true, wantErr: "--native"},
+		{name: "document with user", asUser: "deploy", document: "Custom-Shell", nArgs: 1, wantErr: "--as-user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTempSelectionState(t)
+			resetSessionFlags(t)
+			rememberSessionParams("i-1", tt.asUser, tt.document)
+
+			err := applyStoredSessionParams("i-1", tt.nArgs, tt.explicitNative)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("applyStoredSessionParams() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStoredConnectionParamsMissing(t *testing.T) {
+	useTempSelectionState(t)
+	rememberPortForwardParams("i-other", 80, 8080)
+
+	if _, err := storedConnectionParams("i-1"); !errors.Is(err, errNoLastSelection) {
+		t.Errorf("storedConnectionParams() error = %v, want errNoLastSelection", err)
+	}
+}
+
+func TestStoredPortForwardPortsReplayed(t *testing.T) {
+	useTempSelectionState(t)
+	rememberPortForwardParams("i-1", 5432, 15432)
+	rememberSessionParams("i-1", "deploy", "")
+
+	params, err := storedConnectionParams("i-1")
+	if err != nil {
+		t.Fatalf("storedConnectionParams() error = %v", err)
+	}
+	if params.RemotePort != 5432 || params.LocalPort != 15432 || params.AsUser != "deploy" {
+		t.Errorf("stored params = %+v, want ports and user kept side by side", params)
+	}
+
+	remote, local, err := storedPortForwardPorts("i-1", 0, 9000)
+	if err != nil {
+		t.Fatalf("storedPortForwardPorts() error = %v", err)
+	}
+	if remote != 5432 || local != 9000 {
+		t.Errorf("ports = %d/%d, want the stored remote port and the explicit local port", remote, local)
+	}
+}
+
+func TestStoredPortForwardPortsRevalidated(t *testing.T) {
+	useTempSelectionState(t)
+	rememberPortForwardParams("i-1", 70000, 8080)
+
+	remote, local, err := storedPortForwardPorts("i-1", 0, 0)
+	if err != nil {
+		t.Fatalf("storedPortForwardPorts() error = %v", err)
+	}
+	if _, _, err := portForwardPorts(nil, remote, local); err == nil || !strings.Contains(err.Error(), "invalid remote port") {
+		t.Errorf("portForwardPorts() error = %v, want the stored port rejected", err)
+	}
+}
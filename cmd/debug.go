@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/johnlam90/aws-ssm/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var debugCmd = &cobra.Command{
+	Use:   "debug",
+	Short: "Troubleshooting helpers",
+	Long: `Commands that help diagnose unexpected behaviour.
+
+Examples:
+  # Show recognized environment variables and what they map to
+  aws-ssm debug env`,
+}
+
+var debugEnvCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Show recognized environment variables and their values",
+	Long: `Print every environment variable aws-ssm recognizes, its current value, and
+the config field or flag it relates to. Sensitive values such as credentials are masked.
+
+Precedence: CLI flags > Environment variables > Config file > Defaults
+
+Examples:
+  # Show all recognized variables
+  aws-ssm debug env
+
+  # Machine-readable output
+  aws-ssm debug env --output json`,
+	Args: cobra.NoArgs,
+	RunE: runDebugEnv,
+}
+
+func init() {
+	rootCmd.AddCommand(debugCmd)
+	debugCmd.AddCommand(debugEnvCmd)
+}
+
+func runDebugEnv(_ *cobra.Command, _ []string) error {
+	if err := validateOutputFlags(); err != nil {
+		return err
+	}
+
+	report := config.EnvReport()
+	if isJSONOutput() {
+		return printJSON(map[string]interface{}{"env": envReportJSON(report)})
+	}
+	return printEnvReport(os.Stdout, report, configuredTableMaxRows())
+}
+
+// envReportJSON converts the report into plain maps for JSON output
+func envReportJSON(report []config.EnvVarStatus) []map[string]interface{} {
+	entries := make([]map[string]interface{}, 0, len(report))
+	for _, status := range report {
+		entries = append(entries, map[string]interface{}{
+			"name":         status.Name,
+			"set":          status.Set,
+			"value":        status.Value,
+			"config_field": status.ConfigField,
+			"sensitive":    status.Sensitive,
+			"description":  status.Description,
+		})
+	}
+	return entries
+}
+
+// printEnvReport renders the environment report as a table of at most maxRows rows (0 = no cap)
+func printEnvReport(out io.Writer, report []config.EnvVarStatus, maxRows int) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	if _, err := fmt.Fprintln(w, "VARIABLE\tVALUE\tMAPS TO\tDESCRIPTION"); err != nil {
+		return fmt.Errorf("failed to write table header: %w", err)
+	}
+
+	shown := cappedRows(len(report), maxRows)
+	for _, status := range report[:shown] {
+		value := "(unset)"
+		if status.Set {
+			value = status.Value
+			if value == "" {
+				value = `""`
+			}
+		}
+		field := status.ConfigField
+		if field == "" {
+			field = "-"
+		}
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", status.Name, value, field, status.Description); err != nil {
+			return fmt.Errorf("failed to write table row: %w", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to flush table writer: %w", err)
+	}
+	return writeTruncationFooter(out, shown, len(report))
+}
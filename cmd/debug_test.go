@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/johnlam90/aws-ssm/pkg/config"
+)
+
+func TestPrintEnvReport(t *testing.T) {
+	t.Setenv("AWS_REGION", "ap-south-1")
+	t.Setenv("AWS_SSM_SESSION_TIMEOUT", "30m")
+	t.Setenv("AWS_SESSION_TOKEN", "FwoGZXIvYXdzEXAMPLETOKEN1234")
+
+	var out bytes.Buffer
+	if err := printEnvReport(&out, config.EnvReport(), 0); err != nil {
+		t.Fatalf("printEnvReport failed: %v", err)
+	}
+	output := out.String()
+
+	for _, want := range [][]string{
+		{"AWS_REGION", "ap-south-1", "default.region (--region)"},
+		{"AWS_SSM_SESSION_TIMEOUT", "30m"},
+		{"AWS_SESSION_TOKEN", "****************1234"},
+	} {
+		line := findLine(output, want[0]+" ")
+		if line == "" {
+			t.Fatalf("expected a line for %s in output:\n%s", want[0], output)
+		}
+		for _, fragment := range want[1:] {
+			if !strings.Contains(line, fragment) {
+				t.Errorf("line for %s = %q, want it to contain %q", want[0], line, fragment)
+			}
+		}
+	}
+	if strings.Contains(output, "FwoGZXIvYXdzEXAMPLETOKEN1234") {
+		t.Error("session token must be masked")
+	}
+}
+
+// findLine returns the first line starting with prefix
+func findLine(output, prefix string) string {
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, prefix) {
+			return line
+		}
+	}
+	return ""
+}
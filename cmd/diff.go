@@ -0,0 +1,324 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v3"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare ASG and node group capacity against a desired-state file",
+	Long: `Compare the min, max and desired capacity of Auto Scaling Groups and EKS node
+groups against a desired-state YAML file and report drift per resource.
+
+Only the fields set in the file are compared, so leave out desired for groups whose
+size is managed by an autoscaler. The command exits 6 when drift is found, 1 when a
+resource could not be checked, and 0 when everything matches.
+
+Desired-state file:
+  asgs:
+    - name: web-asg
+      min: 2
+      max: 6
+      desired: 3
+  nodegroups:
+    - cluster: prod
+      name: workers
+      min: 1
+      max: 10
+
+Examples:
+  # Check for drift in the current region
+  aws-ssm diff --file desired.yaml
+
+  # Machine-readable report for CI
+  aws-ssm diff --file desired.yaml --output json`,
+	Args: cobra.NoArgs,
+	RunE: runDiff,
+}
+
+var diffFile string
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().StringVarP(&diffFile, "file", "f", "", "Desired-state YAML file")
+	_ = diffCmd.MarkFlagRequired("file")
+}
+
+// desiredCapacity is the capacity a desired-state entry pins; nil fields are not compared
+type desiredCapacity struct {
+	Min     *int32 `yaml:"min"`
+	Max     *int32 `yaml:"max"`
+	Desired *int32 `yaml:"desired"`
+}
+
+// desiredASG is an Auto Scaling Group entry of the desired-state file
+type desiredASG struct {
+	Name            string `yaml:"name"`
+	desiredCapacity `yaml:",inline"`
+}
+
+// desiredNodeGroup is an EKS node group entry of the desired-state file
+type desiredNodeGroup struct {
+	Cluster         string `yaml:"cluster"`
+	Name            string `yaml:"name"`
+	desiredCapacity `yaml:",inline"`
+}
+
+// desiredState is the desired-state file read by diff
+type desiredState struct {
+	ASGs       []desiredASG       `yaml:"asgs"`
+	NodeGroups []desiredNodeGroup `yaml:"nodegroups"`
+}
+
+// driftSource describes the resources compared by diff; *aws.Client implements it
+type driftSource interface {
+	DescribeAutoScalingGroup(ctx context.Context, asgName string) (*aws.AutoScalingGroup, error)
+	DescribeNodeGroupPublic(ctx context.Context, clusterName, nodeGroupName string) (*aws.NodeGroup, error)
+}
+
+// Drift status of a resource
+const (
+	driftStatusOK    = "ok"
+	driftStatusDrift = "drift"
+	driftStatusError = "error"
+)
+
+// fieldDrift is one capacity field that differs from the desired state
+type fieldDrift struct {
+	Field   string `json:"field"`
+	Desired int32  `json:"desired"`
+	Actual  int32  `json:"actual"`
+}
+
+// resourceDrift is the comparison result of one resource
+type resourceDrift struct {
+	Type    string       `json:"type"`
+	Cluster string       `json:"cluster,omitempty"`
+	Name    string       `json:"name"`
+	Status  string       `json:"status"`
+	Drift   []fieldDrift `json:"drift,omitempty"`
+	Error   string       `json:"error,omitempty"`
+}
+
+// driftReport is the data reported by diff
+type driftReport struct {
+	Resources []resourceDrift `json:"resources"`
+	Drifted   int             `json:"drifted"`
+	Errors    int             `json:"errors"`
+}
+
+func runDiff(_ *cobra.Command, _ []string) error {
+	if err := validateOutputFlags(); err != nil {
+		return err
+	}
+
+	desired, err := loadDesiredState(diffFile)
+	if err != nil {
+		return err
+	}
+
+	// Create a context that can be cancelled with Ctrl+C
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	client, err := aws.NewClient(ctx, region, profile, configPath)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client: %w", err)
+	}
+
+	report := buildDriftReport(ctx, client, desired)
+	if isJSONOutput() {
+		err = printJSON(report)
+	} else {
+		err = printDriftReport(os.Stdout, report, tableMaxRows(client.AppConfig))
+	}
+	if err != nil {
+		return err
+	}
+	return driftResult(report)
+}
+
+// loadDesiredState reads and validates a desired-state file
+func loadDesiredState(path string) (*desiredState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read desired-state file: %w", err)
+	}
+	desired, err := parseDesiredState(data)
+	if err != nil {
+		return nil, usageErrorf("invalid desired-state file %s: %v", path, err)
+	}
+	return desired, nil
+}
+
+// parseDesiredState decodes a desired-state document, rejecting unknown fields
+// so a typo is not silently ignored
+func parseDesiredState(data []byte) (*desiredState, error) {
+	var desired desiredState
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&desired); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if len(desired.ASGs)+len(desired.NodeGroups) == 0 {
+		return nil, fmt.Errorf("no asgs or nodegroups listed")
+	}
+	for i, asg := range desired.ASGs {
+		if strings.TrimSpace(asg.Name) == "" {
+			return nil, fmt.Errorf("asgs[%d]: name is required", i)
+		}
+		if err := asg.validate(); err != nil {
+			return nil, fmt.Errorf("asg %s: %w", asg.Name, err)
+		}
+	}
+	for i, ng := range desired.NodeGroups {
+		if strings.TrimSpace(ng.Cluster) == "" || strings.TrimSpace(ng.Name) == "" {
+			return nil, fmt.Errorf("nodegroups[%d]: cluster and name are required", i)
+		}
+		if err := ng.validate(); err != nil {
+			return nil, fmt.Errorf("nodegroup %s/%s: %w", ng.Cluster, ng.Name, err)
+		}
+	}
+	return &desired, nil
+}
+
+// validate checks that the pinned sizes are consistent
+func (d desiredCapacity) validate() error {
+	for _, field := range []struct {
+		name  string
+		value *int32
+	}{{"min", d.Min}, {"max", d.Max}, {"desired", d.Desired}} {
+		if field.value != nil && *field.value < 0 {
+			return fmt.Errorf("%s cannot be negative", field.name)
+		}
+	}
+	if d.Min != nil && d.Max != nil && *d.Min > *d.Max {
+		return fmt.Errorf("min (%d) cannot be greater than max (%d)", *d.Min, *d.Max)
+	}
+	return nil
+}
+
+// compare returns the fields whose actual value differs from the desired one
+func (d desiredCapacity) compare(minSize, maxSize, desired int32) []fieldDrift {
+	var drift []fieldDrift
+	for _, field := range []struct {
+		name   string
+		want   *int32
+		actual int32
+	}{{"min", d.Min, minSize}, {"max", d.Max, maxSize}, {"desired", d.Desired, desired}} {
+		if field.want != nil && *field.want != field.actual {
+			drift = append(drift, fieldDrift{Field: field.name, Desired: *field.want, Actual: field.actual})
+		}
+	}
+	return drift
+}
+
+// buildDriftReport compares every resource in the desired state with its actual capacity
+func buildDriftReport(ctx context.Context, src driftSource, desired *desiredState) driftReport {
+	report := driftReport{Resources: make([]resourceDrift, 0, len(desired.ASGs)+len(desired.NodeGroups))}
+	for _, want := range desired.ASGs {
+		result := resourceDrift{Type: "asg", Name: want.Name}
+		if asg, err := src.DescribeAutoScalingGroup(ctx, want.Name); err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Drift = want.compare(asg.MinSize, asg.MaxSize, asg.DesiredCapacity)
+		}
+		report.add(result)
+	}
+	for _, want := range desired.NodeGroups {
+		result := resourceDrift{Type: "nodegroup", Cluster: want.Cluster, Name: want.Name}
+		if ng, err := src.DescribeNodeGroupPublic(ctx, want.Cluster, want.Name); err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Drift = want.compare(ng.MinSize, ng.MaxSize, ng.DesiredSize)
+		}
+		report.add(result)
+	}
+	return report
+}
+
+// add sets the status of result and appends it to the report
+func (r *driftReport) add(result resourceDrift) {
+	switch {
+	case result.Error != "":
+		result.Status = driftStatusError
+		r.Errors++
+	case len(result.Drift) > 0:
+		result.Status = driftStatusDrift
+		r.Drifted++
+	default:
+		result.Status = driftStatusOK
+	}
+	r.Resources = append(r.Resources, result)
+}
+
+// driftResult turns the report into the command result: an error when a
+// resource could not be checked, exitCodeDrift when anything drifted
+func driftResult(report driftReport) error {
+	if report.Errors > 0 {
+		return fmt.Errorf("could not check %d of %d resource(s)", report.Errors, len(report.Resources))
+	}
+	if report.Drifted > 0 {
+		return withExitCode(exitCodeDrift, fmt.Errorf("drift detected in %d of %d resource(s)", report.Drifted, len(report.Resources)))
+	}
+	return nil
+}
+
+// printDriftReport renders the report as a table of at most maxRows resources
+// (0 = no cap) followed by a drift summary over every resource
+func printDriftReport(out io.Writer, report driftReport, maxRows int) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	if _, err := fmt.Fprintln(w, "RESOURCE\tSTATUS\tDETAILS"); err != nil {
+		return fmt.Errorf("failed to write table header: %w", err)
+	}
+	shown := cappedRows(len(report.Resources), maxRows)
+	for _, r := range report.Resources[:shown] {
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", r.resourceName(), r.Status, r.details()); err != nil {
+			return fmt.Errorf("failed to write table row: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to flush table writer: %w", err)
+	}
+	if err := writeTruncationFooter(out, shown, len(report.Resources)); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(out, "\n%d of %d resource(s) drifted\n", report.Drifted, len(report.Resources))
+	return err
+}
+
+// resourceName returns the resource as type/name or nodegroup/cluster/name
+func (r resourceDrift) resourceName() string {
+	if r.Cluster != "" {
+		return r.Type + "/" + r.Cluster + "/" + r.Name
+	}
+	return r.Type + "/" + r.Name
+}
+
+// details describes the drift or error of a resource
+func (r resourceDrift) details() string {
+	if r.Error != "" {
+		return r.Error
+	}
+	if len(r.Drift) == 0 {
+		return "-"
+	}
+	parts := make([]string, 0, len(r.Drift))
+	for _, d := range r.Drift {
+		parts = append(parts, fmt.Sprintf("%s: desired %d, actual %d", d.Field, d.Desired, d.Actual))
+	}
+	return strings.Join(parts, "; ")
+}
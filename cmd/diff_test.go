@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+)
+
+const testDesiredState = `
+asgs:
+  - name: web
+    min: 2
+    max: 6
+    desired: 3
+nodegroups:
+  - cluster: prod
+    name: workers
+    min: 1
+    max: 10
+`
+
+func driftTestSource(webDesired, workersMax int32) *fakeCapacitySource {
+	return &fakeCapacitySource{
+		asgs: map[string]*aws.AutoScalingGroup{
+			"web": {Name: "web", MinSize: 2, MaxSize: 6, DesiredCapacity: webDesired},
+		},
+		nodeGroups: map[string]map[string]*aws.NodeGroup{
+			// Desired is not pinned in the file, so an autoscaler may change it freely
+			"prod": {"workers": {Name: "workers", MinSize: 1, MaxSize: workersMax, DesiredSize: 7}},
+		},
+	}
+}
+
+func TestDiffReport(t *testing.T) {
+	desired, err := parseDesiredState([]byte(testDesiredState))
+	if err != nil {
+		t.Fatalf("parseDesiredState() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		src          *fakeCapacitySource
+		wantDrifted  int
+		wantExitCode int
+		wantOutput   []string
+	}{
+		{
+			name:         "no drift",
+			src:          driftTestSource(3, 10),
+			wantExitCode: exitCodeOK,
+			wantOutput:   []string{"asg/web", "nodegroup/prod/workers", "0 of 2 resource(s) drifted"},
+		},
+		{
+			name:         "drift",
+			src:          driftTestSource(5, 12),
+			wantDrifted:  2,
+			wantExitCode: exitCodeDrift,
+			wantOutput: []string{
+				"desired: desired 3, actual 5",
+				"max: desired 10, actual 12",
+				"2 of 2 resource(s) drifted",
+			},
+		},
+		{
+			name:         "missing resource",
+			src:          &fakeCapacitySource{asgs: driftTestSource(3, 10).asgs},
+			wantExitCode: exitCodeError,
+			wantOutput:   []string{"nodegroup/prod/workers", "error"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := buildDriftReport(context.Background(), fakeDriftSource{tt.src}, desired)
+			if report.Drifted != tt.wantDrifted {
+				t.Errorf("Drifted = %d, want %d", report.Drifted, tt.wantDrifted)
+			}
+
+			var out bytes.Buffer
+			if err := printDriftReport(&out, report, 0); err != nil {
+				t.Fatalf("printDriftReport() error = %v", err)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, out.String())
+				}
+			}
+
+			if code := ExitCode(driftResult(report)); code != tt.wantExitCode {
+				t.Errorf("ExitCode() = %d, want %d", code, tt.wantExitCode)
+			}
+		})
+	}
+}
+
+// fakeDriftSource serves the capacity fake, reporting unknown node groups as errors
+type fakeDriftSource struct{ *fakeCapacitySource }
+
+func (s fakeDriftSource) DescribeNodeGroupPublic(ctx context.Context, cluster, name string) (*aws.NodeGroup, error) {
+	ng, _ := s.fakeCapacitySource.DescribeNodeGroupPublic(ctx, cluster, name)
+	if ng == nil {
+		return nil, os.ErrNotExist
+	}
+	return ng, nil
+}
+
+func TestParseDesiredStateInvalid(t *testing.T) {
+	tests := map[string]string{
+		"empty":            "",
+		"unknown field":    "asgs:\n  - name: web\n    desird: 3\n",
+		"missing name":     "asgs:\n  - min: 1\n",
+		"missing cluster":  "nodegroups:\n  - name: workers\n",
+		"negative size":    "asgs:\n  - name: web\n    desired: -1\n",
+		"min above max":    "asgs:\n  - name: web\n    min: 5\n    max: 2\n",
+		"not a size value": "asgs:\n  - name: web\n    max: many\n",
+	}
+	for name, doc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseDesiredState([]byte(doc)); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadDesiredStateUsageError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "desired.yaml")
+	if err := os.WriteFile(path, []byte("asgs: []\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, err := loadDesiredState(path)
+	if code := ExitCode(err); code != exitCodeUsage {
+		t.Errorf("ExitCode() = %d, want %d (err = %v)", code, exitCodeUsage, err)
+	}
+}
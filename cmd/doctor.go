@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/config"
+	"github.com/johnlam90/aws-ssm/pkg/health"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose common environment problems",
+	Long: `Run a set of local diagnostics that catch common causes of confusing AWS errors.
+
+Checks performed:
+  - Configuration file can be loaded
+  - Local clock is in sync with AWS (clock skew breaks SigV4 request signing)
+
+Examples:
+  # Run all diagnostics
+  aws-ssm doctor
+
+  # Run diagnostics against a specific region's endpoints
+  aws-ssm doctor --region eu-west-1`,
+	RunE: runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+func runDoctor(_ *cobra.Command, _ []string) error {
+	// Create a context that can be cancelled with Ctrl+C
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	result := newDoctorChecker().CheckAll(ctx)
+	printDoctorResult(os.Stdout, result)
+
+	if result.Overall == health.StatusError || result.Overall == health.StatusCritical {
+		return fmt.Errorf("one or more checks failed")
+	}
+	return nil
+}
+
+// newDoctorChecker returns the checks run by doctor
+func newDoctorChecker() *health.Checker {
+	checker := health.NewChecker()
+	checker.AddCheck("configuration", health.NewConfigHealthCheck(func() error {
+		_, err := config.LoadConfig(configPath)
+		return err
+	}))
+	checker.AddCheck("clock_skew", newClockSkewCheck(resolveDoctorRegion()))
+	return checker
+}
+
+// newClockSkewCheck builds a clock skew check against the regional EC2 endpoint
+func newClockSkewCheck(awsRegion string) *health.ClockSkewCheck {
+	endpoint := fmt.Sprintf("https://ec2.%s.amazonaws.com", awsRegion)
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+
+	return health.NewClockSkewCheck(func(ctx context.Context) (time.Time, error) {
+		return health.FetchServerTime(ctx, httpClient, endpoint)
+	}, health.DefaultClockSkewThreshold)
+}
+
+// resolveDoctorRegion picks the region to probe without requiring AWS credentials
+func resolveDoctorRegion() string {
+	if region != "" {
+		return region
+	}
+	if envRegion := os.Getenv("AWS_REGION"); envRegion != "" {
+		return envRegion
+	}
+	if cfg, err := config.LoadConfig(configPath); err == nil && cfg.Default.Region != "" {
+		return cfg.Default.Region
+	}
+	return "us-east-1"
+}
+
+// warnOnClockSkew runs the clock skew check inline and prints a warning if needed
+func warnOnClockSkew(ctx context.Context) {
+	result := newClockSkewCheck(resolveDoctorRegion()).Check(ctx)
+	if result.Status == health.StatusWarning || result.Status == health.StatusCritical {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", result.Message)
+	}
+}
+
+// printDoctorResult renders diagnostic results in a stable order
+func printDoctorResult(out io.Writer, result *health.CompositeResult) {
+	names := make([]string, 0, len(result.Checks))
+	for name := range result.Checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		check := result.Checks[name]
+		fmt.Fprintf(out, "%s %-15s %s\n", doctorStatusIcon(check.Status), name, check.Message)
+	}
+	fmt.Fprintf(out, "\nOverall: %s\n", result.Overall)
+}
+
+// doctorStatusIcon maps a health status to a short marker
+func doctorStatusIcon(status health.Status) string {
+	switch status {
+	case health.StatusOK:
+		return "✓"
+	case health.StatusWarning:
+		return "!"
+	case health.StatusUnknown:
+		return "?"
+	default:
+		return "✗"
+	}
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/spf13/cobra"
+)
+
+// dryRun makes mutating commands stop after resolution and validation and
+// print the change instead of applying it
+var dryRun bool
+
+// resourceMutator is the set of client methods that change AWS resources;
+// *aws.Client implements it. Mutating commands apply changes only through it.
+type resourceMutator interface {
+	UpdateAutoScalingGroupCapacity(ctx context.Context, asgName string, minSize, maxSize, desiredCapacity int32) error
+	UpdateNodeGroupScaling(ctx context.Context, clusterName, nodeGroupName string, minSize, maxSize, desiredSize int32) error
+	UpdateNodeGroupLaunchTemplate(ctx context.Context, clusterName, nodeGroupName, launchTemplateID, version string) error
+	UpdateNodeGroupReleaseVersion(ctx context.Context, clusterName, nodeGroupName, releaseVersion string) error
+	UpdateNodeGroupLabelsAndTaints(ctx context.Context, clusterName, nodeGroupName string, plan *aws.NodeGroupConfigPlan) error
+	TagInstances(ctx context.Context, instanceIDs []string, tags map[string]string) []aws.TagResult
+}
+
+func init() {
+	for _, c := range []*cobra.Command{asgScaleCmd, scaleCmd, updateLTCmd, updateAMICmd, updateConfigCmd, updateLabelsCmd, updateTaintsCmd, ec2TagBulkCmd} {
+		c.Flags().BoolVar(&dryRun, "dry-run", false, "Resolve and validate the change and print it without applying it")
+	}
+}
+
+// reportDryRun prints the change a command would make and reports whether
+// --dry-run is set, in which case the caller must not apply it
+func reportDryRun(format string, args ...interface{}) bool {
+	if !dryRun {
+		return false
+	}
+	fmt.Printf("Dry run: would "+format+"\n", args...)
+	fmt.Println("No changes were made.")
+	return true
+}
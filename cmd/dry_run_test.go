@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+)
+
+// recordingMutator records every mutating call it receives
+type recordingMutator struct {
+	calls []string
+}
+
+func (m *recordingMutator) UpdateAutoScalingGroupCapacity(context.Context, string, int32, int32, int32) error {
+	m.calls = append(m.calls, "UpdateAutoScalingGroupCapacity")
+	return nil
+}
+
+func (m *recordingMutator) UpdateNodeGroupScaling(context.Context, string, string, int32, int32, int32) error {
+	m.calls = append(m.calls, "UpdateNodeGroupScaling")
+	return nil
+}
+
+func (m *recordingMutator) UpdateNodeGroupLaunchTemplate(context.Context, string, string, string, string) error {
+	m.calls = append(m.calls, "UpdateNodeGroupLaunchTemplate")
+	return nil
+}
+
+func (m *recordingMutator) UpdateNodeGroupReleaseVersion(context.Context, string, string, string) error {
+	m.calls = append(m.calls, "UpdateNodeGroupReleaseVersion")
+	return nil
+}
+
+func (m *recordingMutator) UpdateNodeGroupLabelsAndTaints(context.Context, string, string, *aws.NodeGroupConfigPlan) error {
+	m.calls = append(m.calls, "UpdateNodeGroupLabelsAndTaints")
+	return nil
+}
+
+func (m *recordingMutator) TagInstances(_ context.Context, instanceIDs []string, _ map[string]string) []aws.TagResult {
+	m.calls = append(m.calls, "TagInstances")
+	results := make([]aws.TagResult, 0, len(instanceIDs))
+	for _, id := range instanceIDs {
+		results = append(results, aws.TagResult{InstanceID: id})
+	}
+	return results
+}
+
+func TestMutatingCommandsHonorDryRun(t *testing.T) {
+	// Applied tags invalidate the cache; keep it out of the real home directory
+	t.Setenv("HOME", t.TempDir())
+	ctx := context.Background()
+	commands := []struct {
+		name    string
+		execute func(resourceMutator) error
+	}{
+		{"asg scale", func(m resourceMutator) error {
+			return executeASGScaling(ctx, m, "web", ASGScalingParameters{Min: 1, Max: 4, Desired: 2})
+		}},
+		{"nodegroup scale", func(m resourceMutator) error {
+			return executeScaling(ctx, m, "prod", "workers", ScalingParameters{Min: 1, Max: 4, Desired: 2})
+		}},
+		{"update-lt", func(m resourceMutator) error {
+			return executeLTUpdate(ctx, m, "prod", "workers", "lt-0abc", "7")
+		}},
+		{"update-ami", func(m resourceMutator) error {
+			return executeAMIUpdate(ctx, m, "prod", "workers", "1.30.0-20240703")
+		}},
+		{"update-config", func(m resourceMutator) error {
+			return executeNodeGroupConfigUpdate(ctx, m, "prod", "workers", &aws.NodeGroupConfigPlan{Labels: map[string]string{"tier": "web"}})
+		}},
+		{"ec2 tag-bulk", func(m resourceMutator) error {
+			return executeTagBulk(ctx, m, "", []string{"i-0abc"}, map[string]string{"Team": "web"})
+		}},
+	}
+
+	for _, tt := range commands {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(prev bool) { dryRun = prev }(dryRun)
+
+			dryRun = true
+			m := &recordingMutator{}
+			out := captureStdout(t, func() error { return tt.execute(m) })
+			if len(m.calls) != 0 {
+				t.Errorf("--dry-run called %v", m.calls)
+			}
+			if !strings.Contains(out, "Dry run: would") || !strings.Contains(out, "No changes were made.") {
+				t.Errorf("output does not describe the dry run:\n%s", out)
+			}
+
+			dryRun = false
+			m = &recordingMutator{}
+			captureStdout(t, func() error { return tt.execute(m) })
+			if len(m.calls) != 1 {
+				t.Errorf("without --dry-run: calls = %v, want one", m.calls)
+			}
+		})
+	}
+}
+
+func TestDryRunFlagOnMutatingCommands(t *testing.T) {
+	for _, c := range []string{"asg scale", "eks nodegroup scale", "eks nodegroup update-lt", "eks nodegroup update-ami", "eks nodegroup update-config", "eks nodegroup update-labels", "eks nodegroup update-taints", "ec2 tag-bulk"} {
+		cmd, _, err := rootCmd.Find(strings.Fields(c))
+		if err != nil {
+			t.Fatalf("Find(%q) error = %v", c, err)
+		}
+		if cmd.Flags().Lookup("dry-run") == nil {
+			t.Errorf("%s has no --dry-run flag", c)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/johnlam90/aws-ssm/pkg/validation"
+	"github.com/spf13/cobra"
+)
+
+var (
+	bulkTags        []string
+	bulkTagFilters  []string
+	bulkSkipConfirm bool
+)
+
+var ec2Cmd = &cobra.Command{
+	Use:   "ec2",
+	Short: "EC2 instance operations",
+	Long: `Perform operations across EC2 instances.
+
+Examples:
+  # Tag every running instance in the stage environment
+  aws-ssm ec2 tag-bulk --tag CostCenter=1234 --filter Env=stage`,
+}
+
+var ec2TagBulkCmd = &cobra.Command{
+	Use:   "tag-bulk",
+	Short: "Apply tags to all running instances matching tag filters",
+	Long: `Resolve running EC2 instances matching the given tag filters and apply tags
+to all of them concurrently. Each instance is reported individually; a failure on
+one instance does not stop the others. Cached instance data for the affected
+instances is invalidated afterwards.
+
+Examples:
+  # Tag all stage instances with a cost center
+  aws-ssm ec2 tag-bulk --tag CostCenter=1234 --filter Env=stage
+
+  # Apply several tags to instances matching several filters
+  aws-ssm ec2 tag-bulk --tag Owner=platform --tag CostCenter=1234 --filter Env=stage --filter Team=web
+
+  # Skip the confirmation prompt
+  aws-ssm ec2 tag-bulk --tag Owner=platform --filter Env=stage --skip-confirm`,
+	Args: cobra.NoArgs,
+	RunE: runEC2TagBulk,
+}
+
+func init() {
+	rootCmd.AddCommand(ec2Cmd)
+	ec2Cmd.AddCommand(ec2TagBulkCmd)
+
+	ec2TagBulkCmd.Flags().StringSliceVar(&bulkTags, "tag", nil, "Tag to apply (format: Key=Value, can be used multiple times)")
+	ec2TagBulkCmd.Flags().StringSliceVar(&bulkTagFilters, "filter", nil, "Select instances by tag (format: Key=Value, can be used multiple times)")
+	ec2TagBulkCmd.Flags().BoolVar(&bulkSkipConfirm, "skip-confirm", false, "Skip confirmation prompt")
+	_ = ec2TagBulkCmd.MarkFlagRequired("tag")
+	_ = ec2TagBulkCmd.MarkFlagRequired("filter")
+}
+
+func runEC2TagBulk(_ *cobra.Command, _ []string) error {
+	tags, err := parseTagPairs(bulkTags, "--tag")
+	if err != nil {
+		return err
+	}
+	if err := validateTags(tags); err != nil {
+		return err
+	}
+	filters, err := parseTagPairs(bulkTagFilters, "--filter")
+	if err != nil {
+		return err
+	}
+
+	// Create a context that can be cancelled with Ctrl+C
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	client, err := aws.NewClient(ctx, region, profile, configPath)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client: %w", err)
+	}
+
+	instances, err := client.ListInstances(ctx, filters)
+	if err != nil {
+		return fmt.Errorf("failed to resolve instances: %w", err)
+	}
+	if len(instances) == 0 {
+		fmt.Println("No instances matched the filters")
+		return nil
+	}
+
+	instanceIDs := make([]string, 0, len(instances))
+	for _, inst := range instances {
+		instanceIDs = append(instanceIDs, inst.InstanceID)
+	}
+
+	showBlastRadius(ctx, client, taggingBlastRadius(instanceIDs, tags))
+	if !bulkSkipConfirm && !dryRun {
+		p := newLinePrompter(os.Stdin, os.Stdout)
+		confirmed, err := p.Confirm(fmt.Sprintf("Apply %d tag(s) to %d instance(s)?", len(tags), len(instanceIDs)), false)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Tagging cancelled")
+			return nil
+		}
+	}
+
+	return executeTagBulk(ctx, client, client.GetRegion(), instanceIDs, tags)
+}
+
+// executeTagBulk applies tags to the instances and reports the result of each
+func executeTagBulk(ctx context.Context, client resourceMutator, awsRegion string, instanceIDs []string, tags map[string]string) error {
+	if reportDryRun("apply %s to %d instance(s): %s", formatTagPairs(tags), len(instanceIDs), strings.Join(instanceIDs, ", ")) {
+		return nil
+	}
+
+	results := client.TagInstances(ctx, instanceIDs, tags)
+
+	var succeeded []string
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+			fmt.Printf("  ✗ %s: %v\n", result.InstanceID, result.Err)
+			continue
+		}
+		succeeded = append(succeeded, result.InstanceID)
+		fmt.Printf("  ✓ %s\n", result.InstanceID)
+	}
+
+	invalidateInstanceCache(awsRegion, succeeded)
+
+	fmt.Printf("\nTagged %d of %d instance(s)\n", len(succeeded), len(results))
+	if failed > 0 {
+		return fmt.Errorf("failed to tag %d instance(s)", failed)
+	}
+	return nil
+}
+
+// invalidateInstanceCache drops cached entries that reference the given instances
+func invalidateInstanceCache(awsRegion string, instanceIDs []string) {
+	if len(instanceIDs) == 0 {
+		return
+	}
+	svc, err := newCacheServiceFromConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to open cache for invalidation: %v\n", err)
+		return
+	}
+	if _, err := svc.InvalidateInstances(awsRegion, instanceIDs); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to invalidate cache: %v\n", err)
+	}
+}
+
+// parseTagPairs parses Key=Value flag values into a map
+func parseTagPairs(values []string, flagName string) (map[string]string, error) {
+	pairs := make(map[string]string, len(values))
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, usageErrorf("invalid %s format: %s (expected Key=Value)", flagName, value)
+		}
+		pairs[strings.TrimSpace(parts[0])] = parts[1]
+	}
+	return pairs, nil
+}
+
+// validateTags checks tag keys and values against AWS tagging rules
+func validateTags(tags map[string]string) error {
+	validator := &validation.TagValidator{}
+	for key, value := range tags {
+		if strings.HasPrefix(strings.ToLower(key), "aws:") {
+			return fmt.Errorf("invalid tag %s: the aws: prefix is reserved", key)
+		}
+		if result := validator.Validate(key, value); !result.Valid {
+			return fmt.Errorf("invalid tag %s=%s: %s", key, value, strings.Join(result.Errors, "; "))
+		}
+	}
+	return nil
+}
+
+// formatTagPairs renders tags as a stable, comma-separated Key=Value list
+func formatTagPairs(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+tags[k])
+	}
+	return strings.Join(parts, ", ")
+}
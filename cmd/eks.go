@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
@@ -43,9 +44,15 @@ Examples:
 
 func init() {
 	rootCmd.AddCommand(eksCmd)
+	addReuseLastFlag(eksCmd, "cluster")
 }
 
 func runEKS(_ *cobra.Command, args []string) error {
+	args, err := applyReuseLast(args, reuseLast, lastClusterName)
+	if err != nil {
+		return err
+	}
+
 	// Create a context that can be cancelled with Ctrl+C
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
@@ -82,7 +89,10 @@ func runEKS(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to describe EKS cluster: %w", err)
 	}
 
+	rememberCluster(cluster.Name, client.GetRegion())
+
 	// Display cluster information
+	cluster.Tags = sensitiveTagMask(client).Apply(cluster.Tags)
 	displayClusterInfo(cluster)
 
 	return nil
@@ -99,9 +109,8 @@ func selectEKSClusterInteractive(ctx context.Context, client *aws.Client) (*aws.
 
 	if err != nil {
 		// Check if it's a context cancellation (Ctrl+C)
-		if err == context.Canceled {
-			printSelectionCancelled()
-			return nil, nil
+		if errors.Is(err, context.Canceled) {
+			return nil, errCancelled
 		}
 		return nil, fmt.Errorf("failed to select EKS cluster: %w", err)
 	}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/johnlam90/aws-ssm/pkg/kubeconfig"
+	"github.com/spf13/cobra"
+)
+
+var (
+	kubeconfigPath  string
+	kubeconfigMerge bool
+	kubeconfigAlias string
+)
+
+var eksKubeconfigCmd = &cobra.Command{
+	Use:   "kubeconfig [cluster-name]",
+	Short: "Write a kubeconfig entry for an EKS cluster",
+	Long: `Write a kubeconfig entry for an EKS cluster, like 'aws eks update-kubeconfig'.
+
+The entry authenticates with an exec credential plugin that runs
+'aws eks get-token', using the current --profile when one is set.
+By default the entry is merged into the existing kubeconfig and made the
+current context; --merge=false replaces the file.
+
+Examples:
+  # Pick a cluster interactively and merge it into ~/.kube/config
+  aws-ssm eks kubeconfig
+
+  # Write a specific cluster to its own file
+  aws-ssm eks kubeconfig my-cluster --kubeconfig ./prod.kubeconfig --merge=false
+
+  # Name the context instead of using the cluster ARN
+  aws-ssm eks kubeconfig my-cluster --alias prod`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runEKSKubeconfig,
+}
+
+func init() {
+	eksCmd.AddCommand(eksKubeconfigCmd)
+	eksKubeconfigCmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", "", "Kubeconfig file to write (default: first entry of $KUBECONFIG, or ~/.kube/config)")
+	eksKubeconfigCmd.Flags().BoolVar(&kubeconfigMerge, "merge", true, "Merge into the existing kubeconfig; --merge=false overwrites it")
+	eksKubeconfigCmd.Flags().StringVar(&kubeconfigAlias, "alias", "", "Context name (default: the cluster ARN)")
+	addReuseLastFlag(eksKubeconfigCmd, "cluster")
+}
+
+func runEKSKubeconfig(_ *cobra.Command, args []string) error {
+	args, err := applyReuseLast(args, reuseLast, lastClusterName)
+	if err != nil {
+		return err
+	}
+
+	// Create a context that can be cancelled with Ctrl+C
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	client, err := aws.NewClient(ctx, region, profile, configPath)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client: %w", err)
+	}
+
+	var clusterName string
+	if len(args) > 0 {
+		clusterName = args[0]
+	} else {
+		cluster, selectionErr := selectEKSClusterInteractive(ctx, client)
+		if selectionErr != nil {
+			return fmt.Errorf("failed to select EKS cluster: %w", selectionErr)
+		}
+		if cluster == nil {
+			fmt.Println("No cluster selected")
+			return nil
+		}
+		clusterName = cluster.Name
+	}
+
+	cluster, err := client.DescribeClusterBasic(ctx, clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to describe EKS cluster: %w", err)
+	}
+	rememberCluster(cluster.Name, client.GetRegion())
+
+	path := kubeconfigPath
+	if path == "" {
+		if path, err = kubeconfig.DefaultPath(); err != nil {
+			return err
+		}
+	}
+	opts := kubeconfig.Options{Profile: profile, Alias: kubeconfigAlias}
+	if err := kubeconfig.Write(path, kubeconfigCluster(cluster, client.GetRegion()), opts, kubeconfigMerge); err != nil {
+		return err
+	}
+
+	action := "Added"
+	if !kubeconfigMerge {
+		action = "Wrote"
+	}
+	fmt.Printf("%s context for %s to %s\n", action, cluster.Name, path)
+	return nil
+}
+
+// kubeconfigCluster returns the details of cluster a kubeconfig entry needs
+func kubeconfigCluster(cluster *aws.Cluster, region string) kubeconfig.Cluster {
+	return kubeconfig.Cluster{
+		Name:     cluster.Name,
+		ARN:      cluster.ARN,
+		Endpoint: cluster.Endpoint,
+		CAData:   cluster.CertificateAuthority.Data,
+		Region:   region,
+	}
+}
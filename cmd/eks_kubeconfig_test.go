@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/johnlam90/aws-ssm/pkg/kubeconfig"
+)
+
+func TestKubeconfigClusterFromDescribedCluster(t *testing.T) {
+	cluster := &aws.Cluster{
+		Name:                 "prod",
+		ARN:                  "arn:aws:eks:eu-west-1:123456789012:cluster/prod",
+		Endpoint:             "https://ABC.eu-west-1.eks.amazonaws.com",
+		CertificateAuthority: aws.CertificateAuthority{Data: "Y2E="},
+	}
+
+	path := filepath.Join(t.TempDir(), "config")
+	if err := kubeconfig.Write(path, kubeconfigCluster(cluster, "eu-west-1"), kubeconfig.Options{}, true); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	cfg, err := kubeconfig.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cfg.Clusters) != 1 || cfg.Clusters[0].Name != cluster.ARN ||
+		cfg.Clusters[0].Cluster.Server != cluster.Endpoint || cfg.Clusters[0].Cluster.CertificateAuthorityData != "Y2E=" {
+		t.Errorf("clusters = %+v, want the described endpoint and CA", cfg.Clusters)
+	}
+	if cfg.CurrentContext != cluster.ARN {
+		t.Errorf("current-context = %q, want %q", cfg.CurrentContext, cluster.ARN)
+	}
+}
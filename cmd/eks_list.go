@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"sync"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/spf13/cobra"
+)
+
+// eksListConcurrency bounds the clusters described at once by eks list
+const eksListConcurrency = 4
+
+var eksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List EKS clusters in the region",
+	Long: `List the EKS clusters in the current region with their status, Kubernetes
+version, platform version and number of managed node groups.
+
+Examples:
+  # Table output
+  aws-ssm eks list
+
+  # Machine-readable output, e.g. to inventory clusters in CI
+  aws-ssm eks list --region eu-west-1 --output json
+  aws-ssm eks list --output yaml
+
+JSON and YAML output is a list of clusters, each with the fields name, status,
+version, platform_version, node_group_count and region. The table honors
+--max-rows; structured output always lists every cluster.`,
+	Args: cobra.NoArgs,
+	RunE: runEKSList,
+}
+
+func init() {
+	eksCmd.AddCommand(eksListCmd)
+}
+
+// eksClusterSummary is one cluster in eks list output. Its JSON and YAML
+// field names are the documented eks list schema, so renaming one is a
+// breaking change for scripts.
+type eksClusterSummary struct {
+	Name            string `json:"name"`
+	Status          string `json:"status"`
+	Version         string `json:"version"`
+	PlatformVersion string `json:"platform_version"`
+	NodeGroupCount  int    `json:"node_group_count"`
+	Region          string `json:"region"`
+}
+
+func runEKSList(_ *cobra.Command, _ []string) error {
+	if err := validateTableOutputFlags(); err != nil {
+		return err
+	}
+
+	// Create a context that can be cancelled with Ctrl+C
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	client, err := aws.NewClient(ctx, region, profile, configPath)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client: %w", err)
+	}
+
+	names, err := client.ListClusters(ctx)
+	if err != nil {
+		return err
+	}
+	clusters, err := collectEKSClusters(ctx, names, func(ctx context.Context, name string) (eksClusterSummary, error) {
+		cluster, err := client.DescribeClusterBasic(ctx, name)
+		if err != nil {
+			return eksClusterSummary{}, err
+		}
+		nodeGroups, err := client.ListNodeGroupsForCluster(ctx, name)
+		if err != nil {
+			return eksClusterSummary{}, err
+		}
+		return eksClusterSummary{
+			Name:            cluster.Name,
+			Status:          cluster.Status,
+			Version:         cluster.Version,
+			PlatformVersion: cluster.PlatformVersion,
+			NodeGroupCount:  len(nodeGroups),
+			Region:          client.GetRegion(),
+		}, nil
+	})
+	if err != nil {
+		return err
+	}
+	return writeEKSClusterList(os.Stdout, outputFormat, clusters, tableMaxRows(client.AppConfig))
+}
+
+// collectEKSClusters describes every named cluster, at most
+// eksListConcurrency at a time, and returns them sorted by name. The first
+// failure is returned.
+func collectEKSClusters(ctx context.Context, names []string, describe func(context.Context, string) (eksClusterSummary, error)) ([]eksClusterSummary, error) {
+	clusters := make([]eksClusterSummary, len(names))
+	errs := make([]error, len(names))
+	sem := make(chan struct{}, eksListConcurrency)
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			case sem <- struct{}{}:
+			}
+			defer func() { <-sem }()
+			clusters[i], errs[i] = describe(ctx, name)
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe EKS cluster %s: %w", names[i], err)
+		}
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].Name < clusters[j].Name })
+	return clusters, nil
+}
+
+// writeEKSClusterList writes clusters to out as JSON, YAML or a table of at
+// most maxRows rows (0 = no cap)
+func writeEKSClusterList(out io.Writer, format string, clusters []eksClusterSummary, maxRows int) error {
+	switch format {
+	case outputFormatJSON:
+		return writeJSON(out, clusters)
+	case outputFormatYAML:
+		return writeYAML(out, clusters)
+	}
+
+	if len(clusters) == 0 {
+		_, err := fmt.Fprintln(out, "No EKS clusters found")
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	if _, err := fmt.Fprintln(w, "NAME\tSTATUS\tVERSION\tPLATFORM VERSION\tNODE GROUPS"); err != nil {
+		return fmt.Errorf("failed to write table header: %w", err)
+	}
+	shown := cappedRows(len(clusters), maxRows)
+	for _, c := range clusters[:shown] {
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			c.Name,
+			valueOrDash(c.Status),
+			valueOrDash(c.Version),
+			valueOrDash(c.PlatformVersion),
+			strconv.Itoa(c.NodeGroupCount),
+		); err != nil {
+			return fmt.Errorf("failed to write table row: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to flush table writer: %w", err)
+	}
+	return writeTruncationFooter(out, shown, len(clusters))
+}
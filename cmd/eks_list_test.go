@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+var testEKSClusters = map[string]eksClusterSummary{
+	"prod":    {Name: "prod", Status: "ACTIVE", Version: "1.30", PlatformVersion: "eks.8", NodeGroupCount: 3, Region: "us-east-1"},
+	"staging": {Name: "staging", Status: "UPDATING", Version: "1.29", PlatformVersion: "eks.12", NodeGroupCount: 1, Region: "us-east-1"},
+	"new":     {Name: "new", Status: "CREATING", Region: "us-east-1"},
+}
+
+// describeTestEKSCluster returns the summary of a mock cluster
+func describeTestEKSCluster(_ context.Context, name string) (eksClusterSummary, error) {
+	cluster, ok := testEKSClusters[name]
+	if !ok {
+		return eksClusterSummary{}, errors.New("ResourceNotFoundException")
+	}
+	return cluster, nil
+}
+
+func TestCollectEKSClustersSortsByName(t *testing.T) {
+	clusters, err := collectEKSClusters(context.Background(), []string{"staging", "prod", "new"}, describeTestEKSCluster)
+	if err != nil {
+		t.Fatalf("collectEKSClusters() error = %v", err)
+	}
+	var names []string
+	for _, c := range clusters {
+		names = append(names, c.Name)
+	}
+	if strings.Join(names, ",") != "new,prod,staging" {
+		t.Errorf("clusters = %v, want sorted by name", names)
+	}
+
+	if _, err := collectEKSClusters(context.Background(), []string{"prod", "gone"}, describeTestEKSCluster); err == nil || !strings.Contains(err.Error(), "gone") {
+		t.Errorf("collectEKSClusters() error = %v, want the failing cluster named", err)
+	}
+}
+
+func TestWriteEKSClusterListTable(t *testing.T) {
+	clusters, err := collectEKSClusters(context.Background(), []string{"prod", "staging", "new"}, describeTestEKSCluster)
+	if err != nil {
+		t.Fatalf("collectEKSClusters() error = %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := writeEKSClusterList(&out, "", clusters, 0); err != nil {
+		t.Fatalf("writeEKSClusterList() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("table has %d lines, want header + 3:\n%s", len(lines), out.String())
+	}
+	for _, column := range []string{"NAME", "STATUS", "VERSION", "PLATFORM VERSION", "NODE GROUPS"} {
+		if !strings.Contains(lines[0], column) {
+			t.Errorf("header %q missing %s", lines[0], column)
+		}
+	}
+	if fields := strings.Fields(lines[2]); !reflect.DeepEqual(fields, []string{"prod", "ACTIVE", "1.30", "eks.8", "3"}) {
+		t.Errorf("prod row = %v", fields)
+	}
+	if fields := strings.Fields(lines[1]); !reflect.DeepEqual(fields, []string{"new", "CREATING", "-", "-", "0"}) {
+		t.Errorf("new row = %v, want dashes for missing versions", fields)
+	}
+
+	out.Reset()
+	if err := writeEKSClusterList(&out, outputFormatTable, nil, 0); err != nil || !strings.Contains(out.String(), "No EKS clusters") {
+		t.Errorf("empty table = %q, %v", out.String(), err)
+	}
+}
+
+func TestWriteEKSClusterListStructured(t *testing.T) {
+	clusters := []eksClusterSummary{testEKSClusters["prod"], testEKSClusters["staging"]}
+
+	var out bytes.Buffer
+	if err := writeEKSClusterList(&out, outputFormatJSON, clusters, 0); err != nil {
+		t.Fatalf("writeEKSClusterList(json) error = %v", err)
+	}
+	var fromJSON []eksClusterSummary
+	if err := json.Unmarshal(out.Bytes(), &fromJSON); err != nil {
+		t.Fatalf("JSON output does not unmarshal: %v\n%s", err, out.String())
+	}
+	if !reflect.DeepEqual(fromJSON, clusters) {
+		t.Errorf("JSON round trip = %+v, want %+v", fromJSON, clusters)
+	}
+
+	out.Reset()
+	if err := writeEKSClusterList(&out, outputFormatYAML, clusters, 0); err != nil {
+		t.Fatalf("writeEKSClusterList(yaml) error = %v", err)
+	}
+	var fromYAML []map[string]interface{}
+	if err := yaml.Unmarshal(out.Bytes(), &fromYAML); err != nil {
+		t.Fatalf("YAML output does not unmarshal: %v\n%s", err, out.String())
+	}
+	if len(fromYAML) != 2 || fromYAML[0]["platform_version"] != "eks.8" || fromYAML[0]["node_group_count"] != 3 {
+		t.Errorf("YAML output = %+v", fromYAML)
+	}
+}
+
+func TestWriteEKSClusterListGolden(t *testing.T) {
+	origSelect := selectExpr
+	defer func() { selectExpr = origSelect }()
+	selectExpr = ""
+
+	clusters, err := collectEKSClusters(context.Background(), []string{"prod", "staging", "new"}, describeTestEKSCluster)
+	if err != nil {
+		t.Fatalf("collectEKSClusters() error = %v", err)
+	}
+
+	for _, format := range []string{outputFormatTable, outputFormatJSON, outputFormatYAML} {
+		t.Run(format, func(t *testing.T) {
+			want, err := os.ReadFile(filepath.Join("testdata", "eks_list."+format+".golden"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var out bytes.Buffer
+			if err := writeEKSClusterList(&out, format, clusters, 0); err != nil {
+				t.Fatalf("writeEKSClusterList() error = %v", err)
+			}
+			if out.String() != string(want) {
+				t.Errorf("%s output differs from the golden file:\ngot:\n%s\nwant:\n%s", format, out.String(), want)
+			}
+		})
+	}
+}
+
+func TestWriteEKSClusterListMaxRows(t *testing.T) {
+	clusters, err := collectEKSClusters(context.Background(), []string{"prod", "staging", "new"}, describeTestEKSCluster)
+	if err != nil {
+		t.Fatalf("collectEKSClusters() error = %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := writeEKSClusterList(&out, outputFormatTable, clusters, 2); err != nil {
+		t.Fatalf("writeEKSClusterList() error = %v", err)
+	}
+	if strings.Contains(out.String(), "staging") || !strings.Contains(out.String(), "Showing 2 of 3") {
+		t.Errorf("capped table = %q, want two rows and a truncation footer", out.String())
+	}
+
+	out.Reset()
+	if err := writeEKSClusterList(&out, outputFormatJSON, clusters, 2); err != nil {
+		t.Fatalf("writeEKSClusterList(json) error = %v", err)
+	}
+	if !strings.Contains(out.String(), "staging") {
+		t.Errorf("JSON output = %q, want every cluster regardless of --max-rows", out.String())
+	}
+}
@@ -2,9 +2,11 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	awsconfig "github.com/aws/aws-sdk-go-v2/aws"
@@ -20,6 +22,8 @@ var (
 	desiredSize           int32
 	skipConfirm           bool
 	launchTemplateVersion string
+	ngCapacityType        string
+	ngInstanceFamily      string
 )
 
 var eksNodeGroupCmd = &cobra.Command{
@@ -38,6 +42,9 @@ Examples:
   # Scale with custom min/max/desired
   aws-ssm eks nodegroup scale my-cluster --nodegroup my-ng --min 1 --max 5 --desired 3
 
+  # Only offer spot node groups running m5 instances
+  aws-ssm eks nodegroup scale my-cluster --capacity-type SPOT --instance-family m5
+
   # Using 'ng' alias
   aws-ssm eks ng scale my-cluster --desired 2`,
 }
@@ -106,20 +113,31 @@ func init() {
 	eksNodeGroupCmd.AddCommand(scaleCmd)
 	eksNodeGroupCmd.AddCommand(updateLTCmd)
 
+	// Node group filters apply to every node group subcommand
+	eksNodeGroupCmd.PersistentFlags().StringVar(&ngCapacityType, "capacity-type", "", "Only target node groups with this capacity type (ON_DEMAND, SPOT or CAPACITY_BLOCK)")
+	eksNodeGroupCmd.PersistentFlags().StringVar(&ngInstanceFamily, "instance-family", "", "Only target node groups with an instance type in this family (e.g. m5)")
+
 	// Scale command flags
 	scaleCmd.Flags().StringVar(&nodeGroupName, "nodegroup", "", "Node group name (if not provided, interactive selection will be used)")
 	scaleCmd.Flags().Int32Var(&minSize, "min", -1, "Minimum size (optional - defaults to current or desired)")
 	scaleCmd.Flags().Int32Var(&maxSize, "max", -1, "Maximum size (optional - defaults to current or desired)")
 	scaleCmd.Flags().Int32Var(&desiredSize, "desired", -1, "Desired size (required when cluster is specified)")
 	scaleCmd.Flags().BoolVar(&skipConfirm, "skip-confirm", false, "Skip confirmation prompt")
+	addReuseLastFlag(scaleCmd, "cluster")
 
 	// Update launch template command flags
 	updateLTCmd.Flags().StringVar(&nodeGroupName, "nodegroup", "", "Node group name (if not provided, interactive selection will be used)")
 	updateLTCmd.Flags().StringVar(&launchTemplateVersion, "version", "", "Launch template version (if not provided, interactive selection will be used)")
 	updateLTCmd.Flags().BoolVar(&skipConfirm, "skip-confirm", false, "Skip confirmation prompt")
+	addReuseLastFlag(updateLTCmd, "cluster")
 }
 
 func runScale(_ *cobra.Command, args []string) error {
+	args, err := applyReuseLast(args, reuseLast, lastClusterName)
+	if err != nil {
+		return err
+	}
+
 	// Create a context that can be cancelled with Ctrl+C
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
@@ -130,11 +148,6 @@ func runScale(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create AWS client: %w", err)
 	}
 
-	// Validate required flags for CLI mode
-	if len(args) > 0 && desiredSize == -1 {
-		return fmt.Errorf("--desired flag is required when cluster name is provided")
-	}
-
 	// For CLI mode (non-interactive), run once without loop
 	if len(args) > 0 {
 		_, err := runScaleOnce(ctx, client, args)
@@ -176,8 +189,14 @@ func runScaleOnce(ctx context.Context, client *aws.Client, args []string) (bool,
 		return false, fmt.Errorf("failed to describe node group: %w", err)
 	}
 
+	// Prefill defaults from the node group's scale:* tags when --desired is omitted
+	defaults := applyScalingTagDefaults(ng.Tags, ScalingParameters{Min: minSize, Max: maxSize, Desired: desiredSize})
+	if len(args) > 0 && defaults.Desired == -1 {
+		return false, usageErrorf("--desired flag is required when cluster name is provided (or tag the node group with %s)", scaleTagDesired)
+	}
+
 	// Calculate final scaling parameters
-	shouldRetry, finalParams, err := calculateFinalParametersWithRetry(ng, minSize, maxSize, desiredSize, len(args))
+	shouldRetry, finalParams, err := calculateFinalParametersWithRetry(ng, defaults.Min, defaults.Max, desiredSize, defaults.Desired, len(args))
 	if err != nil {
 		return false, err
 	}
@@ -194,6 +213,7 @@ func runScaleOnce(ctx context.Context, client *aws.Client, args []string) (bool,
 
 	// Display configuration
 	displayScalingConfiguration(clusterName, resolvedNodeGroupName, ng, finalParams)
+	showBlastRadius(ctx, client, scalingBlastRadius("scale node group", clusterName+"/"+resolvedNodeGroupName, ng.DesiredSize, finalParams.Desired).withARN(nodeGroupIdentifier(clusterName, ng)))
 
 	// Confirm action
 	shouldRetry, confirmed := confirmScalingActionWithRetry()
@@ -243,17 +263,26 @@ func resolveClusterName(ctx context.Context, client *aws.Client, args []string)
 	if cluster == nil {
 		return "", fmt.Errorf("no cluster selected")
 	}
+	rememberCluster(cluster.Name, client.GetRegion())
 	return cluster.Name, nil
 }
 
 // resolveNodeGroupName gets node group name from flag or interactive selection
 func resolveNodeGroupName(ctx context.Context, client *aws.Client, clusterName string) (string, error) {
+	filter, err := nodeGroupFilterFromFlags()
+	if err != nil {
+		return "", err
+	}
+
 	if nodeGroupName != "" {
+		if err := checkNodeGroupMatchesFilter(ctx, client, clusterName, nodeGroupName, filter); err != nil {
+			return "", err
+		}
 		return nodeGroupName, nil
 	}
 
 	// Interactive selection
-	ng, err := selectNodeGroupInteractive(ctx, client, clusterName)
+	ng, err := selectNodeGroupInteractive(ctx, client, clusterName, filter)
 	if err != nil {
 		return "", fmt.Errorf("failed to select node group: %w", err)
 	}
@@ -263,6 +292,36 @@ func resolveNodeGroupName(ctx context.Context, client *aws.Client, clusterName s
 	return ng.Name, nil
 }
 
+// nodeGroupFilterFromFlags builds the node group filter from --capacity-type
+// and --instance-family
+func nodeGroupFilterFromFlags() (fuzzy.NodeGroupFilter, error) {
+	capacityType, err := fuzzy.ParseCapacityType(ngCapacityType)
+	if err != nil {
+		return fuzzy.NodeGroupFilter{}, err
+	}
+	return fuzzy.NodeGroupFilter{
+		CapacityType:   capacityType,
+		InstanceFamily: strings.TrimSpace(ngInstanceFamily),
+	}, nil
+}
+
+// checkNodeGroupMatchesFilter refuses a node group named with --nodegroup
+// that does not match the node group filters
+func checkNodeGroupMatchesFilter(ctx context.Context, client *aws.Client, clusterName, name string, filter fuzzy.NodeGroupFilter) error {
+	if filter.IsEmpty() {
+		return nil
+	}
+	ng, err := client.DescribeNodeGroupPublic(ctx, clusterName, name)
+	if err != nil {
+		return fmt.Errorf("failed to describe node group: %w", err)
+	}
+	info := fuzzy.NodeGroupInfo{Name: ng.Name, CapacityType: ng.CapacityType, InstanceTypes: ng.InstanceTypes}
+	if !filter.Matches(info) {
+		return fmt.Errorf("node group %s does not match %s", name, filter)
+	}
+	return nil
+}
+
 // ScalingParameters holds the final scaling configuration
 type ScalingParameters struct {
 	Min     int32
@@ -292,19 +351,22 @@ func calculateFinalParameters(ng *aws.NodeGroup, minSize, maxSize, desiredSize i
 }
 
 // calculateFinalParametersWithRetry calculates the final min, max, and desired values
-// Returns (shouldRetry, params, error) where shouldRetry indicates if user wants to select a different nodegroup
-func calculateFinalParametersWithRetry(ng *aws.NodeGroup, minSizeParam, maxSizeParam, desiredSizeParam int32, argCount int) (bool, ScalingParameters, error) {
+// Returns (shouldRetry, params, error) where shouldRetry indicates if user wants to select a different nodegroup.
+// defaultDesired (-1 when unset) is offered at the prompt, or used directly in CLI mode.
+func calculateFinalParametersWithRetry(ng *aws.NodeGroup, minSizeParam, maxSizeParam, desiredSizeParam, defaultDesired int32, argCount int) (bool, ScalingParameters, error) {
 	// Use local variables to avoid shadowing package-level variables
 	finalDesired := desiredSizeParam
 
 	// Prompt for desired size in interactive mode if not provided
 	if argCount == 0 && desiredSizeParam == -1 {
-		shouldRetry := promptForDesiredSizeWithRetry(ng)
+		shouldRetry := promptForDesiredSizeWithRetry(ng, defaultDesired)
 		if shouldRetry {
 			return true, ScalingParameters{}, nil
 		}
 		// After prompting, use the package-level desiredSize variable that was updated
 		finalDesired = desiredSize
+	} else if desiredSizeParam == -1 {
+		finalDesired = defaultDesired
 	}
 
 	// Calculate final values
@@ -335,17 +397,26 @@ func promptForDesiredSize(ng *aws.NodeGroup) {
 }
 
 // promptForDesiredSizeWithRetry prompts user for desired size with retry support
-// Returns true if user wants to retry (select different nodegroup), false otherwise
-func promptForDesiredSizeWithRetry(ng *aws.NodeGroup) bool {
+// Returns true if user wants to retry (select different nodegroup), false otherwise.
+// An empty answer accepts defaultDesired when it is set (not -1).
+func promptForDesiredSizeWithRetry(ng *aws.NodeGroup, defaultDesired int32) bool {
 	fmt.Printf("\nCurrent node group configuration:\n")
 	fmt.Printf("  Min Size:     %d\n", ng.MinSize)
 	fmt.Printf("  Max Size:     %d\n", ng.MaxSize)
 	fmt.Printf("  Desired Size: %d\n", ng.DesiredSize)
 	fmt.Printf("  Current Size: %d\n\n", ng.CurrentSize)
-	fmt.Printf("Enter desired size (or 'back' to select a different nodegroup): ")
+	if defaultDesired != -1 {
+		fmt.Printf("Enter desired size [%d from %s tag] (or 'back' to select a different nodegroup): ", defaultDesired, scaleTagDesired)
+	} else {
+		fmt.Printf("Enter desired size (or 'back' to select a different nodegroup): ")
+	}
 
 	var input string
 	_, err := fmt.Scanln(&input)
+	if input == "" && defaultDesired != -1 {
+		desiredSize = defaultDesired
+		return false
+	}
 	if err != nil {
 		fmt.Printf("Error reading input: %v\n", err)
 		return false
@@ -412,6 +483,7 @@ func displayScalingConfiguration(clusterName, nodeGroupName string, ng *aws.Node
 	fmt.Printf("\n")
 	fmt.Printf("Cluster:       %s\n", clusterName)
 	fmt.Printf("Node Group:    %s\n", nodeGroupName)
+	fmt.Printf("ARN:           %s\n", nodeGroupIdentifier(clusterName, ng))
 	fmt.Printf("\n")
 	fmt.Printf("Current Configuration:\n")
 	fmt.Printf("  Min:         %d\n", ng.MinSize)
@@ -454,7 +526,7 @@ func confirmScalingAction() bool {
 // confirmScalingActionWithRetry prompts for user confirmation with retry support
 // Returns (shouldRetry, confirmed) where shouldRetry indicates if user wants to select a different nodegroup
 func confirmScalingActionWithRetry() (bool, bool) {
-	if skipConfirm {
+	if skipConfirm || dryRun {
 		return false, true
 	}
 
@@ -481,7 +553,10 @@ func confirmScalingActionWithRetry() (bool, bool) {
 }
 
 // executeScaling performs the actual scaling operation
-func executeScaling(ctx context.Context, client *aws.Client, clusterName, nodeGroupName string, params ScalingParameters) error {
+func executeScaling(ctx context.Context, client resourceMutator, clusterName, nodeGroupName string, params ScalingParameters) error {
+	if reportDryRun("scale node group %s/%s to min %d, max %d, desired %d", clusterName, nodeGroupName, params.Min, params.Max, params.Desired) {
+		return nil
+	}
 	fmt.Printf("Scaling node group %s...\n", nodeGroupName)
 
 	err := client.UpdateNodeGroupScaling(ctx, clusterName, nodeGroupName, params.Min, params.Max, params.Desired)
@@ -524,7 +599,7 @@ func (a *nodeGroupClientAdapter) GetConfig() awsconfig.Config {
 }
 
 // selectNodeGroupInteractive displays an interactive fuzzy finder to select a node group
-func selectNodeGroupInteractive(ctx context.Context, client *aws.Client, clusterName string) (*fuzzy.NodeGroupInfo, error) {
+func selectNodeGroupInteractive(ctx context.Context, client *aws.Client, clusterName string, filter fuzzy.NodeGroupFilter) (*fuzzy.NodeGroupInfo, error) {
 	// Show loading message with spinner
 	s := createLoadingSpinner("Loading node groups...")
 	s.Start()
@@ -555,15 +630,14 @@ func selectNodeGroupInteractive(ctx context.Context, client *aws.Client, cluster
 	colors := fuzzy.NewDefaultColorManager(noColor)
 
 	// Create node group finder
-	finder := fuzzy.NewNodeGroupFinder(loader, colors)
+	finder := fuzzy.NewNodeGroupFinder(loader, colors, sensitiveTagMask(client)).WithFilter(filter)
 
 	// Select node group
 	selectedNodeGroup, err := finder.SelectNodeGroupInteractive(ctx, clusterName)
 	if err != nil {
 		// Check if it's a context cancellation (Ctrl+C)
-		if err == context.Canceled {
-			printSelectionCancelled()
-			return nil, nil
+		if errors.Is(err, context.Canceled) {
+			return nil, errCancelled
 		}
 		return nil, fmt.Errorf("failed to select node group: %w", err)
 	}
@@ -598,6 +672,11 @@ func (a *launchTemplateClientAdapter) GetConfig() awsconfig.Config {
 }
 
 func runUpdateLT(_ *cobra.Command, args []string) error {
+	args, err := applyReuseLast(args, reuseLast, lastClusterName)
+	if err != nil {
+		return err
+	}
+
 	// Create a context that can be cancelled with Ctrl+C
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
@@ -666,6 +745,7 @@ func runUpdateLTOnce(ctx context.Context, client *aws.Client, args []string) (bo
 
 	// Display configuration
 	displayLTUpdateConfiguration(clusterName, resolvedNodeGroupName, ng, version)
+	showBlastRadius(ctx, client, launchTemplateBlastRadius(clusterName+"/"+resolvedNodeGroupName, ng.CurrentSize, ng.LaunchTemplate.Version, version).withARN(nodeGroupIdentifier(clusterName, ng)))
 
 	// Confirm action with retry support
 	shouldRetry, confirmed := confirmLTUpdateActionWithRetry()
@@ -753,9 +833,8 @@ func selectLaunchTemplateVersionInteractive(ctx context.Context, client *aws.Cli
 	selectedVersion, err := finder.SelectVersionInteractive(ctx)
 	if err != nil {
 		// Check if it's a context cancellation (Ctrl+C)
-		if err == context.Canceled {
-			printSelectionCancelled()
-			return "", nil
+		if errors.Is(err, context.Canceled) {
+			return "", errCancelled
 		}
 		return "", fmt.Errorf("failed to select launch template version: %w", err)
 	}
@@ -818,9 +897,8 @@ func selectLaunchTemplateVersionInteractiveWithRetry(ctx context.Context, client
 	selectedVersion, err := finder.SelectVersionInteractive(ctx)
 	if err != nil {
 		// Check if it's a context cancellation (Ctrl+C)
-		if err == context.Canceled {
-			printSelectionCancelled()
-			return false, "", nil
+		if errors.Is(err, context.Canceled) {
+			return false, "", errCancelled
 		}
 		return false, "", fmt.Errorf("failed to select launch template version: %w", err)
 	}
@@ -838,6 +916,7 @@ func displayLTUpdateConfiguration(clusterName, nodeGroupName string, ng *aws.Nod
 	fmt.Printf("\n")
 	fmt.Printf("Cluster:                  %s\n", clusterName)
 	fmt.Printf("Node Group:               %s\n", nodeGroupName)
+	fmt.Printf("ARN:                      %s\n", nodeGroupIdentifier(clusterName, ng))
 	fmt.Printf("\n")
 	fmt.Printf("Current Configuration:\n")
 	fmt.Printf("  Launch Template:        %s (%s)\n", ng.LaunchTemplate.Name, ng.LaunchTemplate.ID)
@@ -874,7 +953,7 @@ func confirmLTUpdateAction() bool {
 // confirmLTUpdateActionWithRetry prompts for user confirmation with retry support
 // Returns (shouldRetry, confirmed) where shouldRetry indicates if user wants to select a different nodegroup
 func confirmLTUpdateActionWithRetry() (bool, bool) {
-	if skipConfirm {
+	if skipConfirm || dryRun {
 		return false, true
 	}
 
@@ -900,7 +979,10 @@ func confirmLTUpdateActionWithRetry() (bool, bool) {
 	return false, true
 }
 
-func executeLTUpdate(ctx context.Context, client *aws.Client, clusterName, nodeGroupName, launchTemplateID, version string) error {
+func executeLTUpdate(ctx context.Context, client resourceMutator, clusterName, nodeGroupName, launchTemplateID, version string) error {
+	if reportDryRun("update node group %s/%s to launch template %s version %s", clusterName, nodeGroupName, launchTemplateID, version) {
+		return nil
+	}
 	fmt.Printf("Updating launch template version for node group %s...\n", nodeGroupName)
 
 	err := client.UpdateNodeGroupLaunchTemplate(ctx, clusterName, nodeGroupName, launchTemplateID, version)
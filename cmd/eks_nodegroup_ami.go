@@ -0,0 +1,313 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/johnlam90/aws-ssm/pkg/ui/fuzzy"
+	"github.com/spf13/cobra"
+)
+
+var (
+	amiReleaseVersion string
+	amiUpdateAll      bool
+)
+
+var updateAMICmd = &cobra.Command{
+	Use:   "update-ami [cluster-name]",
+	Short: "Update the AMI release version of an EKS node group",
+	Long: `Update an EKS managed node group to a different EKS optimized AMI release version.
+
+If the cluster name or node group name is not provided, an interactive fuzzy finder
+will be displayed to select them. If the release version is not provided, the
+available releases for the node group's AMI type and Kubernetes version are listed
+for selection.
+
+Examples:
+  # Interactive selection
+  aws-ssm eks nodegroup update-ami my-cluster
+
+  # Update a specific node group to a specific release
+  aws-ssm eks nodegroup update-ami my-cluster --nodegroup my-ng --release-version 1.29.0-20240213
+
+  # Skip confirmation prompt
+  aws-ssm eks ng update-ami my-cluster --nodegroup my-ng --release-version 1.29.0-20240213 --skip-confirm
+
+  # Move every on-demand node group to its newest release, confirming once
+  aws-ssm eks nodegroup update-ami my-cluster --all --capacity-type on-demand`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runUpdateAMI,
+}
+
+func init() {
+	eksNodeGroupCmd.AddCommand(updateAMICmd)
+
+	updateAMICmd.Flags().StringVar(&nodeGroupName, "nodegroup", "", "Node group name (if not provided, interactive selection will be used)")
+	updateAMICmd.Flags().StringVar(&amiReleaseVersion, "release-version", "", "AMI release version (if not provided, interactive selection will be used)")
+	updateAMICmd.Flags().BoolVar(&skipConfirm, "skip-confirm", false, "Skip confirmation prompt")
+	updateAMICmd.Flags().BoolVar(&amiUpdateAll, "all", false, "Update every node group matching --capacity-type and --instance-family, to --release-version or each group's newest release")
+	addReuseLastFlag(updateAMICmd, "cluster")
+}
+
+func runUpdateAMI(_ *cobra.Command, args []string) error {
+	args, err := applyReuseLast(args, reuseLast, lastClusterName)
+	if err != nil {
+		return err
+	}
+
+	// Create a context that can be cancelled with Ctrl+C
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if amiUpdateAll && nodeGroupName != "" {
+		return usageErrorf("--all cannot be combined with --nodegroup")
+	}
+
+	client, err := aws.NewClient(ctx, region, profile, configPath)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client: %w", err)
+	}
+
+	if amiUpdateAll {
+		return runUpdateAMIAll(ctx, client, args)
+	}
+
+	clusterName, resolvedNodeGroupName, err := resolveClusterAndNodeGroup(ctx, client, args)
+	if err != nil {
+		return err
+	}
+	if resolvedNodeGroupName == "" {
+		return nil
+	}
+
+	ng, err := client.DescribeNodeGroupPublic(ctx, clusterName, resolvedNodeGroupName)
+	if err != nil {
+		return fmt.Errorf("failed to describe node group: %w", err)
+	}
+	if ng.AMIType == "CUSTOM" {
+		return fmt.Errorf("node group %s uses a custom AMI from its launch template; use update-lt instead", resolvedNodeGroupName)
+	}
+
+	version, err := resolveAMIReleaseVersion(ctx, client, ng)
+	if err != nil {
+		return err
+	}
+	if version == "" {
+		// User pressed ESC at the version selector
+		return nil
+	}
+	if version == ng.ReleaseVersion {
+		fmt.Printf("Node group %s is already on release version %s\n", resolvedNodeGroupName, version)
+		return nil
+	}
+
+	displayAMIUpdateConfiguration(clusterName, resolvedNodeGroupName, ng, version)
+	showBlastRadius(ctx, client, amiReleaseBlastRadius(clusterName+"/"+resolvedNodeGroupName, ng.CurrentSize, ng.ReleaseVersion, version).withARN(nodeGroupIdentifier(clusterName, ng)))
+
+	if !confirmAMIUpdateAction() {
+		return nil
+	}
+
+	return executeAMIUpdate(ctx, client, clusterName, resolvedNodeGroupName, version)
+}
+
+// resolveAMIReleaseVersion returns the --release-version flag, or lets the user
+// pick one of the releases available for the node group
+func resolveAMIReleaseVersion(ctx context.Context, client *aws.Client, ng *aws.NodeGroup) (string, error) {
+	if amiReleaseVersion != "" {
+		return amiReleaseVersion, nil
+	}
+	if !interactiveSelectionAllowed() {
+		return "", usageErrorf("--release-version is required when running non-interactively")
+	}
+
+	fmt.Println()
+	s := createLoadingSpinner("Loading AMI release versions...")
+	s.Start()
+	versions, err := client.ListNodeGroupReleaseVersions(ctx, ng.AMIType, ng.Version)
+	s.Stop()
+
+	if err != nil {
+		return "", fmt.Errorf("failed to list AMI release versions: %w", err)
+	}
+	if len(versions) == 0 {
+		return "", fmt.Errorf("no AMI release versions found for %s on Kubernetes %s", ng.AMIType, ng.Version)
+	}
+
+	printInteractivePrompt("AMI release version selector")
+	fmt.Printf("\nCurrent release version: %s\n\n", valueOrDash(ng.ReleaseVersion))
+
+	selected, err := fuzzy.SelectReleaseVersion(ctx, versions, ng.ReleaseVersion)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return "", errCancelled
+		}
+		return "", fmt.Errorf("failed to select AMI release version: %w", err)
+	}
+	return selected, nil
+}
+
+// runUpdateAMIAll updates every matching node group in the cluster as one step
+// plan: the rollout is listed and confirmed once, then stops at the first failure
+func runUpdateAMIAll(ctx context.Context, client *aws.Client, args []string) error {
+	clusterName, err := resolveClusterName(ctx, client, args)
+	if err != nil {
+		return err
+	}
+	filter, err := nodeGroupFilterFromFlags()
+	if err != nil {
+		return err
+	}
+
+	names, err := client.ListNodeGroupsForCluster(ctx, clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to list node groups: %w", err)
+	}
+	var groups []*aws.NodeGroup
+	for _, name := range names {
+		ng, err := client.DescribeNodeGroupPublic(ctx, clusterName, name)
+		if err != nil {
+			return fmt.Errorf("failed to describe node group %s: %w", name, err)
+		}
+		if filter.Matches(fuzzy.NodeGroupInfo{Name: ng.Name, CapacityType: ng.CapacityType, InstanceTypes: ng.InstanceTypes}) {
+			groups = append(groups, ng)
+		}
+	}
+
+	steps, nodes, err := planAMIUpdateSteps(os.Stdout, client, clusterName, groups, func(ng *aws.NodeGroup) (string, error) {
+		return targetAMIReleaseVersion(ctx, client, ng)
+	})
+	if err != nil {
+		return err
+	}
+	if len(steps) == 0 {
+		fmt.Println("No matching node group needs an AMI release update")
+		return nil
+	}
+
+	showBlastRadius(ctx, client, blastRadius{
+		Operation:         "update AMI release versions",
+		Resource:          fmt.Sprintf("%s (%d node groups)", clusterName, len(steps)),
+		InstancesAffected: int(nodes),
+		Notes:             []string{"Nodes in each node group are replaced by a rolling update"},
+	})
+
+	runner := &stepRunner{
+		out:         os.Stdout,
+		prompt:      newLinePrompter(os.Stdin, os.Stdout),
+		skipConfirm: skipConfirm || dryRun,
+	}
+	_, err = runner.Run(ctx, "Update AMI release versions in cluster "+clusterName, steps)
+	return err
+}
+
+// targetAMIReleaseVersion returns --release-version, or the newest release
+// available for the node group's AMI type and Kubernetes version
+func targetAMIReleaseVersion(ctx context.Context, client *aws.Client, ng *aws.NodeGroup) (string, error) {
+	if amiReleaseVersion != "" {
+		return amiReleaseVersion, nil
+	}
+	versions, err := client.ListNodeGroupReleaseVersions(ctx, ng.AMIType, ng.Version)
+	if err != nil {
+		return "", fmt.Errorf("failed to list AMI release versions for node group %s: %w", ng.Name, err)
+	}
+	if len(versions) == 0 {
+		return "", fmt.Errorf("no AMI release versions found for node group %s (%s on Kubernetes %s)", ng.Name, ng.AMIType, ng.Version)
+	}
+	return versions[0], nil
+}
+
+// planAMIUpdateSteps returns one step per node group that is not already on
+// its target release, and the number of nodes they replace. Node groups with a
+// custom AMI are reported on out and left out of the plan.
+func planAMIUpdateSteps(out io.Writer, client resourceMutator, clusterName string, groups []*aws.NodeGroup, target func(*aws.NodeGroup) (string, error)) ([]planStep, int32, error) {
+	var steps []planStep
+	var nodes int32
+	for _, ng := range groups {
+		if ng.AMIType == "CUSTOM" {
+			fmt.Fprintf(out, "Skipping node group %s: it uses a custom AMI from its launch template; use update-lt instead\n", ng.Name)
+			continue
+		}
+		version, err := target(ng)
+		if err != nil {
+			return nil, 0, err
+		}
+		if version == ng.ReleaseVersion {
+			fmt.Fprintf(out, "Node group %s is already on release version %s\n", ng.Name, version)
+			continue
+		}
+
+		name := ng.Name
+		steps = append(steps, planStep{
+			Name: fmt.Sprintf("update %s from %s to %s", name, valueOrDash(ng.ReleaseVersion), version),
+			Run: func(ctx context.Context) error {
+				return executeAMIUpdate(ctx, client, clusterName, name, version)
+			},
+		})
+		nodes += ng.CurrentSize
+	}
+	return steps, nodes, nil
+}
+
+func displayAMIUpdateConfiguration(clusterName, nodeGroupName string, ng *aws.NodeGroup, version string) {
+	fmt.Printf("\n")
+	fmt.Printf("Cluster:                  %s\n", clusterName)
+	fmt.Printf("Node Group:               %s\n", nodeGroupName)
+	fmt.Printf("ARN:                      %s\n", nodeGroupIdentifier(clusterName, ng))
+	fmt.Printf("\n")
+	fmt.Printf("Current Configuration:\n")
+	fmt.Printf("  AMI Type:               %s\n", valueOrDash(ng.AMIType))
+	fmt.Printf("  Release Version:        %s\n", valueOrDash(ng.ReleaseVersion))
+	fmt.Printf("\n")
+	fmt.Printf("Target Configuration:\n")
+	fmt.Printf("  New Release Version:    %s\n", version)
+	fmt.Printf("\n")
+}
+
+// confirmAMIUpdateAction prompts for confirmation unless --skip-confirm is set
+func confirmAMIUpdateAction() bool {
+	if skipConfirm || dryRun {
+		return true
+	}
+
+	fmt.Printf("⚠️  Are you sure you want to update the AMI release version? (yes/no): ")
+	var response string
+	_, err := fmt.Scanln(&response)
+	if err != nil {
+		fmt.Printf("Error reading input: %v\n", err)
+		return false
+	}
+
+	if response != "yes" && response != "y" {
+		fmt.Println("Operation cancelled.")
+		return false
+	}
+
+	fmt.Printf("\n")
+	return true
+}
+
+func executeAMIUpdate(ctx context.Context, client resourceMutator, clusterName, nodeGroupName, version string) error {
+	if reportDryRun("update node group %s/%s to AMI release version %s", clusterName, nodeGroupName, version) {
+		return nil
+	}
+	fmt.Printf("Updating AMI release version for node group %s...\n", nodeGroupName)
+
+	if err := client.UpdateNodeGroupReleaseVersion(ctx, clusterName, nodeGroupName, version); err != nil {
+		return fmt.Errorf("failed to update AMI release version: %w", err)
+	}
+
+	fmt.Printf("✓ Successfully initiated AMI release update for node group %s\n", nodeGroupName)
+	fmt.Printf("\n")
+	fmt.Printf("Note: The update operation may take several minutes to complete.\n")
+	fmt.Printf("      Nodes will be replaced with the new AMI release.\n")
+	fmt.Printf("You can check the status with: aws-ssm eks %s\n", clusterName)
+
+	return nil
+}
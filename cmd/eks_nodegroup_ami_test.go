@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+)
+
+func TestResolveAMIReleaseVersion(t *testing.T) {
+	originalVersion, originalNonInteractive := amiReleaseVersion, nonInteractive
+	defer func() { amiReleaseVersion, nonInteractive = originalVersion, originalNonInteractive }()
+	nonInteractive = true
+
+	amiReleaseVersion = "1.29.0-20240227"
+	got, err := resolveAMIReleaseVersion(context.Background(), nil, &aws.NodeGroup{})
+	if err != nil || got != "1.29.0-20240227" {
+		t.Errorf("resolveAMIReleaseVersion() = %q, %v; want the flag value", got, err)
+	}
+
+	amiReleaseVersion = ""
+	_, err = resolveAMIReleaseVersion(context.Background(), nil, &aws.NodeGroup{})
+	if code := ExitCode(err); code != exitCodeUsage {
+		t.Errorf("ExitCode() = %d, want %d (err = %v)", code, exitCodeUsage, err)
+	}
+}
+
+func TestPlanAMIUpdateSteps(t *testing.T) {
+	groups := []*aws.NodeGroup{
+		{Name: "workers", AMIType: "AL2023_x86_64_STANDARD", ReleaseVersion: "1.29.0-20240101", CurrentSize: 3},
+		{Name: "current", AMIType: "AL2023_x86_64_STANDARD", ReleaseVersion: "1.29.0-20240227", CurrentSize: 2},
+		{Name: "custom", AMIType: "CUSTOM", CurrentSize: 4},
+		{Name: "gpu", AMIType: "AL2_x86_64_GPU", ReleaseVersion: "1.29.0-20240110", CurrentSize: 1},
+	}
+	target := func(*aws.NodeGroup) (string, error) { return "1.29.0-20240227", nil }
+
+	var out bytes.Buffer
+	mutator := &recordingMutator{}
+	steps, nodes, err := planAMIUpdateSteps(&out, mutator, "prod", groups, target)
+	if err != nil {
+		t.Fatalf("planAMIUpdateSteps() error = %v", err)
+	}
+	if len(steps) != 2 || !strings.Contains(steps[0].Name, "workers") || !strings.Contains(steps[1].Name, "gpu") {
+		t.Fatalf("steps = %+v, want workers then gpu", steps)
+	}
+	if nodes != 4 {
+		t.Errorf("nodes = %d, want 4", nodes)
+	}
+	if !strings.Contains(out.String(), "Skipping node group custom") || !strings.Contains(out.String(), "current is already on") {
+		t.Errorf("skipped node groups not reported:\n%s", out.String())
+	}
+
+	originalDryRun := dryRun
+	defer func() { dryRun = originalDryRun }()
+	dryRun = false
+	runner := &stepRunner{out: &out, skipConfirm: true}
+	if _, err := runner.Run(context.Background(), "Update AMI release versions", steps); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(mutator.calls) != 2 {
+		t.Errorf("calls = %v, want one release update per planned node group", mutator.calls)
+	}
+}
+
+func TestPlanAMIUpdateStepsTargetError(t *testing.T) {
+	errLookup := errors.New("lookup failed")
+	groups := []*aws.NodeGroup{{Name: "workers", AMIType: "AL2_x86_64"}}
+	_, _, err := planAMIUpdateSteps(&bytes.Buffer{}, &recordingMutator{}, "prod", groups, func(*aws.NodeGroup) (string, error) {
+		return "", errLookup
+	})
+	if !errors.Is(err, errLookup) {
+		t.Errorf("planAMIUpdateSteps() error = %v, want %v", err, errLookup)
+	}
+}
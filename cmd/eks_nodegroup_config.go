@@ -0,0 +1,313 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ngAddLabels    []string
+	ngRemoveLabels []string
+	ngAddTaints    []string
+	ngRemoveTaints []string
+)
+
+var updateConfigCmd = &cobra.Command{
+	Use:   "update-config [cluster-name]",
+	Short: "Add or remove Kubernetes labels and taints on an EKS node group",
+	Long: `Add or remove the Kubernetes labels and taints EKS applies to a managed node group's nodes.
+
+The resulting labels and taints are shown before confirming. Taints use the kubectl
+format key=value:Effect (or key:Effect), where Effect is NoSchedule, NoExecute or
+PreferNoSchedule; --remove-taint removes every effect set for the key.
+
+If the cluster name or node group name is not provided, an interactive fuzzy finder
+will be displayed to select them.
+
+Examples:
+  # Add a label and remove a taint
+  aws-ssm eks nodegroup update-config my-cluster --nodegroup my-ng --add-label team=payments --remove-taint dedicated
+
+  # Dedicate a node group to GPU workloads
+  aws-ssm eks ng update-config my-cluster --nodegroup gpu --add-taint nvidia.com/gpu=true:NoSchedule
+
+  # Skip confirmation prompt
+  aws-ssm eks ng update-config my-cluster --nodegroup my-ng --remove-label tier --skip-confirm`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runUpdateNodeGroupConfig,
+}
+
+var updateLabelsCmd = &cobra.Command{
+	Use:   "update-labels [cluster-name]",
+	Short: "Add or remove Kubernetes labels on an EKS node group",
+	Long: `Add or remove the Kubernetes labels EKS applies to a managed node group's nodes.
+
+This is update-config limited to labels; the resulting labels are shown before confirming.
+
+Examples:
+  # Add two labels and remove one
+  aws-ssm eks nodegroup update-labels my-cluster --nodegroup my-ng --add-label team=payments --add-label env=prod --remove-label tier`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runUpdateNodeGroupLabels,
+}
+
+var updateTaintsCmd = &cobra.Command{
+	Use:   "update-taints [cluster-name]",
+	Short: "Add or remove Kubernetes taints on an EKS node group",
+	Long: `Add or remove the Kubernetes taints EKS applies to a managed node group's nodes.
+
+This is update-config limited to taints; the resulting taints are shown before confirming.
+Taints use the kubectl format key=value:Effect (or key:Effect), where Effect is
+NoSchedule, NoExecute or PreferNoSchedule; --remove-taint removes every effect set for the key.
+
+Examples:
+  # Dedicate a node group to GPU workloads
+  aws-ssm eks nodegroup update-taints my-cluster --nodegroup gpu --add-taint nvidia.com/gpu=true:NoSchedule
+
+  # Replace a taint
+  aws-ssm eks ng update-taints my-cluster --nodegroup my-ng --remove-taint spot --add-taint dedicated=batch:NoExecute`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runUpdateNodeGroupTaints,
+}
+
+func init() {
+	eksNodeGroupCmd.AddCommand(updateConfigCmd)
+	eksNodeGroupCmd.AddCommand(updateLabelsCmd)
+	eksNodeGroupCmd.AddCommand(updateTaintsCmd)
+
+	updateConfigCmd.Flags().StringVar(&nodeGroupName, "nodegroup", "", "Node group name (if not provided, interactive selection will be used)")
+	updateConfigCmd.Flags().StringSliceVar(&ngAddLabels, "add-label", nil, "Label to add or update (format: key=value, can be used multiple times)")
+	updateConfigCmd.Flags().StringSliceVar(&ngRemoveLabels, "remove-label", nil, "Label key to remove (can be used multiple times)")
+	updateConfigCmd.Flags().StringSliceVar(&ngAddTaints, "add-taint", nil, "Taint to add or update (format: key=value:Effect, can be used multiple times)")
+	updateConfigCmd.Flags().StringSliceVar(&ngRemoveTaints, "remove-taint", nil, "Taint key to remove (can be used multiple times)")
+	updateConfigCmd.Flags().BoolVar(&skipConfirm, "skip-confirm", false, "Skip confirmation prompt")
+	addReuseLastFlag(updateConfigCmd, "cluster")
+
+	updateLabelsCmd.Flags().StringVar(&nodeGroupName, "nodegroup", "", "Node group name (if not provided, interactive selection will be used)")
+	updateLabelsCmd.Flags().StringSliceVar(&ngAddLabels, "add-label", nil, "Label to add or update (format: key=value, can be used multiple times)")
+	updateLabelsCmd.Flags().StringSliceVar(&ngRemoveLabels, "remove-label", nil, "Label key to remove (can be used multiple times)")
+	updateLabelsCmd.Flags().BoolVar(&skipConfirm, "skip-confirm", false, "Skip confirmation prompt")
+	addReuseLastFlag(updateLabelsCmd, "cluster")
+
+	updateTaintsCmd.Flags().StringVar(&nodeGroupName, "nodegroup", "", "Node group name (if not provided, interactive selection will be used)")
+	updateTaintsCmd.Flags().StringSliceVar(&ngAddTaints, "add-taint", nil, "Taint to add or update (format: key=value:Effect, can be used multiple times)")
+	updateTaintsCmd.Flags().StringSliceVar(&ngRemoveTaints, "remove-taint", nil, "Taint key to remove (can be used multiple times)")
+	updateTaintsCmd.Flags().BoolVar(&skipConfirm, "skip-confirm", false, "Skip confirmation prompt")
+	addReuseLastFlag(updateTaintsCmd, "cluster")
+}
+
+func runUpdateNodeGroupConfig(_ *cobra.Command, args []string) error {
+	change, err := buildNodeGroupConfigChange(ngAddLabels, ngRemoveLabels, ngAddTaints, ngRemoveTaints)
+	if err != nil {
+		return err
+	}
+	return applyNodeGroupConfigChange(args, change, "label and taint changes")
+}
+
+func runUpdateNodeGroupLabels(_ *cobra.Command, args []string) error {
+	change, err := buildNodeGroupLabelChange(ngAddLabels, ngRemoveLabels)
+	if err != nil {
+		return err
+	}
+	return applyNodeGroupConfigChange(args, change, "label changes")
+}
+
+func runUpdateNodeGroupTaints(_ *cobra.Command, args []string) error {
+	change, err := buildNodeGroupTaintChange(ngAddTaints, ngRemoveTaints)
+	if err != nil {
+		return err
+	}
+	return applyNodeGroupConfigChange(args, change, "taint changes")
+}
+
+// applyNodeGroupConfigChange selects the node group, shows the planned
+// change and applies it once confirmed; changes describes it in the prompt
+func applyNodeGroupConfigChange(args []string, change aws.NodeGroupConfigChange, changes string) error {
+	args, err := applyReuseLast(args, reuseLast, lastClusterName)
+	if err != nil {
+		return err
+	}
+
+	// Create a context that can be cancelled with Ctrl+C
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	client, err := aws.NewClient(ctx, region, profile, configPath)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client: %w", err)
+	}
+
+	clusterName, resolvedNodeGroupName, err := resolveClusterAndNodeGroup(ctx, client, args)
+	if err != nil {
+		return err
+	}
+	if resolvedNodeGroupName == "" {
+		return nil
+	}
+
+	ng, err := client.DescribeNodeGroupPublic(ctx, clusterName, resolvedNodeGroupName)
+	if err != nil {
+		return fmt.Errorf("failed to describe node group: %w", err)
+	}
+
+	plan, err := aws.PlanNodeGroupConfigUpdate(ng.Labels, ng.Taints, change)
+	if err != nil {
+		return err
+	}
+	if plan.IsEmpty() {
+		fmt.Printf("Node group %s already has the requested labels and taints\n", resolvedNodeGroupName)
+		return nil
+	}
+
+	fmt.Print(formatNodeGroupConfigUpdate(clusterName, resolvedNodeGroupName, ng, plan))
+	showBlastRadius(ctx, client, nodeGroupConfigBlastRadius(clusterName+"/"+resolvedNodeGroupName, ng.CurrentSize, plan).withARN(nodeGroupIdentifier(clusterName, ng)))
+
+	if !skipConfirm && !dryRun {
+		p := newLinePrompter(os.Stdin, os.Stdout)
+		confirmed, err := p.Confirm("Apply these "+changes+"?", false)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Operation cancelled.")
+			return nil
+		}
+	}
+
+	return executeNodeGroupConfigUpdate(ctx, client, clusterName, resolvedNodeGroupName, plan)
+}
+
+// executeNodeGroupConfigUpdate applies the label and taint plan to the node group
+func executeNodeGroupConfigUpdate(ctx context.Context, client resourceMutator, clusterName, nodeGroupName string, plan *aws.NodeGroupConfigPlan) error {
+	if reportDryRun("apply the label and taint changes above to node group %s/%s", clusterName, nodeGroupName) {
+		return nil
+	}
+
+	fmt.Printf("Updating labels and taints for node group %s...\n", nodeGroupName)
+	if err := client.UpdateNodeGroupLabelsAndTaints(ctx, clusterName, nodeGroupName, plan); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Successfully initiated label and taint update for node group %s\n", nodeGroupName)
+	fmt.Printf("You can check the status with: aws-ssm eks %s\n", clusterName)
+	return nil
+}
+
+// buildNodeGroupConfigChange parses and validates the update-config flags
+func buildNodeGroupConfigChange(addLabels, removeLabels, addTaints, removeTaints []string) (aws.NodeGroupConfigChange, error) {
+	var change aws.NodeGroupConfigChange
+	if len(addLabels)+len(removeLabels)+len(addTaints)+len(removeTaints) == 0 {
+		return change, usageErrorf("nothing to update: pass --add-label, --remove-label, --add-taint or --remove-taint")
+	}
+
+	labels, err := parseTagPairs(addLabels, "--add-label")
+	if err != nil {
+		return change, err
+	}
+	if len(labels) > 0 {
+		change.AddLabels = labels
+	}
+	change.RemoveLabels = trimmedValues(removeLabels)
+	for _, spec := range addTaints {
+		taint, err := aws.ParseTaint(spec)
+		if err != nil {
+			return change, usageErrorf("invalid --add-taint: %v", err)
+		}
+		change.AddTaints = append(change.AddTaints, taint)
+	}
+	change.RemoveTaints = trimmedValues(removeTaints)
+
+	if err := change.Validate(); err != nil {
+		return change, usageErrorf("%v", err)
+	}
+	return change, nil
+}
+
+// buildNodeGroupLabelChange parses and validates the update-labels flags
+func buildNodeGroupLabelChange(addLabels, removeLabels []string) (aws.NodeGroupConfigChange, error) {
+	if len(addLabels)+len(removeLabels) == 0 {
+		return aws.NodeGroupConfigChange{}, usageErrorf("nothing to update: pass --add-label or --remove-label")
+	}
+	return buildNodeGroupConfigChange(addLabels, removeLabels, nil, nil)
+}
+
+// buildNodeGroupTaintChange parses and validates the update-taints flags
+func buildNodeGroupTaintChange(addTaints, removeTaints []string) (aws.NodeGroupConfigChange, error) {
+	if len(addTaints)+len(removeTaints) == 0 {
+		return aws.NodeGroupConfigChange{}, usageErrorf("nothing to update: pass --add-taint or --remove-taint")
+	}
+	return buildNodeGroupConfigChange(nil, nil, addTaints, removeTaints)
+}
+
+// trimmedValues trims whitespace from flag values
+func trimmedValues(values []string) []string {
+	var out []string
+	for _, v := range values {
+		out = append(out, strings.TrimSpace(v))
+	}
+	return out
+}
+
+// formatNodeGroupConfigUpdate renders the changes and the resulting labels and taints
+func formatNodeGroupConfigUpdate(clusterName, nodeGroupName string, ng *aws.NodeGroup, plan *aws.NodeGroupConfigPlan) string {
+	var sb strings.Builder
+	sb.WriteString("\n")
+	fmt.Fprintf(&sb, "Cluster:                  %s\n", clusterName)
+	fmt.Fprintf(&sb, "Node Group:               %s\n", nodeGroupName)
+	fmt.Fprintf(&sb, "ARN:                      %s\n", nodeGroupIdentifier(clusterName, ng))
+	sb.WriteString("\n")
+
+	sb.WriteString("Changes:\n")
+	keys := make([]string, 0, len(plan.AddOrUpdateLabels))
+	for k := range plan.AddOrUpdateLabels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if old, ok := ng.Labels[k]; ok {
+			fmt.Fprintf(&sb, "  ~ label %s: %s → %s\n", k, old, plan.AddOrUpdateLabels[k])
+		} else {
+			fmt.Fprintf(&sb, "  + label %s=%s\n", k, plan.AddOrUpdateLabels[k])
+		}
+	}
+	for _, k := range plan.RemoveLabels {
+		fmt.Fprintf(&sb, "  - label %s=%s\n", k, ng.Labels[k])
+	}
+	for _, t := range plan.AddOrUpdateTaints {
+		fmt.Fprintf(&sb, "  + taint %s\n", formatTaint(t))
+	}
+	for _, t := range plan.RemoveTaints {
+		fmt.Fprintf(&sb, "  - taint %s\n", formatTaint(t))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("Desired Configuration:\n")
+	labels := "-"
+	if len(plan.Labels) > 0 {
+		labels = formatTagPairs(plan.Labels)
+	}
+	fmt.Fprintf(&sb, "  Labels:                 %s\n", labels)
+	taints := make([]string, 0, len(plan.Taints))
+	for _, t := range plan.Taints {
+		taints = append(taints, formatTaint(t))
+	}
+	fmt.Fprintf(&sb, "  Taints:                 %s\n", valueOrDash(strings.Join(taints, ", ")))
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// formatTaint renders a taint as key=value:EFFECT
+func formatTaint(t aws.Taint) string {
+	if t.Value == "" {
+		return t.Key + ":" + t.Effect
+	}
+	return t.Key + "=" + t.Value + ":" + t.Effect
+}
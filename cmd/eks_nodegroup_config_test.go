@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+)
+
+func TestBuildNodeGroupConfigChange(t *testing.T) {
+	change, err := buildNodeGroupConfigChange(
+		[]string{"team=payments", "env=prod"},
+		[]string{" tier "},
+		[]string{"dedicated=gpu:NoSchedule"},
+		[]string{"spot"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := aws.NodeGroupConfigChange{
+		AddLabels:    map[string]string{"team": "payments", "env": "prod"},
+		RemoveLabels: []string{"tier"},
+		AddTaints:    []aws.Taint{{Key: "dedicated", Value: "gpu", Effect: "NO_SCHEDULE"}},
+		RemoveTaints: []string{"spot"},
+	}
+	if !reflect.DeepEqual(change, want) {
+		t.Errorf("buildNodeGroupConfigChange() = %+v, want %+v", change, want)
+	}
+
+	invalid := []struct {
+		name                                             string
+		addLabels, removeLabels, addTaints, removeTaints []string
+	}{
+		{name: "no changes"},
+		{name: "label without value", addLabels: []string{"team"}},
+		{name: "invalid label key", addLabels: []string{"team name=x"}},
+		{name: "taint without effect", addTaints: []string{"dedicated=gpu"}},
+		{name: "invalid taint key", removeTaints: []string{"bad/key/x"}},
+		{name: "label added and removed", addLabels: []string{"tier=api"}, removeLabels: []string{"tier"}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildNodeGroupConfigChange(tt.addLabels, tt.removeLabels, tt.addTaints, tt.removeTaints)
+			if code := ExitCode(err); code != exitCodeUsage {
+				t.Errorf("ExitCode() = %d, want %d (err = %v)", code, exitCodeUsage, err)
+			}
+		})
+	}
+}
+
+func TestFormatNodeGroupConfigUpdate(t *testing.T) {
+	ng := &aws.NodeGroup{
+		Labels: map[string]string{"team": "payments", "tier": "web"},
+		Taints: []aws.Taint{{Key: "spot", Effect: "PREFER_NO_SCHEDULE"}},
+	}
+	change := aws.NodeGroupConfigChange{
+		AddLabels:    map[string]string{"env": "prod", "team": "billing"},
+		RemoveLabels: []string{"tier"},
+		AddTaints:    []aws.Taint{{Key: "dedicated", Value: "gpu", Effect: "NO_EXECUTE"}},
+		RemoveTaints: []string{"spot"},
+	}
+	plan, err := aws.PlanNodeGroupConfigUpdate(ng.Labels, ng.Taints, change)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := formatNodeGroupConfigUpdate("prod", "workers", ng, plan)
+	for _, want := range []string{
+		"+ label env=prod",
+		"~ label team: payments → billing",
+		"- label tier=web",
+		"+ taint dedicated=gpu:NO_EXECUTE",
+		"- taint spot:PREFER_NO_SCHEDULE",
+		"Labels:                 env=prod, team=billing\n",
+		"Taints:                 dedicated=gpu:NO_EXECUTE\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+
+	b := nodeGroupConfigBlastRadius("prod/workers", 3, plan)
+	if b.InstancesAffected != 3 || len(b.Notes) != 1 || !strings.Contains(b.Notes[0], "evicts running pods") {
+		t.Errorf("nodeGroupConfigBlastRadius() = %+v", b)
+	}
+}
+
+func TestBuildNodeGroupLabelAndTaintChanges(t *testing.T) {
+	labels := map[string]string{"tier": "web"}
+	taints := []aws.Taint{{Key: "spot", Effect: "NO_SCHEDULE"}}
+
+	change, err := buildNodeGroupLabelChange([]string{"team=payments", "env=prod"}, []string{"tier"})
+	if err != nil {
+		t.Fatalf("buildNodeGroupLabelChange() error = %v", err)
+	}
+	plan, err := aws.PlanNodeGroupConfigUpdate(labels, taints, change)
+	if err != nil {
+		t.Fatalf("PlanNodeGroupConfigUpdate() error = %v", err)
+	}
+	if !reflect.DeepEqual(plan.AddOrUpdateLabels, map[string]string{"team": "payments", "env": "prod"}) ||
+		!reflect.DeepEqual(plan.RemoveLabels, []string{"tier"}) {
+		t.Errorf("label payload = %+v / %v", plan.AddOrUpdateLabels, plan.RemoveLabels)
+	}
+	if len(plan.AddOrUpdateTaints)+len(plan.RemoveTaints) != 0 {
+		t.Errorf("update-labels touched taints: %+v", plan)
+	}
+
+	change, err = buildNodeGroupTaintChange([]string{"dedicated=gpu:NoSchedule", "batch:NoExecute"}, []string{"spot"})
+	if err != nil {
+		t.Fatalf("buildNodeGroupTaintChange() error = %v", err)
+	}
+	plan, err = aws.PlanNodeGroupConfigUpdate(labels, taints, change)
+	if err != nil {
+		t.Fatalf("PlanNodeGroupConfigUpdate() error = %v", err)
+	}
+	wantAdded := []aws.Taint{{Key: "dedicated", Value: "gpu", Effect: "NO_SCHEDULE"}, {Key: "batch", Effect: "NO_EXECUTE"}}
+	if !reflect.DeepEqual(plan.AddOrUpdateTaints, wantAdded) || !reflect.DeepEqual(plan.RemoveTaints, taints) {
+		t.Errorf("taint payload = %+v / %+v", plan.AddOrUpdateTaints, plan.RemoveTaints)
+	}
+	if len(plan.AddOrUpdateLabels)+len(plan.RemoveLabels) != 0 {
+		t.Errorf("update-taints touched labels: %+v", plan)
+	}
+
+	for name, build := range map[string]func() error{
+		"no label flags": func() error { _, err := buildNodeGroupLabelChange(nil, nil); return err },
+		"no taint flags": func() error { _, err := buildNodeGroupTaintChange(nil, nil); return err },
+		"bad taint":      func() error { _, err := buildNodeGroupTaintChange([]string{"dedicated=gpu"}, nil); return err },
+		"bad label":      func() error { _, err := buildNodeGroupLabelChange([]string{"team"}, nil); return err },
+	} {
+		if code := ExitCode(build()); code != exitCodeUsage {
+			t.Errorf("%s: ExitCode() = %d, want %d", name, code, exitCodeUsage)
+		}
+	}
+	if _, err := buildNodeGroupTaintChange(nil, nil); err == nil || !strings.Contains(err.Error(), "--add-taint") {
+		t.Errorf("buildNodeGroupTaintChange() error = %v, want the taint flags named", err)
+	}
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Node group tags that supply scaling defaults when --desired is not given
+const (
+	scaleTagMin     = "scale:min"
+	scaleTagMax     = "scale:max"
+	scaleTagDesired = "scale:desired"
+)
+
+// scalingTagDefaults holds scaling defaults read from node group tags; -1 means unset
+type scalingTagDefaults struct {
+	Min     int32
+	Max     int32
+	Desired int32
+}
+
+// parseScalingTags reads the scale:* convention tags. Missing tags stay unset;
+// malformed values are also left unset and described in the returned warnings.
+func parseScalingTags(tags map[string]string) (scalingTagDefaults, []string) {
+	defaults := scalingTagDefaults{Min: -1, Max: -1, Desired: -1}
+	var warnings []string
+	for _, field := range []struct {
+		key  string
+		dest *int32
+	}{
+		{scaleTagMin, &defaults.Min},
+		{scaleTagMax, &defaults.Max},
+		{scaleTagDesired, &defaults.Desired},
+	} {
+		raw, ok := tags[field.key]
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 32)
+		if err != nil || value < 0 {
+			warnings = append(warnings, fmt.Sprintf("ignoring %s tag %q: not a non-negative integer", field.key, raw))
+			continue
+		}
+		*field.dest = int32(value)
+	}
+	return defaults, warnings
+}
+
+// fill returns params with unset (-1) values taken from the tag defaults
+func (d scalingTagDefaults) fill(params ScalingParameters) ScalingParameters {
+	if params.Min == -1 {
+		params.Min = d.Min
+	}
+	if params.Max == -1 {
+		params.Max = d.Max
+	}
+	if params.Desired == -1 {
+		params.Desired = d.Desired
+	}
+	return params
+}
+
+// applyScalingTagDefaults prefills flag values from the node group's scale:*
+// tags when --desired was not given, warning about malformed tags
+func applyScalingTagDefaults(tags map[string]string, params ScalingParameters) ScalingParameters {
+	if params.Desired != -1 {
+		return params
+	}
+	defaults, warnings := parseScalingTags(tags)
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	return defaults.fill(params)
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+)
+
+func TestParseScalingTags(t *testing.T) {
+	tests := []struct {
+		name         string
+		tags         map[string]string
+		want         scalingTagDefaults
+		wantWarnings int
+	}{
+		{
+			name: "no tags",
+			tags: nil,
+			want: scalingTagDefaults{Min: -1, Max: -1, Desired: -1},
+		},
+		{
+			name: "all tags",
+			tags: map[string]string{"scale:min": "1", "scale:max": "5", "scale:desired": " 3 ", "team": "core"},
+			want: scalingTagDefaults{Min: 1, Max: 5, Desired: 3},
+		},
+		{
+			name: "desired only",
+			tags: map[string]string{"scale:desired": "0"},
+			want: scalingTagDefaults{Min: -1, Max: -1, Desired: 0},
+		},
+		{
+			name:         "malformed values are ignored",
+			tags:         map[string]string{"scale:min": "one", "scale:max": "-2", "scale:desired": "3"},
+			want:         scalingTagDefaults{Min: -1, Max: -1, Desired: 3},
+			wantWarnings: 2,
+		},
+		{
+			name:         "out of range",
+			tags:         map[string]string{"scale:desired": "99999999999"},
+			want:         scalingTagDefaults{Min: -1, Max: -1, Desired: -1},
+			wantWarnings: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, warnings := parseScalingTags(tt.tags)
+			if got != tt.want {
+				t.Errorf("parseScalingTags() = %+v, want %+v", got, tt.want)
+			}
+			if len(warnings) != tt.wantWarnings {
+				t.Errorf("parseScalingTags() warnings = %v, want %d", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestApplyScalingTagDefaults(t *testing.T) {
+	tags := map[string]string{"scale:min": "2", "scale:max": "6", "scale:desired": "4"}
+	tests := []struct {
+		name   string
+		tags   map[string]string
+		params ScalingParameters
+		want   ScalingParameters
+	}{
+		{
+			name:   "fills unset flags",
+			tags:   tags,
+			params: ScalingParameters{Min: -1, Max: -1, Desired: -1},
+			want:   ScalingParameters{Min: 2, Max: 6, Desired: 4},
+		},
+		{
+			name:   "flags win over tags",
+			tags:   tags,
+			params: ScalingParameters{Min: 1, Max: -1, Desired: -1},
+			want:   ScalingParameters{Min: 1, Max: 6, Desired: 4},
+		},
+		{
+			name:   "explicit desired skips tags",
+			tags:   tags,
+			params: ScalingParameters{Min: -1, Max: -1, Desired: 3},
+			want:   ScalingParameters{Min: -1, Max: -1, Desired: 3},
+		},
+		{
+			name:   "malformed tags keep current behavior",
+			tags:   map[string]string{"scale:desired": "lots"},
+			params: ScalingParameters{Min: -1, Max: -1, Desired: -1},
+			want:   ScalingParameters{Min: -1, Max: -1, Desired: -1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := applyScalingTagDefaults(tt.tags, tt.params); got != tt.want {
+				t.Errorf("applyScalingTagDefaults() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateFinalParametersUsesTagDesiredInCLIMode(t *testing.T) {
+	ng := &aws.NodeGroup{MinSize: 1, MaxSize: 3, DesiredSize: 2}
+
+	retry, params, err := calculateFinalParametersWithRetry(ng, -1, -1, -1, 5, 1)
+	if err != nil || retry {
+		t.Fatalf("calculateFinalParametersWithRetry() = retry %v, err %v", retry, err)
+	}
+	want := ScalingParameters{Min: 1, Max: 5, Desired: 5}
+	if params != want {
+		t.Errorf("params = %+v, want %+v", params, want)
+	}
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/spf13/cobra"
+)
+
+var eniCmd = &cobra.Command{
+	Use:   "eni",
+	Short: "Inspect elastic network interfaces",
+	Long: `Inspect elastic network interfaces (ENIs) that are not tied to a single instance.
+
+Examples:
+  # List unattached ENIs in the current region
+  aws-ssm eni orphans`,
+}
+
+var eniOrphansCmd = &cobra.Command{
+	Use:   "orphans",
+	Short: "List unattached (orphaned) ENIs",
+	Long: `List ENIs in the current region that are available but not attached to anything.
+Orphaned ENIs consume subnet addresses and usually indicate missed cleanup.
+
+ENIs managed by AWS services are excluded. EC2 does not report an ENI creation
+time, so AGE is only known for ENIs tagged by the Amazon VPC CNI.
+
+Examples:
+  # Table output
+  aws-ssm eni orphans --region us-west-2
+
+  # Machine-readable output
+  aws-ssm eni orphans --output json`,
+	Args: cobra.NoArgs,
+	RunE: runENIOrphans,
+}
+
+func init() {
+	rootCmd.AddCommand(eniCmd)
+	eniCmd.AddCommand(eniOrphansCmd)
+}
+
+func runENIOrphans(_ *cobra.Command, _ []string) error {
+	if err := validateOutputFlags(); err != nil {
+		return err
+	}
+
+	// Create a context that can be cancelled with Ctrl+C
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	client, err := aws.NewClient(ctx, region, profile, configPath)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client: %w", err)
+	}
+
+	orphans, err := client.ListOrphanedENIs(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if isJSONOutput() {
+		return printJSON(map[string]interface{}{
+			"region":  client.GetRegion(),
+			"orphans": orphanedENIsJSON(orphans, now),
+		})
+	}
+	if len(orphans) == 0 {
+		fmt.Printf("No orphaned ENIs found in %s\n", client.GetRegion())
+		return nil
+	}
+	fmt.Printf("Orphaned ENIs in %s: %d\n\n", client.GetRegion(), len(orphans))
+	return printOrphanedENIs(os.Stdout, orphans, now, tableMaxRows(client.AppConfig))
+}
+
+// orphanedENIsJSON converts orphaned ENIs into plain maps for JSON output
+func orphanedENIsJSON(orphans []aws.OrphanedENI, now time.Time) []map[string]interface{} {
+	entries := make([]map[string]interface{}, 0, len(orphans))
+	for _, eni := range orphans {
+		entry := map[string]interface{}{
+			"network_interface_id": eni.NetworkInterfaceID,
+			"subnet_id":            eni.SubnetID,
+			"vpc_id":               eni.VpcID,
+			"availability_zone":    eni.AvailabilityZone,
+			"private_ip":           eni.PrivateIP,
+			"interface_type":       eni.InterfaceType,
+			"description":          eni.Description,
+		}
+		if !eni.CreatedAt.IsZero() {
+			entry["created"] = eni.CreatedAt.UTC().Format(time.RFC3339)
+			entry["age_seconds"] = int64(eni.Age(now).Seconds())
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// formatENIAge renders an ENI age in days or hours, or "-" when unknown
+func formatENIAge(age time.Duration) string {
+	switch {
+	case age <= 0:
+		return "-"
+	case age >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(age.Hours())/24)
+	case age >= time.Hour:
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	default:
+		return "<1h"
+	}
+}
+
+// printOrphanedENIs renders orphaned ENIs as a table of at most maxRows rows (0 = no cap)
+func printOrphanedENIs(out io.Writer, orphans []aws.OrphanedENI, now time.Time, maxRows int) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	if _, err := fmt.Fprintln(w, "ENI ID\tSUBNET\tAZ\tPRIVATE IP\tAGE\tDESCRIPTION"); err != nil {
+		return fmt.Errorf("failed to write table header: %w", err)
+	}
+
+	shown := cappedRows(len(orphans), maxRows)
+	for _, eni := range orphans[:shown] {
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			eni.NetworkInterfaceID,
+			valueOrDash(eni.SubnetID),
+			valueOrDash(eni.AvailabilityZone),
+			valueOrDash(eni.PrivateIP),
+			formatENIAge(eni.Age(now)),
+			valueOrDash(eni.Description),
+		); err != nil {
+			return fmt.Errorf("failed to write table row: %w", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to flush table writer: %w", err)
+	}
+	return writeTruncationFooter(out, shown, len(orphans))
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+)
+
+func TestFormatENIAge(t *testing.T) {
+	tests := []struct {
+		age  time.Duration
+		want string
+	}{
+		{0, "-"},
+		{30 * time.Minute, "<1h"},
+		{5 * time.Hour, "5h"},
+		{49 * time.Hour, "2d"},
+	}
+
+	for _, tt := range tests {
+		if got := formatENIAge(tt.age); got != tt.want {
+			t.Errorf("formatENIAge(%v) = %q, want %q", tt.age, got, tt.want)
+		}
+	}
+}
+
+func TestPrintOrphanedENIs(t *testing.T) {
+	now := time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC)
+	orphans := []aws.OrphanedENI{
+		{NetworkInterfaceID: "eni-111", SubnetID: "subnet-aaa", AvailabilityZone: "us-east-1a", PrivateIP: "10.0.0.5", CreatedAt: now.Add(-72 * time.Hour), Description: "aws-K8S-i-123"},
+		{NetworkInterfaceID: "eni-222", SubnetID: "subnet-bbb"},
+	}
+
+	var buf bytes.Buffer
+	if err := printOrphanedENIs(&buf, orphans, now, 0); err != nil {
+		t.Fatalf("printOrphanedENIs() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header and 2 rows, got %d lines:\n%s", len(lines), buf.String())
+	}
+	for _, want := range []string{"eni-111", "subnet-aaa", "3d", "aws-K8S-i-123"} {
+		if !strings.Contains(lines[1], want) {
+			t.Errorf("row %q missing %q", lines[1], want)
+		}
+	}
+	if fields := strings.Fields(lines[2]); len(fields) != 6 || fields[4] != "-" {
+		t.Errorf("row for unknown age = %q, want dash placeholders", lines[2])
+	}
+}
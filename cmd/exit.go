@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/spf13/cobra"
+)
+
+// Process exit codes returned by ExitCode
+const (
+	exitCodeOK              = 0
+	exitCodeError           = 1
+	exitCodeUsage           = 2
+	exitCodeMultipleMatches = 3
+	exitCodeAccessDenied    = 4
+	exitCodeNoCredentials   = 5
+	exitCodeDrift           = 6
+	exitCodeCancelled       = 130 // 128 + SIGINT, as shells report Ctrl+C
+)
+
+// errCancelled reports that the user interrupted an operation with Ctrl+C
+var errCancelled = errors.New("operation cancelled")
+
+// exitCodeErr attaches a specific process exit code to an error
+type exitCodeErr struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeErr) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitCodeErr) Unwrap() error {
+	return e.err
+}
+
+// withExitCode wraps err so that ExitCode reports code for it
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitCodeErr{code: code, err: err}
+}
+
+// usageErrorf returns an error for invalid flags or arguments
+func usageErrorf(format string, args ...interface{}) error {
+	return withExitCode(exitCodeUsage, fmt.Errorf(format, args...))
+}
+
+// ExitCode returns the process exit code for an error returned by Execute:
+// 0 success, 1 generic error, 2 usage error, 3 ambiguous instance match,
+// 4 access denied, 5 no AWS credentials, 6 drift found by diff, and 130 cancelled
+func ExitCode(err error) int {
+	if err == nil {
+		return exitCodeOK
+	}
+	var coded *exitCodeErr
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+	switch {
+	case errors.Is(err, errCancelled), errors.Is(err, context.Canceled):
+		return exitCodeCancelled
+	case aws.IsAccessDenied(err):
+		return exitCodeAccessDenied
+	case errors.Is(err, aws.ErrNoCredentials):
+		return exitCodeNoCredentials
+	default:
+		return exitCodeError
+	}
+}
+
+// noCredentialsHelp explains how to configure credentials when none are found
+const noCredentialsHelp = `aws-ssm could not find any AWS credentials. Configure them in one of these ways:
+
+  Environment variables:
+    export AWS_ACCESS_KEY_ID=AKIA...
+    export AWS_SECRET_ACCESS_KEY=...
+
+  A named profile in ~/.aws/credentials:
+    aws configure --profile dev
+    aws-ssm list --profile dev
+
+  AWS IAM Identity Center (SSO):
+    aws configure sso
+    aws sso login --profile my-sso
+    aws-ssm list --profile my-sso
+
+Then check the result with: aws-ssm doctor`
+
+// explainError writes onboarding help to w for errors a first-time user is
+// likely to hit
+func explainError(w io.Writer, err error) {
+	if errors.Is(err, aws.ErrNoCredentials) {
+		fmt.Fprintf(w, "\n%s\n\n", noCredentialsHelp)
+	}
+}
+
+// markUsageErrors makes flag parsing and argument validation failures on root
+// and its subcommands report exitCodeUsage
+func markUsageErrors(root *cobra.Command) {
+	root.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return withExitCode(exitCodeUsage, err)
+	})
+	markArgsUsageErrors(root)
+}
+
+// markArgsUsageErrors wraps the Args validators of cmd and its subcommands
+func markArgsUsageErrors(cmd *cobra.Command) {
+	if validate := cmd.Args; validate != nil {
+		cmd.Args = func(c *cobra.Command, args []string) error {
+			return withExitCode(exitCodeUsage, validate(c, args))
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		markArgsUsageErrors(sub)
+	}
+}
+
+// unknownCommandArgs rejects positional arguments on a command that only has
+// subcommands, suggesting close matches like cobra's default check
+func unknownCommandArgs(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	msg := fmt.Sprintf("unknown command %q for %q", args[0], cmd.CommandPath())
+	if cmd.DisableSuggestions {
+		return errors.New(msg)
+	}
+	if cmd.SuggestionsMinimumDistance <= 0 {
+		cmd.SuggestionsMinimumDistance = 2
+	}
+	if suggestions := cmd.SuggestionsFor(args[0]); len(suggestions) > 0 {
+		msg += "\n\nDid you mean this?"
+		for _, s := range suggestions {
+			msg += "\n\t" + s
+		}
+	}
+	return errors.New(msg)
+}
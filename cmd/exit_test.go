@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/spf13/cobra"
+)
+
+func TestExitCode(t *testing.T) {
+	base := errors.New("boom")
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "nil", err: nil, want: exitCodeOK},
+		{name: "plain error", err: base, want: exitCodeError},
+		{name: "coded error", err: withExitCode(exitCodeMultipleMatches, base), want: exitCodeMultipleMatches},
+		{name: "wrapped coded error", err: fmt.Errorf("outer: %w", withExitCode(exitCodeMultipleMatches, base)), want: exitCodeMultipleMatches},
+		{name: "usage error", err: usageErrorf("--desired flag is required"), want: exitCodeUsage},
+		{name: "cancelled", err: fmt.Errorf("failed to select node group: %w", errCancelled), want: exitCodeCancelled},
+		{name: "context cancelled", err: fmt.Errorf("describe: %w", context.Canceled), want: exitCodeCancelled},
+		{name: "access denied", err: fmt.Errorf("failed to list: %w", apiError{code: "UnauthorizedOperation"}), want: exitCodeAccessDenied},
+		{name: "other api error", err: apiError{code: "ThrottlingException"}, want: exitCodeError},
+		{name: "no credentials", err: fmt.Errorf("failed to create AWS client: %w", aws.ErrNoCredentials), want: exitCodeNoCredentials},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	if withExitCode(exitCodeError, nil) != nil {
+		t.Error("withExitCode(nil) should return nil")
+	}
+}
+
+// apiError mimics an AWS API error carrying an error code
+type apiError struct {
+	code string
+}
+
+func (e apiError) Error() string     { return "api error " + e.code }
+func (e apiError) ErrorCode() string { return e.code }
+
+func TestExplainErrorNoCredentials(t *testing.T) {
+	var out bytes.Buffer
+	err := fmt.Errorf("failed to create AWS client: %w", aws.ErrNoCredentials)
+	explainError(&out, err)
+
+	for _, want := range []string{"could not find any AWS credentials", "AWS_ACCESS_KEY_ID", "aws configure --profile", "aws sso login"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("onboarding message missing %q:\n%s", want, out.String())
+		}
+	}
+	if got := ExitCode(err); got != exitCodeNoCredentials {
+		t.Errorf("ExitCode() = %d, want %d", got, exitCodeNoCredentials)
+	}
+
+	out.Reset()
+	explainError(&out, errors.New("boom"))
+	if out.Len() != 0 {
+		t.Errorf("unexpected onboarding output for other errors: %q", out.String())
+	}
+}
+
+func TestMarkUsageErrors(t *testing.T) {
+	newRoot := func() *cobra.Command {
+		root := &cobra.Command{Use: "aws-ssm", Args: unknownCommandArgs, RunE: func(*cobra.Command, []string) error { return nil }, SilenceErrors: true, SilenceUsage: true}
+		root.AddCommand(&cobra.Command{
+			Use:  "scale",
+			Args: cobra.ExactArgs(1),
+			RunE: func(*cobra.Command, []string) error { return nil },
+		})
+		root.Flags().Bool("verbose", false, "")
+		markUsageErrors(root)
+		return root
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{name: "valid", args: []string{"scale", "my-asg"}, want: exitCodeOK},
+		{name: "unknown flag", args: []string{"scale", "my-asg", "--bogus"}, want: exitCodeUsage},
+		{name: "wrong arg count", args: []string{"scale"}, want: exitCodeUsage},
+		{name: "unknown command", args: []string{"scael"}, want: exitCodeUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := newRoot()
+			root.SetArgs(tt.args)
+			err := root.Execute()
+			if got := ExitCode(err); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d (err %v)", got, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestUnknownCommandArgsSuggests(t *testing.T) {
+	root := &cobra.Command{Use: "aws-ssm"}
+	root.AddCommand(&cobra.Command{Use: "scale", Run: func(*cobra.Command, []string) {}})
+
+	err := unknownCommandArgs(root, []string{"scael"})
+	if err == nil || !strings.Contains(err.Error(), "Did you mean this?") || !strings.Contains(err.Error(), "scale") {
+		t.Errorf("unknownCommandArgs() = %v, want a suggestion for scale", err)
+	}
+	if err := unknownCommandArgs(root, nil); err != nil {
+		t.Errorf("unknownCommandArgs(nil) = %v, want nil", err)
+	}
+}
+
+func newMultipleInstancesError(allowInteractive bool) *aws.MultipleInstancesError {
+	return &aws.MultipleInstancesError{
+		Identifier: "web",
+		Instances: []aws.Instance{
+			{InstanceID: "i-0aaaaaaaaaaaaaaa1", Name: "web-1", PrivateIP: "10.0.1.10", AvailabilityZone: "us-east-1a", State: "running"},
+			{InstanceID: "i-0bbbbbbbbbbbbbbb2", Name: "web-2", PrivateIP: "10.0.2.20", AvailabilityZone: "us-east-1b", State: "running"},
+		},
+		AllowInteractive: allowInteractive,
+	}
+}
+
+func TestReportMultipleMatches(t *testing.T) {
+	multiErr := newMultipleInstancesError(false)
+
+	var buf bytes.Buffer
+	err := reportMultipleMatches(&buf, multiErr)
+
+	if ExitCode(err) != exitCodeMultipleMatches {
+		t.Errorf("ExitCode() = %d, want %d", ExitCode(err), exitCodeMultipleMatches)
+	}
+	var target *aws.MultipleInstancesError
+	if !errors.As(err, &target) {
+		t.Errorf("error %v should wrap *aws.MultipleInstancesError", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"Found 2 instances matching 'web'",
+		"i-0aaaaaaaaaaaaaaa1", "web-1", "10.0.1.10", "us-east-1a",
+		"i-0bbbbbbbbbbbbbbb2", "web-2", "10.0.2.20", "us-east-1b",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("listing missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestHandleInstanceResolutionErrorNonInteractive(t *testing.T) {
+	tests := []struct {
+		name             string
+		allowInteractive bool
+		nonInteractive   bool
+	}{
+		{name: "error disallows interactive", allowInteractive: false},
+		{name: "non-interactive flag", allowInteractive: true, nonInteractive: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := nonInteractive
+			nonInteractive = tt.nonInteractive
+			defer func() { nonInteractive = original }()
+
+			// The client is never used because no selector is opened
+			instance, err := handleInstanceResolutionError(context.Background(), nil, newMultipleInstancesError(tt.allowInteractive))
+			if instance != nil {
+				t.Errorf("instance = %v, want nil", instance)
+			}
+			if ExitCode(err) != exitCodeMultipleMatches {
+				t.Errorf("ExitCode() = %d, want %d (err %v)", ExitCode(err), exitCodeMultipleMatches, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/johnlam90/aws-ssm/pkg/config"
+	"github.com/johnlam90/aws-ssm/pkg/security"
+	"github.com/johnlam90/aws-ssm/pkg/validation"
+	"github.com/spf13/cobra"
+)
+
+// defaultCommandChoices lists the commands that may run when aws-ssm is invoked without a subcommand
+var defaultCommandChoices = []string{"tui", "session", "list"}
+
+// maxCacheTTLMinutes caps the cache TTL accepted by the setup wizard (one day)
+const maxCacheTTLMinutes = 1440
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Interactively create or update the configuration file",
+	Long: `Walk through the most common settings and write them to the config file.
+
+Prompts for:
+  - Default AWS region
+  - AWS profile (leave empty to use the default credential chain)
+  - Security level for remote commands (low, medium, high, strict)
+  - Cache TTL in minutes
+  - Default command to run when aws-ssm is invoked without a subcommand
+
+Existing values are offered as defaults, so re-running init updates the current config.
+
+Examples:
+  # Create ~/.aws-ssm/config.yaml
+  aws-ssm init
+
+  # Write to a specific config file
+  aws-ssm init --config ~/.aws-ssm/work.yaml`,
+	Args: cobra.NoArgs,
+	RunE: runInit,
+}
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+}
+
+func runInit(_ *cobra.Command, _ []string) error {
+	return runSetupWizard(newLinePrompter(os.Stdin, os.Stdout), os.Stdout, configPath)
+}
+
+// runSetupWizard prompts for the main settings and writes them to the config file at path
+func runSetupWizard(p prompter, out io.Writer, path string) error {
+	resolvedPath, err := config.ResolvePath(path)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadConfig(path)
+	if err != nil {
+		return fmt.Errorf("failed to load existing config: %w", err)
+	}
+
+	proceed, err := confirmWizardTarget(p, out, resolvedPath)
+	if err != nil || !proceed {
+		return err
+	}
+	if err := askWizardSettings(p, out, cfg); err != nil {
+		return err
+	}
+
+	printWizardSummary(out, cfg)
+
+	write, err := p.Confirm(fmt.Sprintf("Write configuration to %s?", resolvedPath), true)
+	if err != nil {
+		return err
+	}
+	if !write {
+		fmt.Fprintln(out, "Configuration not saved.")
+		return nil
+	}
+
+	if err := config.SaveConfig(cfg, resolvedPath); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	fmt.Fprintf(out, "Configuration saved to %s\n", resolvedPath)
+	return nil
+}
+
+// confirmWizardTarget announces whether the wizard creates or updates the
+// config at path, asking before an existing one is changed
+func confirmWizardTarget(p prompter, out io.Writer, path string) (bool, error) {
+	if _, statErr := os.Stat(path); statErr != nil {
+		fmt.Fprintf(out, "Creating configuration at %s\n\n", path)
+		return true, nil
+	}
+
+	fmt.Fprintf(out, "Existing configuration found at %s\n", path)
+	update, err := p.Confirm("Update existing values?", true)
+	if err != nil {
+		return false, err
+	}
+	if !update {
+		fmt.Fprintln(out, "Configuration unchanged.")
+		return false, nil
+	}
+	fmt.Fprintln(out)
+	return true, nil
+}
+
+// askWizardSettings asks for each setting, offering the current value of cfg
+// as the default, and stores the answers in cfg
+func askWizardSettings(p prompter, out io.Writer, cfg *config.Config) error {
+	var err error
+	regionValue := cfg.Default.Region
+	if regionValue == "" {
+		regionValue = "us-east-1"
+	}
+	if cfg.Default.Region, err = askValid(p, out, "AWS region", regionValue, validateWizardRegion); err != nil {
+		return err
+	}
+	if cfg.Default.Profile, err = askValid(p, out, "AWS profile (empty for default credential chain)", cfg.Default.Profile, validateWizardProfile); err != nil {
+		return err
+	}
+	if cfg.Security.Level, err = askValid(p, out, "Security level (low, medium, high, strict)", cfg.Security.Level, validateWizardSecurityLevel); err != nil {
+		return err
+	}
+
+	ttl, err := askValid(p, out, "Cache TTL in minutes", strconv.Itoa(cfg.Cache.TTLMinutes), validateWizardCacheTTL)
+	if err != nil {
+		return err
+	}
+	cfg.Cache.TTLMinutes, _ = strconv.Atoi(ttl)
+
+	commandQuestion := fmt.Sprintf("Default command (%s, or none)", strings.Join(defaultCommandChoices, ", "))
+	commandValue := cfg.Default.Command
+	if commandValue == "" {
+		commandValue = "none"
+	}
+	command, err := askValid(p, out, commandQuestion, commandValue, validateWizardDefaultCommand)
+	if err != nil {
+		return err
+	}
+	if command == "none" {
+		command = ""
+	}
+	cfg.Default.Command = command
+	return nil
+}
+
+// askValid keeps asking until validate accepts the answer, returning the normalized value
+func askValid(p prompter, out io.Writer, question, defaultValue string, validate func(string) (string, error)) (string, error) {
+	for {
+		answer, err := p.Ask(question, defaultValue)
+		if err != nil {
+			return "", err
+		}
+		value, err := validate(answer)
+		if err == nil {
+			return value, nil
+		}
+		fmt.Fprintf(out, "  %v\n", err)
+	}
+}
+
+func validateWizardRegion(value string) (string, error) {
+	result := (&validation.RegionValidator{}).Validate(value)
+	if !result.Valid {
+		return "", fmt.Errorf("invalid region: %s", strings.Join(result.Errors, "; "))
+	}
+	return result.Fields["region"].(string), nil
+}
+
+func validateWizardProfile(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+	result := (&validation.ProfileValidator{}).Validate(value)
+	if !result.Valid {
+		return "", fmt.Errorf("invalid profile: %s", strings.Join(result.Errors, "; "))
+	}
+	return value, nil
+}
+
+func validateWizardSecurityLevel(value string) (string, error) {
+	level, err := security.ParseLevel(value)
+	if err != nil {
+		return "", err
+	}
+	return string(level), nil
+}
+
+func validateWizardCacheTTL(value string) (string, error) {
+	ttl, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || ttl < 1 || ttl > maxCacheTTLMinutes {
+		return "", fmt.Errorf("cache TTL must be a whole number of minutes between 1 and %d", maxCacheTTLMinutes)
+	}
+	return strconv.Itoa(ttl), nil
+}
+
+func validateWizardDefaultCommand(value string) (string, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" || value == "none" {
+		return "none", nil
+	}
+	for _, choice := range defaultCommandChoices {
+		if value == choice {
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("default command must be one of: %s, none", strings.Join(defaultCommandChoices, ", "))
+}
+
+// printWizardSummary shows the values that are about to be written
+func printWizardSummary(out io.Writer, cfg *config.Config) {
+	profileValue := cfg.Default.Profile
+	if profileValue == "" {
+		profileValue = "(default credential chain)"
+	}
+	commandValue := cfg.Default.Command
+	if commandValue == "" {
+		commandValue = "(none - show help)"
+	}
+
+	fmt.Fprintln(out, "\nSummary:")
+	fmt.Fprintf(out, "  Region:          %s\n", cfg.Default.Region)
+	fmt.Fprintf(out, "  Profile:         %s\n", profileValue)
+	fmt.Fprintf(out, "  Security level:  %s\n", cfg.Security.Level)
+	fmt.Fprintf(out, "  Cache TTL:       %d minutes\n", cfg.Cache.TTLMinutes)
+	fmt.Fprintf(out, "  Default command: %s\n\n", commandValue)
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/johnlam90/aws-ssm/pkg/config"
+)
+
+// runWizardWithInput drives the setup wizard with one answer per line
+func runWizardWithInput(t *testing.T, lines ...string) string {
+	t.Helper()
+	var out bytes.Buffer
+	p := newLinePrompter(strings.NewReader(strings.Join(lines, "\n")+"\n"), &out)
+	if err := runSetupWizard(p, &out, ""); err != nil {
+		t.Fatalf("runSetupWizard failed: %v\noutput:\n%s", err, out.String())
+	}
+	return out.String()
+}
+
+func TestSetupWizardWritesConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	output := runWizardWithInput(t,
+		"eu-west-1", // region
+		"prod",      // profile
+		"high",      // security level
+		"15",        // cache TTL
+		"tui",       // default command
+		"y",         // write config
+	)
+
+	cfg, err := config.LoadConfig(filepath.Join(home, ".aws-ssm", "config.yaml"))
+	if err != nil {
+		t.Fatalf("failed to load written config: %v", err)
+	}
+	if cfg.Default.Region != "eu-west-1" {
+		t.Errorf("region = %q, want eu-west-1", cfg.Default.Region)
+	}
+	if cfg.Default.Profile != "prod" {
+		t.Errorf("profile = %q, want prod", cfg.Default.Profile)
+	}
+	if cfg.Security.Level != "high" {
+		t.Errorf("security level = %q, want high", cfg.Security.Level)
+	}
+	if cfg.Cache.TTLMinutes != 15 {
+		t.Errorf("cache TTL = %d, want 15", cfg.Cache.TTLMinutes)
+	}
+	if cfg.Default.Command != "tui" {
+		t.Errorf("default command = %q, want tui", cfg.Default.Command)
+	}
+	if !strings.Contains(output, "Summary:") {
+		t.Errorf("expected summary in output:\n%s", output)
+	}
+}
+
+func TestSetupWizardRepromptsOnInvalidInput(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	output := runWizardWithInput(t,
+		"not a region", "us-west-2",
+		"bad profile!", "",
+		"extreme", "strict",
+		"0", "abc", "30",
+		"ssh", "none",
+		"yes",
+	)
+
+	cfg, err := config.LoadConfig("")
+	if err != nil {
+		t.Fatalf("failed to load written config: %v", err)
+	}
+	if cfg.Default.Region != "us-west-2" || cfg.Default.Profile != "" || cfg.Security.Level != "strict" ||
+		cfg.Cache.TTLMinutes != 30 || cfg.Default.Command != "" {
+		t.Errorf("unexpected config: region=%q profile=%q level=%q ttl=%d command=%q",
+			cfg.Default.Region, cfg.Default.Profile, cfg.Security.Level, cfg.Cache.TTLMinutes, cfg.Default.Command)
+	}
+	for _, msg := range []string{"invalid region", "invalid profile", "invalid security level", "cache TTL must be", "default command must be"} {
+		if !strings.Contains(output, msg) {
+			t.Errorf("expected %q in output:\n%s", msg, output)
+		}
+	}
+}
+
+func TestSetupWizardUpdatesExistingConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	runWizardWithInput(t, "eu-west-1", "prod", "high", "15", "tui", "y")
+
+	// Accept the update, keep every existing value except the TTL
+	output := runWizardWithInput(t, "y", "", "", "", "45", "", "y")
+	if !strings.Contains(output, "Existing configuration found") {
+		t.Errorf("expected existing config notice in output:\n%s", output)
+	}
+	if !strings.Contains(output, "AWS region [eu-west-1]") {
+		t.Errorf("expected existing region offered as default:\n%s", output)
+	}
+
+	cfg, err := config.LoadConfig("")
+	if err != nil {
+		t.Fatalf("failed to load written config: %v", err)
+	}
+	if cfg.Default.Region != "eu-west-1" || cfg.Default.Profile != "prod" || cfg.Security.Level != "high" ||
+		cfg.Cache.TTLMinutes != 45 || cfg.Default.Command != "tui" {
+		t.Errorf("unexpected config after update: region=%q profile=%q level=%q ttl=%d command=%q",
+			cfg.Default.Region, cfg.Default.Profile, cfg.Security.Level, cfg.Cache.TTLMinutes, cfg.Default.Command)
+	}
+}
+
+func TestSetupWizardDeclineLeavesConfigUntouched(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	runWizardWithInput(t, "eu-west-1", "prod", "high", "15", "tui", "n")
+
+	if _, err := os.Stat(filepath.Join(home, ".aws-ssm", "config.yaml")); !os.IsNotExist(err) {
+		t.Fatalf("expected no config file when write is declined, stat err = %v", err)
+	}
+}
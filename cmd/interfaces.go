@@ -99,7 +99,7 @@ func runInterfaces(_ *cobra.Command, args []string) error {
 			// Check if user cancelled (Ctrl+C)
 			if err == context.Canceled {
 				fmt.Println("\nSelection cancelled.")
-				return nil
+				return errCancelled
 			}
 			return fmt.Errorf("failed to select instance: %w", err)
 		}
@@ -130,5 +130,11 @@ func runInterfaces(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to list network interfaces: %w", err)
 	}
 
+	// Orphaned ENIs are not attached to any instance above, so flag them separately.
+	// This is informational; a failure here should not fail the listing.
+	if orphans, err := client.ListOrphanedENIs(ctx); err == nil && len(orphans) > 0 {
+		fmt.Printf("\nWarning: %d unattached ENI(s) in %s; run 'aws-ssm eni orphans' to review\n", len(orphans), client.GetRegion())
+	}
+
 	return nil
 }
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+)
+
+// defaultLargeResultThreshold is used when the config does not set default.large_result_threshold
+const defaultLargeResultThreshold = 500
+
+// largeResultAction is the user's choice when a query returns too many instances
+type largeResultAction int
+
+const (
+	// largeResultShowAll continues with the full result set
+	largeResultShowAll largeResultAction = iota
+	// largeResultAddFilter re-runs the query with an extra tag filter
+	largeResultAddFilter
+	// largeResultCancel stops without printing anything
+	largeResultCancel
+)
+
+// exceedsThreshold reports whether count is large enough to warrant a prompt.
+// A non-positive threshold disables the check.
+func exceedsThreshold(count, threshold int) bool {
+	return threshold > 0 && count > threshold
+}
+
+// promptLargeResult explains that a query matched many instances and asks the
+// user to narrow it with a tag filter, show everything, or cancel. more means
+// listing stopped early, so count is only a lower bound. For
+// largeResultAddFilter the parsed Key and Value are returned.
+func promptLargeResult(p prompter, out io.Writer, count int, more bool, threshold int) (largeResultAction, string, string, error) {
+	if more {
+		fmt.Fprintf(out, "This query matched more than %d instances; listing stopped after %d.\n", threshold, count)
+	} else {
+		fmt.Fprintf(out, "This query matched %d instances (more than %d).\n", count, threshold)
+	}
+	fmt.Fprintln(out, "Narrow it with a tag filter, or use --limit to cap the output.")
+
+	for {
+		answer, err := p.Ask("Tag filter (Key=Value), 'all' to show everything, or 'q' to cancel", "q")
+		if err != nil {
+			return largeResultCancel, "", "", err
+		}
+
+		switch strings.ToLower(answer) {
+		case "all":
+			return largeResultShowAll, "", "", nil
+		case "q", "quit":
+			return largeResultCancel, "", "", nil
+		}
+
+		parts := strings.SplitN(answer, "=", 2)
+		if len(parts) == 2 && strings.TrimSpace(parts[0]) != "" {
+			return largeResultAddFilter, strings.TrimSpace(parts[0]), parts[1], nil
+		}
+		fmt.Fprintf(out, "  invalid filter %q (expected Key=Value)\n", answer)
+	}
+}
+
+// limitInstances truncates instances to at most limit entries. A non-positive
+// limit returns the slice unchanged. The original count is also returned.
+func limitInstances(instances []aws.Instance, limit int) ([]aws.Instance, int) {
+	total := len(instances)
+	if limit <= 0 || total <= limit {
+		return instances, total
+	}
+	return instances[:limit], total
+}
+
+// largeResultThreshold returns the configured prompt threshold for a client
+func largeResultThreshold(client *aws.Client) int {
+	if client.AppConfig == nil {
+		return defaultLargeResultThreshold
+	}
+	return client.AppConfig.Default.LargeResultThreshold
+}
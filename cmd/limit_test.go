@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+)
+
+func TestExceedsThreshold(t *testing.T) {
+	tests := []struct {
+		count, threshold int
+		want             bool
+	}{
+		{count: 10, threshold: 500, want: false},
+		{count: 500, threshold: 500, want: false},
+		{count: 501, threshold: 500, want: true},
+		{count: 5000, threshold: 0, want: false},
+		{count: 5000, threshold: -1, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := exceedsThreshold(tt.count, tt.threshold); got != tt.want {
+			t.Errorf("exceedsThreshold(%d, %d) = %v, want %v", tt.count, tt.threshold, got, tt.want)
+		}
+	}
+}
+
+func TestPromptLargeResult(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantAction largeResultAction
+		wantKey    string
+		wantValue  string
+		wantOutput string
+	}{
+		{name: "show all", input: "all\n", wantAction: largeResultShowAll},
+		{name: "default cancels", input: "\n", wantAction: largeResultCancel},
+		{name: "explicit cancel", input: "q\n", wantAction: largeResultCancel},
+		{name: "add filter", input: "Env=stage\n", wantAction: largeResultAddFilter, wantKey: "Env", wantValue: "stage"},
+		{
+			name:       "invalid filter is re-asked",
+			input:      "stage\n=x\nTeam=web\n",
+			wantAction: largeResultAddFilter,
+			wantKey:    "Team",
+			wantValue:  "web",
+			wantOutput: `invalid filter "stage"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			p := newLinePrompter(strings.NewReader(tt.input), &out)
+
+			action, key, value, err := promptLargeResult(p, &out, 1200, false, 500)
+			if err != nil {
+				t.Fatalf("promptLargeResult returned error: %v", err)
+			}
+			if action != tt.wantAction || key != tt.wantKey || value != tt.wantValue {
+				t.Errorf("got (%v, %q, %q), want (%v, %q, %q)", action, key, value, tt.wantAction, tt.wantKey, tt.wantValue)
+			}
+			if !strings.Contains(out.String(), "matched 1200 instances (more than 500)") {
+				t.Errorf("expected count and threshold in output:\n%s", out.String())
+			}
+			if tt.wantOutput != "" && !strings.Contains(out.String(), tt.wantOutput) {
+				t.Errorf("expected %q in output:\n%s", tt.wantOutput, out.String())
+			}
+		})
+	}
+}
+
+func TestPromptLargeResultStoppedEarly(t *testing.T) {
+	var out bytes.Buffer
+	p := newLinePrompter(strings.NewReader("q\n"), &out)
+
+	if _, _, _, err := promptLargeResult(p, &out, 1000, true, 500); err != nil {
+		t.Fatalf("promptLargeResult returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), "more than 500 instances; listing stopped after 1000") {
+		t.Errorf("expected the partial count to be reported as a lower bound:\n%s", out.String())
+	}
+}
+
+func TestLimitInstances(t *testing.T) {
+	instances := make([]aws.Instance, 5)
+	for i := range instances {
+		instances[i].InstanceID = string(rune('a' + i))
+	}
+
+	tests := []struct {
+		limit     int
+		wantLen   int
+		wantTotal int
+	}{
+		{limit: 0, wantLen: 5, wantTotal: 5},
+		{limit: -1, wantLen: 5, wantTotal: 5},
+		{limit: 3, wantLen: 3, wantTotal: 5},
+		{limit: 5, wantLen: 5, wantTotal: 5},
+		{limit: 10, wantLen: 5, wantTotal: 5},
+	}
+
+	for _, tt := range tests {
+		got, total := limitInstances(instances, tt.limit)
+		if len(got) != tt.wantLen || total != tt.wantTotal {
+			t.Errorf("limitInstances(limit=%d) = len %d total %d, want len %d total %d", tt.limit, len(got), total, tt.wantLen, tt.wantTotal)
+		}
+		if len(got) > 0 && got[0].InstanceID != "a" {
+			t.Errorf("limitInstances(limit=%d) should keep leading instances, got first %q", tt.limit, got[0].InstanceID)
+		}
+	}
+}
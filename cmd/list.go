@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"strings"
@@ -10,12 +11,23 @@ import (
 	"text/tabwriter"
 
 	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/johnlam90/aws-ssm/pkg/config"
 	"github.com/spf13/cobra"
 )
 
+// nameTagKey is the tag EC2 instance names are read from
+const nameTagKey = "Name"
+
 var (
-	tagFilter []string
-	allStates bool
+	tagFilter          []string
+	allStates          bool
+	listLimit          int
+	minVolumeSize      int64
+	unencryptedVolumes bool
+	listTagColumns     []string
+	listSort           string
+	listPlatform       string
+	listWide           bool
 )
 
 var listCmd = &cobra.Command{
@@ -34,7 +46,23 @@ Examples:
   aws-ssm list --tag Environment=production --tag Team=backend
 
   # List instances in a specific region
-  aws-ssm list --region us-west-2`,
+  aws-ssm list --region us-west-2
+
+  # Find instances with a volume of 500 GiB or more, or with unencrypted volumes
+  aws-ssm list --min-volume-size 500
+  aws-ssm list --unencrypted-volumes
+
+  # Show the Environment and Team tags as extra columns
+  aws-ssm list --tag-column Environment --tag-column Team
+
+  # Newest instances first
+  aws-ssm list --sort launch-time:desc
+
+  # Windows instances only, with the PLATFORM column
+  aws-ssm list --platform windows --wide
+
+  # Print running instance IDs as JSON
+  aws-ssm list --output json --select 'instances[?State==` + "`running`" + `].InstanceID'`,
 	RunE: runList,
 }
 
@@ -42,9 +70,25 @@ func init() {
 	rootCmd.AddCommand(listCmd)
 	listCmd.Flags().StringSliceVarP(&tagFilter, "tag", "t", []string{}, "Filter by tags (format: Key=Value)")
 	listCmd.Flags().BoolVarP(&allStates, "all", "a", false, "Show instances in all states (not just running)")
+	listCmd.Flags().IntVar(&listLimit, "limit", 0, "Maximum number of instances to show (0 = no limit)")
+	listCmd.Flags().Int64Var(&minVolumeSize, "min-volume-size", 0, "Only show instances with an attached EBS volume of at least this many GiB")
+	listCmd.Flags().BoolVar(&unencryptedVolumes, "unencrypted-volumes", false, "Only show instances with at least one unencrypted EBS volume")
+	listCmd.Flags().StringSliceVar(&listTagColumns, "tag-column", nil, "Add a table column with this tag's value (repeatable)")
+	listCmd.Flags().StringVar(&listPlatform, "platform", "", "Only show instances on this platform (linux or windows)")
+	listCmd.Flags().BoolVar(&listWide, "wide", false, "Show extra columns (platform)")
+	listCmd.Flags().StringVar(&listSort, "sort", "", "Sort by name, id, state, type or launch-time, optionally with :asc or :desc (default: default.sort from config)")
 }
 
 func runList(_ *cobra.Command, _ []string) error {
+	if err := validateOutputFlags(); err != nil {
+		return err
+	}
+
+	filters, err := buildListFilters()
+	if err != nil {
+		return err
+	}
+
 	// Create a context that can be cancelled with Ctrl+C
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
@@ -55,20 +99,35 @@ func runList(_ *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to create AWS client: %w", err)
 	}
 
-	// Parse tag filters
-	tagFilters := make(map[string]string)
-	for _, tag := range tagFilter {
-		parts := strings.SplitN(tag, "=", 2)
-		if len(parts) != 2 {
-			return fmt.Errorf("invalid tag format: %s (expected Key=Value)", tag)
-		}
-		tagFilters[parts[0]] = parts[1]
+	instanceSort, err := resolveInstanceSort(listSort, client.AppConfig)
+	if err != nil {
+		return err
 	}
 
-	// List instances
-	instances, err := client.ListInstances(ctx, tagFilters)
+	instances, cancelled, err := listInstancesNarrowing(ctx, client, filters.Tags)
 	if err != nil {
-		return fmt.Errorf("failed to list instances: %w", err)
+		return err
+	}
+	if cancelled {
+		fmt.Println("Listing cancelled")
+		return nil
+	}
+
+	// Volume filters need a DescribeVolumes pass, so only pay for it when asked
+	if !filters.Volumes.IsZero() {
+		if err := client.LoadVolumeSummaries(ctx, instances); err != nil {
+			return err
+		}
+		instances = aws.FilterInstancesByVolumes(instances, filters.Volumes)
+	}
+	instances = aws.FilterInstancesByPlatform(instances, filters.Platform)
+
+	// Sort before limiting so --limit keeps the first instances in sort order
+	aws.SortInstances(instances, instanceSort)
+	instances, total := limitInstances(instances, listLimit)
+
+	if isJSONOutput() {
+		return printListJSON(maskInstanceTags(instances, sensitiveTagMask(client)))
 	}
 
 	if len(instances) == 0 {
@@ -77,39 +136,184 @@ func runList(_ *cobra.Command, _ []string) error {
 	}
 
 	// Display instances in a table
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	if _, err := fmt.Fprintln(w, "INSTANCE ID\tNAME\tSTATE\tINSTANCE TYPE\tPRIVATE IP\tPUBLIC IP\tAVAILABILITY ZONE"); err != nil {
-		return fmt.Errorf("failed to write table header: %w", err)
+	table := instanceTableOptions{
+		ShowVolumes:  !filters.Volumes.IsZero(),
+		ShowPlatform: listWide,
+		TagColumns:   listTagColumns,
+		TagMask:      sensitiveTagMask(client),
+		MaxRows:      tableMaxRows(client.AppConfig),
 	}
-	if _, err := fmt.Fprintln(w, strings.Repeat("-", 11)+"\t"+strings.Repeat("-", 4)+"\t"+strings.Repeat("-", 5)+"\t"+strings.Repeat("-", 13)+"\t"+strings.Repeat("-", 10)+"\t"+strings.Repeat("-", 9)+"\t"+strings.Repeat("-", 17)); err != nil {
-		return fmt.Errorf("failed to write table separator: %w", err)
+	if err := printInstanceTable(os.Stdout, instances, table); err != nil {
+		return err
 	}
 
-	for _, instance := range instances {
-		// Skip non-running instances unless --all flag is set
-		if !allStates && instance.State != "running" {
-			continue
+	if total > len(instances) {
+		fmt.Printf("\nShowing first %d of %d instances (adjust with --limit)\n", len(instances), total)
+	}
+
+	return nil
+}
+
+// listFilters are the instance filters given on the list command line
+type listFilters struct {
+	Tags     map[string]string
+	Volumes  aws.VolumeFilter
+	Platform string
+}
+
+// buildListFilters parses and validates the --tag, volume and --platform flags
+func buildListFilters() (listFilters, error) {
+	if minVolumeSize < 0 {
+		return listFilters{}, usageErrorf("--min-volume-size must not be negative, got %d", minVolumeSize)
+	}
+	platform, err := aws.ParsePlatform(listPlatform)
+	if err != nil {
+		return listFilters{}, usageErrorf("invalid --platform: %v", err)
+	}
+
+	tags := make(map[string]string)
+	for _, tag := range tagFilter {
+		parts := strings.SplitN(tag, "=", 2)
+		if len(parts) != 2 {
+			return listFilters{}, fmt.Errorf("invalid tag format: %s (expected Key=Value)", tag)
+		}
+		tags[parts[0]] = parts[1]
+	}
+
+	return listFilters{
+		Tags:     tags,
+		Volumes:  aws.VolumeFilter{MinVolumeSizeGiB: minVolumeSize, UnencryptedVolumes: unencryptedVolumes},
+		Platform: platform,
+	}, nil
+}
+
+// listInstancesNarrowing lists instances, offering to narrow the query when it
+// is unexpectedly large. Listing stops at the first page past the threshold so
+// the prompt comes before every page is fetched; cancelled is set when the user
+// gives up instead.
+func listInstancesNarrowing(ctx context.Context, client *aws.Client, tagFilters map[string]string) ([]aws.Instance, bool, error) {
+	threshold := largeResultThreshold(client)
+	canPrompt := listLimit <= 0 && !isJSONOutput() && stdinIsTerminal()
+	fetchLimit := 0
+	if canPrompt {
+		fetchLimit = threshold
+	}
+	p := newLinePrompter(os.Stdin, os.Stdout)
+
+	for {
+		instances, more, err := listInstancesWithProgress(ctx, client, tagFilters, fetchLimit)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to list instances: %w", err)
+		}
+		if !canPrompt || !exceedsThreshold(len(instances), threshold) {
+			return instances, false, nil
+		}
+
+		action, key, value, err := promptLargeResult(p, os.Stdout, len(instances), more, threshold)
+		if err != nil {
+			return nil, false, err
 		}
+		switch action {
+		case largeResultCancel:
+			return nil, true, nil
+		case largeResultShowAll:
+			if !more {
+				return instances, false, nil
+			}
+			instances, _, err = listInstancesWithProgress(ctx, client, tagFilters, 0)
+			if err != nil {
+				return nil, false, fmt.Errorf("failed to list instances: %w", err)
+			}
+			return instances, false, nil
+		}
+		tagFilters[key] = value
+	}
+}
+
+// listInstancesWithProgress lists instances behind a spinner that counts the
+// instances fetched so far, stopping after the page that passes a positive
+// limit; JSON output stays free of progress text
+func listInstancesWithProgress(ctx context.Context, client *aws.Client, tagFilters map[string]string, limit int) ([]aws.Instance, bool, error) {
+	if isJSONOutput() {
+		return client.ListInstancesUpTo(ctx, tagFilters, limit, nil)
+	}
+
+	s := createLoadingSpinner("Listing instances...")
+	s.Start()
+	defer s.Stop()
+	return client.ListInstancesUpTo(ctx, tagFilters, limit, func(_, items int) {
+		s.Lock()
+		s.Suffix = fmt.Sprintf(" Listing instances... %d fetched", items)
+		s.Unlock()
+	})
+}
 
-		name := instance.Name
-		if name == "" {
-			name = "-"
+// resolveInstanceSort returns the instance order from the --sort flag, falling
+// back to default.sort from the config
+func resolveInstanceSort(flagValue string, cfg *config.Config) (aws.InstanceSort, error) {
+	if strings.TrimSpace(flagValue) != "" {
+		s, err := aws.ParseInstanceSort(flagValue)
+		if err != nil {
+			return aws.InstanceSort{}, usageErrorf("invalid --sort: %v", err)
 		}
+		return s, nil
+	}
+	if cfg == nil {
+		return aws.InstanceSort{}, nil
+	}
+	s, err := aws.ParseInstanceSort(cfg.Default.Sort)
+	if err != nil {
+		return aws.InstanceSort{}, fmt.Errorf("invalid default.sort in config: %w", err)
+	}
+	return s, nil
+}
+
+// instanceTableOptions selects the optional columns of the list table
+type instanceTableOptions struct {
+	ShowVolumes  bool
+	ShowPlatform bool
+	TagColumns   []string // Tag keys shown as extra columns, in order
+	TagMask      config.TagMask
+	MaxRows      int // Rows printed before a "showing X of Y" footer; 0 prints all
+}
 
-		publicIP := instance.PublicIP
-		if publicIP == "" {
-			publicIP = "-"
+// printInstanceTable renders instances as a table, skipping non-running
+// instances unless --all is set
+func printInstanceTable(out io.Writer, instances []aws.Instance, opts instanceTableOptions) error {
+	header := "INSTANCE ID\tNAME\tSTATE\tINSTANCE TYPE\tPRIVATE IP\tPUBLIC IP\tAVAILABILITY ZONE"
+	separator := strings.Repeat("-", 11) + "\t" + strings.Repeat("-", 4) + "\t" + strings.Repeat("-", 5) + "\t" + strings.Repeat("-", 13) + "\t" + strings.Repeat("-", 10) + "\t" + strings.Repeat("-", 9) + "\t" + strings.Repeat("-", 17)
+	if opts.ShowPlatform {
+		header += "\tPLATFORM"
+		separator += "\t" + strings.Repeat("-", 8)
+	}
+	if opts.ShowVolumes {
+		header += "\tVOLUMES"
+		separator += "\t" + strings.Repeat("-", 7)
+	}
+	for _, key := range opts.TagColumns {
+		header += "\t" + key
+		separator += "\t" + strings.Repeat("-", len(key))
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	if _, err := fmt.Fprintln(w, header); err != nil {
+		return fmt.Errorf("failed to write table header: %w", err)
+	}
+	if _, err := fmt.Fprintln(w, separator); err != nil {
+		return fmt.Errorf("failed to write table separator: %w", err)
+	}
+
+	// Skip non-running instances unless --all flag is set
+	visible := make([]aws.Instance, 0, len(instances))
+	for _, instance := range instances {
+		if allStates || instance.State == "running" {
+			visible = append(visible, instance)
 		}
+	}
 
-		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-			instance.InstanceID,
-			name,
-			instance.State,
-			instance.InstanceType,
-			instance.PrivateIP,
-			publicIP,
-			instance.AvailabilityZone,
-		); err != nil {
+	shown := cappedRows(len(visible), opts.MaxRows)
+	for _, instance := range visible[:shown] {
+		row := strings.Join(instanceTableRow(instance, opts), "\t")
+		if _, err := fmt.Fprintln(w, row); err != nil {
 			return fmt.Errorf("failed to write table row: %w", err)
 		}
 	}
@@ -117,6 +321,93 @@ func runList(_ *cobra.Command, _ []string) error {
 	if err := w.Flush(); err != nil {
 		return fmt.Errorf("failed to flush table writer: %w", err)
 	}
+	return writeTruncationFooter(out, shown, len(visible))
+}
 
-	return nil
+// instanceTableRow returns the table cells for one instance
+func instanceTableRow(instance aws.Instance, opts instanceTableOptions) []string {
+	// The name comes from the Name tag, so it is masked like that tag
+	name := opts.TagMask.Value(nameTagKey, instance.Name)
+	if instance.Name == "" {
+		name = "-"
+	}
+
+	publicIP := instance.PublicIP
+	if publicIP == "" {
+		publicIP = "-"
+	}
+
+	row := []string{
+		instance.InstanceID,
+		name,
+		instance.State,
+		instance.InstanceType,
+		instance.PrivateIP,
+		publicIP,
+		instance.AvailabilityZone,
+	}
+	if opts.ShowPlatform {
+		platform := instance.Platform
+		if platform == "" {
+			platform = "-"
+		}
+		row = append(row, platform)
+	}
+	if opts.ShowVolumes {
+		row = append(row, formatVolumeSummary(instance.Volumes))
+	}
+	for _, key := range opts.TagColumns {
+		// Missing tags leave the cell blank; only values that exist are masked
+		value, ok := instance.Tags[key]
+		if ok {
+			value = opts.TagMask.Value(key, value)
+		}
+		row = append(row, value)
+	}
+	return row
+}
+
+// formatVolumeSummary renders a volume summary such as "2 vols, 508 GiB, 1 unencrypted"
+func formatVolumeSummary(summary *aws.VolumeSummary) string {
+	if summary == nil || summary.Count == 0 {
+		return "-"
+	}
+	text := fmt.Sprintf("%d vols, %d GiB", summary.Count, summary.TotalSizeGiB)
+	if summary.Encrypted() {
+		return text + ", encrypted"
+	}
+	return fmt.Sprintf("%s, %d unencrypted", text, summary.UnencryptedCount)
+}
+
+// maskInstanceTags returns copies of instances with sensitive tag values, and
+// the name when the Name tag is sensitive, redacted
+func maskInstanceTags(instances []aws.Instance, mask config.TagMask) []aws.Instance {
+	if !mask.Enabled() {
+		return instances
+	}
+	masked := make([]aws.Instance, len(instances))
+	for i, instance := range instances {
+		instance.Tags = mask.Apply(instance.Tags)
+		if instance.Name != "" {
+			instance.Name = mask.Value(nameTagKey, instance.Name)
+		}
+		masked[i] = instance
+	}
+	return masked
+}
+
+// printListJSON prints the listed instances as a JSON document
+func printListJSON(instances []aws.Instance) error {
+	filtered := make([]aws.Instance, 0, len(instances))
+	for _, instance := range instances {
+		// Skip non-running instances unless --all flag is set
+		if !allStates && instance.State != "running" {
+			continue
+		}
+		filtered = append(filtered, instance)
+	}
+
+	return printJSON(map[string]interface{}{
+		"instances": filtered,
+	})
 }
@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/johnlam90/aws-ssm/pkg/config"
+)
+
+func TestFormatVolumeSummary(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary *aws.VolumeSummary
+		want    string
+	}{
+		{name: "not loaded", summary: nil, want: "-"},
+		{name: "no volumes", summary: &aws.VolumeSummary{}, want: "-"},
+		{name: "encrypted", summary: &aws.VolumeSummary{Count: 1, TotalSizeGiB: 8, LargestSizeGiB: 8}, want: "1 vols, 8 GiB, encrypted"},
+		{name: "mixed", summary: &aws.VolumeSummary{Count: 2, TotalSizeGiB: 508, LargestSizeGiB: 500, UnencryptedCount: 1}, want: "2 vols, 508 GiB, 1 unencrypted"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatVolumeSummary(tt.summary); got != tt.want {
+				t.Errorf("formatVolumeSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaskInstanceTagsJSON(t *testing.T) {
+	instances := []aws.Instance{
+		{InstanceID: "i-111", Tags: map[string]string{"Owner": "alice@example.com", "Env": "prod"}},
+	}
+
+	masked := maskInstanceTags(instances, config.NewTagMask([]string{"owner"}))
+	raw, err := json.Marshal(map[string]interface{}{"instances": masked})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	out := string(raw)
+	if strings.Contains(out, "alice@example.com") {
+		t.Errorf("JSON output leaks the masked tag value: %s", out)
+	}
+	if !strings.Contains(out, `"Owner":"***"`) || !strings.Contains(out, `"Env":"prod"`) {
+		t.Errorf("JSON output = %s, want Owner masked and Env shown", out)
+	}
+	if instances[0].Tags["Owner"] != "alice@example.com" {
+		t.Error("maskInstanceTags() modified the listed instances")
+	}
+}
+
+func TestInstanceTableTagColumns(t *testing.T) {
+	opts := instanceTableOptions{
+		TagColumns: []string{"Environment", "Team", "Owner"},
+		TagMask:    config.NewTagMask([]string{"owner"}),
+	}
+	tests := []struct {
+		name     string
+		instance aws.Instance
+		want     []string
+	}{
+		{
+			name:     "all tags present",
+			instance: aws.Instance{InstanceID: "i-111", Tags: map[string]string{"Environment": "prod", "Team": "payments", "Owner": "alice"}},
+			want:     []string{"prod", "payments", config.MaskedTagValue},
+		},
+		{
+			name:     "missing tags are blank",
+			instance: aws.Instance{InstanceID: "i-222", Tags: map[string]string{"Team": "data"}},
+			want:     []string{"", "data", ""},
+		},
+		{
+			name:     "untagged instance",
+			instance: aws.Instance{InstanceID: "i-333"},
+			want:     []string{"", "", ""},
+		},
+		{
+			name:     "empty sensitive value is masked",
+			instance: aws.Instance{InstanceID: "i-444", Tags: map[string]string{"Owner": ""}},
+			want:     []string{"", "", config.MaskedTagValue},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row := instanceTableRow(tt.instance, opts)
+			if row[0] != tt.instance.InstanceID {
+				t.Errorf("first cell = %q, want instance ID", row[0])
+			}
+			got := row[len(row)-len(opts.TagColumns):]
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("tag cells = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstanceTableMasksName(t *testing.T) {
+	opts := instanceTableOptions{TagMask: config.NewTagMask([]string{"name"})}
+
+	row := instanceTableRow(aws.Instance{InstanceID: "i-111", Name: "payroll-db", Tags: map[string]string{"Name": "payroll-db"}}, opts)
+	if row[1] != config.MaskedTagValue {
+		t.Errorf("name cell = %q, want it masked like the Name tag", row[1])
+	}
+	row = instanceTableRow(aws.Instance{InstanceID: "i-222"}, opts)
+	if row[1] != "-" {
+		t.Errorf("name cell without a name = %q, want -", row[1])
+	}
+	row = instanceTableRow(aws.Instance{InstanceID: "i-333", Name: "web"}, instanceTableOptions{TagMask: config.NewTagMask([]string{"owner"})})
+	if row[1] != "web" {
+		t.Errorf("name cell = %q, want web when Name is not sensitive", row[1])
+	}
+
+	masked := maskInstanceTags([]aws.Instance{{InstanceID: "i-111", Name: "payroll-db"}}, opts.TagMask)
+	if masked[0].Name != config.MaskedTagValue {
+		t.Errorf("JSON name = %q, want it masked", masked[0].Name)
+	}
+}
+
+func TestPrintInstanceTableTagColumnHeaders(t *testing.T) {
+	instances := []aws.Instance{
+		{InstanceID: "i-111", State: "running", Tags: map[string]string{"Environment": "prod"}},
+		{InstanceID: "i-222", State: "running"},
+	}
+
+	var out strings.Builder
+	if err := printInstanceTable(&out, instances, instanceTableOptions{TagColumns: []string{"Environment", "Team"}}); err != nil {
+		t.Fatalf("printInstanceTable() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want header, separator and 2 rows:\n%s", len(lines), out.String())
+	}
+	if !strings.HasSuffix(lines[0], "Environment   Team") {
+		t.Errorf("header = %q, want tag columns appended in order", lines[0])
+	}
+	envCol := strings.Index(lines[0], "Environment")
+	if got := lines[2][envCol : envCol+len("prod")]; got != "prod" {
+		t.Errorf("Environment cell = %q, want prod aligned under its header", got)
+	}
+	if len(strings.TrimRight(lines[3], " ")) > envCol {
+		t.Errorf("row without tags = %q, want blank tag cells", lines[3])
+	}
+}
+
+func TestResolveInstanceSort(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Default.Sort = "launch-time:desc"
+	bad := &config.Config{}
+	bad.Default.Sort = "uptime"
+
+	tests := []struct {
+		name    string
+		flag    string
+		cfg     *config.Config
+		want    aws.InstanceSort
+		wantErr bool
+	}{
+		{name: "no flag or config keeps API order"},
+		{name: "config default applies", cfg: cfg, want: aws.NewestFirst},
+		{name: "flag overrides config", flag: "name", cfg: cfg, want: aws.InstanceSort{Field: aws.SortFieldName}},
+		{name: "invalid flag", flag: "name:up", cfg: cfg, wantErr: true},
+		{name: "invalid config", cfg: bad, wantErr: true},
+		{name: "flag wins over invalid config", flag: "id:desc", cfg: bad, want: aws.InstanceSort{Field: aws.SortFieldID, Descending: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveInstanceSort(tt.flag, tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveInstanceSort() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveInstanceSort() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintInstanceTablePlatformFilter(t *testing.T) {
+	instances := []aws.Instance{
+		{InstanceID: "i-win-running", State: "running", Platform: aws.PlatformWindows},
+		{InstanceID: "i-win-stopped", State: "stopped", Platform: aws.PlatformWindows},
+		{InstanceID: "i-lnx-running", State: "running", Platform: aws.PlatformLinux},
+	}
+
+	tests := []struct {
+		name     string
+		platform string
+		all      bool
+		want     []string
+	}{
+		{name: "windows running", platform: aws.PlatformWindows, want: []string{"i-win-running"}},
+		{name: "windows all states", platform: aws.PlatformWindows, all: true, want: []string{"i-win-running", "i-win-stopped"}},
+		{name: "linux", platform: aws.PlatformLinux, want: []string{"i-lnx-running"}},
+		{name: "any platform", want: []string{"i-win-running", "i-lnx-running"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := allStates
+			allStates = tt.all
+			defer func() { allStates = old }()
+
+			var out strings.Builder
+			filtered := aws.FilterInstancesByPlatform(instances, tt.platform)
+			if err := printInstanceTable(&out, filtered, instanceTableOptions{ShowPlatform: true}); err != nil {
+				t.Fatalf("printInstanceTable() error = %v", err)
+			}
+
+			lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+			if !strings.Contains(lines[0], "PLATFORM") {
+				t.Errorf("header = %q, want a PLATFORM column", lines[0])
+			}
+			rows := lines[2:]
+			if len(rows) != len(tt.want) {
+				t.Fatalf("got rows:\n%s\nwant %v", strings.Join(rows, "\n"), tt.want)
+			}
+			for i, row := range rows {
+				fields := strings.Fields(row)
+				if fields[0] != tt.want[i] {
+					t.Errorf("row %d = %s, want %s", i, fields[0], tt.want[i])
+				}
+				if platform := fields[len(fields)-1]; platform != "windows" && platform != "linux" {
+					t.Errorf("row %d platform cell = %q", i, platform)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/johnlam90/aws-ssm/pkg/config"
+)
+
+// applyLocalConfig applies the directory-local config found from dir. Values
+// only fill gaps: --region/--profile and already-set environment variables win,
+// and the global config is consulted later only for what is still unset. Env
+// keys outside the allowlist are refused, and every key that is applied or
+// refused is reported to notes with the file it came from.
+func applyLocalConfig(dir string, notes io.Writer) (*config.LocalConfig, error) {
+	local, err := config.LoadLocalConfig(dir)
+	if err != nil || local == nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(local.Env))
+	for key := range local.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !config.LocalEnvAllowed(key) {
+			fmt.Fprintf(notes, "Warning: refusing to set %s from %s: not allowed in a directory-local config\n", key, local.Path)
+			continue
+		}
+		if _, set := os.LookupEnv(key); set {
+			continue
+		}
+		if err := os.Setenv(key, local.Env[key]); err != nil {
+			return nil, fmt.Errorf("failed to set %s from %s: %w", key, local.Path, err)
+		}
+		fmt.Fprintf(notes, "Set %s from %s\n", key, local.Path)
+	}
+
+	if region == "" && os.Getenv("AWS_REGION") == "" {
+		region = local.Region
+	}
+	if profile == "" && os.Getenv("AWS_PROFILE") == "" {
+		profile = local.Profile
+	}
+	return local, nil
+}
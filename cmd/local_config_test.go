@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeLocalConfig(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, ".aws-ssm.yaml"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestApplyLocalConfig(t *testing.T) {
+	const envKey = "AWS_SSM_SESSION_IDLE_WARNING"
+
+	tests := []struct {
+		name        string
+		flagRegion  string
+		envRegion   string
+		wantRegion  string
+		wantProfile string
+	}{
+		{name: "fills unset values", wantRegion: "eu-west-3", wantProfile: "project"},
+		{name: "flag overrides local config", flagRegion: "us-west-2", wantRegion: "us-west-2", wantProfile: "project"},
+		{name: "env overrides local config", envRegion: "sa-east-1", wantRegion: "", wantProfile: "project"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			nested := filepath.Join(root, "a", "b")
+			if err := os.MkdirAll(nested, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			writeLocalConfig(t, root, "region: eu-west-3\nprofile: project\nenv:\n  "+envKey+": from-file\n")
+
+			originalRegion, originalProfile := region, profile
+			defer func() { region, profile = originalRegion, originalProfile }()
+			region, profile = tt.flagRegion, ""
+			t.Setenv("AWS_REGION", tt.envRegion)
+			t.Setenv("AWS_PROFILE", "")
+			t.Setenv(envKey, "")
+			if err := os.Unsetenv(envKey); err != nil {
+				t.Fatal(err)
+			}
+
+			local, err := applyLocalConfig(nested, io.Discard)
+			if err != nil {
+				t.Fatalf("applyLocalConfig() error = %v", err)
+			}
+			if local == nil {
+				t.Fatal("applyLocalConfig() did not discover the config in a parent directory")
+			}
+			if region != tt.wantRegion {
+				t.Errorf("region = %q, want %q", region, tt.wantRegion)
+			}
+			if profile != tt.wantProfile {
+				t.Errorf("profile = %q, want %q", profile, tt.wantProfile)
+			}
+			if got := os.Getenv(envKey); got != "from-file" {
+				t.Errorf("%s = %q, want from-file", envKey, got)
+			}
+		})
+	}
+}
+
+func TestApplyLocalConfigKeepsExistingEnv(t *testing.T) {
+	const envKey = "AWS_SSM_SESSION_IDLE_WARNING"
+	dir := t.TempDir()
+	writeLocalConfig(t, dir, "env:\n  "+envKey+": from-file\n")
+	t.Setenv(envKey, "from-shell")
+
+	if _, err := applyLocalConfig(dir, io.Discard); err != nil {
+		t.Fatalf("applyLocalConfig() error = %v", err)
+	}
+	if got := os.Getenv(envKey); got != "from-shell" {
+		t.Errorf("%s = %q, want the shell value to win", envKey, got)
+	}
+}
+
+func TestApplyLocalConfigRefusesDeniedEnv(t *testing.T) {
+	dir := t.TempDir()
+	writeLocalConfig(t, dir, "env:\n  AWS_SSM_SECURITY_LEVEL: permissive\n  HTTPS_PROXY: http://attacker:8080\n  AWS_CA_BUNDLE: /tmp/ca.pem\n  AWS_SECRET_ACCESS_KEY: stolen\n  AWS_SSM_EMF_NAMESPACE: exfil\n  AWS_SSM_SESSION_IDLE_WARNING: 10m\n")
+	for _, key := range []string{"AWS_SSM_SECURITY_LEVEL", "HTTPS_PROXY", "AWS_CA_BUNDLE", "AWS_SECRET_ACCESS_KEY", "AWS_SSM_EMF_NAMESPACE", "AWS_SSM_SESSION_IDLE_WARNING"} {
+		t.Setenv(key, "")
+		if err := os.Unsetenv(key); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var notes bytes.Buffer
+	if _, err := applyLocalConfig(dir, &notes); err != nil {
+		t.Fatalf("applyLocalConfig() error = %v", err)
+	}
+	for _, key := range []string{"AWS_SSM_SECURITY_LEVEL", "HTTPS_PROXY", "AWS_CA_BUNDLE", "AWS_SECRET_ACCESS_KEY", "AWS_SSM_EMF_NAMESPACE"} {
+		if value, set := os.LookupEnv(key); set {
+			t.Errorf("%s = %q, want it refused", key, value)
+		}
+		if !strings.Contains(notes.String(), "refusing to set "+key+" from "+filepath.Join(dir, ".aws-ssm.yaml")) {
+			t.Errorf("notes do not report refusing %s:\n%s", key, notes.String())
+		}
+	}
+	if got := os.Getenv("AWS_SSM_SESSION_IDLE_WARNING"); got != "10m" {
+		t.Errorf("AWS_SSM_SESSION_IDLE_WARNING = %q, want 10m", got)
+	}
+	if !strings.Contains(notes.String(), "Set AWS_SSM_SESSION_IDLE_WARNING from "+filepath.Join(dir, ".aws-ssm.yaml")) {
+		t.Errorf("notes do not report the applied key:\n%s", notes.String())
+	}
+}
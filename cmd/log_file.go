@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/johnlam90/aws-ssm/pkg/logging"
+)
+
+var (
+	logFile string
+	// logFileOpened is the path already attached to the logger
+	logFileOpened string
+)
+
+// attachLogFile tees the application logs to a size-rotated file at path
+func attachLogFile(path string) error {
+	if path == "" || path == logFileOpened {
+		return nil
+	}
+	file, err := logging.NewRotatingFile(path, logging.DefaultLogFileMaxBytes, logging.DefaultLogFileBackups)
+	if err != nil {
+		return fmt.Errorf("invalid --log-file: %w", err)
+	}
+	logging.AddOutput(file)
+	logFileOpened = path
+	return nil
+}
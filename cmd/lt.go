@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/spf13/cobra"
+)
+
+var ltCmd = &cobra.Command{
+	Use:     "lt",
+	Aliases: []string{"launch-template"},
+	Short:   "Inspect EC2 launch templates",
+	Long: `Inspect EC2 launch templates outside the interactive pickers.
+
+Examples:
+  # List all versions of a launch template
+  aws-ssm lt versions lt-0123456789abcdef0`,
+}
+
+var ltVersionsCmd = &cobra.Command{
+	Use:   "versions <launch-template-id>",
+	Short: "List versions of a launch template",
+	Long: `List every version of a launch template with its AMI, instance type, creation
+date, and whether it is the default or latest version. Newest versions are shown first.
+
+Examples:
+  # Table output
+  aws-ssm lt versions lt-0123456789abcdef0
+
+  # Machine-readable output
+  aws-ssm lt versions lt-0123456789abcdef0 --output json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLTVersions,
+}
+
+func init() {
+	rootCmd.AddCommand(ltCmd)
+	ltCmd.AddCommand(ltVersionsCmd)
+}
+
+func runLTVersions(_ *cobra.Command, args []string) error {
+	if err := validateOutputFlags(); err != nil {
+		return err
+	}
+
+	launchTemplateID := strings.TrimSpace(args[0])
+	if !strings.HasPrefix(launchTemplateID, "lt-") {
+		return fmt.Errorf("invalid launch template ID %q: must start with lt-", launchTemplateID)
+	}
+
+	// Create a context that can be cancelled with Ctrl+C
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	client, err := aws.NewClient(ctx, region, profile, configPath)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client: %w", err)
+	}
+
+	versions, err := client.ListLaunchTemplateVersions(ctx, launchTemplateID)
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		return fmt.Errorf("no versions found for launch template %s", launchTemplateID)
+	}
+	sortLaunchTemplateVersions(versions)
+
+	if isJSONOutput() {
+		return printJSON(map[string]interface{}{"versions": launchTemplateVersionsJSON(versions)})
+	}
+	return printLaunchTemplateVersions(os.Stdout, versions, tableMaxRows(client.AppConfig))
+}
+
+// sortLaunchTemplateVersions orders versions newest first
+func sortLaunchTemplateVersions(versions []aws.LaunchTemplateVersion) {
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].VersionNumber > versions[j].VersionNumber
+	})
+}
+
+// launchTemplateVersionsJSON converts versions into plain maps for JSON output
+func launchTemplateVersionsJSON(versions []aws.LaunchTemplateVersion) []map[string]interface{} {
+	entries := make([]map[string]interface{}, 0, len(versions))
+	for _, v := range versions {
+		entry := map[string]interface{}{
+			"launch_template_id":   v.LaunchTemplateID,
+			"launch_template_name": v.LaunchTemplateName,
+			"version":              v.VersionNumber,
+			"description":          v.VersionDescription,
+			"image_id":             v.ImageID,
+			"instance_type":        v.InstanceType,
+			"created_by":           v.CreatedBy,
+			"default":              v.DefaultVersion,
+			"latest":               v.IsLatest,
+		}
+		if !v.CreatedAt.IsZero() {
+			entry["created"] = v.CreatedAt.UTC().Format(time.RFC3339)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// launchTemplateVersionFlags returns the default/latest markers for a version
+func launchTemplateVersionFlags(v aws.LaunchTemplateVersion) string {
+	var flags []string
+	if v.DefaultVersion {
+		flags = append(flags, "default")
+	}
+	if v.IsLatest {
+		flags = append(flags, "latest")
+	}
+	if len(flags) == 0 {
+		return "-"
+	}
+	return strings.Join(flags, ",")
+}
+
+// printLaunchTemplateVersions renders versions as a table of at most maxRows rows (0 = no cap)
+func printLaunchTemplateVersions(out io.Writer, versions []aws.LaunchTemplateVersion, maxRows int) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	if _, err := fmt.Fprintln(w, "VERSION\tFLAGS\tAMI\tINSTANCE TYPE\tCREATED\tDESCRIPTION"); err != nil {
+		return fmt.Errorf("failed to write table header: %w", err)
+	}
+
+	shown := cappedRows(len(versions), maxRows)
+	for _, v := range versions[:shown] {
+		created := "-"
+		if !v.CreatedAt.IsZero() {
+			created = v.CreatedAt.UTC().Format("2006-01-02 15:04")
+		}
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			strconv.FormatInt(v.VersionNumber, 10),
+			launchTemplateVersionFlags(v),
+			valueOrDash(v.ImageID),
+			valueOrDash(v.InstanceType),
+			created,
+			valueOrDash(v.VersionDescription),
+		); err != nil {
+			return fmt.Errorf("failed to write table row: %w", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to flush table writer: %w", err)
+	}
+	return writeTruncationFooter(out, shown, len(versions))
+}
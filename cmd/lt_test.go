@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+)
+
+func mockLaunchTemplateVersions() []aws.LaunchTemplateVersion {
+	created := time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)
+	versions := []aws.LaunchTemplateVersion{
+		{VersionNumber: 1, DefaultVersion: true, ImageID: "ami-111", InstanceType: "t3.micro", CreatedAt: created, VersionDescription: "initial"},
+		{VersionNumber: 3, ImageID: "ami-333", InstanceType: "m5.large", CreatedAt: created.Add(48 * time.Hour)},
+		{VersionNumber: 2, ImageID: "ami-222", InstanceType: "t3.small"},
+	}
+	aws.MarkLatestVersion(versions)
+	return versions
+}
+
+func TestLaunchTemplateVersionFlags(t *testing.T) {
+	versions := mockLaunchTemplateVersions()
+	want := map[int64]string{1: "default", 2: "-", 3: "latest"}
+
+	for _, v := range versions {
+		if got := launchTemplateVersionFlags(v); got != want[v.VersionNumber] {
+			t.Errorf("version %d flags = %q, want %q", v.VersionNumber, got, want[v.VersionNumber])
+		}
+	}
+
+	both := aws.LaunchTemplateVersion{DefaultVersion: true, IsLatest: true}
+	if got := launchTemplateVersionFlags(both); got != "default,latest" {
+		t.Errorf("flags = %q, want default,latest", got)
+	}
+}
+
+func TestPrintLaunchTemplateVersions(t *testing.T) {
+	versions := mockLaunchTemplateVersions()
+	sortLaunchTemplateVersions(versions)
+
+	var buf bytes.Buffer
+	if err := printLaunchTemplateVersions(&buf, versions, 0); err != nil {
+		t.Fatalf("printLaunchTemplateVersions() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want 4:\n%s", len(lines), buf.String())
+	}
+	wantFields := [][]string{
+		{"VERSION", "FLAGS", "AMI"},
+		{"3", "latest", "ami-333", "m5.large", "2025-03-16 09:30"},
+		{"2", "-", "ami-222", "t3.small"},
+		{"1", "default", "ami-111", "t3.micro", "2025-03-14 09:30", "initial"},
+	}
+	for i, fields := range wantFields {
+		for _, field := range fields {
+			if !strings.Contains(lines[i], field) {
+				t.Errorf("line %d %q missing %q", i, lines[i], field)
+			}
+		}
+	}
+}
+
+func TestLaunchTemplateVersionsJSON(t *testing.T) {
+	entries := launchTemplateVersionsJSON(mockLaunchTemplateVersions())
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	if entries[0]["default"] != true || entries[0]["created"] != "2025-03-14T09:30:00Z" {
+		t.Errorf("entry 0 = %v", entries[0])
+	}
+	if _, ok := entries[2]["created"]; ok {
+		t.Errorf("entry without create time should omit created: %v", entries[2])
+	}
+	if entries[1]["latest"] != true {
+		t.Errorf("entry 1 latest = %v, want true", entries[1]["latest"])
+	}
+}
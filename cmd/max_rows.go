@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/johnlam90/aws-ssm/pkg/config"
+)
+
+// maxRowsFlag is the --max-rows flag name
+const maxRowsFlag = "max-rows"
+
+// tableMaxRows returns how many rows table output may print: --max-rows when
+// given, otherwise output.max_rows from cfg. 0 means no cap.
+func tableMaxRows(cfg *config.Config) int {
+	if rootCmd.PersistentFlags().Changed(maxRowsFlag) {
+		return maxRows
+	}
+	if cfg == nil || cfg.Output.MaxRows < 0 {
+		return 0
+	}
+	return cfg.Output.MaxRows
+}
+
+// configuredTableMaxRows is tableMaxRows for commands that do not otherwise
+// load the config file
+func configuredTableMaxRows() int {
+	if rootCmd.PersistentFlags().Changed(maxRowsFlag) {
+		return maxRows
+	}
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return 0
+	}
+	return tableMaxRows(cfg)
+}
+
+// cappedRows returns how many of total rows to print under limit (0 = no cap)
+func cappedRows(total, limit int) int {
+	if limit <= 0 || total <= limit {
+		return total
+	}
+	return limit
+}
+
+// writeTruncationFooter tells the reader that table rows were cut by --max-rows
+func writeTruncationFooter(out io.Writer, shown, total int) error {
+	if shown >= total {
+		return nil
+	}
+	if _, err := fmt.Fprintf(out, "\nShowing %d of %d (use --max-rows to change)\n", shown, total); err != nil {
+		return fmt.Errorf("failed to write table footer: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/johnlam90/aws-ssm/pkg/config"
+)
+
+// captureStdout returns what fn writes to os.Stdout
+func captureStdout(t *testing.T, fn func() error) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	orig := os.Stdout
+	os.Stdout = w
+	fnErr := fn()
+	os.Stdout = orig
+	_ = w.Close()
+	out, _ := io.ReadAll(r)
+	if fnErr != nil {
+		t.Fatalf("unexpected error: %v", fnErr)
+	}
+	return string(out)
+}
+
+// setMaxRowsFlag sets --max-rows as if passed on the command line
+func setMaxRowsFlag(t *testing.T, value string) {
+	t.Helper()
+	flag := rootCmd.PersistentFlags().Lookup(maxRowsFlag)
+	origValue, origChanged := flag.Value.String(), flag.Changed
+	t.Cleanup(func() {
+		_ = flag.Value.Set(origValue)
+		flag.Changed = origChanged
+	})
+	if err := rootCmd.PersistentFlags().Set(maxRowsFlag, value); err != nil {
+		t.Fatalf("set --max-rows: %v", err)
+	}
+}
+
+func TestTableMaxRows(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Output.MaxRows = 50
+
+	if got := tableMaxRows(nil); got != 0 {
+		t.Errorf("no flag, no config = %d, want 0", got)
+	}
+	if got := tableMaxRows(cfg); got != 50 {
+		t.Errorf("config default = %d, want 50", got)
+	}
+	setMaxRowsFlag(t, "5")
+	if got := tableMaxRows(cfg); got != 5 {
+		t.Errorf("flag over config = %d, want 5", got)
+	}
+	setMaxRowsFlag(t, "0")
+	if got := tableMaxRows(cfg); got != 0 {
+		t.Errorf("explicit --max-rows 0 = %d, want 0 (no cap)", got)
+	}
+}
+
+func manyInstances(n int) []aws.Instance {
+	instances := make([]aws.Instance, n)
+	for i := range instances {
+		instances[i] = aws.Instance{InstanceID: fmt.Sprintf("i-%03d", i), Name: fmt.Sprintf("web-%d", i), State: "running"}
+	}
+	return instances
+}
+
+func TestPrintInstanceTableMaxRows(t *testing.T) {
+	instances := manyInstances(5)
+	instances[1].State = "stopped" // hidden without --all, so not counted
+
+	var out bytes.Buffer
+	if err := printInstanceTable(&out, instances, instanceTableOptions{MaxRows: 2}); err != nil {
+		t.Fatalf("printInstanceTable() error = %v", err)
+	}
+	got := out.String()
+	for _, want := range []string{"i-000", "i-002"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %s in output:\n%s", want, got)
+		}
+	}
+	for _, hidden := range []string{"i-001", "i-003", "i-004"} {
+		if strings.Contains(got, hidden) {
+			t.Errorf("did not expect %s in output:\n%s", hidden, got)
+		}
+	}
+	if !strings.Contains(got, "Showing 2 of 4 (use --max-rows to change)") {
+		t.Errorf("missing truncation footer:\n%s", got)
+	}
+
+	out.Reset()
+	if err := printInstanceTable(&out, instances, instanceTableOptions{MaxRows: 4}); err != nil {
+		t.Fatalf("printInstanceTable() error = %v", err)
+	}
+	if strings.Contains(out.String(), "Showing") {
+		t.Errorf("no footer expected when every row fits:\n%s", out.String())
+	}
+}
+
+func TestListJSONIgnoresMaxRows(t *testing.T) {
+	setMaxRowsFlag(t, "2")
+
+	out := captureStdout(t, func() error { return printListJSON(manyInstances(5)) })
+	var decoded struct {
+		Instances []aws.Instance `json:"instances"`
+	}
+	if err := json.Unmarshal([]byte(out), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(decoded.Instances) != 5 {
+		t.Errorf("JSON has %d instances, want all 5 despite --max-rows 2", len(decoded.Instances))
+	}
+}
+
+func TestWriteTruncationFooter(t *testing.T) {
+	var out bytes.Buffer
+	if err := writeTruncationFooter(&out, 3, 3); err != nil || out.Len() != 0 {
+		t.Errorf("untruncated table wrote %q (err %v)", out.String(), err)
+	}
+	if err := writeTruncationFooter(&out, 10, 250); err != nil {
+		t.Fatalf("writeTruncationFooter() error = %v", err)
+	}
+	if got := strings.TrimSpace(out.String()); got != "Showing 10 of 250 (use --max-rows to change)" {
+		t.Errorf("footer = %q", got)
+	}
+}
+
+func TestTableWritersHonorMaxRows(t *testing.T) {
+	now := time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC)
+	writers := map[string]func(io.Writer) error{
+		"eni orphans": func(out io.Writer) error {
+			return printOrphanedENIs(out, []aws.OrphanedENI{{NetworkInterfaceID: "eni-111"}, {NetworkInterfaceID: "eni-222"}}, now, 1)
+		},
+		"lt versions": func(out io.Writer) error {
+			return printLaunchTemplateVersions(out, mockLaunchTemplateVersions(), 1)
+		},
+		"capacity": func(out io.Writer) error {
+			return printCapacityReport(out, capacityReport{Regions: []regionCapacity{{Region: "us-east-1"}, {Region: "eu-west-1"}}}, 1)
+		},
+		"drift": func(out io.Writer) error {
+			return printDriftReport(out, driftReport{Resources: []resourceDrift{{Type: "asg", Name: "web"}, {Type: "asg", Name: "api"}}}, 1)
+		},
+		"debug env": func(out io.Writer) error {
+			return printEnvReport(out, []config.EnvVarStatus{{EnvVar: config.EnvVar{Name: "AWS_REGION"}}, {EnvVar: config.EnvVar{Name: "AWS_PROFILE"}}}, 1)
+		},
+	}
+	for name, write := range writers {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := write(&out); err != nil {
+				t.Fatalf("write error = %v", err)
+			}
+			if !strings.Contains(out.String(), "Showing 1 of ") {
+				t.Errorf("missing truncation footer:\n%s", out.String())
+			}
+		})
+	}
+
+	var out bytes.Buffer
+	report := capacityReport{Regions: []regionCapacity{{Region: "us-east-1"}, {Region: "eu-west-1"}}}
+	if err := printCapacityReport(&out, report, 1); err != nil {
+		t.Fatalf("printCapacityReport() error = %v", err)
+	}
+	if strings.Contains(out.String(), "eu-west-1") || !strings.Contains(out.String(), "TOTAL") {
+		t.Errorf("capped capacity table = %q, want one region and the total row", out.String())
+	}
+}
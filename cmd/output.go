@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jmespath/go-jmespath"
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/johnlam90/aws-ssm/pkg/config"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// outputFormatJSON is the --output value that selects machine-readable JSON
+const outputFormatJSON = "json"
+
+// Extra --output values accepted by commands that support validateTableOutputFlags
+const (
+	outputFormatTable = "table"
+	outputFormatYAML  = "yaml"
+)
+
+// validateOutputFlags checks --output and --select for supported combinations
+func validateOutputFlags() error {
+	if outputFormat != "" && outputFormat != outputFormatJSON {
+		return usageErrorf("unsupported output format %q (supported: json)", outputFormat)
+	}
+	if selectExpr != "" && outputFormat != outputFormatJSON {
+		return usageErrorf("--select requires --output json")
+	}
+	return nil
+}
+
+// validateTableOutputFlags is validateOutputFlags for commands that also accept
+// --output table (the default) and --output yaml
+func validateTableOutputFlags() error {
+	switch outputFormat {
+	case "", outputFormatTable, outputFormatJSON, outputFormatYAML:
+	default:
+		return usageErrorf("unsupported output format %q (supported: table, json, yaml)", outputFormat)
+	}
+	if selectExpr != "" && outputFormat != outputFormatJSON {
+		return usageErrorf("--select requires --output json")
+	}
+	return nil
+}
+
+// isJSONOutput reports whether JSON output was requested
+func isJSONOutput() bool {
+	return outputFormat == outputFormatJSON
+}
+
+// applySelect evaluates a JMESPath expression against v. The value is first
+// round-tripped through JSON so expressions see the same shape that is printed.
+func applySelect(v interface{}, expr string) (interface{}, error) {
+	compiled, err := jmespath.Compile(normalizeSelectLiterals(expr))
+	if err != nil {
+		return nil, fmt.Errorf("invalid --select expression %q: %w", expr, err)
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode output: %w", err)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil, fmt.Errorf("failed to decode output: %w", err)
+	}
+
+	result, err := compiled.Search(generic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate --select expression %q: %w", expr, err)
+	}
+	return result, nil
+}
+
+// normalizeSelectLiterals quotes backtick literals that are not valid JSON, so
+// the elided-quote form used by the AWS CLI (`running`) behaves like `"running"`.
+// Raw strings ('...') and quoted identifiers ("...") are copied through untouched.
+func normalizeSelectLiterals(expr string) string {
+	var b strings.Builder
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		if c != '`' && c != '\'' && c != '"' {
+			b.WriteByte(c)
+			continue
+		}
+
+		// Find the matching closing delimiter, honouring backslash escapes
+		end := i + 1
+		for end < len(expr) && expr[end] != c {
+			if expr[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(expr) {
+			// Unterminated; leave it for the parser to report
+			b.WriteString(expr[i:])
+			break
+		}
+
+		token := expr[i : end+1]
+		if c == '`' {
+			literal := strings.ReplaceAll(expr[i+1:end], "\\`", "`")
+			if !json.Valid([]byte(literal)) {
+				quoted, _ := json.Marshal(strings.TrimSpace(literal))
+				token = "`" + strings.ReplaceAll(string(quoted), "`", "\\`") + "`"
+			}
+		}
+		b.WriteString(token)
+		i = end
+	}
+	return b.String()
+}
+
+// sensitiveTagMask returns the mask for the client's configured sensitive tags
+func sensitiveTagMask(client *aws.Client) config.TagMask {
+	if client == nil || client.AppConfig == nil {
+		return config.TagMask{}
+	}
+	return client.AppConfig.TagMask()
+}
+
+// printJSON writes v to stdout as indented JSON, applying --select when set
+func printJSON(v interface{}) error {
+	return writeJSON(os.Stdout, v)
+}
+
+// writeJSON writes v to out as indented JSON, applying --select when set
+func writeJSON(out io.Writer, v interface{}) error {
+	if selectExpr != "" {
+		selected, err := applySelect(v, selectExpr)
+		if err != nil {
+			return err
+		}
+		v = selected
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to write JSON output: %w", err)
+	}
+	return nil
+}
+
+// writeYAML writes v to out as YAML. The value is round-tripped through JSON
+// so keys match the JSON output's field names.
+func writeYAML(out io.Writer, v interface{}) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	decoder := json.NewDecoder(strings.NewReader(string(raw)))
+	decoder.UseNumber()
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return fmt.Errorf("failed to decode output: %w", err)
+	}
+
+	encoder := yaml.NewEncoder(out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(yamlNumbers(generic)); err != nil {
+		return fmt.Errorf("failed to write YAML output: %w", err)
+	}
+	return encoder.Close()
+}
+
+// yamlNumbers replaces json.Number values so integers are written as YAML
+// integers rather than floats in exponent form
+func yamlNumbers(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, item := range value {
+			value[k] = yamlNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = yamlNumbers(item)
+		}
+	case json.Number:
+		if n, err := value.Int64(); err == nil {
+			return n
+		}
+		if f, err := value.Float64(); err == nil {
+			return f
+		}
+		return value.String()
+	}
+	return v
+}
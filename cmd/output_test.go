@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type selectSampleInstance struct {
+	InstanceID string
+	Name       string
+	State      string
+	Tags       map[string]string
+}
+
+func selectSampleResult() map[string]interface{} {
+	return map[string]interface{}{
+		"instances": []selectSampleInstance{
+			{InstanceID: "i-111", Name: "web-1", State: "running", Tags: map[string]string{"Env": "prod"}},
+			{InstanceID: "i-222", Name: "web-2", State: "stopped", Tags: map[string]string{"Env": "prod"}},
+			{InstanceID: "i-333", Name: "db-1", State: "running", Tags: map[string]string{"Env": "dev"}},
+		},
+	}
+}
+
+func TestApplySelect(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want interface{}
+	}{
+		{
+			name: "filter running instance IDs",
+			expr: "instances[?State==`running`].InstanceID",
+			want: []interface{}{"i-111", "i-333"},
+		},
+		{
+			name: "filter with JSON string literal",
+			expr: "instances[?State==`\"stopped\"`].InstanceID",
+			want: []interface{}{"i-222"},
+		},
+		{
+			name: "project all names",
+			expr: "instances[*].Name",
+			want: []interface{}{"web-1", "web-2", "db-1"},
+		},
+		{
+			name: "nested tag lookup",
+			expr: "instances[?Tags.Env=='dev'].Name | [0]",
+			want: "db-1",
+		},
+		{
+			name: "count instances",
+			expr: "length(instances)",
+			want: float64(3),
+		},
+		{
+			name: "multiselect hash",
+			expr: "instances[0].{id: InstanceID, state: State}",
+			want: map[string]interface{}{"id": "i-111", "state": "running"},
+		},
+		{
+			name: "missing field yields null",
+			expr: "clusters",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := applySelect(selectSampleResult(), tt.expr)
+			if err != nil {
+				t.Fatalf("applySelect(%q) returned error: %v", tt.expr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("applySelect(%q) = %#v, want %#v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplySelectInvalidExpression(t *testing.T) {
+	for _, expr := range []string{"instances[?State==", "instances[*", "]["} {
+		_, err := applySelect(selectSampleResult(), expr)
+		if err == nil {
+			t.Fatalf("expected error for expression %q", expr)
+		}
+		if !strings.Contains(err.Error(), "invalid --select expression") {
+			t.Errorf("error for %q = %v, want invalid --select expression", expr, err)
+		}
+	}
+}
+
+func TestValidateOutputFlags(t *testing.T) {
+	origFormat, origSelect := outputFormat, selectExpr
+	defer func() { outputFormat, selectExpr = origFormat, origSelect }()
+
+	tests := []struct {
+		name    string
+		format  string
+		expr    string
+		wantErr bool
+	}{
+		{name: "defaults", format: "", expr: "", wantErr: false},
+		{name: "json only", format: "json", expr: "", wantErr: false},
+		{name: "json with select", format: "json", expr: "instances", wantErr: false},
+		{name: "select without json", format: "", expr: "instances", wantErr: true},
+		{name: "unknown format", format: "yaml", expr: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputFormat, selectExpr = tt.format, tt.expr
+			err := validateOutputFlags()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateOutputFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateTableOutputFlags(t *testing.T) {
+	origFormat, origSelect := outputFormat, selectExpr
+	defer func() { outputFormat, selectExpr = origFormat, origSelect }()
+
+	tests := []struct {
+		format  string
+		expr    string
+		wantErr bool
+	}{
+		{format: ""},
+		{format: "table"},
+		{format: "json", expr: "entries"},
+		{format: "yaml"},
+		{format: "yaml", expr: "entries", wantErr: true},
+		{format: "csv", wantErr: true},
+	}
+	for _, tt := range tests {
+		outputFormat, selectExpr = tt.format, tt.expr
+		if err := validateTableOutputFlags(); (err != nil) != tt.wantErr {
+			t.Errorf("validateTableOutputFlags(%q, %q) error = %v, wantErr %v", tt.format, tt.expr, err, tt.wantErr)
+		}
+	}
+}
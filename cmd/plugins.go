@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/johnlam90/aws-ssm/pkg/config"
+	"github.com/johnlam90/aws-ssm/pkg/plugins"
+	"github.com/spf13/cobra"
+)
+
+// registerPlugins adds a subcommand for each discovered plugin. Plugins never
+// shadow built-in commands or their aliases.
+func registerPlugins(root *cobra.Command, discovered []plugins.Plugin) {
+	for _, p := range discovered {
+		if commandNameTaken(root, p.Name) {
+			continue
+		}
+		root.AddCommand(newPluginCommand(p))
+	}
+}
+
+// commandNameTaken reports whether name is already a command or alias on root
+func commandNameTaken(root *cobra.Command, name string) bool {
+	if name == "help" || name == "completion" {
+		return true
+	}
+	for _, c := range root.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// newPluginCommand wraps a plugin executable in a cobra command that passes
+// its arguments through untouched
+func newPluginCommand(p plugins.Plugin) *cobra.Command {
+	return &cobra.Command{
+		Use:                p.Name,
+		Short:              fmt.Sprintf("Plugin provided by %s", p.Path),
+		DisableFlagParsing: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPlugin(cmd, p, args)
+		},
+	}
+}
+
+func runPlugin(cmd *cobra.Command, p plugins.Plugin, args []string) error {
+	// Flag parsing is disabled so plugins see their own flags; pick out the
+	// global flags aws-ssm owns and hand them over through the environment
+	pluginArgs, global := extractGlobalFlags(args)
+
+	// Create a context that can be cancelled with Ctrl+C
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	env := plugins.Env(os.Environ(), resolvePluginContext(global))
+	err := plugins.Run(ctx, p, pluginArgs, env, cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr())
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return withExitCode(exitErr.ExitCode(), fmt.Errorf("plugin %s failed: %w", p.Name, err))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to run plugin %s: %w", p.Name, err)
+	}
+	return nil
+}
+
+// extractGlobalFlags removes --region/-r, --profile/-p and --config from args,
+// returning the remaining args and the extracted values. Parsing stops at "--".
+func extractGlobalFlags(args []string) ([]string, plugins.Context) {
+	var global plugins.Context
+	targets := map[string]*string{
+		"--region":  &global.Region,
+		"-r":        &global.Region,
+		"--profile": &global.Profile,
+		"-p":        &global.Profile,
+		"--config":  &global.ConfigPath,
+	}
+
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		name, value, hasValue := strings.Cut(arg, "=")
+		target, ok := targets[name]
+		if !ok {
+			rest = append(rest, arg)
+			continue
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				rest = append(rest, arg)
+				continue
+			}
+			i++
+			value = args[i]
+		}
+		*target = value
+	}
+	return rest, global
+}
+
+// resolvePluginContext fills in region and profile the same way the built-in
+// commands do: flag, then environment, then the config file defaults
+func resolvePluginContext(global plugins.Context) plugins.Context {
+	if global.Region == "" {
+		global.Region = region
+	}
+	if global.Profile == "" {
+		global.Profile = profile
+	}
+	if global.ConfigPath == "" {
+		global.ConfigPath = configPath
+	}
+
+	if global.Region == "" {
+		global.Region = firstNonEmpty(os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION"))
+	}
+	if global.Profile == "" {
+		global.Profile = os.Getenv("AWS_PROFILE")
+	}
+	if global.Region == "" || global.Profile == "" {
+		if cfg, err := config.LoadConfig(global.ConfigPath); err == nil {
+			global.Region = firstNonEmpty(global.Region, cfg.Default.Region)
+			global.Profile = firstNonEmpty(global.Profile, cfg.Default.Profile)
+		}
+	}
+	return global
+}
+
+// firstNonEmpty returns the first non-empty value
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
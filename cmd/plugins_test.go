@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/johnlam90/aws-ssm/pkg/plugins"
+	"github.com/spf13/cobra"
+)
+
+func TestExtractGlobalFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantArgs []string
+		want     plugins.Context
+	}{
+		{
+			name:     "separate values",
+			args:     []string{"--region", "us-west-2", "scan", "-p", "prod", "--verbose"},
+			wantArgs: []string{"scan", "--verbose"},
+			want:     plugins.Context{Region: "us-west-2", Profile: "prod"},
+		},
+		{
+			name:     "inline values",
+			args:     []string{"--region=eu-west-1", "--config=/tmp/c.yaml", "x"},
+			wantArgs: []string{"x"},
+			want:     plugins.Context{Region: "eu-west-1", ConfigPath: "/tmp/c.yaml"},
+		},
+		{
+			name:     "stops at double dash",
+			args:     []string{"-r", "us-east-1", "--", "--region", "ignored"},
+			wantArgs: []string{"--", "--region", "ignored"},
+			want:     plugins.Context{Region: "us-east-1"},
+		},
+		{
+			name:     "trailing flag without value is passed through",
+			args:     []string{"scan", "--profile"},
+			wantArgs: []string{"scan", "--profile"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotArgs, got := extractGlobalFlags(tt.args)
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("args = %q, want %q", gotArgs, tt.wantArgs)
+			}
+			if got != tt.want {
+				t.Errorf("context = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterPluginsSkipsBuiltins(t *testing.T) {
+	root := &cobra.Command{Use: "aws-ssm"}
+	root.AddCommand(&cobra.Command{Use: "list", Aliases: []string{"ls"}, Run: func(*cobra.Command, []string) {}})
+
+	registerPlugins(root, []plugins.Plugin{
+		{Name: "list", Path: "/bin/aws-ssm-list"},
+		{Name: "ls", Path: "/bin/aws-ssm-ls"},
+		{Name: "help", Path: "/bin/aws-ssm-help"},
+		{Name: "audit", Path: "/bin/aws-ssm-audit"},
+	})
+
+	var names []string
+	for _, c := range root.Commands() {
+		names = append(names, c.Name())
+	}
+	if want := []string{"audit", "list"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("commands = %v, want %v", names, want)
+	}
+}
+
+func TestDiscoveredPluginRunsAsSubcommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin scripts require a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	outFile := filepath.Join(dir, "out.txt")
+	script := "#!/bin/sh\n" +
+		`printf '%s\n' "$AWS_REGION" "$AWS_DEFAULT_REGION" "$AWS_PROFILE" "$*" > "` + outFile + `"` + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "aws-ssm-hello"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	t.Setenv("AWS_PROFILE", "from-env")
+
+	root := &cobra.Command{Use: "aws-ssm"}
+	registerPlugins(root, plugins.Discover(dir))
+
+	hello, _, err := root.Find([]string{"hello"})
+	if err != nil || hello.Name() != "hello" {
+		t.Fatalf("plugin not registered as a subcommand: %v", err)
+	}
+
+	root.SetArgs([]string{"hello", "--region", "ap-southeast-2", "greet", "--loud"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	data, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("plugin did not run: %v", err)
+	}
+	got := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	want := []string{"ap-southeast-2", "ap-southeast-2", "from-env", "greet --loud"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("plugin saw %q, want %q", got, want)
+	}
+}
+
+func TestPluginExitCodeIsPropagated(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin scripts require a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "aws-ssm-fail"), []byte("#!/bin/sh\nexit 7\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_PROFILE", "default")
+
+	root := &cobra.Command{Use: "aws-ssm", SilenceErrors: true, SilenceUsage: true}
+	registerPlugins(root, plugins.Discover(dir))
+	root.SetArgs([]string{"fail"})
+
+	err := root.Execute()
+	if got := ExitCode(err); got != 7 {
+		t.Errorf("ExitCode() = %d, want 7 (err = %v)", got, err)
+	}
+}
@@ -2,18 +2,21 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/johnlam90/aws-ssm/pkg/security"
 	"github.com/spf13/cobra"
 )
 
 var (
 	remotePort int
 	localPort  int
+	viaBastion string
 )
 
 var portForwardCmd = &cobra.Command{
@@ -40,68 +43,73 @@ Examples:
   aws-ssm port-forward web-server --remote-port 80 --local-port 8080
 
   # Access RDS through a bastion instance
-  aws-ssm port-forward bastion --remote-port 5432 --local-port 5432`,
-	Args: cobra.ExactArgs(1),
+  aws-ssm port-forward bastion --remote-port 5432 --local-port 5432
+
+  # Reach an instance without the SSM agent by hopping through a bastion
+  aws-ssm port-forward app-server --via bastion --remote-port 8080 --local-port 8080
+
+  # Forward to the previously selected instance
+  aws-ssm port-forward --reuse-last --remote-port 80 --local-port 8080
+
+  # Forward the ports last used with this instance
+  aws-ssm port-forward db-server --reuse-params`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runPortForward,
 }
 
 func init() {
 	rootCmd.AddCommand(portForwardCmd)
-	portForwardCmd.Flags().IntVarP(&remotePort, "remote-port", "R", 0, "Remote port on the instance (required)")
-	portForwardCmd.Flags().IntVarP(&localPort, "local-port", "L", 0, "Local port to listen on (required)")
-	if err := portForwardCmd.MarkFlagRequired("remote-port"); err != nil {
-		panic(fmt.Sprintf("failed to mark remote-port as required: %v", err))
-	}
-	if err := portForwardCmd.MarkFlagRequired("local-port"); err != nil {
-		panic(fmt.Sprintf("failed to mark local-port as required: %v", err))
-	}
+	portForwardCmd.Flags().IntVarP(&remotePort, "remote-port", "R", 0, "Remote port on the instance (required unless set by a connection override)")
+	portForwardCmd.Flags().IntVarP(&localPort, "local-port", "L", 0, "Local port to listen on (required unless set by a connection override)")
+	portForwardCmd.Flags().StringVar(&viaBastion, "via", "", "Bastion instance to forward through to the target's private IP; only the bastion must be SSM-managed (requires session-manager-plugin)")
+	addReuseLastFlag(portForwardCmd, "instance")
+	addReuseParamsFlag(portForwardCmd, "ports")
 }
 
 func runPortForward(_ *cobra.Command, args []string) error {
+	args, err := applyReuseLast(args, reuseLast, lastInstanceID)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("instance identifier is required (or use --reuse-last)")
+	}
 	identifier := args[0]
 
 	// Create a context that can be cancelled with Ctrl+C
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
-	// Validate ports
-	if remotePort < 1 || remotePort > 65535 {
-		return fmt.Errorf("invalid remote port: %d (must be between 1 and 65535)", remotePort)
-	}
-	if localPort < 1 || localPort > 65535 {
-		return fmt.Errorf("invalid local port: %d (must be between 1 and 65535)", localPort)
-	}
-
 	// Create AWS client
 	client, err := aws.NewClient(ctx, region, profile, configPath)
 	if err != nil {
 		return fmt.Errorf("failed to create AWS client: %w", err)
 	}
 
-	// Resolve instance with interactive fallback
-	fmt.Printf("Searching for instance: %s\n", identifier)
-	instance, err := client.ResolveSingleInstance(ctx, identifier)
+	instance, err := resolveForwardTarget(ctx, client, identifier)
+	if err != nil || instance == nil {
+		return err
+	}
+
+	remote, local, err := resolveForwardPorts(client, instance)
 	if err != nil {
-		if multiErr, ok := err.(*aws.MultipleInstancesError); ok && multiErr.AllowInteractive {
-			fmt.Print(multiErr.FormatInstanceList())
-			selected, selErr := client.SelectInstanceFromProvided(ctx, multiErr.Instances)
-			if selErr != nil {
-				// Check if user cancelled (Ctrl+C)
-				if selErr == context.Canceled {
-					fmt.Println("\nSelection cancelled.")
-					return nil
-				}
-				return fmt.Errorf("instance selection cancelled or failed: %w", selErr)
-			}
-			// Check if user cancelled (Esc) - returns nil instance with nil error
-			if selected == nil {
-				fmt.Println("\nSelection cancelled.")
-				return nil
-			}
-			instance = selected
-		} else {
+		return err
+	}
+
+	rememberInstance(instance.InstanceID, client.GetRegion())
+	rememberPortForwardParams(instance.InstanceID, remote, local)
+	ctx, stopTracking := trackSession(ctx, security.InitializeSecurityWithLevel(configuredSecurityLevel(client)), instance.InstanceID)
+	defer stopTracking()
+
+	if viaBastion != "" {
+		forward, err := client.ResolveBastionForward(ctx, viaBastion, instance, remote, local)
+		if err != nil {
 			return err
 		}
+		if err := client.StartBastionPortForward(ctx, forward); err != nil {
+			return fmt.Errorf("failed to start port forwarding via bastion: %w", err)
+		}
+		return nil
 	}
 
 	// Display instance information
@@ -116,9 +124,57 @@ func runPortForward(_ *cobra.Command, args []string) error {
 	fmt.Printf("  Private IP:  %s\n\n", instance.PrivateIP)
 
 	// Start port forwarding session
-	if err := client.StartPortForwardingSession(ctx, instance.InstanceID, remotePort, localPort); err != nil {
+	if err := client.StartPortForwardingSession(ctx, instance.InstanceID, remote, local); err != nil {
 		return fmt.Errorf("failed to start port forwarding: %w", err)
 	}
 
 	return nil
 }
+
+// resolveForwardTarget resolves identifier to an instance, letting the user
+// pick one when it matches several. A nil instance without an error means the
+// user cancelled the selection with Esc.
+func resolveForwardTarget(ctx context.Context, client *aws.Client, identifier string) (*aws.Instance, error) {
+	fmt.Printf("Searching for instance: %s\n", identifier)
+	instance, err := client.ResolveSingleInstance(ctx, identifier)
+	if err == nil {
+		return instance, nil
+	}
+
+	var multiErr *aws.MultipleInstancesError
+	if !errors.As(err, &multiErr) {
+		return nil, err
+	}
+	if !multiErr.AllowInteractive || !interactiveSelectionAllowed() {
+		return nil, reportMultipleMatches(os.Stderr, multiErr)
+	}
+
+	fmt.Print(multiErr.FormatInstanceList())
+	selected, err := client.SelectInstanceFromProvided(ctx, multiErr.Instances)
+	if err != nil {
+		// Check if user cancelled (Ctrl+C)
+		if err == context.Canceled {
+			fmt.Println("\nSelection cancelled.")
+			return nil, errCancelled
+		}
+		return nil, fmt.Errorf("instance selection cancelled or failed: %w", err)
+	}
+	// Check if user cancelled (Esc) - returns nil instance with nil error
+	if selected == nil {
+		fmt.Println("\nSelection cancelled.")
+	}
+	return selected, nil
+}
+
+// resolveForwardPorts validates the ports, filling those not given from the
+// stored parameters and then from a connection override
+func resolveForwardPorts(client *aws.Client, instance *aws.Instance) (int, int, error) {
+	remote, local := remotePort, localPort
+	if reuseParams {
+		var err error
+		if remote, local, err = storedPortForwardPorts(instance.InstanceID, remote, local); err != nil {
+			return 0, 0, err
+		}
+	}
+	return portForwardPorts(instanceConnectionOverride(client, instance), remote, local)
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// prompter asks the user questions on the terminal. It is an interface so that
+// interactive flows can be driven with scripted input in tests.
+type prompter interface {
+	// Ask prints question and returns the trimmed answer, or defaultValue if
+	// the answer is empty
+	Ask(question, defaultValue string) (string, error)
+	// Confirm asks a yes/no question, returning defaultYes on an empty answer
+	Confirm(question string, defaultYes bool) (bool, error)
+}
+
+// linePrompter reads one answer per line from a reader
+type linePrompter struct {
+	reader *bufio.Reader
+	out    io.Writer
+}
+
+// newLinePrompter creates a prompter that reads from in and writes prompts to out
+func newLinePrompter(in io.Reader, out io.Writer) *linePrompter {
+	return &linePrompter{
+		reader: bufio.NewReader(in),
+		out:    out,
+	}
+}
+
+// Ask prints question and reads a single line answer
+func (p *linePrompter) Ask(question, defaultValue string) (string, error) {
+	if defaultValue != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, defaultValue)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", question)
+	}
+
+	answer, err := p.readLine()
+	if err != nil {
+		return "", err
+	}
+	if answer == "" {
+		return defaultValue, nil
+	}
+	return answer, nil
+}
+
+// Confirm asks a yes/no question, re-asking until the answer is recognised
+func (p *linePrompter) Confirm(question string, defaultYes bool) (bool, error) {
+	hint := "y/N"
+	if defaultYes {
+		hint = "Y/n"
+	}
+
+	for {
+		fmt.Fprintf(p.out, "%s (%s): ", question, hint)
+		answer, err := p.readLine()
+		if err != nil {
+			return false, err
+		}
+
+		switch strings.ToLower(answer) {
+		case "":
+			return defaultYes, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		default:
+			fmt.Fprintln(p.out, "Please answer yes or no.")
+		}
+	}
+}
+
+// readLine reads a line, tolerating a final line without a trailing newline
+func (p *linePrompter) readLine() (string, error) {
+	line, err := p.reader.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// stdinIsTerminal reports whether stdin is an interactive terminal
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -1,6 +1,15 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/johnlam90/aws-ssm/pkg/config"
+	"github.com/johnlam90/aws-ssm/pkg/plugins"
+	"github.com/johnlam90/aws-ssm/pkg/ui/termcolor"
+	"github.com/johnlam90/aws-ssm/pkg/validation"
 	"github.com/spf13/cobra"
 )
 
@@ -13,7 +22,16 @@ var (
 	width           int
 	favorites       bool
 	outputFormat    string
+	selectExpr      string
 	configPath      string
+	connectTimeout  time.Duration
+	requestTimeout  time.Duration
+	keepAlive       time.Duration
+	checkClockSkew  bool
+	nonInteractive  bool
+	assumeRoleARN   string
+	assumeRoleExtID string
+	maxRows         int
 )
 
 var rootCmd = &cobra.Command{
@@ -21,11 +39,82 @@ var rootCmd = &cobra.Command{
 	Short: "AWS SSM Session Manager CLI",
 	Long: `A native Golang CLI tool for managing AWS SSM sessions.
 Connect to EC2 instances using instance ID, DNS name, IP address, or tags.`,
+	PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+		// Resolve --no-color, NO_COLOR, FORCE_COLOR and TTY detection once so
+		// every command and the TUI theme follow the same decision
+		colorsEnabled := termcolor.Enabled(noColor, os.Stdout)
+		noColor = !colorsEnabled
+		termcolor.ApplyProfile(colorsEnabled)
+
+		// Per-project defaults from .aws-ssm.yaml rank below flags and env vars
+		if cwd, err := os.Getwd(); err == nil {
+			if _, err := applyLocalConfig(cwd, os.Stderr); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: ignoring directory-local config: %v\n", err)
+			}
+		}
+
+		if err := attachLogFile(logFile); err != nil {
+			return err
+		}
+
+		if maxRows < 0 {
+			return usageErrorf("--max-rows must not be negative, got %d", maxRows)
+		}
+
+		if region != "" {
+			normalized, err := validation.NormalizeRegion(region)
+			if err != nil {
+				return usageErrorf("%v", err)
+			}
+			region = normalized
+		}
+
+		aws.SetHTTPSettings(aws.HTTPSettings{
+			ConnectTimeout: connectTimeout,
+			RequestTimeout: requestTimeout,
+			KeepAlive:      keepAlive,
+		})
+
+		chain, err := aws.ParseRoleChain(assumeRoleARN, assumeRoleExtID)
+		if err != nil {
+			return usageErrorf("invalid role chain: %v", err)
+		}
+		aws.SetRoleChain(chain)
+
+		if checkClockSkew {
+			warnOnClockSkew(cmd.Context())
+		}
+		return nil
+	},
+	Args: unknownCommandArgs,
+	RunE: runDefaultCommand,
+}
+
+// runDefaultCommand runs the configured default command (default.command),
+// falling back to help when none is set
+func runDefaultCommand(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil || cfg.Default.Command == "" {
+		return cmd.Help()
+	}
+
+	target, _, err := cmd.Find([]string{cfg.Default.Command})
+	if err != nil || target == cmd || target.RunE == nil {
+		return cmd.Help()
+	}
+	return target.RunE(target, args)
 }
 
 // Execute runs the root command
 func Execute() error {
-	return rootCmd.Execute()
+	registerPlugins(rootCmd, plugins.Discover(os.Getenv("PATH")))
+	markUsageErrors(rootCmd)
+	executed, err := rootCmd.ExecuteC()
+	explainError(os.Stderr, err)
+	if err != nil && executed != nil {
+		recordLastError(executed.CommandPath(), err)
+	}
+	return err
 }
 
 func init() {
@@ -36,8 +125,22 @@ func init() {
 	// Enhanced interactive flags
 	rootCmd.PersistentFlags().BoolVarP(&interactive, "interactive", "i", false, "Enable enhanced interactive mode with multi-select support")
 	rootCmd.PersistentFlags().StringSliceVar(&interactiveCols, "columns", []string{"name", "instance-id", "private-ip", "state"}, "Columns to display in interactive mode (name, instance-id, private-ip, state, type, az)")
-	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colors in output")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colors in output (also honors NO_COLOR; FORCE_COLOR forces colors on)")
 	rootCmd.PersistentFlags().IntVar(&width, "width", 0, "Terminal width override (0 = auto-detect)")
 	rootCmd.PersistentFlags().BoolVar(&favorites, "favorites", false, "Show only bookmarked instances (applies to interactive mode)")
 	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "", "Output format (json) for non-interactive use")
+	rootCmd.PersistentFlags().BoolVar(&nonInteractive, "non-interactive", false, "Never open interactive selectors; ambiguous matches fail with a listing (exit code 3)")
+	rootCmd.PersistentFlags().StringVar(&selectExpr, "select", "", "JMESPath expression applied to JSON output (requires --output json)")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Also write application logs to this file, rotated at 10 MiB (3 rotated files kept)")
+	rootCmd.PersistentFlags().IntVar(&maxRows, maxRowsFlag, 0, "Maximum rows printed in tables; JSON output is not affected (default: output.max_rows from config, 0 = no limit)")
+
+	// Network tuning flags (0 = use config file or SDK defaults)
+	rootCmd.PersistentFlags().DurationVar(&connectTimeout, "connect-timeout", 0, "Timeout for establishing connections to AWS endpoints (e.g. 10s)")
+	rootCmd.PersistentFlags().DurationVar(&requestTimeout, "request-timeout", 0, "Overall timeout for a single AWS API request (e.g. 60s)")
+	rootCmd.PersistentFlags().DurationVar(&keepAlive, "keepalive", 0, "TCP keepalive interval for AWS connections (e.g. 15s)")
+	rootCmd.PersistentFlags().BoolVar(&checkClockSkew, "check-clock-skew", false, "Warn before running if the local clock is out of sync with AWS")
+
+	// Role chaining: each role is assumed with the previous role's credentials
+	rootCmd.PersistentFlags().StringVar(&assumeRoleARN, "assume-role-arn", "", "Role ARN to assume, or a comma-separated chain assumed in order (role A, then role B)")
+	rootCmd.PersistentFlags().StringVar(&assumeRoleExtID, "assume-role-external-id", "", "External ID for --assume-role-arn: one for every role, or a comma-separated list matching the chain")
 }
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/johnlam90/aws-ssm/pkg/security"
+	"github.com/spf13/cobra"
+)
+
+var (
+	runStream   bool
+	runMaxBytes int64
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run <instance-identifier> <command>",
+	Short: "Run a command on an EC2 instance via SSM",
+	Long: `Run a shell command on an EC2 instance via SSM Run Command and print its output.
+
+By default the output is collected and printed once the command finishes. Use
+--stream to write output as it arrives instead of buffering it, which keeps
+memory bounded. Streaming stops with an error once --max-bytes have been
+written.
+
+SSM returns at most 24,000 characters of a command's standard output, so
+--stream fails with an error once the output reaches that limit. Write large
+output such as log dumps to a file on the instance and fetch it in parts
+instead.
+
+Examples:
+  # Run a command and print its output
+  aws-ssm run web-server "uptime"
+
+  # Stream recent log lines to a file
+  aws-ssm run web-server "journalctl -u nginx --no-pager -n 200" --stream > nginx.log
+
+  # Stream with a 4 KiB cap
+  aws-ssm run web-server "tail -n 100 /var/log/syslog" --stream --max-bytes 4096`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRun,
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+	runCmd.Flags().BoolVar(&runStream, "stream", false, "Stream output as it arrives instead of buffering it")
+	runCmd.Flags().Int64Var(&runMaxBytes, "max-bytes", aws.DefaultStreamMaxBytes, "Maximum bytes of output to stream (SSM itself returns at most 24,000 characters)")
+}
+
+func runRun(_ *cobra.Command, args []string) error {
+	if runMaxBytes <= 0 {
+		return usageErrorf("--max-bytes must be positive, got %d", runMaxBytes)
+	}
+
+	// Create a context that can be cancelled with Ctrl+C
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	client, err := aws.NewClient(ctx, region, profile, configPath)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client: %w", err)
+	}
+
+	instance, command, err := parseAndResolveArgs(ctx, client, args)
+	if err != nil {
+		if errors.Is(err, errInstanceSelectionCancelled) {
+			return nil
+		}
+		return err
+	}
+	rememberInstance(instance.InstanceID, client.GetRegion())
+
+	if !runStream {
+		return executeRemoteCommand(ctx, client, instance, command)
+	}
+	return streamRemoteCommand(ctx, client, instance, command)
+}
+
+// streamRemoteCommand runs command and streams its output to stdout. Progress
+// messages go to stderr so redirected output contains only the command output.
+func streamRemoteCommand(ctx context.Context, client *aws.Client, instance *aws.Instance, command string) error {
+	securityManager := security.InitializeSecurityWithLevel(configuredSecurityLevel(client))
+	if err := securityManager.ValidateCommand(command); err != nil {
+		return fmt.Errorf("command blocked by security policy: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Streaming output from %s (%s)\n\n", instance.InstanceID, getInstanceDisplayName(instance))
+
+	if err := client.ExecuteCommandStreamWithLimit(ctx, instance.InstanceID, command, os.Stdout, runMaxBytes); err != nil {
+		if errors.Is(err, aws.ErrOutputLimitExceeded) {
+			return fmt.Errorf("%w; raise --max-bytes to see more", err)
+		}
+		if errors.Is(err, aws.ErrOutputTruncated) {
+			return fmt.Errorf("%w; write the output to a file on the instance and fetch it in parts", err)
+		}
+		return fmt.Errorf("failed to execute command: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/johnlam90/aws-ssm/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// lastSelectionShorthand can be passed in place of an identifier to reuse the last selection
+const lastSelectionShorthand = "$"
+
+var (
+	reuseLast bool
+
+	// errNoLastSelection is returned when --reuse-last is used before anything was selected
+	errNoLastSelection = errors.New("no previous selection stored")
+
+	// selectionStatePath returns the state file location; replaced in tests
+	selectionStatePath = config.DefaultSelectionPath
+)
+
+// addReuseLastFlag registers --reuse-last on commands that accept an identifier
+func addReuseLastFlag(cmd *cobra.Command, target string) {
+	cmd.Flags().BoolVar(&reuseLast, "reuse-last", false,
+		fmt.Sprintf("Reuse the previously selected %s (shorthand: pass %q as the identifier)", target, lastSelectionShorthand))
+}
+
+// loadLastSelection reads the stored selection
+func loadLastSelection() (*config.LastSelection, error) {
+	path, err := selectionStatePath()
+	if err != nil {
+		return nil, err
+	}
+	return config.LoadLastSelection(path)
+}
+
+// lastInstanceID returns the stored instance ID and the region it was selected in
+func lastInstanceID() (string, string, error) {
+	sel, err := loadLastSelection()
+	if err != nil {
+		return "", "", err
+	}
+	if sel.InstanceID == "" {
+		return "", "", fmt.Errorf("%w: select an instance first or pass an identifier", errNoLastSelection)
+	}
+	return sel.InstanceID, sel.InstanceRegion, nil
+}
+
+// lastClusterName returns the stored EKS cluster name and the region it was selected in
+func lastClusterName() (string, string, error) {
+	sel, err := loadLastSelection()
+	if err != nil {
+		return "", "", err
+	}
+	if sel.Cluster == "" {
+		return "", "", fmt.Errorf("%w: select a cluster first or pass a cluster name", errNoLastSelection)
+	}
+	return sel.Cluster, sel.ClusterRegion, nil
+}
+
+// applyReuseLast substitutes the stored identifier into args. A leading "$" is
+// replaced, keeping any further arguments; with reuse set the identifier is the
+// only argument, so --reuse-last alongside positional arguments is rejected.
+// The command then runs in the region the identifier was selected in.
+func applyReuseLast(args []string, reuse bool, stored func() (string, string, error)) ([]string, error) {
+	shorthand := len(args) > 0 && args[0] == lastSelectionShorthand
+	if !shorthand && !reuse {
+		return args, nil
+	}
+	if reuse && len(args) > 0 {
+		return nil, usageErrorf("--reuse-last cannot be combined with positional arguments; pass %q in place of the identifier instead", lastSelectionShorthand)
+	}
+
+	id, storedRegion, err := stored()
+	if err != nil {
+		return nil, err
+	}
+	if err := useStoredRegion(storedRegion); err != nil {
+		return nil, err
+	}
+	if shorthand {
+		return append([]string{id}, args[1:]...), nil
+	}
+	return []string{id}, nil
+}
+
+// useStoredRegion points the command at the region a reused identifier was
+// selected in, since it would not be found elsewhere. An explicit --region
+// that differs is rejected. Selections saved without a region are not checked.
+func useStoredRegion(storedRegion string) error {
+	switch {
+	case storedRegion == "":
+		return nil
+	case region == "":
+		region = storedRegion
+		return nil
+	case !strings.EqualFold(region, storedRegion):
+		return usageErrorf("the last selection is in %s, not --region %s; pass an identifier to select one in %s", storedRegion, region, region)
+	default:
+		return nil
+	}
+}
+
+// rememberInstance stores the selected instance, replacing any previous one
+func rememberInstance(instanceID, awsRegion string) {
+	updateLastSelection(func(sel *config.LastSelection) {
+		sel.InstanceID = instanceID
+		sel.InstanceRegion = awsRegion
+	})
+}
+
+// rememberCluster stores the selected EKS cluster, replacing any previous one
+func rememberCluster(clusterName, awsRegion string) {
+	updateLastSelection(func(sel *config.LastSelection) {
+		sel.Cluster = clusterName
+		sel.ClusterRegion = awsRegion
+	})
+}
+
+// updateLastSelection applies fn to the stored selection. Failures only warn,
+// since remembering the selection must never break the command itself.
+func updateLastSelection(fn func(*config.LastSelection)) {
+	path, err := selectionStatePath()
+	if err != nil {
+		return
+	}
+	sel, err := config.LoadLastSelection(path)
+	if err != nil {
+		sel = &config.LastSelection{}
+	}
+	fn(sel)
+	if err := config.SaveLastSelection(path, sel); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to remember selection: %v\n", err)
+	}
+}
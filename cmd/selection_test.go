@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// useTempSelectionState points the selection store at a temporary file
+func useTempSelectionState(t *testing.T) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "last_selection.json")
+	original := selectionStatePath
+	selectionStatePath = func() (string, error) { return path, nil }
+	t.Cleanup(func() { selectionStatePath = original })
+}
+
+// useRegionFlag sets the global --region value for the duration of a test
+func useRegionFlag(t *testing.T, value string) {
+	t.Helper()
+	original := region
+	region = value
+	t.Cleanup(func() { region = original })
+}
+
+func TestApplyReuseLastResolvesStoredInstance(t *testing.T) {
+	useTempSelectionState(t)
+	rememberInstance("i-0123456789abcdef0", "us-east-1")
+
+	tests := []struct {
+		name  string
+		args  []string
+		reuse bool
+		want  []string
+	}{
+		{name: "flag with no args", reuse: true, want: []string{"i-0123456789abcdef0"}},
+		{name: "shorthand", args: []string{"$"}, want: []string{"i-0123456789abcdef0"}},
+		{name: "shorthand with command", args: []string{"$", "df -h"}, want: []string{"i-0123456789abcdef0", "df -h"}},
+		{name: "explicit identifier untouched", args: []string{"web"}, want: []string{"web"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useRegionFlag(t, "")
+			got, err := applyReuseLast(tt.args, tt.reuse, lastInstanceID)
+			if err != nil {
+				t.Fatalf("applyReuseLast() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("applyReuseLast() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyReuseLastErrorsWhenNothingStored(t *testing.T) {
+	useTempSelectionState(t)
+
+	if _, err := applyReuseLast(nil, true, lastInstanceID); !errors.Is(err, errNoLastSelection) {
+		t.Errorf("--reuse-last error = %v, want errNoLastSelection", err)
+	}
+	if _, err := applyReuseLast([]string{"$"}, false, lastClusterName); !errors.Is(err, errNoLastSelection) {
+		t.Errorf("shorthand error = %v, want errNoLastSelection", err)
+	}
+}
+
+func TestApplyReuseLastRejectsPositionalArgs(t *testing.T) {
+	useTempSelectionState(t)
+	rememberInstance("i-0123456789abcdef0", "us-east-1")
+
+	for _, args := range [][]string{{"web-server"}, {"uptime"}, {"$"}} {
+		if _, err := applyReuseLast(args, true, lastInstanceID); err == nil {
+			t.Errorf("applyReuseLast(%v, reuse) expected error", args)
+		}
+	}
+}
+
+func TestApplyReuseLastUsesStoredRegion(t *testing.T) {
+	useTempSelectionState(t)
+	rememberCluster("prod", "eu-west-1")
+
+	tests := []struct {
+		name       string
+		flag       string
+		wantRegion string
+		wantErr    bool
+	}{
+		{name: "no --region adopts stored region", flag: "", wantRegion: "eu-west-1"},
+		{name: "matching --region", flag: "EU-WEST-1", wantRegion: "EU-WEST-1"},
+		{name: "different --region rejected", flag: "us-east-1", wantRegion: "us-east-1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useRegionFlag(t, tt.flag)
+			_, err := applyReuseLast(nil, true, lastClusterName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyReuseLast() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if region != tt.wantRegion {
+				t.Errorf("region = %q, want %q", region, tt.wantRegion)
+			}
+		})
+	}
+}
+
+func TestNewSelectionReplacesStoredTarget(t *testing.T) {
+	useTempSelectionState(t)
+	rememberInstance("i-old", "us-east-1")
+	rememberCluster("prod", "eu-west-1")
+	rememberInstance("i-new", "us-west-2")
+
+	id, idRegion, err := lastInstanceID()
+	if err != nil || id != "i-new" || idRegion != "us-west-2" {
+		t.Errorf("lastInstanceID() = %q, %q, %v; want i-new, us-west-2", id, idRegion, err)
+	}
+	cluster, clusterRegion, err := lastClusterName()
+	if err != nil || cluster != "prod" || clusterRegion != "eu-west-1" {
+		t.Errorf("lastClusterName() = %q, %q, %v; want prod, eu-west-1", cluster, clusterRegion, err)
+	}
+}
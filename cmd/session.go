@@ -4,9 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
+	"regexp"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/johnlam90/aws-ssm/pkg/aws"
 	"github.com/johnlam90/aws-ssm/pkg/security"
@@ -15,12 +19,20 @@ import (
 
 var (
 	useNative                     bool
+	sessionAsUser                 string
+	sessionDocument               string
+	sessionASG                    string
+	sessionWaitReady              bool
+	sessionWaitTimeout            time.Duration
 	errInstanceSelectionCancelled = errors.New("instance selection cancelled")
 )
 
+// posixUsernamePattern matches portable POSIX user names
+var posixUsernamePattern = regexp.MustCompile(`^[a-z_][a-z0-9_-]{0,31}$`)
+
 var sessionCmd = &cobra.Command{
 	Use:     "session [instance-identifier] [command]",
-	Aliases: []string{"s"},
+	Aliases: []string{"s", "connect"},
 	Short:   "Start an SSM session with an EC2 instance or execute a command",
 	Long: `Start an interactive SSM session with an EC2 instance or execute a remote command.
 
@@ -64,17 +76,55 @@ Examples:
   aws-ssm session ec2-1-2-3-4.us-west-2.compute.amazonaws.com
 
   # Execute command with specific region and profile
-  aws-ssm session web-server "systemctl status nginx" --region us-west-2 --profile production`,
+  aws-ssm session web-server "systemctl status nginx" --region us-west-2 --profile production
+
+  # Connect and switch to another user (uses the session-manager-plugin)
+  aws-ssm session web-server --as-user deploy
+
+  # Connect with a custom Session-type SSM document (uses the session-manager-plugin)
+  aws-ssm session web-server --document Org-RestrictedShell
+
+  # Pick an in-service instance of an Auto Scaling Group (auto-connects if only one)
+  aws-ssm connect --asg web-asg
+  aws-ssm connect --asg web-asg "uptime"
+
+  # Connect to a just-started instance once its SSM agent has registered
+  aws-ssm session i-1234567890abcdef0 --wait-ready --wait-timeout 10m
+
+  # Reconnect to the previously selected instance
+  aws-ssm session --reuse-last
+  aws-ssm session $ "uptime"
+
+  # Reconnect with the user or document last used with this instance
+  aws-ssm connect i-1234567890abcdef0 --reuse-params`,
 	Args: cobra.MaximumNArgs(2),
 	RunE: runSession,
 }
 
 func init() {
 	rootCmd.AddCommand(sessionCmd)
-	sessionCmd.Flags().BoolVarP(&useNative, "native", "n", true, "Use native Go implementation (no plugin required)")
+	sessionCmd.Flags().BoolVarP(&useNative, "native", "n", false, "Use native Go implementation (no plugin required); default: used when session-manager-plugin is not installed")
+	sessionCmd.Flags().BoolVar(&usePlugin, "plugin", false, "Use the session-manager-plugin; default: used when it is installed")
+	addReuseLastFlag(sessionCmd, "instance")
+	addReuseParamsFlag(sessionCmd, "--as-user or --document")
+	sessionCmd.Flags().StringVar(&sessionAsUser, "as-user", "", "Switch to this user (sudo su - <user>) when the interactive session starts; requires session-manager-plugin")
+	sessionCmd.Flags().StringVar(&sessionDocument, "document", "", "Start the session with this Session-type SSM document; requires session-manager-plugin")
+	sessionCmd.Flags().BoolVar(&sessionWaitReady, "wait-ready", false, "Wait for the instance's SSM agent to register and come online before connecting")
+	sessionCmd.Flags().DurationVar(&sessionWaitTimeout, "wait-timeout", aws.DefaultSSMReadyTimeout, "How long --wait-ready waits for the SSM agent")
+	sessionCmd.Flags().StringVar(&sessionASG, "asg", "", "Select from the in-service instances of this Auto Scaling Group; the only argument is then an optional command")
 }
 
-func runSession(_ *cobra.Command, args []string) error {
+func runSession(cmd *cobra.Command, args []string) error {
+	nArgs, err := validateSessionFlags(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	args, err = applyReuseLast(args, reuseLast, lastInstanceID)
+	if err != nil {
+		return err
+	}
+
 	// Create a context that can be cancelled with Ctrl+C
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
@@ -85,24 +135,23 @@ func runSession(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create AWS client: %w", err)
 	}
 
-	// Parse and resolve arguments
-	instance, command, err := parseAndResolveArgs(ctx, client, args)
-	if err != nil {
-		if errors.Is(err, errInstanceSelectionCancelled) {
-			return nil
+	// Fail fast on an unusable document before any instance selection
+	if sessionDocument != "" {
+		if err := client.ValidateSessionDocument(ctx, sessionDocument); err != nil {
+			return err
 		}
+	}
+
+	instance, command, err := resolveSessionTarget(ctx, client, args)
+	if err != nil || instance == nil {
 		return err
 	}
 
-	// If no instance resolved, use interactive selection
-	if instance == nil {
-		instance, err = selectInstanceInteractive(ctx, client)
-		if err != nil {
-			return err
-		}
-		if instance == nil {
-			return nil // User cancelled
-		}
+	rememberInstance(instance.InstanceID, client.GetRegion())
+
+	documentFromFlag := sessionDocument
+	if err := applySessionGuards(ctx, client, instance.InstanceID, nArgs, nativeForced(cmd)); err != nil {
+		return err
 	}
 
 	// Execute based on command presence
@@ -110,9 +159,106 @@ func runSession(_ *cobra.Command, args []string) error {
 		return executeRemoteCommand(ctx, client, instance, command)
 	}
 
+	if err := applySessionOverride(client, instance, nativeForced(cmd)); err != nil {
+		return err
+	}
+	if sessionDocument != documentFromFlag {
+		if err := client.ValidateSessionDocument(ctx, sessionDocument); err != nil {
+			return err
+		}
+	}
+
 	return startInteractiveSession(ctx, client, instance)
 }
 
+// nativeForced reports whether --native was passed and is still in effect
+func nativeForced(cmd *cobra.Command) bool {
+	return cmd.Flags().Changed("native") && useNative
+}
+
+// validateSessionFlags resolves the session mode and rejects flag combinations
+// that cannot work together. It returns the argument count as seen by the
+// flags that care whether a command was given: with --asg the group stands in
+// for the identifier, so a single argument is the command.
+func validateSessionFlags(cmd *cobra.Command, args []string) (int, error) {
+	nArgs := len(args)
+	var err error
+	useNative, sessionModeDetected, err = resolveSessionMode(cmd.Flags().Changed("native"), useNative, usePlugin, sessionPluginInstalled)
+	if err != nil {
+		return 0, err
+	}
+	if sessionASG != "" {
+		if reuseLast {
+			return 0, usageErrorf("--asg cannot be combined with --reuse-last")
+		}
+		if len(args) > 1 {
+			return 0, usageErrorf("--asg takes at most one argument, the command to run")
+		}
+		nArgs++
+	}
+
+	if sessionAsUser != "" {
+		if nArgs > 1 {
+			return 0, usageErrorf("--as-user only applies to interactive sessions, not remote commands")
+		}
+		if nativeForced(cmd) {
+			return 0, usageErrorf("--as-user is not supported with --native; it requires the session-manager-plugin")
+		}
+		useNative = false
+	}
+	if sessionDocument != "" {
+		if err := validateSessionDocumentFlags(nArgs, nativeForced(cmd)); err != nil {
+			return 0, err
+		}
+		useNative = false
+	}
+	return nArgs, nil
+}
+
+// resolveSessionTarget resolves the instance and optional command from args or
+// --asg, falling back to interactive selection. A nil instance without an
+// error means the user cancelled.
+func resolveSessionTarget(ctx context.Context, client *aws.Client, args []string) (*aws.Instance, string, error) {
+	var instance *aws.Instance
+	var command string
+	var err error
+	if sessionASG != "" {
+		instance, command, err = resolveASGArgs(ctx, client, sessionASG, args)
+	} else {
+		instance, command, err = parseAndResolveArgs(ctx, client, args)
+	}
+	if err != nil {
+		if errors.Is(err, errInstanceSelectionCancelled) {
+			return nil, "", nil
+		}
+		return nil, "", err
+	}
+	if instance != nil {
+		return instance, command, nil
+	}
+
+	// No identifier given, so select interactively
+	if nonInteractive {
+		return nil, "", fmt.Errorf("an instance identifier is required with --non-interactive")
+	}
+	instance, err = selectInstanceInteractive(ctx, client)
+	return instance, command, err
+}
+
+// applySessionGuards waits for the SSM agent when --wait-ready is set and
+// fills --as-user/--document from the stored parameters with --reuse-params
+func applySessionGuards(ctx context.Context, client *aws.Client, instanceID string, nArgs int, forcedNative bool) error {
+	if sessionWaitReady {
+		if err := waitForSSMAgent(ctx, client, instanceID, sessionWaitTimeout); err != nil {
+			return err
+		}
+	}
+	if reuseParams {
+		return applyStoredSessionParams(instanceID, nArgs, forcedNative)
+	}
+	return nil
+}
+
 // parseAndResolveArgs parses command line arguments and resolves instance
 func parseAndResolveArgs(ctx context.Context, client *aws.Client, args []string) (*aws.Instance, string, error) {
 	switch len(args) {
@@ -149,6 +295,49 @@ func parseAndResolveArgs(ctx context.Context, client *aws.Client, args []string)
 	}
 }
 
+// resolveASGArgs picks an in-service instance of asgName; args holds at most the command
+func resolveASGArgs(ctx context.Context, client *aws.Client, asgName string, args []string) (*aws.Instance, string, error) {
+	fmt.Printf("Listing in-service instances of Auto Scaling Group: %s\n", asgName)
+	candidates, err := client.ListASGInServiceInstances(ctx, asgName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	instance, err := chooseASGInstance(asgName, candidates, func(instances []aws.Instance) (*aws.Instance, error) {
+		return selectFromMultipleInstances(ctx, client, instances)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	command := ""
+	if len(args) == 1 {
+		command = args[0]
+	}
+	return instance, command, nil
+}
+
+// chooseASGInstance returns the only candidate directly, otherwise asks pick.
+// Without an interactive terminal, several candidates are listed and reported
+// with exitCodeMultipleMatches.
+func chooseASGInstance(asgName string, candidates []aws.Instance, pick func([]aws.Instance) (*aws.Instance, error)) (*aws.Instance, error) {
+	switch len(candidates) {
+	case 0:
+		return nil, fmt.Errorf("no running in-service instances in Auto Scaling Group %s", asgName)
+	case 1:
+		fmt.Printf("Auto-selecting the only in-service instance: %s\n", candidates[0].InstanceID)
+		return &candidates[0], nil
+	}
+
+	if !interactiveSelectionAllowed() {
+		return nil, reportMultipleMatches(os.Stderr, &aws.MultipleInstancesError{
+			Identifier: asgName,
+			Instances:  candidates,
+		})
+	}
+	return pick(candidates)
+}
+
 // resolveInstance resolves an instance from an identifier
 func resolveInstance(ctx context.Context, client *aws.Client, identifier string) (*aws.Instance, error) {
 	fmt.Printf("Searching for instance: %s\n", identifier)
@@ -157,10 +346,41 @@ func resolveInstance(ctx context.Context, client *aws.Client, identifier string)
 
 // handleInstanceResolutionError handles errors from instance resolution
 func handleInstanceResolutionError(ctx context.Context, client *aws.Client, err error) (*aws.Instance, error) {
-	if multiErr, ok := err.(*aws.MultipleInstancesError); ok && multiErr.AllowInteractive {
-		return selectFromMultipleInstances(ctx, client, multiErr.Instances)
+	var multiErr *aws.MultipleInstancesError
+	if !errors.As(err, &multiErr) {
+		return nil, err
+	}
+	if !multiErr.AllowInteractive || !interactiveSelectionAllowed() {
+		return nil, reportMultipleMatches(os.Stderr, multiErr)
+	}
+	return selectFromMultipleInstances(ctx, client, multiErr.Instances)
+}
+
+// interactiveSelectionAllowed reports whether an ambiguous match may open a selector
+func interactiveSelectionAllowed() bool {
+	return !nonInteractive && stdinIsTerminal()
+}
+
+// reportMultipleMatches lists every matching instance and returns an error carrying
+// exitCodeMultipleMatches, for scripts that cannot use the interactive selector
+func reportMultipleMatches(out io.Writer, multiErr *aws.MultipleInstancesError) error {
+	if _, err := io.WriteString(out, formatMultipleMatches(multiErr)); err != nil {
+		return fmt.Errorf("failed to write instance list: %w", err)
 	}
-	return nil, err
+	return withExitCode(exitCodeMultipleMatches, fmt.Errorf("%w; use a more specific identifier such as an instance ID", multiErr))
+}
+
+// formatMultipleMatches renders the matching instances as a table
+func formatMultipleMatches(multiErr *aws.MultipleInstancesError) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Found %d instances matching '%s':\n\n", len(multiErr.Instances), multiErr.Identifier)
+	table := multiErr.Table()
+	b.WriteString(table.Header + "\n")
+	for _, row := range table.Rows {
+		b.WriteString(row + "\n")
+	}
+	b.WriteString("\n")
+	return b.String()
 }
 
 // selectFromMultipleInstances handles interactive selection from multiple matching instances
@@ -171,7 +391,7 @@ func selectFromMultipleInstances(ctx context.Context, client *aws.Client, instan
 	if selErr != nil {
 		if selErr == context.Canceled {
 			fmt.Println("\nSelection cancelled.")
-			return nil, errInstanceSelectionCancelled
+			return nil, errCancelled
 		}
 		return nil, fmt.Errorf("instance selection cancelled or failed: %w", selErr)
 	}
@@ -205,7 +425,7 @@ func selectInstanceInteractive(ctx context.Context, client *aws.Client) (*aws.In
 	if err != nil {
 		if err == context.Canceled {
 			fmt.Println("\nSelection cancelled.")
-			return nil, nil
+			return nil, errCancelled
 		}
 		return nil, fmt.Errorf("failed to select instance: %w", err)
 	}
@@ -218,9 +438,21 @@ func selectInstanceInteractive(ctx context.Context, client *aws.Client) (*aws.In
 	return selectedInstance, nil
 }
 
+// configuredSecurityLevel returns the security level from the config file, if valid
+func configuredSecurityLevel(client *aws.Client) security.Level {
+	if client.AppConfig == nil {
+		return ""
+	}
+	level, err := security.ParseLevel(client.AppConfig.Security.Level)
+	if err != nil {
+		return ""
+	}
+	return level
+}
+
 // executeRemoteCommand executes a command on a remote instance
 func executeRemoteCommand(ctx context.Context, client *aws.Client, instance *aws.Instance, command string) error {
-	securityManager := security.InitializeSecurity()
+	securityManager := security.InitializeSecurityWithLevel(configuredSecurityLevel(client))
 	if err := securityManager.ValidateCommand(command); err != nil {
 		return fmt.Errorf("command blocked by security policy: %w", err)
 	}
@@ -256,11 +488,46 @@ func startInteractiveSession(ctx context.Context, client *aws.Client, instance *
 	}
 	fmt.Printf("  AZ:          %s\n\n", instance.AvailabilityZone)
 
-	if useNative {
+	securityManager := security.InitializeSecurityWithLevel(configuredSecurityLevel(client))
+	var initialCommand string
+	if sessionAsUser != "" {
+		var err error
+		initialCommand, err = buildSwitchUserCommand(securityManager, sessionAsUser)
+		if err != nil {
+			return err
+		}
+	}
+	rememberSessionParams(instance.InstanceID, sessionAsUser, sessionDocument)
+	ctx, stopTracking := trackSession(ctx, securityManager, instance.InstanceID)
+	defer stopTracking()
+
+	if sessionAsUser != "" {
+		fmt.Printf("Switching to user %s after connecting\n\n", sessionAsUser)
+		if err := client.StartSessionWithCommand(ctx, instance.InstanceID, initialCommand); err != nil {
+			return fmt.Errorf("failed to start session: %w", err)
+		}
+	} else if sessionDocument != "" {
+		fmt.Printf("Using SSM document %s\n\n", sessionDocument)
+		if err := client.StartSessionWithOptions(ctx, instance.InstanceID, aws.SessionOptions{DocumentName: sessionDocument}); err != nil {
+			return fmt.Errorf("failed to start session: %w", err)
+		}
+	} else if sessionShell != "" {
+		fmt.Printf("Starting shell: %s\n\n", sessionShell)
+		if err := client.StartSessionWithCommand(ctx, instance.InstanceID, sessionShell); err != nil {
+			return fmt.Errorf("failed to start session: %w", err)
+		}
+	} else if useNative {
+		if sessionModeDetected {
+			announceSessionMode(true)
+		}
+		applySessionIdleWarning(client)
 		if err := client.StartNativeSession(ctx, instance.InstanceID); err != nil {
 			return fmt.Errorf("failed to start native session: %w", err)
 		}
 	} else {
+		if sessionModeDetected {
+			announceSessionMode(false)
+		}
 		if err := client.StartSession(ctx, instance.InstanceID); err != nil {
 			return fmt.Errorf("failed to start session: %w", err)
 		}
@@ -269,6 +536,40 @@ func startInteractiveSession(ctx context.Context, client *aws.Client, instance *
 	return nil
 }
 
+// applySessionIdleWarning configures the native session idle warning from the security settings
+func applySessionIdleWarning(client *aws.Client) {
+	timeout, warnBefore := security.InitializeSecurityWithLevel(configuredSecurityLevel(client)).SessionIdlePolicy()
+	client.IdleWarning = aws.IdleWarningConfig{Timeout: timeout, WarnBefore: warnBefore}
+}
+
+// validateSessionDocumentFlags rejects flag combinations that cannot use a custom document
+func validateSessionDocumentFlags(nArgs int, explicitNative bool) error {
+	if sessionAsUser != "" {
+		return usageErrorf("--document cannot be combined with --as-user")
+	}
+	if nArgs > 1 {
+		return usageErrorf("--document only applies to interactive sessions, not remote commands")
+	}
+	if explicitNative {
+		return usageErrorf("--document is not supported with --native; it requires the session-manager-plugin")
+	}
+	return nil
+}
+
+// buildSwitchUserCommand builds the initial command that switches to user and
+// validates it against the security manager
+func buildSwitchUserCommand(sm *security.Manager, user string) (string, error) {
+	if user != sm.SanitizeInput(user) || !posixUsernamePattern.MatchString(user) {
+		return "", fmt.Errorf("invalid --as-user value %q: must be a valid POSIX user name", user)
+	}
+
+	command := "sudo su - " + user
+	if err := sm.ValidateCommand(command); err != nil {
+		return "", fmt.Errorf("--as-user command blocked by security policy: %w", err)
+	}
+	return command, nil
+}
+
 // getInstanceDisplayName returns a display name for an instance
 func getInstanceDisplayName(instance *aws.Instance) string {
 	if name := instance.Name; name != "" {
@@ -276,3 +577,18 @@ func getInstanceDisplayName(instance *aws.Instance) string {
 	}
 	return "(no name)"
 }
+
+// waitForSSMAgent blocks until the SSM agent on instanceID is online,
+// reporting each state it passes through
+func waitForSSMAgent(ctx context.Context, client *aws.Client, instanceID string, timeout time.Duration) error {
+	start := time.Now()
+	fmt.Printf("Waiting up to %v for the SSM agent on %s...\n", timeout, instanceID)
+	err := client.WaitForSSMReady(ctx, instanceID, timeout, func(state string) {
+		fmt.Printf("  [%s] %s\n", time.Since(start).Round(time.Second), state)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("SSM agent online after %s\n", time.Since(start).Round(time.Second))
+	return nil
+}
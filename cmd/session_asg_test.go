@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+)
+
+func TestChooseASGInstance(t *testing.T) {
+	original := nonInteractive
+	nonInteractive = true
+	defer func() { nonInteractive = original }()
+
+	pickNotCalled := func(t *testing.T) func([]aws.Instance) (*aws.Instance, error) {
+		return func([]aws.Instance) (*aws.Instance, error) {
+			t.Fatal("picker should not be called")
+			return nil, nil
+		}
+	}
+
+	t.Run("single instance auto-selects", func(t *testing.T) {
+		candidates := []aws.Instance{{InstanceID: "i-only", State: "running"}}
+		got, err := chooseASGInstance("web-asg", candidates, pickNotCalled(t))
+		if err != nil {
+			t.Fatalf("chooseASGInstance() error = %v", err)
+		}
+		if got.InstanceID != "i-only" {
+			t.Errorf("selected %s, want i-only", got.InstanceID)
+		}
+	})
+
+	t.Run("no instances", func(t *testing.T) {
+		if _, err := chooseASGInstance("web-asg", nil, pickNotCalled(t)); err == nil {
+			t.Fatal("expected an error for an empty group")
+		}
+	})
+
+	t.Run("multiple instances without a terminal", func(t *testing.T) {
+		candidates := []aws.Instance{{InstanceID: "i-a"}, {InstanceID: "i-b"}}
+		_, err := chooseASGInstance("web-asg", candidates, pickNotCalled(t))
+		if got := ExitCode(err); got != exitCodeMultipleMatches {
+			t.Errorf("ExitCode() = %d, want %d (err = %v)", got, exitCodeMultipleMatches, err)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateSessionDocumentFlags(t *testing.T) {
+	tests := []struct {
+		name           string
+		asUser         string
+		nArgs          int
+		explicitNative bool
+		wantErr        string
+	}{
+		{name: "interactive session", nArgs: 1},
+		{name: "no args", nArgs: 0},
+		{name: "with as-user", asUser: "deploy", nArgs: 1, wantErr: "--as-user"},
+		{name: "remote command", nArgs: 2, wantErr: "interactive sessions"},
+		{name: "explicit native", nArgs: 1, explicitNative: true, wantErr: "--native"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := sessionAsUser
+			defer func() { sessionAsUser = orig }()
+			sessionAsUser = tt.asUser
+
+			err := validateSessionDocumentFlags(tt.nArgs, tt.explicitNative)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateSessionDocumentFlags() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validateSessionDocumentFlags() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/johnlam90/aws-ssm/pkg/config"
+)
+
+// Session modes recorded in the last-selection state
+const (
+	sessionModeNative = "native"
+	sessionModePlugin = "plugin"
+)
+
+var (
+	usePlugin bool
+	// sessionModeDetected is set when the session mode came from plugin detection
+	sessionModeDetected bool
+	// sessionPluginInstalled reports whether the session-manager-plugin is available; replaced in tests
+	sessionPluginInstalled = aws.SessionManagerPluginInstalled
+)
+
+// resolveSessionMode returns whether to use the native implementation. An
+// explicit --native or --plugin wins; otherwise native mode is used exactly
+// when the session-manager-plugin is not installed, and detected is true.
+func resolveSessionMode(nativeSet, native, plugin bool, pluginInstalled func() bool) (useNative, detected bool, err error) {
+	switch {
+	case plugin && nativeSet && native:
+		return false, false, usageErrorf("--native and --plugin cannot be combined")
+	case plugin:
+		return false, false, nil
+	case nativeSet:
+		return native, false, nil
+	}
+	return !pluginInstalled(), true, nil
+}
+
+// announceSessionMode explains a detected session mode the first time it is
+// used, and again whenever detection picks a different mode
+func announceSessionMode(native bool) {
+	mode := sessionModePlugin
+	if native {
+		mode = sessionModeNative
+	}
+	if sel, err := loadLastSelection(); err == nil && sel.SessionMode == mode {
+		return
+	}
+
+	if native {
+		fmt.Println("session-manager-plugin not found; using the native Go implementation (pass --plugin to require the plugin)")
+	} else {
+		fmt.Println("session-manager-plugin found; using it for sessions (pass --native to use the native Go implementation)")
+	}
+	updateLastSelection(func(sel *config.LastSelection) {
+		sel.SessionMode = mode
+	})
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveSessionMode(t *testing.T) {
+	tests := []struct {
+		name            string
+		nativeSet       bool
+		native          bool
+		plugin          bool
+		pluginInstalled bool
+		wantNative      bool
+		wantDetected    bool
+		wantErr         bool
+	}{
+		{name: "plugin installed selects plugin mode", pluginInstalled: true, wantNative: false, wantDetected: true},
+		{name: "plugin absent selects native mode", pluginInstalled: false, wantNative: true, wantDetected: true},
+		{name: "--native overrides an installed plugin", nativeSet: true, native: true, pluginInstalled: true, wantNative: true},
+		{name: "--native=false forces plugin mode", nativeSet: true, native: false, pluginInstalled: false, wantNative: false},
+		{name: "--plugin overrides a missing plugin", plugin: true, pluginInstalled: false, wantNative: false},
+		{name: "--native and --plugin conflict", nativeSet: true, native: true, plugin: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detections := 0
+			gotNative, gotDetected, err := resolveSessionMode(tt.nativeSet, tt.native, tt.plugin, func() bool {
+				detections++
+				return tt.pluginInstalled
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveSessionMode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if ExitCode(err) != exitCodeUsage {
+					t.Errorf("ExitCode() = %d, want %d", ExitCode(err), exitCodeUsage)
+				}
+				return
+			}
+			if gotNative != tt.wantNative || gotDetected != tt.wantDetected {
+				t.Errorf("resolveSessionMode() = native %v, detected %v; want %v, %v", gotNative, gotDetected, tt.wantNative, tt.wantDetected)
+			}
+			if !tt.wantDetected && detections > 0 {
+				t.Error("explicit flags should not probe for the plugin")
+			}
+		})
+	}
+}
+
+func TestAnnounceSessionModeOnce(t *testing.T) {
+	useTempSelectionState(t)
+
+	first := captureStdout(t, func() error { announceSessionMode(true); return nil })
+	if !strings.Contains(first, "using the native Go implementation") {
+		t.Errorf("first announcement = %q, want native mode notice", first)
+	}
+	if again := captureStdout(t, func() error { announceSessionMode(true); return nil }); again != "" {
+		t.Errorf("repeated announcement = %q, want none", again)
+	}
+	if changed := captureStdout(t, func() error { announceSessionMode(false); return nil }); !strings.Contains(changed, "session-manager-plugin found") {
+		t.Errorf("announcement after mode change = %q, want plugin mode notice", changed)
+	}
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/security"
+)
+
+// sessionTimeoutCheckInterval is how often a running session is checked
+// against the session timeout
+var sessionTimeoutCheckInterval = 30 * time.Second
+
+// trackSession registers the session to instanceID with sm and checks it
+// against the session timeout while it runs. The returned context is
+// cancelled, ending the session, once it outlives the timeout; the returned
+// func stops tracking and is deferred until the session ends.
+func trackSession(ctx context.Context, sm *security.Manager, instanceID string) (context.Context, func()) {
+	sm.RegisterSession(instanceID)
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	go func() {
+		ticker := time.NewTicker(sessionTimeoutCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := sm.CheckSessionTimeout(instanceID); err != nil {
+					// The terminal may be in raw mode, so line endings need explicit carriage returns
+					fmt.Fprintf(os.Stderr, "\r\nWarning: %v; disconnecting\r\n", err)
+					cancel(err)
+					return
+				}
+			}
+		}
+	}()
+
+	return ctx, func() {
+		cancel(nil)
+		sm.ExpireSession(instanceID)
+	}
+}
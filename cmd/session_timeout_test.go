@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/security"
+)
+
+func newSessionTimeoutTestManager(t *testing.T, timeout time.Duration) *security.Manager {
+	t.Helper()
+	cfg := security.DefaultConfig()
+	cfg.SessionTimeout = timeout
+	cfg.CredentialRotationCheck = false
+	cfg.AuditLogFile = filepath.Join(t.TempDir(), "audit.log")
+	return security.NewManager(cfg)
+}
+
+func useFastSessionTimeoutChecks(t *testing.T) {
+	t.Helper()
+	orig := sessionTimeoutCheckInterval
+	sessionTimeoutCheckInterval = 5 * time.Millisecond
+	t.Cleanup(func() { sessionTimeoutCheckInterval = orig })
+}
+
+func TestTrackSessionEndsSessionPastTimeout(t *testing.T) {
+	useFastSessionTimeoutChecks(t)
+	const instanceID = "i-0123456789abcdef0"
+
+	ctx, stop := trackSession(context.Background(), newSessionTimeoutTestManager(t, 20*time.Millisecond), instanceID)
+	defer stop()
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("trackSession() did not end a session past the timeout")
+	}
+	if cause := context.Cause(ctx); cause == nil || !strings.Contains(cause.Error(), "session timeout") {
+		t.Errorf("context cause = %v, want the session timeout", cause)
+	}
+}
+
+func TestTrackSessionKeepsActiveSession(t *testing.T) {
+	useFastSessionTimeoutChecks(t)
+	const instanceID = "i-0123456789abcdef0"
+	sm := newSessionTimeoutTestManager(t, time.Hour)
+
+	ctx, stop := trackSession(context.Background(), sm, instanceID)
+	time.Sleep(30 * time.Millisecond)
+	if err := ctx.Err(); err != nil {
+		t.Fatalf("session within the timeout ended: %v", err)
+	}
+
+	stop()
+	if cause := context.Cause(ctx); cause != context.Canceled {
+		t.Errorf("context cause after stop = %v, want a plain cancellation", cause)
+	}
+}
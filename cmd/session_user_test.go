@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/johnlam90/aws-ssm/pkg/security"
+)
+
+func TestBuildSwitchUserCommand(t *testing.T) {
+	medium := security.NewManager(security.DefaultConfig())
+
+	tests := []struct {
+		name    string
+		user    string
+		want    string
+		wantErr string
+	}{
+		{name: "simple user", user: "deploy", want: "sudo su - deploy"},
+		{name: "user with dash and digits", user: "app-user01", want: "sudo su - app-user01"},
+		{name: "underscore prefix", user: "_svc", want: "sudo su - _svc"},
+		{name: "empty", user: "", wantErr: "invalid --as-user"},
+		{name: "command chaining", user: "root; rm -rf /", wantErr: "invalid --as-user"},
+		{name: "command substitution", user: "$(whoami)", wantErr: "invalid --as-user"},
+		{name: "backticks", user: "`id`", wantErr: "invalid --as-user"},
+		{name: "flag injection", user: "-c", wantErr: "invalid --as-user"},
+		{name: "whitespace", user: "deploy extra", wantErr: "invalid --as-user"},
+		{name: "too long", user: strings.Repeat("a", 33), wantErr: "invalid --as-user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildSwitchUserCommand(medium, tt.user)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("buildSwitchUserCommand(%q) error = %v, want %q", tt.user, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("buildSwitchUserCommand(%q) unexpected error: %v", tt.user, err)
+			}
+			if got != tt.want {
+				t.Errorf("buildSwitchUserCommand(%q) = %q, want %q", tt.user, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildSwitchUserCommandRespectsSecurityPolicy(t *testing.T) {
+	cfg := security.DefaultConfig()
+	cfg.Level = security.SecurityStrict // sudo is not in the strict allowlist
+	strict := security.NewManager(cfg)
+
+	_, err := buildSwitchUserCommand(strict, "deploy")
+	if err == nil || !strings.Contains(err.Error(), "blocked by security policy") {
+		t.Fatalf("expected strict policy to block sudo, got %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/johnlam90/aws-ssm/pkg/cache"
+	"github.com/johnlam90/aws-ssm/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// statusIdentityTimeout bounds the STS call so status stays fast in shell prompts
+const statusIdentityTimeout = 2 * time.Second
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Print a one-line summary of the current context",
+	Long: `Print a single line describing the current region, profile, credential
+validity, and local cache state. Suitable for shell prompts and quick checks.
+
+Credential checks use a cheap STS GetCallerIdentity call, bounded to a couple of
+seconds so prompts stay fast. Missing, expired or invalid credentials are shown
+as creds=invalid rather than failing the command.
+
+Examples:
+  # One-line summary
+  aws-ssm status
+
+  # Machine-readable summary
+  aws-ssm status --output json`,
+	Args: cobra.NoArgs,
+	RunE: runStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+}
+
+// statusSummary is the data reported by the status command
+type statusSummary struct {
+	Region           string `json:"region"`
+	Profile          string `json:"profile"`
+	CredentialsValid bool   `json:"credentials_valid"`
+	Account          string `json:"account,omitempty"`
+	Arn              string `json:"arn,omitempty"`
+	CredentialError  string `json:"credential_error,omitempty"`
+	CacheEntries     int    `json:"cache_entries"`
+	CacheExpired     int    `json:"cache_expired"`
+}
+
+func runStatus(_ *cobra.Command, _ []string) error {
+	if err := validateOutputFlags(); err != nil {
+		return err
+	}
+
+	// Create a context that can be cancelled with Ctrl+C
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	// Credentials are not pre-checked so that invalid ones are reported, not fatal
+	client, err := aws.NewUncheckedClient(ctx, region, profile, configPath)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client: %w", err)
+	}
+
+	svc, err := newCacheServiceFromConfig()
+	if err != nil {
+		// The summary is still useful without cache information
+		svc = nil
+	}
+
+	summary := buildStatusSummary(ctx, client.GetCallerIdentity, svc, client.GetRegion(), resolveStatusProfile(client.AppConfig))
+	if isJSONOutput() {
+		return printJSON(summary)
+	}
+	fmt.Println(formatStatusLine(summary))
+	return nil
+}
+
+// resolveStatusProfile returns the profile in effect for display purposes, in
+// the order NewClient applies them
+func resolveStatusProfile(appCfg *config.Config) string {
+	if profile != "" {
+		return profile
+	}
+	if envProfile := os.Getenv("AWS_PROFILE"); envProfile != "" {
+		return envProfile
+	}
+	if appCfg != nil && appCfg.Default.Profile != "" {
+		return appCfg.Default.Profile
+	}
+	return "default"
+}
+
+// buildStatusSummary gathers the status fields. The identity is always asked
+// of STS, so the reported credential validity is never stale.
+func buildStatusSummary(ctx context.Context, identityFn func(context.Context) (*aws.CallerIdentity, error), svc *cache.Service, awsRegion, awsProfile string) statusSummary {
+	summary := statusSummary{
+		Region:  awsRegion,
+		Profile: awsProfile,
+	}
+
+	identityCtx, cancel := context.WithTimeout(ctx, statusIdentityTimeout)
+	defer cancel()
+	identity, err := identityFn(identityCtx)
+	if err != nil {
+		summary.CredentialError = err.Error()
+	}
+	if identity != nil {
+		summary.CredentialsValid = true
+		summary.Account = identity.Account
+		summary.Arn = identity.Arn
+	}
+
+	summary.CacheEntries, summary.CacheExpired = statusCacheCounts(svc)
+	return summary
+}
+
+// statusIdentityQuery is the query of the caller identities earlier versions
+// of status cached; they are not resource data, so they are not counted
+const statusIdentityQuery = "identity"
+
+// statusCacheCounts returns the number of cached entries and how many of them
+// have expired
+func statusCacheCounts(svc *cache.Service) (int, int) {
+	if svc == nil {
+		return 0, 0
+	}
+	entries, err := svc.ListEntries()
+	if err != nil {
+		return 0, 0
+	}
+	total, expired := 0, 0
+	for _, entry := range entries {
+		if entry.Query == statusIdentityQuery {
+			continue
+		}
+		total++
+		if entry.Expired {
+			expired++
+		}
+	}
+	return total, expired
+}
+
+// formatStatusLine renders the summary as a single line
+func formatStatusLine(s statusSummary) string {
+	creds := "creds=invalid"
+	if s.CredentialsValid {
+		creds = "creds=ok"
+		if s.Account != "" {
+			creds += "(" + s.Account + ")"
+		}
+	}
+
+	parts := []string{
+		"region=" + valueOrDash(s.Region),
+		"profile=" + valueOrDash(s.Profile),
+		creds,
+		fmt.Sprintf("cache=%d", s.CacheEntries),
+	}
+	if s.CacheExpired > 0 {
+		parts[len(parts)-1] += fmt.Sprintf("(%d expired)", s.CacheExpired)
+	}
+	return strings.Join(parts, " ")
+}
+
+// valueOrDash returns "-" for empty values
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
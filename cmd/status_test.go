@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/johnlam90/aws-ssm/pkg/cache"
+	"github.com/johnlam90/aws-ssm/pkg/config"
+)
+
+func newTestCacheService(t *testing.T) *cache.Service {
+	t.Helper()
+	svc, err := cache.NewCacheService(t.TempDir(), 5)
+	if err != nil {
+		t.Fatalf("NewCacheService: %v", err)
+	}
+	return svc
+}
+
+func TestBuildStatusSummaryReflectsIdentityAndCache(t *testing.T) {
+	svc := newTestCacheService(t)
+	if err := svc.Set("instances_us-east-1_abc", []string{"i-1"}, "us-east-1", "list"); err != nil {
+		t.Fatalf("seed cache: %v", err)
+	}
+	// Identities cached by earlier versions are not counted
+	if err := svc.Set("identity_dev_us-east-1", aws.CallerIdentity{Account: "999999999999"}, "us-east-1", statusIdentityQuery); err != nil {
+		t.Fatalf("seed cache: %v", err)
+	}
+
+	calls := 0
+	identityFn := func(context.Context) (*aws.CallerIdentity, error) {
+		calls++
+		return &aws.CallerIdentity{Account: "123456789012", Arn: "arn:aws:iam::123456789012:user/dev"}, nil
+	}
+
+	first := buildStatusSummary(context.Background(), identityFn, svc, "us-east-1", "dev")
+	if !first.CredentialsValid || first.Account != "123456789012" {
+		t.Errorf("first summary = %+v, want the identity from STS", first)
+	}
+	if first.CacheEntries != 1 {
+		t.Errorf("CacheEntries = %d, want 1", first.CacheEntries)
+	}
+
+	second := buildStatusSummary(context.Background(), identityFn, svc, "us-east-1", "dev")
+	if calls != 2 {
+		t.Errorf("identity lookups = %d, want one per summary", calls)
+	}
+
+	want := "region=us-east-1 profile=dev creds=ok(123456789012) cache=1"
+	if got := formatStatusLine(second); got != want {
+		t.Errorf("formatStatusLine() = %q, want %q", got, want)
+	}
+}
+
+func TestBuildStatusSummaryInvalidCredentials(t *testing.T) {
+	svc := newTestCacheService(t)
+	identityFn := func(context.Context) (*aws.CallerIdentity, error) {
+		return nil, errors.New("ExpiredToken")
+	}
+
+	summary := buildStatusSummary(context.Background(), identityFn, svc, "eu-west-1", "default")
+	if summary.CredentialsValid || !strings.Contains(summary.CredentialError, "ExpiredToken") {
+		t.Errorf("summary = %+v, want invalid credentials with error", summary)
+	}
+	if summary.CacheEntries != 0 {
+		t.Errorf("CacheEntries = %d, want 0", summary.CacheEntries)
+	}
+
+	want := "region=eu-west-1 profile=default creds=invalid cache=0"
+	if got := formatStatusLine(summary); got != want {
+		t.Errorf("formatStatusLine() = %q, want %q", got, want)
+	}
+}
+
+func TestBuildStatusSummaryWithoutCache(t *testing.T) {
+	identityFn := func(context.Context) (*aws.CallerIdentity, error) {
+		return &aws.CallerIdentity{Account: "111122223333"}, nil
+	}
+
+	summary := buildStatusSummary(context.Background(), identityFn, nil, "", "")
+	if !summary.CredentialsValid || summary.CacheEntries != 0 {
+		t.Errorf("summary = %+v, want valid credentials and no cache", summary)
+	}
+	if got, want := formatStatusLine(summary), "region=- profile=- creds=ok(111122223333) cache=0"; got != want {
+		t.Errorf("formatStatusLine() = %q, want %q", got, want)
+	}
+}
+
+func TestResolveStatusProfile(t *testing.T) {
+	origProfile := profile
+	defer func() { profile = origProfile }()
+
+	appCfg := &config.Config{}
+	appCfg.Default.Profile = "from-config"
+
+	tests := []struct {
+		name   string
+		flag   string
+		env    string
+		appCfg *config.Config
+		want   string
+	}{
+		{"flag wins", "from-flag", "from-env", appCfg, "from-flag"},
+		{"environment before config", "", "from-env", appCfg, "from-env"},
+		{"config default.profile", "", "", appCfg, "from-config"},
+		{"fallback", "", "", nil, "default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile = tt.flag
+			t.Setenv("AWS_PROFILE", tt.env)
+			if got := resolveStatusProfile(tt.appCfg); got != tt.want {
+				t.Errorf("resolveStatusProfile() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Step outcome statuses reported by stepRunner
+const (
+	stepSucceeded = "succeeded"
+	stepFailed    = "failed"
+	stepSkipped   = "skipped"
+)
+
+// planStep is one mutating step of a chained operation, such as
+// upgrade cluster → update node groups → verify
+type planStep struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// stepOutcome records what happened to a single step
+type stepOutcome struct {
+	Name   string
+	Status string
+	Err    error
+}
+
+// stepPlanResult aggregates the outcomes of a step plan
+type stepPlanResult struct {
+	Confirmed bool
+	Outcomes  []stepOutcome
+}
+
+// Count returns the number of steps with the given status
+func (r stepPlanResult) Count(status string) int {
+	n := 0
+	for _, o := range r.Outcomes {
+		if o.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Err joins the errors of every failed step, or returns nil
+func (r stepPlanResult) Err() error {
+	var errs []error
+	for _, o := range r.Outcomes {
+		if o.Status == stepFailed {
+			errs = append(errs, fmt.Errorf("step %q: %w", o.Name, o.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// stepRunner presents every planned step up front, confirms once, then runs the
+// steps in order with per-step status
+type stepRunner struct {
+	out               io.Writer
+	prompt            prompter
+	skipConfirm       bool
+	continueOnFailure bool // keep going after a failed step instead of stopping
+}
+
+// Run executes steps after a single confirmation. A declined confirmation is not
+// an error; every step is reported as skipped.
+func (r *stepRunner) Run(ctx context.Context, title string, steps []planStep) (stepPlanResult, error) {
+	result := stepPlanResult{Outcomes: make([]stepOutcome, len(steps))}
+	for i, step := range steps {
+		result.Outcomes[i] = stepOutcome{Name: step.Name, Status: stepSkipped}
+	}
+
+	fmt.Fprintf(r.out, "%s (%d steps):\n", title, len(steps))
+	for i, step := range steps {
+		fmt.Fprintf(r.out, "  %d. %s\n", i+1, step.Name)
+	}
+	fmt.Fprintln(r.out)
+
+	if !r.skipConfirm {
+		confirmed, err := r.prompt.Confirm("Run all steps?", false)
+		if err != nil {
+			return result, err
+		}
+		if !confirmed {
+			fmt.Fprintln(r.out, "Cancelled; no steps were run.")
+			return result, nil
+		}
+	}
+	result.Confirmed = true
+
+	for i, step := range steps {
+		if err := ctx.Err(); err != nil {
+			fmt.Fprintf(r.out, "Stopped before step %d: %v\n", i+1, err)
+			return result, err
+		}
+
+		fmt.Fprintf(r.out, "[%d/%d] %s...\n", i+1, len(steps), step.Name)
+		if err := step.Run(ctx); err != nil {
+			result.Outcomes[i] = stepOutcome{Name: step.Name, Status: stepFailed, Err: err}
+			fmt.Fprintf(r.out, "[%d/%d] %s failed: %v\n", i+1, len(steps), step.Name, err)
+			if !r.continueOnFailure {
+				break
+			}
+			continue
+		}
+		result.Outcomes[i].Status = stepSucceeded
+		fmt.Fprintf(r.out, "[%d/%d] %s done\n", i+1, len(steps), step.Name)
+	}
+
+	fmt.Fprintf(r.out, "\n%d succeeded, %d failed, %d skipped\n",
+		result.Count(stepSucceeded), result.Count(stepFailed), result.Count(stepSkipped))
+	return result, result.Err()
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// recordedSteps builds steps that record their execution order and fail where requested
+func recordedSteps(ran *[]string, failAt map[string]error, names ...string) []planStep {
+	steps := make([]planStep, 0, len(names))
+	for _, name := range names {
+		name := name
+		steps = append(steps, planStep{Name: name, Run: func(context.Context) error {
+			*ran = append(*ran, name)
+			return failAt[name]
+		}})
+	}
+	return steps
+}
+
+func outcomeStatuses(result stepPlanResult) []string {
+	statuses := make([]string, 0, len(result.Outcomes))
+	for _, o := range result.Outcomes {
+		statuses = append(statuses, o.Status)
+	}
+	return statuses
+}
+
+func TestStepRunner(t *testing.T) {
+	errUpdate := errors.New("update failed")
+
+	tests := []struct {
+		name              string
+		answer            string
+		continueOnFailure bool
+		failAt            map[string]error
+		wantRan           []string
+		wantStatuses      []string
+		wantConfirmed     bool
+		wantErr           error
+	}{
+		{
+			name:          "all steps succeed",
+			answer:        "y",
+			wantRan:       []string{"upgrade", "update", "verify"},
+			wantStatuses:  []string{stepSucceeded, stepSucceeded, stepSucceeded},
+			wantConfirmed: true,
+		},
+		{
+			name:          "stop on failure",
+			answer:        "y",
+			failAt:        map[string]error{"update": errUpdate},
+			wantRan:       []string{"upgrade", "update"},
+			wantStatuses:  []string{stepSucceeded, stepFailed, stepSkipped},
+			wantConfirmed: true,
+			wantErr:       errUpdate,
+		},
+		{
+			name:              "continue on failure",
+			answer:            "y",
+			continueOnFailure: true,
+			failAt:            map[string]error{"update": errUpdate},
+			wantRan:           []string{"upgrade", "update", "verify"},
+			wantStatuses:      []string{stepSucceeded, stepFailed, stepSucceeded},
+			wantConfirmed:     true,
+			wantErr:           errUpdate,
+		},
+		{
+			name:         "declined confirmation runs nothing",
+			answer:       "n",
+			wantStatuses: []string{stepSkipped, stepSkipped, stepSkipped},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			var ran []string
+			runner := &stepRunner{
+				out:               &out,
+				prompt:            newLinePrompter(strings.NewReader(tt.answer+"\n"), &out),
+				continueOnFailure: tt.continueOnFailure,
+			}
+
+			result, err := runner.Run(context.Background(), "Upgrade cluster", recordedSteps(&ran, tt.failAt, "upgrade", "update", "verify"))
+
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if strings.Join(ran, ",") != strings.Join(tt.wantRan, ",") {
+				t.Errorf("ran = %v, want %v", ran, tt.wantRan)
+			}
+			if got := outcomeStatuses(result); strings.Join(got, ",") != strings.Join(tt.wantStatuses, ",") {
+				t.Errorf("statuses = %v, want %v", got, tt.wantStatuses)
+			}
+			if result.Confirmed != tt.wantConfirmed {
+				t.Errorf("Confirmed = %v, want %v", result.Confirmed, tt.wantConfirmed)
+			}
+			if !strings.Contains(out.String(), "1. upgrade") || !strings.Contains(out.String(), "3. verify") {
+				t.Errorf("plan should list every step up front:\n%s", out.String())
+			}
+		})
+	}
+}
+
+func TestStepRunnerConfirmsOnce(t *testing.T) {
+	var out bytes.Buffer
+	var ran []string
+	// Only a single answer is available; a second prompt would fail to read
+	runner := &stepRunner{out: &out, prompt: newLinePrompter(strings.NewReader("yes\n"), &out)}
+
+	result, err := runner.Run(context.Background(), "Plan", recordedSteps(&ran, nil, "a", "b", "c", "d"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Count(stepSucceeded) != 4 {
+		t.Errorf("succeeded = %d, want 4", result.Count(stepSucceeded))
+	}
+	if strings.Count(out.String(), "Run all steps?") != 1 {
+		t.Errorf("expected exactly one confirmation prompt:\n%s", out.String())
+	}
+}
+
+func TestStepRunnerSkipConfirmAndCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var out bytes.Buffer
+	var ran []string
+	steps := []planStep{
+		{Name: "first", Run: func(context.Context) error { ran = append(ran, "first"); cancel(); return nil }},
+		{Name: "second", Run: func(context.Context) error { ran = append(ran, "second"); return nil }},
+	}
+
+	runner := &stepRunner{out: &out, skipConfirm: true}
+	result, err := runner.Run(ctx, "Plan", steps)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("error = %v, want context.Canceled", err)
+	}
+	if strings.Join(ran, ",") != "first" {
+		t.Errorf("ran = %v, want only first", ran)
+	}
+	if got := outcomeStatuses(result); strings.Join(got, ",") != stepSucceeded+","+stepSkipped {
+		t.Errorf("statuses = %v", got)
+	}
+}
+
+func TestStepPlanResultErrAggregatesFailures(t *testing.T) {
+	errA, errB := errors.New("a broke"), errors.New("b broke")
+	result := stepPlanResult{Outcomes: []stepOutcome{
+		{Name: "a", Status: stepFailed, Err: errA},
+		{Name: "b", Status: stepFailed, Err: errB},
+		{Name: "c", Status: stepSucceeded},
+	}}
+
+	err := result.Err()
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Fatalf("Err() = %v, want both failures", err)
+	}
+	if result.Count(stepFailed) != 2 || result.Count(stepSucceeded) != 1 {
+		t.Errorf("counts = %d failed, %d succeeded", result.Count(stepFailed), result.Count(stepSucceeded))
+	}
+	if (stepPlanResult{}).Err() != nil {
+		t.Error("empty result should have no error")
+	}
+}
@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"runtime"
+	"syscall"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/config"
+	"github.com/johnlam90/aws-ssm/pkg/security"
+	"github.com/johnlam90/aws-ssm/pkg/support"
+	"github.com/johnlam90/aws-ssm/pkg/version"
+	"github.com/spf13/cobra"
+)
+
+// supportLogTailBytes is how much of the end of each log file is bundled
+const supportLogTailBytes = 1 << 20
+
+var (
+	supportBundleFile string
+
+	// supportBundleChecker returns the health checks run for the bundle; replaced in tests
+	supportBundleChecker = newDoctorChecker
+	// lastErrorPath returns the last-error state file location; replaced in tests
+	lastErrorPath = config.DefaultLastErrorPath
+)
+
+var supportBundleCmd = &cobra.Command{
+	Use:   "support-bundle",
+	Short: "Collect diagnostics into a zip file to attach to issue reports",
+	Long: `Collect diagnostics into a zip file and print its path.
+
+The bundle contains version information, the config file, recognized environment
+variables, the end of the --log-file and audit log, the last failed command, and
+the doctor health checks. Credentials and values of secret-looking keys are masked.
+
+Examples:
+  # Write aws-ssm-support-<timestamp>.zip to the current directory
+  aws-ssm support-bundle
+
+  # Include recent application logs
+  aws-ssm support-bundle --log-file ~/.aws-ssm/aws-ssm.log
+
+  # Choose the output file
+  aws-ssm support-bundle --file /tmp/bundle.zip`,
+	Args: cobra.NoArgs,
+	RunE: runSupportBundle,
+}
+
+func init() {
+	rootCmd.AddCommand(supportBundleCmd)
+	supportBundleCmd.Flags().StringVar(&supportBundleFile, "file", "", "Bundle path (default: aws-ssm-support-<timestamp>.zip in the current directory)")
+}
+
+func runSupportBundle(_ *cobra.Command, _ []string) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	now := time.Now()
+	path := supportBundleFile
+	if path == "" {
+		path = fmt.Sprintf("aws-ssm-support-%s.zip", now.Format("20060102-150405"))
+	}
+
+	files := collectSupportFiles(ctx)
+	var buf bytes.Buffer
+	if err := support.WriteZip(&buf, files, now); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write support bundle: %w", err)
+	}
+
+	fmt.Printf("Support bundle written to %s\n", path)
+	return nil
+}
+
+// collectSupportFiles gathers the bundle contents. Sources that cannot be read
+// are noted in their file instead of failing the bundle.
+func collectSupportFiles(ctx context.Context) []support.File {
+	return []support.File{
+		{Name: "version.txt", Content: supportVersionInfo()},
+		{Name: "config.yaml", Content: supportConfigFile()},
+		{Name: "env.txt", Content: supportEnvReport()},
+		{Name: "health.txt", Content: supportHealthReport(ctx)},
+		{Name: "last_error.json", Content: supportLastError()},
+		{Name: "logs/aws-ssm.log", Content: supportLogTail(logFile, "--log-file")},
+		{Name: "logs/audit.log", Content: supportLogTail(os.Getenv(security.AuditLogFileEnv), security.AuditLogFileEnv)},
+	}
+}
+
+func supportVersionInfo() []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "aws-ssm version %s\n", version.GetFullVersion())
+	for _, key := range []string{"version", "commit", "build_date", "go_version", "os", "arch"} {
+		fmt.Fprintf(&b, "%s: %s\n", key, version.GetBuildInfo()[key])
+	}
+	fmt.Fprintf(&b, "runtime: %s/%s %s\n", runtime.GOOS, runtime.GOARCH, runtime.Version())
+	return b.Bytes()
+}
+
+func supportConfigFile() []byte {
+	path, err := config.ResolvePath(configPath)
+	if err != nil {
+		return []byte(fmt.Sprintf("# config path could not be resolved: %v\n", err))
+	}
+	// #nosec G304 - path is the user's own config file
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return []byte(fmt.Sprintf("# %s could not be read: %v\n", path, err))
+	}
+	return append([]byte(fmt.Sprintf("# %s\n", path)), data...)
+}
+
+func supportEnvReport() []byte {
+	var b bytes.Buffer
+	if err := printEnvReport(&b, config.EnvReport(), 0); err != nil {
+		return []byte(fmt.Sprintf("environment report failed: %v\n", err))
+	}
+	return b.Bytes()
+}
+
+func supportHealthReport(ctx context.Context) []byte {
+	var b bytes.Buffer
+	printDoctorResult(&b, supportBundleChecker().CheckAll(ctx))
+	return b.Bytes()
+}
+
+func supportLastError() []byte {
+	path, err := lastErrorPath()
+	if err != nil {
+		return []byte(fmt.Sprintf("{\"error\": %q}\n", err.Error()))
+	}
+	lastErr, err := config.LoadLastError(path)
+	if err != nil {
+		return []byte(fmt.Sprintf("{\"error\": %q}\n", err.Error()))
+	}
+	if lastErr == nil {
+		return []byte("null\n")
+	}
+	data, err := json.MarshalIndent(lastErr, "", "  ")
+	if err != nil {
+		return []byte(fmt.Sprintf("{\"error\": %q}\n", err.Error()))
+	}
+	return append(data, '\n')
+}
+
+// supportLogTail returns the last supportLogTailBytes of the log at path;
+// source names the flag or variable that sets the path
+func supportLogTail(path, source string) []byte {
+	if path == "" {
+		return []byte(fmt.Sprintf("(not configured; set %s to include this log)\n", source))
+	}
+	// #nosec G304 - path is the user's own log file
+	file, err := os.Open(path)
+	if err != nil {
+		return []byte(fmt.Sprintf("(%s could not be read: %v)\n", path, err))
+	}
+	defer func() { _ = file.Close() }()
+
+	if info, err := file.Stat(); err == nil && info.Size() > supportLogTailBytes {
+		if _, err := file.Seek(-supportLogTailBytes, io.SeekEnd); err != nil {
+			return []byte(fmt.Sprintf("(%s could not be read: %v)\n", path, err))
+		}
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return []byte(fmt.Sprintf("(%s could not be read: %v)\n", path, err))
+	}
+	return data
+}
+
+// recordLastError stores a failed command for the next support bundle.
+// Failures are ignored, since recording must never mask the command's own error.
+func recordLastError(command string, err error) {
+	if err == nil || errors.Is(err, errCancelled) || errors.Is(err, context.Canceled) {
+		return
+	}
+	path, pathErr := lastErrorPath()
+	if pathErr != nil {
+		return
+	}
+	_ = config.SaveLastError(path, &config.LastError{
+		Command:  command,
+		Message:  err.Error(),
+		ExitCode: ExitCode(err),
+		Time:     time.Now().UTC(),
+	})
+}
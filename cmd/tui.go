@@ -42,12 +42,25 @@ Examples:
   aws-ssm tui --profile production
 
   # Launch TUI without colors
-  aws-ssm tui --no-color`,
+  aws-ssm tui --no-color
+
+  # Browse without connecting, scaling or updating anything
+  aws-ssm tui --view-only
+
+  # Stream loaded resources as JSON lines instead of opening the UI
+  aws-ssm tui --headless-json`,
 	RunE: runTUI,
 }
 
+var (
+	tuiViewOnly     bool
+	tuiHeadlessJSON bool
+)
+
 func init() {
 	rootCmd.AddCommand(tuiCmd)
+	tuiCmd.Flags().BoolVar(&tuiViewOnly, "view-only", false, "Disable connecting, scaling and launch template updates (browse only)")
+	tuiCmd.Flags().BoolVar(&tuiHeadlessJSON, "headless-json", false, "Run the data loaders without the UI and stream JSON events to stdout")
 }
 
 func runTUI(_ *cobra.Command, _ []string) error {
@@ -61,6 +74,10 @@ func runTUI(_ *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to create AWS client: %w", err)
 	}
 
+	if tuiHeadlessJSON {
+		return tui.RunHeadless(os.Stdout, sensitiveTagMask(client), tui.HeadlessLoaders(ctx, client)...)
+	}
+
 	// Get actual region from client
 	actualRegion := client.GetRegion()
 
@@ -70,12 +87,24 @@ func runTUI(_ *cobra.Command, _ []string) error {
 		actualProfile = "default"
 	}
 
+	ec2Sort, err := resolveInstanceSort("", client.AppConfig)
+	if err != nil {
+		return err
+	}
+
 	// Create TUI config
 	config := tui.Config{
 		Region:     actualRegion,
 		Profile:    actualProfile,
 		ConfigPath: configPath,
 		NoColor:    noColor,
+		ViewOnly:   tuiViewOnly,
+		EC2Sort:    ec2Sort,
+	}
+	if client.AppConfig != nil {
+		config.EC2RowTemplate = client.AppConfig.TUI.EC2RowTemplate
+		config.MaxConcurrentLoads = client.AppConfig.TUI.MaxConcurrentLoads
+		config.SensitiveTags = client.AppConfig.Output.SensitiveTags
 	}
 
 	// Create TUI model
@@ -109,6 +138,7 @@ func runTUI(_ *cobra.Command, _ []string) error {
 	// Check if we need to start an SSM session
 	if instanceID := m.GetPendingSSMSession(); instanceID != nil {
 		fmt.Printf("\nStarting session with instance %s...\n\n", *instanceID)
+		applySessionIdleWarning(client)
 		if err := startPendingSSMSession(ctx, client, *instanceID); err != nil {
 			return fmt.Errorf("failed to start SSM session: %w", err)
 		}
@@ -122,6 +152,7 @@ func runTUI(_ *cobra.Command, _ []string) error {
 		if err != nil {
 			return fmt.Errorf("failed to fetch cluster details: %w", err)
 		}
+		cluster.Tags = sensitiveTagMask(client).Apply(cluster.Tags)
 		tui.DisplayClusterInfo(cluster)
 		return nil
 	}
@@ -41,16 +41,6 @@ func printInteractivePrompt(selectorName string) {
 	}
 }
 
-// printSelectionCancelled prints a styled cancellation message
-func printSelectionCancelled() {
-	if noColor {
-		fmt.Println("\nSelection cancelled.")
-	} else {
-		msg := fuzzy.ColorYellow + "Selection cancelled." + fuzzy.ColorReset
-		fmt.Printf("\n%s\n", msg)
-	}
-}
-
 // printNoSelection prints a styled message when no item is selected
 func printNoSelection(itemType string) {
 	if noColor {
@@ -16,11 +16,14 @@ func main() {
 	ctx := context.Background()
 	metrics.InitializeGlobalMetricsService(ctx)
 
-	if err := cmd.Execute(); err != nil {
+	err := cmd.Execute()
+	// Flush metrics recorded since the last report before exiting
+	metrics.ShutdownGlobalMetricsService()
+	if err != nil {
 		if _, writeErr := fmt.Fprintf(os.Stderr, "Error: %v\n", err); writeErr != nil {
 			// If we can't even print the error, just exit with error code
-			os.Exit(1)
+			os.Exit(cmd.ExitCode(err))
 		}
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 }
@@ -0,0 +1,54 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+)
+
+// asgMemberBatchSize bounds the instance IDs sent in one DescribeInstances filter
+const asgMemberBatchSize = 200
+
+// InServiceInstanceIDs returns the IDs of the group's instances in the
+// InService lifecycle state, in group order
+func (g *AutoScalingGroup) InServiceInstanceIDs() []string {
+	var ids []string
+	for _, inst := range g.Instances {
+		if inst.LifecycleState == string(types.LifecycleStateInService) {
+			ids = append(ids, inst.InstanceID)
+		}
+	}
+	return ids
+}
+
+// ListASGInServiceInstances returns the running EC2 instances that are
+// InService members of the named Auto Scaling Group
+func (c *Client) ListASGInServiceInstances(ctx context.Context, asgName string) ([]Instance, error) {
+	asg, err := c.DescribeAutoScalingGroup(ctx, asgName)
+	if err != nil {
+		return nil, err
+	}
+	return c.inServiceInstances(ctx, asg)
+}
+
+// inServiceInstances describes the group's InService instances, keeping the group order
+func (c *Client) inServiceInstances(ctx context.Context, asg *AutoScalingGroup) ([]Instance, error) {
+	ids := asg.InServiceInstanceIDs()
+	instances, err := c.describeRunningInstancesByID(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe instances of Auto Scaling Group %s: %w", asg.Name, err)
+	}
+	byID := make(map[string]Instance, len(instances))
+	for _, inst := range instances {
+		byID[inst.InstanceID] = inst
+	}
+
+	members := make([]Instance, 0, len(byID))
+	for _, id := range ids {
+		if inst, ok := byID[id]; ok {
+			members = append(members, inst)
+		}
+	}
+	return members, nil
+}
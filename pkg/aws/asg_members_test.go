@@ -0,0 +1,68 @@
+package aws
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func TestInServiceInstancesUsesASGMembers(t *testing.T) {
+	asg := &AutoScalingGroup{
+		Name: "web-asg",
+		Instances: []ASGInstance{
+			{InstanceID: "i-1", LifecycleState: "InService"},
+			{InstanceID: "i-2", LifecycleState: "Pending"},
+			{InstanceID: "i-3", LifecycleState: "InService"},
+			{InstanceID: "i-4", LifecycleState: "Terminating"},
+			{InstanceID: "i-5", LifecycleState: "Standby"},
+		},
+	}
+
+	var captured []types.Filter
+	c := &Client{}
+	c.describeInstancesHook = func(_ context.Context, f []types.Filter) ([]Instance, error) {
+		captured = f
+		// EC2 returns results in its own order
+		return []Instance{{InstanceID: "i-3", State: "running"}, {InstanceID: "i-1", State: "running"}}, nil
+	}
+
+	got, err := c.inServiceInstances(context.Background(), asg)
+	if err != nil {
+		t.Fatalf("inServiceInstances() error = %v", err)
+	}
+
+	ids := findFilterByName(t, captured, "instance-id")
+	if !reflect.DeepEqual(ids.Values, []string{"i-1", "i-3"}) {
+		t.Errorf("instance-id filter = %v, want only the InService members [i-1 i-3]", ids.Values)
+	}
+	state := findFilterByName(t, captured, "instance-state-name")
+	if !reflect.DeepEqual(state.Values, []string{"running"}) {
+		t.Errorf("state filter = %v, want [running]", state.Values)
+	}
+
+	var gotIDs []string
+	for _, inst := range got {
+		gotIDs = append(gotIDs, inst.InstanceID)
+	}
+	if !reflect.DeepEqual(gotIDs, []string{"i-1", "i-3"}) {
+		t.Errorf("instances = %v, want group order [i-1 i-3]", gotIDs)
+	}
+}
+
+func TestInServiceInstancesNoMembers(t *testing.T) {
+	c := &Client{}
+	c.describeInstancesHook = func(context.Context, []types.Filter) ([]Instance, error) {
+		t.Fatal("DescribeInstances should not be called without InService instances")
+		return nil, nil
+	}
+
+	got, err := c.inServiceInstances(context.Background(), &AutoScalingGroup{
+		Name:      "empty",
+		Instances: []ASGInstance{{InstanceID: "i-1", LifecycleState: "Pending"}},
+	})
+	if err != nil || len(got) != 0 {
+		t.Errorf("inServiceInstances() = %v, %v; want none", got, err)
+	}
+}
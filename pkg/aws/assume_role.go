@@ -0,0 +1,136 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// assumeRoleSessionName identifies aws-ssm sessions in CloudTrail
+const assumeRoleSessionName = "aws-ssm"
+
+// RoleHop is one role in an assume-role chain
+type RoleHop struct {
+	RoleARN    string
+	ExternalID string
+}
+
+// roleChain holds the process-wide role chain set from CLI flags
+var roleChain []RoleHop
+
+// SetRoleChain sets the roles assumed, in order, by clients created afterwards
+func SetRoleChain(chain []RoleHop) {
+	roleChain = chain
+}
+
+// ParseRoleChain parses a comma-separated list of role ARNs and the matching
+// external IDs. A single external ID applies to every hop; otherwise there must
+// be one entry per role, left empty for roles that do not need one.
+func ParseRoleChain(roleARNs, externalIDs string) ([]RoleHop, error) {
+	if strings.TrimSpace(roleARNs) == "" {
+		if strings.TrimSpace(externalIDs) != "" {
+			return nil, fmt.Errorf("an external ID requires a role to assume")
+		}
+		return nil, nil
+	}
+
+	arns := splitTrimmed(roleARNs)
+	chain := make([]RoleHop, len(arns))
+	for i, roleARN := range arns {
+		if err := validateRoleARN(roleARN); err != nil {
+			return nil, fmt.Errorf("invalid role %d of %d: %w", i+1, len(arns), err)
+		}
+		chain[i].RoleARN = roleARN
+	}
+
+	if strings.TrimSpace(externalIDs) == "" {
+		return chain, nil
+	}
+	ids := splitTrimmed(externalIDs)
+	switch len(ids) {
+	case 1:
+		for i := range chain {
+			chain[i].ExternalID = ids[0]
+		}
+	case len(chain):
+		for i := range chain {
+			chain[i].ExternalID = ids[i]
+		}
+	default:
+		return nil, fmt.Errorf("got %d external IDs for %d roles (expected 1 or %d)", len(ids), len(chain), len(chain))
+	}
+	return chain, nil
+}
+
+// splitTrimmed splits a comma-separated list, trimming each entry
+func splitTrimmed(list string) []string {
+	parts := strings.Split(list, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
+
+// validateRoleARN checks that value is an IAM role ARN
+func validateRoleARN(value string) error {
+	if value == "" {
+		return fmt.Errorf("role ARN is empty")
+	}
+	parsed, err := arn.Parse(value)
+	if err != nil {
+		return fmt.Errorf("%q is not an ARN: %w", value, err)
+	}
+	if parsed.Service != "iam" || !strings.HasPrefix(parsed.Resource, "role/") {
+		return fmt.Errorf("%q is not an IAM role ARN", value)
+	}
+	return nil
+}
+
+// stsClientFactory creates the STS client used to assume a hop's role
+type stsClientFactory func(cfg aws.Config) stscreds.AssumeRoleAPIClient
+
+func newSTSClient(cfg aws.Config) stscreds.AssumeRoleAPIClient {
+	return sts.NewFromConfig(cfg)
+}
+
+// assumeRoleChain returns cfg with credentials for the last role in chain. Each
+// role is assumed with the previous role's credentials, starting from cfg's.
+// Every hop is assumed up front so a failure names the hop that caused it; the
+// returned credentials refresh through the same chain when they expire.
+func assumeRoleChain(ctx context.Context, cfg aws.Config, chain []RoleHop, newSTS stsClientFactory) (aws.Config, error) {
+	for i, hop := range chain {
+		cfg = withAssumedRole(cfg, hop, newSTS)
+		if _, err := cfg.Credentials.Retrieve(ctx); err != nil {
+			return aws.Config{}, fmt.Errorf("failed to assume role %d of %d (%s): %w", i+1, len(chain), hop.RoleARN, err)
+		}
+	}
+	return cfg, nil
+}
+
+// deferredRoleChain returns cfg with credentials for the last role in chain
+// without assuming any role yet, so a failure surfaces from the first API call
+func deferredRoleChain(cfg aws.Config, chain []RoleHop, newSTS stsClientFactory) aws.Config {
+	for _, hop := range chain {
+		cfg = withAssumedRole(cfg, hop, newSTS)
+	}
+	return cfg
+}
+
+// withAssumedRole returns a copy of cfg whose credentials assume hop's role
+// with cfg's credentials
+func withAssumedRole(cfg aws.Config, hop RoleHop, newSTS stsClientFactory) aws.Config {
+	provider := stscreds.NewAssumeRoleProvider(newSTS(cfg), hop.RoleARN, func(o *stscreds.AssumeRoleOptions) {
+		o.RoleSessionName = assumeRoleSessionName
+		if hop.ExternalID != "" {
+			o.ExternalID = aws.String(hop.ExternalID)
+		}
+	})
+	cfg = cfg.Copy()
+	cfg.Credentials = aws.NewCredentialsCache(provider)
+	return cfg
+}
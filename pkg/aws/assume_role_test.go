@@ -0,0 +1,186 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	ststypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
+)
+
+// assumedRole records one AssumeRole call and the credentials that made it
+type assumedRole struct {
+	RoleARN     string
+	ExternalID  string
+	SourceKeyID string
+}
+
+// MockAssumeRoleSTS issues credentials named after the assumed role
+type MockAssumeRoleSTS struct {
+	source aws.CredentialsProvider
+	calls  *[]assumedRole
+	fail   map[string]error
+}
+
+func (m *MockAssumeRoleSTS) AssumeRole(ctx context.Context, params *sts.AssumeRoleInput, _ ...func(*sts.Options)) (*sts.AssumeRoleOutput, error) {
+	source, err := m.source.Retrieve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	roleARN := aws.ToString(params.RoleArn)
+	*m.calls = append(*m.calls, assumedRole{
+		RoleARN:     roleARN,
+		ExternalID:  aws.ToString(params.ExternalId),
+		SourceKeyID: source.AccessKeyID,
+	})
+	if err := m.fail[roleARN]; err != nil {
+		return nil, err
+	}
+	return &sts.AssumeRoleOutput{Credentials: &ststypes.Credentials{
+		AccessKeyId:     aws.String("AK-" + roleARN[strings.LastIndex(roleARN, "/")+1:]),
+		SecretAccessKey: aws.String("secret"),
+		SessionToken:    aws.String("token"),
+		Expiration:      aws.Time(time.Now().Add(time.Hour)),
+	}}, nil
+}
+
+// mockSTSFactory returns a factory whose clients record calls into calls
+func mockSTSFactory(calls *[]assumedRole, fail map[string]error) stsClientFactory {
+	return func(cfg aws.Config) stscreds.AssumeRoleAPIClient {
+		return &MockAssumeRoleSTS{source: cfg.Credentials, calls: calls, fail: fail}
+	}
+}
+
+func baseCredentialsConfig() aws.Config {
+	return aws.Config{Credentials: credentials.NewStaticCredentialsProvider("AK-base", "secret", "")}
+}
+
+func TestAssumeRoleChainAssumesRolesInOrder(t *testing.T) {
+	chain := []RoleHop{
+		{RoleARN: "arn:aws:iam::111111111111:role/RoleA", ExternalID: "ext-a"},
+		{RoleARN: "arn:aws:iam::222222222222:role/RoleB", ExternalID: "ext-b"},
+	}
+	var calls []assumedRole
+
+	cfg, err := assumeRoleChain(context.Background(), baseCredentialsConfig(), chain, mockSTSFactory(&calls, nil))
+	if err != nil {
+		t.Fatalf("assumeRoleChain() error = %v", err)
+	}
+
+	want := []assumedRole{
+		{RoleARN: "arn:aws:iam::111111111111:role/RoleA", ExternalID: "ext-a", SourceKeyID: "AK-base"},
+		{RoleARN: "arn:aws:iam::222222222222:role/RoleB", ExternalID: "ext-b", SourceKeyID: "AK-RoleA"},
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("AssumeRole calls = %+v, want %+v", calls, want)
+	}
+
+	creds, err := cfg.Credentials.Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("Retrieve() error = %v", err)
+	}
+	if creds.AccessKeyID != "AK-RoleB" {
+		t.Errorf("final credentials = %s, want those of the last role", creds.AccessKeyID)
+	}
+}
+
+func TestAssumeRoleChainReportsFailingHop(t *testing.T) {
+	chain := []RoleHop{
+		{RoleARN: "arn:aws:iam::111111111111:role/RoleA"},
+		{RoleARN: "arn:aws:iam::222222222222:role/RoleB"},
+		{RoleARN: "arn:aws:iam::333333333333:role/RoleC"},
+	}
+	var calls []assumedRole
+	fail := map[string]error{"arn:aws:iam::222222222222:role/RoleB": errors.New("AccessDenied")}
+
+	_, err := assumeRoleChain(context.Background(), baseCredentialsConfig(), chain, mockSTSFactory(&calls, fail))
+	if err == nil {
+		t.Fatal("assumeRoleChain() succeeded, want an error from the second hop")
+	}
+	if !strings.Contains(err.Error(), "role 2 of 3") || !strings.Contains(err.Error(), "role/RoleB") {
+		t.Errorf("error = %v, want it to name hop 2 (RoleB)", err)
+	}
+	for _, call := range calls {
+		if strings.HasSuffix(call.RoleARN, "RoleC") {
+			t.Error("RoleC assumed after an earlier hop failed")
+		}
+	}
+}
+
+func TestParseRoleChain(t *testing.T) {
+	const (
+		roleA = "arn:aws:iam::111111111111:role/RoleA"
+		roleB = "arn:aws:iam::222222222222:role/path/RoleB"
+	)
+	tests := []struct {
+		name        string
+		arns        string
+		externalIDs string
+		want        []RoleHop
+		wantErr     bool
+	}{
+		{name: "none", arns: "", want: nil},
+		{name: "single role", arns: roleA, want: []RoleHop{{RoleARN: roleA}}},
+		{
+			name: "chain with spaces",
+			arns: roleA + " , " + roleB,
+			want: []RoleHop{{RoleARN: roleA}, {RoleARN: roleB}},
+		},
+		{
+			name: "one external ID for every hop", arns: roleA + "," + roleB, externalIDs: "shared",
+			want: []RoleHop{{RoleARN: roleA, ExternalID: "shared"}, {RoleARN: roleB, ExternalID: "shared"}},
+		},
+		{
+			name: "external ID per hop", arns: roleA + "," + roleB, externalIDs: ",ext-b",
+			want: []RoleHop{{RoleARN: roleA}, {RoleARN: roleB, ExternalID: "ext-b"}},
+		},
+		{name: "external ID count mismatch", arns: roleA + "," + roleB, externalIDs: "a,b,c", wantErr: true},
+		{name: "external ID without role", externalIDs: "ext", wantErr: true},
+		{name: "empty hop", arns: roleA + ",", wantErr: true},
+		{name: "not an ARN", arns: "RoleA", wantErr: true},
+		{name: "not a role", arns: "arn:aws:iam::111111111111:user/dev", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRoleChain(tt.arns, tt.externalIDs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRoleChain() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRoleChain() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeferredRoleChainAssumesOnFirstUse(t *testing.T) {
+	chain := []RoleHop{
+		{RoleARN: "arn:aws:iam::111111111111:role/RoleA"},
+		{RoleARN: "arn:aws:iam::222222222222:role/RoleB"},
+	}
+	var calls []assumedRole
+
+	cfg := deferredRoleChain(baseCredentialsConfig(), chain, mockSTSFactory(&calls, nil))
+	if len(calls) != 0 {
+		t.Fatalf("deferredRoleChain() assumed %d role(s) up front, want none", len(calls))
+	}
+
+	creds, err := cfg.Credentials.Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("Retrieve() error = %v", err)
+	}
+	if creds.AccessKeyID != "AK-RoleB" || len(calls) != 2 {
+		t.Errorf("credentials = %s after %d AssumeRole call(s), want AK-RoleB after 2", creds.AccessKeyID, len(calls))
+	}
+
+	if cfg := deferredRoleChain(baseCredentialsConfig(), nil, mockSTSFactory(&calls, nil)); cfg.Credentials == nil {
+		t.Error("deferredRoleChain() without roles dropped the base credentials")
+	}
+}
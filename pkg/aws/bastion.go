@@ -0,0 +1,144 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// remoteHostForwardDocument forwards a local port through the session's
+// instance to a port on another host
+const remoteHostForwardDocument = "AWS-StartPortForwardingSessionToRemoteHost"
+
+// SSMInstanceInfoAPI defines the interface for SSM managed instance lookups
+type SSMInstanceInfoAPI interface {
+	DescribeInstanceInformation(ctx context.Context, params *ssm.DescribeInstanceInformationInput, optFns ...func(*ssm.Options)) (*ssm.DescribeInstanceInformationOutput, error)
+}
+
+// BastionForward is a port forward to a target instance that hops through a
+// bastion: the SSM session runs on the bastion, which connects to the target
+type BastionForward struct {
+	Bastion    *Instance
+	Target     *Instance
+	Host       string // Address the bastion connects to, the target's private IP
+	RemotePort int
+	LocalPort  int
+}
+
+// sessionOptions returns the plugin session options that start the forward
+// on the bastion
+func (f *BastionForward) sessionOptions() SessionOptions {
+	return SessionOptions{
+		DocumentName: remoteHostForwardDocument,
+		Parameters: map[string][]string{
+			"host":            {f.Host},
+			"portNumber":      {strconv.Itoa(f.RemotePort)},
+			"localPortNumber": {strconv.Itoa(f.LocalPort)},
+		},
+	}
+}
+
+// ResolveBastionForward resolves the bastion named by bastionIdentifier and
+// checks that it is managed by SSM before planning the forward. The target
+// only needs a private IP the bastion can reach; it need not run the agent.
+func (c *Client) ResolveBastionForward(ctx context.Context, bastionIdentifier string, target *Instance, remotePort, localPort int) (*BastionForward, error) {
+	var api SSMInstanceInfoAPI
+	if c.SSMClient != nil {
+		api = c.SSMClient
+	} else {
+		api = ssm.NewFromConfig(c.Config)
+	}
+	return resolveBastionForward(ctx, c.ResolveSingleInstance, api, bastionIdentifier, target, remotePort, localPort)
+}
+
+func resolveBastionForward(ctx context.Context, resolve func(context.Context, string) (*Instance, error), api SSMInstanceInfoAPI, bastionIdentifier string, target *Instance, remotePort, localPort int) (*BastionForward, error) {
+	bastion, err := resolve(ctx, bastionIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve bastion %s: %w", bastionIdentifier, err)
+	}
+	if bastion.InstanceID == target.InstanceID {
+		return nil, fmt.Errorf("bastion and target are the same instance (%s)", target.InstanceID)
+	}
+	if target.PrivateIP == "" {
+		return nil, fmt.Errorf("target %s has no private IP for the bastion to connect to", target.InstanceID)
+	}
+	if err := checkSSMManaged(ctx, api, bastion.InstanceID); err != nil {
+		return nil, err
+	}
+
+	return &BastionForward{
+		Bastion:    bastion,
+		Target:     target,
+		Host:       target.PrivateIP,
+		RemotePort: remotePort,
+		LocalPort:  localPort,
+	}, nil
+}
+
+// checkSSMManaged fails unless every instance is registered with SSM and its
+// agent is online
+func checkSSMManaged(ctx context.Context, api SSMInstanceInfoAPI, instanceIDs ...string) error {
+	status, err := ssmPingStatuses(ctx, api, instanceIDs...)
+	if err != nil {
+		return err
+	}
+
+	var problems []string
+	for _, id := range instanceIDs {
+		ping, ok := status[id]
+		switch {
+		case !ok:
+			problems = append(problems, id+" is not managed by SSM")
+		case ping != types.PingStatusOnline:
+			problems = append(problems, fmt.Sprintf("%s SSM agent is %s", id, ping))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("cannot connect through SSM: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// ssmPingStatuses returns the SSM agent ping status of each instance that is
+// registered with SSM; unregistered instances are absent from the map
+func ssmPingStatuses(ctx context.Context, api SSMInstanceInfoAPI, instanceIDs ...string) (map[string]types.PingStatus, error) {
+	status := make(map[string]types.PingStatus, len(instanceIDs))
+	input := &ssm.DescribeInstanceInformationInput{
+		Filters: []types.InstanceInformationStringFilter{
+			{Key: aws.String("InstanceIds"), Values: instanceIDs},
+		},
+	}
+	for {
+		output, err := api.DescribeInstanceInformation(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check SSM registration: %w", err)
+		}
+		for _, info := range output.InstanceInformationList {
+			status[aws.ToString(info.InstanceId)] = info.PingStatus
+		}
+		if output.NextToken == nil {
+			return status, nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// StartBastionPortForward forwards LocalPort to RemotePort on the target
+// through an SSM session on the bastion. Requires the session-manager-plugin.
+func (c *Client) StartBastionPortForward(ctx context.Context, forward *BastionForward) error {
+	fmt.Printf("Starting port forwarding session via bastion...\n")
+	fmt.Printf("  Bastion:     %s\n", forward.Bastion.InstanceID)
+	fmt.Printf("  Target:      %s (%s)\n", forward.Target.InstanceID, forward.Host)
+	fmt.Printf("  Remote Port: %d\n", forward.RemotePort)
+	fmt.Printf("  Local Port:  %d\n", forward.LocalPort)
+	fmt.Println()
+
+	return c.StartSessionWithOptions(ctx, forward.Bastion.InstanceID, forward.sessionOptions())
+}
@@ -0,0 +1,147 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// MockSSMInstanceInfoAPI is a mock implementation of SSMInstanceInfoAPI
+type MockSSMInstanceInfoAPI struct {
+	DescribeInstanceInformationFunc func(ctx context.Context, params *ssm.DescribeInstanceInformationInput, optFns ...func(*ssm.Options)) (*ssm.DescribeInstanceInformationOutput, error)
+}
+
+func (m *MockSSMInstanceInfoAPI) DescribeInstanceInformation(ctx context.Context, params *ssm.DescribeInstanceInformationInput, optFns ...func(*ssm.Options)) (*ssm.DescribeInstanceInformationOutput, error) {
+	return m.DescribeInstanceInformationFunc(ctx, params, optFns...)
+}
+
+// managedInstances answers DescribeInstanceInformation with the given ping
+// status for each registered instance
+func managedInstances(pings map[string]types.PingStatus, requested *[]string) *MockSSMInstanceInfoAPI {
+	return &MockSSMInstanceInfoAPI{
+		DescribeInstanceInformationFunc: func(_ context.Context, params *ssm.DescribeInstanceInformationInput, _ ...func(*ssm.Options)) (*ssm.DescribeInstanceInformationOutput, error) {
+			output := &ssm.DescribeInstanceInformationOutput{}
+			for _, filter := range params.Filters {
+				if aws.ToString(filter.Key) != "InstanceIds" {
+					continue
+				}
+				*requested = append(*requested, filter.Values...)
+				for _, id := range filter.Values {
+					if ping, ok := pings[id]; ok {
+						output.InstanceInformationList = append(output.InstanceInformationList,
+							types.InstanceInformation{InstanceId: aws.String(id), PingStatus: ping})
+					}
+				}
+			}
+			return output, nil
+		},
+	}
+}
+
+func TestResolveBastionForward(t *testing.T) {
+	bastion := &Instance{InstanceID: "i-bastion", Name: "bastion", PrivateIP: "10.0.0.5"}
+	target := &Instance{InstanceID: "i-target", Name: "app", PrivateIP: "10.0.8.21"}
+
+	var resolved []string
+	resolve := func(_ context.Context, identifier string) (*Instance, error) {
+		resolved = append(resolved, identifier)
+		if identifier != "bastion" {
+			return nil, errors.New("no instance found")
+		}
+		return bastion, nil
+	}
+	var requested []string
+	// The target is not registered with SSM; only the bastion needs the agent
+	api := managedInstances(map[string]types.PingStatus{
+		"i-bastion": types.PingStatusOnline,
+	}, &requested)
+
+	forward, err := resolveBastionForward(context.Background(), resolve, api, "bastion", target, 5432, 15432)
+	if err != nil {
+		t.Fatalf("resolveBastionForward() error = %v", err)
+	}
+	if strings.Join(resolved, ",") != "bastion" {
+		t.Errorf("resolved identifiers = %v, want [bastion]", resolved)
+	}
+	if strings.Join(requested, ",") != "i-bastion" {
+		t.Errorf("SSM registration checked for %v, want only the bastion", requested)
+	}
+	if forward.Bastion != bastion || forward.Target != target || forward.Host != "10.0.8.21" {
+		t.Errorf("forward = %+v, want bastion i-bastion to target 10.0.8.21", forward)
+	}
+
+	input := buildStartSessionInput(forward.Bastion.InstanceID, forward.sessionOptions())
+	if aws.ToString(input.Target) != "i-bastion" {
+		t.Errorf("session target = %q, want the bastion", aws.ToString(input.Target))
+	}
+	if aws.ToString(input.DocumentName) != "AWS-StartPortForwardingSessionToRemoteHost" {
+		t.Errorf("DocumentName = %q", aws.ToString(input.DocumentName))
+	}
+	want := map[string]string{"host": "10.0.8.21", "portNumber": "5432", "localPortNumber": "15432"}
+	for key, value := range want {
+		if got := input.Parameters[key]; len(got) != 1 || got[0] != value {
+			t.Errorf("parameter %s = %v, want [%s]", key, got, value)
+		}
+	}
+}
+
+func TestResolveBastionForwardErrors(t *testing.T) {
+	bastion := &Instance{InstanceID: "i-bastion", PrivateIP: "10.0.0.5"}
+	resolveBastion := func(context.Context, string) (*Instance, error) { return bastion, nil }
+
+	tests := []struct {
+		name    string
+		resolve func(context.Context, string) (*Instance, error)
+		target  *Instance
+		pings   map[string]types.PingStatus
+		want    string
+	}{
+		{
+			name:    "bastion not found",
+			resolve: func(context.Context, string) (*Instance, error) { return nil, errors.New("no instance found") },
+			target:  &Instance{InstanceID: "i-target", PrivateIP: "10.0.8.21"},
+			want:    "failed to resolve bastion",
+		},
+		{
+			name:    "same instance",
+			resolve: resolveBastion,
+			target:  bastion,
+			want:    "same instance",
+		},
+		{
+			name:    "target without private IP",
+			resolve: resolveBastion,
+			target:  &Instance{InstanceID: "i-target"},
+			want:    "no private IP",
+		},
+		{
+			name:    "bastion not managed",
+			resolve: resolveBastion,
+			target:  &Instance{InstanceID: "i-target", PrivateIP: "10.0.8.21"},
+			pings:   map[string]types.PingStatus{"i-target": types.PingStatusOnline},
+			want:    "i-bastion is not managed by SSM",
+		},
+		{
+			name:    "bastion agent offline",
+			resolve: resolveBastion,
+			target:  &Instance{InstanceID: "i-target", PrivateIP: "10.0.8.21"},
+			pings:   map[string]types.PingStatus{"i-bastion": types.PingStatusConnectionLost},
+			want:    "i-bastion SSM agent is ConnectionLost",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requested []string
+			_, err := resolveBastionForward(context.Background(), tt.resolve, managedInstances(tt.pings, &requested), "bastion", tt.target, 80, 8080)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("resolveBastionForward() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -3,15 +3,22 @@ package aws
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/eks"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/johnlam90/aws-ssm/pkg/cache"
 	appconfig "github.com/johnlam90/aws-ssm/pkg/config"
+	"github.com/johnlam90/aws-ssm/pkg/security"
+	"github.com/johnlam90/aws-ssm/pkg/validation"
 )
 
 // Ensure Client implements the fuzzy.AWSClientInterface interface
@@ -23,8 +30,11 @@ type Client struct {
 	SSMClient      *ssm.Client
 	EKSClient      *eks.Client
 	Config         aws.Config
-	AppConfig      *appconfig.Config // Cached application config for performance
-	CircuitBreaker *CircuitBreaker   // Circuit breaker for AWS API calls
+	AppConfig      *appconfig.Config          // Cached application config for performance
+	CircuitBreaker *CircuitBreaker            // Circuit breaker for AWS API calls
+	HTTPOptions    security.HTTPClientOptions // Transport settings used for AWS API calls
+	IdleWarning    IdleWarningConfig          // Idle warning for native shell sessions; zero disables
+	DescribeCache  *cache.Service             // Caches instance descriptions by ID set; nil disables
 
 	// Test hook: if set, overrides instance description logic used by FindInstances
 	describeInstancesHook func(ctx context.Context, filters []types.Filter) ([]Instance, error)
@@ -67,16 +77,119 @@ func (c *Client) GetProfile() string {
 	return ""
 }
 
-// NewClient creates a new AWS client with EC2 and SSM services
-func NewClient(ctx context.Context, region, profile, configPath string) (*Client, error) {
-	var opts []func(*config.LoadOptions) error
+// HTTPSettings holds command-line overrides for the AWS SDK HTTP transport.
+// Zero values fall back to the config file and then to built-in defaults.
+type HTTPSettings struct {
+	ConnectTimeout time.Duration
+	RequestTimeout time.Duration
+	KeepAlive      time.Duration
+}
+
+// httpOverrides holds process-wide transport overrides set from CLI flags
+var httpOverrides HTTPSettings
 
-	// Set region if provided
+// SetHTTPSettings sets the transport overrides applied to clients created afterwards
+func SetHTTPSettings(settings HTTPSettings) {
+	httpOverrides = settings
+}
+
+// resolveHTTPOptions merges CLI overrides, config file values, and defaults
+func resolveHTTPOptions(appCfg *appconfig.Config, overrides HTTPSettings) security.HTTPClientOptions {
+	opts := security.DefaultHTTPClientOptions()
+
+	if appCfg != nil {
+		if appCfg.Network.ConnectTimeout > 0 {
+			opts.ConnectTimeout = time.Duration(appCfg.Network.ConnectTimeout) * time.Second
+		}
+		if appCfg.Network.RequestTimeout > 0 {
+			opts.RequestTimeout = time.Duration(appCfg.Network.RequestTimeout) * time.Second
+		}
+		if appCfg.Network.KeepAlive > 0 {
+			opts.KeepAlive = time.Duration(appCfg.Network.KeepAlive) * time.Second
+		}
+	}
+
+	if overrides.ConnectTimeout > 0 {
+		opts.ConnectTimeout = overrides.ConnectTimeout
+	}
+	if overrides.RequestTimeout > 0 {
+		opts.RequestTimeout = overrides.RequestTimeout
+	}
+	if overrides.KeepAlive > 0 {
+		opts.KeepAlive = overrides.KeepAlive
+	}
+
+	return opts
+}
+
+// newHTTPClient builds the SDK transport with the resolved timeouts. It stays a
+// BuildableClient so the SDK can still layer AWS_CA_BUNDLE or a profile
+// ca_bundle on top, and keeps the SDK's HTTP/2 and connection pool defaults.
+func newHTTPClient(opts security.HTTPClientOptions) *awshttp.BuildableClient {
+	return awshttp.NewBuildableClient().
+		WithDialerOptions(func(d *net.Dialer) {
+			d.Timeout = opts.ConnectTimeout
+			d.KeepAlive = opts.KeepAlive
+		}).
+		WithTransportOptions(func(tr *http.Transport) {
+			tr.TLSHandshakeTimeout = opts.ConnectTimeout
+			tr.IdleConnTimeout = opts.IdleConnTimeout
+		}).
+		WithTimeout(opts.RequestTimeout)
+}
+
+// resolveRegionSetting returns the region from the flag, AWS_REGION or the app
+// config, in that order, along with where it came from
+func resolveRegionSetting(region string, appCfg *appconfig.Config) (string, string) {
 	switch {
 	case region != "":
-		opts = append(opts, config.WithRegion(region))
+		return region, "region"
 	case os.Getenv("AWS_REGION") != "":
-		opts = append(opts, config.WithRegion(os.Getenv("AWS_REGION")))
+		return os.Getenv("AWS_REGION"), "AWS_REGION"
+	case appCfg.Default.Region != "":
+		return appCfg.Default.Region, "config default.region"
+	default:
+		return "", ""
+	}
+}
+
+// NewClient creates a new AWS client with EC2 and SSM services
+func NewClient(ctx context.Context, region, profile, configPath string) (*Client, error) {
+	return newClient(ctx, region, profile, configPath, true)
+}
+
+// NewUncheckedClient creates a client like NewClient, but without resolving
+// credentials or assuming the role chain up front. Missing or invalid
+// credentials then surface from the first API call, for callers such as
+// status that report them instead of failing.
+func NewUncheckedClient(ctx context.Context, region, profile, configPath string) (*Client, error) {
+	return newClient(ctx, region, profile, configPath, false)
+}
+
+// newClient creates a client, resolving credentials and assuming the role
+// chain before returning when precheck is set
+func newClient(ctx context.Context, region, profile, configPath string, precheck bool) (*Client, error) {
+	// Load application config once for performance (cached in client)
+	appCfg, err := appconfig.LoadConfig(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load application config: %w", err)
+	}
+
+	httpOptions := resolveHTTPOptions(appCfg, httpOverrides)
+
+	var opts []func(*config.LoadOptions) error
+	// Only replace the SDK's default transport when a timeout was overridden
+	if httpOptions != security.DefaultHTTPClientOptions() {
+		opts = append(opts, config.WithHTTPClient(newHTTPClient(httpOptions)))
+	}
+
+	// Set region if provided, normalized so typos fail before reaching the SDK
+	if value, source := resolveRegionSetting(region, appCfg); value != "" {
+		normalized, err := validation.NormalizeRegion(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+		opts = append(opts, config.WithRegion(normalized))
 	}
 
 	// Set profile if provided
@@ -85,17 +198,34 @@ func NewClient(ctx context.Context, region, profile, configPath string) (*Client
 		opts = append(opts, config.WithSharedConfigProfile(profile))
 	case os.Getenv("AWS_PROFILE") != "":
 		opts = append(opts, config.WithSharedConfigProfile(os.Getenv("AWS_PROFILE")))
+	case appCfg.Default.Profile != "":
+		opts = append(opts, config.WithSharedConfigProfile(appCfg.Default.Profile))
 	}
 
 	cfg, err := config.LoadDefaultConfig(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load AWS SDK config: %w", err)
 	}
+	if precheck {
+		if err := checkCredentials(ctx, cfg.Credentials); err != nil {
+			return nil, err
+		}
+		if len(roleChain) > 0 {
+			if cfg, err = assumeRoleChain(ctx, cfg, roleChain, newSTSClient); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		cfg = deferredRoleChain(cfg, roleChain, newSTSClient)
+	}
 
-	// Load application config once for performance (cached in client)
-	appCfg, err := appconfig.LoadConfig(configPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load application config: %w", err)
+	var describeCache *cache.Service
+	if appCfg.Cache.Enabled {
+		if describeCache, err = cache.NewCacheServiceFromConfig(appCfg); err != nil {
+			// Caching is optional; continue with uncached descriptions
+			fmt.Fprintf(os.Stderr, "Warning: failed to initialize describe cache: %v\n", err)
+			describeCache = nil
+		}
 	}
 
 	return &Client{
@@ -105,6 +235,8 @@ func NewClient(ctx context.Context, region, profile, configPath string) (*Client
 		Config:                cfg,
 		AppConfig:             appCfg,
 		CircuitBreaker:        NewCircuitBreaker(DefaultCircuitBreakerConfig()),
+		HTTPOptions:           httpOptions,
+		DescribeCache:         describeCache,
 		describeInstancesHook: nil,
 		// Interactive UI flags
 		InteractiveMode: false,
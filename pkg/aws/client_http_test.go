@@ -0,0 +1,175 @@
+package aws
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	appconfig "github.com/johnlam90/aws-ssm/pkg/config"
+)
+
+func TestResolveHTTPOptions(t *testing.T) {
+	appCfg := &appconfig.Config{}
+	appCfg.Network.ConnectTimeout = 5
+	appCfg.Network.RequestTimeout = 45
+	appCfg.Network.KeepAlive = 10
+
+	tests := []struct {
+		name        string
+		appCfg      *appconfig.Config
+		overrides   HTTPSettings
+		wantConnect time.Duration
+		wantRequest time.Duration
+		wantKeep    time.Duration
+	}{
+		{"defaults", nil, HTTPSettings{}, 30 * time.Second, 0, 30 * time.Second},
+		{"config file", appCfg, HTTPSettings{}, 5 * time.Second, 45 * time.Second, 10 * time.Second},
+		{"flags override config", appCfg, HTTPSettings{ConnectTimeout: 2 * time.Second, KeepAlive: 3 * time.Second}, 2 * time.Second, 45 * time.Second, 3 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := resolveHTTPOptions(tt.appCfg, tt.overrides)
+			if opts.ConnectTimeout != tt.wantConnect {
+				t.Errorf("ConnectTimeout = %v, want %v", opts.ConnectTimeout, tt.wantConnect)
+			}
+			if opts.RequestTimeout != tt.wantRequest {
+				t.Errorf("RequestTimeout = %v, want %v", opts.RequestTimeout, tt.wantRequest)
+			}
+			if opts.KeepAlive != tt.wantKeep {
+				t.Errorf("KeepAlive = %v, want %v", opts.KeepAlive, tt.wantKeep)
+			}
+		})
+	}
+}
+
+func TestNewClientAppliesHTTPSettings(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+
+	SetHTTPSettings(HTTPSettings{ConnectTimeout: 3 * time.Second, RequestTimeout: 20 * time.Second, KeepAlive: 7 * time.Second})
+	defer SetHTTPSettings(HTTPSettings{})
+
+	client, err := NewClient(context.Background(), "", "", "")
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	if client.HTTPOptions.ConnectTimeout != 3*time.Second || client.HTTPOptions.KeepAlive != 7*time.Second {
+		t.Errorf("unexpected HTTP options: %+v", client.HTTPOptions)
+	}
+
+	httpClient, ok := client.Config.HTTPClient.(*awshttp.BuildableClient)
+	if !ok {
+		t.Fatalf("expected *awshttp.BuildableClient, got %T", client.Config.HTTPClient)
+	}
+	if httpClient.GetTimeout() != 20*time.Second {
+		t.Errorf("client timeout = %v, want 20s", httpClient.GetTimeout())
+	}
+	if got := httpClient.GetTransport().TLSHandshakeTimeout; got != 3*time.Second {
+		t.Errorf("TLS handshake timeout = %v, want 3s", got)
+	}
+	if got := httpClient.GetDialer().KeepAlive; got != 7*time.Second {
+		t.Errorf("dialer keepalive = %v, want 7s", got)
+	}
+	if !httpClient.GetTransport().ForceAttemptHTTP2 {
+		t.Error("expected the SDK transport defaults, including HTTP/2, to be kept")
+	}
+}
+
+func TestNewClientAcceptsCABundle(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv("AWS_CA_BUNDLE", writeTestCABundle(t, home))
+
+	tests := []struct {
+		name      string
+		overrides HTTPSettings
+	}{
+		{"defaults", HTTPSettings{}},
+		{"timeout overrides", HTTPSettings{ConnectTimeout: 3 * time.Second, RequestTimeout: 20 * time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetHTTPSettings(tt.overrides)
+			defer SetHTTPSettings(HTTPSettings{})
+
+			client, err := NewClient(context.Background(), "", "", "")
+			if err != nil {
+				t.Fatalf("NewClient with AWS_CA_BUNDLE failed: %v", err)
+			}
+			httpClient, ok := client.Config.HTTPClient.(*awshttp.BuildableClient)
+			if !ok {
+				t.Fatalf("expected *awshttp.BuildableClient, got %T", client.Config.HTTPClient)
+			}
+			tlsConfig := httpClient.GetTransport().TLSClientConfig
+			if tlsConfig == nil || tlsConfig.RootCAs == nil {
+				t.Error("expected the CA bundle to be applied to the transport")
+			}
+		})
+	}
+}
+
+// writeTestCABundle writes a self-signed CA certificate to dir and returns its path
+func writeTestCABundle(t *testing.T, dir string) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "aws-ssm test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNewClientNormalizesRegion(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+
+	t.Setenv("AWS_REGION", "EU-WEST-1")
+	client, err := NewClient(context.Background(), "", "", "")
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	if got := client.GetRegion(); got != "eu-west-1" {
+		t.Errorf("region = %q, want eu-west-1", got)
+	}
+
+	_, err = NewClient(context.Background(), "us-east1", "", "")
+	if err == nil || !strings.Contains(err.Error(), `did you mean "us-east-1"`) {
+		t.Errorf("NewClient(us-east1) error = %v, want a did-you-mean suggestion", err)
+	}
+}
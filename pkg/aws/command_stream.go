@@ -0,0 +1,183 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// DefaultStreamMaxBytes is the default cap on bytes written by ExecuteCommandStream
+const DefaultStreamMaxBytes int64 = 10 * 1024 * 1024
+
+// InvocationOutputLimit is how many characters of standard output
+// GetCommandInvocation returns; SSM drops any output past it
+const InvocationOutputLimit = 24000
+
+// ErrOutputLimitExceeded is returned when streamed output reaches the byte cap
+var ErrOutputLimitExceeded = errors.New("command output exceeded byte limit")
+
+// ErrOutputTruncated is returned when the output reaches InvocationOutputLimit,
+// so the rest of it cannot be streamed
+var ErrOutputTruncated = errors.New("command output reached the SSM output limit and was truncated")
+
+// commandStreamPollInterval is the delay between invocation polls while streaming
+var commandStreamPollInterval = time.Second
+
+// CommandInvocationAPI defines the interface for polling command invocations
+type CommandInvocationAPI interface {
+	GetCommandInvocation(ctx context.Context, params *ssm.GetCommandInvocationInput, optFns ...func(*ssm.Options)) (*ssm.GetCommandInvocationOutput, error)
+}
+
+// ExecuteCommandStream executes a command and writes its output to out as it
+// arrives instead of buffering it, stopping at DefaultStreamMaxBytes. Output is
+// polled with GetCommandInvocation, so at most InvocationOutputLimit characters
+// are available; longer output fails with ErrOutputTruncated.
+func (c *Client) ExecuteCommandStream(ctx context.Context, instanceID, command string, out io.Writer) error {
+	return c.ExecuteCommandStreamWithLimit(ctx, instanceID, command, out, DefaultStreamMaxBytes)
+}
+
+// ExecuteCommandStreamWithLimit streams command output, failing with
+// ErrOutputLimitExceeded once maxBytes have been written. A non-positive
+// maxBytes uses DefaultStreamMaxBytes.
+func (c *Client) ExecuteCommandStreamWithLimit(ctx context.Context, instanceID, command string, out io.Writer, maxBytes int64) error {
+	ctx, cancel := context.WithTimeout(ctx, MaxCommandTimeout)
+	defer cancel()
+
+	if err := c.CircuitBreaker.Allow(); err != nil {
+		return fmt.Errorf("circuit breaker open: %w", err)
+	}
+
+	commandID, err := c.sendCommand(ctx, instanceID, command)
+	if err != nil {
+		return err
+	}
+
+	return streamCommandOutput(ctx, c.SSMClient, c.CircuitBreaker, instanceID, commandID, out, maxBytes)
+}
+
+// commandStream tracks how much of an invocation's output has been forwarded.
+// Only offsets are kept, so memory does not grow with the output size.
+type commandStream struct {
+	out      io.Writer
+	maxBytes int64
+	written  int64 // bytes written to out
+	stdout   int   // bytes of StandardOutputContent already forwarded
+}
+
+// write forwards chunk to out, truncating it at the byte cap
+func (s *commandStream) write(chunk string) error {
+	if chunk == "" {
+		return nil
+	}
+	remaining := s.maxBytes - s.written
+	truncated := int64(len(chunk)) > remaining
+	if truncated {
+		chunk = chunk[:remaining]
+	}
+	n, err := io.WriteString(s.out, chunk)
+	s.written += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write command output: %w", err)
+	}
+	if truncated {
+		return fmt.Errorf("%w (%d bytes)", ErrOutputLimitExceeded, s.maxBytes)
+	}
+	return nil
+}
+
+// forwardStdout writes any stdout that arrived since the previous poll,
+// failing once the output reaches InvocationOutputLimit
+func (s *commandStream) forwardStdout(content string) error {
+	if len(content) <= s.stdout {
+		return nil
+	}
+	chunk := content[s.stdout:]
+	s.stdout = len(content)
+	if err := s.write(chunk); err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(content) >= InvocationOutputLimit {
+		return fmt.Errorf("%w (%d characters)", ErrOutputTruncated, InvocationOutputLimit)
+	}
+	return nil
+}
+
+// streamCommandOutput polls an invocation and forwards new output until it finishes
+func streamCommandOutput(ctx context.Context, api CommandInvocationAPI, cb *CircuitBreaker, instanceID, commandID string, out io.Writer, maxBytes int64) error {
+	if maxBytes <= 0 {
+		maxBytes = DefaultStreamMaxBytes
+	}
+	stream := &commandStream{out: out, maxBytes: maxBytes}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("command execution cancelled or timed out: %w", ctx.Err())
+		default:
+		}
+
+		if err := cb.Allow(); err != nil {
+			return fmt.Errorf("circuit breaker open: %w", err)
+		}
+
+		invocation, err := api.GetCommandInvocation(ctx, &ssm.GetCommandInvocationInput{
+			CommandId:  aws.String(commandID),
+			InstanceId: aws.String(instanceID),
+		})
+		if err != nil {
+			// The invocation may not be registered yet; retry until the context ends
+			cb.RecordFailure()
+		} else {
+			cb.RecordSuccess()
+			if err := stream.forwardStdout(aws.ToString(invocation.StandardOutputContent)); err != nil {
+				return err
+			}
+			done, err := finishCommandStream(stream, invocation)
+			if done {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("command execution cancelled or timed out: %w", ctx.Err())
+		case <-time.After(commandStreamPollInterval):
+		}
+	}
+}
+
+// finishCommandStream reports whether the invocation reached a terminal status,
+// forwarding stderr and returning the command's error when it did
+func finishCommandStream(stream *commandStream, invocation *ssm.GetCommandInvocationOutput) (bool, error) {
+	stderr := aws.ToString(invocation.StandardErrorContent)
+
+	switch invocation.Status {
+	case types.CommandInvocationStatusPending, types.CommandInvocationStatusInProgress,
+		types.CommandInvocationStatusDelayed, types.CommandInvocationStatusCancelling:
+		return false, nil
+	case types.CommandInvocationStatusSuccess:
+		if stderr == "" {
+			return true, nil
+		}
+		header := "[STDERR]\n"
+		if stream.stdout > 0 {
+			header = "\n" + header
+		}
+		return true, stream.write(header + stderr)
+	case types.CommandInvocationStatusFailed:
+		return true, fmt.Errorf("command failed: %s", stderr)
+	case types.CommandInvocationStatusCancelled:
+		return true, fmt.Errorf("command was cancelled")
+	case types.CommandInvocationStatusTimedOut:
+		return true, fmt.Errorf("command timed out")
+	default:
+		return true, fmt.Errorf("unknown command status: %s", invocation.Status)
+	}
+}
@@ -0,0 +1,142 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// mockChunkedInvocationAPI returns cumulative output that grows one chunk per poll
+type mockChunkedInvocationAPI struct {
+	chunks []string
+	stderr string
+	status types.CommandInvocationStatus
+	polls  int
+}
+
+func (m *mockChunkedInvocationAPI) GetCommandInvocation(_ context.Context, _ *ssm.GetCommandInvocationInput, _ ...func(*ssm.Options)) (*ssm.GetCommandInvocationOutput, error) {
+	m.polls++
+	n := m.polls
+	status := types.CommandInvocationStatusInProgress
+	if n >= len(m.chunks) {
+		n = len(m.chunks)
+		status = m.status
+	}
+	return &ssm.GetCommandInvocationOutput{
+		Status:                status,
+		StandardOutputContent: aws.String(strings.Join(m.chunks[:n], "")),
+		StandardErrorContent:  aws.String(m.stderr),
+	}, nil
+}
+
+// recordingWriter records each write separately
+type recordingWriter struct {
+	writes []string
+}
+
+func (w *recordingWriter) Write(p []byte) (int, error) {
+	w.writes = append(w.writes, string(p))
+	return len(p), nil
+}
+
+func TestStreamCommandOutput(t *testing.T) {
+	origInterval := commandStreamPollInterval
+	commandStreamPollInterval = 0
+	defer func() { commandStreamPollInterval = origInterval }()
+
+	tests := []struct {
+		name       string
+		api        *mockChunkedInvocationAPI
+		maxBytes   int64
+		wantWrites []string
+		wantErr    error
+		wantErrMsg string
+	}{
+		{
+			name:       "chunks are forwarded incrementally",
+			api:        &mockChunkedInvocationAPI{chunks: []string{"line1\n", "line2\n", "line3\n"}, status: types.CommandInvocationStatusSuccess},
+			maxBytes:   1024,
+			wantWrites: []string{"line1\n", "line2\n", "line3\n"},
+		},
+		{
+			name:       "stderr is appended on success",
+			api:        &mockChunkedInvocationAPI{chunks: []string{"out\n"}, stderr: "warn", status: types.CommandInvocationStatusSuccess},
+			maxBytes:   1024,
+			wantWrites: []string{"out\n", "\n[STDERR]\nwarn"},
+		},
+		{
+			name:       "byte cap truncates and fails",
+			api:        &mockChunkedInvocationAPI{chunks: []string{"aaaa", "bbbb", "cccc"}, status: types.CommandInvocationStatusSuccess},
+			maxBytes:   6,
+			wantWrites: []string{"aaaa", "bb"},
+			wantErr:    ErrOutputLimitExceeded,
+		},
+		{
+			name:       "output at the SSM limit fails",
+			api:        &mockChunkedInvocationAPI{chunks: []string{strings.Repeat("a", InvocationOutputLimit-10), strings.Repeat("b", 10)}, status: types.CommandInvocationStatusSuccess},
+			maxBytes:   DefaultStreamMaxBytes,
+			wantWrites: []string{strings.Repeat("a", InvocationOutputLimit-10), strings.Repeat("b", 10)},
+			wantErr:    ErrOutputTruncated,
+		},
+		{
+			name:       "failed command keeps streamed output",
+			api:        &mockChunkedInvocationAPI{chunks: []string{"partial"}, stderr: "boom", status: types.CommandInvocationStatusFailed},
+			maxBytes:   1024,
+			wantWrites: []string{"partial"},
+			wantErrMsg: "command failed: boom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &recordingWriter{}
+			cb := NewCircuitBreaker(DefaultCircuitBreakerConfig())
+			err := streamCommandOutput(context.Background(), tt.api, cb, "i-123", "cmd-1", out, tt.maxBytes)
+
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+			case tt.wantErrMsg != "":
+				if err == nil || err.Error() != tt.wantErrMsg {
+					t.Fatalf("error = %v, want %q", err, tt.wantErrMsg)
+				}
+			case err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if strings.Join(out.writes, "|") != strings.Join(tt.wantWrites, "|") {
+				t.Errorf("writes = %q, want %q", out.writes, tt.wantWrites)
+			}
+			var total int64
+			for _, w := range out.writes {
+				total += int64(len(w))
+			}
+			if total > tt.maxBytes {
+				t.Errorf("wrote %d bytes, cap is %d", total, tt.maxBytes)
+			}
+		})
+	}
+}
+
+func TestStreamCommandOutputCancelled(t *testing.T) {
+	origInterval := commandStreamPollInterval
+	commandStreamPollInterval = 0
+	defer func() { commandStreamPollInterval = origInterval }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	api := &mockChunkedInvocationAPI{chunks: []string{"a", "b", "c"}, status: types.CommandInvocationStatusSuccess}
+	cb := NewCircuitBreaker(DefaultCircuitBreakerConfig())
+	err := streamCommandOutput(ctx, api, cb, "i-123", "cmd-1", &recordingWriter{}, 1024)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("error = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,81 @@
+package aws
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/johnlam90/aws-ssm/pkg/cache"
+)
+
+// describeCacheKey returns the cache key for a set of instance IDs. The IDs are
+// sorted and hashed so the same set maps to the same key regardless of order.
+func describeCacheKey(region string, instanceIDs []string) string {
+	sorted := append([]string(nil), instanceIDs...)
+	sort.Strings(sorted)
+	sum := sha256.Sum256([]byte(strings.Join(sorted, ",")))
+	return cache.GenerateCacheKey(region, "describe_ids_"+hex.EncodeToString(sum[:16]))
+}
+
+// describeRunningInstancesByID describes the running instances among
+// instanceIDs in batches. When DescribeCache is set, results for the same ID
+// set are served from cache within the EC2 TTL.
+func (c *Client) describeRunningInstancesByID(ctx context.Context, instanceIDs []string) ([]Instance, error) {
+	if len(instanceIDs) == 0 {
+		return nil, nil
+	}
+
+	key := describeCacheKey(c.GetRegion(), instanceIDs)
+	if c.DescribeCache != nil {
+		if cached, ok := c.DescribeCache.Get(key); ok {
+			if instances, ok := decodeCachedInstances(cached); ok {
+				return instances, nil
+			}
+		}
+	}
+
+	var instances []Instance
+	for start := 0; start < len(instanceIDs); start += asgMemberBatchSize {
+		end := min(start+asgMemberBatchSize, len(instanceIDs))
+		filters := []ec2types.Filter{
+			{Name: aws.String("instance-id"), Values: instanceIDs[start:end]},
+		}
+		batch, err := c.describeInstances(ctx, appendStateFilter(filters, []string{"running"}))
+		if err != nil {
+			return nil, err
+		}
+		instances = append(instances, batch...)
+	}
+
+	if c.DescribeCache != nil {
+		query := fmt.Sprintf("describe %d instance(s)", len(instanceIDs))
+		if err := c.DescribeCache.SetWithResourceType(key, instances, cache.ResourceEC2, c.GetRegion(), query); err != nil {
+			// Caching is optional; the fresh result is still usable
+			fmt.Fprintf(os.Stderr, "Warning: failed to cache instance descriptions: %v\n", err)
+		}
+	}
+	return instances, nil
+}
+
+// decodeCachedInstances converts a cache entry read back from disk into instances
+func decodeCachedInstances(data interface{}) ([]Instance, bool) {
+	if instances, ok := data.([]Instance); ok {
+		return instances, true
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, false
+	}
+	var instances []Instance
+	if err := json.Unmarshal(raw, &instances); err != nil {
+		return nil, false
+	}
+	return instances, true
+}
@@ -0,0 +1,109 @@
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/johnlam90/aws-ssm/pkg/cache"
+)
+
+// newDescribeCacheClient returns a client with a temporary describe cache and
+// a describe hook that counts calls
+func newDescribeCacheClient(t *testing.T, calls *int) (*Client, *cache.Service) {
+	t.Helper()
+	svc, err := cache.NewCacheService(t.TempDir(), 5)
+	if err != nil {
+		t.Fatalf("NewCacheService() error = %v", err)
+	}
+	c := &Client{DescribeCache: svc}
+	c.Config.Region = "us-east-1"
+	c.describeInstancesHook = func(_ context.Context, f []types.Filter) ([]Instance, error) {
+		*calls++
+		var out []Instance
+		for _, id := range findFilterByName(t, f, "instance-id").Values {
+			out = append(out, Instance{InstanceID: id, State: "running"})
+		}
+		return out, nil
+	}
+	return c, svc
+}
+
+func TestDescribeCacheKeyIgnoresOrder(t *testing.T) {
+	a := describeCacheKey("us-east-1", []string{"i-2", "i-1", "i-3"})
+	b := describeCacheKey("us-east-1", []string{"i-3", "i-2", "i-1"})
+	if a != b {
+		t.Errorf("keys differ for the same ID set: %q vs %q", a, b)
+	}
+	if a == describeCacheKey("us-east-1", []string{"i-1", "i-2"}) {
+		t.Error("different ID sets share a key")
+	}
+	if a == describeCacheKey("us-west-2", []string{"i-1", "i-2", "i-3"}) {
+		t.Error("different regions share a key")
+	}
+}
+
+func TestDescribeRunningInstancesByIDHitsCache(t *testing.T) {
+	var calls int
+	c, _ := newDescribeCacheClient(t, &calls)
+	ctx := context.Background()
+
+	first, err := c.describeRunningInstancesByID(ctx, []string{"i-1", "i-2"})
+	if err != nil {
+		t.Fatalf("first describe error = %v", err)
+	}
+	second, err := c.describeRunningInstancesByID(ctx, []string{"i-2", "i-1"})
+	if err != nil {
+		t.Fatalf("second describe error = %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("DescribeInstances called %d times, want 1 with the second resolution served from cache", calls)
+	}
+	if len(second) != len(first) || second[0].InstanceID != "i-1" || second[0].State != "running" {
+		t.Errorf("cached instances = %+v, want %+v", second, first)
+	}
+}
+
+func TestDescribeRunningInstancesByIDInvalidatedByTagMutation(t *testing.T) {
+	var calls int
+	c, svc := newDescribeCacheClient(t, &calls)
+	ctx := context.Background()
+
+	if _, err := c.describeRunningInstancesByID(ctx, []string{"i-1", "i-2"}); err != nil {
+		t.Fatalf("describe error = %v", err)
+	}
+
+	// Tag one member the way `ec2 tag-bulk` does, then invalidate it
+	api := &MockEC2TaggingAPI{tagged: make(map[string]map[string]string)}
+	results := tagInstances(ctx, api, []string{"i-2"}, map[string]string{"Env": "prod"}, 1)
+	if results[0].Err != nil {
+		t.Fatalf("tagInstances() error = %v", results[0].Err)
+	}
+	if _, err := svc.InvalidateInstances("us-east-1", []string{"i-2"}); err != nil {
+		t.Fatalf("InvalidateInstances() error = %v", err)
+	}
+
+	if _, err := c.describeRunningInstancesByID(ctx, []string{"i-1", "i-2"}); err != nil {
+		t.Fatalf("describe error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("DescribeInstances called %d times, want 2 after the tag mutation invalidated the cache", calls)
+	}
+}
+
+func TestDescribeRunningInstancesByIDWithoutCache(t *testing.T) {
+	var calls int
+	c, _ := newDescribeCacheClient(t, &calls)
+	c.DescribeCache = nil
+	ctx := context.Background()
+
+	for range 2 {
+		if _, err := c.describeRunningInstancesByID(ctx, []string{"i-1"}); err != nil {
+			t.Fatalf("describe error = %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("DescribeInstances called %d times, want 2 without a cache", calls)
+	}
+}
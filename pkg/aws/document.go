@@ -0,0 +1,48 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// SSMDocumentAPI defines the interface for SSM document lookups
+type SSMDocumentAPI interface {
+	GetDocument(ctx context.Context, params *ssm.GetDocumentInput, optFns ...func(*ssm.Options)) (*ssm.GetDocumentOutput, error)
+}
+
+// ValidateSessionDocument checks that the named SSM document exists and is a
+// Session-type document usable with StartSession
+func (c *Client) ValidateSessionDocument(ctx context.Context, name string) error {
+	var api SSMDocumentAPI
+	if c.SSMClient != nil {
+		api = c.SSMClient
+	} else {
+		api = ssm.NewFromConfig(c.Config)
+	}
+	return validateSessionDocument(ctx, api, name)
+}
+
+func validateSessionDocument(ctx context.Context, api SSMDocumentAPI, name string) error {
+	if name == "" {
+		return fmt.Errorf("document name cannot be empty")
+	}
+
+	output, err := api.GetDocument(ctx, &ssm.GetDocumentInput{Name: aws.String(name)})
+	if err != nil {
+		var notFound *types.InvalidDocument
+		if errors.As(err, &notFound) {
+			return fmt.Errorf("SSM document %q not found: %w", name, err)
+		}
+		return fmt.Errorf("failed to get SSM document %q: %w", name, err)
+	}
+
+	if output.DocumentType != types.DocumentTypeSession {
+		return fmt.Errorf("SSM document %q has type %q, expected %q", name, output.DocumentType, types.DocumentTypeSession)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// MockSSMDocumentAPI is a mock implementation of SSMDocumentAPI
+type MockSSMDocumentAPI struct {
+	GetDocumentFunc func(ctx context.Context, params *ssm.GetDocumentInput, optFns ...func(*ssm.Options)) (*ssm.GetDocumentOutput, error)
+}
+
+func (m *MockSSMDocumentAPI) GetDocument(ctx context.Context, params *ssm.GetDocumentInput, optFns ...func(*ssm.Options)) (*ssm.GetDocumentOutput, error) {
+	return m.GetDocumentFunc(ctx, params, optFns...)
+}
+
+func TestValidateSessionDocument(t *testing.T) {
+	tests := []struct {
+		name    string
+		docName string
+		docType types.DocumentType
+		apiErr  error
+		wantErr bool
+	}{
+		{name: "session document", docName: "Org-RestrictedShell", docType: types.DocumentTypeSession},
+		{name: "command document", docName: "AWS-RunShellScript", docType: types.DocumentTypeCommand, wantErr: true},
+		{name: "automation document", docName: "AWS-RestartEC2Instance", docType: types.DocumentTypeAutomation, wantErr: true},
+		{name: "missing document", docName: "Nope", apiErr: &types.InvalidDocument{Message: aws.String("not found")}, wantErr: true},
+		{name: "api failure", docName: "Org-RestrictedShell", apiErr: errors.New("throttled"), wantErr: true},
+		{name: "empty name", docName: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requested string
+			api := &MockSSMDocumentAPI{
+				GetDocumentFunc: func(_ context.Context, params *ssm.GetDocumentInput, _ ...func(*ssm.Options)) (*ssm.GetDocumentOutput, error) {
+					requested = aws.ToString(params.Name)
+					if tt.apiErr != nil {
+						return nil, tt.apiErr
+					}
+					return &ssm.GetDocumentOutput{Name: params.Name, DocumentType: tt.docType}, nil
+				},
+			}
+
+			err := validateSessionDocument(context.Background(), api, tt.docName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateSessionDocument() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.docName != "" && requested != tt.docName {
+				t.Errorf("GetDocument name = %q, want %q", requested, tt.docName)
+			}
+		})
+	}
+}
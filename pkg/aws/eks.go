@@ -51,6 +51,9 @@ type NodeGroup struct {
 	NodeGroupARN   string
 	Status         string
 	Version        string
+	ReleaseVersion string
+	AMIType        string
+	CapacityType   string // ON_DEMAND, SPOT or CAPACITY_BLOCK
 	InstanceTypes  []string
 	DiskSize       int32
 	DesiredSize    int32
@@ -101,11 +104,21 @@ func (ng *NodeGroup) GetVersion() string {
 	return ng.Version
 }
 
+// GetReleaseVersion returns the node group AMI release version
+func (ng *NodeGroup) GetReleaseVersion() string {
+	return ng.ReleaseVersion
+}
+
 // GetInstanceTypes returns the node group instance types
 func (ng *NodeGroup) GetInstanceTypes() []string {
 	return ng.InstanceTypes
 }
 
+// GetCapacityType returns the node group capacity type
+func (ng *NodeGroup) GetCapacityType() string {
+	return ng.CapacityType
+}
+
 // GetDesiredSize returns the node group desired size
 func (ng *NodeGroup) GetDesiredSize() int32 {
 	return ng.DesiredSize
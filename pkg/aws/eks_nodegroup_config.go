@@ -0,0 +1,299 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+)
+
+var (
+	k8sNamePattern   = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+	k8sPrefixPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+)
+
+// NodeGroupConfigChange lists label and taint edits for a node group
+type NodeGroupConfigChange struct {
+	AddLabels    map[string]string
+	RemoveLabels []string
+	AddTaints    []Taint
+	// RemoveTaints holds taint keys; every effect set for a key is removed
+	RemoveTaints []string
+}
+
+// NodeGroupConfigPlan is a change resolved against a node group's current labels
+// and taints: the desired result plus the payload sent to UpdateNodegroupConfig
+type NodeGroupConfigPlan struct {
+	Labels            map[string]string
+	Taints            []Taint
+	AddOrUpdateLabels map[string]string
+	RemoveLabels      []string
+	AddOrUpdateTaints []Taint
+	RemoveTaints      []Taint
+}
+
+// IsEmpty reports whether the plan leaves the node group unchanged
+func (p *NodeGroupConfigPlan) IsEmpty() bool {
+	return len(p.AddOrUpdateLabels) == 0 && len(p.RemoveLabels) == 0 &&
+		len(p.AddOrUpdateTaints) == 0 && len(p.RemoveTaints) == 0
+}
+
+// ValidateLabelKey checks a Kubernetes label or taint key: an optional DNS
+// subdomain prefix and "/", then a name of at most 63 characters
+func ValidateLabelKey(key string) error {
+	name := key
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		prefix := key[:i]
+		name = key[i+1:]
+		if prefix == "" || len(prefix) > 253 || !k8sPrefixPattern.MatchString(prefix) {
+			return fmt.Errorf("invalid key %q: prefix must be a lowercase DNS subdomain", key)
+		}
+	}
+	if name == "" || len(name) > 63 || !k8sNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid key %q: name must be 1-63 alphanumeric characters, '-', '_' or '.', starting and ending with an alphanumeric", key)
+	}
+	return nil
+}
+
+// ValidateLabelValue checks a Kubernetes label or taint value, which may be empty
+func ValidateLabelValue(value string) error {
+	if value == "" {
+		return nil
+	}
+	if len(value) > 63 || !k8sNamePattern.MatchString(value) {
+		return fmt.Errorf("invalid value %q: must be at most 63 alphanumeric characters, '-', '_' or '.', starting and ending with an alphanumeric", value)
+	}
+	return nil
+}
+
+// ParseTaintEffect accepts an EKS (NO_SCHEDULE) or kubectl (NoSchedule) effect
+// and returns the EKS form
+func ParseTaintEffect(effect string) (string, error) {
+	normalized := strings.NewReplacer("_", "", "-", "").Replace(strings.ToUpper(strings.TrimSpace(effect)))
+	for _, known := range ekstypes.TaintEffect("").Values() {
+		if strings.ReplaceAll(string(known), "_", "") == normalized {
+			return string(known), nil
+		}
+	}
+	return "", fmt.Errorf("invalid taint effect %q (expected NoSchedule, NoExecute or PreferNoSchedule)", effect)
+}
+
+// ParseTaint parses a kubectl-style taint, key=value:Effect or key:Effect
+func ParseTaint(spec string) (Taint, error) {
+	keyValue, effect, ok := strings.Cut(strings.TrimSpace(spec), ":")
+	if !ok {
+		return Taint{}, fmt.Errorf("invalid taint %q (expected key=value:Effect)", spec)
+	}
+	key, value, _ := strings.Cut(keyValue, "=")
+	taint := Taint{Key: key, Value: value}
+	var err error
+	if taint.Effect, err = ParseTaintEffect(effect); err != nil {
+		return Taint{}, err
+	}
+	return taint, nil
+}
+
+// Validate checks keys and values and rejects keys both added and removed
+func (c NodeGroupConfigChange) Validate() error {
+	for key, value := range c.AddLabels {
+		if err := ValidateLabelKey(key); err != nil {
+			return fmt.Errorf("label: %w", err)
+		}
+		if err := ValidateLabelValue(value); err != nil {
+			return fmt.Errorf("label %s: %w", key, err)
+		}
+	}
+	for _, key := range c.RemoveLabels {
+		if err := ValidateLabelKey(key); err != nil {
+			return fmt.Errorf("label: %w", err)
+		}
+		if _, ok := c.AddLabels[key]; ok {
+			return fmt.Errorf("label %s is both added and removed", key)
+		}
+	}
+
+	added := make(map[string]bool, len(c.AddTaints))
+	for _, t := range c.AddTaints {
+		if err := ValidateLabelKey(t.Key); err != nil {
+			return fmt.Errorf("taint: %w", err)
+		}
+		if err := ValidateLabelValue(t.Value); err != nil {
+			return fmt.Errorf("taint %s: %w", t.Key, err)
+		}
+		if _, err := ParseTaintEffect(t.Effect); err != nil {
+			return fmt.Errorf("taint %s: %w", t.Key, err)
+		}
+		added[t.Key] = true
+	}
+	for _, key := range c.RemoveTaints {
+		if err := ValidateLabelKey(key); err != nil {
+			return fmt.Errorf("taint: %w", err)
+		}
+		if added[key] {
+			return fmt.Errorf("taint %s is both added and removed", key)
+		}
+	}
+	return nil
+}
+
+// PlanNodeGroupConfigUpdate merges a change into the current labels and taints.
+// Added labels and taints that are already set are left out of the payload, and
+// removing a label or taint the node group does not have is an error.
+func PlanNodeGroupConfigUpdate(labels map[string]string, taints []Taint, change NodeGroupConfigChange) (*NodeGroupConfigPlan, error) {
+	if err := change.Validate(); err != nil {
+		return nil, err
+	}
+
+	plan := &NodeGroupConfigPlan{
+		Labels:            make(map[string]string, len(labels)+len(change.AddLabels)),
+		AddOrUpdateLabels: make(map[string]string),
+	}
+	if err := planLabelChanges(plan, labels, change); err != nil {
+		return nil, err
+	}
+	if err := planTaintChanges(plan, taints, change); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// planLabelChanges fills the label side of plan from the current labels
+func planLabelChanges(plan *NodeGroupConfigPlan, labels map[string]string, change NodeGroupConfigChange) error {
+	for k, v := range labels {
+		plan.Labels[k] = v
+	}
+	for k, v := range change.AddLabels {
+		if current, ok := labels[k]; ok && current == v {
+			continue
+		}
+		plan.AddOrUpdateLabels[k] = v
+		plan.Labels[k] = v
+	}
+	for _, k := range change.RemoveLabels {
+		if _, ok := labels[k]; !ok {
+			return fmt.Errorf("label %s is not set on the node group", k)
+		}
+		plan.RemoveLabels = append(plan.RemoveLabels, k)
+		delete(plan.Labels, k)
+	}
+	sort.Strings(plan.RemoveLabels)
+	return nil
+}
+
+// planTaintChanges fills the taint side of plan from the current taints
+func planTaintChanges(plan *NodeGroupConfigPlan, taints []Taint, change NodeGroupConfigChange) error {
+	removed := make(map[string]bool, len(change.RemoveTaints))
+	for _, key := range change.RemoveTaints {
+		found := false
+		for _, t := range taints {
+			if t.Key == key {
+				plan.RemoveTaints = append(plan.RemoveTaints, t)
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("taint %s is not set on the node group", key)
+		}
+		removed[key] = true
+	}
+
+	// Taints are identified by key and effect; an add with a new value updates it
+	for _, t := range taints {
+		if !removed[t.Key] {
+			plan.Taints = append(plan.Taints, t)
+		}
+	}
+	for _, add := range change.AddTaints {
+		add.Effect, _ = ParseTaintEffect(add.Effect)
+		i := indexTaint(plan.Taints, add.Key, add.Effect)
+		switch {
+		case i < 0:
+			plan.Taints = append(plan.Taints, add)
+		case plan.Taints[i] == add:
+			continue
+		default:
+			plan.Taints[i] = add
+		}
+		plan.AddOrUpdateTaints = append(plan.AddOrUpdateTaints, add)
+	}
+	sort.Slice(plan.Taints, func(i, j int) bool {
+		if plan.Taints[i].Key != plan.Taints[j].Key {
+			return plan.Taints[i].Key < plan.Taints[j].Key
+		}
+		return plan.Taints[i].Effect < plan.Taints[j].Effect
+	})
+	return nil
+}
+
+// indexTaint returns the position of the taint with key and effect, or -1
+func indexTaint(taints []Taint, key, effect string) int {
+	for i, t := range taints {
+		if t.Key == key && t.Effect == effect {
+			return i
+		}
+	}
+	return -1
+}
+
+// UpdateNodeGroupLabelsAndTaints applies a plan's label and taint changes to a node group
+func (c *Client) UpdateNodeGroupLabelsAndTaints(ctx context.Context, clusterName, nodeGroupName string, plan *NodeGroupConfigPlan) error {
+	var api EKSAPI
+	if c.EKSClient != nil {
+		api = c.EKSClient
+	} else {
+		api = eks.NewFromConfig(c.Config)
+	}
+	return updateNodeGroupLabelsAndTaints(ctx, api, clusterName, nodeGroupName, plan)
+}
+
+func updateNodeGroupLabelsAndTaints(ctx context.Context, api EKSAPI, clusterName, nodeGroupName string, plan *NodeGroupConfigPlan) error {
+	if plan == nil || plan.IsEmpty() {
+		return fmt.Errorf("no label or taint changes to apply")
+	}
+
+	input := &eks.UpdateNodegroupConfigInput{
+		ClusterName:   &clusterName,
+		NodegroupName: &nodeGroupName,
+	}
+	if len(plan.AddOrUpdateLabels) > 0 || len(plan.RemoveLabels) > 0 {
+		input.Labels = &ekstypes.UpdateLabelsPayload{
+			RemoveLabels: plan.RemoveLabels,
+		}
+		if len(plan.AddOrUpdateLabels) > 0 {
+			input.Labels.AddOrUpdateLabels = plan.AddOrUpdateLabels
+		}
+	}
+	if len(plan.AddOrUpdateTaints) > 0 || len(plan.RemoveTaints) > 0 {
+		input.Taints = &ekstypes.UpdateTaintsPayload{
+			AddOrUpdateTaints: toEKSTaints(plan.AddOrUpdateTaints),
+			RemoveTaints:      toEKSTaints(plan.RemoveTaints),
+		}
+	}
+
+	_, err := api.UpdateNodegroupConfig(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to update node group labels and taints: %w", err)
+	}
+
+	return nil
+}
+
+// toEKSTaints converts taints to the EKS API type
+func toEKSTaints(taints []Taint) []ekstypes.Taint {
+	if len(taints) == 0 {
+		return nil
+	}
+	out := make([]ekstypes.Taint, 0, len(taints))
+	for _, t := range taints {
+		taint := ekstypes.Taint{Key: &t.Key, Effect: ekstypes.TaintEffect(t.Effect)}
+		if t.Value != "" {
+			taint.Value = &t.Value
+		}
+		out = append(out, taint)
+	}
+	return out
+}
@@ -0,0 +1,195 @@
+package aws
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+)
+
+func TestValidateLabelKey(t *testing.T) {
+	tests := []struct {
+		key     string
+		wantErr bool
+	}{
+		{key: "team"},
+		{key: "node.kubernetes.io/role"},
+		{key: "example.com/gpu_type.v2"},
+		{key: "", wantErr: true},
+		{key: "-team", wantErr: true},
+		{key: "team name", wantErr: true},
+		{key: "Example.com/role", wantErr: true},
+		{key: "/role", wantErr: true},
+		{key: "example.com/", wantErr: true},
+		{key: "a123456789012345678901234567890123456789012345678901234567890123", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if err := ValidateLabelKey(tt.key); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateLabelKey(%q) error = %v, wantErr %v", tt.key, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseTaint(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    Taint
+		wantErr bool
+	}{
+		{spec: "dedicated=gpu:NoSchedule", want: Taint{Key: "dedicated", Value: "gpu", Effect: "NO_SCHEDULE"}},
+		{spec: "spot:PREFER_NO_SCHEDULE", want: Taint{Key: "spot", Effect: "PREFER_NO_SCHEDULE"}},
+		{spec: "drain=true:no-execute", want: Taint{Key: "drain", Value: "true", Effect: "NO_EXECUTE"}},
+		{spec: "dedicated=gpu", wantErr: true},
+		{spec: "dedicated=gpu:Sometimes", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseTaint(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTaint(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTaint(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanNodeGroupConfigUpdate(t *testing.T) {
+	labels := map[string]string{"team": "payments", "tier": "web"}
+	taints := []Taint{
+		{Key: "dedicated", Value: "gpu", Effect: "NO_EXECUTE"},
+		{Key: "dedicated", Value: "gpu", Effect: "NO_SCHEDULE"},
+		{Key: "spot", Effect: "PREFER_NO_SCHEDULE"},
+	}
+
+	tests := []struct {
+		name    string
+		change  NodeGroupConfigChange
+		want    NodeGroupConfigPlan
+		wantErr bool
+	}{
+		{
+			name:   "add and update labels",
+			change: NodeGroupConfigChange{AddLabels: map[string]string{"env": "prod", "tier": "api", "team": "payments"}},
+			want: NodeGroupConfigPlan{
+				Labels:            map[string]string{"team": "payments", "tier": "api", "env": "prod"},
+				Taints:            taints,
+				AddOrUpdateLabels: map[string]string{"env": "prod", "tier": "api"},
+			},
+		},
+		{
+			name:   "remove label",
+			change: NodeGroupConfigChange{RemoveLabels: []string{"tier"}},
+			want: NodeGroupConfigPlan{
+				Labels:            map[string]string{"team": "payments"},
+				Taints:            taints,
+				AddOrUpdateLabels: map[string]string{},
+				RemoveLabels:      []string{"tier"},
+			},
+		},
+		{
+			name:   "remove taint drops every effect of the key",
+			change: NodeGroupConfigChange{RemoveTaints: []string{"dedicated"}},
+			want: NodeGroupConfigPlan{
+				Labels:            labels,
+				Taints:            []Taint{{Key: "spot", Effect: "PREFER_NO_SCHEDULE"}},
+				AddOrUpdateLabels: map[string]string{},
+				RemoveTaints:      taints[:2],
+			},
+		},
+		{
+			name: "add taint updates value of same key and effect",
+			change: NodeGroupConfigChange{AddTaints: []Taint{
+				{Key: "spot", Value: "true", Effect: "PreferNoSchedule"},
+				{Key: "dedicated", Value: "gpu", Effect: "NO_SCHEDULE"},
+			}},
+			want: NodeGroupConfigPlan{
+				Labels: labels,
+				Taints: []Taint{
+					{Key: "dedicated", Value: "gpu", Effect: "NO_EXECUTE"},
+					{Key: "dedicated", Value: "gpu", Effect: "NO_SCHEDULE"},
+					{Key: "spot", Value: "true", Effect: "PREFER_NO_SCHEDULE"},
+				},
+				AddOrUpdateLabels: map[string]string{},
+				AddOrUpdateTaints: []Taint{{Key: "spot", Value: "true", Effect: "PREFER_NO_SCHEDULE"}},
+			},
+		},
+		{name: "remove missing label", change: NodeGroupConfigChange{RemoveLabels: []string{"env"}}, wantErr: true},
+		{name: "remove missing taint", change: NodeGroupConfigChange{RemoveTaints: []string{"gpu"}}, wantErr: true},
+		{name: "invalid label key", change: NodeGroupConfigChange{AddLabels: map[string]string{"bad key": "x"}}, wantErr: true},
+		{
+			name:    "label added and removed",
+			change:  NodeGroupConfigChange{AddLabels: map[string]string{"tier": "api"}, RemoveLabels: []string{"tier"}},
+			wantErr: true,
+		},
+		{
+			name:    "taint added and removed",
+			change:  NodeGroupConfigChange{AddTaints: []Taint{{Key: "spot", Effect: "NO_SCHEDULE"}}, RemoveTaints: []string{"spot"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PlanNodeGroupConfigUpdate(labels, taints, tt.change)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PlanNodeGroupConfigUpdate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("PlanNodeGroupConfigUpdate() =\n%+v\nwant\n%+v", *got, tt.want)
+			}
+		})
+	}
+
+	if labels["tier"] != "web" || len(taints) != 3 {
+		t.Error("PlanNodeGroupConfigUpdate() modified the current config")
+	}
+}
+
+func TestUpdateNodeGroupLabelsAndTaints(t *testing.T) {
+	var got *eks.UpdateNodegroupConfigInput
+	mockAPI := &MockEKSAPI{
+		UpdateNodegroupConfigFunc: func(_ context.Context, params *eks.UpdateNodegroupConfigInput, _ ...func(*eks.Options)) (*eks.UpdateNodegroupConfigOutput, error) {
+			got = params
+			return &eks.UpdateNodegroupConfigOutput{}, nil
+		},
+	}
+
+	plan := &NodeGroupConfigPlan{
+		AddOrUpdateLabels: map[string]string{"env": "prod"},
+		RemoveTaints:      []Taint{{Key: "spot", Effect: "PREFER_NO_SCHEDULE"}},
+	}
+	if err := updateNodeGroupLabelsAndTaints(context.Background(), mockAPI, "cluster-1", "ng-1", plan); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if *got.ClusterName != "cluster-1" || *got.NodegroupName != "ng-1" {
+		t.Errorf("wrong target %s/%s", *got.ClusterName, *got.NodegroupName)
+	}
+	if got.ScalingConfig != nil {
+		t.Error("scaling config must not be sent with a label/taint update")
+	}
+	if got.Labels == nil || !reflect.DeepEqual(got.Labels.AddOrUpdateLabels, map[string]string{"env": "prod"}) || got.Labels.RemoveLabels != nil {
+		t.Errorf("labels payload = %+v", got.Labels)
+	}
+	if got.Taints == nil || got.Taints.AddOrUpdateTaints != nil || len(got.Taints.RemoveTaints) != 1 {
+		t.Fatalf("taints payload = %+v", got.Taints)
+	}
+	removed := got.Taints.RemoveTaints[0]
+	if *removed.Key != "spot" || removed.Value != nil || removed.Effect != ekstypes.TaintEffectPreferNoSchedule {
+		t.Errorf("removed taint = %+v", removed)
+	}
+
+	t.Run("EmptyPlan", func(t *testing.T) {
+		if err := updateNodeGroupLabelsAndTaints(context.Background(), mockAPI, "cluster-1", "ng-1", &NodeGroupConfigPlan{}); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
@@ -314,9 +314,11 @@ func convertNodeGroup(ng *ekstypes.Nodegroup) *NodeGroup {
 	}
 
 	nodeGroup := &NodeGroup{
-		Status: string(ng.Status),
-		Tags:   ng.Tags,
-		Labels: ng.Labels,
+		Status:       string(ng.Status),
+		AMIType:      string(ng.AmiType),
+		CapacityType: string(ng.CapacityType),
+		Tags:         ng.Tags,
+		Labels:       ng.Labels,
 	}
 
 	// Set basic fields
@@ -326,6 +328,9 @@ func convertNodeGroup(ng *ekstypes.Nodegroup) *NodeGroup {
 	if ng.Version != nil {
 		nodeGroup.Version = *ng.Version
 	}
+	if ng.ReleaseVersion != nil {
+		nodeGroup.ReleaseVersion = *ng.ReleaseVersion
+	}
 	if ng.DiskSize != nil {
 		nodeGroup.DiskSize = *ng.DiskSize
 	}
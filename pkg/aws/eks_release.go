@@ -0,0 +1,117 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// SSMParametersAPI defines the interface for reading SSM public parameters
+type SSMParametersAPI interface {
+	GetParametersByPath(ctx context.Context, params *ssm.GetParametersByPathInput, optFns ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error)
+}
+
+// eksAMIParameterPaths maps node group AMI types to the SSM public parameter
+// path under /aws/service/eks/optimized-ami/<kubernetes-version>/
+var eksAMIParameterPaths = map[string]string{
+	"AL2_x86_64":             "amazon-linux-2",
+	"AL2_x86_64_GPU":         "amazon-linux-2-gpu",
+	"AL2_ARM_64":             "amazon-linux-2-arm64",
+	"AL2023_x86_64_STANDARD": "amazon-linux-2023/x86_64/standard",
+	"AL2023_ARM_64_STANDARD": "amazon-linux-2023/arm64/standard",
+	"AL2023_x86_64_NVIDIA":   "amazon-linux-2023/x86_64/nvidia",
+	"AL2023_x86_64_NEURON":   "amazon-linux-2023/x86_64/neuron",
+}
+
+// eksAMIParameterPath returns the SSM parameter path listing the EKS optimized
+// AMI releases for an AMI type and Kubernetes version
+func eksAMIParameterPath(amiType, kubernetesVersion string) (string, error) {
+	if kubernetesVersion == "" {
+		return "", fmt.Errorf("kubernetes version cannot be empty")
+	}
+	suffix := eksAMIParameterPaths[amiType]
+	if suffix == "" {
+		return "", fmt.Errorf("listing release versions is not supported for AMI type %q", amiType)
+	}
+	return fmt.Sprintf("/aws/service/eks/optimized-ami/%s/%s", kubernetesVersion, suffix), nil
+}
+
+// ListNodeGroupReleaseVersions returns the EKS optimized AMI release versions
+// available for an AMI type and Kubernetes version, newest first
+func (c *Client) ListNodeGroupReleaseVersions(ctx context.Context, amiType, kubernetesVersion string) ([]string, error) {
+	var api SSMParametersAPI
+	if c.SSMClient != nil {
+		api = c.SSMClient
+	} else {
+		api = ssm.NewFromConfig(c.Config)
+	}
+	return listNodeGroupReleaseVersions(ctx, api, amiType, kubernetesVersion)
+}
+
+func listNodeGroupReleaseVersions(ctx context.Context, api SSMParametersAPI, amiType, kubernetesVersion string) ([]string, error) {
+	path, err := eksAMIParameterPath(amiType, kubernetesVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var versions []string
+	paginator := ssm.NewGetParametersByPathPaginator(api, &ssm.GetParametersByPathInput{
+		Path:      aws.String(path),
+		Recursive: aws.Bool(true),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list EKS AMI release versions: %w", err)
+		}
+		for _, p := range page.Parameters {
+			if p.Name == nil || p.Value == nil || !strings.HasSuffix(*p.Name, "/release_version") {
+				continue
+			}
+			if v := *p.Value; !seen[v] {
+				seen[v] = true
+				versions = append(versions, v)
+			}
+		}
+	}
+
+	// Release versions are "<kubernetes-version>-<yyyymmdd>", so they sort as strings
+	sort.Sort(sort.Reverse(sort.StringSlice(versions)))
+	return versions, nil
+}
+
+// UpdateNodeGroupReleaseVersion updates a node group to an EKS optimized AMI release version
+func (c *Client) UpdateNodeGroupReleaseVersion(ctx context.Context, clusterName, nodeGroupName, releaseVersion string) error {
+	var api EKSAPI
+	if c.EKSClient != nil {
+		api = c.EKSClient
+	} else {
+		api = eks.NewFromConfig(c.Config)
+	}
+	return updateNodeGroupReleaseVersion(ctx, api, clusterName, nodeGroupName, releaseVersion)
+}
+
+func updateNodeGroupReleaseVersion(ctx context.Context, api EKSAPI, clusterName, nodeGroupName, releaseVersion string) error {
+	if releaseVersion == "" {
+		return fmt.Errorf("release version cannot be empty")
+	}
+
+	input := &eks.UpdateNodegroupVersionInput{
+		ClusterName:    &clusterName,
+		NodegroupName:  &nodeGroupName,
+		ReleaseVersion: &releaseVersion,
+	}
+
+	_, err := api.UpdateNodegroupVersion(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to update node group release version: %w", err)
+	}
+
+	return nil
+}
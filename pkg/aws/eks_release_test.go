@@ -0,0 +1,100 @@
+package aws
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+type mockSSMParametersAPI struct {
+	path      string
+	recursive bool
+	params    []ssmtypes.Parameter
+}
+
+func (m *mockSSMParametersAPI) GetParametersByPath(_ context.Context, params *ssm.GetParametersByPathInput, _ ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error) {
+	m.path = aws.ToString(params.Path)
+	m.recursive = aws.ToBool(params.Recursive)
+	return &ssm.GetParametersByPathOutput{Parameters: m.params}, nil
+}
+
+func TestConvertNodeGroupReleaseVersion(t *testing.T) {
+	ng := convertNodeGroup(&ekstypes.Nodegroup{
+		NodegroupName:  aws.String("workers"),
+		Version:        aws.String("1.29"),
+		ReleaseVersion: aws.String("1.29.0-20240213"),
+		AmiType:        ekstypes.AMITypesAl2X8664,
+	})
+
+	if ng.ReleaseVersion != "1.29.0-20240213" {
+		t.Errorf("ReleaseVersion = %q, want 1.29.0-20240213", ng.ReleaseVersion)
+	}
+	if ng.AMIType != "AL2_x86_64" {
+		t.Errorf("AMIType = %q, want AL2_x86_64", ng.AMIType)
+	}
+	if ng.GetReleaseVersion() != ng.ReleaseVersion {
+		t.Errorf("GetReleaseVersion() = %q, want %q", ng.GetReleaseVersion(), ng.ReleaseVersion)
+	}
+}
+
+func TestUpdateNodeGroupReleaseVersion(t *testing.T) {
+	var got *eks.UpdateNodegroupVersionInput
+	mockAPI := &MockEKSAPI{
+		UpdateNodegroupVersionFunc: func(_ context.Context, params *eks.UpdateNodegroupVersionInput, _ ...func(*eks.Options)) (*eks.UpdateNodegroupVersionOutput, error) {
+			got = params
+			return &eks.UpdateNodegroupVersionOutput{}, nil
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		if err := updateNodeGroupReleaseVersion(context.Background(), mockAPI, "cluster-1", "ng-1", "1.29.0-20240213"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if aws.ToString(got.ClusterName) != "cluster-1" || aws.ToString(got.NodegroupName) != "ng-1" {
+			t.Errorf("updated %s/%s, want cluster-1/ng-1", aws.ToString(got.ClusterName), aws.ToString(got.NodegroupName))
+		}
+		if aws.ToString(got.ReleaseVersion) != "1.29.0-20240213" {
+			t.Errorf("ReleaseVersion = %q, want 1.29.0-20240213", aws.ToString(got.ReleaseVersion))
+		}
+		if got.LaunchTemplate != nil || got.Version != nil {
+			t.Error("release version update should not change the launch template or Kubernetes version")
+		}
+	})
+
+	t.Run("EmptyVersion", func(t *testing.T) {
+		if err := updateNodeGroupReleaseVersion(context.Background(), mockAPI, "cluster-1", "ng-1", ""); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestListNodeGroupReleaseVersions(t *testing.T) {
+	base := "/aws/service/eks/optimized-ami/1.29/amazon-linux-2"
+	api := &mockSSMParametersAPI{params: []ssmtypes.Parameter{
+		{Name: aws.String(base + "/recommended/release_version"), Value: aws.String("1.29.0-20240227")},
+		{Name: aws.String(base + "/recommended/image_id"), Value: aws.String("ami-123")},
+		{Name: aws.String(base + "/amazon-eks-node-1.29-v20240213/release_version"), Value: aws.String("1.29.0-20240213")},
+		{Name: aws.String(base + "/amazon-eks-node-1.29-v20240227/release_version"), Value: aws.String("1.29.0-20240227")},
+	}}
+
+	got, err := listNodeGroupReleaseVersions(context.Background(), api, "AL2_x86_64", "1.29")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if api.path != base || !api.recursive {
+		t.Errorf("queried %q (recursive=%v), want %q recursively", api.path, api.recursive, base)
+	}
+	if want := []string{"1.29.0-20240227", "1.29.0-20240213"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("versions = %v, want %v", got, want)
+	}
+
+	if _, err := listNodeGroupReleaseVersions(context.Background(), api, "CUSTOM", "1.29"); err == nil {
+		t.Error("expected error for custom AMI type")
+	}
+}
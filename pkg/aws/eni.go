@@ -0,0 +1,127 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// eniCreatedAtTag is set by the Amazon VPC CNI on the ENIs it creates. EC2 does
+// not report an ENI creation time, so this tag is the only age source.
+const eniCreatedAtTag = "node.k8s.amazonaws.com/createdAt"
+
+// EC2NetworkInterfacesAPI defines the interface for describing ENIs
+type EC2NetworkInterfacesAPI interface {
+	DescribeNetworkInterfaces(ctx context.Context, params *ec2.DescribeNetworkInterfacesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error)
+}
+
+// OrphanedENI is an unattached network interface that still incurs cost or
+// consumes subnet addresses
+type OrphanedENI struct {
+	NetworkInterfaceID string
+	SubnetID           string
+	VpcID              string
+	AvailabilityZone   string
+	PrivateIP          string
+	InterfaceType      string
+	Description        string
+	CreatedAt          time.Time // zero when the creation time is unknown
+}
+
+// Age returns how long ago the ENI was created, or 0 when unknown
+func (e OrphanedENI) Age(now time.Time) time.Duration {
+	if e.CreatedAt.IsZero() {
+		return 0
+	}
+	return now.Sub(e.CreatedAt)
+}
+
+// IsOrphanedENI reports whether an ENI is available and unattached. ENIs managed
+// by AWS services are excluded because their owners clean them up.
+func IsOrphanedENI(iface types.NetworkInterface) bool {
+	if iface.Status != types.NetworkInterfaceStatusAvailable {
+		return false
+	}
+	if aws.ToBool(iface.RequesterManaged) {
+		return false
+	}
+	return iface.Attachment == nil || iface.Attachment.Status == types.AttachmentStatusDetached
+}
+
+// ListOrphanedENIs returns the unattached ENIs in the client's region, oldest first
+func (c *Client) ListOrphanedENIs(ctx context.Context) ([]OrphanedENI, error) {
+	var api EC2NetworkInterfacesAPI
+	if c.EC2Client != nil {
+		api = c.EC2Client
+	} else {
+		api = ec2.NewFromConfig(c.Config)
+	}
+	return listOrphanedENIs(ctx, api)
+}
+
+func listOrphanedENIs(ctx context.Context, api EC2NetworkInterfacesAPI) ([]OrphanedENI, error) {
+	input := &ec2.DescribeNetworkInterfacesInput{
+		Filters: []types.Filter{
+			{Name: aws.String("status"), Values: []string{string(types.NetworkInterfaceStatusAvailable)}},
+		},
+	}
+
+	var orphans []OrphanedENI
+	paginator := ec2.NewDescribeNetworkInterfacesPaginator(api, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe network interfaces: %w", err)
+		}
+		for _, iface := range page.NetworkInterfaces {
+			if IsOrphanedENI(iface) {
+				orphans = append(orphans, toOrphanedENI(iface))
+			}
+		}
+	}
+
+	sortOrphanedENIs(orphans)
+	return orphans, nil
+}
+
+// toOrphanedENI converts an EC2 network interface into an OrphanedENI
+func toOrphanedENI(iface types.NetworkInterface) OrphanedENI {
+	eni := OrphanedENI{
+		NetworkInterfaceID: aws.ToString(iface.NetworkInterfaceId),
+		SubnetID:           aws.ToString(iface.SubnetId),
+		VpcID:              aws.ToString(iface.VpcId),
+		AvailabilityZone:   aws.ToString(iface.AvailabilityZone),
+		PrivateIP:          aws.ToString(iface.PrivateIpAddress),
+		InterfaceType:      string(iface.InterfaceType),
+		Description:        aws.ToString(iface.Description),
+	}
+	for _, tag := range iface.TagSet {
+		if aws.ToString(tag.Key) != eniCreatedAtTag {
+			continue
+		}
+		if createdAt, err := time.Parse(time.RFC3339, aws.ToString(tag.Value)); err == nil {
+			eni.CreatedAt = createdAt
+		}
+	}
+	return eni
+}
+
+// sortOrphanedENIs orders ENIs oldest first, with unknown ages last
+func sortOrphanedENIs(orphans []OrphanedENI) {
+	sort.SliceStable(orphans, func(i, j int) bool {
+		a, b := orphans[i].CreatedAt, orphans[j].CreatedAt
+		switch {
+		case a.IsZero() != b.IsZero():
+			return !a.IsZero()
+		case !a.Equal(b):
+			return a.Before(b)
+		default:
+			return orphans[i].NetworkInterfaceID < orphans[j].NetworkInterfaceID
+		}
+	})
+}
@@ -0,0 +1,138 @@
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// MockEC2NetworkInterfacesAPI returns a fixed set of ENIs
+type MockEC2NetworkInterfacesAPI struct {
+	interfaces []types.NetworkInterface
+}
+
+func (m *MockEC2NetworkInterfacesAPI) DescribeNetworkInterfaces(_ context.Context, _ *ec2.DescribeNetworkInterfacesInput, _ ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	return &ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: m.interfaces}, nil
+}
+
+func TestIsOrphanedENI(t *testing.T) {
+	tests := []struct {
+		name  string
+		iface types.NetworkInterface
+		want  bool
+	}{
+		{
+			name:  "available without attachment",
+			iface: types.NetworkInterface{Status: types.NetworkInterfaceStatusAvailable},
+			want:  true,
+		},
+		{
+			name: "available with detached attachment",
+			iface: types.NetworkInterface{
+				Status:     types.NetworkInterfaceStatusAvailable,
+				Attachment: &types.NetworkInterfaceAttachment{Status: types.AttachmentStatusDetached},
+			},
+			want: true,
+		},
+		{
+			name: "in use",
+			iface: types.NetworkInterface{
+				Status:     types.NetworkInterfaceStatusInUse,
+				Attachment: &types.NetworkInterfaceAttachment{Status: types.AttachmentStatusAttached},
+			},
+		},
+		{
+			name: "attaching",
+			iface: types.NetworkInterface{
+				Status:     types.NetworkInterfaceStatusAttaching,
+				Attachment: &types.NetworkInterfaceAttachment{Status: types.AttachmentStatusAttaching},
+			},
+		},
+		{
+			name: "detaching",
+			iface: types.NetworkInterface{
+				Status:     types.NetworkInterfaceStatusDetaching,
+				Attachment: &types.NetworkInterfaceAttachment{Status: types.AttachmentStatusDetaching},
+			},
+		},
+		{
+			name: "available but still attaching",
+			iface: types.NetworkInterface{
+				Status:     types.NetworkInterfaceStatusAvailable,
+				Attachment: &types.NetworkInterfaceAttachment{Status: types.AttachmentStatusAttaching},
+			},
+		},
+		{
+			name: "available but requester managed",
+			iface: types.NetworkInterface{
+				Status:           types.NetworkInterfaceStatusAvailable,
+				RequesterManaged: aws.Bool(true),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsOrphanedENI(tt.iface); got != tt.want {
+				t.Errorf("IsOrphanedENI() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListOrphanedENIs(t *testing.T) {
+	api := &MockEC2NetworkInterfacesAPI{interfaces: []types.NetworkInterface{
+		{
+			NetworkInterfaceId: aws.String("eni-unknown-age"),
+			Status:             types.NetworkInterfaceStatusAvailable,
+			SubnetId:           aws.String("subnet-1"),
+		},
+		{
+			NetworkInterfaceId: aws.String("eni-attached"),
+			Status:             types.NetworkInterfaceStatusInUse,
+			Attachment:         &types.NetworkInterfaceAttachment{Status: types.AttachmentStatusAttached},
+		},
+		{
+			NetworkInterfaceId: aws.String("eni-newer"),
+			Status:             types.NetworkInterfaceStatusAvailable,
+			SubnetId:           aws.String("subnet-2"),
+			TagSet:             []types.Tag{{Key: aws.String(eniCreatedAtTag), Value: aws.String("2024-06-01T00:00:00Z")}},
+		},
+		{
+			NetworkInterfaceId: aws.String("eni-older"),
+			Status:             types.NetworkInterfaceStatusAvailable,
+			SubnetId:           aws.String("subnet-3"),
+			TagSet:             []types.Tag{{Key: aws.String(eniCreatedAtTag), Value: aws.String("2024-01-01T00:00:00Z")}},
+		},
+	}}
+
+	orphans, err := listOrphanedENIs(context.Background(), api)
+	if err != nil {
+		t.Fatalf("listOrphanedENIs() error = %v", err)
+	}
+
+	want := []string{"eni-older", "eni-newer", "eni-unknown-age"}
+	if len(orphans) != len(want) {
+		t.Fatalf("got %d orphans, want %d", len(orphans), len(want))
+	}
+	for i, id := range want {
+		if orphans[i].NetworkInterfaceID != id {
+			t.Errorf("orphan %d = %s, want %s", i, orphans[i].NetworkInterfaceID, id)
+		}
+	}
+	if orphans[0].SubnetID != "subnet-3" {
+		t.Errorf("SubnetID = %s, want subnet-3", orphans[0].SubnetID)
+	}
+
+	now := time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)
+	if got := orphans[0].Age(now); got != 10*24*time.Hour {
+		t.Errorf("Age() = %v, want 240h", got)
+	}
+	if got := orphans[2].Age(now); got != 0 {
+		t.Errorf("Age() for unknown creation time = %v, want 0", got)
+	}
+}
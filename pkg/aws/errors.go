@@ -0,0 +1,59 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// ErrNoCredentials reports that no AWS credentials are configured anywhere the SDK looks
+var ErrNoCredentials = errors.New("no AWS credentials found")
+
+// accessDeniedCodes are the API error codes AWS services use for authorization failures
+var accessDeniedCodes = map[string]bool{
+	"AccessDenied":           true,
+	"AccessDeniedException":  true,
+	"UnauthorizedOperation":  true,
+	"UnauthorizedException":  true,
+	"AuthorizationError":     true,
+	"NotAuthorized":          true,
+	"NotAuthorizedException": true,
+}
+
+// IsAccessDenied reports whether err, or any error it wraps, is an AWS
+// authorization failure
+func IsAccessDenied(err error) bool {
+	var apiErr interface{ ErrorCode() string }
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return accessDeniedCodes[apiErr.ErrorCode()]
+}
+
+// noIMDSRoleMessage is how the default credential chain fails once it has
+// fallen through every configured source to the EC2 instance role
+const noIMDSRoleMessage = "no EC2 IMDS role found"
+
+// checkCredentials resolves credentials up front so a machine with none
+// configured fails with ErrNoCredentials instead of an opaque SDK error on the
+// first API call. Other retrieval failures, such as an expired SSO token, are
+// left for that first call to report as before.
+func checkCredentials(ctx context.Context, provider aws.CredentialsProvider) error {
+	if provider == nil {
+		return ErrNoCredentials
+	}
+	creds, err := provider.Retrieve(ctx)
+	if err != nil {
+		if strings.Contains(err.Error(), noIMDSRoleMessage) {
+			return fmt.Errorf("%w: %v", ErrNoCredentials, err)
+		}
+		return nil
+	}
+	if !creds.HasKeys() {
+		return ErrNoCredentials
+	}
+	return nil
+}
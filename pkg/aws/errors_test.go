@@ -0,0 +1,78 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+type codedError struct{ code string }
+
+func (e codedError) Error() string     { return e.code }
+func (e codedError) ErrorCode() string { return e.code }
+
+func TestIsAccessDenied(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "plain error", err: errors.New("AccessDenied"), want: false},
+		{name: "access denied", err: codedError{"AccessDeniedException"}, want: true},
+		{name: "ec2 unauthorized", err: codedError{"UnauthorizedOperation"}, want: true},
+		{name: "wrapped", err: fmt.Errorf("failed to describe: %w", codedError{"AccessDenied"}), want: true},
+		{name: "throttled", err: codedError{"ThrottlingException"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsAccessDenied(tt.err); got != tt.want {
+				t.Errorf("IsAccessDenied() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeCredentialsProvider returns fixed credentials or an error
+type fakeCredentialsProvider struct {
+	creds aws.Credentials
+	err   error
+}
+
+func (p fakeCredentialsProvider) Retrieve(context.Context) (aws.Credentials, error) {
+	return p.creds, p.err
+}
+
+func TestCheckCredentials(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider aws.CredentialsProvider
+		wantNone bool
+	}{
+		{name: "no provider", provider: nil, wantNone: true},
+		{name: "empty credentials", provider: fakeCredentialsProvider{}, wantNone: true},
+		{
+			name:     "chain exhausted",
+			provider: fakeCredentialsProvider{err: errors.New("failed to refresh cached credentials, no EC2 IMDS role found, operation error ec2imds: GetMetadata")},
+			wantNone: true,
+		},
+		{name: "expired sso token is left to the api call", provider: fakeCredentialsProvider{err: errors.New("refresh cached SSO token failed")}},
+		{name: "static keys", provider: fakeCredentialsProvider{creds: aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkCredentials(context.Background(), tt.provider)
+			if got := errors.Is(err, ErrNoCredentials); got != tt.wantNone {
+				t.Errorf("checkCredentials() error = %v, want ErrNoCredentials = %v", err, tt.wantNone)
+			}
+			if !tt.wantNone && err != nil {
+				t.Errorf("checkCredentials() unexpected error = %v", err)
+			}
+		})
+	}
+}
@@ -31,6 +31,14 @@ func columnNamesToConfig(columnNames []string) fuzzy.ColumnConfig {
 	return config
 }
 
+// sensitiveTags returns the configured tag keys to mask in output
+func (c *Client) sensitiveTags() []string {
+	if c.AppConfig == nil {
+		return nil
+	}
+	return c.AppConfig.Output.SensitiveTags
+}
+
 // SelectInstanceInteractive displays an enhanced interactive fuzzy finder to select an EC2 instance
 func (c *Client) SelectInstanceInteractive(ctx context.Context) (*Instance, error) {
 	// Use cached configuration for performance (loaded once in NewClient)
@@ -64,6 +72,9 @@ func (c *Client) SelectInstanceInteractive(ctx context.Context) (*Instance, erro
 		Width:        width,
 		Favorites:    c.Favorites,
 		ConfigPath:   "", // Using default
+
+		SensitiveTags: cfg.Output.SensitiveTags,
+		LabelTemplate: cfg.Interactive.LabelTemplate,
 	}
 
 	// Create instance loader
@@ -72,7 +83,7 @@ func (c *Client) SelectInstanceInteractive(ctx context.Context) (*Instance, erro
 	// Wrap with cache if enabled
 	var loader fuzzy.InstanceLoader = baseLoader
 	if fuzzyConfig.Cache.Enabled {
-		cacheService, err := cache.NewCacheService(fuzzyConfig.Cache.CacheDir, fuzzyConfig.Cache.TTLMinutes)
+		cacheService, err := cache.NewCacheServiceFromConfig(cfg)
 		if err != nil {
 			// Log warning but continue without cache
 			fmt.Printf("Warning: failed to initialize cache: %v\n", err)
@@ -144,6 +155,8 @@ func (c *Client) SelectInstancesInteractive(ctx context.Context) ([]Instance, er
 		Width:        width,
 		Favorites:    c.Favorites,
 		ConfigPath:   "", // Using default
+
+		SensitiveTags: cfg.Output.SensitiveTags,
 	}
 
 	// Create instance loader
@@ -152,7 +165,7 @@ func (c *Client) SelectInstancesInteractive(ctx context.Context) ([]Instance, er
 	// Wrap with cache if enabled
 	var loader fuzzy.InstanceLoader = baseLoader
 	if fuzzyConfig.Cache.Enabled {
-		cacheService, err := cache.NewCacheService(fuzzyConfig.Cache.CacheDir, fuzzyConfig.Cache.TTLMinutes)
+		cacheService, err := cache.NewCacheServiceFromConfig(cfg)
 		if err != nil {
 			// Log warning but continue without cache
 			fmt.Printf("Warning: failed to initialize cache: %v\n", err)
@@ -194,15 +207,24 @@ func (c *Client) SelectInstancesInteractive(ctx context.Context) ([]Instance, er
 // SelectInstanceFromProvided displays an interactive fuzzy finder for a provided instance slice.
 // It does not refetch instances and assumes the slice is non-empty.
 func (c *Client) SelectInstanceFromProvided(ctx context.Context, instances []Instance) (*Instance, error) {
+	fuzzyConfig := fuzzy.DefaultConfig()
+	fuzzyConfig.SensitiveTags = c.sensitiveTags()
+
+	return selectProvidedInstance(instances, func(candidates []fuzzy.Instance) ([]fuzzy.Instance, error) {
+		loader := fuzzy.NewProvidedInstanceLoader(candidates)
+		finder := fuzzy.NewEnhancedFinder(loader, fuzzyConfig).WithColumns(fuzzy.MultipleMatchColumns())
+		return finder.SelectInstanceInteractive(ctx)
+	})
+}
+
+// selectProvidedInstance offers the running instances (or all of them when
+// none is running) to pick and maps the first pick back to its Instance.
+// A nil instance with a nil error means nothing was selected.
+func selectProvidedInstance(instances []Instance, pick func([]fuzzy.Instance) ([]fuzzy.Instance, error)) (*Instance, error) {
 	if len(instances) == 0 {
 		return nil, fmt.Errorf("no instances provided for interactive selection")
 	}
 
-	// For now, keep the original implementation for this method
-	// This method currently uses a different fuzzy finder approach for provided instances
-	// to maintain compatibility with the existing API. The enhanced finder will be
-	// integrated in a future refactoring when we can ensure backward compatibility.
-
 	// Filter running instances first to reduce noise, but if that empties the list, fall back.
 	running := make([]Instance, 0, len(instances))
 	for _, inst := range instances {
@@ -214,35 +236,7 @@ func (c *Client) SelectInstanceFromProvided(ctx context.Context, instances []Ins
 		instances = running
 	}
 
-	// Create fuzzy config
-	fuzzyConfig := fuzzy.DefaultConfig()
-
-	// Convert AWS instances to fuzzy instances
-	var fuzzyInstances []fuzzy.Instance
-	for _, inst := range instances {
-		fuzzyInst := fuzzy.Instance{
-			InstanceID:       inst.InstanceID,
-			Name:             inst.Name,
-			State:            inst.State,
-			PrivateIP:        inst.PrivateIP,
-			PublicIP:         inst.PublicIP,
-			PrivateDNS:       inst.PrivateDNS,
-			PublicDNS:        inst.PublicDNS,
-			InstanceType:     inst.InstanceType,
-			AvailabilityZone: inst.AvailabilityZone,
-			Tags:             inst.Tags,
-		}
-		fuzzyInstances = append(fuzzyInstances, fuzzyInst)
-	}
-
-	// Create loader for provided instances
-	loader := fuzzy.NewProvidedInstanceLoader(fuzzyInstances)
-
-	// Create enhanced fuzzy finder
-	finder := fuzzy.NewEnhancedFinder(loader, fuzzyConfig)
-
-	// Select instance
-	selectedInstances, err := finder.SelectInstanceInteractive(ctx)
+	selectedInstances, err := pick(toFuzzyInstances(instances))
 	if err != nil {
 		return nil, err
 	}
@@ -261,6 +255,27 @@ func (c *Client) SelectInstanceFromProvided(ctx context.Context, instances []Ins
 	return nil, fmt.Errorf("selected instance not found in provided list")
 }
 
+// toFuzzyInstances converts AWS instances to fuzzy finder instances
+func toFuzzyInstances(instances []Instance) []fuzzy.Instance {
+	fuzzyInstances := make([]fuzzy.Instance, 0, len(instances))
+	for _, inst := range instances {
+		fuzzyInstances = append(fuzzyInstances, fuzzy.Instance{
+			InstanceID:       inst.InstanceID,
+			Name:             inst.Name,
+			State:            inst.State,
+			PrivateIP:        inst.PrivateIP,
+			PublicIP:         inst.PublicIP,
+			PrivateDNS:       inst.PrivateDNS,
+			PublicDNS:        inst.PublicDNS,
+			InstanceType:     inst.InstanceType,
+			AvailabilityZone: inst.AvailabilityZone,
+			LaunchTime:       inst.LaunchTime,
+			Tags:             inst.Tags,
+		})
+	}
+	return fuzzyInstances
+}
+
 // EKSDescriber wraps the Client to provide the ClusterDescriber interface
 type EKSDescriber struct {
 	client *Client
@@ -293,7 +308,9 @@ func (c *Client) SelectEKSClusterInteractive(ctx context.Context) (*Cluster, err
 	loader := fuzzy.NewAWSEKSLoader(describer, describer)
 
 	// Create EKS finder
-	finder := fuzzy.NewEKSFinder(loader, fuzzy.DefaultConfig())
+	fuzzyConfig := fuzzy.DefaultConfig()
+	fuzzyConfig.SensitiveTags = c.sensitiveTags()
+	finder := fuzzy.NewEKSFinder(loader, fuzzyConfig)
 
 	// Select cluster
 	selectedCluster, err := finder.SelectClusterInteractive(ctx)
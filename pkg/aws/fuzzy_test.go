@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/johnlam90/aws-ssm/pkg/ui/fuzzy"
@@ -159,3 +160,51 @@ func TestClientInteractiveFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectProvidedInstance(t *testing.T) {
+	instances := []Instance{
+		{InstanceID: "i-1", Name: "web-1", State: "running"},
+		{InstanceID: "i-2", Name: "web-2", State: "stopped"},
+		{InstanceID: "i-3", Name: "web-3", State: "running"},
+	}
+	tests := []struct {
+		name      string
+		instances []Instance
+		pickID    string
+		wantIDs   []string // Candidates offered to the picker
+		wantID    string
+		wantErr   bool
+	}{
+		{name: "returns the chosen instance", instances: instances, pickID: "i-3", wantIDs: []string{"i-1", "i-3"}, wantID: "i-3"},
+		{name: "offers all when none is running", instances: instances[1:2], pickID: "i-2", wantIDs: []string{"i-2"}, wantID: "i-2"},
+		{name: "nothing selected", instances: instances, wantIDs: []string{"i-1", "i-3"}},
+		{name: "unknown selection", instances: instances, pickID: "i-9", wantIDs: []string{"i-1", "i-3"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var offered []string
+			selected, err := selectProvidedInstance(tt.instances, func(candidates []fuzzy.Instance) ([]fuzzy.Instance, error) {
+				for _, c := range candidates {
+					offered = append(offered, c.InstanceID)
+				}
+				if tt.pickID == "" {
+					return nil, nil
+				}
+				return []fuzzy.Instance{{InstanceID: tt.pickID}}, nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("selectProvidedInstance() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if strings.Join(offered, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("offered %v, want %v", offered, tt.wantIDs)
+			}
+			gotID := ""
+			if selected != nil {
+				gotID = selected.InstanceID
+			}
+			if gotID != tt.wantID {
+				t.Errorf("selected %q, want %q", gotID, tt.wantID)
+			}
+		})
+	}
+}
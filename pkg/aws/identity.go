@@ -0,0 +1,40 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// STSAPI defines the interface for STS operations
+type STSAPI interface {
+	GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error)
+}
+
+// CallerIdentity describes the principal behind the current credentials
+type CallerIdentity struct {
+	Account string
+	Arn     string
+	UserID  string
+}
+
+// GetCallerIdentity returns the identity of the current credentials. It is a
+// cheap call that succeeds only when the credentials are valid.
+func (c *Client) GetCallerIdentity(ctx context.Context) (*CallerIdentity, error) {
+	return getCallerIdentity(ctx, sts.NewFromConfig(c.Config))
+}
+
+func getCallerIdentity(ctx context.Context, api STSAPI) (*CallerIdentity, error) {
+	output, err := api.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get caller identity: %w", err)
+	}
+
+	return &CallerIdentity{
+		Account: aws.ToString(output.Account),
+		Arn:     aws.ToString(output.Arn),
+		UserID:  aws.ToString(output.UserId),
+	}, nil
+}
@@ -0,0 +1,43 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// MockSTSAPI returns a fixed caller identity or error
+type MockSTSAPI struct {
+	output *sts.GetCallerIdentityOutput
+	err    error
+}
+
+func (m *MockSTSAPI) GetCallerIdentity(_ context.Context, _ *sts.GetCallerIdentityInput, _ ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+	return m.output, m.err
+}
+
+func TestGetCallerIdentity(t *testing.T) {
+	api := &MockSTSAPI{output: &sts.GetCallerIdentityOutput{
+		Account: aws.String("123456789012"),
+		Arn:     aws.String("arn:aws:iam::123456789012:user/dev"),
+		UserId:  aws.String("AIDAEXAMPLE"),
+	}}
+
+	identity, err := getCallerIdentity(context.Background(), api)
+	if err != nil {
+		t.Fatalf("getCallerIdentity() error = %v", err)
+	}
+	if identity.Account != "123456789012" || identity.UserID != "AIDAEXAMPLE" {
+		t.Errorf("identity = %+v", identity)
+	}
+}
+
+func TestGetCallerIdentityError(t *testing.T) {
+	api := &MockSTSAPI{err: errors.New("ExpiredToken")}
+	if _, err := getCallerIdentity(context.Background(), api); err == nil {
+		t.Error("getCallerIdentity() expected error")
+	}
+}
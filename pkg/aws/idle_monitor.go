@@ -0,0 +1,97 @@
+package aws
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// IdleWarningConfig controls the idle warning shown during native shell sessions
+type IdleWarningConfig struct {
+	// Timeout is how long a session may be idle before it is disconnected
+	Timeout time.Duration
+	// WarnBefore is how long before Timeout the warning is shown
+	WarnBefore time.Duration
+}
+
+// Enabled reports whether the configuration asks for idle warnings
+func (c IdleWarningConfig) Enabled() bool {
+	return c.Timeout > 0 && c.WarnBefore > 0 && c.WarnBefore < c.Timeout
+}
+
+// IdleMonitor tracks session activity and reports when an idle session is
+// about to reach its timeout
+type IdleMonitor struct {
+	cfg   IdleWarningConfig
+	clock Clock
+
+	mu           sync.Mutex
+	lastActivity time.Time
+	warned       bool
+}
+
+// NewIdleMonitor creates an idle monitor that uses the real clock
+func NewIdleMonitor(cfg IdleWarningConfig) *IdleMonitor {
+	return NewIdleMonitorWithClock(cfg, realClock{})
+}
+
+// NewIdleMonitorWithClock creates an idle monitor with an injected clock
+func NewIdleMonitorWithClock(cfg IdleWarningConfig, clk Clock) *IdleMonitor {
+	return &IdleMonitor{
+		cfg:          cfg,
+		clock:        clk,
+		lastActivity: clk.Now(),
+	}
+}
+
+// Touch records session activity, restarting the idle period
+func (m *IdleMonitor) Touch() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastActivity = m.clock.Now()
+	m.warned = false
+}
+
+// Check returns the time left before the idle timeout and whether a warning
+// is due. A warning is due once per idle period, WarnBefore ahead of Timeout.
+func (m *IdleMonitor) Check() (time.Duration, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	remaining := m.cfg.Timeout - m.clock.Now().Sub(m.lastActivity)
+	if m.warned || remaining > m.cfg.WarnBefore {
+		return remaining, false
+	}
+	m.warned = true
+	return remaining, true
+}
+
+// Run calls Check every interval until ctx is done, calling warn when a
+// warning is due
+func (m *IdleMonitor) Run(ctx context.Context, interval time.Duration, warn func(remaining time.Duration)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if remaining, due := m.Check(); due {
+				warn(remaining)
+			}
+		}
+	}
+}
+
+// activityWriter passes writes through to w, recording each one as activity
+type activityWriter struct {
+	w       io.Writer
+	monitor *IdleMonitor
+}
+
+func (a activityWriter) Write(p []byte) (int, error) {
+	a.monitor.Touch()
+	return a.w.Write(p)
+}
@@ -0,0 +1,89 @@
+package aws
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestIdleMonitorWarnsBeforeTimeout(t *testing.T) {
+	clk := &testClock{t: time.Unix(0, 0)}
+	m := NewIdleMonitorWithClock(IdleWarningConfig{Timeout: 10 * time.Minute, WarnBefore: time.Minute}, clk)
+
+	clk.Advance(8*time.Minute + 59*time.Second)
+	if remaining, due := m.Check(); due {
+		t.Fatalf("warning due with %s remaining, want none before the last minute", remaining)
+	}
+
+	clk.Advance(time.Second)
+	remaining, due := m.Check()
+	if !due {
+		t.Fatal("expected a warning one minute before the timeout")
+	}
+	if remaining != time.Minute {
+		t.Errorf("remaining = %s, want 1m0s", remaining)
+	}
+
+	clk.Advance(30 * time.Second)
+	if _, due := m.Check(); due {
+		t.Error("warning repeated within the same idle period")
+	}
+}
+
+func TestIdleMonitorTouchResetsIdlePeriod(t *testing.T) {
+	clk := &testClock{t: time.Unix(0, 0)}
+	m := NewIdleMonitorWithClock(IdleWarningConfig{Timeout: 10 * time.Minute, WarnBefore: time.Minute}, clk)
+
+	clk.Advance(9 * time.Minute)
+	if _, due := m.Check(); !due {
+		t.Fatal("expected a warning before activity")
+	}
+
+	m.Touch()
+	clk.Advance(5 * time.Minute)
+	if remaining, due := m.Check(); due || remaining != 5*time.Minute {
+		t.Fatalf("after activity Check() = %s, %v; want 5m0s, false", remaining, due)
+	}
+
+	clk.Advance(4 * time.Minute)
+	if _, due := m.Check(); !due {
+		t.Error("expected a new warning after the next idle period")
+	}
+}
+
+func TestIdleWarningConfigEnabled(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  IdleWarningConfig
+		want bool
+	}{
+		{name: "zero", cfg: IdleWarningConfig{}, want: false},
+		{name: "no warning", cfg: IdleWarningConfig{Timeout: time.Hour}, want: false},
+		{name: "warning longer than timeout", cfg: IdleWarningConfig{Timeout: time.Minute, WarnBefore: time.Hour}, want: false},
+		{name: "valid", cfg: IdleWarningConfig{Timeout: time.Hour, WarnBefore: time.Minute}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.Enabled(); got != tt.want {
+				t.Errorf("Enabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestActivityWriterTouchesMonitor(t *testing.T) {
+	clk := &testClock{t: time.Unix(0, 0)}
+	m := NewIdleMonitorWithClock(IdleWarningConfig{Timeout: 10 * time.Minute, WarnBefore: time.Minute}, clk)
+
+	clk.Advance(9 * time.Minute)
+	var out bytes.Buffer
+	if _, err := (activityWriter{w: &out, monitor: m}).Write([]byte("$ ")); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "$ " {
+		t.Errorf("output = %q, want passthrough", out.String())
+	}
+	if remaining, due := m.Check(); due || remaining != 10*time.Minute {
+		t.Errorf("Check() = %s, %v; want output to restart the idle period", remaining, due)
+	}
+}
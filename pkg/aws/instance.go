@@ -9,6 +9,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/johnlam90/aws-ssm/pkg/ui/fuzzy"
 )
 
 // Instance represents an EC2 instance with its metadata
@@ -21,11 +22,13 @@ type Instance struct {
 	PrivateDNS       string
 	PublicDNS        string
 	InstanceType     string
+	Platform         string // PlatformLinux or PlatformWindows
 	AvailabilityZone string
 	Tags             map[string]string
 	LaunchTime       time.Time
 	SecurityGroups   []string
 	InstanceProfile  string
+	Volumes          *VolumeSummary `json:",omitempty"` // nil unless loaded with LoadVolumeSummaries
 }
 
 // FindInstances queries EC2 instances based on various identifiers and only returns running instances.
@@ -121,6 +124,23 @@ func appendStateFilter(filters []types.Filter, states []string) []types.Filter {
 
 // ListInstances lists all EC2 instances with optional tag filters
 func (c *Client) ListInstances(ctx context.Context, tagFilters map[string]string) ([]Instance, error) {
+	return c.ListInstancesWithProgress(ctx, tagFilters, nil)
+}
+
+// ListInstancesWithProgress is ListInstances, calling progress after each page
+func (c *Client) ListInstancesWithProgress(ctx context.Context, tagFilters map[string]string, progress PageProgressFunc) ([]Instance, error) {
+	return c.describeInstancesWithProgress(ctx, runningInstanceFilters(tagFilters), progress)
+}
+
+// ListInstancesUpTo is ListInstancesWithProgress, but stops fetching pages once
+// more than limit instances were found; more reports whether any were left
+func (c *Client) ListInstancesUpTo(ctx context.Context, tagFilters map[string]string, limit int, progress PageProgressFunc) ([]Instance, bool, error) {
+	return c.describeInstancesUpTo(ctx, runningInstanceFilters(tagFilters), progress, limit)
+}
+
+// runningInstanceFilters returns the DescribeInstances filters for running
+// instances carrying every tag in tagFilters
+func runningInstanceFilters(tagFilters map[string]string) []types.Filter {
 	var filters []types.Filter
 
 	// Add tag filters if provided
@@ -136,22 +156,32 @@ func (c *Client) ListInstances(ctx context.Context, tagFilters map[string]string
 		Name:   aws.String("instance-state-name"),
 		Values: []string{"running"},
 	})
-
-	return c.describeInstances(ctx, filters)
+	return filters
 }
 
 func (c *Client) describeInstances(ctx context.Context, filters []types.Filter) ([]Instance, error) {
+	return c.describeInstancesWithProgress(ctx, filters, nil)
+}
+
+// describeInstancesWithProgress pages through DescribeInstances, reporting
+// progress after each page
+func (c *Client) describeInstancesWithProgress(ctx context.Context, filters []types.Filter, progress PageProgressFunc) ([]Instance, error) {
+	instances, _, err := c.describeInstancesUpTo(ctx, filters, progress, 0)
+	return instances, err
+}
+
+// describeInstancesUpTo is describeInstancesWithProgress, stopping after the
+// page that takes the count above a positive limit
+func (c *Client) describeInstancesUpTo(ctx context.Context, filters []types.Filter, progress PageProgressFunc, limit int) ([]Instance, bool, error) {
 	if c.describeInstancesHook != nil {
-		return c.describeInstancesHook(ctx, filters)
+		instances, err := c.describeInstancesHook(ctx, filters)
+		return instances, false, err
 	}
-	var instances []Instance
-	var nextToken *string
 
-	// Paginate through all results
-	for {
+	return FetchPagesUpTo(ctx, func(ctx context.Context, nextToken *string) ([]Instance, *string, error) {
 		// Check circuit breaker before making API call
 		if err := c.CircuitBreaker.Allow(); err != nil {
-			return nil, fmt.Errorf("circuit breaker open: %w", err)
+			return nil, nil, fmt.Errorf("circuit breaker open: %w", err)
 		}
 
 		input := &ec2.DescribeInstancesInput{
@@ -162,66 +192,65 @@ func (c *Client) describeInstances(ctx context.Context, filters []types.Filter)
 		result, err := c.EC2Client.DescribeInstances(ctx, input)
 		if err != nil {
 			c.CircuitBreaker.RecordFailure()
-			return nil, fmt.Errorf("failed to describe instances: %w", err)
+			return nil, nil, fmt.Errorf("failed to describe instances: %w", err)
 		}
 
 		// Record success
 		c.CircuitBreaker.RecordSuccess()
 
-		// Process instances from this page
+		var instances []Instance
 		for _, reservation := range result.Reservations {
 			for _, inst := range reservation.Instances {
-				instance := Instance{
-					InstanceID:       aws.ToString(inst.InstanceId),
-					State:            string(inst.State.Name),
-					PrivateIP:        aws.ToString(inst.PrivateIpAddress),
-					PublicIP:         aws.ToString(inst.PublicIpAddress),
-					PrivateDNS:       aws.ToString(inst.PrivateDnsName),
-					PublicDNS:        aws.ToString(inst.PublicDnsName),
-					InstanceType:     string(inst.InstanceType),
-					AvailabilityZone: aws.ToString(inst.Placement.AvailabilityZone),
-					Tags:             make(map[string]string),
-					LaunchTime:       aws.ToTime(inst.LaunchTime),
-					SecurityGroups:   make([]string, 0, len(inst.SecurityGroups)),
-				}
-
-				// Extract tags
-				for _, tag := range inst.Tags {
-					key := aws.ToString(tag.Key)
-					value := aws.ToString(tag.Value)
-					instance.Tags[key] = value
-					if key == "Name" {
-						instance.Name = value
-					}
-				}
-
-				// Extract security groups
-				for _, sg := range inst.SecurityGroups {
-					group := aws.ToString(sg.GroupId)
-					if group == "" {
-						group = aws.ToString(sg.GroupName)
-					}
-					if group != "" {
-						instance.SecurityGroups = append(instance.SecurityGroups, group)
-					}
-				}
-
-				if inst.IamInstanceProfile != nil {
-					instance.InstanceProfile = aws.ToString(inst.IamInstanceProfile.Arn)
-				}
-
-				instances = append(instances, instance)
+				instances = append(instances, convertEC2Instance(inst))
 			}
 		}
+		return instances, result.NextToken, nil
+	}, progress, limit)
+}
+
+// convertEC2Instance converts an EC2 API instance to an Instance
+func convertEC2Instance(inst types.Instance) Instance {
+	instance := Instance{
+		InstanceID:       aws.ToString(inst.InstanceId),
+		State:            string(inst.State.Name),
+		PrivateIP:        aws.ToString(inst.PrivateIpAddress),
+		PublicIP:         aws.ToString(inst.PublicIpAddress),
+		PrivateDNS:       aws.ToString(inst.PrivateDnsName),
+		PublicDNS:        aws.ToString(inst.PublicDnsName),
+		InstanceType:     string(inst.InstanceType),
+		Platform:         instancePlatform(inst),
+		AvailabilityZone: aws.ToString(inst.Placement.AvailabilityZone),
+		Tags:             make(map[string]string),
+		LaunchTime:       aws.ToTime(inst.LaunchTime),
+		SecurityGroups:   make([]string, 0, len(inst.SecurityGroups)),
+	}
+
+	// Extract tags
+	for _, tag := range inst.Tags {
+		key := aws.ToString(tag.Key)
+		value := aws.ToString(tag.Value)
+		instance.Tags[key] = value
+		if key == "Name" {
+			instance.Name = value
+		}
+	}
 
-		// Check if there are more pages
-		if result.NextToken == nil {
-			break
+	// Extract security groups
+	for _, sg := range inst.SecurityGroups {
+		group := aws.ToString(sg.GroupId)
+		if group == "" {
+			group = aws.ToString(sg.GroupName)
 		}
-		nextToken = result.NextToken
+		if group != "" {
+			instance.SecurityGroups = append(instance.SecurityGroups, group)
+		}
+	}
+
+	if inst.IamInstanceProfile != nil {
+		instance.InstanceProfile = aws.ToString(inst.IamInstanceProfile.Arn)
 	}
 
-	return instances, nil
+	return instance
 }
 
 // ResolveSingleInstance finds a single instance by identifier and validates it's running
@@ -265,16 +294,20 @@ func (e *MultipleInstancesError) Error() string {
 	return fmt.Sprintf("multiple instances found matching '%s'", e.Identifier)
 }
 
+// Table renders the matching instances with the disambiguation columns of
+// the interactive selector
+func (e *MultipleInstancesError) Table() fuzzy.Table {
+	return fuzzy.MultipleMatchColumns().Render(toFuzzyInstances(e.Instances))
+}
+
 // FormatInstanceList returns a formatted string listing all matching instances
 func (e *MultipleInstancesError) FormatInstanceList() string {
 	var b strings.Builder
 	fmt.Fprintf(&b, "Found %d instances matching '%s':\n\n", len(e.Instances), e.Identifier)
-	for i, inst := range e.Instances {
-		name := inst.Name
-		if name == "" {
-			name = "(no name)"
-		}
-		fmt.Fprintf(&b, "%d. %s - %s [%s] - %s\n", i+1, inst.InstanceID, name, inst.State, inst.PrivateIP)
+	table := e.Table()
+	fmt.Fprintf(&b, "    %s\n", table.Header)
+	for i, row := range table.Rows {
+		fmt.Fprintf(&b, "%2d. %s\n", i+1, row)
 	}
 	if e.AllowInteractive {
 		b.WriteString("\nOpening interactive selector... (Esc to cancel)\n")
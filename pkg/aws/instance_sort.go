@@ -0,0 +1,121 @@
+package aws
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// InstanceSortField names a field instances can be sorted by
+type InstanceSortField string
+
+// Supported instance sort fields
+const (
+	SortFieldName       InstanceSortField = "name"
+	SortFieldID         InstanceSortField = "id"
+	SortFieldState      InstanceSortField = "state"
+	SortFieldType       InstanceSortField = "type"
+	SortFieldLaunchTime InstanceSortField = "launch-time"
+)
+
+var instanceSortFields = []InstanceSortField{SortFieldName, SortFieldID, SortFieldState, SortFieldType, SortFieldLaunchTime}
+
+// InstanceSort orders instances by a field; the zero value keeps the API order
+type InstanceSort struct {
+	Field      InstanceSortField
+	Descending bool
+}
+
+// NewestFirst sorts instances by launch time, most recent first
+var NewestFirst = InstanceSort{Field: SortFieldLaunchTime, Descending: true}
+
+// ParseInstanceSort parses a "field[:asc|desc]" spec such as "launch-time:desc".
+// An empty spec yields the zero InstanceSort.
+func ParseInstanceSort(spec string) (InstanceSort, error) {
+	spec = strings.ToLower(strings.TrimSpace(spec))
+	if spec == "" {
+		return InstanceSort{}, nil
+	}
+
+	field, direction, _ := strings.Cut(spec, ":")
+	s := InstanceSort{Field: InstanceSortField(strings.TrimSpace(field))}
+	valid := false
+	for _, f := range instanceSortFields {
+		if s.Field == f {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return InstanceSort{}, fmt.Errorf("unknown sort field %q (expected one of %s)", field, joinSortFields())
+	}
+
+	switch strings.TrimSpace(direction) {
+	case "", "asc":
+	case "desc":
+		s.Descending = true
+	default:
+		return InstanceSort{}, fmt.Errorf("unknown sort direction %q (expected asc or desc)", direction)
+	}
+	return s, nil
+}
+
+// IsZero reports whether s keeps the API order
+func (s InstanceSort) IsZero() bool {
+	return s.Field == ""
+}
+
+// String returns the spec form of s, e.g. "launch-time:desc"
+func (s InstanceSort) String() string {
+	if s.IsZero() {
+		return ""
+	}
+	if s.Descending {
+		return string(s.Field) + ":desc"
+	}
+	return string(s.Field) + ":asc"
+}
+
+// Less reports whether a sorts before b. Ties fall back to the instance ID so
+// the order is stable across refreshes.
+func (s InstanceSort) Less(a, b Instance) bool {
+	var cmp int
+	switch s.Field {
+	case SortFieldName:
+		cmp = strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	case SortFieldState:
+		cmp = strings.Compare(a.State, b.State)
+	case SortFieldType:
+		cmp = strings.Compare(a.InstanceType, b.InstanceType)
+	case SortFieldLaunchTime:
+		cmp = a.LaunchTime.Compare(b.LaunchTime)
+	}
+	if s.Descending {
+		cmp = -cmp
+	}
+	if cmp != 0 {
+		return cmp < 0
+	}
+	if s.Descending && s.Field == SortFieldID {
+		return a.InstanceID > b.InstanceID
+	}
+	return a.InstanceID < b.InstanceID
+}
+
+// SortInstances sorts instances in place; the zero InstanceSort leaves them untouched
+func SortInstances(instances []Instance, s InstanceSort) {
+	if s.IsZero() {
+		return
+	}
+	sort.SliceStable(instances, func(i, j int) bool {
+		return s.Less(instances[i], instances[j])
+	})
+}
+
+func joinSortFields() string {
+	names := make([]string, len(instanceSortFields))
+	for i, f := range instanceSortFields {
+		names[i] = string(f)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,83 @@
+package aws
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseInstanceSort(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    InstanceSort
+		wantErr bool
+	}{
+		{spec: "", want: InstanceSort{}},
+		{spec: "name", want: InstanceSort{Field: SortFieldName}},
+		{spec: "launch-time:desc", want: NewestFirst},
+		{spec: " Launch-Time:ASC ", want: InstanceSort{Field: SortFieldLaunchTime}},
+		{spec: "id:desc", want: InstanceSort{Field: SortFieldID, Descending: true}},
+		{spec: "uptime", wantErr: true},
+		{spec: "name:sideways", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseInstanceSort(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseInstanceSort(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseInstanceSort(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortInstancesByLaunchTime(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	instances := func() []Instance {
+		return []Instance{
+			{InstanceID: "i-b", LaunchTime: base.Add(time.Hour)},
+			{InstanceID: "i-c", LaunchTime: base.Add(2 * time.Hour)},
+			{InstanceID: "i-a", LaunchTime: base},
+			{InstanceID: "i-d", LaunchTime: base.Add(time.Hour)},
+		}
+	}
+	ids := func(list []Instance) string {
+		parts := make([]string, len(list))
+		for i, inst := range list {
+			parts[i] = inst.InstanceID
+		}
+		return strings.Join(parts, ",")
+	}
+
+	tests := []struct {
+		name string
+		sort InstanceSort
+		want string
+	}{
+		{name: "oldest first", sort: InstanceSort{Field: SortFieldLaunchTime}, want: "i-a,i-b,i-d,i-c"},
+		{name: "newest first", sort: NewestFirst, want: "i-c,i-b,i-d,i-a"},
+		{name: "zero keeps order", sort: InstanceSort{}, want: "i-b,i-c,i-a,i-d"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := instances()
+			SortInstances(list, tt.sort)
+			if got := ids(list); got != tt.want {
+				t.Errorf("order = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstanceSortLessByName(t *testing.T) {
+	a := Instance{InstanceID: "i-1", Name: "alpha"}
+	b := Instance{InstanceID: "i-2", Name: "Bravo"}
+	if !(InstanceSort{Field: SortFieldName}).Less(a, b) {
+		t.Error("expected alpha before Bravo ascending")
+	}
+	if !(InstanceSort{Field: SortFieldName, Descending: true}).Less(b, a) {
+		t.Error("expected Bravo before alpha descending")
+	}
+}
@@ -152,6 +152,7 @@ func (is *InstanceStream) processReservations(reservations []types.Reservation,
 				PrivateDNS:       aws.ToString(inst.PrivateDnsName),
 				PublicDNS:        aws.ToString(inst.PublicDnsName),
 				InstanceType:     string(inst.InstanceType),
+				Platform:         instancePlatform(inst),
 				AvailabilityZone: aws.ToString(inst.Placement.AvailabilityZone),
 				Tags:             make(map[string]string),
 			}
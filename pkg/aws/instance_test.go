@@ -1,7 +1,9 @@
 package aws
 
 import (
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestMultipleInstancesErrorFormatting(t *testing.T) {
@@ -186,3 +188,39 @@ func contains(s, substr string) bool {
 	}
 	return false
 }
+
+func TestMultipleInstancesErrorTable(t *testing.T) {
+	launched := time.Date(2026, 3, 4, 5, 6, 0, 0, time.UTC)
+	err := &MultipleInstancesError{
+		Identifier: "web",
+		Instances: []Instance{
+			{InstanceID: "i-0aaaaaaaaaaaaaaa1", Name: "web-1", State: "running", InstanceType: "t3.micro", PrivateIP: "10.0.1.10", AvailabilityZone: "us-east-1a", LaunchTime: launched},
+			{InstanceID: "i-0bbbbbbbbbbbbbbb2", State: "stopped"},
+		},
+	}
+
+	table := err.Table()
+	if len(table.Rows) != 2 {
+		t.Fatalf("rows = %d, want 2", len(table.Rows))
+	}
+
+	tests := []struct {
+		row  string
+		want []string
+	}{
+		{table.Header, []string{"INSTANCE ID", "NAME", "STATE", "TYPE", "PRIVATE IP", "AZ", "LAUNCHED"}},
+		{table.Rows[0], []string{"i-0aaaaaaaaaaaaaaa1", "web-1", "running", "t3.micro", "10.0.1.10", "us-east-1a", "2026-03-04 05:06"}},
+		{table.Rows[1], []string{"i-0bbbbbbbbbbbbbbb2", "(no name)", "stopped", "-", "-", "-", "-"}},
+	}
+	for _, tt := range tests {
+		cells := strings.Split(tt.row, " | ")
+		if len(cells) != len(tt.want) {
+			t.Fatalf("row %q has %d cells, want %d", tt.row, len(cells), len(tt.want))
+		}
+		for i, want := range tt.want {
+			if got := strings.TrimSpace(cells[i]); got != want {
+				t.Errorf("row %q cell %d = %q, want %q", tt.row, i, got, want)
+			}
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
@@ -16,9 +17,12 @@ type LaunchTemplateVersion struct {
 	VersionNumber      int64
 	VersionDescription string
 	CreateTime         string
+	CreatedAt          time.Time
 	CreatedBy          string
 	DefaultVersion     bool
 	IsLatest           bool
+	ImageID            string
+	InstanceType       string
 }
 
 // GetLaunchTemplateID returns the launch template ID
@@ -93,6 +97,7 @@ func listLaunchTemplateVersions(ctx context.Context, api EC2LaunchTemplateAPI, l
 		}
 	}
 
+	MarkLatestVersion(versions)
 	return versions, nil
 }
 
@@ -145,6 +150,7 @@ func convertLaunchTemplateVersion(v ec2types.LaunchTemplateVersion) LaunchTempla
 	}
 	if v.CreateTime != nil {
 		version.CreateTime = v.CreateTime.String()
+		version.CreatedAt = *v.CreateTime
 	}
 	if v.CreatedBy != nil {
 		version.CreatedBy = *v.CreatedBy
@@ -152,10 +158,30 @@ func convertLaunchTemplateVersion(v ec2types.LaunchTemplateVersion) LaunchTempla
 	if v.DefaultVersion != nil {
 		version.DefaultVersion = *v.DefaultVersion
 	}
+	if data := v.LaunchTemplateData; data != nil {
+		if data.ImageId != nil {
+			version.ImageID = *data.ImageId
+		}
+		version.InstanceType = string(data.InstanceType)
+	}
 
 	return version
 }
 
+// MarkLatestVersion sets IsLatest on the version with the highest version number
+func MarkLatestVersion(versions []LaunchTemplateVersion) {
+	latest := -1
+	for i := range versions {
+		versions[i].IsLatest = false
+		if latest == -1 || versions[i].VersionNumber > versions[latest].VersionNumber {
+			latest = i
+		}
+	}
+	if latest >= 0 {
+		versions[latest].IsLatest = true
+	}
+}
+
 // FormatVersionForDisplay formats a version number for display
 func FormatVersionForDisplay(versionNumber int64, isDefault bool) string {
 	versionStr := strconv.FormatInt(versionNumber, 10)
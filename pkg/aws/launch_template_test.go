@@ -174,3 +174,54 @@ func TestConvertLaunchTemplateVersion(t *testing.T) {
 		t.Error("DefaultVersion mismatch")
 	}
 }
+
+func TestConvertLaunchTemplateVersionData(t *testing.T) {
+	v := convertLaunchTemplateVersion(ec2types.LaunchTemplateVersion{
+		VersionNumber: aws.Int64(3),
+		LaunchTemplateData: &ec2types.ResponseLaunchTemplateData{
+			ImageId:      aws.String("ami-0abc"),
+			InstanceType: ec2types.InstanceTypeM5Large,
+		},
+	})
+
+	if v.ImageID != "ami-0abc" {
+		t.Errorf("ImageID = %q, want ami-0abc", v.ImageID)
+	}
+	if v.InstanceType != "m5.large" {
+		t.Errorf("InstanceType = %q, want m5.large", v.InstanceType)
+	}
+}
+
+func TestMarkLatestVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		numbers    []int64
+		wantLatest int64
+	}{
+		{name: "ascending", numbers: []int64{1, 2, 3}, wantLatest: 3},
+		{name: "descending", numbers: []int64{5, 4, 1}, wantLatest: 5},
+		{name: "unordered", numbers: []int64{2, 7, 3}, wantLatest: 7},
+		{name: "single", numbers: []int64{1}, wantLatest: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			versions := make([]LaunchTemplateVersion, 0, len(tt.numbers))
+			for _, n := range tt.numbers {
+				// Stale flags must be cleared
+				versions = append(versions, LaunchTemplateVersion{VersionNumber: n, IsLatest: true})
+			}
+
+			MarkLatestVersion(versions)
+
+			for _, v := range versions {
+				if want := v.VersionNumber == tt.wantLatest; v.IsLatest != want {
+					t.Errorf("version %d IsLatest = %v, want %v", v.VersionNumber, v.IsLatest, want)
+				}
+			}
+		})
+	}
+
+	// Empty input must not panic
+	MarkLatestVersion(nil)
+}
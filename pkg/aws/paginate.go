@@ -0,0 +1,47 @@
+package aws
+
+import "context"
+
+// PageProgressFunc is called after each fetched page with the page number
+// (starting at 1) and the number of items fetched so far
+type PageProgressFunc func(page, items int)
+
+// PageFetchFunc fetches the page at token, returning its items and the token
+// of the next page, or nil after the last page
+type PageFetchFunc[T any] func(ctx context.Context, token *string) ([]T, *string, error)
+
+// FetchPages fetches every page in order and returns all items. progress may be
+// nil. An error stops fetching and discards the items fetched so far.
+func FetchPages[T any](ctx context.Context, fetch PageFetchFunc[T], progress PageProgressFunc) ([]T, error) {
+	items, _, err := FetchPagesUpTo(ctx, fetch, progress, 0)
+	return items, err
+}
+
+// FetchPagesUpTo is FetchPages, but stops after the page that takes the item
+// count above limit; more reports whether pages were left unfetched. A
+// non-positive limit fetches every page.
+func FetchPagesUpTo[T any](ctx context.Context, fetch PageFetchFunc[T], progress PageProgressFunc, limit int) (items []T, more bool, err error) {
+	var token *string
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, false, err
+		}
+
+		pageItems, next, err := fetch(ctx, token)
+		if err != nil {
+			return nil, false, err
+		}
+		items = append(items, pageItems...)
+		if progress != nil {
+			progress(page, len(items))
+		}
+
+		if next == nil || *next == "" {
+			return items, false, nil
+		}
+		if limit > 0 && len(items) > limit {
+			return items, true, nil
+		}
+		token = next
+	}
+}
@@ -0,0 +1,146 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// pagedFetch serves pages in order; each token is the index of its page
+func pagedFetch(pages [][]string, calls *[]string) PageFetchFunc[string] {
+	return func(_ context.Context, token *string) ([]string, *string, error) {
+		*calls = append(*calls, aws.ToString(token))
+		index, _ := strconv.Atoi(aws.ToString(token))
+		var next *string
+		if index+1 < len(pages) {
+			next = aws.String(strconv.Itoa(index + 1))
+		}
+		return pages[index], next, nil
+	}
+}
+
+func TestFetchPagesReportsCumulativeProgress(t *testing.T) {
+	pages := [][]string{{"i-1", "i-2"}, {"i-3"}, {}, {"i-4", "i-5", "i-6"}}
+
+	tests := []struct {
+		name         string
+		pages        [][]string
+		wantItems    []string
+		wantProgress [][2]int
+		wantTokens   []string
+	}{
+		{
+			name:         "several pages",
+			pages:        pages,
+			wantItems:    []string{"i-1", "i-2", "i-3", "i-4", "i-5", "i-6"},
+			wantProgress: [][2]int{{1, 2}, {2, 3}, {3, 3}, {4, 6}},
+			wantTokens:   []string{"", "1", "2", "3"},
+		},
+		{
+			name:         "single page",
+			pages:        [][]string{{"i-1"}},
+			wantItems:    []string{"i-1"},
+			wantProgress: [][2]int{{1, 1}},
+			wantTokens:   []string{""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			var progress [][2]int
+			items, err := FetchPages(context.Background(), pagedFetch(tt.pages, &calls), func(page, items int) {
+				progress = append(progress, [2]int{page, items})
+			})
+			if err != nil {
+				t.Fatalf("FetchPages() error = %v", err)
+			}
+			if !reflect.DeepEqual(items, tt.wantItems) {
+				t.Errorf("items = %v, want %v", items, tt.wantItems)
+			}
+			if !reflect.DeepEqual(progress, tt.wantProgress) {
+				t.Errorf("progress = %v, want %v", progress, tt.wantProgress)
+			}
+			if !reflect.DeepEqual(calls, tt.wantTokens) {
+				t.Errorf("tokens = %q, want %q", calls, tt.wantTokens)
+			}
+		})
+	}
+}
+
+func TestFetchPagesWithoutProgress(t *testing.T) {
+	var calls []string
+	items, err := FetchPages(context.Background(), pagedFetch([][]string{{"a"}, {"b"}}, &calls), nil)
+	if err != nil || len(items) != 2 {
+		t.Fatalf("FetchPages() = %v, %v; want both items", items, err)
+	}
+}
+
+func TestFetchPagesUpTo(t *testing.T) {
+	pages := [][]string{{"i-1", "i-2"}, {"i-3", "i-4"}, {"i-5"}}
+
+	tests := []struct {
+		name       string
+		limit      int
+		wantItems  int
+		wantMore   bool
+		wantTokens []string
+	}{
+		{name: "stops after the page that passes the limit", limit: 3, wantItems: 4, wantMore: true, wantTokens: []string{"", "1"}},
+		{name: "limit reached on the last page", limit: 4, wantItems: 5, wantTokens: []string{"", "1", "2"}},
+		{name: "no limit", limit: 0, wantItems: 5, wantTokens: []string{"", "1", "2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			items, more, err := FetchPagesUpTo(context.Background(), pagedFetch(pages, &calls), nil, tt.limit)
+			if err != nil {
+				t.Fatalf("FetchPagesUpTo() error = %v", err)
+			}
+			if len(items) != tt.wantItems || more != tt.wantMore {
+				t.Errorf("FetchPagesUpTo() = %d items, more %v; want %d, %v", len(items), more, tt.wantItems, tt.wantMore)
+			}
+			if !reflect.DeepEqual(calls, tt.wantTokens) {
+				t.Errorf("tokens = %q, want %q", calls, tt.wantTokens)
+			}
+		})
+	}
+}
+
+func TestFetchPagesStopsOnError(t *testing.T) {
+	errThrottled := errors.New("throttled")
+	page := 0
+	fetch := func(_ context.Context, _ *string) ([]int, *string, error) {
+		page++
+		if page == 2 {
+			return nil, nil, errThrottled
+		}
+		return []int{page}, aws.String("next"), nil
+	}
+
+	var reported int
+	items, err := FetchPages(context.Background(), fetch, func(_, _ int) { reported++ })
+	if !errors.Is(err, errThrottled) || items != nil {
+		t.Fatalf("FetchPages() = %v, %v; want the page error and no items", items, err)
+	}
+	if reported != 1 {
+		t.Errorf("progress called %d times, want 1", reported)
+	}
+}
+
+func TestFetchPagesHonoursCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	fetch := func(_ context.Context, _ *string) ([]int, *string, error) {
+		cancel()
+		return []int{1}, aws.String("next"), nil
+	}
+
+	if _, err := FetchPages(ctx, fetch, nil); !errors.Is(err, context.Canceled) {
+		t.Fatalf("FetchPages() error = %v, want context.Canceled", err)
+	}
+}
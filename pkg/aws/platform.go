@@ -0,0 +1,49 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// Instance platforms as reported in Instance.Platform
+const (
+	PlatformLinux   = "linux"
+	PlatformWindows = "windows"
+)
+
+// instancePlatform returns the platform of an EC2 instance. EC2 only sets
+// Platform for Windows, so everything else is treated as Linux.
+func instancePlatform(inst types.Instance) string {
+	if inst.Platform == types.PlatformValuesWindows {
+		return PlatformWindows
+	}
+	return PlatformLinux
+}
+
+// ParsePlatform validates a --platform value; an empty value matches every platform
+func ParsePlatform(value string) (string, error) {
+	platform := strings.ToLower(strings.TrimSpace(value))
+	switch platform {
+	case "", PlatformLinux, PlatformWindows:
+		return platform, nil
+	default:
+		return "", fmt.Errorf("unknown platform %q (supported: %s, %s)", value, PlatformLinux, PlatformWindows)
+	}
+}
+
+// FilterInstancesByPlatform returns the instances on platform, preserving order.
+// An empty platform returns all instances.
+func FilterInstancesByPlatform(instances []Instance, platform string) []Instance {
+	if platform == "" {
+		return instances
+	}
+	filtered := make([]Instance, 0, len(instances))
+	for _, inst := range instances {
+		if inst.Platform == platform {
+			filtered = append(filtered, inst)
+		}
+	}
+	return filtered
+}
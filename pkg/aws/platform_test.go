@@ -0,0 +1,83 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func TestInstancePlatform(t *testing.T) {
+	tests := []struct {
+		name string
+		inst types.Instance
+		want string
+	}{
+		{name: "windows", inst: types.Instance{Platform: types.PlatformValuesWindows}, want: PlatformWindows},
+		{name: "unset is linux", inst: types.Instance{}, want: PlatformLinux},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := instancePlatform(tt.inst); got != tt.want {
+				t.Errorf("instancePlatform() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePlatform(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "", want: ""},
+		{value: "linux", want: PlatformLinux},
+		{value: " Windows ", want: PlatformWindows},
+		{value: "macos", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParsePlatform(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePlatform(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePlatform(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterInstancesByPlatform(t *testing.T) {
+	instances := []Instance{
+		{InstanceID: "i-win1", Platform: PlatformWindows, Volumes: &VolumeSummary{Count: 1, LargestSizeGiB: 30, UnencryptedCount: 1}},
+		{InstanceID: "i-lnx1", Platform: PlatformLinux, Volumes: &VolumeSummary{Count: 1, LargestSizeGiB: 8, UnencryptedCount: 1}},
+		{InstanceID: "i-win2", Platform: PlatformWindows, Volumes: &VolumeSummary{Count: 1, LargestSizeGiB: 50}},
+	}
+
+	tests := []struct {
+		name     string
+		platform string
+		volumes  VolumeFilter
+		want     []string
+	}{
+		{name: "no filter", want: []string{"i-win1", "i-lnx1", "i-win2"}},
+		{name: "windows", platform: PlatformWindows, want: []string{"i-win1", "i-win2"}},
+		{name: "linux", platform: PlatformLinux, want: []string{"i-lnx1"}},
+		{name: "windows with unencrypted volumes", platform: PlatformWindows, volumes: VolumeFilter{UnencryptedVolumes: true}, want: []string{"i-win1"}},
+		{name: "linux with large volumes", platform: PlatformLinux, volumes: VolumeFilter{MinVolumeSizeGiB: 20}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FilterInstancesByPlatform(FilterInstancesByVolumes(instances, tt.volumes), tt.platform)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d instances, want %v", len(got), tt.want)
+			}
+			for i, inst := range got {
+				if inst.InstanceID != tt.want[i] {
+					t.Errorf("instance %d = %s, want %s", i, inst.InstanceID, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package aws
+
+import "fmt"
+
+// ScalingStepGuard limits how far a single scaling operation may move desired capacity.
+// A zero limit disables that check.
+type ScalingStepGuard struct {
+	MaxStep        int32 // Maximum absolute change in desired capacity
+	MaxStepPercent int32 // Maximum change as a percentage of current desired capacity
+}
+
+// ScalingStepError reports a scaling change that exceeds the configured step guard
+type ScalingStepError struct {
+	Current int32
+	Desired int32
+	Delta   int32
+	Reason  string
+}
+
+func (e *ScalingStepError) Error() string {
+	return fmt.Sprintf("desired capacity change %d -> %d (delta %d) exceeds %s", e.Current, e.Desired, e.Delta, e.Reason)
+}
+
+// CapacityDelta returns the absolute difference between current and desired capacity
+func CapacityDelta(current, desired int32) int32 {
+	if desired > current {
+		return desired - current
+	}
+	return current - desired
+}
+
+// CapacityDeltaPercent returns the change as a percentage of current capacity.
+// The second result is false when current capacity is zero and the percentage is undefined.
+func CapacityDeltaPercent(current, desired int32) (float64, bool) {
+	if current <= 0 {
+		return 0, false
+	}
+	return float64(CapacityDelta(current, desired)) / float64(current) * 100, true
+}
+
+// Check returns a *ScalingStepError when moving from current to desired exceeds the guard
+func (g ScalingStepGuard) Check(current, desired int32) error {
+	delta := CapacityDelta(current, desired)
+	if delta == 0 {
+		return nil
+	}
+
+	if g.MaxStep > 0 && delta > g.MaxStep {
+		return &ScalingStepError{
+			Current: current,
+			Desired: desired,
+			Delta:   delta,
+			Reason:  fmt.Sprintf("max step of %d", g.MaxStep),
+		}
+	}
+
+	// Percent limits only apply when there is a non-zero baseline
+	if percent, ok := CapacityDeltaPercent(current, desired); ok && g.MaxStepPercent > 0 && percent > float64(g.MaxStepPercent) {
+		return &ScalingStepError{
+			Current: current,
+			Desired: desired,
+			Delta:   delta,
+			Reason:  fmt.Sprintf("max step of %d%% (%.0f%%)", g.MaxStepPercent, percent),
+		}
+	}
+
+	return nil
+}
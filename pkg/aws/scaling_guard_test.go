@@ -0,0 +1,68 @@
+package aws
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCapacityDelta(t *testing.T) {
+	tests := []struct {
+		current, desired int32
+		want             int32
+		wantPercent      float64
+		wantPercentOK    bool
+	}{
+		{current: 2, desired: 200, want: 198, wantPercent: 9900, wantPercentOK: true},
+		{current: 10, desired: 5, want: 5, wantPercent: 50, wantPercentOK: true},
+		{current: 4, desired: 4, want: 0, wantPercent: 0, wantPercentOK: true},
+		{current: 0, desired: 3, want: 3, wantPercentOK: false},
+	}
+
+	for _, tt := range tests {
+		if got := CapacityDelta(tt.current, tt.desired); got != tt.want {
+			t.Errorf("CapacityDelta(%d, %d) = %d, want %d", tt.current, tt.desired, got, tt.want)
+		}
+		percent, ok := CapacityDeltaPercent(tt.current, tt.desired)
+		if ok != tt.wantPercentOK || percent != tt.wantPercent {
+			t.Errorf("CapacityDeltaPercent(%d, %d) = (%v, %v), want (%v, %v)", tt.current, tt.desired, percent, ok, tt.wantPercent, tt.wantPercentOK)
+		}
+	}
+}
+
+func TestScalingStepGuardCheck(t *testing.T) {
+	tests := []struct {
+		name             string
+		guard            ScalingStepGuard
+		current, desired int32
+		wantBlocked      bool
+	}{
+		{name: "huge jump blocked by absolute limit", guard: ScalingStepGuard{MaxStep: 50}, current: 2, desired: 200, wantBlocked: true},
+		{name: "small change allowed", guard: ScalingStepGuard{MaxStep: 50}, current: 2, desired: 10},
+		{name: "change equal to limit allowed", guard: ScalingStepGuard{MaxStep: 50}, current: 10, desired: 60},
+		{name: "scale down beyond limit blocked", guard: ScalingStepGuard{MaxStep: 50}, current: 100, desired: 0, wantBlocked: true},
+		{name: "percent limit blocks doubling", guard: ScalingStepGuard{MaxStepPercent: 50}, current: 10, desired: 20, wantBlocked: true},
+		{name: "percent limit allows small change", guard: ScalingStepGuard{MaxStepPercent: 50}, current: 10, desired: 14},
+		{name: "percent limit ignored from zero", guard: ScalingStepGuard{MaxStepPercent: 50}, current: 0, desired: 5},
+		{name: "absolute still applies from zero", guard: ScalingStepGuard{MaxStep: 3, MaxStepPercent: 50}, current: 0, desired: 5, wantBlocked: true},
+		{name: "disabled guard allows anything", guard: ScalingStepGuard{}, current: 2, desired: 2000},
+		{name: "no change always allowed", guard: ScalingStepGuard{MaxStep: 1, MaxStepPercent: 1}, current: 7, desired: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.guard.Check(tt.current, tt.desired)
+			if blocked := err != nil; blocked != tt.wantBlocked {
+				t.Fatalf("Check(%d, %d) error = %v, wantBlocked %v", tt.current, tt.desired, err, tt.wantBlocked)
+			}
+			if err != nil {
+				var stepErr *ScalingStepError
+				if !errors.As(err, &stepErr) {
+					t.Fatalf("error %T is not *ScalingStepError", err)
+				}
+				if stepErr.Delta != CapacityDelta(tt.current, tt.desired) {
+					t.Errorf("Delta = %d, want %d", stepErr.Delta, CapacityDelta(tt.current, tt.desired))
+				}
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package aws
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -26,8 +27,30 @@ var (
 	execLookPath = exec.LookPath
 )
 
+// interactiveCommandDocument is the SSM document that runs a command in an interactive session
+const interactiveCommandDocument = "AWS-StartInteractiveCommand"
+
 // StartSession initiates an SSM session with the specified instance
 func (c *Client) StartSession(ctx context.Context, instanceID string) error {
+	return c.StartSessionWithCommand(ctx, instanceID, "")
+}
+
+// SessionOptions customizes a plugin-based session
+type SessionOptions struct {
+	Command      string              // Initial command, run via the interactive command document
+	DocumentName string              // Custom Session-type document; takes precedence over Command
+	Parameters   map[string][]string // Parameters for DocumentName
+}
+
+// StartSessionWithCommand initiates an interactive SSM session that runs command
+// as soon as it starts. An empty command starts a regular shell session.
+// Requires the session-manager-plugin.
+func (c *Client) StartSessionWithCommand(ctx context.Context, instanceID, command string) error {
+	return c.StartSessionWithOptions(ctx, instanceID, SessionOptions{Command: command})
+}
+
+// StartSessionWithOptions initiates an SSM session using the session-manager-plugin
+func (c *Client) StartSessionWithOptions(ctx context.Context, instanceID string, opts SessionOptions) error {
 	// Check if session-manager-plugin is installed
 	if err := checkSessionManagerPlugin(); err != nil {
 		return err
@@ -46,14 +69,33 @@ func (c *Client) StartSession(ctx context.Context, instanceID string) error {
 		api = ssm.NewFromConfig(c.Config)
 	}
 
-	return startSession(ctx, api, c.Config.Region, instanceID, c.CircuitBreaker)
+	return startSessionWithOptions(ctx, api, c.Config.Region, instanceID, opts, c.CircuitBreaker)
 }
 
 func startSession(ctx context.Context, api SSMAPI, region, instanceID string, cb *CircuitBreaker) error {
-	// Start SSM session
+	return startSessionWithOptions(ctx, api, region, instanceID, SessionOptions{}, cb)
+}
+
+// buildStartSessionInput builds the StartSession request, using a custom document
+// or the interactive command document when an initial command is given
+func buildStartSessionInput(instanceID string, opts SessionOptions) *ssm.StartSessionInput {
 	input := &ssm.StartSessionInput{
 		Target: aws.String(instanceID),
 	}
+	switch {
+	case opts.DocumentName != "":
+		input.DocumentName = aws.String(opts.DocumentName)
+		input.Parameters = opts.Parameters
+	case opts.Command != "":
+		input.DocumentName = aws.String(interactiveCommandDocument)
+		input.Parameters = map[string][]string{"command": {opts.Command}}
+	}
+	return input
+}
+
+func startSessionWithOptions(ctx context.Context, api SSMAPI, region, instanceID string, opts SessionOptions, cb *CircuitBreaker) error {
+	// Start SSM session
+	input := buildStartSessionInput(instanceID, opts)
 
 	result, err := api.StartSession(ctx, input)
 	if err != nil {
@@ -72,7 +114,7 @@ func startSession(ctx context.Context, api SSMAPI, region, instanceID string, cb
 		terminateInput := &ssm.TerminateSessionInput{
 			SessionId: aws.String(sessionID),
 		}
-		if _, terminateErr := api.TerminateSession(ctx, terminateInput); terminateErr != nil {
+		if _, terminateErr := api.TerminateSession(context.WithoutCancel(ctx), terminateInput); terminateErr != nil {
 			// Log but don't fail - session might already be terminated
 			fmt.Printf("Warning: failed to terminate session: %v\n", terminateErr)
 		}
@@ -100,6 +142,12 @@ func startSession(ctx context.Context, api SSMAPI, region, instanceID string, cb
 	params := map[string]interface{}{
 		"Target": instanceID,
 	}
+	if input.DocumentName != nil {
+		params["DocumentName"] = aws.ToString(input.DocumentName)
+		if input.Parameters != nil {
+			params["Parameters"] = input.Parameters
+		}
+	}
 	paramsJSON, err := json.Marshal(params)
 	if err != nil {
 		return fmt.Errorf("failed to marshal parameters: %w", err)
@@ -134,24 +182,68 @@ func startSession(ctx context.Context, api SSMAPI, region, instanceID string, cb
 		}
 	}()
 
+	// Terminating the session makes the plugin exit once the stream closes
+	stopWatch := terminateOnCancel(ctx, api, sessionID)
+	defer stopWatch()
+
 	fmt.Printf("Starting session with instance %s...\n", instanceID)
-	if runErr := cmd.Run(); runErr != nil {
+	runErr := cmd.Run()
+	if cause := sessionCancelCause(ctx); cause != nil {
+		return fmt.Errorf("session ended: %w", cause)
+	}
+	if runErr != nil {
 		return fmt.Errorf("session-manager-plugin failed: %w", runErr)
 	}
 
 	return nil
 }
 
+// sessionCancelCause returns why ctx was cancelled when it carries a cause,
+// such as the session timeout. A plain cancellation, as from an interrupt the
+// session handles itself, returns nil.
+func sessionCancelCause(ctx context.Context) error {
+	if cause := context.Cause(ctx); cause != nil && !errors.Is(cause, context.Canceled) {
+		return cause
+	}
+	return nil
+}
+
+// terminateOnCancel terminates sessionID if ctx is cancelled with a cause
+// before the returned func is called
+func terminateOnCancel(ctx context.Context, api SSMAPI, sessionID string) func() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		select {
+		case <-stop:
+		case <-ctx.Done():
+			if sessionCancelCause(ctx) != nil {
+				_ = terminateSessionSilently(context.WithoutCancel(ctx), api, sessionID)
+			}
+		}
+	}()
+	return func() {
+		close(stop)
+		<-done
+	}
+}
+
+// SessionManagerPluginInstalled reports whether the session-manager-plugin is in PATH
+func SessionManagerPluginInstalled() bool {
+	return checkSessionManagerPlugin() == nil
+}
+
 // checkSessionManagerPlugin verifies that the session-manager-plugin is installed
 func checkSessionManagerPlugin() error {
 	_, err := execLookPath("session-manager-plugin")
 	if err != nil {
 		return fmt.Errorf("session-manager-plugin not found in PATH\n\n" +
-			"The session-manager-plugin is required for plugin-based mode (--native=false).\n" +
-			"By default, aws-ssm uses native mode which does NOT require the plugin.\n\n" +
+			"The session-manager-plugin is required for plugin-based mode (--plugin).\n" +
+			"Without the plugin, aws-ssm uses native mode, which does NOT require it.\n\n" +
 			"To install the plugin, see:\n" +
 			"https://docs.aws.amazon.com/systems-manager/latest/userguide/session-manager-working-with-install-plugin.html\n\n" +
-			"Or use native mode (default): aws-ssm session <instance>")
+			"Or use native mode: aws-ssm session <instance> --native")
 	}
 	return nil
 }
@@ -3,10 +3,14 @@ package aws
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"time"
 
 	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 	"github.com/mmmorris1975/ssm-session-client/ssmclient"
+	"golang.org/x/term"
 )
 
 // Variables for mocking in tests
@@ -25,10 +29,10 @@ func (c *Client) StartNativeSession(ctx context.Context, instanceID string) erro
 		api = ssm.NewFromConfig(c.Config)
 	}
 
-	return startNativeSession(ctx, api, c.Config, instanceID, c.CircuitBreaker)
+	return startNativeSession(ctx, api, c.Config, instanceID, c.CircuitBreaker, c.IdleWarning)
 }
 
-func startNativeSession(ctx context.Context, api SSMAPI, config awsSdk.Config, instanceID string, cb *CircuitBreaker) error {
+func startNativeSession(ctx context.Context, api SSMAPI, config awsSdk.Config, instanceID string, cb *CircuitBreaker, idle IdleWarningConfig) error {
 	fmt.Printf("Starting native SSM session with instance %s...\n", instanceID)
 	fmt.Println("(Using pure Go implementation - no session-manager-plugin required)")
 	fmt.Println()
@@ -58,9 +62,12 @@ func startNativeSession(ctx context.Context, api SSMAPI, config awsSdk.Config, i
 
 	// Use the ssm-session-client library for shell session
 	// It accepts AWS SDK v2 config directly
-	if sessionErr := ssmShellSession(config, instanceID); sessionErr != nil {
+	stopIdleWatch := watchIdleSession(ctx, idle)
+	sessionErr := runNativeSession(ctx, func() error { return ssmShellSession(config, instanceID) })
+	stopIdleWatch()
+	if sessionErr != nil {
 		// Attempt to terminate the session even if it failed
-		terminateErr := terminateSessionSilently(ctx, api, sessionID)
+		terminateErr := terminateSessionSilently(context.WithoutCancel(ctx), api, sessionID)
 		if terminateErr != nil {
 			fmt.Printf("Warning: failed to terminate session after error: %v\n", terminateErr)
 		}
@@ -68,7 +75,7 @@ func startNativeSession(ctx context.Context, api SSMAPI, config awsSdk.Config, i
 	}
 
 	// Terminate the session after it completes
-	if terminateErr := terminateSessionSilently(ctx, api, sessionID); terminateErr != nil {
+	if terminateErr := terminateSessionSilently(context.WithoutCancel(ctx), api, sessionID); terminateErr != nil {
 		// Log but don't fail - session might already be terminated
 		fmt.Printf("Warning: failed to terminate session: %v\n", terminateErr)
 	}
@@ -126,9 +133,9 @@ func startPortForwardingSession(ctx context.Context, api SSMAPI, config awsSdk.C
 		LocalPort:  localPort,
 	}
 
-	if sessionErr := ssmPortForwardingSession(config, portForwardingInput); sessionErr != nil {
+	if sessionErr := runNativeSession(ctx, func() error { return ssmPortForwardingSession(config, portForwardingInput) }); sessionErr != nil {
 		// Attempt to terminate the session even if it failed
-		terminateErr := terminateSessionSilently(ctx, api, sessionID)
+		terminateErr := terminateSessionSilently(context.WithoutCancel(ctx), api, sessionID)
 		if terminateErr != nil {
 			fmt.Printf("Warning: failed to terminate session after error: %v\n", terminateErr)
 		}
@@ -136,7 +143,7 @@ func startPortForwardingSession(ctx context.Context, api SSMAPI, config awsSdk.C
 	}
 
 	// Terminate the session after it completes
-	if terminateErr := terminateSessionSilently(ctx, api, sessionID); terminateErr != nil {
+	if terminateErr := terminateSessionSilently(context.WithoutCancel(ctx), api, sessionID); terminateErr != nil {
 		// Log but don't fail - session might already be terminated
 		fmt.Printf("Warning: failed to terminate session: %v\n", terminateErr)
 	}
@@ -144,6 +151,72 @@ func startPortForwardingSession(ctx context.Context, api SSMAPI, config awsSdk.C
 	return nil
 }
 
+// runNativeSession runs session until it returns or ctx is cancelled with a
+// cause, such as the session timeout. The session library cannot be
+// cancelled, so it is left to end with the process after the terminal state
+// it may have changed is restored.
+func runNativeSession(ctx context.Context, session func() error) error {
+	restore := func() {}
+	if fd := int(os.Stdin.Fd()); term.IsTerminal(fd) {
+		if state, err := term.GetState(fd); err == nil {
+			restore = func() { _ = term.Restore(fd, state) }
+		}
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- session() }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		cause := sessionCancelCause(ctx)
+		if cause == nil {
+			return <-done
+		}
+		restore()
+		return fmt.Errorf("session ended: %w", cause)
+	}
+}
+
+// watchIdleSession prints a warning before an idle shell session times out.
+// The shell library reads stdin and writes stdout itself, so activity is
+// measured on session output (which includes the echo of typed input) by
+// routing os.Stdout through a pipe. The returned func stops watching and
+// restores os.Stdout.
+func watchIdleSession(ctx context.Context, cfg IdleWarningConfig) func() {
+	if !cfg.Enabled() {
+		return func() {}
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		return func() {}
+	}
+
+	monitor := NewIdleMonitor(cfg)
+	stdout := os.Stdout
+	os.Stdout = w
+	copied := make(chan struct{})
+	go func() {
+		defer close(copied)
+		_, _ = io.Copy(activityWriter{w: stdout, monitor: monitor}, r)
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	go monitor.Run(ctx, time.Second, func(remaining time.Duration) {
+		// The terminal is in raw mode, so line endings need explicit carriage returns
+		fmt.Fprintf(os.Stderr, "\r\nWarning: session has been idle; it will be disconnected in about %s\r\n", remaining.Round(time.Second))
+	})
+
+	return func() {
+		cancel()
+		os.Stdout = stdout
+		_ = w.Close()
+		<-copied
+		_ = r.Close()
+	}
+}
+
 func terminateSessionSilently(ctx context.Context, api SSMAPI, sessionID string) error {
 	terminateInput := &ssm.TerminateSessionInput{
 		SessionId: &sessionID,
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
@@ -34,7 +35,7 @@ func TestStartNativeSession(t *testing.T) {
 			return nil
 		}
 
-		err := startNativeSession(context.Background(), mockAPI, aws.Config{}, "i-123", cb)
+		err := startNativeSession(context.Background(), mockAPI, aws.Config{}, "i-123", cb, IdleWarningConfig{})
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 		}
@@ -46,7 +47,7 @@ func TestStartNativeSession(t *testing.T) {
 				return nil, errors.New("ssm error")
 			},
 		}
-		err := startNativeSession(context.Background(), failAPI, aws.Config{}, "i-123", cb)
+		err := startNativeSession(context.Background(), failAPI, aws.Config{}, "i-123", cb, IdleWarningConfig{})
 		if err == nil {
 			t.Error("expected error, got nil")
 		}
@@ -57,7 +58,7 @@ func TestStartNativeSession(t *testing.T) {
 			return errors.New("shell error")
 		}
 
-		err := startNativeSession(context.Background(), mockAPI, aws.Config{}, "i-123", cb)
+		err := startNativeSession(context.Background(), mockAPI, aws.Config{}, "i-123", cb, IdleWarningConfig{})
 		if err == nil {
 			t.Error("expected error, got nil")
 		}
@@ -116,3 +117,63 @@ func TestStartPortForwardingSession(t *testing.T) {
 		}
 	})
 }
+
+func TestRunNativeSessionEndsOnCancelCause(t *testing.T) {
+	timeoutErr := errors.New("session i-123 exceeded the 1h0m0s session timeout")
+	ctx, cancel := context.WithCancelCause(context.Background())
+	release := make(chan struct{})
+	defer close(release)
+
+	cancel(timeoutErr)
+	err := runNativeSession(ctx, func() error {
+		<-release
+		return nil
+	})
+	if !errors.Is(err, timeoutErr) {
+		t.Errorf("runNativeSession() error = %v, want the cancel cause", err)
+	}
+}
+
+func TestRunNativeSessionWaitsOnPlainCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	sessionErr := errors.New("interrupted")
+	err := runNativeSession(ctx, func() error { return sessionErr })
+	if !errors.Is(err, sessionErr) {
+		t.Errorf("runNativeSession() error = %v, want the session's own error", err)
+	}
+}
+
+func TestTerminateOnCancel(t *testing.T) {
+	terminated := make(chan string, 1)
+	api := &MockSSMAPI{
+		TerminateSessionFunc: func(_ context.Context, in *ssm.TerminateSessionInput, _ ...func(*ssm.Options)) (*ssm.TerminateSessionOutput, error) {
+			terminated <- aws.ToString(in.SessionId)
+			return &ssm.TerminateSessionOutput{}, nil
+		},
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	stop := terminateOnCancel(ctx, api, "session-123")
+	cancel(errors.New("session timeout"))
+	select {
+	case id := <-terminated:
+		if id != "session-123" {
+			t.Errorf("terminated %q, want session-123", id)
+		}
+	case <-time.After(time.Second):
+		t.Error("expected the session to be terminated on cancellation")
+	}
+	stop()
+
+	ctx, cancelPlain := context.WithCancel(context.Background())
+	stop = terminateOnCancel(ctx, api, "session-456")
+	cancelPlain()
+	stop()
+	select {
+	case id := <-terminated:
+		t.Errorf("terminated %q on a plain cancellation", id)
+	default:
+	}
+}
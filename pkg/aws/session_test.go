@@ -5,6 +5,7 @@ import (
 	"errors"
 	"os"
 	"os/exec"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -178,3 +179,63 @@ func TestStartSession(t *testing.T) {
 		}
 	})
 }
+
+func TestBuildStartSessionInput(t *testing.T) {
+	plain := buildStartSessionInput("i-123", SessionOptions{})
+	if aws.ToString(plain.Target) != "i-123" || plain.DocumentName != nil || plain.Parameters != nil {
+		t.Errorf("expected plain shell session input, got %+v", plain)
+	}
+
+	withCommand := buildStartSessionInput("i-123", SessionOptions{Command: "sudo su - deploy"})
+	if aws.ToString(withCommand.DocumentName) != "AWS-StartInteractiveCommand" {
+		t.Errorf("DocumentName = %q, want AWS-StartInteractiveCommand", aws.ToString(withCommand.DocumentName))
+	}
+	if got := withCommand.Parameters["command"]; len(got) != 1 || got[0] != "sudo su - deploy" {
+		t.Errorf("command parameter = %v, want [sudo su - deploy]", got)
+	}
+
+	withDocument := buildStartSessionInput("i-123", SessionOptions{DocumentName: "Org-RestrictedShell"})
+	if aws.ToString(withDocument.DocumentName) != "Org-RestrictedShell" || withDocument.Parameters != nil {
+		t.Errorf("expected custom document input, got %+v", withDocument)
+	}
+}
+
+func TestStartSessionWithOptionsPassesDocument(t *testing.T) {
+	origExecCommand := execCommand
+	defer func() { execCommand = origExecCommand }()
+
+	var pluginArgs []string
+	execCommand = func(name string, arg ...string) *exec.Cmd {
+		pluginArgs = arg
+		cs := []string{"-test.run=TestHelperProcess", "--", name}
+		cs = append(cs, arg...)
+		cmd := exec.Command(os.Args[0], cs...)
+		cmd.Env = []string{"GO_WANT_HELPER_PROCESS=1"}
+		return cmd
+	}
+
+	var gotInput *ssm.StartSessionInput
+	mockAPI := &MockSSMAPI{
+		StartSessionFunc: func(_ context.Context, params *ssm.StartSessionInput, _ ...func(*ssm.Options)) (*ssm.StartSessionOutput, error) {
+			gotInput = params
+			return &ssm.StartSessionOutput{SessionId: aws.String("session-123")}, nil
+		},
+		TerminateSessionFunc: func(_ context.Context, _ *ssm.TerminateSessionInput, _ ...func(*ssm.Options)) (*ssm.TerminateSessionOutput, error) {
+			return &ssm.TerminateSessionOutput{}, nil
+		},
+	}
+
+	cb := NewCircuitBreaker(DefaultCircuitBreakerConfig())
+	err := startSessionWithOptions(context.Background(), mockAPI, "us-east-1", "i-123", SessionOptions{DocumentName: "Org-RestrictedShell"}, cb)
+	if err != nil {
+		t.Fatalf("startSessionWithOptions() error = %v", err)
+	}
+
+	if gotInput == nil || aws.ToString(gotInput.DocumentName) != "Org-RestrictedShell" {
+		t.Errorf("StartSession DocumentName = %v, want Org-RestrictedShell", gotInput)
+	}
+	// The plugin parameters are the fifth argument
+	if len(pluginArgs) < 5 || !strings.Contains(pluginArgs[4], `"DocumentName":"Org-RestrictedShell"`) {
+		t.Errorf("plugin parameters = %v, want DocumentName Org-RestrictedShell", pluginArgs)
+	}
+}
@@ -0,0 +1,85 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+const (
+	// DefaultSSMReadyTimeout is how long WaitForSSMReady waits by default
+	DefaultSSMReadyTimeout = 5 * time.Minute
+	// ssmReadyPollInterval is the delay between registration checks
+	ssmReadyPollInterval = 5 * time.Second
+)
+
+// ErrSSMReadyTimeout is returned when an instance's SSM agent does not come
+// online in time
+var ErrSSMReadyTimeout = errors.New("timed out waiting for the SSM agent")
+
+// WaitForSSMReady polls SSM until the agent on instanceID has registered and
+// is online, or timeout elapses. Freshly launched or started instances take a
+// while to register. progress, when set, is called each time the observed
+// state changes.
+func (c *Client) WaitForSSMReady(ctx context.Context, instanceID string, timeout time.Duration, progress func(string)) error {
+	var api SSMInstanceInfoAPI
+	if c.SSMClient != nil {
+		api = c.SSMClient
+	} else {
+		api = ssm.NewFromConfig(c.Config)
+	}
+	return waitForSSMReady(ctx, api, instanceID, timeout, ssmReadyPollInterval, progress)
+}
+
+func waitForSSMReady(ctx context.Context, api SSMInstanceInfoAPI, instanceID string, timeout, interval time.Duration, progress func(string)) error {
+	if timeout <= 0 {
+		timeout = DefaultSSMReadyTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	last := ""
+	for {
+		status, err := ssmPingStatuses(ctx, api, instanceID)
+		switch {
+		case err == nil:
+			state := ssmReadyState(status, instanceID)
+			if state == "" {
+				return nil
+			}
+			if state != last && progress != nil {
+				progress(state)
+			}
+			last = state
+		case ctx.Err() == nil:
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("%w on %s after %v (%s)", ErrSSMReadyTimeout, instanceID, timeout, last)
+			}
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// ssmReadyState describes why instanceID is not ready for a session yet, or
+// returns "" when its agent is online
+func ssmReadyState(status map[string]types.PingStatus, instanceID string) string {
+	ping, ok := status[instanceID]
+	switch {
+	case !ok:
+		return "not registered with SSM yet"
+	case ping != types.PingStatusOnline:
+		return fmt.Sprintf("SSM agent is %s", ping)
+	default:
+		return ""
+	}
+}
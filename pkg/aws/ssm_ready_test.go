@@ -0,0 +1,75 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// registeringInstance reports instanceID through the given ping statuses,
+// one per call, with "" meaning not registered; the last status repeats
+func registeringInstance(instanceID string, pings ...types.PingStatus) (*MockSSMInstanceInfoAPI, *int) {
+	calls := 0
+	return &MockSSMInstanceInfoAPI{
+		DescribeInstanceInformationFunc: func(context.Context, *ssm.DescribeInstanceInformationInput, ...func(*ssm.Options)) (*ssm.DescribeInstanceInformationOutput, error) {
+			ping := pings[min(calls, len(pings)-1)]
+			calls++
+			output := &ssm.DescribeInstanceInformationOutput{}
+			if ping != "" {
+				output.InstanceInformationList = []types.InstanceInformation{
+					{InstanceId: aws.String(instanceID), PingStatus: ping},
+				}
+			}
+			return output, nil
+		},
+	}, &calls
+}
+
+func TestWaitForSSMReadyBecomesOnline(t *testing.T) {
+	api, calls := registeringInstance("i-new", "", "", types.PingStatusConnectionLost, types.PingStatusOnline)
+
+	var progress []string
+	err := waitForSSMReady(context.Background(), api, "i-new", time.Second, time.Millisecond, func(state string) {
+		progress = append(progress, state)
+	})
+	if err != nil {
+		t.Fatalf("waitForSSMReady() error = %v", err)
+	}
+	if *calls != 4 {
+		t.Errorf("DescribeInstanceInformation called %d times, want 4", *calls)
+	}
+	want := "not registered with SSM yet|SSM agent is ConnectionLost"
+	if got := strings.Join(progress, "|"); got != want {
+		t.Errorf("progress = %q, want %q (one report per state change)", got, want)
+	}
+}
+
+func TestWaitForSSMReadyTimeout(t *testing.T) {
+	api, _ := registeringInstance("i-new", "")
+
+	err := waitForSSMReady(context.Background(), api, "i-new", 20*time.Millisecond, time.Millisecond, nil)
+	if !errors.Is(err, ErrSSMReadyTimeout) {
+		t.Fatalf("waitForSSMReady() error = %v, want ErrSSMReadyTimeout", err)
+	}
+	if !strings.Contains(err.Error(), "not registered") {
+		t.Errorf("timeout error %q does not include the last observed state", err)
+	}
+}
+
+func TestWaitForSSMReadyAPIError(t *testing.T) {
+	api := &MockSSMInstanceInfoAPI{
+		DescribeInstanceInformationFunc: func(context.Context, *ssm.DescribeInstanceInformationInput, ...func(*ssm.Options)) (*ssm.DescribeInstanceInformationOutput, error) {
+			return nil, errors.New("AccessDenied")
+		},
+	}
+	err := waitForSSMReady(context.Background(), api, "i-new", time.Second, time.Millisecond, nil)
+	if err == nil || errors.Is(err, ErrSSMReadyTimeout) || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Errorf("waitForSSMReady() error = %v, want the API error", err)
+	}
+}
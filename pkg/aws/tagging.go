@@ -0,0 +1,98 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// bulkTagWorkerLimit bounds the number of concurrent CreateTags calls
+const bulkTagWorkerLimit = 10
+
+// EC2TaggingAPI defines the interface for EC2 tagging operations
+type EC2TaggingAPI interface {
+	CreateTags(ctx context.Context, params *ec2.CreateTagsInput, optFns ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error)
+}
+
+// TagResult is the outcome of tagging a single instance
+type TagResult struct {
+	InstanceID string
+	Err        error
+}
+
+// TagInstances applies tags to each instance concurrently. Every instance is
+// attempted even if others fail; results are returned in instance ID order.
+func (c *Client) TagInstances(ctx context.Context, instanceIDs []string, tags map[string]string) []TagResult {
+	var api EC2TaggingAPI
+	if c.EC2Client != nil {
+		api = c.EC2Client
+	} else {
+		api = ec2.NewFromConfig(c.Config)
+	}
+	return tagInstances(ctx, api, instanceIDs, tags, bulkTagWorkerLimit)
+}
+
+func tagInstances(ctx context.Context, api EC2TaggingAPI, instanceIDs []string, tags map[string]string, concurrency int) []TagResult {
+	if len(instanceIDs) == 0 {
+		return nil
+	}
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	ec2Tags := buildEC2Tags(tags)
+	results := make([]TagResult, len(instanceIDs))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for idx, instanceID := range instanceIDs {
+		wg.Add(1)
+		go func(idx int, instanceID string) {
+			defer wg.Done()
+			results[idx] = TagResult{InstanceID: instanceID}
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				results[idx].Err = ctx.Err()
+				return
+			}
+
+			_, err := api.CreateTags(ctx, &ec2.CreateTagsInput{
+				Resources: []string{instanceID},
+				Tags:      ec2Tags,
+			})
+			if err != nil {
+				results[idx].Err = fmt.Errorf("failed to tag instance %s: %w", instanceID, err)
+			}
+		}(idx, instanceID)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].InstanceID < results[j].InstanceID })
+	return results
+}
+
+// buildEC2Tags converts a tag map into EC2 tags sorted by key
+func buildEC2Tags(tags map[string]string) []types.Tag {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	ec2Tags := make([]types.Tag, 0, len(keys))
+	for _, k := range keys {
+		ec2Tags = append(ec2Tags, types.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(tags[k]),
+		})
+	}
+	return ec2Tags
+}
@@ -0,0 +1,89 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+)
+
+// MockEC2TaggingAPI records CreateTags calls per instance
+type MockEC2TaggingAPI struct {
+	mu       sync.Mutex
+	tagged   map[string]map[string]string
+	failures map[string]error
+}
+
+func (m *MockEC2TaggingAPI) CreateTags(_ context.Context, params *ec2.CreateTagsInput, _ ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, id := range params.Resources {
+		if err := m.failures[id]; err != nil {
+			return nil, err
+		}
+		if m.tagged[id] == nil {
+			m.tagged[id] = make(map[string]string)
+		}
+		for _, tag := range params.Tags {
+			m.tagged[id][aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+	}
+	return &ec2.CreateTagsOutput{}, nil
+}
+
+func TestTagInstancesAppliesTagsToEveryInstance(t *testing.T) {
+	api := &MockEC2TaggingAPI{tagged: make(map[string]map[string]string)}
+	ids := []string{"i-003", "i-001", "i-002"}
+	tags := map[string]string{"CostCenter": "1234", "Team": "platform"}
+
+	results := tagInstances(context.Background(), api, ids, tags, 2)
+
+	if len(results) != len(ids) {
+		t.Fatalf("expected %d results, got %d", len(ids), len(results))
+	}
+	for i, want := range []string{"i-001", "i-002", "i-003"} {
+		if results[i].InstanceID != want {
+			t.Errorf("result %d instance = %s, want %s", i, results[i].InstanceID, want)
+		}
+		if results[i].Err != nil {
+			t.Errorf("unexpected error for %s: %v", want, results[i].Err)
+		}
+	}
+	for _, id := range ids {
+		got := api.tagged[id]
+		for k, v := range tags {
+			if got[k] != v {
+				t.Errorf("instance %s tag %s = %q, want %q", id, k, got[k], v)
+			}
+		}
+	}
+}
+
+func TestTagInstancesReportsFailuresWithoutAborting(t *testing.T) {
+	api := &MockEC2TaggingAPI{
+		tagged:   make(map[string]map[string]string),
+		failures: map[string]error{"i-002": errors.New("UnauthorizedOperation")},
+	}
+	ids := []string{"i-001", "i-002", "i-003"}
+
+	results := tagInstances(context.Background(), api, ids, map[string]string{"Env": "stage"}, 1)
+
+	var failed []string
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, r.InstanceID)
+		}
+	}
+	if len(failed) != 1 || failed[0] != "i-002" {
+		t.Fatalf("expected only i-002 to fail, got %v", failed)
+	}
+	for _, id := range []string{"i-001", "i-003"} {
+		if api.tagged[id]["Env"] != "stage" {
+			t.Errorf("expected %s to be tagged despite other failures", id)
+		}
+	}
+}
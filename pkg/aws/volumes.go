@@ -0,0 +1,158 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// volumeInstanceBatchSize bounds the instance IDs sent in one DescribeVolumes filter
+const volumeInstanceBatchSize = 200
+
+// EC2VolumesAPI defines the interface for describing EBS volumes
+type EC2VolumesAPI interface {
+	DescribeVolumes(ctx context.Context, params *ec2.DescribeVolumesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVolumesOutput, error)
+}
+
+// VolumeSummary aggregates the EBS volumes attached to an instance
+type VolumeSummary struct {
+	Count            int
+	TotalSizeGiB     int64
+	LargestSizeGiB   int64
+	UnencryptedCount int
+}
+
+// Encrypted reports whether every attached volume is encrypted
+func (s VolumeSummary) Encrypted() bool {
+	return s.Count > 0 && s.UnencryptedCount == 0
+}
+
+// VolumeFilter selects instances by their attached volumes. Zero values match everything.
+type VolumeFilter struct {
+	MinVolumeSizeGiB   int64 // at least one volume of this size or larger
+	UnencryptedVolumes bool  // at least one unencrypted volume
+}
+
+// IsZero reports whether the filter has no criteria
+func (f VolumeFilter) IsZero() bool {
+	return f.MinVolumeSizeGiB <= 0 && !f.UnencryptedVolumes
+}
+
+// Matches reports whether an instance's volume summary satisfies the filter.
+// Instances without a loaded summary only match an empty filter.
+func (f VolumeFilter) Matches(inst Instance) bool {
+	if f.IsZero() {
+		return true
+	}
+	if inst.Volumes == nil {
+		return false
+	}
+	if f.MinVolumeSizeGiB > 0 && inst.Volumes.LargestSizeGiB < f.MinVolumeSizeGiB {
+		return false
+	}
+	if f.UnencryptedVolumes && inst.Volumes.UnencryptedCount == 0 {
+		return false
+	}
+	return true
+}
+
+// FilterInstancesByVolumes returns the instances matching f, preserving order
+func FilterInstancesByVolumes(instances []Instance, f VolumeFilter) []Instance {
+	if f.IsZero() {
+		return instances
+	}
+	filtered := make([]Instance, 0, len(instances))
+	for _, inst := range instances {
+		if f.Matches(inst) {
+			filtered = append(filtered, inst)
+		}
+	}
+	return filtered
+}
+
+// LoadVolumeSummaries fills in Volumes for each instance using batched
+// DescribeVolumes calls. Instances without volumes get an empty summary.
+func (c *Client) LoadVolumeSummaries(ctx context.Context, instances []Instance) error {
+	var api EC2VolumesAPI
+	if c.EC2Client != nil {
+		api = c.EC2Client
+	} else {
+		api = ec2.NewFromConfig(c.Config)
+	}
+	return loadVolumeSummaries(ctx, api, instances)
+}
+
+func loadVolumeSummaries(ctx context.Context, api EC2VolumesAPI, instances []Instance) error {
+	ids := make([]string, 0, len(instances))
+	for _, inst := range instances {
+		ids = append(ids, inst.InstanceID)
+	}
+
+	summaries, err := describeVolumeSummaries(ctx, api, ids)
+	if err != nil {
+		return err
+	}
+	for i := range instances {
+		summary := summaries[instances[i].InstanceID]
+		instances[i].Volumes = &summary
+	}
+	return nil
+}
+
+// describeVolumeSummaries fetches the volumes attached to instanceIDs in batches
+func describeVolumeSummaries(ctx context.Context, api EC2VolumesAPI, instanceIDs []string) (map[string]VolumeSummary, error) {
+	wanted := make(map[string]bool, len(instanceIDs))
+	for _, id := range instanceIDs {
+		wanted[id] = true
+	}
+
+	summaries := make(map[string]VolumeSummary, len(instanceIDs))
+	for start := 0; start < len(instanceIDs); start += volumeInstanceBatchSize {
+		end := min(start+volumeInstanceBatchSize, len(instanceIDs))
+		input := &ec2.DescribeVolumesInput{
+			Filters: []types.Filter{
+				{Name: aws.String("attachment.instance-id"), Values: instanceIDs[start:end]},
+			},
+		}
+
+		paginator := ec2.NewDescribeVolumesPaginator(api, input)
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to describe volumes: %w", err)
+			}
+			addVolumesToSummaries(summaries, page.Volumes, wanted)
+		}
+	}
+	return summaries, nil
+}
+
+// addVolumesToSummaries adds each volume to the summary of every wanted
+// instance it is attached to. Multi-attach volumes count for each instance.
+func addVolumesToSummaries(summaries map[string]VolumeSummary, volumes []types.Volume, wanted map[string]bool) {
+	for _, volume := range volumes {
+		size := int64(aws.ToInt32(volume.Size))
+		encrypted := aws.ToBool(volume.Encrypted)
+
+		seen := make(map[string]bool, len(volume.Attachments))
+		for _, attachment := range volume.Attachments {
+			id := aws.ToString(attachment.InstanceId)
+			if !wanted[id] || seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			summary := summaries[id]
+			summary.Count++
+			summary.TotalSizeGiB += size
+			summary.LargestSizeGiB = max(summary.LargestSizeGiB, size)
+			if !encrypted {
+				summary.UnencryptedCount++
+			}
+			summaries[id] = summary
+		}
+	}
+}
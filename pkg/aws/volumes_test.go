@@ -0,0 +1,156 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// MockEC2VolumesAPI serves volumes for the requested instance IDs, one volume per page
+type MockEC2VolumesAPI struct {
+	volumes []types.Volume
+	batches [][]string
+}
+
+func (m *MockEC2VolumesAPI) DescribeVolumes(_ context.Context, params *ec2.DescribeVolumesInput, _ ...func(*ec2.Options)) (*ec2.DescribeVolumesOutput, error) {
+	ids := map[string]bool{}
+	for _, f := range params.Filters {
+		if aws.ToString(f.Name) == "attachment.instance-id" {
+			for _, id := range f.Values {
+				ids[id] = true
+			}
+			if params.NextToken == nil {
+				m.batches = append(m.batches, f.Values)
+			}
+		}
+	}
+
+	var matching []types.Volume
+	for _, v := range m.volumes {
+		for _, a := range v.Attachments {
+			if ids[aws.ToString(a.InstanceId)] {
+				matching = append(matching, v)
+				break
+			}
+		}
+	}
+
+	start := 0
+	if params.NextToken != nil {
+		_, _ = fmt.Sscanf(aws.ToString(params.NextToken), "%d", &start)
+	}
+	if start >= len(matching) {
+		return &ec2.DescribeVolumesOutput{}, nil
+	}
+	out := &ec2.DescribeVolumesOutput{Volumes: matching[start : start+1]}
+	if start+1 < len(matching) {
+		out.NextToken = aws.String(fmt.Sprintf("%d", start+1))
+	}
+	return out, nil
+}
+
+func mockVolume(sizeGiB int32, encrypted bool, instanceIDs ...string) types.Volume {
+	v := types.Volume{Size: aws.Int32(sizeGiB), Encrypted: aws.Bool(encrypted)}
+	for _, id := range instanceIDs {
+		v.Attachments = append(v.Attachments, types.VolumeAttachment{InstanceId: aws.String(id)})
+	}
+	return v
+}
+
+func TestLoadVolumeSummaries(t *testing.T) {
+	api := &MockEC2VolumesAPI{volumes: []types.Volume{
+		mockVolume(8, true, "i-1"),
+		mockVolume(500, false, "i-1"),
+		mockVolume(100, true, "i-2", "i-3"), // multi-attach
+		mockVolume(50, false, "i-other"),
+	}}
+	instances := []Instance{{InstanceID: "i-1"}, {InstanceID: "i-2"}, {InstanceID: "i-3"}, {InstanceID: "i-4"}}
+
+	if err := loadVolumeSummaries(context.Background(), api, instances); err != nil {
+		t.Fatalf("loadVolumeSummaries() error = %v", err)
+	}
+
+	want := map[string]VolumeSummary{
+		"i-1": {Count: 2, TotalSizeGiB: 508, LargestSizeGiB: 500, UnencryptedCount: 1},
+		"i-2": {Count: 1, TotalSizeGiB: 100, LargestSizeGiB: 100},
+		"i-3": {Count: 1, TotalSizeGiB: 100, LargestSizeGiB: 100},
+		"i-4": {},
+	}
+	for _, inst := range instances {
+		if inst.Volumes == nil {
+			t.Fatalf("%s: volume summary not loaded", inst.InstanceID)
+		}
+		if *inst.Volumes != want[inst.InstanceID] {
+			t.Errorf("%s: summary = %+v, want %+v", inst.InstanceID, *inst.Volumes, want[inst.InstanceID])
+		}
+	}
+	if !instances[1].Volumes.Encrypted() || instances[0].Volumes.Encrypted() || instances[3].Volumes.Encrypted() {
+		t.Error("Encrypted() should be true only when every volume of a non-empty set is encrypted")
+	}
+}
+
+func TestLoadVolumeSummariesBatchesInstanceIDs(t *testing.T) {
+	instances := make([]Instance, volumeInstanceBatchSize*2+5)
+	for i := range instances {
+		instances[i].InstanceID = fmt.Sprintf("i-%04d", i)
+	}
+	last := instances[len(instances)-1].InstanceID
+	api := &MockEC2VolumesAPI{volumes: []types.Volume{mockVolume(20, true, last)}}
+
+	if err := loadVolumeSummaries(context.Background(), api, instances); err != nil {
+		t.Fatalf("loadVolumeSummaries() error = %v", err)
+	}
+
+	if len(api.batches) != 3 {
+		t.Fatalf("expected 3 DescribeVolumes batches, got %d", len(api.batches))
+	}
+	for i, batch := range api.batches {
+		if len(batch) > volumeInstanceBatchSize {
+			t.Errorf("batch %d has %d IDs, limit is %d", i, len(batch), volumeInstanceBatchSize)
+		}
+	}
+	if got := instances[len(instances)-1].Volumes; got == nil || got.Count != 1 {
+		t.Errorf("last instance summary = %+v, want one volume", got)
+	}
+}
+
+func TestVolumeFilterMatches(t *testing.T) {
+	large := Instance{InstanceID: "i-large", Volumes: &VolumeSummary{Count: 2, TotalSizeGiB: 1008, LargestSizeGiB: 1000}}
+	unencrypted := Instance{InstanceID: "i-plain", Volumes: &VolumeSummary{Count: 1, TotalSizeGiB: 30, LargestSizeGiB: 30, UnencryptedCount: 1}}
+	noVolumes := Instance{InstanceID: "i-none", Volumes: &VolumeSummary{}}
+	notLoaded := Instance{InstanceID: "i-unknown"}
+
+	tests := []struct {
+		name   string
+		filter VolumeFilter
+		inst   Instance
+		want   bool
+	}{
+		{name: "empty filter matches unloaded", filter: VolumeFilter{}, inst: notLoaded, want: true},
+		{name: "min size matches largest volume", filter: VolumeFilter{MinVolumeSizeGiB: 500}, inst: large, want: true},
+		{name: "min size uses largest not total", filter: VolumeFilter{MinVolumeSizeGiB: 1005}, inst: large, want: false},
+		{name: "min size rejects small", filter: VolumeFilter{MinVolumeSizeGiB: 100}, inst: unencrypted, want: false},
+		{name: "unencrypted matches", filter: VolumeFilter{UnencryptedVolumes: true}, inst: unencrypted, want: true},
+		{name: "unencrypted rejects encrypted", filter: VolumeFilter{UnencryptedVolumes: true}, inst: large, want: false},
+		{name: "unencrypted rejects no volumes", filter: VolumeFilter{UnencryptedVolumes: true}, inst: noVolumes, want: false},
+		{name: "combined criteria", filter: VolumeFilter{MinVolumeSizeGiB: 10, UnencryptedVolumes: true}, inst: unencrypted, want: true},
+		{name: "unloaded never matches criteria", filter: VolumeFilter{MinVolumeSizeGiB: 1}, inst: notLoaded, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(tt.inst); got != tt.want {
+				t.Errorf("Matches(%s) = %v, want %v", tt.inst.InstanceID, got, tt.want)
+			}
+		})
+	}
+
+	filtered := FilterInstancesByVolumes([]Instance{large, unencrypted, noVolumes}, VolumeFilter{UnencryptedVolumes: true})
+	if len(filtered) != 1 || filtered[0].InstanceID != "i-plain" {
+		t.Errorf("FilterInstancesByVolumes() = %v, want only i-plain", filtered)
+	}
+}
@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// adaptiveTTL bounds the TTL of entries whose lifetime follows how often their data changes
+type adaptiveTTL struct {
+	min time.Duration
+	max time.Duration
+}
+
+// EnableAdaptiveTTL makes each store compare the new data with the cached
+// data: unchanged data doubles the entry's TTL and changed data halves it,
+// within [min, max]. A non-positive max disables adaptive TTLs.
+func (c *Service) EnableAdaptiveTTL(min, max time.Duration) {
+	if max <= 0 {
+		c.adaptive = nil
+		return
+	}
+	if min <= 0 || min > max {
+		min = max
+	}
+	c.adaptive = &adaptiveTTL{min: min, max: max}
+}
+
+// entryTTL returns the TTL of an entry: its adaptive TTL in adaptive mode,
+// otherwise the TTL of its resource type
+func (c *Service) entryTTL(resourceType ResourceType, adaptive time.Duration) time.Duration {
+	if c.adaptive != nil && adaptive > 0 {
+		return adaptive
+	}
+	return c.ttlFor(resourceType)
+}
+
+// adaptTTL returns the TTL and payload hash for storing payload under key,
+// based on whether the payload differs from the one cached before
+func (c *Service) adaptTTL(key string, resourceType ResourceType, payload []byte) (time.Duration, string) {
+	sum := sha256.Sum256(payload)
+	hash := hex.EncodeToString(sum[:])
+
+	ttl := c.ttlFor(resourceType)
+	if prev, err := c.Peek(key); err == nil && prev.PayloadHash != "" {
+		if prev.TTL > 0 {
+			ttl = prev.TTL
+		}
+		if prev.PayloadHash == hash {
+			ttl *= 2
+		} else {
+			ttl /= 2
+		}
+	}
+	return c.adaptive.clamp(ttl), hash
+}
+
+// clamp limits ttl to the adaptive bounds
+func (a *adaptiveTTL) clamp(ttl time.Duration) time.Duration {
+	if ttl < a.min {
+		return a.min
+	}
+	if ttl > a.max {
+		return a.max
+	}
+	return ttl
+}
@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/config"
+)
+
+func TestAdaptiveTTL(t *testing.T) {
+	tests := []struct {
+		name     string
+		payloads []string
+		wantTTLs []time.Duration
+	}{
+		{
+			name:     "stable data extends up to the max",
+			payloads: []string{"a", "a", "a", "a", "a"},
+			wantTTLs: []time.Duration{10 * time.Minute, 20 * time.Minute, 40 * time.Minute, time.Hour, time.Hour},
+		},
+		{
+			name:     "volatile data contracts down to the min",
+			payloads: []string{"a", "b", "c", "d", "e"},
+			wantTTLs: []time.Duration{10 * time.Minute, 5 * time.Minute, 2*time.Minute + 30*time.Second, 2 * time.Minute, 2 * time.Minute},
+		},
+		{
+			name:     "a change after stable data contracts",
+			payloads: []string{"a", "a", "a", "b"},
+			wantTTLs: []time.Duration{10 * time.Minute, 20 * time.Minute, 40 * time.Minute, 20 * time.Minute},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, err := NewCacheService(t.TempDir(), 10)
+			if err != nil {
+				t.Fatalf("NewCacheService() error = %v", err)
+			}
+			svc.EnableAdaptiveTTL(2*time.Minute, time.Hour)
+
+			for i, payload := range tt.payloads {
+				if err := svc.Set("instances", []string{payload}, "us-east-1", "q"); err != nil {
+					t.Fatalf("Set() error = %v", err)
+				}
+				entry, err := svc.Peek("instances")
+				if err != nil {
+					t.Fatalf("Peek() error = %v", err)
+				}
+				if entry.TTL != tt.wantTTLs[i] {
+					t.Errorf("refresh %d: TTL = %v, want %v", i, entry.TTL, tt.wantTTLs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAdaptiveTTLGovernsExpiry(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{}
+	cfg.Cache.CacheDir = dir
+	cfg.Cache.TTLMinutes = 10
+	cfg.Cache.AdaptiveTTL = true
+	cfg.Cache.AdaptiveTTLMin = 1
+	cfg.Cache.AdaptiveTTLMax = 60
+	svc, err := NewCacheServiceFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewCacheServiceFromConfig() error = %v", err)
+	}
+
+	// Two identical refreshes extend the TTL from 10 to 20 minutes
+	for i := 0; i < 2; i++ {
+		if err := svc.Set("clusters", []string{"prod"}, "us-east-1", "q"); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+	}
+	ageCacheEntry(t, dir, "clusters", 15*time.Minute)
+	if _, ok := svc.Get("clusters"); !ok {
+		t.Error("entry expired before its extended TTL")
+	}
+
+	// Without adaptive mode the global TTL applies again
+	svc.EnableAdaptiveTTL(0, 0)
+	if _, ok := svc.Get("clusters"); ok {
+		t.Error("entry should expire after the global TTL once adaptive mode is off")
+	}
+}
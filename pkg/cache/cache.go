@@ -2,26 +2,93 @@
 package cache
 
 import (
+	"crypto/cipher"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/metrics"
+)
+
+// ResourceType identifies the kind of AWS resource stored in a cache entry
+type ResourceType string
+
+// Resource types with independently configurable TTLs
+const (
+	ResourceEC2       ResourceType = "ec2"
+	ResourceEKS       ResourceType = "eks"
+	ResourceASG       ResourceType = "asg"
+	ResourceNodeGroup ResourceType = "nodegroup"
 )
 
 // Entry represents a cached item
 type Entry struct {
-	Data      interface{} `json:"data"`
-	Timestamp time.Time   `json:"timestamp"`
-	Region    string      `json:"region"`
-	Query     string      `json:"query"`
+	Data         interface{}   `json:"data"`
+	Timestamp    time.Time     `json:"timestamp"`
+	Region       string        `json:"region"`
+	Query        string        `json:"query"`
+	ResourceType ResourceType  `json:"resource_type,omitempty"`
+	TTL          time.Duration `json:"ttl,omitempty"`          // Adaptive TTL chosen when the entry was stored
+	PayloadHash  string        `json:"payload_hash,omitempty"` // Hash of Data, compared on the next store
+	ExpiresAt    *time.Time    `json:"expires_at,omitempty"`   // Explicit expiry from SetWithTTL, overriding any TTL
 }
 
 // Service handles caching of instance data
 type Service struct {
-	cacheDir string
-	ttl      time.Duration
+	cacheDir          string
+	ttl               time.Duration
+	resourceTTLs      map[ResourceType]time.Duration
+	adaptive          *adaptiveTTL
+	memory            *memoryLRU // Optional in-memory layer in front of the files
+	size              *sizeTracker
+	clock             Clock
+	compressThreshold int         // Gzip JSON bodies larger than this many bytes; 0 disables
+	aead              cipher.AEAD // Encrypts cache files when set
+}
+
+// Clock abstracts access to the current time for deterministic testing.
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+// SetResourceTTL overrides the TTL for entries of the given resource type.
+// A non-positive ttl removes the override so the global TTL applies again.
+func (c *Service) SetResourceTTL(resourceType ResourceType, ttl time.Duration) {
+	if ttl <= 0 {
+		delete(c.resourceTTLs, resourceType)
+		return
+	}
+	c.resourceTTLs[resourceType] = ttl
+}
+
+// expiresAt returns when entry expires: its explicit expiry if it has one,
+// otherwise its timestamp plus the TTL that applies to it
+func (c *Service) expiresAt(entry *Entry) time.Time {
+	if entry.ExpiresAt != nil {
+		return *entry.ExpiresAt
+	}
+	return entry.Timestamp.Add(c.entryTTL(entry.ResourceType, entry.TTL))
+}
+
+// expired reports whether entry has outlived its expiry
+func (c *Service) expired(entry *Entry) bool {
+	return c.clock.Now().After(c.expiresAt(entry))
+}
+
+// ttlFor returns the TTL that applies to entries of the given resource type
+func (c *Service) ttlFor(resourceType ResourceType) time.Duration {
+	if ttl, ok := c.resourceTTLs[resourceType]; ok {
+		return ttl
+	}
+	return c.ttl
 }
 
 func (c *Service) cachePathForKey(key string) (string, error) {
@@ -51,8 +118,14 @@ func (c *Service) safeCachePath(path string) (string, error) {
 	return cleanPath, nil
 }
 
-// NewCacheService creates a new cache service
+// NewCacheService creates a new cache service that uses the real clock
 func NewCacheService(cacheDir string, ttlMinutes int) (*Service, error) {
+	return NewCacheServiceWithClock(cacheDir, ttlMinutes, realClock{})
+}
+
+// NewCacheServiceWithClock creates a new cache service whose expiry checks
+// read the time from clk
+func NewCacheServiceWithClock(cacheDir string, ttlMinutes int, clk Clock) (*Service, error) {
 	if cacheDir == "" {
 		homeDir, err := os.UserHomeDir()
 		if err != nil {
@@ -67,20 +140,56 @@ func NewCacheService(cacheDir string, ttlMinutes int) (*Service, error) {
 	}
 
 	return &Service{
-		cacheDir: cacheDir,
-		ttl:      time.Duration(ttlMinutes) * time.Minute,
+		cacheDir:     cacheDir,
+		ttl:          time.Duration(ttlMinutes) * time.Minute,
+		resourceTTLs: make(map[ResourceType]time.Duration),
+		size:         &sizeTracker{},
+		clock:        clk,
 	}, nil
 }
 
-// Get retrieves cached data for the given key
+// Get retrieves cached data for the given key, checking the memory layer
+// before reading from disk
 func (c *Service) Get(key string) (interface{}, bool) {
 	cleanPath, err := c.cachePathForKey(key)
 	if err != nil {
+		metrics.CacheMisses.Inc(1)
+		return nil, false
+	}
+
+	if entry, ok := c.memoryGet(key); ok {
+		if !c.expired(&entry) {
+			metrics.CacheHits.Inc(1)
+			metrics.CacheMemoryHits.Inc(1)
+			return entry.Data, true
+		}
+		c.memoryRemove(key)
+	}
+
+	entry, ok := c.readEntry(cleanPath)
+	if !ok {
+		metrics.CacheMisses.Inc(1)
+		return nil, false
+	}
+
+	// Check if cache entry is expired
+	if c.expired(entry) {
+		// Remove expired cache file (ignore error as it's cleanup)
+		//nolint:errcheck // Cleanup operation, error is not critical
+		_ = c.removeCacheFile(cleanPath)
+		metrics.CacheMisses.Inc(1)
 		return nil, false
 	}
 
+	c.memoryPut(key, *entry)
+	metrics.CacheHits.Inc(1)
+	return entry.Data, true
+}
+
+// readEntry reads and decodes the cache file at cleanPath, warning about
+// anything other than a missing file
+func (c *Service) readEntry(cleanPath string) (*Entry, bool) {
 	// Check file size before reading to prevent reading excessively large files
-	const maxCacheFileSize = 10 * 1024 * 1024 // 10 MB limit
 	fileInfo, err := os.Stat(cleanPath)
 	if err != nil {
 		if !os.IsNotExist(err) {
@@ -105,34 +214,91 @@ func (c *Service) Get(key string) (interface{}, bool) {
 	}
 
 	var entry Entry
-	if unmarshalErr := json.Unmarshal(data, &entry); unmarshalErr != nil {
+	if unmarshalErr := c.unmarshalCacheFile(data, &entry); unmarshalErr != nil {
 		fmt.Fprintf(os.Stderr, "Warning: failed to unmarshal cache entry %s: %v\n", cleanPath, unmarshalErr)
+		if errors.Is(unmarshalErr, errCacheDecrypt) {
+			// Unreadable with the current key, so it can never be served
+			//nolint:errcheck // Cleanup operation, error is not critical
+			_ = c.removeCacheFile(cleanPath)
+		}
 		return nil, false
 	}
+	return &entry, true
+}
 
-	// Check if cache entry is expired
-	if time.Since(entry.Timestamp) > c.ttl {
-		// Remove expired cache file (ignore error as it's cleanup)
-		//nolint:errcheck // Cleanup operation, error is not critical
-		_ = os.Remove(cleanPath)
-		return nil, false
+// Peek reads the full cache entry for the given key without enforcing the TTL.
+// Expired entries are returned as-is and left on disk, which makes it suitable
+// for inspecting snapshots rather than serving lookups.
+func (c *Service) Peek(key string) (*Entry, error) {
+	cleanPath, err := c.cachePathForKey(key)
+	if err != nil {
+		return nil, err
 	}
 
-	return entry.Data, true
+	fileInfo, err := os.Stat(cleanPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("cache entry %q not found", key)
+		}
+		return nil, fmt.Errorf("failed to stat cache file: %w", err)
+	}
+	if fileInfo.Size() > maxCacheFileSize {
+		return nil, fmt.Errorf("cache file exceeds size limit (%d > %d bytes)", fileInfo.Size(), maxCacheFileSize)
+	}
+
+	data, err := os.ReadFile(cleanPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache file: %w", err)
+	}
+
+	var entry Entry
+	if err := c.unmarshalCacheFile(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cache entry: %w", err)
+	}
+	return &entry, nil
 }
 
-// Set stores data in cache with the given key
+// Set stores data in cache with the given key using the global TTL
 func (c *Service) Set(key string, data interface{}, region, query string) error {
+	return c.SetWithResourceType(key, data, "", region, query)
+}
+
+// SetWithResourceType stores data in cache tagged with a resource type so the
+// entry expires according to that type's TTL
+func (c *Service) SetWithResourceType(key string, data interface{}, resourceType ResourceType, region, query string) error {
+	return c.store(key, data, resourceType, region, query, 0)
+}
+
+// SetWithTTL stores data in cache with its own TTL, overriding the service
+// and resource type TTLs for this entry. A non-positive ttl behaves like Set.
+func (c *Service) SetWithTTL(key string, data interface{}, region, query string, ttl time.Duration) error {
+	return c.store(key, data, "", region, query, ttl)
+}
+
+// store writes an entry for key, expiring after ttl when it is positive
+func (c *Service) store(key string, data interface{}, resourceType ResourceType, region, query string, ttl time.Duration) error {
 	cacheFile, err := c.cachePathForKey(key)
 	if err != nil {
 		return err
 	}
 
+	now := c.clock.Now()
 	entry := Entry{
-		Data:      data,
-		Timestamp: time.Now(),
-		Region:    region,
-		Query:     query,
+		Data:         data,
+		Timestamp:    now,
+		Region:       region,
+		Query:        query,
+		ResourceType: resourceType,
+	}
+	if ttl > 0 {
+		expiresAt := now.Add(ttl)
+		entry.ExpiresAt = &expiresAt
+	} else if c.adaptive != nil {
+		payload, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("failed to marshal cache entry: %w", err)
+		}
+		entry.TTL, entry.PayloadHash = c.adaptTTL(key, resourceType, payload)
 	}
 
 	jsonData, err := json.Marshal(entry)
@@ -140,13 +306,34 @@ func (c *Service) Set(key string, data interface{}, region, query string) error
 		return fmt.Errorf("failed to marshal cache entry: %w", err)
 	}
 
+	fileData, err := c.encodeCacheFile(jsonData)
+	if err != nil {
+		return err
+	}
+
 	// Write to temporary file first, then rename to avoid corruption
+	previousSize := fileSize(cacheFile)
 	tempFile := cacheFile + ".tmp"
-	if err := os.WriteFile(tempFile, jsonData, 0600); err != nil {
+	if err := os.WriteFile(tempFile, fileData, 0600); err != nil {
 		return fmt.Errorf("failed to write cache file: %w", err)
 	}
+	if err := os.Rename(tempFile, cacheFile); err != nil {
+		c.memoryRemove(key)
+		return err
+	}
 
-	return os.Rename(tempFile, cacheFile)
+	// Keep the decoded form in memory so memory hits return the same shape
+	// of data as disk hits
+	if c.memory != nil {
+		var stored Entry
+		if err := json.Unmarshal(jsonData, &stored); err != nil {
+			c.memoryRemove(key)
+			return nil
+		}
+		c.memoryPut(key, stored)
+	}
+	c.adjustSize(int64(len(fileData)) - previousSize)
+	return nil
 }
 
 // Delete removes cached data for the given key
@@ -155,7 +342,8 @@ func (c *Service) Delete(key string) error {
 	if err != nil {
 		return err
 	}
-	return os.Remove(cacheFile)
+	c.memoryRemove(key)
+	return c.removeCacheFile(cacheFile)
 }
 
 // Clear removes all cache files
@@ -165,6 +353,7 @@ func (c *Service) Clear() error {
 		return fmt.Errorf("failed to read cache directory: %w", err)
 	}
 
+	c.memoryPurge()
 	for _, file := range files {
 		if filepath.Ext(file.Name()) == ".json" {
 			if err := os.Remove(filepath.Join(c.cacheDir, file.Name())); err != nil {
@@ -173,16 +362,29 @@ func (c *Service) Clear() error {
 		}
 	}
 
+	if total, err := c.diskUsage(); err == nil {
+		c.resetSize(total)
+	}
 	return nil
 }
 
 // Cleanup removes expired cache files
 func (c *Service) Cleanup() error {
+	_, err := c.cleanupExpired()
+	return err
+}
+
+// cleanupExpired removes expired and unreadable cache files, returning how
+// many expired files were removed
+func (c *Service) cleanupExpired() (int, error) {
 	files, err := os.ReadDir(c.cacheDir)
 	if err != nil {
-		return fmt.Errorf("failed to read cache directory: %w", err)
+		return 0, fmt.Errorf("failed to read cache directory: %w", err)
 	}
 
+	removed := 0
+	var kept int64 // Size of the files left in place, to refresh the size gauge
+
 	for _, file := range files {
 		if filepath.Ext(file.Name()) != ".json" {
 			continue
@@ -200,29 +402,56 @@ func (c *Service) Cleanup() error {
 		}
 
 		var entry Entry
-		if unmarshalErr := json.Unmarshal(data, &entry); unmarshalErr != nil {
+		if unmarshalErr := c.unmarshalCacheFile(data, &entry); unmarshalErr != nil {
 			// Invalid cache file, remove it (ignore error as it's cleanup)
 			//nolint:errcheck // Cleanup operation, error is not critical
 			_ = os.Remove(cleanPath)
+			c.memoryRemove(strings.TrimSuffix(file.Name(), ".json"))
 			continue
 		}
 
-		if time.Since(entry.Timestamp) > c.ttl {
+		if !c.expired(&entry) {
+			kept += int64(len(data))
+		} else {
+			c.memoryRemove(strings.TrimSuffix(file.Name(), ".json"))
 			// Remove expired cache file
 			if removeErr := os.Remove(cleanPath); removeErr != nil {
 				fmt.Fprintf(os.Stderr, "Warning: failed to remove expired cache file %s: %v\n", file.Name(), removeErr)
+				kept += int64(len(data))
+				continue
 			}
+			removed++
 		}
 	}
 
-	return nil
+	c.resetSize(kept)
+	return removed, nil
+}
+
+// Stats summarizes the cache directory
+type Stats struct {
+	TotalFiles   int
+	ExpiredFiles int
+	DiskSize     int64 // Bytes on disk, after compression
+	LogicalSize  int64 // Bytes of JSON once decompressed
 }
 
-// GetCacheStats returns cache statistics
+// GetCacheStats returns cache statistics, with totalSize measured on disk
 func (c *Service) GetCacheStats() (totalFiles, expiredFiles int, totalSize int64, err error) {
+	stats, err := c.Stats()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return stats.TotalFiles, stats.ExpiredFiles, stats.DiskSize, nil
+}
+
+// Stats returns cache statistics, including both the on-disk and the
+// decompressed size of the entries
+func (c *Service) Stats() (Stats, error) {
+	var stats Stats
 	files, err := os.ReadDir(c.cacheDir)
 	if err != nil {
-		return 0, 0, 0, fmt.Errorf("failed to read cache directory: %w", err)
+		return stats, fmt.Errorf("failed to read cache directory: %w", err)
 	}
 
 	for _, file := range files {
@@ -230,12 +459,12 @@ func (c *Service) GetCacheStats() (totalFiles, expiredFiles int, totalSize int64
 			continue
 		}
 
-		totalFiles++
+		stats.TotalFiles++
 		info, err := file.Info()
 		if err != nil {
 			continue
 		}
-		totalSize += info.Size()
+		stats.DiskSize += info.Size()
 
 		cleanPath, pathErr := c.safeCachePath(filepath.Join(c.cacheDir, file.Name()))
 		if pathErr != nil {
@@ -245,18 +474,23 @@ func (c *Service) GetCacheStats() (totalFiles, expiredFiles int, totalSize int64
 		if err != nil {
 			continue
 		}
+		body, err := c.decodeCacheFile(data)
+		if err != nil {
+			continue
+		}
+		stats.LogicalSize += int64(len(body))
 
 		var entry Entry
-		if unmarshalErr := json.Unmarshal(data, &entry); unmarshalErr != nil {
+		if unmarshalErr := json.Unmarshal(body, &entry); unmarshalErr != nil {
 			continue
 		}
 
-		if time.Since(entry.Timestamp) > c.ttl {
-			expiredFiles++
+		if c.expired(&entry) {
+			stats.ExpiredFiles++
 		}
 	}
 
-	return totalFiles, expiredFiles, totalSize, nil
+	return stats, nil
 }
 
 // GenerateCacheKey generates a cache key based on region and query
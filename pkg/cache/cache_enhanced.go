@@ -2,7 +2,6 @@ package cache
 
 import (
 	"context"
-	"encoding/json"
 	"os"
 	"sync"
 	"time"
@@ -132,7 +131,6 @@ func (ec *EnhancedService) GetWithRefresh(key, region, query string, refreshFn R
 	}
 
 	// Check file size before reading
-	const maxCacheFileSize = 10 * 1024 * 1024 // 10 MB limit
 	fileInfo, err := os.Stat(cleanPath)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -165,7 +163,7 @@ func (ec *EnhancedService) GetWithRefresh(key, region, query string, refreshFn R
 	}
 
 	var entry EnhancedEntry
-	if unmarshalErr := json.Unmarshal(data, &entry); unmarshalErr != nil {
+	if unmarshalErr := ec.unmarshalCacheFile(data, &entry); unmarshalErr != nil {
 		ec.logger.Warn("Failed to unmarshal cache entry", logging.String("file", cleanPath), logging.String("error", unmarshalErr.Error()))
 		ec.recordMiss()
 		return nil, false, false
@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) Now() time.Time          { return c.t }
+func (c *fakeClock) Advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func setupTestCacheServiceWithClock(t *testing.T, dir string, clk Clock) *Service {
+	t.Helper()
+	svc, err := NewCacheServiceWithClock(dir, 1, clk)
+	if err != nil {
+		t.Fatalf("new cache service: %v", err)
+	}
+	return svc
+}
+
+func TestCacheExpiryFollowsClock(t *testing.T) {
+	dir := t.TempDir()
+	clk := &fakeClock{t: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	svc := setupTestCacheServiceWithClock(t, dir, clk)
+
+	if err := svc.Set("k", "v", "us-east-1", "q"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	clk.Advance(59 * time.Second)
+	if _, ok := svc.Get("k"); !ok {
+		t.Fatalf("Get() missed before the TTL elapsed")
+	}
+	if _, expired, _, err := svc.GetCacheStats(); err != nil || expired != 0 {
+		t.Fatalf("GetCacheStats() expired = %d, err = %v; want 0, nil", expired, err)
+	}
+
+	clk.Advance(2 * time.Second)
+	if _, expired, _, err := svc.GetCacheStats(); err != nil || expired != 1 {
+		t.Fatalf("GetCacheStats() expired = %d, err = %v; want 1, nil", expired, err)
+	}
+	if _, ok := svc.Get("k"); ok {
+		t.Errorf("Get() hit after the TTL elapsed")
+	}
+}
+
+func TestCacheCleanupFollowsClock(t *testing.T) {
+	dir := t.TempDir()
+	clk := &fakeClock{t: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	svc := setupTestCacheServiceWithClock(t, dir, clk)
+
+	if err := svc.Set("old", "v", "us-east-1", "q"); err != nil {
+		t.Fatalf("Set(old) error = %v", err)
+	}
+	clk.Advance(45 * time.Second)
+	if err := svc.Set("new", "v", "us-east-1", "q"); err != nil {
+		t.Fatalf("Set(new) error = %v", err)
+	}
+	clk.Advance(30 * time.Second)
+
+	if err := svc.Cleanup(); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "old.json")); !os.IsNotExist(err) {
+		t.Errorf("Cleanup() kept the expired entry: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "new.json")); err != nil {
+		t.Errorf("Cleanup() removed the fresh entry: %v", err)
+	}
+}
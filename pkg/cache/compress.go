@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// maxCacheFileSize limits both the on-disk size of a cache file and the size
+// of its JSON body once decompressed
+const maxCacheFileSize = 10 * 1024 * 1024 // 10 MB limit
+
+// compressedHeader prefixes cache files whose JSON body is gzipped. Plain
+// files start with the JSON body itself, so no header means uncompressed.
+var compressedHeader = []byte("gz1:")
+
+// EnableCompression gzips the JSON body of entries larger than thresholdBytes
+// before writing them. A non-positive threshold disables compression; entries
+// already written compressed stay readable either way.
+func (c *Service) EnableCompression(thresholdBytes int) {
+	if thresholdBytes < 0 {
+		thresholdBytes = 0
+	}
+	c.compressThreshold = thresholdBytes
+}
+
+// encodeCacheFile returns the file contents to write for jsonData,
+// compressed when it exceeds the compression threshold and then encrypted
+// when encryption is enabled
+func (c *Service) encodeCacheFile(jsonData []byte) ([]byte, error) {
+	if c.compressThreshold <= 0 || len(jsonData) <= c.compressThreshold {
+		return c.encrypt(jsonData)
+	}
+
+	var buf bytes.Buffer
+	buf.Write(compressedHeader)
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(jsonData); err != nil {
+		return nil, fmt.Errorf("failed to compress cache entry: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress cache entry: %w", err)
+	}
+	return c.encrypt(buf.Bytes())
+}
+
+// decodeCacheFile returns the JSON body of a cache file, decrypting it and
+// then decompressing it when it carries the compressed header
+func (c *Service) decodeCacheFile(data []byte) ([]byte, error) {
+	data, err := c.decrypt(data)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(data, compressedHeader) {
+		return data, nil
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(data[len(compressedHeader):]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress cache entry: %w", err)
+	}
+	defer func() { _ = zr.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(zr, maxCacheFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress cache entry: %w", err)
+	}
+	if len(body) > maxCacheFileSize {
+		return nil, fmt.Errorf("decompressed cache entry exceeds size limit (%d bytes)", maxCacheFileSize)
+	}
+	return body, nil
+}
+
+// unmarshalCacheFile decodes the contents of a cache file into v, so a
+// corrupted gzip stream or undecryptable file is reported the same way as
+// corrupted JSON
+func (c *Service) unmarshalCacheFile(data []byte, v interface{}) error {
+	body, err := c.decodeCacheFile(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
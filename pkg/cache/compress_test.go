@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func largePayload() []map[string]string {
+	payload := make([]map[string]string, 200)
+	for i := range payload {
+		payload[i] = map[string]string{
+			"InstanceId": "i-0123456789abcdef0",
+			"State":      "running",
+			"Tags":       strings.Repeat("Environment=production,", 5),
+		}
+	}
+	return payload
+}
+
+func TestCompressionShrinksLargeEntries(t *testing.T) {
+	dir := t.TempDir()
+	svc := setupTestCacheService(t, dir)
+	svc.EnableCompression(4096)
+
+	payload := largePayload()
+	if err := svc.Set("large", payload, "us-east-1", "q"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	info, err := os.Stat(filepath.Join(dir, "large.json"))
+	if err != nil {
+		t.Fatalf("stat cache file: %v", err)
+	}
+	if info.Size() >= int64(len(raw)) {
+		t.Errorf("on-disk size = %d, want less than the raw JSON size %d", info.Size(), len(raw))
+	}
+
+	v, ok := svc.Get("large")
+	if !ok {
+		t.Fatalf("Get() missed a compressed entry")
+	}
+	if items, isSlice := v.([]interface{}); !isSlice || len(items) != len(payload) {
+		t.Errorf("Get() = %T with unexpected contents", v)
+	}
+
+	stats, err := svc.Stats()
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	if stats.DiskSize != info.Size() || stats.LogicalSize <= stats.DiskSize {
+		t.Errorf("Stats() = %+v, want disk size %d and a larger logical size", stats, info.Size())
+	}
+}
+
+func TestCompressionLeavesSmallEntriesPlain(t *testing.T) {
+	dir := t.TempDir()
+	svc := setupTestCacheService(t, dir)
+	svc.EnableCompression(4096)
+
+	if err := svc.Set("small", "v", "us-east-1", "q"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "small.json"))
+	if err != nil {
+		t.Fatalf("read cache file: %v", err)
+	}
+	if !json.Valid(data) {
+		t.Errorf("small entry was not stored as plain JSON: %q", data)
+	}
+}
+
+func TestCorruptedCompressedEntryIsMissAndCleanedUp(t *testing.T) {
+	dir := t.TempDir()
+	svc := setupTestCacheService(t, dir)
+	svc.EnableCompression(4096)
+
+	if err := svc.Set("large", largePayload(), "us-east-1", "q"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	path := filepath.Join(dir, "large.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read cache file: %v", err)
+	}
+	// Truncate the gzip stream partway through
+	if err := os.WriteFile(path, data[:len(data)/2], 0600); err != nil {
+		t.Fatalf("truncate cache file: %v", err)
+	}
+
+	if _, ok := svc.Get("large"); ok {
+		t.Errorf("Get() hit a truncated compressed entry")
+	}
+	if err := svc.Cleanup(); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Cleanup() kept the corrupted entry: %v", err)
+	}
+}
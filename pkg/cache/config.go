@@ -0,0 +1,33 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/config"
+)
+
+// NewCacheServiceFromConfig creates a cache service using the cache section of the
+// application config, including any per-resource-type TTL overrides. Cache
+// files are encrypted when AWS_SSM_CACHE_KEY is set.
+func NewCacheServiceFromConfig(cfg *config.Config) (*Service, error) {
+	svc, err := NewCacheService(cfg.Cache.CacheDir, cfg.Cache.TTLMinutes)
+	if err != nil {
+		return nil, err
+	}
+
+	svc.SetResourceTTL(ResourceEC2, time.Duration(cfg.Cache.EC2TTLMinutes)*time.Minute)
+	svc.SetResourceTTL(ResourceEKS, time.Duration(cfg.Cache.EKSTTLMinutes)*time.Minute)
+	svc.SetResourceTTL(ResourceASG, time.Duration(cfg.Cache.ASGTTLMinutes)*time.Minute)
+	svc.SetResourceTTL(ResourceNodeGroup, time.Duration(cfg.Cache.NodeGroupTTLMinutes)*time.Minute)
+	if cfg.Cache.AdaptiveTTL {
+		svc.EnableAdaptiveTTL(time.Duration(cfg.Cache.AdaptiveTTLMin)*time.Minute, time.Duration(cfg.Cache.AdaptiveTTLMax)*time.Minute)
+	}
+	svc.EnableCompression(cfg.Cache.CompressThreshold)
+	if key, ok := CacheKeyFromEnv(); ok {
+		if err := svc.enableEncryption(key); err != nil {
+			return nil, err
+		}
+	}
+
+	return svc, nil
+}
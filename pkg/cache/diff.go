@@ -0,0 +1,129 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// instanceIDField is the field used to match instances across snapshots
+const instanceIDField = "InstanceID"
+
+// FieldChange describes a single field that differs between two snapshots
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// InstanceChange describes an instance present in both snapshots whose fields differ
+type InstanceChange struct {
+	InstanceID string        `json:"instance_id"`
+	Changes    []FieldChange `json:"changes"`
+}
+
+// SnapshotDiff is the categorized difference between two instance-list snapshots
+type SnapshotDiff struct {
+	Added   []string         `json:"added"`
+	Removed []string         `json:"removed"`
+	Changed []InstanceChange `json:"changed"`
+}
+
+// IsEmpty reports whether the two snapshots were identical
+func (d *SnapshotDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffInstanceSnapshots compares two cached instance lists and reports which
+// instances were added, removed, or changed between the first and second snapshot.
+// Instances are matched by InstanceID; results are sorted for stable output.
+func DiffInstanceSnapshots(before, after interface{}) (*SnapshotDiff, error) {
+	oldSet, err := indexInstances(before)
+	if err != nil {
+		return nil, fmt.Errorf("invalid first snapshot: %w", err)
+	}
+	newSet, err := indexInstances(after)
+	if err != nil {
+		return nil, fmt.Errorf("invalid second snapshot: %w", err)
+	}
+
+	diff := &SnapshotDiff{
+		Added:   []string{},
+		Removed: []string{},
+		Changed: []InstanceChange{},
+	}
+
+	for id, newInst := range newSet {
+		oldInst, ok := oldSet[id]
+		if !ok {
+			diff.Added = append(diff.Added, id)
+			continue
+		}
+		if changes := diffFields(oldInst, newInst); len(changes) > 0 {
+			diff.Changed = append(diff.Changed, InstanceChange{InstanceID: id, Changes: changes})
+		}
+	}
+
+	for id := range oldSet {
+		if _, ok := newSet[id]; !ok {
+			diff.Removed = append(diff.Removed, id)
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		return diff.Changed[i].InstanceID < diff.Changed[j].InstanceID
+	})
+
+	return diff, nil
+}
+
+// indexInstances normalizes a snapshot payload into instance records keyed by InstanceID.
+// The payload may be typed data or the generic form produced by decoding a cache file.
+func indexInstances(data interface{}) (map[string]map[string]interface{}, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	var records []map[string]interface{}
+	if err := json.Unmarshal(raw, &records); err != nil {
+		return nil, fmt.Errorf("snapshot is not an instance list: %w", err)
+	}
+
+	index := make(map[string]map[string]interface{}, len(records))
+	for i, record := range records {
+		id, _ := record[instanceIDField].(string)
+		if id == "" {
+			return nil, fmt.Errorf("entry %d has no %s", i, instanceIDField)
+		}
+		index[id] = record
+	}
+	return index, nil
+}
+
+// diffFields returns the sorted list of fields whose values differ between two records
+func diffFields(oldRecord, newRecord map[string]interface{}) []FieldChange {
+	fields := make(map[string]struct{}, len(oldRecord)+len(newRecord))
+	for field := range oldRecord {
+		fields[field] = struct{}{}
+	}
+	for field := range newRecord {
+		fields[field] = struct{}{}
+	}
+
+	var changes []FieldChange
+	for field := range fields {
+		oldValue, newValue := oldRecord[field], newRecord[field]
+		if !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, FieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
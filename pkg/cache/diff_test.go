@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"testing"
+)
+
+type snapshotInstance struct {
+	InstanceID string
+	Name       string
+	State      string
+	PrivateIP  string
+	Tags       map[string]string
+}
+
+func TestDiffInstanceSnapshots(t *testing.T) {
+	before := []snapshotInstance{
+		{InstanceID: "i-aaa", Name: "web-1", State: "running", PrivateIP: "10.0.0.1"},
+		{InstanceID: "i-bbb", Name: "web-2", State: "running", PrivateIP: "10.0.0.2", Tags: map[string]string{"Env": "dev"}},
+		{InstanceID: "i-ccc", Name: "db-1", State: "running", PrivateIP: "10.0.0.3"},
+	}
+	after := []snapshotInstance{
+		{InstanceID: "i-aaa", Name: "web-1", State: "running", PrivateIP: "10.0.0.1"},
+		{InstanceID: "i-bbb", Name: "web-2", State: "stopped", PrivateIP: "10.0.0.2", Tags: map[string]string{"Env": "prod"}},
+		{InstanceID: "i-ddd", Name: "web-3", State: "pending", PrivateIP: "10.0.0.4"},
+		{InstanceID: "i-eee", Name: "web-4", State: "pending", PrivateIP: "10.0.0.5"},
+	}
+
+	diff, err := DiffInstanceSnapshots(before, after)
+	if err != nil {
+		t.Fatalf("diff failed: %v", err)
+	}
+
+	if len(diff.Added) != 2 || diff.Added[0] != "i-ddd" || diff.Added[1] != "i-eee" {
+		t.Errorf("unexpected added: %v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0] != "i-ccc" {
+		t.Errorf("unexpected removed: %v", diff.Removed)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].InstanceID != "i-bbb" {
+		t.Fatalf("unexpected changed: %+v", diff.Changed)
+	}
+
+	changes := diff.Changed[0].Changes
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 field changes, got %+v", changes)
+	}
+	if changes[0].Field != "State" || changes[0].Old != "running" || changes[0].New != "stopped" {
+		t.Errorf("unexpected state change: %+v", changes[0])
+	}
+	if changes[1].Field != "Tags" {
+		t.Errorf("expected tags change, got %+v", changes[1])
+	}
+}
+
+func TestDiffInstanceSnapshotsFromCacheEntries(t *testing.T) {
+	svc, err := NewCacheService(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("new cache service: %v", err)
+	}
+
+	first := []snapshotInstance{{InstanceID: "i-aaa", State: "running"}}
+	second := []snapshotInstance{{InstanceID: "i-aaa", State: "running"}}
+	if err := svc.Set("snap-a", first, "us-east-1", "all"); err != nil {
+		t.Fatalf("set snap-a: %v", err)
+	}
+	if err := svc.Set("snap-b", second, "us-east-1", "all"); err != nil {
+		t.Fatalf("set snap-b: %v", err)
+	}
+
+	// Peek ignores the TTL so expired snapshots can still be compared
+	a, err := svc.Peek("snap-a")
+	if err != nil {
+		t.Fatalf("peek snap-a: %v", err)
+	}
+	b, err := svc.Peek("snap-b")
+	if err != nil {
+		t.Fatalf("peek snap-b: %v", err)
+	}
+
+	diff, err := DiffInstanceSnapshots(a.Data, b.Data)
+	if err != nil {
+		t.Fatalf("diff failed: %v", err)
+	}
+	if !diff.IsEmpty() {
+		t.Errorf("expected identical snapshots, got %+v", diff)
+	}
+
+	if _, err := svc.Peek("missing"); err == nil {
+		t.Error("expected error for missing entry")
+	}
+}
+
+func TestDiffInstanceSnapshotsRejectsNonInstanceData(t *testing.T) {
+	tests := []struct {
+		name string
+		data interface{}
+	}{
+		{"scalar", "not a list"},
+		{"missing id", []map[string]string{{"Name": "web"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DiffInstanceSnapshots(tt.data, []snapshotInstance{}); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
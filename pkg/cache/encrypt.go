@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// CacheKeyEnvVar names the environment variable whose value, hashed with
+// SHA-256, becomes the cache encryption key
+const CacheKeyEnvVar = "AWS_SSM_CACHE_KEY"
+
+// encryptedHeader prefixes cache files encrypted with AES-256-GCM; the nonce
+// follows the header, then the sealed file body
+var encryptedHeader = []byte("enc1:")
+
+// errCacheDecrypt marks cache files that could not be decrypted
+var errCacheDecrypt = errors.New("failed to decrypt cache entry")
+
+// NewEncryptedCacheService creates a cache service that encrypts every cache
+// file with AES-256-GCM using key, which must be 32 bytes long. Files that
+// cannot be decrypted with key are treated as misses and removed.
+func NewEncryptedCacheService(cacheDir string, ttlMinutes int, key []byte) (*Service, error) {
+	svc, err := NewCacheService(cacheDir, ttlMinutes)
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.enableEncryption(key); err != nil {
+		return nil, err
+	}
+	return svc, nil
+}
+
+// CacheKeyFromEnv derives a cache encryption key from AWS_SSM_CACHE_KEY,
+// reporting false when the variable is unset or empty
+func CacheKeyFromEnv() ([]byte, bool) {
+	passphrase := os.Getenv(CacheKeyEnvVar)
+	if passphrase == "" {
+		return nil, false
+	}
+	sum := sha256.Sum256([]byte(passphrase))
+	return sum[:], true
+}
+
+// enableEncryption makes the service encrypt and decrypt cache files with key
+func (c *Service) enableEncryption(key []byte) error {
+	if len(key) != 32 {
+		return fmt.Errorf("cache encryption key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return fmt.Errorf("failed to create cache cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return fmt.Errorf("failed to create cache cipher: %w", err)
+	}
+	c.aead = aead
+	return nil
+}
+
+// encrypt seals body when encryption is enabled
+func (c *Service) encrypt(body []byte) ([]byte, error) {
+	if c.aead == nil {
+		return body, nil
+	}
+
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate cache nonce: %w", err)
+	}
+	out := make([]byte, 0, len(encryptedHeader)+len(nonce)+len(body)+c.aead.Overhead())
+	out = append(out, encryptedHeader...)
+	out = append(out, nonce...)
+	return c.aead.Seal(out, nonce, body, nil), nil
+}
+
+// decrypt opens data when encryption is enabled. With encryption enabled,
+// files that are not encrypted, or were encrypted with another key, fail.
+func (c *Service) decrypt(data []byte) ([]byte, error) {
+	if c.aead == nil {
+		if bytes.HasPrefix(data, encryptedHeader) {
+			return nil, fmt.Errorf("%w: no cache key configured", errCacheDecrypt)
+		}
+		return data, nil
+	}
+
+	if !bytes.HasPrefix(data, encryptedHeader) {
+		return nil, fmt.Errorf("%w: entry is not encrypted", errCacheDecrypt)
+	}
+	sealed := data[len(encryptedHeader):]
+	if len(sealed) < c.aead.NonceSize() {
+		return nil, fmt.Errorf("%w: entry is truncated", errCacheDecrypt)
+	}
+	nonce, ciphertext := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	body, err := c.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errCacheDecrypt, err)
+	}
+	return body, nil
+}
@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testCacheKey(passphrase string) []byte {
+	sum := sha256.Sum256([]byte(passphrase))
+	return sum[:]
+}
+
+func TestEncryptedCacheRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	svc, err := NewEncryptedCacheService(dir, 1, testCacheKey("secret"))
+	if err != nil {
+		t.Fatalf("NewEncryptedCacheService() error = %v", err)
+	}
+	svc.EnableCompression(4096)
+
+	for key, data := range map[string]interface{}{
+		"small": map[string]string{"PrivateIpAddress": "10.0.0.12"},
+		"large": largePayload(),
+	} {
+		if err := svc.Set(key, data, "us-east-1", "q"); err != nil {
+			t.Fatalf("Set(%s) error = %v", key, err)
+		}
+		raw, err := os.ReadFile(filepath.Join(dir, key+".json"))
+		if err != nil {
+			t.Fatalf("read cache file: %v", err)
+		}
+		if !bytes.HasPrefix(raw, encryptedHeader) || bytes.Contains(raw, []byte("us-east-1")) {
+			t.Errorf("%s entry is not encrypted on disk", key)
+		}
+		if _, ok := svc.Get(key); !ok {
+			t.Errorf("Get(%s) missed an encrypted entry", key)
+		}
+	}
+}
+
+func TestEncryptedCacheWrongKeyIsMiss(t *testing.T) {
+	dir := t.TempDir()
+	writer, err := NewEncryptedCacheService(dir, 1, testCacheKey("secret"))
+	if err != nil {
+		t.Fatalf("NewEncryptedCacheService() error = %v", err)
+	}
+	if err := writer.Set("k", "v", "us-east-1", "q"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	reader, err := NewEncryptedCacheService(dir, 1, testCacheKey("other"))
+	if err != nil {
+		t.Fatalf("NewEncryptedCacheService() error = %v", err)
+	}
+	if _, ok := reader.Get("k"); ok {
+		t.Fatalf("Get() hit with the wrong key")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "k.json")); !os.IsNotExist(err) {
+		t.Errorf("undecryptable entry was not removed: %v", err)
+	}
+}
+
+func TestEncryptedCacheRejectsPlainEntries(t *testing.T) {
+	dir := t.TempDir()
+	if err := setupTestCacheService(t, dir).Set("k", "v", "us-east-1", "q"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	svc, err := NewEncryptedCacheService(dir, 1, testCacheKey("secret"))
+	if err != nil {
+		t.Fatalf("NewEncryptedCacheService() error = %v", err)
+	}
+	if _, ok := svc.Get("k"); ok {
+		t.Errorf("Get() served a plaintext entry in encrypted mode")
+	}
+}
+
+func TestNewEncryptedCacheServiceKeyLength(t *testing.T) {
+	if _, err := NewEncryptedCacheService(t.TempDir(), 1, []byte("short")); err == nil {
+		t.Errorf("expected an error for a key that is not 32 bytes")
+	}
+}
+
+func TestCacheKeyFromEnv(t *testing.T) {
+	t.Setenv(CacheKeyEnvVar, "")
+	if _, ok := CacheKeyFromEnv(); ok {
+		t.Errorf("CacheKeyFromEnv() reported a key with the variable empty")
+	}
+
+	t.Setenv(CacheKeyEnvVar, "secret")
+	key, ok := CacheKeyFromEnv()
+	if !ok || !bytes.Equal(key, testCacheKey("secret")) {
+		t.Errorf("CacheKeyFromEnv() = %x, %v; want the SHA-256 of the variable", key, ok)
+	}
+}
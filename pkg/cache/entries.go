@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// EntryInfo describes one cache entry without its data
+type EntryInfo struct {
+	Key              string       `json:"key"`
+	Region           string       `json:"region"`
+	Query            string       `json:"query"`
+	ResourceType     ResourceType `json:"resource_type,omitempty"`
+	CachedAt         time.Time    `json:"cached_at"`
+	ExpiresAt        time.Time    `json:"expires_at"`
+	Expired          bool         `json:"expired"`
+	SizeBytes        int64        `json:"size_bytes"`
+	LogicalSizeBytes int64        `json:"logical_size_bytes"` // Bytes of JSON once decompressed
+}
+
+// ListEntries describes every readable cache entry, sorted by key. Files that
+// cannot be read or parsed are skipped, as in GetCacheStats.
+func (c *Service) ListEntries() ([]EntryInfo, error) {
+	return c.listEntries("")
+}
+
+// GetByRegion describes the readable cache entries stored for region, sorted
+// by key. The region matches case-insensitively, as in ClearRegion.
+func (c *Service) GetByRegion(region string) ([]EntryInfo, error) {
+	region = strings.ToLower(strings.TrimSpace(region))
+	if region == "" {
+		return nil, fmt.Errorf("region cannot be empty")
+	}
+	return c.listEntries(region)
+}
+
+// listEntries describes the cache entries for region, or all entries when
+// region is empty
+func (c *Service) listEntries(region string) ([]EntryInfo, error) {
+	files, err := os.ReadDir(c.cacheDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	entries := []EntryInfo{}
+	for _, file := range files {
+		if filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			continue
+		}
+
+		cleanPath, pathErr := c.safeCachePath(filepath.Join(c.cacheDir, file.Name()))
+		if pathErr != nil {
+			continue
+		}
+		data, err := os.ReadFile(cleanPath)
+		if err != nil {
+			continue
+		}
+
+		var entry struct {
+			Timestamp    time.Time     `json:"timestamp"`
+			Region       string        `json:"region"`
+			Query        string        `json:"query"`
+			ResourceType ResourceType  `json:"resource_type"`
+			TTL          time.Duration `json:"ttl"`
+			ExpiresAt    *time.Time    `json:"expires_at"`
+		}
+		body, decodeErr := c.decodeCacheFile(data)
+		if decodeErr == nil {
+			decodeErr = json.Unmarshal(body, &entry)
+		}
+		if decodeErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping unreadable cache entry %s: %v\n", file.Name(), decodeErr)
+			continue
+		}
+		if region != "" && !strings.EqualFold(entry.Region, region) {
+			continue
+		}
+
+		expiresAt := c.expiresAt(&Entry{
+			Timestamp:    entry.Timestamp,
+			ResourceType: entry.ResourceType,
+			TTL:          entry.TTL,
+			ExpiresAt:    entry.ExpiresAt,
+		})
+		entries = append(entries, EntryInfo{
+			Key:              strings.TrimSuffix(file.Name(), ".json"),
+			Region:           entry.Region,
+			Query:            entry.Query,
+			ResourceType:     entry.ResourceType,
+			CachedAt:         entry.Timestamp,
+			ExpiresAt:        expiresAt,
+			Expired:          c.clock.Now().After(expiresAt),
+			SizeBytes:        info.Size(),
+			LogicalSizeBytes: int64(len(body)),
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries, nil
+}
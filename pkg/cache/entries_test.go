@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestListEntries(t *testing.T) {
+	dir := t.TempDir()
+	svc, err := NewCacheService(dir, 10)
+	if err != nil {
+		t.Fatalf("new cache service: %v", err)
+	}
+	svc.SetResourceTTL(ResourceASG, time.Minute)
+
+	if err := svc.Set("instances_us-east-1_all", []string{"i-1"}, "us-east-1", "all"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if err := svc.SetWithResourceType("asgs_eu-west-1_all", []string{"web"}, ResourceASG, "eu-west-1", "all"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0o600); err != nil {
+		t.Fatalf("write broken entry: %v", err)
+	}
+
+	entries, err := svc.ListEntries()
+	if err != nil {
+		t.Fatalf("list entries: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2 (broken file skipped): %+v", len(entries), entries)
+	}
+
+	asg, inst := entries[0], entries[1]
+	if asg.Key != "asgs_eu-west-1_all" || inst.Key != "instances_us-east-1_all" {
+		t.Fatalf("entries not sorted by key: %s, %s", asg.Key, inst.Key)
+	}
+	if asg.Region != "eu-west-1" || asg.ResourceType != ResourceASG || asg.SizeBytes <= 0 || asg.LogicalSizeBytes <= 0 {
+		t.Errorf("unexpected asg entry: %+v", asg)
+	}
+	if got := asg.ExpiresAt.Sub(asg.CachedAt); got != time.Minute {
+		t.Errorf("asg entry TTL = %v, want the resource override of 1m", got)
+	}
+	if got := inst.ExpiresAt.Sub(inst.CachedAt); got != 10*time.Minute {
+		t.Errorf("instance entry TTL = %v, want the global 10m", got)
+	}
+	if asg.Expired || inst.Expired {
+		t.Errorf("fresh entries reported expired: %+v", entries)
+	}
+}
+
+func TestGetByRegion(t *testing.T) {
+	dir := t.TempDir()
+	svc, err := NewCacheService(dir, 10)
+	if err != nil {
+		t.Fatalf("new cache service: %v", err)
+	}
+
+	fixtures := []struct{ key, region string }{
+		{"instances_us-east-1_all", "us-east-1"},
+		{"clusters_us-east-1_all", "us-east-1"},
+		{"instances_eu-west-1_all", "eu-west-1"},
+		{"instances_ap-south-1_all", "ap-south-1"},
+	}
+	for _, f := range fixtures {
+		if err := svc.Set(f.key, []string{"i-1"}, f.region, "all"); err != nil {
+			t.Fatalf("set %s: %v", f.key, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0o600); err != nil {
+		t.Fatalf("write broken entry: %v", err)
+	}
+
+	tests := []struct {
+		region   string
+		wantKeys []string
+	}{
+		{"us-east-1", []string{"clusters_us-east-1_all", "instances_us-east-1_all"}},
+		{"EU-WEST-1", []string{"instances_eu-west-1_all"}},
+		{"us-west-2", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.region, func(t *testing.T) {
+			entries, err := svc.GetByRegion(tt.region)
+			if err != nil {
+				t.Fatalf("GetByRegion() error = %v", err)
+			}
+			if len(entries) != len(tt.wantKeys) {
+				t.Fatalf("GetByRegion() returned %d entries, want %d: %+v", len(entries), len(tt.wantKeys), entries)
+			}
+			for i, entry := range entries {
+				if entry.Key != tt.wantKeys[i] || entry.Query != "all" || entry.SizeBytes <= 0 || entry.ExpiresAt.IsZero() {
+					t.Errorf("entry %d = %+v, want key %s with metadata", i, entry, tt.wantKeys[i])
+				}
+			}
+		})
+	}
+
+	if _, err := svc.GetByRegion(" "); err == nil {
+		t.Errorf("expected an error for an empty region")
+	}
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSetWithTTLExpiresEntriesIndependently(t *testing.T) {
+	dir := t.TempDir()
+	clk := &fakeClock{t: time.Now()}
+	svc := setupTestCacheServiceWithClock(t, dir, clk)
+
+	if err := svc.SetWithTTL("ec2", "instances", "us-east-1", "q", 5*time.Minute); err != nil {
+		t.Fatalf("SetWithTTL(ec2) error = %v", err)
+	}
+	if err := svc.SetWithTTL("eks", "clusters", "us-east-1", "q", 24*time.Hour); err != nil {
+		t.Fatalf("SetWithTTL(eks) error = %v", err)
+	}
+
+	clk.Advance(10 * time.Minute)
+	if _, ok := svc.Get("ec2"); ok {
+		t.Errorf("Get(ec2) hit after its 5m TTL")
+	}
+	if _, ok := svc.Get("eks"); !ok {
+		t.Errorf("Get(eks) missed within its 24h TTL")
+	}
+
+	clk.Advance(24 * time.Hour)
+	if err := svc.Cleanup(); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "eks.json")); !os.IsNotExist(err) {
+		t.Errorf("Cleanup() kept the expired entry: %v", err)
+	}
+}
+
+func TestSetWithTTLOutlivesServiceTTL(t *testing.T) {
+	clk := &fakeClock{t: time.Now()}
+	svc := setupTestCacheServiceWithClock(t, t.TempDir(), clk)
+
+	if err := svc.Set("default", "v", "r", "q"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := svc.SetWithTTL("long", "v", "r", "q", time.Hour); err != nil {
+		t.Fatalf("SetWithTTL() error = %v", err)
+	}
+
+	// The service TTL is one minute
+	clk.Advance(2 * time.Minute)
+	if _, ok := svc.Get("default"); ok {
+		t.Errorf("Get(default) hit after the service TTL")
+	}
+	if _, ok := svc.Get("long"); !ok {
+		t.Errorf("Get(long) missed within its own TTL")
+	}
+}
+
+func TestEntriesWithoutExpiryUseDefaultTTL(t *testing.T) {
+	dir := t.TempDir()
+	clk := &fakeClock{t: time.Now()}
+	svc := setupTestCacheServiceWithClock(t, dir, clk)
+
+	// A file written before per-entry TTLs existed has no expires_at field
+	legacy := `{"data":"v","timestamp":"` + clk.t.Add(-30*time.Second).Format(time.RFC3339Nano) + `","region":"r","query":"q"}`
+	if err := os.WriteFile(filepath.Join(dir, "legacy.json"), []byte(legacy), 0600); err != nil {
+		t.Fatalf("write legacy entry: %v", err)
+	}
+	if _, ok := svc.Get("legacy"); !ok {
+		t.Fatalf("Get(legacy) missed within the default TTL")
+	}
+
+	clk.Advance(time.Minute)
+	if _, ok := svc.Get("legacy"); ok {
+		t.Errorf("Get(legacy) hit after the default TTL")
+	}
+}
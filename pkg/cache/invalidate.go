@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// InvalidateInstances removes cache entries for the given region that reference
+// any of the instance IDs, so changed instances are reloaded on next use.
+// It returns the number of entries removed.
+func (c *Service) InvalidateInstances(region string, instanceIDs []string) (int, error) {
+	if len(instanceIDs) == 0 {
+		return 0, nil
+	}
+
+	files, err := os.ReadDir(c.cacheDir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	removed := 0
+	for _, file := range files {
+		if filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		cleanPath, pathErr := c.safeCachePath(filepath.Join(c.cacheDir, file.Name()))
+		if pathErr != nil {
+			continue
+		}
+		data, err := os.ReadFile(cleanPath)
+		if err != nil {
+			continue
+		}
+
+		var entry struct {
+			Region string          `json:"region"`
+			Data   json.RawMessage `json:"data"`
+		}
+		if unmarshalErr := c.unmarshalCacheFile(data, &entry); unmarshalErr != nil {
+			continue
+		}
+		if region != "" && entry.Region != region {
+			continue
+		}
+		if !referencesAny(string(entry.Data), instanceIDs) {
+			continue
+		}
+
+		if removeErr := c.removeCacheFile(cleanPath); removeErr != nil && !os.IsNotExist(removeErr) {
+			fmt.Fprintf(os.Stderr, "Warning: failed to remove cache file %s: %v\n", file.Name(), removeErr)
+			continue
+		}
+		c.memoryRemove(strings.TrimSuffix(file.Name(), ".json"))
+		removed++
+	}
+
+	return removed, nil
+}
+
+// referencesAny reports whether data contains any of the quoted IDs
+func referencesAny(data string, ids []string) bool {
+	for _, id := range ids {
+		if id != "" && strings.Contains(data, `"`+id+`"`) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClearRegion removes every cache entry stored for region, leaving other
+// regions' entries intact. It returns the number of entries removed.
+func (c *Service) ClearRegion(region string) (int, error) {
+	region = strings.ToLower(strings.TrimSpace(region))
+	if region == "" {
+		return 0, fmt.Errorf("region cannot be empty")
+	}
+
+	files, err := os.ReadDir(c.cacheDir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	removed := 0
+	for _, file := range files {
+		if filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		cleanPath, pathErr := c.safeCachePath(filepath.Join(c.cacheDir, file.Name()))
+		if pathErr != nil {
+			continue
+		}
+		data, err := os.ReadFile(cleanPath)
+		if err != nil {
+			continue
+		}
+
+		var entry struct {
+			Region string `json:"region"`
+		}
+		if unmarshalErr := c.unmarshalCacheFile(data, &entry); unmarshalErr != nil {
+			continue
+		}
+		if !strings.EqualFold(entry.Region, region) {
+			continue
+		}
+
+		if removeErr := c.removeCacheFile(cleanPath); removeErr != nil && !os.IsNotExist(removeErr) {
+			fmt.Fprintf(os.Stderr, "Warning: failed to remove cache file %s: %v\n", file.Name(), removeErr)
+			continue
+		}
+		c.memoryRemove(strings.TrimSuffix(file.Name(), ".json"))
+		removed++
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,89 @@
+package cache
+
+import "testing"
+
+func TestInvalidateInstances(t *testing.T) {
+	svc, err := NewCacheService(t.TempDir(), 10)
+	if err != nil {
+		t.Fatalf("new cache service: %v", err)
+	}
+
+	type inst struct{ InstanceID string }
+	mustSet := func(key, region string, data interface{}) {
+		t.Helper()
+		if err := svc.Set(key, data, region, "q"); err != nil {
+			t.Fatalf("set %s: %v", key, err)
+		}
+	}
+	mustSet("instance_us-east-1_i-001", "us-east-1", inst{"i-001"})
+	mustSet("instances_us-east-1_all", "us-east-1", []inst{{"i-001"}, {"i-009"}})
+	mustSet("instances_us-east-1_other", "us-east-1", []inst{{"i-009"}})
+	mustSet("instances_us-west-2_all", "us-west-2", []inst{{"i-001"}})
+	mustSet("instances_us-east-1_prefix", "us-east-1", []inst{{"i-0011"}})
+
+	removed, err := svc.InvalidateInstances("us-east-1", []string{"i-001"})
+	if err != nil {
+		t.Fatalf("invalidate: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("removed = %d, want 2", removed)
+	}
+
+	for key, wantHit := range map[string]bool{
+		"instance_us-east-1_i-001":   false,
+		"instances_us-east-1_all":    false,
+		"instances_us-east-1_other":  true,
+		"instances_us-west-2_all":    true,
+		"instances_us-east-1_prefix": true,
+	} {
+		if _, ok := svc.Get(key); ok != wantHit {
+			t.Errorf("Get(%s) hit = %v, want %v", key, ok, wantHit)
+		}
+	}
+}
+
+func TestClearRegion(t *testing.T) {
+	svc, err := NewCacheService(t.TempDir(), 10)
+	if err != nil {
+		t.Fatalf("new cache service: %v", err)
+	}
+
+	entries := map[string]string{
+		"instances_us-east-1_all":  "us-east-1",
+		"instances_us-east-1_web":  "us-east-1",
+		"instances_us-west-2_all":  "us-west-2",
+		"asgs_eu-west-1_all":       "eu-west-1",
+		"nodegroups_eu-west-1_all": "eu-west-1",
+	}
+	for key, region := range entries {
+		if err := svc.Set(key, []string{key}, region, "q"); err != nil {
+			t.Fatalf("set %s: %v", key, err)
+		}
+	}
+
+	removed, err := svc.ClearRegion(" US-EAST-1 ")
+	if err != nil {
+		t.Fatalf("clear region: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("removed = %d, want 2", removed)
+	}
+
+	total, _, _, err := svc.GetCacheStats()
+	if err != nil {
+		t.Fatalf("cache stats: %v", err)
+	}
+	if total != 3 {
+		t.Errorf("total files = %d, want 3", total)
+	}
+	for key, region := range entries {
+		_, ok := svc.Get(key)
+		if want := region != "us-east-1"; ok != want {
+			t.Errorf("Get(%s) hit = %v, want %v", key, ok, want)
+		}
+	}
+
+	if _, err := svc.ClearRegion(""); err == nil {
+		t.Error("expected empty region to fail")
+	}
+}
@@ -0,0 +1,49 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/metrics"
+)
+
+// StartBackgroundCleanup runs Cleanup every interval in a goroutine until ctx
+// is cancelled or the returned stop function is called. stop waits for the
+// goroutine to exit and is safe to call more than once. Nothing runs in the
+// background unless a caller starts it here.
+func (c *Service) StartBackgroundCleanup(ctx context.Context, interval time.Duration) (stop func()) {
+	if interval <= 0 {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				removed, err := c.cleanupExpired()
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: background cache cleanup failed: %v\n", err)
+					continue
+				}
+				metrics.CacheExpiredRemoved.Inc(float64(removed))
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(cancel)
+		<-done
+	}
+}
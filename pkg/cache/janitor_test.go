@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/metrics"
+)
+
+func TestBackgroundCleanupRemovesExpiredEntries(t *testing.T) {
+	dir := t.TempDir()
+	clk := &fakeClock{t: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	svc := setupTestCacheServiceWithClock(t, dir, clk)
+
+	if err := svc.Set("old", "v", "us-east-1", "q"); err != nil {
+		t.Fatalf("Set(old) error = %v", err)
+	}
+	clk.Advance(45 * time.Second)
+	if err := svc.Set("new", "v", "us-east-1", "q"); err != nil {
+		t.Fatalf("Set(new) error = %v", err)
+	}
+	clk.Advance(30 * time.Second)
+
+	before := metrics.CacheExpiredRemoved.GetValue()
+	stop := svc.StartBackgroundCleanup(context.Background(), 10*time.Millisecond)
+	defer stop()
+
+	oldPath := filepath.Join(dir, "old.json")
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, err := os.Stat(oldPath); os.IsNotExist(err) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("background cleanup did not remove the expired entry")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	stop()
+
+	if _, err := os.Stat(filepath.Join(dir, "new.json")); err != nil {
+		t.Errorf("background cleanup removed the fresh entry: %v", err)
+	}
+	if got := metrics.CacheExpiredRemoved.GetValue() - before; got != 1 {
+		t.Errorf("cache_expired_removed_total grew by %v, want 1", got)
+	}
+}
+
+func TestBackgroundCleanupStopsWithContext(t *testing.T) {
+	svc := setupTestCacheService(t, t.TempDir())
+	ctx, cancel := context.WithCancel(context.Background())
+	stop := svc.StartBackgroundCleanup(ctx, time.Hour)
+	cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		stop()
+		stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("stop did not return after the context was cancelled")
+	}
+}
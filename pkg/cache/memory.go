@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// memoryLRU holds the most recently used deserialized entries in front of the
+// file cache, bounded to maxEntries
+type memoryLRU struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List // Front is the most recently used
+	items      map[string]*list.Element
+}
+
+// memoryItem is the value stored in each list element
+type memoryItem struct {
+	key   string
+	entry Entry
+}
+
+func newMemoryLRU(maxEntries int) *memoryLRU {
+	return &memoryLRU{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+// NewCacheServiceWithMemory creates a cache service that keeps up to
+// maxEntries recently used entries in memory, so hot keys are served without
+// reading from disk. A non-positive maxEntries disables the memory layer.
+func NewCacheServiceWithMemory(cacheDir string, ttlMinutes, maxEntries int) (*Service, error) {
+	svc, err := NewCacheService(cacheDir, ttlMinutes)
+	if err != nil {
+		return nil, err
+	}
+	if maxEntries > 0 {
+		svc.memory = newMemoryLRU(maxEntries)
+	}
+	return svc, nil
+}
+
+// get returns the entry for key and marks it as most recently used
+func (m *memoryLRU) get(key string) (Entry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.items[key]
+	if !ok {
+		return Entry{}, false
+	}
+	m.order.MoveToFront(elem)
+	return elem.Value.(*memoryItem).entry, true
+}
+
+// put stores entry under key, evicting the least recently used entry when
+// the capacity is exceeded
+func (m *memoryLRU) put(key string, entry Entry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.items[key]; ok {
+		elem.Value.(*memoryItem).entry = entry
+		m.order.MoveToFront(elem)
+		return
+	}
+
+	m.items[key] = m.order.PushFront(&memoryItem{key: key, entry: entry})
+	for m.order.Len() > m.maxEntries {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.items, oldest.Value.(*memoryItem).key)
+	}
+}
+
+// remove drops key from memory
+func (m *memoryLRU) remove(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.items[key]; ok {
+		m.order.Remove(elem)
+		delete(m.items, key)
+	}
+}
+
+// purge drops every entry from memory
+func (m *memoryLRU) purge() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.order.Init()
+	m.items = make(map[string]*list.Element)
+}
+
+// len returns the number of entries held in memory
+func (m *memoryLRU) len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+// memoryGet returns the in-memory entry for key, if the memory layer is enabled
+func (c *Service) memoryGet(key string) (Entry, bool) {
+	if c.memory == nil {
+		return Entry{}, false
+	}
+	return c.memory.get(key)
+}
+
+// memoryPut stores entry in the memory layer, if enabled
+func (c *Service) memoryPut(key string, entry Entry) {
+	if c.memory != nil {
+		c.memory.put(key, entry)
+	}
+}
+
+// memoryRemove drops key from the memory layer, if enabled
+func (c *Service) memoryRemove(key string) {
+	if c.memory != nil {
+		c.memory.remove(key)
+	}
+}
+
+// memoryPurge empties the memory layer, if enabled
+func (c *Service) memoryPurge() {
+	if c.memory != nil {
+		c.memory.purge()
+	}
+}
@@ -0,0 +1,116 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/johnlam90/aws-ssm/pkg/metrics"
+)
+
+func TestMemoryLayerServesHotKeysWithoutDisk(t *testing.T) {
+	dir := t.TempDir()
+	svc, err := NewCacheServiceWithMemory(dir, 10, 2)
+	if err != nil {
+		t.Fatalf("NewCacheServiceWithMemory() error = %v", err)
+	}
+	if err := svc.Set("k", []int{1, 2, 3}, "us-east-1", "q"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	// With the file gone, only the memory layer can answer
+	if err := os.Remove(filepath.Join(dir, "k.json")); err != nil {
+		t.Fatalf("remove cache file: %v", err)
+	}
+	memoryHits := metrics.CacheMemoryHits.GetValue()
+	v, ok := svc.Get("k")
+	if !ok {
+		t.Fatalf("expected memory hit")
+	}
+	if arr, isArr := v.([]interface{}); !isArr || len(arr) != 3 {
+		t.Errorf("memory hit data = %#v, want the same shape as a disk hit", v)
+	}
+	if got := metrics.CacheMemoryHits.GetValue(); got != memoryHits+1 {
+		t.Errorf("CacheMemoryHits = %v, want %v", got, memoryHits+1)
+	}
+}
+
+func TestMemoryLayerPromotesDiskHits(t *testing.T) {
+	dir := t.TempDir()
+	writer := setupTestCacheService(t, dir)
+	if err := writer.Set("k", "v", "us-east-1", "q"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	svc, err := NewCacheServiceWithMemory(dir, 1, 2)
+	if err != nil {
+		t.Fatalf("NewCacheServiceWithMemory() error = %v", err)
+	}
+	hits, memoryHits := metrics.CacheHits.GetValue(), metrics.CacheMemoryHits.GetValue()
+	if _, ok := svc.Get("k"); !ok {
+		t.Fatalf("expected disk hit")
+	}
+	if metrics.CacheHits.GetValue() != hits+1 || metrics.CacheMemoryHits.GetValue() != memoryHits {
+		t.Errorf("disk hit counted as a memory hit")
+	}
+	if svc.memory.len() != 1 {
+		t.Errorf("memory entries = %d, want the disk hit promoted", svc.memory.len())
+	}
+}
+
+func TestMemoryLayerEvictsLeastRecentlyUsed(t *testing.T) {
+	svc, err := NewCacheServiceWithMemory(t.TempDir(), 1, 2)
+	if err != nil {
+		t.Fatalf("NewCacheServiceWithMemory() error = %v", err)
+	}
+	for _, key := range []string{"a", "b"} {
+		if err := svc.Set(key, key, "r", "q"); err != nil {
+			t.Fatalf("Set(%s) error = %v", key, err)
+		}
+	}
+	svc.Get("a") // b is now least recently used
+	if err := svc.Set("c", "c", "r", "q"); err != nil {
+		t.Fatalf("Set(c) error = %v", err)
+	}
+
+	if svc.memory.len() != 2 {
+		t.Fatalf("memory entries = %d, want 2", svc.memory.len())
+	}
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if _, ok := svc.memory.get(key); ok != want {
+			t.Errorf("key %s in memory = %v, want %v", key, ok, want)
+		}
+	}
+}
+
+func TestMemoryLayerStaysConsistent(t *testing.T) {
+	svc, err := NewCacheServiceWithMemory(t.TempDir(), 1, 10)
+	if err != nil {
+		t.Fatalf("NewCacheServiceWithMemory() error = %v", err)
+	}
+	setupTestCacheEntries(t, svc)
+
+	if err := svc.Set("k1", "updated", "r", "q1"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if v, _ := svc.Get("k1"); v != "updated" {
+		t.Errorf("Get(k1) after overwrite = %v, want updated", v)
+	}
+
+	if err := svc.Delete("k1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, ok := svc.Get("k1"); ok {
+		t.Errorf("Get(k1) hit after Delete")
+	}
+
+	if err := svc.Clear(); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if _, ok := svc.Get("k2"); ok {
+		t.Errorf("Get(k2) hit after Clear")
+	}
+	if svc.memory.len() != 0 {
+		t.Errorf("memory entries after Clear = %d, want 0", svc.memory.len())
+	}
+}
@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/johnlam90/aws-ssm/pkg/metrics"
+)
+
+func TestCacheMetrics(t *testing.T) {
+	metrics.CacheHits.Set(0)
+	metrics.CacheMisses.Set(0)
+	metrics.CacheSize.Set(0)
+
+	svc := setupTestCacheService(t, t.TempDir())
+	if err := svc.Set("k", "v", "us-east-1", "q"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	_, _, size, err := svc.GetCacheStats()
+	if err != nil {
+		t.Fatalf("GetCacheStats() error = %v", err)
+	}
+	if got := metrics.CacheSize.GetValue(); got != float64(size) || size == 0 {
+		t.Errorf("CacheSize after Set = %v, want %d", got, size)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, ok := svc.Get("k"); !ok {
+			t.Fatalf("Get(k) missed")
+		}
+	}
+	if _, ok := svc.Get("missing"); ok {
+		t.Fatalf("Get(missing) hit")
+	}
+
+	if got := metrics.CacheHits.GetValue(); got != 3 {
+		t.Errorf("CacheHits = %v, want 3", got)
+	}
+	if got := metrics.CacheMisses.GetValue(); got != 1 {
+		t.Errorf("CacheMisses = %v, want 1", got)
+	}
+	if got := metrics.CacheHitRatio(); got != 0.75 {
+		t.Errorf("CacheHitRatio() = %v, want 0.75", got)
+	}
+
+	if err := svc.Clear(); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if got := metrics.CacheSize.GetValue(); got != 0 {
+		t.Errorf("CacheSize after Clear = %v, want 0", got)
+	}
+}
+
+func TestCacheSizeTracksWritesIncrementally(t *testing.T) {
+	metrics.CacheSize.Set(0)
+	dir := t.TempDir()
+	svc := setupTestCacheService(t, dir)
+
+	assertSize := func(when string) {
+		t.Helper()
+		_, _, size, err := svc.GetCacheStats()
+		if err != nil {
+			t.Fatalf("GetCacheStats() error = %v", err)
+		}
+		if got := metrics.CacheSize.GetValue(); got != float64(size) {
+			t.Errorf("CacheSize %s = %v, want %d", when, got, size)
+		}
+	}
+
+	if err := svc.Set("a", "v", "us-east-1", "q"); err != nil {
+		t.Fatalf("Set(a) error = %v", err)
+	}
+	assertSize("after the first Set")
+	if err := svc.Set("b", []string{"i-1", "i-2"}, "us-east-1", "q"); err != nil {
+		t.Fatalf("Set(b) error = %v", err)
+	}
+	assertSize("after a second Set")
+	if err := svc.Set("a", "a much longer value than before", "us-east-1", "q"); err != nil {
+		t.Fatalf("Set(a) overwrite error = %v", err)
+	}
+	assertSize("after overwriting an entry")
+	if err := svc.Delete("b"); err != nil {
+		t.Fatalf("Delete(b) error = %v", err)
+	}
+	assertSize("after Delete")
+
+	// A file written behind the service's back is not seen by writes, which no
+	// longer list the directory, but the next cleanup pass picks it up
+	if err := os.WriteFile(filepath.Join(dir, "external.json"), []byte(`{"timestamp":"2999-01-01T00:00:00Z"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	before := metrics.CacheSize.GetValue()
+	if err := svc.Set("c", "v", "us-east-1", "q"); err != nil {
+		t.Fatalf("Set(c) error = %v", err)
+	}
+	if got, want := metrics.CacheSize.GetValue(), before+float64(fileSize(filepath.Join(dir, "c.json"))); got != want {
+		t.Errorf("CacheSize after Set = %v, want %v: only the new entry added", got, want)
+	}
+	if err := svc.Cleanup(); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+	assertSize("after Cleanup")
+}
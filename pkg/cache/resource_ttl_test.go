@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/config"
+)
+
+// ageCacheEntry rewrites an entry's timestamp so it appears to be age old
+func ageCacheEntry(t *testing.T, dir, key string, age time.Duration) {
+	t.Helper()
+	path := filepath.Join(dir, key+".json")
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read cache file: %v", err)
+	}
+	var e Entry
+	if err := json.Unmarshal(b, &e); err != nil {
+		t.Fatalf("unmarshal cache entry: %v", err)
+	}
+	e.Timestamp = time.Now().Add(-age)
+	nb, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("marshal cache entry: %v", err)
+	}
+	if err := os.WriteFile(path, nb, 0600); err != nil {
+		t.Fatalf("write cache file: %v", err)
+	}
+}
+
+func TestCacheResourceTypeTTLs(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{}
+	cfg.Cache.CacheDir = dir
+	cfg.Cache.TTLMinutes = 10
+	cfg.Cache.EC2TTLMinutes = 2
+	cfg.Cache.EKSTTLMinutes = 60
+	cfg.Cache.ASGTTLMinutes = 5
+	cfg.Cache.NodeGroupTTLMinutes = 30
+
+	svc, err := NewCacheServiceFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("new cache service: %v", err)
+	}
+
+	tests := []struct {
+		key          string
+		resourceType ResourceType
+		age          time.Duration
+		wantHit      bool
+	}{
+		{"ec2-fresh", ResourceEC2, 1 * time.Minute, true},
+		{"ec2-expired", ResourceEC2, 3 * time.Minute, false},
+		{"eks-fresh", ResourceEKS, 45 * time.Minute, true},
+		{"eks-expired", ResourceEKS, 61 * time.Minute, false},
+		{"asg-fresh", ResourceASG, 4 * time.Minute, true},
+		{"asg-expired", ResourceASG, 6 * time.Minute, false},
+		{"ng-fresh", ResourceNodeGroup, 20 * time.Minute, true},
+		{"ng-expired", ResourceNodeGroup, 31 * time.Minute, false},
+		{"untyped-fresh", "", 9 * time.Minute, true},
+		{"untyped-expired", "", 11 * time.Minute, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if err := svc.SetWithResourceType(tt.key, "value", tt.resourceType, "us-east-1", "q"); err != nil {
+				t.Fatalf("set: %v", err)
+			}
+			ageCacheEntry(t, dir, tt.key, tt.age)
+
+			if _, ok := svc.Get(tt.key); ok != tt.wantHit {
+				t.Errorf("Get(%s) hit = %v, want %v", tt.key, ok, tt.wantHit)
+			}
+		})
+	}
+}
+
+func TestCacheResourceTTLFallsBackToGlobal(t *testing.T) {
+	svc, err := NewCacheService(t.TempDir(), 10)
+	if err != nil {
+		t.Fatalf("new cache service: %v", err)
+	}
+
+	if got := svc.ttlFor(ResourceEKS); got != 10*time.Minute {
+		t.Errorf("unset resource TTL = %v, want global 10m", got)
+	}
+
+	svc.SetResourceTTL(ResourceEKS, time.Hour)
+	if got := svc.ttlFor(ResourceEKS); got != time.Hour {
+		t.Errorf("resource TTL = %v, want 1h", got)
+	}
+
+	svc.SetResourceTTL(ResourceEKS, 0)
+	if got := svc.ttlFor(ResourceEKS); got != 10*time.Minute {
+		t.Errorf("cleared resource TTL = %v, want global 10m", got)
+	}
+}
@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/johnlam90/aws-ssm/pkg/metrics"
+)
+
+// sizeTracker follows the on-disk size of the cache from the bytes written
+// and removed, so the size gauge stays current without listing the directory
+// on every write
+type sizeTracker struct {
+	mu    sync.Mutex
+	known bool // False until the directory has been measured once
+	bytes int64
+}
+
+// adjustSize applies a change of delta bytes to the tracked size and
+// publishes it. Until the size is known the directory is measured instead,
+// once, after the change that prompted it.
+func (c *Service) adjustSize(delta int64) {
+	c.size.mu.Lock()
+	defer c.size.mu.Unlock()
+
+	if !c.size.known {
+		total, err := c.diskUsage()
+		if err != nil {
+			return
+		}
+		c.size.bytes, c.size.known = total, true
+	} else {
+		c.size.bytes += delta
+		if c.size.bytes < 0 {
+			c.size.bytes = 0
+		}
+	}
+	metrics.CacheSize.Set(float64(c.size.bytes))
+}
+
+// resetSize replaces the tracked size with a measured total
+func (c *Service) resetSize(total int64) {
+	c.size.mu.Lock()
+	defer c.size.mu.Unlock()
+	c.size.bytes, c.size.known = total, true
+	metrics.CacheSize.Set(float64(total))
+}
+
+// diskUsage sums the sizes of the cache files without reading them
+func (c *Service) diskUsage() (int64, error) {
+	files, err := os.ReadDir(c.cacheDir)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, file := range files {
+		if filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		if info, err := file.Info(); err == nil {
+			total += info.Size()
+		}
+	}
+	return total, nil
+}
+
+// fileSize returns the size of the file at path, or 0 when it does not exist
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// removeCacheFile removes the cache file at path and deducts its size
+func (c *Service) removeCacheFile(path string) error {
+	size := fileSize(path)
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	c.adjustSize(-size)
+	return nil
+}
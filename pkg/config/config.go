@@ -13,27 +13,39 @@ import (
 // Config represents the application configuration
 type Config struct {
 	Default struct {
-		Region  string            `yaml:"region"`
-		Profile string            `yaml:"profile"`
-		Filters map[string]string `yaml:"filters"`
-		Columns []string          `yaml:"columns"`
-		Weights map[string]int    `yaml:"weights"`
+		Region               string            `yaml:"region"`
+		Profile              string            `yaml:"profile"`
+		Filters              map[string]string `yaml:"filters"`
+		Columns              []string          `yaml:"columns"`
+		Weights              map[string]int    `yaml:"weights"`
+		Command              string            `yaml:"command"`
+		LargeResultThreshold int               `yaml:"large_result_threshold"`
+		Sort                 string            `yaml:"sort"` // Instance order, e.g. "launch-time:desc"
 	} `yaml:"default"`
 	Interactive struct {
-		Columns      []string `yaml:"columns"`
-		NoColor      bool     `yaml:"no_color"`
-		Width        int      `yaml:"width"`
-		CacheTTL     int      `yaml:"cache_ttl_minutes"`
-		MaxInstances int      `yaml:"max_instances"`
+		Columns       []string `yaml:"columns"`
+		NoColor       bool     `yaml:"no_color"`
+		Width         int      `yaml:"width"`
+		CacheTTL      int      `yaml:"cache_ttl_minutes"`
+		MaxInstances  int      `yaml:"max_instances"`
+		LabelTemplate string   `yaml:"label_template"` // Replaces the instance finder's NAME column, e.g. "{name} [{tag:Environment}]"
 	} `yaml:"interactive"`
 	Keybindings map[string]string `yaml:"keybindings"`
 	Cache       struct {
-		Enabled           bool   `yaml:"enabled"`
-		TTLMinutes        int    `yaml:"ttl_minutes"`
-		CacheDir          string `yaml:"cache_dir"`
-		BackgroundRefresh bool   `yaml:"background_refresh"`
-		RefreshWorkers    int    `yaml:"refresh_workers"`
-		StaleThreshold    int    `yaml:"stale_threshold_minutes"`
+		Enabled             bool   `yaml:"enabled"`
+		TTLMinutes          int    `yaml:"ttl_minutes"`
+		CacheDir            string `yaml:"cache_dir"`
+		BackgroundRefresh   bool   `yaml:"background_refresh"`
+		RefreshWorkers      int    `yaml:"refresh_workers"`
+		StaleThreshold      int    `yaml:"stale_threshold_minutes"`
+		EC2TTLMinutes       int    `yaml:"ec2_ttl_minutes"`
+		EKSTTLMinutes       int    `yaml:"eks_ttl_minutes"`
+		ASGTTLMinutes       int    `yaml:"asg_ttl_minutes"`
+		NodeGroupTTLMinutes int    `yaml:"nodegroup_ttl_minutes"`
+		AdaptiveTTL         bool   `yaml:"adaptive_ttl"` // Extend the TTL of unchanged data, shorten it for changing data
+		AdaptiveTTLMin      int    `yaml:"adaptive_ttl_min_minutes"`
+		AdaptiveTTLMax      int    `yaml:"adaptive_ttl_max_minutes"`
+		CompressThreshold   int    `yaml:"compress_threshold_bytes"` // Gzip entries larger than this; 0 disables
 	} `yaml:"cache"`
 	Performance struct {
 		EnableMetrics     bool `yaml:"enable_metrics"`
@@ -50,6 +62,30 @@ type Config struct {
 	Plugins struct {
 		Dir string `yaml:"dir"`
 	} `yaml:"plugins"`
+	Network struct {
+		ConnectTimeout int `yaml:"connect_timeout_seconds"`
+		RequestTimeout int `yaml:"request_timeout_seconds"`
+		KeepAlive      int `yaml:"keepalive_seconds"`
+	} `yaml:"network"`
+	Security struct {
+		Level string `yaml:"level"`
+	} `yaml:"security"`
+	Scaling struct {
+		MaxStep        int `yaml:"max_step"`
+		MaxStepPercent int `yaml:"max_step_percent"`
+	} `yaml:"scaling"`
+	Confirmations struct {
+		BlastRadius bool `yaml:"blast_radius"`
+	} `yaml:"confirmations"`
+	TUI struct {
+		EC2RowTemplate     string `yaml:"ec2_row_template"`
+		MaxConcurrentLoads int    `yaml:"max_concurrent_loads"` // Simultaneous TUI data loads; 0 uses the default
+	} `yaml:"tui"`
+	Output struct {
+		SensitiveTags []string `yaml:"sensitive_tags"`
+		MaxRows       int      `yaml:"max_rows"` // Default --max-rows for table output; 0 shows every row
+	} `yaml:"output"`
+	Connections []ConnectionOverride `yaml:"connections"`
 }
 
 // LoadConfig loads configuration from file
@@ -76,6 +112,12 @@ func LoadConfig(configPath string) (*Config, error) {
 	return config, nil
 }
 
+// ResolvePath returns the config file path that LoadConfig and SaveConfig use
+// for the given --config value
+func ResolvePath(configPath string) (string, error) {
+	return resolveConfigPath(configPath)
+}
+
 // resolveConfigPath resolves and validates the config file path
 func resolveConfigPath(configPath string) (string, error) {
 	if configPath == "" {
@@ -114,11 +156,14 @@ func resolveConfigPath(configPath string) (string, error) {
 func createDefaultConfig() *Config {
 	return &Config{
 		Default: struct {
-			Region  string            `yaml:"region"`
-			Profile string            `yaml:"profile"`
-			Filters map[string]string `yaml:"filters"`
-			Columns []string          `yaml:"columns"`
-			Weights map[string]int    `yaml:"weights"`
+			Region               string            `yaml:"region"`
+			Profile              string            `yaml:"profile"`
+			Filters              map[string]string `yaml:"filters"`
+			Columns              []string          `yaml:"columns"`
+			Weights              map[string]int    `yaml:"weights"`
+			Command              string            `yaml:"command"`
+			LargeResultThreshold int               `yaml:"large_result_threshold"`
+			Sort                 string            `yaml:"sort"` // Instance order, e.g. "launch-time:desc"
 		}{
 			Filters: make(map[string]string),
 			Columns: []string{"name", "instance-id", "private-ip", "state"},
@@ -129,13 +174,15 @@ func createDefaultConfig() *Config {
 				"ip":          2,
 				"dns":         1,
 			},
+			LargeResultThreshold: 500, // Prompt before listing more instances than this (0 = never)
 		},
 		Interactive: struct {
-			Columns      []string `yaml:"columns"`
-			NoColor      bool     `yaml:"no_color"`
-			Width        int      `yaml:"width"`
-			CacheTTL     int      `yaml:"cache_ttl_minutes"`
-			MaxInstances int      `yaml:"max_instances"`
+			Columns       []string `yaml:"columns"`
+			NoColor       bool     `yaml:"no_color"`
+			Width         int      `yaml:"width"`
+			CacheTTL      int      `yaml:"cache_ttl_minutes"`
+			MaxInstances  int      `yaml:"max_instances"`
+			LabelTemplate string   `yaml:"label_template"` // Replaces the instance finder's NAME column, e.g. "{name} [{tag:Environment}]"
 		}{
 			Columns:      []string{"name", "instance-id", "private-ip", "state"},
 			NoColor:      false,
@@ -161,19 +208,35 @@ func createDefaultConfig() *Config {
 			":":      "palette",
 		},
 		Cache: struct {
-			Enabled           bool   `yaml:"enabled"`
-			TTLMinutes        int    `yaml:"ttl_minutes"`
-			CacheDir          string `yaml:"cache_dir"`
-			BackgroundRefresh bool   `yaml:"background_refresh"`
-			RefreshWorkers    int    `yaml:"refresh_workers"`
-			StaleThreshold    int    `yaml:"stale_threshold_minutes"`
+			Enabled             bool   `yaml:"enabled"`
+			TTLMinutes          int    `yaml:"ttl_minutes"`
+			CacheDir            string `yaml:"cache_dir"`
+			BackgroundRefresh   bool   `yaml:"background_refresh"`
+			RefreshWorkers      int    `yaml:"refresh_workers"`
+			StaleThreshold      int    `yaml:"stale_threshold_minutes"`
+			EC2TTLMinutes       int    `yaml:"ec2_ttl_minutes"`
+			EKSTTLMinutes       int    `yaml:"eks_ttl_minutes"`
+			ASGTTLMinutes       int    `yaml:"asg_ttl_minutes"`
+			NodeGroupTTLMinutes int    `yaml:"nodegroup_ttl_minutes"`
+			AdaptiveTTL         bool   `yaml:"adaptive_ttl"` // Extend the TTL of unchanged data, shorten it for changing data
+			AdaptiveTTLMin      int    `yaml:"adaptive_ttl_min_minutes"`
+			AdaptiveTTLMax      int    `yaml:"adaptive_ttl_max_minutes"`
+			CompressThreshold   int    `yaml:"compress_threshold_bytes"` // Gzip entries larger than this; 0 disables
 		}{
-			Enabled:           true,
-			TTLMinutes:        5,
-			CacheDir:          "",
-			BackgroundRefresh: true,
-			RefreshWorkers:    3,
-			StaleThreshold:    6,
+			Enabled:             true,
+			TTLMinutes:          5,
+			CacheDir:            "",
+			BackgroundRefresh:   true,
+			RefreshWorkers:      3,
+			StaleThreshold:      6,
+			EC2TTLMinutes:       0, // 0 = use ttl_minutes
+			EKSTTLMinutes:       0,
+			ASGTTLMinutes:       0,
+			NodeGroupTTLMinutes: 0,
+			AdaptiveTTL:         false,
+			AdaptiveTTLMin:      1,
+			AdaptiveTTLMax:      60,
+			CompressThreshold:   0,
 		},
 		Performance: struct {
 			EnableMetrics     bool `yaml:"enable_metrics"`
@@ -202,6 +265,32 @@ func createDefaultConfig() *Config {
 		}{
 			Dir: "",
 		},
+		Network: struct {
+			ConnectTimeout int `yaml:"connect_timeout_seconds"`
+			RequestTimeout int `yaml:"request_timeout_seconds"`
+			KeepAlive      int `yaml:"keepalive_seconds"`
+		}{
+			ConnectTimeout: 30,
+			RequestTimeout: 0, // No overall limit; per-call contexts still apply
+			KeepAlive:      30,
+		},
+		Security: struct {
+			Level string `yaml:"level"`
+		}{
+			Level: "medium",
+		},
+		Scaling: struct {
+			MaxStep        int `yaml:"max_step"`
+			MaxStepPercent int `yaml:"max_step_percent"`
+		}{
+			MaxStep:        50,
+			MaxStepPercent: 0, // Disabled; percent steps are noisy for small groups
+		},
+		Confirmations: struct {
+			BlastRadius bool `yaml:"blast_radius"`
+		}{
+			BlastRadius: true,
+		},
 	}
 }
 
@@ -253,11 +342,11 @@ func SaveConfig(config *Config, configPath string) error {
 			return fmt.Errorf("failed to get user home directory: %w", err)
 		}
 		configPath = filepath.Join(homeDir, ".aws-ssm", "config.yaml")
+	}
 
-		// Ensure config directory exists with restricted permissions
-		if err := os.MkdirAll(filepath.Dir(configPath), 0700); err != nil {
-			return fmt.Errorf("failed to create config directory: %w", err)
-		}
+	// Ensure config directory exists with restricted permissions
+	if err := os.MkdirAll(filepath.Dir(configPath), 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
 	data, err := yaml.Marshal(config)
@@ -0,0 +1,63 @@
+package config
+
+// ConnectionOverride sets connection parameters for the instances it matches
+type ConnectionOverride struct {
+	Match       ConnectionMatch     `yaml:"match"`
+	User        string              `yaml:"user"`     // Switch to this user, as with --as-user
+	Shell       string              `yaml:"shell"`    // Command that starts the interactive shell, e.g. "bash -l"
+	Document    string              `yaml:"document"` // Session-type SSM document, as with --document
+	PortForward *PortForwardDefault `yaml:"port_forward"`
+}
+
+// ConnectionMatch selects instances; every field set must match
+type ConnectionMatch struct {
+	InstanceID string            `yaml:"instance_id"`
+	Name       string            `yaml:"name"`
+	Tags       map[string]string `yaml:"tags"`
+}
+
+// PortForwardDefault is the port-forward used when no ports are given
+type PortForwardDefault struct {
+	RemotePort int `yaml:"remote_port"`
+	LocalPort  int `yaml:"local_port"` // Defaults to the remote port
+}
+
+// ConnectionOverride returns the override matching an instance, or nil. An
+// instance ID match is more specific than a name match, which is more specific
+// than a tag match; more matching tags are more specific, and the first entry
+// wins a tie.
+func (c *Config) ConnectionOverride(instanceID, name string, tags map[string]string) *ConnectionOverride {
+	var best *ConnectionOverride
+	bestScore := 0
+	for i := range c.Connections {
+		score := c.Connections[i].Match.score(instanceID, name, tags)
+		if score > bestScore {
+			best, bestScore = &c.Connections[i], score
+		}
+	}
+	return best
+}
+
+// score rates how specifically m matches an instance; 0 means no match
+func (m ConnectionMatch) score(instanceID, name string, tags map[string]string) int {
+	score := 0
+	if m.InstanceID != "" {
+		if m.InstanceID != instanceID {
+			return 0
+		}
+		score += 10000
+	}
+	if m.Name != "" {
+		if m.Name != name {
+			return 0
+		}
+		score += 1000
+	}
+	for key, value := range m.Tags {
+		if actual, ok := tags[key]; !ok || actual != value {
+			return 0
+		}
+		score++
+	}
+	return score
+}
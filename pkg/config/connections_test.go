@@ -0,0 +1,43 @@
+package config
+
+import "testing"
+
+func TestConnectionOverride(t *testing.T) {
+	cfg := &Config{Connections: []ConnectionOverride{
+		{Match: ConnectionMatch{Tags: map[string]string{"Role": "db"}}, User: "postgres"},
+		{Match: ConnectionMatch{Tags: map[string]string{"Role": "db", "Env": "prod"}}, User: "dba"},
+		{Match: ConnectionMatch{Name: "db-1"}, Document: "DbShell"},
+		{Match: ConnectionMatch{InstanceID: "i-0abc"}, Shell: "bash -l"},
+		{Match: ConnectionMatch{Name: "web-1", Tags: map[string]string{"Env": "dev"}}, User: "www"},
+	}}
+
+	tests := []struct {
+		name       string
+		instanceID string
+		instName   string
+		tags       map[string]string
+		want       int // Index into cfg.Connections; -1 for no override
+	}{
+		{"by tag", "i-1", "db-9", map[string]string{"Role": "db"}, 0},
+		{"more tags win", "i-1", "db-9", map[string]string{"Role": "db", "Env": "prod"}, 1},
+		{"name beats tags", "i-1", "db-1", map[string]string{"Role": "db", "Env": "prod"}, 2},
+		{"ID beats name", "i-0abc", "db-1", map[string]string{"Role": "db"}, 3},
+		{"all criteria must match", "i-2", "web-1", map[string]string{"Env": "prod"}, -1},
+		{"name and tag", "i-2", "web-1", map[string]string{"Env": "dev"}, 4},
+		{"no match", "i-3", "cache-1", nil, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cfg.ConnectionOverride(tt.instanceID, tt.instName, tt.tags)
+			if tt.want < 0 {
+				if got != nil {
+					t.Errorf("ConnectionOverride() = %+v, want nil", got)
+				}
+				return
+			}
+			if got != &cfg.Connections[tt.want] {
+				t.Errorf("ConnectionOverride() = %+v, want %+v", got, cfg.Connections[tt.want])
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// EnvVar describes an environment variable recognized by aws-ssm
+type EnvVar struct {
+	Name        string
+	ConfigField string // Config file field or flag the variable relates to, empty if env-only
+	Description string
+	Sensitive   bool // Values are masked when displayed
+}
+
+// EnvVarStatus is an EnvVar together with its value in the current environment
+type EnvVarStatus struct {
+	EnvVar
+	Set   bool
+	Value string // Masked for sensitive variables
+}
+
+// knownEnvVars lists every environment variable aws-ssm reads, directly or via the AWS SDK
+var knownEnvVars = []EnvVar{
+	{Name: "AWS_REGION", ConfigField: "default.region (--region)", Description: "AWS region"},
+	{Name: "AWS_DEFAULT_REGION", ConfigField: "default.region (--region)", Description: "AWS region fallback used by the SDK"},
+	{Name: "AWS_PROFILE", ConfigField: "default.profile (--profile)", Description: "Shared config profile"},
+	{Name: "AWS_CONFIG_FILE", Description: "Path to the shared AWS config file"},
+	{Name: "AWS_SHARED_CREDENTIALS_FILE", Description: "Path to the shared AWS credentials file"},
+	{Name: "AWS_ACCESS_KEY_ID", Description: "Static access key", Sensitive: true},
+	{Name: "AWS_SECRET_ACCESS_KEY", Description: "Static secret key", Sensitive: true},
+	{Name: "AWS_SESSION_TOKEN", Description: "Temporary session token", Sensitive: true},
+	{Name: "AWS_SSM_SECURITY_LEVEL", ConfigField: "security.level", Description: "Security level for remote commands"},
+	{Name: "AWS_SSM_SECURITY_POLICY", Description: "Path to a security policy file applied on top of the security level"},
+	{Name: "AWS_SSM_SESSION_TIMEOUT", Description: "Session timeout (Go duration)"},
+	{Name: "AWS_SSM_SESSION_IDLE_WARNING", Description: "Warn when a session has been idle this long (Go duration)"},
+	{Name: "AWS_SSM_AUDIT_LOGGING", Description: "Enable audit logging (true/false)"},
+	{Name: "AWS_SSM_AUDIT_LOG_FILE", Description: "Also write audit events to this file (read by audit export)"},
+	{Name: "AWS_SSM_FEATURE_METRICS", ConfigField: "performance.enable_metrics", Description: "Metrics feature gate"},
+	{Name: "AWS_SSM_FEATURE_HEALTH_CHECKS", Description: "Health checks feature gate"},
+	{Name: "AWS_SSM_FEATURE_SECURITY", Description: "Security feature gate"},
+	{Name: "AWS_SSM_FEATURE_VALIDATION", Description: "Validation feature gate"},
+	{Name: "AWS_SSM_EMF_NAMESPACE", Description: "Write metrics in CloudWatch Embedded Metric Format under this namespace"},
+	{Name: "AWS_SSM_EMF_ENDPOINT", Description: "Where EMF metrics go: tcp://host:port, udp://host:port or a file (default udp://127.0.0.1:25888)"},
+	{Name: "AWS_SSM_CACHE_KEY", Description: "Passphrase used to encrypt cache files", Sensitive: true},
+	{Name: "AWS_SSM_CONFIG", ConfigField: "--config", Description: "Config path passed to plugins (set by aws-ssm, not read by it)"},
+	{Name: "NO_COLOR", ConfigField: "--no-color", Description: "Disable colored output when set"},
+	{Name: "FORCE_COLOR", ConfigField: "--no-color", Description: "Force colored output (0 or false disables it)"},
+	{Name: "KUBECONFIG", ConfigField: "eks kubeconfig --kubeconfig", Description: "Kubeconfig file list; the first entry is written"},
+	{Name: "HTTPS_PROXY", Description: "Proxy for AWS API calls"},
+	{Name: "NO_PROXY", Description: "Hosts that bypass the proxy"},
+	{Name: "HOME", ConfigField: "--config default location (~/.aws-ssm)", Description: "Home directory"},
+}
+
+// KnownEnvVars returns the environment variables aws-ssm recognizes
+func KnownEnvVars() []EnvVar {
+	vars := make([]EnvVar, len(knownEnvVars))
+	copy(vars, knownEnvVars)
+	return vars
+}
+
+// EnvReport returns the current state of every recognized environment variable,
+// masking the values of sensitive ones
+func EnvReport() []EnvVarStatus {
+	report := make([]EnvVarStatus, 0, len(knownEnvVars))
+	for _, v := range knownEnvVars {
+		status := EnvVarStatus{EnvVar: v}
+		if value, ok := os.LookupEnv(v.Name); ok {
+			status.Set = true
+			status.Value = value
+			if v.Sensitive {
+				status.Value = MaskSecret(value)
+			}
+		}
+		report = append(report, status)
+	}
+	return report
+}
+
+// MaskSecret hides all but the last four characters of a secret value
+func MaskSecret(value string) string {
+	const visible = 4
+	const maxStars = 16
+	if len(value) <= visible {
+		return strings.Repeat("*", len(value))
+	}
+	stars := len(value) - visible
+	if stars > maxStars {
+		stars = maxStars
+	}
+	return strings.Repeat("*", stars) + value[len(value)-visible:]
+}
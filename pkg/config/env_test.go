@@ -0,0 +1,83 @@
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+func TestEnvReport(t *testing.T) {
+	t.Setenv("AWS_REGION", "eu-west-1")
+	t.Setenv("AWS_PROFILE", "prod")
+	t.Setenv("AWS_SSM_SECURITY_LEVEL", "strict")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SSM_CACHE_KEY", "correct-horse-battery")
+	t.Setenv("KUBECONFIG", "/tmp/kube/config")
+
+	report := make(map[string]EnvVarStatus)
+	for _, status := range EnvReport() {
+		report[status.Name] = status
+	}
+
+	tests := []struct {
+		name        string
+		wantValue   string
+		wantField   string
+		wantSet     bool
+		wantMasking bool
+	}{
+		{name: "AWS_REGION", wantValue: "eu-west-1", wantField: "default.region (--region)", wantSet: true},
+		{name: "AWS_PROFILE", wantValue: "prod", wantField: "default.profile (--profile)", wantSet: true},
+		{name: "AWS_SSM_SECURITY_LEVEL", wantValue: "strict", wantField: "security.level", wantSet: true},
+		{name: "AWS_SECRET_ACCESS_KEY", wantValue: "****************EKEY", wantSet: true, wantMasking: true},
+		{name: "AWS_ACCESS_KEY_ID", wantValue: "****", wantSet: true, wantMasking: true},
+		{name: "AWS_SSM_CACHE_KEY", wantValue: "****************tery", wantSet: true, wantMasking: true},
+		{name: "KUBECONFIG", wantValue: "/tmp/kube/config", wantField: "eks kubeconfig --kubeconfig", wantSet: true},
+	}
+
+	for _, tt := range tests {
+		status, ok := report[tt.name]
+		if !ok {
+			t.Errorf("%s missing from report", tt.name)
+			continue
+		}
+		if status.Set != tt.wantSet || status.Value != tt.wantValue {
+			t.Errorf("%s = (set %v, %q), want (set %v, %q)", tt.name, status.Set, status.Value, tt.wantSet, tt.wantValue)
+		}
+		if status.ConfigField != tt.wantField {
+			t.Errorf("%s config field = %q, want %q", tt.name, status.ConfigField, tt.wantField)
+		}
+		if status.Sensitive != tt.wantMasking {
+			t.Errorf("%s sensitive = %v, want %v", tt.name, status.Sensitive, tt.wantMasking)
+		}
+	}
+}
+
+func TestEnvReportUnsetVariable(t *testing.T) {
+	// t.Setenv restores the original value after the test
+	t.Setenv("AWS_SSM_AUDIT_LOGGING", "")
+	if err := os.Unsetenv("AWS_SSM_AUDIT_LOGGING"); err != nil {
+		t.Fatalf("unsetenv: %v", err)
+	}
+
+	for _, status := range EnvReport() {
+		if status.Name == "AWS_SSM_AUDIT_LOGGING" && (status.Set || status.Value != "") {
+			t.Errorf("unset variable reported as %+v", status)
+		}
+	}
+}
+
+func TestMaskSecret(t *testing.T) {
+	tests := map[string]string{
+		"":                                     "",
+		"abc":                                  "***",
+		"abcd":                                 "****",
+		"abcdefgh":                             "****efgh",
+		"0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ": "****************WXYZ",
+	}
+	for in, want := range tests {
+		if got := MaskSecret(in); got != want {
+			t.Errorf("MaskSecret(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LastError records the most recent failed command for support bundles
+type LastError struct {
+	Command  string    `json:"command"`
+	Message  string    `json:"message"`
+	ExitCode int       `json:"exit_code"`
+	Time     time.Time `json:"time"`
+}
+
+// DefaultLastErrorPath returns the location of the last-error state file
+func DefaultLastErrorPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".aws-ssm", "last_error.json"), nil
+}
+
+// LoadLastError reads the last error; a missing file yields nil
+func LoadLastError(path string) (*LastError, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read last error: %w", err)
+	}
+
+	var lastErr LastError
+	if err := json.Unmarshal(data, &lastErr); err != nil {
+		return nil, fmt.Errorf("failed to parse last error: %w", err)
+	}
+	return &lastErr, nil
+}
+
+// SaveLastError writes the last error with restricted permissions
+func SaveLastError(path string, lastErr *LastError) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(lastErr, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal last error: %w", err)
+	}
+	return os.WriteFile(path, data, 0600)
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// LocalConfigFile is the name of a directory-local config file. Like .envrc, it
+// applies to the directory it is in and every directory below it.
+const LocalConfigFile = ".aws-ssm.yaml"
+
+// LocalConfig holds per-project defaults from a directory-local config file.
+// It ranks below flags and environment variables but above the global config.
+type LocalConfig struct {
+	Path    string            `yaml:"-"`
+	Region  string            `yaml:"region"`
+	Profile string            `yaml:"profile"`
+	Env     map[string]string `yaml:"env"`
+}
+
+// localEnvAllowlist lists the environment variables a directory-local config
+// may set. The file is picked up from any parent directory, including an
+// untrusted checkout, so security settings, credentials, proxies, CA bundles
+// and anything that sends data elsewhere, such as EMF metrics, can only come
+// from the user's own environment.
+var localEnvAllowlist = map[string]bool{
+	"AWS_DEFAULT_REGION":           true,
+	"AWS_SSM_SESSION_IDLE_WARNING": true,
+	"FORCE_COLOR":                  true,
+	"NO_COLOR":                     true,
+}
+
+// LocalEnvAllowed reports whether a directory-local config may set key
+func LocalEnvAllowed(key string) bool {
+	return localEnvAllowlist[key]
+}
+
+// FindLocalConfig walks up from startDir looking for LocalConfigFile and
+// returns the first path found, or "" when there is none
+func FindLocalConfig(startDir string) (string, error) {
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		return "", fmt.Errorf("invalid directory %q: %w", startDir, err)
+	}
+
+	for {
+		candidate := filepath.Join(dir, LocalConfigFile)
+		info, err := os.Stat(candidate)
+		switch {
+		case err == nil && info.Mode().IsRegular():
+			return candidate, nil
+		case err != nil && !os.IsNotExist(err):
+			return "", fmt.Errorf("failed to access %s: %w", candidate, err)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// LoadLocalConfig finds and parses the directory-local config for startDir.
+// It returns nil without an error when no local config exists.
+func LoadLocalConfig(startDir string) (*LocalConfig, error) {
+	path, err := FindLocalConfig(startDir)
+	if err != nil || path == "" {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read local config: %w", err)
+	}
+
+	local := &LocalConfig{}
+	if err := yaml.Unmarshal(data, local); err != nil {
+		return nil, fmt.Errorf("failed to parse local config %s: %w", path, err)
+	}
+	local.Path = path
+	return local, nil
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadLocalConfigWalksUp(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "services", "api", "deploy")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "region: eu-central-1\nprofile: project\nenv:\n  AWS_SSM_SECURITY_LEVEL: strict\n"
+	path := filepath.Join(root, LocalConfigFile)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	local, err := LoadLocalConfig(nested)
+	if err != nil {
+		t.Fatalf("LoadLocalConfig() error = %v", err)
+	}
+	if local == nil {
+		t.Fatal("LoadLocalConfig() = nil, want the config from a parent directory")
+	}
+	if local.Path != path {
+		t.Errorf("Path = %q, want %q", local.Path, path)
+	}
+	if local.Region != "eu-central-1" || local.Profile != "project" {
+		t.Errorf("region/profile = %q/%q, want eu-central-1/project", local.Region, local.Profile)
+	}
+	if got := local.Env["AWS_SSM_SECURITY_LEVEL"]; got != "strict" {
+		t.Errorf("Env[AWS_SSM_SECURITY_LEVEL] = %q, want strict", got)
+	}
+}
+
+func TestLoadLocalConfigNearestWins(t *testing.T) {
+	root := t.TempDir()
+	child := filepath.Join(root, "child")
+	if err := os.MkdirAll(child, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, LocalConfigFile), []byte("region: us-east-1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(child, LocalConfigFile), []byte("region: ap-south-1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	local, err := LoadLocalConfig(child)
+	if err != nil || local == nil {
+		t.Fatalf("LoadLocalConfig() = %v, %v", local, err)
+	}
+	if local.Region != "ap-south-1" {
+		t.Errorf("Region = %q, want the nearest config's ap-south-1", local.Region)
+	}
+}
+
+func TestLoadLocalConfigInvalid(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, LocalConfigFile), []byte("region: [unclosed\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadLocalConfig(dir); err == nil {
+		t.Error("LoadLocalConfig() should fail on invalid YAML")
+	}
+}
+
+func TestLocalEnvAllowed(t *testing.T) {
+	for _, key := range []string{"AWS_SSM_SESSION_IDLE_WARNING", "AWS_DEFAULT_REGION", "NO_COLOR"} {
+		if !LocalEnvAllowed(key) {
+			t.Errorf("LocalEnvAllowed(%q) = false, want true", key)
+		}
+	}
+	denied := []string{
+		"AWS_SSM_SECURITY_LEVEL", "AWS_SSM_SECURITY_POLICY", "HTTPS_PROXY", "HTTP_PROXY", "NO_PROXY",
+		"AWS_CA_BUNDLE", "AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN", "AWS_PROFILE",
+		"AWS_SSM_EMF_NAMESPACE", "AWS_SSM_EMF_ENDPOINT", "AWS_SSM_FEATURE_HEALTH_CHECKS", "AWS_SSM_FEATURE_METRICS",
+	}
+	for _, key := range denied {
+		if LocalEnvAllowed(key) {
+			t.Errorf("LocalEnvAllowed(%q) = true, want false", key)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LastSelection records the most recently selected targets so later commands can reuse them
+type LastSelection struct {
+	InstanceID string `json:"instance_id,omitempty"`
+	// InstanceRegion is the region InstanceID was selected in
+	InstanceRegion string `json:"instance_region,omitempty"`
+	Cluster        string `json:"cluster,omitempty"`
+	// ClusterRegion is the region Cluster was selected in
+	ClusterRegion string `json:"cluster_region,omitempty"`
+	// SessionMode is the auto-detected session mode last announced to the user
+	SessionMode string `json:"session_mode,omitempty"`
+	// Connections holds the last-used connection parameters, keyed by instance ID
+	Connections map[string]ConnectionParams `json:"connections,omitempty"`
+	UpdatedAt   time.Time                   `json:"updated_at"`
+}
+
+// ConnectionParams are the parameters of the last connection to an instance
+type ConnectionParams struct {
+	AsUser     string `json:"as_user,omitempty"`
+	Document   string `json:"document,omitempty"`
+	RemotePort int    `json:"remote_port,omitempty"`
+	LocalPort  int    `json:"local_port,omitempty"`
+}
+
+// DefaultSelectionPath returns the location of the last-selection state file
+func DefaultSelectionPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".aws-ssm", "last_selection.json"), nil
+}
+
+// LoadLastSelection reads the last selection; a missing file yields an empty selection
+func LoadLastSelection(path string) (*LastSelection, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &LastSelection{}, nil
+		}
+		return nil, fmt.Errorf("failed to read last selection: %w", err)
+	}
+
+	var sel LastSelection
+	if err := json.Unmarshal(data, &sel); err != nil {
+		return nil, fmt.Errorf("failed to parse last selection: %w", err)
+	}
+	return &sel, nil
+}
+
+// SaveLastSelection writes the last selection with restricted permissions
+func SaveLastSelection(path string, sel *LastSelection) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	sel.UpdatedAt = time.Now().UTC()
+	data, err := json.MarshalIndent(sel, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal last selection: %w", err)
+	}
+	return os.WriteFile(path, data, 0600)
+}
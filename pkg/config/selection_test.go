@@ -0,0 +1,51 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLastSelectionRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "last_selection.json")
+
+	empty, err := LoadLastSelection(path)
+	if err != nil {
+		t.Fatalf("LoadLastSelection() on missing file error = %v", err)
+	}
+	if empty.InstanceID != "" || empty.Cluster != "" {
+		t.Errorf("missing file should yield an empty selection, got %+v", empty)
+	}
+
+	want := &LastSelection{InstanceID: "i-0123456789abcdef0", InstanceRegion: "us-east-1", Cluster: "prod", ClusterRegion: "eu-west-1"}
+	if err := SaveLastSelection(path, want); err != nil {
+		t.Fatalf("SaveLastSelection() error = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("file permissions = %o, want 600", perm)
+	}
+
+	got, err := LoadLastSelection(path)
+	if err != nil {
+		t.Fatalf("LoadLastSelection() error = %v", err)
+	}
+	if got.InstanceID != want.InstanceID || got.Cluster != want.Cluster ||
+		got.InstanceRegion != want.InstanceRegion || got.ClusterRegion != want.ClusterRegion || got.UpdatedAt.IsZero() {
+		t.Errorf("LoadLastSelection() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadLastSelectionCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "last_selection.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := LoadLastSelection(path); err == nil {
+		t.Error("expected error for corrupt state file")
+	}
+}
@@ -0,0 +1,65 @@
+package config
+
+import "strings"
+
+// MaskedTagValue replaces the value of a sensitive tag in output
+const MaskedTagValue = "***"
+
+// TagMask redacts the values of sensitive tag keys. Keys match case-insensitively;
+// the zero value masks nothing.
+type TagMask struct {
+	keys map[string]struct{}
+}
+
+// NewTagMask creates a mask for the given tag keys
+func NewTagMask(keys []string) TagMask {
+	mask := TagMask{}
+	for _, key := range keys {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" {
+			continue
+		}
+		if mask.keys == nil {
+			mask.keys = make(map[string]struct{}, len(keys))
+		}
+		mask.keys[key] = struct{}{}
+	}
+	return mask
+}
+
+// TagMask returns the mask for the configured sensitive tag keys
+func (c *Config) TagMask() TagMask {
+	return NewTagMask(c.Output.SensitiveTags)
+}
+
+// Enabled reports whether any tag keys are masked
+func (m TagMask) Enabled() bool {
+	return len(m.keys) > 0
+}
+
+// Masks reports whether the value of key is redacted
+func (m TagMask) Masks(key string) bool {
+	_, ok := m.keys[strings.ToLower(key)]
+	return ok
+}
+
+// Value returns value, or MaskedTagValue when key is sensitive
+func (m TagMask) Value(key, value string) string {
+	if m.Masks(key) {
+		return MaskedTagValue
+	}
+	return value
+}
+
+// Apply returns tags with sensitive values redacted. The input map is never
+// modified; it is returned as-is when nothing needs masking.
+func (m TagMask) Apply(tags map[string]string) map[string]string {
+	if !m.Enabled() || len(tags) == 0 {
+		return tags
+	}
+	masked := make(map[string]string, len(tags))
+	for key, value := range tags {
+		masked[key] = m.Value(key, value)
+	}
+	return masked
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTagMaskApply(t *testing.T) {
+	mask := NewTagMask([]string{"Owner", " ticket ", ""})
+	tags := map[string]string{"Owner": "alice@example.com", "TICKET": "OPS-123", "Env": "prod"}
+
+	got := mask.Apply(tags)
+	want := map[string]string{"Owner": MaskedTagValue, "TICKET": MaskedTagValue, "Env": "prod"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Apply() = %v, want %v", got, want)
+	}
+	if tags["Owner"] != "alice@example.com" {
+		t.Error("Apply() modified the input map")
+	}
+}
+
+func TestTagMaskValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		keys  []string
+		key   string
+		value string
+		want  string
+	}{
+		{name: "masked key", keys: []string{"Owner"}, key: "Owner", value: "alice", want: MaskedTagValue},
+		{name: "case-insensitive", keys: []string{"owner"}, key: "OWNER", value: "alice", want: MaskedTagValue},
+		{name: "other key", keys: []string{"Owner"}, key: "Team", value: "payments", want: "payments"},
+		{name: "no keys", keys: nil, key: "Owner", value: "alice", want: "alice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewTagMask(tt.keys).Value(tt.key, tt.value); got != tt.want {
+				t.Errorf("Value(%q, %q) = %q, want %q", tt.key, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagMaskDisabled(t *testing.T) {
+	var mask TagMask
+	if mask.Enabled() {
+		t.Error("zero TagMask reports Enabled")
+	}
+	if NewTagMask([]string{" "}).Enabled() {
+		t.Error("blank keys should not enable the mask")
+	}
+}
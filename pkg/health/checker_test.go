@@ -0,0 +1,118 @@
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/metrics"
+)
+
+// sleepCheck reports status after sleeping for delay
+type sleepCheck struct {
+	delay  time.Duration
+	status Status
+}
+
+func (c sleepCheck) Name() string { return "sleep" }
+
+func (c sleepCheck) Check(ctx context.Context) *CheckResult {
+	select {
+	case <-time.After(c.delay):
+		return NewCheckResult(c.status, "done")
+	case <-ctx.Done():
+		return NewCheckResult(StatusError, ctx.Err().Error())
+	}
+}
+
+func TestCheckAllRunsConcurrently(t *testing.T) {
+	hc := NewChecker()
+	hc.AddCheck("a", sleepCheck{delay: 100 * time.Millisecond, status: StatusOK})
+	hc.AddCheck("b", sleepCheck{delay: 100 * time.Millisecond, status: StatusWarning})
+	hc.AddCheck("c", sleepCheck{delay: 100 * time.Millisecond, status: StatusOK})
+
+	start := time.Now()
+	result := hc.CheckAll(context.Background())
+	elapsed := time.Since(start)
+
+	if elapsed >= 200*time.Millisecond {
+		t.Errorf("CheckAll() took %v, want closer to 100ms than 300ms", elapsed)
+	}
+	if len(result.Checks) != 3 {
+		t.Fatalf("CheckAll() returned %d results, want 3", len(result.Checks))
+	}
+	if result.Overall != StatusWarning {
+		t.Errorf("Overall = %s, want warning", result.Overall)
+	}
+}
+
+func TestCheckAllRespectsConcurrencyLimit(t *testing.T) {
+	hc := NewChecker()
+	hc.concurrency = 1
+	hc.AddCheck("a", sleepCheck{delay: 50 * time.Millisecond, status: StatusOK})
+	hc.AddCheck("b", sleepCheck{delay: 50 * time.Millisecond, status: StatusOK})
+
+	start := time.Now()
+	if result := hc.CheckAll(context.Background()); result.Overall != StatusOK {
+		t.Errorf("Overall = %s, want ok", result.Overall)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("CheckAll() took %v with one slot, want the checks run one at a time", elapsed)
+	}
+}
+
+func TestCheckAllCancelled(t *testing.T) {
+	hc := NewChecker()
+	hc.AddCheck("slow", sleepCheck{delay: time.Minute, status: StatusOK})
+	hc.AddCheck("fast", sleepCheck{status: StatusOK})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	result := hc.CheckAll(ctx)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("CheckAll() took %v after cancellation", elapsed)
+	}
+	if result.Overall != StatusError || result.Checks["slow"].Status != StatusError {
+		t.Errorf("Overall = %s, slow = %s; want the cancelled check reported as an error", result.Overall, result.Checks["slow"].Status)
+	}
+	if hc.GetLastCheck() != result {
+		t.Errorf("GetLastCheck() did not return the latest result")
+	}
+}
+
+func TestCheckAllUpdatesStatusGauges(t *testing.T) {
+	hc := NewChecker()
+	hc.AddCheck("gauge_ok", sleepCheck{status: StatusOK})
+	hc.AddCheck("gauge_warning", sleepCheck{status: StatusWarning})
+	hc.AddCheck("gauge_error", sleepCheck{status: StatusError})
+	hc.AddCheck("gauge_critical", sleepCheck{status: StatusCritical})
+
+	if result := hc.CheckAll(context.Background()); result.Overall != StatusError {
+		t.Fatalf("Overall = %s, want error", result.Overall)
+	}
+	for check, want := range map[string]float64{
+		"gauge_ok":       1,
+		"gauge_warning":  0.5,
+		"gauge_error":    0,
+		"gauge_critical": 0,
+	} {
+		if got := metrics.HealthCheckStatusFor(check).GetValue(); got != want {
+			t.Errorf("health_check_status{check=%q} = %v, want %v", check, got, want)
+		}
+		if metrics.HealthCheckDurationFor(check).GetCount() == 0 {
+			t.Errorf("health_check_duration_seconds{check=%q} was not observed", check)
+		}
+	}
+	if got := metrics.HealthOverallStatus.GetValue(); got != 0 {
+		t.Errorf("health_overall_status = %v, want 0", got)
+	}
+
+	hc.RemoveCheck("gauge_error")
+	hc.RemoveCheck("gauge_critical")
+	hc.CheckAll(context.Background())
+	if got := metrics.HealthOverallStatus.GetValue(); got != 0.5 {
+		t.Errorf("health_overall_status = %v after the failures were removed, want 0.5", got)
+	}
+}
@@ -0,0 +1,129 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/logging"
+)
+
+const (
+	// DefaultClockSkewThreshold is the skew above which a warning is reported
+	DefaultClockSkewThreshold = 1 * time.Minute
+	// SigV4MaxClockSkew is the skew beyond which AWS rejects SigV4-signed requests
+	SigV4MaxClockSkew = 5 * time.Minute
+)
+
+// EvaluateClockSkew computes how far the local clock is from the server clock and
+// classifies it. A positive skew means the local clock is ahead of the server.
+// Skew within the threshold is OK, above it is a warning, and anything beyond the
+// SigV4 limit is critical because AWS will reject signed requests.
+func EvaluateClockSkew(localTime, serverTime time.Time, threshold time.Duration) (time.Duration, Status) {
+	skew := localTime.Sub(serverTime)
+
+	abs := skew
+	if abs < 0 {
+		abs = -abs
+	}
+
+	switch {
+	case abs > SigV4MaxClockSkew:
+		return skew, StatusCritical
+	case abs > threshold:
+		return skew, StatusWarning
+	default:
+		return skew, StatusOK
+	}
+}
+
+// FetchServerTime issues a HEAD request to url and returns the time reported in the
+// response Date header. Any HTTP status is accepted since only the header is needed.
+func FetchServerTime(ctx context.Context, client *http.Client, url string) (time.Time, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("request to %s failed: %w", url, err)
+	}
+	defer func() {
+		_ = resp.Body.Close() // Body is empty for HEAD; close error is not actionable
+	}()
+
+	dateHeader := resp.Header.Get("Date")
+	if dateHeader == "" {
+		return time.Time{}, fmt.Errorf("response from %s has no Date header", url)
+	}
+
+	serverTime, err := http.ParseTime(dateHeader)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid Date header %q: %w", dateHeader, err)
+	}
+	return serverTime, nil
+}
+
+// ClockSkewCheck compares the local clock against an AWS endpoint's clock
+type ClockSkewCheck struct {
+	*BaseHealthCheck
+	serverTimeFunc func(ctx context.Context) (time.Time, error)
+	threshold      time.Duration
+}
+
+// NewClockSkewCheck creates a clock skew health check. serverTimeFunc returns the
+// server's notion of the current time, typically via FetchServerTime.
+func NewClockSkewCheck(serverTimeFunc func(ctx context.Context) (time.Time, error), threshold time.Duration) *ClockSkewCheck {
+	if threshold <= 0 {
+		threshold = DefaultClockSkewThreshold
+	}
+	return &ClockSkewCheck{
+		BaseHealthCheck: NewBaseHealthCheck("clock_skew"),
+		serverTimeFunc:  serverTimeFunc,
+		threshold:       threshold,
+	}
+}
+
+// Check performs the clock skew health check
+func (c *ClockSkewCheck) Check(ctx context.Context) *CheckResult {
+	start := time.Now()
+
+	serverTime, err := c.serverTimeFunc(ctx)
+	localTime := time.Now()
+	duration := time.Since(start)
+
+	if err != nil {
+		result := NewCheckResult(StatusUnknown, fmt.Sprintf("Could not determine server time: %v", err))
+		result.WithDuration(duration)
+		result.WithServiceName("clock")
+		c.logger.Warn("Clock skew check failed", logging.String("error", err.Error()))
+		c.setLastCheck(result)
+		return result
+	}
+
+	skew, status := EvaluateClockSkew(localTime, serverTime, c.threshold)
+
+	var message string
+	switch status {
+	case StatusCritical:
+		message = fmt.Sprintf("Local clock is off by %v; AWS will reject signed requests (limit %v). Sync your system clock", skew.Round(time.Second), SigV4MaxClockSkew)
+	case StatusWarning:
+		message = fmt.Sprintf("Local clock is off by %v; requests will fail if skew exceeds %v", skew.Round(time.Second), SigV4MaxClockSkew)
+	default:
+		message = fmt.Sprintf("Local clock is within %v of AWS", c.threshold)
+	}
+
+	result := NewCheckResult(status, message)
+	result.WithDuration(duration)
+	result.WithMetadata("skew", skew.String())
+	result.WithServiceName("clock")
+
+	if status != StatusOK {
+		c.logger.Warn("Clock skew detected", logging.Duration("skew", skew))
+	}
+
+	c.setLastCheck(result)
+	return result
+}
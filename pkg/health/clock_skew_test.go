@@ -0,0 +1,73 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestEvaluateClockSkew(t *testing.T) {
+	server := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		local      time.Time
+		wantSkew   time.Duration
+		wantStatus Status
+	}{
+		{"in sync", server, 0, StatusOK},
+		{"slightly ahead within threshold", server.Add(30 * time.Second), 30 * time.Second, StatusOK},
+		{"ahead above threshold", server.Add(2 * time.Minute), 2 * time.Minute, StatusWarning},
+		{"behind above threshold", server.Add(-90 * time.Second), -90 * time.Second, StatusWarning},
+		{"beyond sigv4 limit", server.Add(6 * time.Minute), 6 * time.Minute, StatusCritical},
+		{"far behind", server.Add(-10 * time.Minute), -10 * time.Minute, StatusCritical},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			skew, status := EvaluateClockSkew(tt.local, server, time.Minute)
+			if skew != tt.wantSkew {
+				t.Errorf("skew = %v, want %v", skew, tt.wantSkew)
+			}
+			if status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestClockSkewCheck(t *testing.T) {
+	ahead := NewClockSkewCheck(func(_ context.Context) (time.Time, error) {
+		return time.Now().Add(-3 * time.Minute), nil
+	}, time.Minute)
+	if result := ahead.Check(context.Background()); result.Status != StatusWarning {
+		t.Errorf("expected warning, got %v: %s", result.Status, result.Message)
+	}
+
+	failing := NewClockSkewCheck(func(_ context.Context) (time.Time, error) {
+		return time.Time{}, errors.New("network down")
+	}, 0)
+	if result := failing.Check(context.Background()); result.Status != StatusUnknown {
+		t.Errorf("expected unknown, got %v", result.Status)
+	}
+}
+
+func TestFetchServerTime(t *testing.T) {
+	want := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Date", want.Format(http.TimeFormat))
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	got, err := FetchServerTime(context.Background(), srv.Client(), srv.URL)
+	if err != nil {
+		t.Fatalf("FetchServerTime failed: %v", err)
+	}
+	if !got.Equal(want) {
+		t.Errorf("server time = %v, want %v", got, want)
+	}
+}
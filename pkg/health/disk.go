@@ -0,0 +1,110 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/logging"
+)
+
+// DiskUsage is the capacity of the filesystem holding a path
+type DiskUsage struct {
+	TotalBytes uint64
+	FreeBytes  uint64 // Available to unprivileged users
+}
+
+// UsedPercent returns the share of the filesystem that is not available
+func (u DiskUsage) UsedPercent() float64 {
+	if u.TotalBytes == 0 {
+		return 0
+	}
+	return float64(u.TotalBytes-u.FreeBytes) / float64(u.TotalBytes) * 100
+}
+
+// DiskSpaceHealthCheck monitors free space on the filesystem holding a path
+type DiskSpaceHealthCheck struct {
+	*BaseHealthCheck
+	thresholdPercent float64
+	path             string
+}
+
+// NewDiskSpaceHealthCheck creates a new disk space health check for the
+// filesystem holding the cache directory
+func NewDiskSpaceHealthCheck(thresholdPercent float64) *DiskSpaceHealthCheck {
+	return &DiskSpaceHealthCheck{
+		BaseHealthCheck:  NewBaseHealthCheck("disk_space"),
+		thresholdPercent: thresholdPercent,
+		path:             defaultDiskSpacePath(),
+	}
+}
+
+// WithPath checks the filesystem holding path instead of the cache directory
+func (c *DiskSpaceHealthCheck) WithPath(path string) *DiskSpaceHealthCheck {
+	c.path = path
+	return c
+}
+
+// defaultDiskSpacePath returns the default cache directory, ~/.aws-ssm/cache
+func defaultDiskSpacePath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return os.TempDir()
+	}
+	return filepath.Join(homeDir, ".aws-ssm", "cache")
+}
+
+// Check performs the disk space health check
+func (c *DiskSpaceHealthCheck) Check(_ context.Context) *CheckResult {
+	start := time.Now()
+
+	result := NewCheckResult(StatusOK, "Disk space within limits")
+
+	path := existingAncestor(c.path)
+	usage, err := diskUsage(path)
+	duration := time.Since(start)
+
+	result.WithDuration(duration)
+	result.WithMetadata("path", path)
+	result.WithServiceName("system")
+
+	if err != nil {
+		result.Status = StatusUnknown
+		result.Message = fmt.Sprintf("Disk space check failed: %v", err)
+		c.logger.Warn("Disk space check failed", logging.String("path", path), logging.String("error", err.Error()))
+		c.setLastCheck(result)
+		return result
+	}
+
+	usedPercent := usage.UsedPercent()
+	if usedPercent > c.thresholdPercent {
+		result.Status = StatusWarning
+		result.Message = fmt.Sprintf("Low disk space: %.1f%% free", 100-usedPercent)
+		c.logger.Warn("Low disk space detected", logging.Float64("usage_percent", usedPercent))
+	}
+
+	result.WithMetadata("disk_usage_percent", usedPercent)
+	result.WithMetadata("free_bytes", usage.FreeBytes)
+	result.WithMetadata("total_bytes", usage.TotalBytes)
+
+	c.setLastCheck(result)
+	return result
+}
+
+// existingAncestor returns path, or its closest existing parent, so a cache
+// directory that has not been created yet is measured on its future filesystem
+func existingAncestor(path string) string {
+	for {
+		if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+			return path
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return path
+		}
+		path = parent
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !unix && !windows
+
+package health
+
+import (
+	"errors"
+	"runtime"
+)
+
+// diskUsage is not implemented on this platform
+func diskUsage(_ string) (DiskUsage, error) {
+	return DiskUsage{}, errors.New("disk usage is not supported on " + runtime.GOOS)
+}
@@ -0,0 +1,61 @@
+package health
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiskSpaceHealthCheck(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name       string
+		path       string
+		threshold  float64
+		wantStatus Status
+		wantPath   string
+	}{
+		{"temp dir within limits", dir, 100, StatusOK, dir},
+		{"negative threshold warns", dir, -1, StatusWarning, dir},
+		{"missing directory uses its parent", filepath.Join(dir, "cache", "ec2"), 100, StatusOK, dir},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewDiskSpaceHealthCheck(tt.threshold).WithPath(tt.path).Check(context.Background())
+			if result.Status != tt.wantStatus {
+				t.Fatalf("status = %s (%s), want %s", result.Status, result.Message, tt.wantStatus)
+			}
+			if got := result.Metadata["path"]; got != tt.wantPath {
+				t.Errorf("path = %v, want %s", got, tt.wantPath)
+			}
+
+			usage := result.Metadata["disk_usage_percent"].(float64)
+			if usage < 0 || usage > 100 {
+				t.Errorf("usage = %v%%, want between 0 and 100", usage)
+			}
+			total := result.Metadata["total_bytes"].(uint64)
+			free := result.Metadata["free_bytes"].(uint64)
+			if total == 0 || free > total {
+				t.Errorf("free/total bytes = %d/%d", free, total)
+			}
+		})
+	}
+}
+
+func TestDiskUsageUsedPercent(t *testing.T) {
+	tests := []struct {
+		usage DiskUsage
+		want  float64
+	}{
+		{DiskUsage{TotalBytes: 200, FreeBytes: 50}, 75},
+		{DiskUsage{TotalBytes: 100, FreeBytes: 100}, 0},
+		{DiskUsage{}, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.usage.UsedPercent(); got != tt.want {
+			t.Errorf("%+v.UsedPercent() = %v, want %v", tt.usage, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+//go:build unix
+
+package health
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// diskUsage reports the capacity of the filesystem holding path
+func diskUsage(path string) (DiskUsage, error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(path, &stat); err != nil {
+		return DiskUsage{}, fmt.Errorf("statfs %s: %w", path, err)
+	}
+	blockSize := uint64(stat.Bsize) // #nosec G115 - block sizes are positive
+	return DiskUsage{
+		TotalBytes: uint64(stat.Blocks) * blockSize,
+		FreeBytes:  uint64(stat.Bavail) * blockSize,
+	}, nil
+}
@@ -0,0 +1,22 @@
+//go:build windows
+
+package health
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows"
+)
+
+// diskUsage reports the capacity of the volume holding path
+func diskUsage(path string) (DiskUsage, error) {
+	pathPtr, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return DiskUsage{}, fmt.Errorf("invalid path %s: %w", path, err)
+	}
+	var free, total, totalFree uint64
+	if err := windows.GetDiskFreeSpaceEx(pathPtr, &free, &total, &totalFree); err != nil {
+		return DiskUsage{}, fmt.Errorf("GetDiskFreeSpaceEx %s: %w", path, err)
+	}
+	return DiskUsage{TotalBytes: total, FreeBytes: free}, nil
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/johnlam90/aws-ssm/pkg/logging"
+	"github.com/johnlam90/aws-ssm/pkg/metrics"
 )
 
 // Status represents health check status
@@ -224,99 +225,6 @@ func (c *ConfigHealthCheck) Check(_ context.Context) *CheckResult {
 	return result
 }
 
-// MemoryHealthCheck monitors memory usage
-type MemoryHealthCheck struct {
-	*BaseHealthCheck
-	thresholdPercent float64
-}
-
-// NewMemoryHealthCheck creates a new memory usage health check
-func NewMemoryHealthCheck(thresholdPercent float64) *MemoryHealthCheck {
-	return &MemoryHealthCheck{
-		BaseHealthCheck:  NewBaseHealthCheck("memory"),
-		thresholdPercent: thresholdPercent,
-	}
-}
-
-// Check performs the memory usage health check
-func (c *MemoryHealthCheck) Check(_ context.Context) *CheckResult {
-	start := time.Now()
-
-	result := NewCheckResult(StatusOK, "Memory usage within limits")
-
-	// This is a simplified memory check
-	// In a real implementation, you'd use runtime.MemStats
-	duration := time.Since(start)
-
-	// Simulate memory check
-	memoryUsage := c.getMemoryUsage()
-
-	if memoryUsage > c.thresholdPercent {
-		result.Status = StatusWarning
-		result.Message = fmt.Sprintf("High memory usage: %.1f%%", memoryUsage)
-		c.logger.Warn("High memory usage detected", logging.Float64("usage_percent", memoryUsage))
-	}
-
-	result.WithDuration(duration)
-	result.WithMetadata("memory_usage_percent", memoryUsage)
-	result.WithServiceName("system")
-
-	c.setLastCheck(result)
-	return result
-}
-
-func (c *MemoryHealthCheck) getMemoryUsage() float64 {
-	// Simplified implementation
-	// In production, use runtime.MemStats and system metrics
-	return float64(50 + (time.Now().Second() % 20)) // Simulate varying memory usage
-}
-
-// DiskSpaceHealthCheck monitors disk space
-type DiskSpaceHealthCheck struct {
-	*BaseHealthCheck
-	thresholdPercent float64
-}
-
-// NewDiskSpaceHealthCheck creates a new disk space health check
-func NewDiskSpaceHealthCheck(thresholdPercent float64) *DiskSpaceHealthCheck {
-	return &DiskSpaceHealthCheck{
-		BaseHealthCheck:  NewBaseHealthCheck("disk_space"),
-		thresholdPercent: thresholdPercent,
-	}
-}
-
-// Check performs the disk space health check
-func (c *DiskSpaceHealthCheck) Check(_ context.Context) *CheckResult {
-	start := time.Now()
-
-	result := NewCheckResult(StatusOK, "Disk space within limits")
-
-	// In production, check actual disk space
-	duration := time.Since(start)
-
-	// Simulate disk space check
-	diskUsage := c.getDiskUsage()
-
-	if diskUsage > c.thresholdPercent {
-		result.Status = StatusWarning
-		result.Message = fmt.Sprintf("Low disk space: %.1f%% free", 100-diskUsage)
-		c.logger.Warn("Low disk space detected", logging.Float64("usage_percent", diskUsage))
-	}
-
-	result.WithDuration(duration)
-	result.WithMetadata("disk_usage_percent", diskUsage)
-	result.WithServiceName("system")
-
-	c.setLastCheck(result)
-	return result
-}
-
-func (c *DiskSpaceHealthCheck) getDiskUsage() float64 {
-	// Simplified implementation
-	// In production, use syscall.Statfs or similar
-	return float64(30 + (time.Now().Second() % 40)) // Simulate varying disk usage
-}
-
 // TCPHealthCheck performs TCP connectivity checks
 type TCPHealthCheck struct {
 	*BaseHealthCheck
@@ -510,12 +418,19 @@ func (c *CompositeHealthCheck) Check(ctx context.Context) *CheckResult {
 	return result
 }
 
+// MaxConcurrentChecks bounds how many checks CheckAll runs at once
+const MaxConcurrentChecks = 8
+
 // Checker manages all health checks
 type Checker struct {
 	checks    map[string]Check
 	mu        sync.RWMutex
 	logger    logging.Logger
 	lastCheck *CompositeResult
+	// concurrency overrides MaxConcurrentChecks when positive
+	concurrency int
+	// monitoring is set while StartMonitoring keeps lastCheck fresh
+	monitoring bool
 }
 
 // CompositeResult represents the result of a composite health check
@@ -569,7 +484,10 @@ func (hc *Checker) ListChecks() []string {
 	return names
 }
 
-// CheckAll performs all registered health checks
+// CheckAll performs all registered health checks concurrently, at most
+// MaxConcurrentChecks at a time, and updates the per-check and overall health
+// metrics. Checks still waiting for a slot when ctx is done are reported as
+// errors without being run.
 func (hc *Checker) CheckAll(ctx context.Context) *CompositeResult {
 	hc.mu.RLock()
 	checks := make([]Check, 0, len(hc.checks))
@@ -582,14 +500,36 @@ func (hc *Checker) CheckAll(ctx context.Context) *CompositeResult {
 
 	start := time.Now()
 
-	results := make(map[string]*CheckResult)
-	var overallStatus = StatusOK
-	var passCount int
-
+	results := make(map[string]*CheckResult, len(checks))
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		sem = make(chan struct{}, hc.concurrencyLimit())
+	)
 	for i, check := range checks {
-		result := check.Check(ctx)
-		results[names[i]] = result
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+
+			var result *CheckResult
+			select {
+			case <-ctx.Done():
+				result = NewCheckResult(StatusError, fmt.Sprintf("Health check not run: %v", ctx.Err()))
+			case sem <- struct{}{}:
+				result = check.Check(ctx)
+				<-sem
+			}
+
+			mu.Lock()
+			results[name] = result
+			mu.Unlock()
+		}(names[i], check)
+	}
+	wg.Wait()
 
+	var overallStatus = StatusOK
+	var passCount int
+	for _, result := range results {
 		switch result.Status {
 		case StatusOK:
 			passCount++
@@ -615,6 +555,11 @@ func (hc *Checker) CheckAll(ctx context.Context) *CompositeResult {
 	hc.lastCheck = compositeResult
 	hc.mu.Unlock()
 
+	// A run cut short by cancellation would report every check as failed
+	if ctx.Err() == nil {
+		recordCheckMetrics(compositeResult)
+	}
+
 	hc.logger.Info("Health check completed",
 		logging.String("overall_status", overallStatus.String()),
 		logging.Int("total_checks", len(checks)),
@@ -624,6 +569,36 @@ func (hc *Checker) CheckAll(ctx context.Context) *CompositeResult {
 	return compositeResult
 }
 
+func (hc *Checker) concurrencyLimit() int {
+	if hc.concurrency > 0 {
+		return hc.concurrency
+	}
+	return MaxConcurrentChecks
+}
+
+// recordCheckMetrics updates the per-check duration and status metrics and
+// the overall status gauge
+func recordCheckMetrics(result *CompositeResult) {
+	for name, check := range result.Checks {
+		metrics.HealthCheckDurationFor(name).Observe(check.Duration.Seconds())
+		metrics.HealthCheckStatusFor(name).Set(statusValue(check.Status))
+	}
+	metrics.HealthOverallStatus.Set(statusValue(result.Overall))
+}
+
+// statusValue maps a status to a health gauge value: 1 for ok, 0.5 for a
+// warning and 0 otherwise
+func statusValue(status Status) float64 {
+	switch status {
+	case StatusOK:
+		return 1
+	case StatusWarning:
+		return 0.5
+	default:
+		return 0
+	}
+}
+
 // GetLastCheck returns the result of the most recent health check
 func (hc *Checker) GetLastCheck() *CompositeResult {
 	hc.mu.RLock()
@@ -633,8 +608,7 @@ func (hc *Checker) GetLastCheck() *CompositeResult {
 
 // HealthHandler is an HTTP handler that returns health check results
 func (hc *Checker) HealthHandler(w http.ResponseWriter, r *http.Request) {
-	// Check all health checks
-	result := hc.CheckAll(r.Context())
+	result := hc.currentResult(r.Context())
 
 	// Set response code based on overall status
 	var statusCode int
@@ -693,7 +667,7 @@ func (hc *Checker) HealthHandler(w http.ResponseWriter, r *http.Request) {
 
 // ReadinessHandler returns readiness status
 func (hc *Checker) ReadinessHandler(w http.ResponseWriter, r *http.Request) {
-	result := hc.CheckAll(r.Context())
+	result := hc.currentResult(r.Context())
 
 	// Readiness fails if any critical checks fail
 	var statusCode int
@@ -730,7 +704,8 @@ func (hc *Checker) LivenessHandler(w http.ResponseWriter, _ *http.Request) {
 	}
 }
 
-// NewDefaultChecker creates a default health checker with common checks
+// NewDefaultChecker creates a default health checker with common checks. The
+// SSM endpoint check is added when AWS_REGION or AWS_DEFAULT_REGION is set.
 func NewDefaultChecker(
 	awsTestFunc func(ctx context.Context) error,
 	cacheTestFunc func(ctx context.Context) error,
@@ -743,6 +718,9 @@ func NewDefaultChecker(
 	hc.AddCheck("configuration", NewConfigHealthCheck(configValidationFunc))
 	hc.AddCheck("memory", NewMemoryHealthCheck(90.0))
 	hc.AddCheck("disk_space", NewDiskSpaceHealthCheck(85.0))
+	if region := regionFromEnv(); region != "" {
+		hc.AddCheck("ssm_endpoint", NewSSMEndpointCheck(region, DefaultSSMEndpointTimeout))
+	}
 
 	return hc
 }
@@ -0,0 +1,82 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/logging"
+)
+
+// MemoryLimitFunc returns the number of bytes heap usage is measured against
+type MemoryLimitFunc func(stats *runtime.MemStats) uint64
+
+// MemoryHealthCheck monitors heap usage against a memory limit
+type MemoryHealthCheck struct {
+	*BaseHealthCheck
+	thresholdPercent float64
+	limitFunc        MemoryLimitFunc
+}
+
+// NewMemoryHealthCheck creates a new memory usage health check measuring heap
+// in use against DefaultMemoryLimit
+func NewMemoryHealthCheck(thresholdPercent float64) *MemoryHealthCheck {
+	return &MemoryHealthCheck{
+		BaseHealthCheck:  NewBaseHealthCheck("memory"),
+		thresholdPercent: thresholdPercent,
+		limitFunc:        DefaultMemoryLimit,
+	}
+}
+
+// WithLimit replaces the limit heap usage is measured against, e.g. with a
+// provider of the container or system memory size
+func (c *MemoryHealthCheck) WithLimit(limitFunc MemoryLimitFunc) *MemoryHealthCheck {
+	c.limitFunc = limitFunc
+	return c
+}
+
+// DefaultMemoryLimit returns the Go soft memory limit (GOMEMLIMIT) when one is
+// set, and otherwise the memory the runtime has obtained from the OS
+func DefaultMemoryLimit(stats *runtime.MemStats) uint64 {
+	if limit := debug.SetMemoryLimit(-1); limit > 0 && limit < math.MaxInt64 {
+		return uint64(limit)
+	}
+	return stats.Sys
+}
+
+// Check performs the memory usage health check
+func (c *MemoryHealthCheck) Check(_ context.Context) *CheckResult {
+	start := time.Now()
+
+	result := NewCheckResult(StatusOK, "Memory usage within limits")
+
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	limit := c.limitFunc(&stats)
+	memoryUsage := 0.0
+	if limit > 0 {
+		memoryUsage = float64(stats.HeapInuse) / float64(limit) * 100
+	}
+	duration := time.Since(start)
+
+	if memoryUsage > c.thresholdPercent {
+		result.Status = StatusWarning
+		result.Message = fmt.Sprintf("High memory usage: %.1f%%", memoryUsage)
+		c.logger.Warn("High memory usage detected", logging.Float64("usage_percent", memoryUsage))
+	}
+
+	result.WithDuration(duration)
+	result.WithMetadata("memory_usage_percent", memoryUsage)
+	result.WithMetadata("heap_inuse", stats.HeapInuse)
+	result.WithMetadata("limit", limit)
+	result.WithMetadata("alloc", stats.Alloc)
+	result.WithMetadata("sys", stats.Sys)
+	result.WithMetadata("num_gc", stats.NumGC)
+	result.WithServiceName("system")
+
+	c.setLastCheck(result)
+	return result
+}
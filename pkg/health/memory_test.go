@@ -0,0 +1,54 @@
+package health
+
+import (
+	"context"
+	"runtime"
+	"testing"
+)
+
+func TestMemoryHealthCheckThreshold(t *testing.T) {
+	tests := []struct {
+		name       string
+		threshold  float64
+		wantStatus Status
+	}{
+		{"very low threshold warns", 0.0001, StatusWarning},
+		{"high threshold is ok", 100, StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewMemoryHealthCheck(tt.threshold).Check(context.Background())
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %s (%s), want %s", result.Status, result.Message, tt.wantStatus)
+			}
+			for _, key := range []string{"memory_usage_percent", "alloc", "sys", "num_gc"} {
+				if _, ok := result.Metadata[key]; !ok {
+					t.Errorf("metadata is missing %s", key)
+				}
+			}
+			if sys, _ := result.Metadata["sys"].(uint64); sys == 0 {
+				t.Errorf("sys = %v, want the runtime's memory", result.Metadata["sys"])
+			}
+		})
+	}
+}
+
+func TestMemoryHealthCheckWithLimit(t *testing.T) {
+	var heapInuse uint64
+	check := NewMemoryHealthCheck(90).WithLimit(func(stats *runtime.MemStats) uint64 {
+		heapInuse = stats.HeapInuse
+		return stats.HeapInuse * 4
+	})
+
+	result := check.Check(context.Background())
+	if result.Status != StatusOK {
+		t.Errorf("status = %s, want ok", result.Status)
+	}
+	if got := result.Metadata["memory_usage_percent"].(float64); got != 25 {
+		t.Errorf("usage = %v%%, want 25%%", got)
+	}
+	if got := result.Metadata["limit"].(uint64); got != heapInuse*4 {
+		t.Errorf("limit = %d, want %d", got, heapInuse*4)
+	}
+}
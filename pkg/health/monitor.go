@@ -0,0 +1,65 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/logging"
+)
+
+// StartMonitoring runs CheckAll immediately and then every interval in a
+// goroutine until ctx is cancelled or the returned stop function is called.
+// Each run updates the health metrics. While monitoring, the HTTP
+// handlers serve the latest result instead of running the checks on every
+// request. stop waits for the goroutine to exit and is safe to call more than
+// once.
+func (hc *Checker) StartMonitoring(ctx context.Context, interval time.Duration) (stop func()) {
+	if interval <= 0 {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	hc.setMonitoring(true)
+	go func() {
+		defer close(done)
+		defer hc.setMonitoring(false)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			hc.CheckAll(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	hc.logger.Info("Health monitoring started", logging.Duration("interval", interval))
+
+	var once sync.Once
+	return func() {
+		once.Do(cancel)
+		<-done
+	}
+}
+
+func (hc *Checker) setMonitoring(monitoring bool) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	hc.monitoring = monitoring
+}
+
+// currentResult returns the latest monitored result, or runs the checks when
+// no monitoring loop has produced one
+func (hc *Checker) currentResult(ctx context.Context) *CompositeResult {
+	hc.mu.RLock()
+	monitoring, last := hc.monitoring, hc.lastCheck
+	hc.mu.RUnlock()
+	if monitoring && last != nil {
+		return last
+	}
+	return hc.CheckAll(ctx)
+}
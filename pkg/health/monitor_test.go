@@ -0,0 +1,118 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/metrics"
+)
+
+// countingCheck counts its runs and reports status
+type countingCheck struct {
+	runs   atomic.Int32
+	status Status
+}
+
+func (c *countingCheck) Name() string { return "counting" }
+
+func (c *countingCheck) Check(context.Context) *CheckResult {
+	c.runs.Add(1)
+	return NewCheckResult(c.status, "checked").WithDuration(10 * time.Millisecond)
+}
+
+func TestStartMonitoringRecordsMetrics(t *testing.T) {
+	hc := NewChecker()
+	ok := &countingCheck{status: StatusOK}
+	warn := &countingCheck{status: StatusWarning}
+	hc.AddCheck("monitor_ok", ok)
+	hc.AddCheck("monitor_warn", warn)
+
+	durations := metrics.HealthCheckDurationFor("monitor_ok").GetCount()
+	stop := hc.StartMonitoring(context.Background(), 10*time.Millisecond)
+	defer stop()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for ok.runs.Load() < 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("monitoring ran the checks %d times, want at least 3", ok.runs.Load())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	first := hc.GetLastCheck()
+	if first == nil {
+		t.Fatalf("GetLastCheck() = nil while monitoring")
+	}
+	deadline = time.Now().Add(2 * time.Second)
+	for hc.GetLastCheck() == first {
+		if time.Now().After(deadline) {
+			t.Fatalf("GetLastCheck() was not updated by the monitoring loop")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	stop()
+
+	if got := metrics.HealthCheckStatusFor("monitor_ok").GetValue(); got != 1 {
+		t.Errorf("monitor_ok status gauge = %v, want 1", got)
+	}
+	if got := metrics.HealthCheckStatusFor("monitor_warn").GetValue(); got != 0.5 {
+		t.Errorf("monitor_warn status gauge = %v, want 0.5", got)
+	}
+	if metrics.HealthCheckDurationFor("monitor_ok").GetCount() <= durations {
+		t.Errorf("monitor_ok duration histogram was not observed")
+	}
+}
+
+func TestHandlersServeMonitoredResult(t *testing.T) {
+	hc := NewChecker()
+	check := &countingCheck{status: StatusOK}
+	hc.AddCheck("served", check)
+
+	stop := hc.StartMonitoring(context.Background(), time.Hour)
+	defer stop()
+	deadline := time.Now().Add(2 * time.Second)
+	for hc.GetLastCheck() == nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("monitoring did not run the checks")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	runs := check.runs.Load()
+	rec := httptest.NewRecorder()
+	hc.HealthHandler(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("HealthHandler() status = %d, want 200", rec.Code)
+	}
+	if got := check.runs.Load(); got != runs {
+		t.Errorf("HealthHandler() re-ran the checks while monitoring")
+	}
+
+	stop()
+	hc.ReadinessHandler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ready", nil))
+	if got := check.runs.Load(); got != runs+1 {
+		t.Errorf("ReadinessHandler() ran the checks %d times after monitoring stopped, want 1", got-runs)
+	}
+}
+
+func TestStartMonitoringStopsWithContext(t *testing.T) {
+	hc := NewChecker()
+	ctx, cancel := context.WithCancel(context.Background())
+	stop := hc.StartMonitoring(ctx, time.Hour)
+	cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		stop()
+		stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("stop did not return after the context was cancelled")
+	}
+}
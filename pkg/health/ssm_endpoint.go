@@ -0,0 +1,109 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	awsssm "github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/johnlam90/aws-ssm/pkg/logging"
+)
+
+// DefaultSSMEndpointTimeout bounds the SSM endpoint probe
+const DefaultSSMEndpointTimeout = 10 * time.Second
+
+// SSMInstanceInformationAPI is the SSM call used to probe the endpoint
+type SSMInstanceInformationAPI interface {
+	DescribeInstanceInformation(ctx context.Context, params *ssm.DescribeInstanceInformationInput, optFns ...func(*ssm.Options)) (*ssm.DescribeInstanceInformationOutput, error)
+}
+
+// SSMEndpointCheck verifies that the regional SSM API answers authorized
+// requests, which AWS connectivity alone does not guarantee
+type SSMEndpointCheck struct {
+	*BaseHealthCheck
+	region  string
+	timeout time.Duration
+	client  SSMInstanceInformationAPI
+}
+
+// NewSSMEndpointCheck creates an SSM endpoint health check for region. The SSM
+// client is built from the default credential chain unless set with WithClient.
+func NewSSMEndpointCheck(region string, timeout time.Duration) *SSMEndpointCheck {
+	if timeout <= 0 {
+		timeout = DefaultSSMEndpointTimeout
+	}
+	return &SSMEndpointCheck{
+		BaseHealthCheck: NewBaseHealthCheck("ssm_endpoint"),
+		region:          region,
+		timeout:         timeout,
+	}
+}
+
+// WithClient sets the SSM client used for the probe
+func (c *SSMEndpointCheck) WithClient(client SSMInstanceInformationAPI) *SSMEndpointCheck {
+	c.client = client
+	return c
+}
+
+// Check calls DescribeInstanceInformation with a single result and reports
+// whether SSM is usable, along with the call's latency
+func (c *SSMEndpointCheck) Check(ctx context.Context) *CheckResult {
+	start := time.Now()
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	result := NewCheckResult(StatusOK, fmt.Sprintf("SSM endpoint in %s is reachable", c.region))
+	result.WithMetadata("region", c.region)
+	result.WithServiceName("ssm")
+
+	client, err := c.ssmClient(ctx)
+	if err == nil {
+		// 5 is the smallest page size the API accepts
+		_, err = client.DescribeInstanceInformation(ctx, &ssm.DescribeInstanceInformationInput{MaxResults: aws.Int32(5)})
+	}
+	duration := time.Since(start)
+	result.WithDuration(duration)
+	result.WithMetadata("latency_ms", duration.Milliseconds())
+
+	switch {
+	case err == nil:
+		c.logger.Debug("SSM endpoint reachable", logging.String("region", c.region), logging.Duration("latency", duration))
+	case awsssm.IsAccessDenied(err):
+		result.Status = StatusError
+		result.Message = fmt.Sprintf("SSM endpoint in %s is reachable but access was denied: %v", c.region, err)
+		c.logger.Error("SSM access denied", logging.String("region", c.region), logging.String("error", err.Error()))
+	default:
+		result.Status = StatusCritical
+		result.Message = fmt.Sprintf("SSM endpoint in %s is not usable: %v", c.region, err)
+		c.logger.Error("SSM endpoint check failed", logging.String("region", c.region), logging.String("error", err.Error()))
+	}
+
+	c.setLastCheck(result)
+	return result
+}
+
+// regionFromEnv returns the region set by AWS_REGION or AWS_DEFAULT_REGION
+func regionFromEnv() string {
+	if region := os.Getenv("AWS_REGION"); region != "" {
+		return region
+	}
+	return os.Getenv("AWS_DEFAULT_REGION")
+}
+
+// ssmClient returns the configured client, creating one for the region if needed
+func (c *SSMEndpointCheck) ssmClient(ctx context.Context) (SSMInstanceInformationAPI, error) {
+	if c.client != nil {
+		return c.client, nil
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(c.region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	c.client = ssm.NewFromConfig(cfg)
+	return c.client, nil
+}
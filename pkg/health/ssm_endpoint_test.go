@@ -0,0 +1,85 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// apiError mimics an AWS API error carrying an error code
+type apiError struct{ code string }
+
+func (e apiError) Error() string     { return e.code + ": request was denied" }
+func (e apiError) ErrorCode() string { return e.code }
+
+type mockSSMInstanceInformation struct {
+	err   error
+	input *ssm.DescribeInstanceInformationInput
+}
+
+func (m *mockSSMInstanceInformation) DescribeInstanceInformation(_ context.Context, params *ssm.DescribeInstanceInformationInput, _ ...func(*ssm.Options)) (*ssm.DescribeInstanceInformationOutput, error) {
+	m.input = params
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &ssm.DescribeInstanceInformationOutput{}, nil
+}
+
+func TestSSMEndpointCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus Status
+	}{
+		{"reachable", nil, StatusOK},
+		{"access denied", apiError{code: "AccessDeniedException"}, StatusError},
+		{"unreachable", errors.New("dial tcp: lookup ssm.us-east-1.amazonaws.com: no such host"), StatusCritical},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mockSSMInstanceInformation{err: tt.err}
+			result := NewSSMEndpointCheck("us-east-1", time.Second).WithClient(client).Check(context.Background())
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %s (%s), want %s", result.Status, result.Message, tt.wantStatus)
+			}
+			if result.Metadata["region"] != "us-east-1" {
+				t.Errorf("region metadata = %v", result.Metadata["region"])
+			}
+			if _, ok := result.Metadata["latency_ms"]; !ok {
+				t.Error("latency_ms metadata missing")
+			}
+			if client.input == nil || client.input.MaxResults == nil || *client.input.MaxResults != 5 {
+				t.Errorf("probe input = %+v, want a single small page", client.input)
+			}
+		})
+	}
+}
+
+func TestNewDefaultCheckerRegistersSSMEndpoint(t *testing.T) {
+	noop := func(context.Context) error { return nil }
+
+	tests := []struct {
+		name   string
+		region string
+		want   bool
+	}{
+		{"region known", "eu-west-1", true},
+		{"no region", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("AWS_REGION", tt.region)
+			t.Setenv("AWS_DEFAULT_REGION", "")
+
+			hc := NewDefaultChecker(noop, noop, func() error { return nil })
+			if _, ok := hc.GetCheck("ssm_endpoint"); ok != tt.want {
+				t.Errorf("ssm_endpoint registered = %v, want %v", ok, tt.want)
+			}
+		})
+	}
+}
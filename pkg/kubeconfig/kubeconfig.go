@@ -0,0 +1,215 @@
+// Package kubeconfig writes kubeconfig entries for EKS clusters.
+package kubeconfig
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// execAPIVersion is the client authentication API the exec plugin speaks
+const execAPIVersion = "client.authentication.k8s.io/v1beta1"
+
+// Cluster is the EKS cluster an entry is generated for
+type Cluster struct {
+	Name     string
+	ARN      string
+	Endpoint string
+	CAData   string // Base64-encoded certificate authority data
+	Region   string
+}
+
+// Options control how an entry is generated
+type Options struct {
+	Profile string // AWS profile the exec plugin uses; empty for the default chain
+	Alias   string // Context name; defaults to the cluster ARN
+}
+
+// Config is a kubeconfig file. Fields this package does not manage are kept
+// in the inline maps so merging into an existing file preserves them.
+type Config struct {
+	APIVersion     string                 `yaml:"apiVersion"`
+	Kind           string                 `yaml:"kind"`
+	Clusters       []NamedCluster         `yaml:"clusters"`
+	Contexts       []NamedContext         `yaml:"contexts"`
+	CurrentContext string                 `yaml:"current-context"`
+	Users          []NamedUser            `yaml:"users"`
+	Extra          map[string]interface{} `yaml:",inline"`
+}
+
+// NamedCluster is a clusters entry
+type NamedCluster struct {
+	Name    string       `yaml:"name"`
+	Cluster ClusterEntry `yaml:"cluster"`
+}
+
+// ClusterEntry is how kubectl reaches a cluster's API server
+type ClusterEntry struct {
+	Server                   string                 `yaml:"server"`
+	CertificateAuthorityData string                 `yaml:"certificate-authority-data,omitempty"`
+	Extra                    map[string]interface{} `yaml:",inline"`
+}
+
+// NamedContext is a contexts entry
+type NamedContext struct {
+	Name    string       `yaml:"name"`
+	Context ContextEntry `yaml:"context"`
+}
+
+// ContextEntry pairs a cluster with a user
+type ContextEntry struct {
+	Cluster string                 `yaml:"cluster"`
+	User    string                 `yaml:"user"`
+	Extra   map[string]interface{} `yaml:",inline"`
+}
+
+// NamedUser is a users entry
+type NamedUser struct {
+	Name string    `yaml:"name"`
+	User UserEntry `yaml:"user"`
+}
+
+// UserEntry holds a user's credentials
+type UserEntry struct {
+	Exec  *ExecConfig            `yaml:"exec,omitempty"`
+	Extra map[string]interface{} `yaml:",inline"`
+}
+
+// ExecConfig runs a command that prints an ExecCredential
+type ExecConfig struct {
+	APIVersion string       `yaml:"apiVersion"`
+	Command    string       `yaml:"command"`
+	Args       []string     `yaml:"args"`
+	Env        []ExecEnvVar `yaml:"env,omitempty"`
+}
+
+// ExecEnvVar is an environment variable set for the exec command
+type ExecEnvVar struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+// DefaultPath returns the first file in $KUBECONFIG, or ~/.kube/config
+func DefaultPath() (string, error) {
+	if paths := filepath.SplitList(os.Getenv("KUBECONFIG")); len(paths) > 0 && paths[0] != "" {
+		return paths[0], nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".kube", "config"), nil
+}
+
+// New returns a kubeconfig holding only the entry for cluster
+func New(cluster Cluster, opts Options) (*Config, error) {
+	cfg := &Config{APIVersion: "v1", Kind: "Config"}
+	if err := cfg.Upsert(cluster, opts); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Upsert adds or replaces the cluster, user and context entries for cluster,
+// named after its ARN like `aws eks update-kubeconfig`, and makes the context
+// current. Entries for other clusters are left untouched.
+func (c *Config) Upsert(cluster Cluster, opts Options) error {
+	if cluster.Name == "" || cluster.Endpoint == "" {
+		return fmt.Errorf("cluster %q has no API endpoint yet", cluster.Name)
+	}
+	if cluster.Region == "" {
+		return fmt.Errorf("region is required for the kubeconfig of cluster %s", cluster.Name)
+	}
+	name := cluster.ARN
+	if name == "" {
+		name = cluster.Name
+	}
+	contextName := opts.Alias
+	if contextName == "" {
+		contextName = name
+	}
+
+	args := []string{"--region", cluster.Region, "eks", "get-token", "--cluster-name", cluster.Name, "--output", "json"}
+	exec := &ExecConfig{APIVersion: execAPIVersion, Command: "aws", Args: args}
+	if opts.Profile != "" {
+		exec.Env = []ExecEnvVar{{Name: "AWS_PROFILE", Value: opts.Profile}}
+	}
+
+	upsert(&c.Clusters, NamedCluster{Name: name, Cluster: ClusterEntry{
+		Server:                   cluster.Endpoint,
+		CertificateAuthorityData: cluster.CAData,
+	}}, func(e NamedCluster) string { return e.Name })
+	upsert(&c.Users, NamedUser{Name: name, User: UserEntry{Exec: exec}},
+		func(e NamedUser) string { return e.Name })
+	upsert(&c.Contexts, NamedContext{Name: contextName, Context: ContextEntry{Cluster: name, User: name}},
+		func(e NamedContext) string { return e.Name })
+	c.CurrentContext = contextName
+	return nil
+}
+
+// upsert replaces the entry of entries named like entry, or appends it
+func upsert[T any](entries *[]T, entry T, name func(T) string) {
+	for i, existing := range *entries {
+		if name(existing) == name(entry) {
+			(*entries)[i] = entry
+			return
+		}
+	}
+	*entries = append(*entries, entry)
+}
+
+// Load reads the kubeconfig at path; a missing file yields an empty config
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - path comes from the user's own --kubeconfig flag
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{APIVersion: "v1", Kind: "Config"}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kubeconfig %s: %w", path, err)
+	}
+	cfg := &Config{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig %s: %w", path, err)
+	}
+	if cfg.APIVersion == "" {
+		cfg.APIVersion = "v1"
+	}
+	if cfg.Kind == "" {
+		cfg.Kind = "Config"
+	}
+	return cfg, nil
+}
+
+// Save writes cfg to path, readable only by the current user
+func Save(path string, cfg *Config) error {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal kubeconfig: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create kubeconfig directory: %w", err)
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// Write adds the entry for cluster to the kubeconfig at path. With merge the
+// existing file's other entries are kept; otherwise the file is replaced.
+func Write(path string, cluster Cluster, opts Options, merge bool) error {
+	var cfg *Config
+	var err error
+	if merge {
+		if cfg, err = Load(path); err != nil {
+			return err
+		}
+		err = cfg.Upsert(cluster, opts)
+	} else {
+		cfg, err = New(cluster, opts)
+	}
+	if err != nil {
+		return err
+	}
+	return Save(path, cfg)
+}
@@ -0,0 +1,202 @@
+package kubeconfig
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+var testCluster = Cluster{
+	Name:     "prod",
+	ARN:      "arn:aws:eks:us-west-2:123456789012:cluster/prod",
+	Endpoint: "https://ABC.gr7.us-west-2.eks.amazonaws.com",
+	CAData:   "LS0tLS1CRUdJTg==",
+	Region:   "us-west-2",
+}
+
+func TestNewStructure(t *testing.T) {
+	cfg, err := New(testCluster, Options{Profile: "production"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error = %v", err)
+	}
+
+	var raw struct {
+		APIVersion     string `yaml:"apiVersion"`
+		Kind           string `yaml:"kind"`
+		CurrentContext string `yaml:"current-context"`
+		Clusters       []struct {
+			Name    string `yaml:"name"`
+			Cluster struct {
+				Server string `yaml:"server"`
+				CA     string `yaml:"certificate-authority-data"`
+			} `yaml:"cluster"`
+		} `yaml:"clusters"`
+		Contexts []struct {
+			Name    string `yaml:"name"`
+			Context struct {
+				Cluster string `yaml:"cluster"`
+				User    string `yaml:"user"`
+			} `yaml:"context"`
+		} `yaml:"contexts"`
+		Users []struct {
+			Name string `yaml:"name"`
+			User struct {
+				Exec struct {
+					APIVersion string              `yaml:"apiVersion"`
+					Command    string              `yaml:"command"`
+					Args       []string            `yaml:"args"`
+					Env        []map[string]string `yaml:"env"`
+				} `yaml:"exec"`
+			} `yaml:"user"`
+		} `yaml:"users"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v\n%s", err, data)
+	}
+
+	arn := testCluster.ARN
+	if raw.APIVersion != "v1" || raw.Kind != "Config" || raw.CurrentContext != arn {
+		t.Errorf("header = %s/%s current-context %q", raw.APIVersion, raw.Kind, raw.CurrentContext)
+	}
+	if len(raw.Clusters) != 1 || raw.Clusters[0].Name != arn ||
+		raw.Clusters[0].Cluster.Server != testCluster.Endpoint || raw.Clusters[0].Cluster.CA != testCluster.CAData {
+		t.Errorf("clusters = %+v", raw.Clusters)
+	}
+	if len(raw.Contexts) != 1 || raw.Contexts[0].Context.Cluster != arn || raw.Contexts[0].Context.User != arn {
+		t.Errorf("contexts = %+v", raw.Contexts)
+	}
+	if len(raw.Users) != 1 {
+		t.Fatalf("users = %+v", raw.Users)
+	}
+	exec := raw.Users[0].User.Exec
+	if exec.APIVersion != execAPIVersion || exec.Command != "aws" {
+		t.Errorf("exec = %+v", exec)
+	}
+	if got := strings.Join(exec.Args, " "); got != "--region us-west-2 eks get-token --cluster-name prod --output json" {
+		t.Errorf("exec args = %q", got)
+	}
+	if len(exec.Env) != 1 || exec.Env[0]["name"] != "AWS_PROFILE" || exec.Env[0]["value"] != "production" {
+		t.Errorf("exec env = %+v, want AWS_PROFILE", exec.Env)
+	}
+}
+
+func TestNewRequiresEndpointAndRegion(t *testing.T) {
+	noEndpoint := testCluster
+	noEndpoint.Endpoint = ""
+	if _, err := New(noEndpoint, Options{}); err == nil {
+		t.Errorf("New() accepted a cluster without an endpoint")
+	}
+	noRegion := testCluster
+	noRegion.Region = ""
+	if _, err := New(noRegion, Options{}); err == nil {
+		t.Errorf("New() accepted a cluster without a region")
+	}
+}
+
+const existingKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: minikube
+  cluster:
+    server: https://192.168.49.2:8443
+    insecure-skip-tls-verify: true
+- name: arn:aws:eks:us-west-2:123456789012:cluster/prod
+  cluster:
+    server: https://stale.example.com
+contexts:
+- name: minikube
+  context:
+    cluster: minikube
+    user: minikube
+    namespace: dev
+current-context: minikube
+preferences: {}
+users:
+- name: minikube
+  user:
+    client-certificate: /home/me/.minikube/client.crt
+`
+
+func TestWriteMergePreservesExistingContexts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(existingKubeconfig), 0600); err != nil {
+		t.Fatalf("write kubeconfig: %v", err)
+	}
+
+	if err := Write(path, testCluster, Options{Alias: "prod"}, true); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.CurrentContext != "prod" {
+		t.Errorf("current-context = %q, want the new context", cfg.CurrentContext)
+	}
+	if len(cfg.Contexts) != 2 || cfg.Contexts[0].Name != "minikube" || cfg.Contexts[0].Context.Extra["namespace"] != "dev" {
+		t.Errorf("contexts = %+v, want minikube kept with its namespace", cfg.Contexts)
+	}
+	if len(cfg.Clusters) != 2 || cfg.Clusters[0].Cluster.Extra["insecure-skip-tls-verify"] != true {
+		t.Errorf("clusters = %+v, want minikube kept with its settings", cfg.Clusters)
+	}
+	if cfg.Clusters[1].Cluster.Server != testCluster.Endpoint {
+		t.Errorf("stale cluster entry not replaced: %+v", cfg.Clusters[1])
+	}
+	if len(cfg.Users) != 2 || cfg.Users[0].User.Extra["client-certificate"] == nil {
+		t.Errorf("users = %+v, want the minikube certificate kept", cfg.Users)
+	}
+	if _, ok := cfg.Extra["preferences"]; !ok {
+		t.Errorf("top-level preferences dropped")
+	}
+
+	// Writing again replaces the entries instead of duplicating them
+	if err := Write(path, testCluster, Options{Alias: "prod"}, true); err != nil {
+		t.Fatalf("second Write() error = %v", err)
+	}
+	if cfg, err = Load(path); err != nil || len(cfg.Clusters) != 2 || len(cfg.Contexts) != 2 || len(cfg.Users) != 2 {
+		t.Errorf("second merge duplicated entries: %+v, %v", cfg, err)
+	}
+}
+
+func TestWriteOverwrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "config")
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(existingKubeconfig), 0600); err != nil {
+		t.Fatalf("write kubeconfig: %v", err)
+	}
+
+	if err := Write(path, testCluster, Options{}, false); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cfg.Contexts) != 1 || cfg.Contexts[0].Name != testCluster.ARN {
+		t.Errorf("contexts = %+v, want only the new cluster", cfg.Contexts)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat kubeconfig: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("kubeconfig mode = %v, want 0600", info.Mode().Perm())
+	}
+}
+
+func TestDefaultPath(t *testing.T) {
+	t.Setenv("KUBECONFIG", "/tmp/a"+string(os.PathListSeparator)+"/tmp/b")
+	if got, err := DefaultPath(); err != nil || got != "/tmp/a" {
+		t.Errorf("DefaultPath() = %q, %v; want the first $KUBECONFIG entry", got, err)
+	}
+}
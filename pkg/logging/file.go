@@ -0,0 +1,144 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	// DefaultLogFileMaxBytes is the size at which a log file is rotated
+	DefaultLogFileMaxBytes = 10 << 20
+	// DefaultLogFileBackups is how many rotated log files are kept
+	DefaultLogFileBackups = 3
+)
+
+// RotatingFile is an append-only log file that is rotated once it would grow
+// past maxBytes. Rotated files are named path.1 (newest) to path.<backups>.
+type RotatingFile struct {
+	path     string
+	maxBytes int64
+	backups  int
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+}
+
+// NewRotatingFile opens path for appending, creating it and its directory if needed
+func NewRotatingFile(path string, maxBytes int64, backups int) (*RotatingFile, error) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultLogFileMaxBytes
+	}
+	if backups < 0 {
+		backups = 0
+	}
+	f := &RotatingFile{path: path, maxBytes: maxBytes, backups: backups}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write appends p, rotating first when p would push the file past its size limit
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+	if f.size > 0 && f.size+int64(len(p)) > f.maxBytes {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close closes the current log file
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// open opens the log file for appending and records its current size
+func (f *RotatingFile) open() error {
+	// #nosec G304 - path comes from the user's own --log-file flag
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+// rotate shifts path.N to path.N+1, dropping the oldest, moves the current
+// file to path.1 and starts a new one
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	f.file = nil
+
+	if f.backups == 0 {
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to rotate log file: %w", err)
+		}
+		return f.open()
+	}
+	for i := f.backups - 1; i >= 1; i-- {
+		src := fmt.Sprintf("%s.%d", f.path, i)
+		if err := os.Rename(src, fmt.Sprintf("%s.%d", f.path, i+1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to rotate log file: %w", err)
+		}
+	}
+	if err := os.Rename(f.path, f.path+".1"); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	return f.open()
+}
+
+// teeWriter writes log records to the primary output and any added outputs.
+// Failures of added outputs are ignored so they never block console logging.
+type teeWriter struct {
+	mu      sync.RWMutex
+	primary io.Writer
+	extra   []io.Writer
+}
+
+func (t *teeWriter) Write(p []byte) (int, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, w := range t.extra {
+		_, _ = w.Write(p)
+	}
+	return t.primary.Write(p)
+}
+
+// add registers another output
+func (t *teeWriter) add(w io.Writer) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.extra = append(t.extra, w)
+}
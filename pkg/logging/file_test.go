@@ -0,0 +1,113 @@
+package logging
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRotatingFileRotatesPastMaxBytes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "app.log")
+	f, err := NewRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatalf("NewRotatingFile() error = %v", err)
+	}
+	defer f.Close()
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatalf("Write(%q) error = %v", line, err)
+		}
+	}
+
+	tests := []struct {
+		file string
+		want string
+	}{
+		{path, "fourth\n"},
+		{path + ".1", "third\n"},
+		{path + ".2", "second\n"},
+	}
+	for _, tt := range tests {
+		data, err := os.ReadFile(tt.file)
+		if err != nil {
+			t.Fatalf("ReadFile(%s) error = %v", tt.file, err)
+		}
+		if string(data) != tt.want {
+			t.Errorf("%s = %q, want %q", filepath.Base(tt.file), data, tt.want)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("%s.3 should have been dropped, stat error = %v", path, err)
+	}
+}
+
+func TestRotatingFileAppendsToExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, []byte("12345678\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := NewRotatingFile(path, 10, 1)
+	if err != nil {
+		t.Fatalf("NewRotatingFile() error = %v", err)
+	}
+	defer f.Close()
+
+	// The existing size counts towards the limit
+	if _, err := f.Write([]byte("next\n")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if data, _ := os.ReadFile(path + ".1"); string(data) != "12345678\n" {
+		t.Errorf("rotated file = %q, want the previous content", data)
+	}
+}
+
+func TestTeeWriterCopiesLogsToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	f, err := NewRotatingFile(path, DefaultLogFileMaxBytes, DefaultLogFileBackups)
+	if err != nil {
+		t.Fatalf("NewRotatingFile() error = %v", err)
+	}
+	defer f.Close()
+
+	var console bytes.Buffer
+	tee := &teeWriter{primary: &console}
+	logger := NewLogger(WithOutput(tee), WithLevel("info"))
+	logger.Info("before file")
+	tee.add(f)
+	logger.With(String("component", "test")).Info("after file")
+	logger.Debug("below level")
+
+	lines := strings.Split(strings.TrimSpace(console.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], "before file") || !strings.Contains(lines[1], "after file") {
+		t.Fatalf("console output = %q, want both info records", console.String())
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if got := strings.TrimSpace(string(data)); got != lines[1] {
+		t.Errorf("log file = %q, want the console record %q", got, lines[1])
+	}
+}
+
+func TestTeeWriterIgnoresFailingOutputs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	f, err := NewRotatingFile(path, DefaultLogFileMaxBytes, DefaultLogFileBackups)
+	if err != nil {
+		t.Fatalf("NewRotatingFile() error = %v", err)
+	}
+	_ = f.Close()
+
+	var console bytes.Buffer
+	tee := &teeWriter{primary: &console}
+	tee.add(f)
+	if _, err := tee.Write([]byte("record\n")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if console.String() != "record\n" {
+		t.Errorf("console = %q, want the record", console.String())
+	}
+}
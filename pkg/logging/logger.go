@@ -387,13 +387,28 @@ func extractContextFields(ctx context.Context) []Field {
 var globalLogger Logger
 var once sync.Once
 
+// globalOutput carries the global logger's records, so outputs added later
+// also receive records from loggers derived before they were added
+var globalOutput = &teeWriter{}
+
 // Init initializes the global logger
 func Init(opts ...Option) {
 	once.Do(func() {
+		opts = append(opts, func(c *Config) {
+			globalOutput.primary = c.Output
+			c.Output = globalOutput
+		})
 		globalLogger = NewLogger(opts...)
 	})
 }
 
+// AddOutput also writes the global logger's records to w, with the same
+// level and format as its primary output
+func AddOutput(w io.Writer) {
+	Default()
+	globalOutput.add(w)
+}
+
 // Default returns the global logger, initializing it if needed
 func Default() Logger {
 	if globalLogger == nil {
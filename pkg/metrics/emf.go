@@ -0,0 +1,182 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/logging"
+)
+
+// DefaultEMFNamespace is the CloudWatch namespace used when none is configured
+const DefaultEMFNamespace = "aws-ssm"
+
+// DefaultEMFEndpoint is the CloudWatch agent's EMF listener, used when no
+// endpoint is configured
+const DefaultEMFEndpoint = "udp://127.0.0.1:25888"
+
+// CloudWatchEMFReporter writes metrics as CloudWatch Embedded Metric Format
+// log lines, which CloudWatch Logs turns into metrics on ingestion. Counters
+// and histograms are reported as the change since the previous report.
+type CloudWatchEMFReporter struct {
+	namespace string
+	out       io.Writer
+	mu        sync.Mutex
+	now       func() time.Time
+	logger    logging.Logger
+	last      map[string]float64 // Last reported cumulative value per series member
+}
+
+// emfMetadata is the _aws member of an EMF document
+type emfMetadata struct {
+	Timestamp         int64                `json:"Timestamp"`
+	CloudWatchMetrics []emfMetricDirective `json:"CloudWatchMetrics"`
+}
+
+type emfMetricDirective struct {
+	Namespace  string             `json:"Namespace"`
+	Dimensions [][]string         `json:"Dimensions"`
+	Metrics    []emfMetricDetails `json:"Metrics"`
+}
+
+type emfMetricDetails struct {
+	Name string `json:"Name"`
+	Unit string `json:"Unit"`
+}
+
+// NewCloudWatchEMFReporter creates a reporter writing EMF documents to out,
+// such as a sink from OpenEMFSink
+func NewCloudWatchEMFReporter(namespace string, out io.Writer) *CloudWatchEMFReporter {
+	if namespace == "" {
+		namespace = DefaultEMFNamespace
+	}
+	return &CloudWatchEMFReporter{
+		namespace: namespace,
+		out:       out,
+		now:       time.Now,
+		logger:    logging.With(logging.String("component", "emf_reporter")),
+		last:      make(map[string]float64),
+	}
+}
+
+// OpenEMFSink opens where EMF documents are written: a CloudWatch agent
+// listener given as tcp://host:port or udp://host:port, or else a file the
+// documents are appended to. An empty endpoint uses DefaultEMFEndpoint.
+func OpenEMFSink(endpoint string) (io.WriteCloser, error) {
+	if endpoint == "" {
+		endpoint = DefaultEMFEndpoint
+	}
+	for _, network := range []string{"tcp", "udp"} {
+		if addr, ok := strings.CutPrefix(endpoint, network+"://"); ok {
+			conn, err := net.DialTimeout(network, addr, 2*time.Second)
+			if err != nil {
+				return nil, fmt.Errorf("failed to connect to EMF endpoint %s: %w", endpoint, err)
+			}
+			return conn, nil
+		}
+	}
+
+	file, err := os.OpenFile(filepath.Clean(endpoint), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open EMF file: %w", err)
+	}
+	return file, nil
+}
+
+// Report writes one EMF document per metric. Labels become dimensions;
+// histograms are reported as <name>_sum and <name>_count, and timers are skipped.
+func (r *CloudWatchEMFReporter) Report(_ context.Context, metrics []*Metric) error {
+	timestamp := r.now().UnixMilli()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	encoder := json.NewEncoder(r.out)
+	written := 0
+	for _, m := range metrics {
+		doc := r.document(m, timestamp)
+		if doc == nil {
+			continue
+		}
+		if err := encoder.Encode(doc); err != nil {
+			return fmt.Errorf("failed to write EMF metric %s: %w", m.Name, err)
+		}
+		written++
+	}
+
+	r.logger.Debug("EMF metrics reported", logging.Int("metric_count", written))
+	return nil
+}
+
+// delta returns how much the cumulative value of a series member grew since
+// the previous report. A value below the last one means the metric was reset.
+func (r *CloudWatchEMFReporter) delta(key string, value float64) float64 {
+	last, seen := r.last[key]
+	r.last[key] = value
+	if !seen || value < last {
+		return value
+	}
+	return value - last
+}
+
+// document builds the EMF document of m, or nil for timers and for counters
+// and histograms that did not change since the previous report
+func (r *CloudWatchEMFReporter) document(m *Metric, timestamp int64) map[string]interface{} {
+	if m == nil || m.Type == MetricTimer {
+		return nil
+	}
+	series := m.Name + formatLabels(m.Labels, "", "")
+
+	doc := make(map[string]interface{}, len(m.Labels)+3)
+	dimensions := make([]string, 0, len(m.Labels))
+	for k, v := range m.Labels {
+		dimensions = append(dimensions, k)
+		doc[k] = v
+	}
+	sort.Strings(dimensions)
+
+	var details []emfMetricDetails
+	switch {
+	case m.Type == MetricHistogram && m.Histogram != nil:
+		count := r.delta(series+"_count", float64(m.Histogram.Count))
+		sum := r.delta(series+"_sum", m.Histogram.Sum)
+		if count == 0 {
+			return nil
+		}
+		details = []emfMetricDetails{{Name: m.Name + "_sum", Unit: "None"}, {Name: m.Name + "_count", Unit: "Count"}}
+		doc[m.Name+"_sum"] = sum
+		doc[m.Name+"_count"] = count
+	case m.Type == MetricCounter:
+		value := r.delta(series, m.Value)
+		if value == 0 {
+			return nil
+		}
+		details = []emfMetricDetails{{Name: m.Name, Unit: "Count"}}
+		doc[m.Name] = value
+	default:
+		details = []emfMetricDetails{{Name: m.Name, Unit: "None"}}
+		doc[m.Name] = m.Value
+	}
+
+	doc["_aws"] = emfMetadata{
+		Timestamp: timestamp,
+		CloudWatchMetrics: []emfMetricDirective{{
+			Namespace:  r.namespace,
+			Dimensions: [][]string{dimensions},
+			Metrics:    details,
+		}},
+	}
+	return doc
+}
+
+// Name returns the name of the EMF reporter
+func (r *CloudWatchEMFReporter) Name() string {
+	return "cloudwatch_emf"
+}
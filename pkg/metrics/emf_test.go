@@ -0,0 +1,264 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// emfDocument is the parsed form of one EMF log line
+type emfDocument struct {
+	AWS struct {
+		Timestamp         int64 `json:"Timestamp"`
+		CloudWatchMetrics []struct {
+			Namespace  string     `json:"Namespace"`
+			Dimensions [][]string `json:"Dimensions"`
+			Metrics    []struct {
+				Name string `json:"Name"`
+				Unit string `json:"Unit"`
+			} `json:"Metrics"`
+		} `json:"CloudWatchMetrics"`
+	} `json:"_aws"`
+	Members map[string]interface{} `json:"-"`
+}
+
+func parseEMF(t *testing.T, out string) []emfDocument {
+	t.Helper()
+	var docs []emfDocument
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var doc emfDocument
+		if err := json.Unmarshal([]byte(line), &doc); err != nil {
+			t.Fatalf("invalid EMF line %q: %v", line, err)
+		}
+		if err := json.Unmarshal([]byte(line), &doc.Members); err != nil {
+			t.Fatalf("invalid EMF line %q: %v", line, err)
+		}
+		if len(doc.AWS.CloudWatchMetrics) != 1 {
+			t.Fatalf("CloudWatchMetrics = %d directives, want 1", len(doc.AWS.CloudWatchMetrics))
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+func TestCloudWatchEMFReporter(t *testing.T) {
+	counter := NewCounter("requests_total", map[string]string{"region": "us-east-1", "operation": "StartSession"})
+	counter.Add(4)
+	histogram := NewHistogram("latency_seconds", nil, nil)
+	histogram.Observe(0.5)
+	histogram.Observe(1.5)
+
+	var buf bytes.Buffer
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	reporter := NewCloudWatchEMFReporter("Custom/SSM", &buf)
+	reporter.now = func() time.Time { return now }
+
+	metrics := []*Metric{counter.ToMetric(), histogram.ToMetric(), NewTimer("skipped", nil).ToMetric()}
+	if err := reporter.Report(context.Background(), metrics); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	docs := parseEMF(t, buf.String())
+	if len(docs) != 2 {
+		t.Fatalf("documents = %d, want 2 (timers are skipped)", len(docs))
+	}
+
+	tests := []struct {
+		name       string
+		doc        emfDocument
+		dimensions []string
+		metrics    map[string]string // name -> unit
+		values     map[string]interface{}
+	}{
+		{
+			name:       "counter",
+			doc:        docs[0],
+			dimensions: []string{"operation", "region"},
+			metrics:    map[string]string{"requests_total": "Count"},
+			values:     map[string]interface{}{"requests_total": 4.0, "region": "us-east-1", "operation": "StartSession"},
+		},
+		{
+			name:       "histogram",
+			doc:        docs[1],
+			dimensions: []string{},
+			metrics:    map[string]string{"latency_seconds_sum": "None", "latency_seconds_count": "Count"},
+			values:     map[string]interface{}{"latency_seconds_sum": 2.0, "latency_seconds_count": 2.0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			directive := tt.doc.AWS.CloudWatchMetrics[0]
+			if tt.doc.AWS.Timestamp != now.UnixMilli() {
+				t.Errorf("Timestamp = %d, want %d", tt.doc.AWS.Timestamp, now.UnixMilli())
+			}
+			if directive.Namespace != "Custom/SSM" {
+				t.Errorf("Namespace = %q, want Custom/SSM", directive.Namespace)
+			}
+			if len(directive.Dimensions) != 1 || !reflect.DeepEqual(directive.Dimensions[0], tt.dimensions) {
+				t.Errorf("Dimensions = %v, want [%v]", directive.Dimensions, tt.dimensions)
+			}
+			got := make(map[string]string)
+			for _, m := range directive.Metrics {
+				got[m.Name] = m.Unit
+			}
+			if !reflect.DeepEqual(got, tt.metrics) {
+				t.Errorf("Metrics = %v, want %v", got, tt.metrics)
+			}
+			for k, want := range tt.values {
+				if tt.doc.Members[k] != want {
+					t.Errorf("member %s = %v, want %v", k, tt.doc.Members[k], want)
+				}
+			}
+		})
+	}
+}
+
+func TestCloudWatchEMFReporterDefaultNamespace(t *testing.T) {
+	if got := NewCloudWatchEMFReporter("", io.Discard).namespace; got != DefaultEMFNamespace {
+		t.Errorf("namespace = %q, want %q", got, DefaultEMFNamespace)
+	}
+}
+
+func TestServiceReportsToEMF(t *testing.T) {
+	var buf bytes.Buffer
+	reporter := NewCloudWatchEMFReporter("", &buf)
+
+	service := NewService(context.Background())
+	service.AddReporter(reporter)
+	service.report()
+
+	docs := parseEMF(t, buf.String())
+	if len(docs) == 0 {
+		t.Fatal("service reported no EMF documents")
+	}
+	for _, doc := range docs {
+		if ns := doc.AWS.CloudWatchMetrics[0].Namespace; ns != DefaultEMFNamespace {
+			t.Errorf("Namespace = %q, want %q", ns, DefaultEMFNamespace)
+		}
+	}
+}
+
+func TestCloudWatchEMFReporterReportsDeltas(t *testing.T) {
+	counter := NewCounter("requests_total", nil)
+	histogram := NewHistogram("latency_seconds", nil, nil)
+	var buf bytes.Buffer
+	reporter := NewCloudWatchEMFReporter("", &buf)
+
+	report := func() []emfDocument {
+		t.Helper()
+		buf.Reset()
+		if err := reporter.Report(context.Background(), []*Metric{counter.ToMetric(), histogram.ToMetric()}); err != nil {
+			t.Fatalf("Report() error = %v", err)
+		}
+		if buf.Len() == 0 {
+			return nil
+		}
+		return parseEMF(t, buf.String())
+	}
+
+	counter.Add(3)
+	histogram.Observe(1)
+	if docs := report(); len(docs) != 2 || docs[0].Members["requests_total"] != 3.0 || docs[1].Members["latency_seconds_count"] != 1.0 {
+		t.Fatalf("first report = %+v, want the initial values", docs)
+	}
+
+	counter.Add(2)
+	docs := report()
+	if len(docs) != 1 || docs[0].Members["requests_total"] != 2.0 {
+		t.Fatalf("second report = %+v, want only the counter's delta of 2", docs)
+	}
+
+	if docs := report(); len(docs) != 0 {
+		t.Errorf("report without changes = %+v, want nothing", docs)
+	}
+
+	counter.Reset()
+	counter.Add(1)
+	if docs := report(); len(docs) != 1 || docs[0].Members["requests_total"] != 1.0 {
+		t.Errorf("report after a reset = %+v, want the new value", docs)
+	}
+}
+
+func TestOpenEMFSinkFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "emf.log")
+	sink, err := OpenEMFSink(path)
+	if err != nil {
+		t.Fatalf("OpenEMFSink() error = %v", err)
+	}
+	counter := NewCounter("requests_total", nil)
+	counter.Inc(1)
+	if err := NewCloudWatchEMFReporter("", sink).Report(context.Background(), []*Metric{counter.ToMetric()}); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if docs := parseEMF(t, string(data)); len(docs) != 1 {
+		t.Errorf("file has %d EMF documents, want 1", len(docs))
+	}
+}
+
+func TestOpenEMFSinkUDP(t *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on UDP: %v", err)
+	}
+	defer listener.Close()
+
+	sink, err := OpenEMFSink("udp://" + listener.LocalAddr().String())
+	if err != nil {
+		t.Fatalf("OpenEMFSink() error = %v", err)
+	}
+	defer sink.Close()
+	counter := NewCounter("requests_total", nil)
+	counter.Inc(1)
+	if err := NewCloudWatchEMFReporter("", sink).Report(context.Background(), []*Metric{counter.ToMetric()}); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	buf := make([]byte, 4096)
+	_ = listener.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, _, err := listener.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("no EMF datagram received: %v", err)
+	}
+	if docs := parseEMF(t, string(buf[:n])); len(docs) != 1 {
+		t.Errorf("datagram has %d EMF documents, want 1", len(docs))
+	}
+}
+
+func TestShutdownGlobalMetricsServiceFlushesEMF(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "emf.log")
+	t.Setenv("AWS_SSM_EMF_NAMESPACE", "Test/SSM")
+	t.Setenv("AWS_SSM_EMF_ENDPOINT", path)
+	if GlobalMetricsService != nil {
+		t.Skip("global metrics service already initialized")
+	}
+
+	InitializeGlobalMetricsService(context.Background())
+	SessionStartTotal.Inc(1)
+	ShutdownGlobalMetricsService()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "session_start_total") {
+		t.Errorf("EMF file after shutdown = %q, want the session_start_total counter", data)
+	}
+	if GlobalMetricsService != nil {
+		t.Error("ShutdownGlobalMetricsService() left the global service set")
+	}
+}
@@ -2,6 +2,10 @@ package metrics
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -30,6 +34,20 @@ type Metric struct {
 	Labels      map[string]string
 	Timestamp   time.Time
 	Description string
+	Histogram   *HistogramData // Set for histograms
+}
+
+// HistogramData is the bucket distribution of a histogram metric
+type HistogramData struct {
+	Buckets []Bucket // Cumulative, by ascending upper bound
+	Sum     float64
+	Count   uint64
+}
+
+// Bucket is the number of observations less than or equal to UpperBound
+type Bucket struct {
+	UpperBound float64
+	Count      uint64
 }
 
 // Counter represents a cumulative counter
@@ -71,6 +89,13 @@ func (c *Counter) Set(value float64) {
 	c.logger.Debug("Counter set", logging.String("name", c.name), logging.Float64("value", value))
 }
 
+// Reset sets the counter back to zero
+func (c *Counter) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.value = 0
+}
+
 // GetValue returns the current counter value
 func (c *Counter) GetValue() float64 {
 	c.mu.RLock()
@@ -131,6 +156,13 @@ func (g *Gauge) Set(value float64) {
 	g.logger.Debug("Gauge set", logging.String("name", g.name), logging.Float64("value", value))
 }
 
+// Reset sets the gauge back to zero
+func (g *Gauge) Reset() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.value = 0
+}
+
 // GetValue returns the current gauge value
 func (g *Gauge) GetValue() float64 {
 	g.mu.RLock()
@@ -213,6 +245,15 @@ func (h *Histogram) Observe(value float64) {
 	h.logger.Debug("Histogram observation", logging.String("name", h.name), logging.Float64("value", value), logging.Int64("count", count))
 }
 
+// Reset discards all observations
+func (h *Histogram) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.sum = 0
+	h.count = 0
+	h.buckets = make(map[float64]uint64)
+}
+
 // GetCount returns the number of observations
 func (h *Histogram) GetCount() uint64 {
 	h.mu.RLock()
@@ -244,6 +285,14 @@ func (h *Histogram) ToMetric() *Metric {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
+	// Observe counts each observation in its first bucket only, so accumulate
+	data := &HistogramData{Sum: h.sum, Count: h.count}
+	var cumulative uint64
+	for _, bound := range DefaultHistogramConfig().Buckets {
+		cumulative += h.buckets[bound]
+		data.Buckets = append(data.Buckets, Bucket{UpperBound: bound, Count: cumulative})
+	}
+
 	return &Metric{
 		Name:        h.name,
 		Type:        MetricHistogram,
@@ -251,6 +300,7 @@ func (h *Histogram) ToMetric() *Metric {
 		Labels:      h.labels,
 		Timestamp:   time.Now(),
 		Description: "Histogram metric",
+		Histogram:   data,
 	}
 }
 
@@ -258,9 +308,28 @@ func (h *Histogram) ToMetric() *Metric {
 type Timer struct {
 	name   string
 	labels map[string]string
+	mu     sync.RWMutex
+	stats  TimerStats
 	logger logging.Logger
 }
 
+// TimerStats aggregates the durations recorded by a timer
+type TimerStats struct {
+	Count uint64
+	Sum   time.Duration
+	Last  time.Duration
+	Min   time.Duration
+	Max   time.Duration
+}
+
+// Mean returns the average recorded duration, or 0 before any recording
+func (s TimerStats) Mean() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Sum / time.Duration(s.Count)
+}
+
 // NewTimer creates a new timer
 func NewTimer(name string, labels map[string]string) *Timer {
 	return &Timer{
@@ -272,9 +341,35 @@ func NewTimer(name string, labels map[string]string) *Timer {
 
 // Record records a duration
 func (t *Timer) Record(duration time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.stats.Count == 0 || duration < t.stats.Min {
+		t.stats.Min = duration
+	}
+	if duration > t.stats.Max {
+		t.stats.Max = duration
+	}
+	t.stats.Count++
+	t.stats.Sum += duration
+	t.stats.Last = duration
 	t.logger.Debug("Timer recorded", logging.String("name", t.name), logging.Duration("duration", duration))
 }
 
+// GetStats returns a snapshot of the recorded durations
+func (t *Timer) GetStats() TimerStats {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.stats
+}
+
+// Reset discards all recorded durations
+func (t *Timer) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stats = TimerStats{}
+}
+
 // Start starts a timer and returns a TimerContext
 func (t *Timer) Start() *TimerContext {
 	return &TimerContext{
@@ -303,13 +398,21 @@ func (tc *TimerContext) Stop() {
 	tc.timer.Record(duration)
 }
 
-// ToMetric converts timer to metric
+// ToMetric converts timer to metric; the value is the mean duration in seconds
 func (t *Timer) ToMetric() *Metric {
+	stats := t.GetStats()
+
+	labels := make(map[string]string, len(t.labels)+1)
+	for k, v := range t.labels {
+		labels[k] = v
+	}
+	labels["statistic"] = "mean"
+
 	return &Metric{
 		Name:        t.name,
 		Type:        MetricTimer,
-		Value:       0,
-		Labels:      t.labels,
+		Value:       stats.Mean().Seconds(),
+		Labels:      labels,
 		Timestamp:   time.Now(),
 		Description: "Timer metric",
 	}
@@ -327,6 +430,11 @@ type MetricCollector interface {
 	ToMetric() *Metric
 }
 
+// Resettable is implemented by metrics that can be reset to their initial state
+type Resettable interface {
+	Reset()
+}
+
 // NewRegistry creates a new registry
 func NewRegistry() *Registry {
 	return &Registry{
@@ -372,6 +480,19 @@ func (r *Registry) CollectAll() []*Metric {
 	return metrics
 }
 
+// ResetAll resets every registered metric that implements Resettable
+func (r *Registry) ResetAll() {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, collector := range r.metrics {
+		if resettable, ok := collector.(Resettable); ok {
+			resettable.Reset()
+		}
+	}
+	r.logger.Debug("Metrics reset", logging.Int("count", len(r.metrics)))
+}
+
 // GetCount returns the number of registered metrics
 func (r *Registry) GetCount() int {
 	r.mu.RLock()
@@ -400,9 +521,11 @@ var (
 	SessionActive     = NewGauge("session_active_total", nil)
 
 	// Cache metrics
-	CacheHits   = NewCounter("cache_hits_total", nil)
-	CacheMisses = NewCounter("cache_misses_total", nil)
-	CacheSize   = NewGauge("cache_size_bytes", nil)
+	CacheHits           = NewCounter("cache_hits_total", nil)
+	CacheMemoryHits     = NewCounter("cache_memory_hits_total", nil) // Subset of CacheHits served without reading disk
+	CacheMisses         = NewCounter("cache_misses_total", nil)
+	CacheExpiredRemoved = NewCounter("cache_expired_removed_total", nil) // Expired files removed by background cleanup
+	CacheSize           = NewGauge("cache_size_bytes", nil)
 
 	// Error metrics
 	ErrorsTotal = NewCounter("errors_total", map[string]string{"type": "unknown"})
@@ -411,11 +534,63 @@ var (
 	CommandExecutionTime = NewHistogram("command_execution_time_seconds", nil, nil)
 	InstanceSearchTime   = NewHistogram("instance_search_time_seconds", nil, nil)
 
-	// Health metrics
+	// Health metrics. HealthCheckDuration and HealthCheckStatus are not
+	// registered; per-check metrics come from HealthCheckDurationFor and
+	// HealthCheckStatusFor.
 	HealthCheckDuration = NewHistogram("health_check_duration_seconds", map[string]string{"check": "unknown"}, nil)
 	HealthCheckStatus   = NewGauge("health_check_status", map[string]string{"check": "unknown"})
+	HealthOverallStatus = NewGauge("health_overall_status", nil) // 1 ok, 0.5 warning, 0 error
 )
 
+// healthCheckMetrics holds the per-check health metrics, created on first use
+var healthCheckMetrics = struct {
+	sync.Mutex
+	durations map[string]*Histogram
+	statuses  map[string]*Gauge
+}{
+	durations: make(map[string]*Histogram),
+	statuses:  make(map[string]*Gauge),
+}
+
+// HealthCheckDurationFor returns the health_check_duration_seconds histogram
+// labelled with check, registering it in the global registry on first use
+func HealthCheckDurationFor(check string) *Histogram {
+	healthCheckMetrics.Lock()
+	defer healthCheckMetrics.Unlock()
+	h, ok := healthCheckMetrics.durations[check]
+	if !ok {
+		h = NewHistogram("health_check_duration_seconds", Labels("check", check), nil)
+		healthCheckMetrics.durations[check] = h
+		globalRegistry.Register("health_check_duration_seconds:"+check, h)
+	}
+	return h
+}
+
+// HealthCheckStatusFor returns the health_check_status gauge labelled with
+// check, registering it in the global registry on first use
+func HealthCheckStatusFor(check string) *Gauge {
+	healthCheckMetrics.Lock()
+	defer healthCheckMetrics.Unlock()
+	g, ok := healthCheckMetrics.statuses[check]
+	if !ok {
+		g = NewGauge("health_check_status", Labels("check", check))
+		healthCheckMetrics.statuses[check] = g
+		globalRegistry.Register("health_check_status:"+check, g)
+	}
+	return g
+}
+
+// CacheHitRatio returns the fraction of cache lookups that were hits, or 0
+// before any lookup has been recorded
+func CacheHitRatio() float64 {
+	hits := CacheHits.GetValue()
+	total := hits + CacheMisses.GetValue()
+	if total == 0 {
+		return 0
+	}
+	return hits / total
+}
+
 // Service provides metrics collection and reporting
 type Service struct {
 	registry  *Registry
@@ -442,25 +617,30 @@ func NewService(ctx context.Context) *Service {
 		stopCh:    make(chan struct{}),
 	}
 
-	// Register common metrics
-	service.registry.Register("aws_ssm_requests_total", AWSSSMRequestsTotal)
-	service.registry.Register("aws_ssm_request_duration_seconds", AWSSSMRequestDuration)
-	service.registry.Register("aws_ssm_request_errors_total", AWSSSMRequestErrors)
-	service.registry.Register("session_start_total", SessionStartTotal)
-	service.registry.Register("session_duration_seconds", SessionDuration)
-	service.registry.Register("session_active_total", SessionActive)
-	service.registry.Register("cache_hits_total", CacheHits)
-	service.registry.Register("cache_misses_total", CacheMisses)
-	service.registry.Register("cache_size_bytes", CacheSize)
-	service.registry.Register("errors_total", ErrorsTotal)
-	service.registry.Register("command_execution_time_seconds", CommandExecutionTime)
-	service.registry.Register("instance_search_time_seconds", InstanceSearchTime)
-	service.registry.Register("health_check_duration_seconds", HealthCheckDuration)
-	service.registry.Register("health_check_status", HealthCheckStatus)
+	registerCommonMetrics(service.registry)
 
 	return service
 }
 
+// registerCommonMetrics registers the package-level metrics in r
+func registerCommonMetrics(r *Registry) {
+	r.Register("aws_ssm_requests_total", AWSSSMRequestsTotal)
+	r.Register("aws_ssm_request_duration_seconds", AWSSSMRequestDuration)
+	r.Register("aws_ssm_request_errors_total", AWSSSMRequestErrors)
+	r.Register("session_start_total", SessionStartTotal)
+	r.Register("session_duration_seconds", SessionDuration)
+	r.Register("session_active_total", SessionActive)
+	r.Register("cache_hits_total", CacheHits)
+	r.Register("cache_memory_hits_total", CacheMemoryHits)
+	r.Register("cache_misses_total", CacheMisses)
+	r.Register("cache_expired_removed_total", CacheExpiredRemoved)
+	r.Register("cache_size_bytes", CacheSize)
+	r.Register("errors_total", ErrorsTotal)
+	r.Register("command_execution_time_seconds", CommandExecutionTime)
+	r.Register("instance_search_time_seconds", InstanceSearchTime)
+	r.Register("health_overall_status", HealthOverallStatus)
+}
+
 // AddReporter adds a metrics reporter
 func (s *Service) AddReporter(reporter Reporter) {
 	s.mu.Lock()
@@ -478,7 +658,7 @@ func (s *Service) Start() {
 // Stop stops the metrics service
 func (s *Service) Stop() {
 	close(s.stopCh)
-	s.logger.Info("Metrics service stopped")
+	s.logger.Debug("Metrics service stopped")
 }
 
 func (s *Service) reportLoop() {
@@ -550,10 +730,11 @@ func (r *ConsoleReporter) Name() string {
 	return "console"
 }
 
-// PrometheusReporter would implement Prometheus format reporting
-// This is a placeholder for a real implementation
+// PrometheusReporter renders metrics in the Prometheus text exposition format
 type PrometheusReporter struct {
 	logger logging.Logger
+	mu     sync.RWMutex
+	text   string
 }
 
 // NewPrometheusReporter creates a new Prometheus reporter for metrics
@@ -563,14 +744,28 @@ func NewPrometheusReporter() *PrometheusReporter {
 	}
 }
 
-// Report formats and outputs metrics in Prometheus format
+// Report renders metrics in Prometheus format; Text returns the latest rendering
 func (r *PrometheusReporter) Report(_ context.Context, metrics []*Metric) error {
-	// In a real implementation, you would format metrics in Prometheus format
-	// and expose them via HTTP endpoint or write to a file
+	var buf strings.Builder
+	if err := WritePrometheus(&buf, metrics); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.text = buf.String()
+	r.mu.Unlock()
+
 	r.logger.Debug("Prometheus metrics reported", logging.Int("metric_count", len(metrics)))
 	return nil
 }
 
+// Text returns the exposition rendered by the latest Report
+func (r *PrometheusReporter) Text() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.text
+}
+
 // Name returns the name of the Prometheus reporter
 func (r *PrometheusReporter) Name() string {
 	return "prometheus"
@@ -590,20 +785,7 @@ func ExportToJSON(_ []*Metric) (string, error) {
 
 // ResetAllMetrics resets all registered metrics
 func ResetAllMetrics() {
-	metrics := globalRegistry.CollectAll()
-	for _, metric := range metrics {
-		// Reset counters and gauges
-		switch metric.Type {
-		case MetricCounter:
-			// Would reset counter to 0
-		case MetricGauge:
-			// Would reset gauge to 0
-		case MetricHistogram:
-			// Would reset histogram
-		case MetricTimer:
-			// Would reset timer
-		}
-	}
+	globalRegistry.ResetAll()
 }
 
 // Labels creates a map of key-value pairs for metric labels
@@ -634,7 +816,8 @@ func NewPerformanceMonitor() *PerformanceMonitor {
 	}
 }
 
-// StartTimer starts a timer with the given name and returns a timer context
+// StartTimer starts a timer with the given name and returns a timer context.
+// New timers are registered in the global registry so their stats are reported.
 func (pm *PerformanceMonitor) StartTimer(name string) *TimerContext {
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
@@ -643,6 +826,7 @@ func (pm *PerformanceMonitor) StartTimer(name string) *TimerContext {
 	if !exists {
 		timer = NewTimer(name, nil)
 		pm.timers[name] = timer
+		globalRegistry.Register(name, timer)
 	}
 
 	return timer.Start()
@@ -671,6 +855,9 @@ func GetGlobalMetricsService() *Service {
 	return GlobalMetricsService
 }
 
+// emfSink is where the global service writes EMF documents, closed on shutdown
+var emfSink io.Closer
+
 // InitializeGlobalMetricsService initializes the global metrics service
 // This should be called explicitly from main() after CLI parsing, not in init()
 func InitializeGlobalMetricsService(ctx context.Context) {
@@ -680,9 +867,34 @@ func InitializeGlobalMetricsService(ctx context.Context) {
 	}
 
 	service := NewService(ctx)
-	GlobalRegistry() // Initialize global registry
+	registerCommonMetrics(GlobalRegistry()) // Expose the common metrics via ServeHTTP
 	GlobalMetricsService = service
 	// Don't add ConsoleReporter to avoid polluting terminal output during interactive sessions
 	// Metrics are still collected and available via GlobalRegistry() for programmatic access
+	if namespace := os.Getenv("AWS_SSM_EMF_NAMESPACE"); namespace != "" {
+		// EMF goes to its own sink so it never mixes with command output on stdout
+		sink, err := OpenEMFSink(os.Getenv("AWS_SSM_EMF_ENDPOINT"))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: EMF metrics disabled: %v\n", err)
+		} else {
+			emfSink = sink
+			service.AddReporter(NewCloudWatchEMFReporter(namespace, sink))
+		}
+	}
 	service.Start()
 }
+
+// ShutdownGlobalMetricsService stops the global metrics service after a final
+// report, so metrics recorded since the last interval are not lost on exit
+func ShutdownGlobalMetricsService() {
+	if GlobalMetricsService == nil {
+		return
+	}
+	GlobalMetricsService.Stop()
+	GlobalMetricsService.report()
+	GlobalMetricsService = nil
+	if emfSink != nil {
+		_ = emfSink.Close()
+		emfSink = nil
+	}
+}
@@ -32,6 +32,19 @@ func TestGauge(t *testing.T) {
 	}
 }
 
+func TestCacheHitRatio(t *testing.T) {
+	CacheHits.Set(0)
+	CacheMisses.Set(0)
+	if r := CacheHitRatio(); r != 0 {
+		t.Fatalf("expected 0 with no lookups got %v", r)
+	}
+	CacheHits.Inc(9)
+	CacheMisses.Inc(1)
+	if r := CacheHitRatio(); r != 0.9 {
+		t.Fatalf("expected 0.9 got %v", r)
+	}
+}
+
 func TestHistogramBuckets(t *testing.T) {
 	h := NewHistogram("test_hist", nil, nil)
 	h.Observe(0.01)
@@ -60,6 +73,49 @@ func TestTimer(t *testing.T) {
 	if tm.ToMetric().Name != "test_timer" {
 		t.Fatalf("timer metric incorrect")
 	}
+	if stats := tm.GetStats(); stats.Count != 2 || stats.Min < time.Millisecond || stats.Max < 5*time.Millisecond {
+		t.Fatalf("timer stats = %+v, want two recordings of at least 1ms and 5ms", stats)
+	}
+}
+
+func TestTimerStats(t *testing.T) {
+	tm := NewTimer("op_seconds", map[string]string{"op": "connect"})
+	if m := tm.ToMetric(); m.Value != 0 {
+		t.Fatalf("empty timer value = %v, want 0", m.Value)
+	}
+
+	for _, d := range []time.Duration{3 * time.Second, time.Second, 2 * time.Second, 6 * time.Second} {
+		tm.Record(d)
+	}
+
+	stats := tm.GetStats()
+	tests := []struct {
+		name string
+		got  time.Duration
+		want time.Duration
+	}{
+		{"sum", stats.Sum, 12 * time.Second},
+		{"last", stats.Last, 6 * time.Second},
+		{"min", stats.Min, time.Second},
+		{"max", stats.Max, 6 * time.Second},
+		{"mean", stats.Mean(), 3 * time.Second},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+	if stats.Count != 4 {
+		t.Errorf("count = %d, want 4", stats.Count)
+	}
+
+	m := tm.ToMetric()
+	if m.Type != MetricTimer || m.Value != 3 {
+		t.Errorf("metric = %s %v, want timer with mean 3s", m.Type, m.Value)
+	}
+	if m.Labels["op"] != "connect" || m.Labels["statistic"] != "mean" {
+		t.Errorf("metric labels = %v, want op and statistic labels", m.Labels)
+	}
 }
 
 func TestRegistry(t *testing.T) {
@@ -82,10 +138,109 @@ func TestRegistry(t *testing.T) {
 	}
 }
 
-func TestPerformanceMonitor(_ *testing.T) {
+func TestPerformanceMonitor(t *testing.T) {
 	pm := NewPerformanceMonitor()
 	tc := pm.StartOperation("op")
 	time.Sleep(1 * time.Millisecond)
 	tc.Stop()
 	pm.RecordOperation("op", 2*time.Millisecond)
+
+	collector, ok := GlobalRegistry().GetMetric("operation_op")
+	if !ok {
+		t.Fatalf("operation timer not registered")
+	}
+	if m := collector.ToMetric(); m.Value < 0.001 {
+		t.Errorf("operation timer value = %v, want at least 1ms", m.Value)
+	}
+}
+
+func TestMetricReset(t *testing.T) {
+	c := NewCounter("reset_counter", nil)
+	c.Add(3)
+	g := NewGauge("reset_gauge", nil)
+	g.Set(7)
+	h := NewHistogram("reset_hist", nil, nil)
+	h.Observe(0.2)
+	h.Observe(4)
+
+	tests := []struct {
+		name   string
+		metric Resettable
+		check  func() bool
+	}{
+		{"counter", c, func() bool { return c.GetValue() == 0 }},
+		{"gauge", g, func() bool { return g.GetValue() == 0 }},
+		{"histogram", h, func() bool { return h.GetCount() == 0 && h.GetSum() == 0 && len(h.GetBuckets()) == 0 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.metric.Reset()
+			if !tt.check() {
+				t.Errorf("%s not zeroed after Reset", tt.name)
+			}
+		})
+	}
+
+	// Reset metrics keep working
+	h.Observe(1)
+	if h.GetCount() != 1 {
+		t.Errorf("histogram count after reset and observe = %d, want 1", h.GetCount())
+	}
+}
+
+func TestRegistryResetAll(t *testing.T) {
+	r := NewRegistry()
+	c := NewCounter("c", nil)
+	c.Inc(5)
+	h := NewHistogram("h", nil, nil)
+	h.Observe(0.5)
+	r.Register("c", c)
+	r.Register("h", h)
+	tm := NewTimer("t", nil)
+	tm.Record(time.Second)
+	r.Register("t", tm)
+
+	r.ResetAll()
+	for _, m := range r.CollectAll() {
+		if m.Value != 0 {
+			t.Errorf("%s value = %v after ResetAll, want 0", m.Name, m.Value)
+		}
+		if m.Histogram != nil && m.Histogram.Count != 0 {
+			t.Errorf("%s count = %d after ResetAll, want 0", m.Name, m.Histogram.Count)
+		}
+	}
+}
+
+func TestResetAllMetrics(t *testing.T) {
+	registerCommonMetrics(GlobalRegistry())
+	CacheHits.Inc(2)
+	SessionDuration.Observe(3)
+
+	ResetAllMetrics()
+	if CacheHits.GetValue() != 0 || SessionDuration.GetCount() != 0 {
+		t.Errorf("global metrics not reset: cache hits %v, session duration count %d", CacheHits.GetValue(), SessionDuration.GetCount())
+	}
+}
+
+func TestHealthCheckMetricsPerCheck(t *testing.T) {
+	status := HealthCheckStatusFor("test_check")
+	if HealthCheckStatusFor("test_check") != status {
+		t.Fatalf("HealthCheckStatusFor() returned a new gauge for the same check")
+	}
+	if HealthCheckStatusFor("other_check") == status {
+		t.Fatalf("HealthCheckStatusFor() shared a gauge between checks")
+	}
+	status.Set(1)
+	HealthCheckDurationFor("test_check").Observe(0.25)
+
+	collector, ok := GlobalRegistry().GetMetric("health_check_status:test_check")
+	if !ok {
+		t.Fatalf("per-check status gauge not registered")
+	}
+	if m := collector.ToMetric(); m.Name != "health_check_status" || m.Labels["check"] != "test_check" || m.Value != 1 {
+		t.Errorf("status metric = %+v, want health_check_status{check=test_check} 1", m)
+	}
+	if _, ok := GlobalRegistry().GetMetric("health_check_duration_seconds:test_check"); !ok {
+		t.Errorf("per-check duration histogram not registered")
+	}
 }
@@ -0,0 +1,156 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/logging"
+)
+
+// prometheusContentType is the content type of the text exposition format
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+var invalidMetricNameChars = regexp.MustCompile(`[^a-zA-Z0-9_:]`)
+
+// WritePrometheus renders metrics in the Prometheus text exposition format.
+// Metrics sharing a name are written under one TYPE and HELP line; timers
+// have no Prometheus type and are skipped.
+func WritePrometheus(w io.Writer, metrics []*Metric) error {
+	sorted := make([]*Metric, 0, len(metrics))
+	for _, m := range metrics {
+		if m != nil && m.Type != MetricTimer {
+			sorted = append(sorted, m)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+		return formatLabels(sorted[i].Labels, "", "") < formatLabels(sorted[j].Labels, "", "")
+	})
+
+	previous := ""
+	for _, m := range sorted {
+		name := prometheusName(m.Name)
+		if name != previous {
+			if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, escapeHelp(m.Description), name, m.Type); err != nil {
+				return fmt.Errorf("failed to write metric header: %w", err)
+			}
+			previous = name
+		}
+		if err := writeSamples(w, name, m); err != nil {
+			return fmt.Errorf("failed to write metric %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// writeSamples writes the sample lines of one metric
+func writeSamples(w io.Writer, name string, m *Metric) error {
+	if m.Type != MetricHistogram || m.Histogram == nil {
+		_, err := fmt.Fprintf(w, "%s%s %s\n", name, formatLabels(m.Labels, "", ""), formatValue(m.Value))
+		return err
+	}
+
+	h := m.Histogram
+	for _, b := range h.Buckets {
+		if math.IsInf(b.UpperBound, 1) {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%s_bucket%s %d\n", name, formatLabels(m.Labels, "le", formatValue(b.UpperBound)), b.Count); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%s_bucket%s %d\n%s_sum%s %s\n%s_count%s %d\n",
+		name, formatLabels(m.Labels, "le", "+Inf"), h.Count,
+		name, formatLabels(m.Labels, "", ""), formatValue(h.Sum),
+		name, formatLabels(m.Labels, "", ""), h.Count)
+	return err
+}
+
+// prometheusName replaces characters Prometheus does not allow in metric names
+func prometheusName(name string) string {
+	name = invalidMetricNameChars.ReplaceAllString(name, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+// formatLabels renders labels sorted by name, plus an extra label when extraName is set
+func formatLabels(labels map[string]string, extraName, extraValue string) string {
+	if len(labels) == 0 && extraName == "" {
+		return ""
+	}
+	pairs := make([]string, 0, len(labels)+1)
+	for k, v := range labels {
+		pairs = append(pairs, prometheusName(k)+`="`+labelValueEscaper.Replace(v)+`"`)
+	}
+	sort.Strings(pairs)
+	if extraName != "" {
+		pairs = append(pairs, extraName+`="`+extraValue+`"`)
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// labelValueEscaper escapes label values as the text format requires
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeHelp escapes backslashes and line feeds in HELP text
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+// formatValue renders a sample value
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// PrometheusHandler is an HTTP handler that serves the registry's metrics in
+// the Prometheus text format
+func (r *Registry) PrometheusHandler(w http.ResponseWriter, _ *http.Request) {
+	var buf strings.Builder
+	if err := WritePrometheus(&buf, r.CollectAll()); err != nil {
+		r.logger.Error("Failed to render metrics", logging.String("error", err.Error()))
+		http.Error(w, "failed to render metrics", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", prometheusContentType)
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.WriteString(w, buf.String()); err != nil {
+		r.logger.Error("Failed to write metrics response", logging.String("error", err.Error()))
+	}
+}
+
+// newMetricsMux routes /metrics to the registry
+func newMetricsMux(r *Registry) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", r.PrometheusHandler)
+	return mux
+}
+
+// ServeHTTP serves the global registry on /metrics at addr until the server fails
+func ServeHTTP(addr string) error {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           newMetricsMux(GlobalRegistry()),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return server.ListenAndServe()
+}
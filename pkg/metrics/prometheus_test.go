@@ -0,0 +1,155 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var (
+	prometheusCommentLine = regexp.MustCompile(`^# (HELP|TYPE) ([a-zA-Z_:][a-zA-Z0-9_:]*) (.*)$`)
+	prometheusSampleLine  = regexp.MustCompile(`^([a-zA-Z_:][a-zA-Z0-9_:]*)(\{([a-zA-Z_][a-zA-Z0-9_]*="([^"\\]|\\.)*"(,[a-zA-Z_][a-zA-Z0-9_]*="([^"\\]|\\.)*")*)?\})? (\S+)$`)
+)
+
+// parsePrometheus validates the exposition format and returns the samples by series
+func parsePrometheus(t *testing.T, text string) map[string]float64 {
+	t.Helper()
+	samples := make(map[string]float64)
+	types := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		if m := prometheusCommentLine.FindStringSubmatch(line); m != nil {
+			if m[1] == "TYPE" {
+				if _, dup := types[m[2]]; dup {
+					t.Errorf("duplicate TYPE line for %s", m[2])
+				}
+				types[m[2]] = m[3]
+			}
+			continue
+		}
+		m := prometheusSampleLine.FindStringSubmatch(line)
+		if m == nil {
+			t.Fatalf("invalid exposition line %q", line)
+		}
+		base := strings.TrimSuffix(strings.TrimSuffix(strings.TrimSuffix(m[1], "_bucket"), "_sum"), "_count")
+		if _, ok := types[m[1]]; !ok && types[base] != "histogram" {
+			t.Errorf("sample %q has no TYPE line", line)
+		}
+		v, err := strconv.ParseFloat(m[len(m)-1], 64)
+		if err != nil {
+			t.Fatalf("invalid sample value in %q: %v", line, err)
+		}
+		samples[m[1]+m[2]] = v
+	}
+	return samples
+}
+
+func newPrometheusTestRegistry() *Registry {
+	r := NewRegistry()
+	c := NewCounter("ssm_commands_total", map[string]string{"region": "us-east-1", "note": "a \"quoted\"\nvalue"})
+	c.Add(3)
+	r.Register("commands", c)
+	g := NewGauge("ssm.active-sessions", nil)
+	g.Set(2)
+	r.Register("sessions", g)
+	h := NewHistogram("ssm_latency_seconds", nil, nil)
+	for _, v := range []float64{0.01, 0.3, 0.3, 4} {
+		h.Observe(v)
+	}
+	r.Register("latency", h)
+	r.Register("timer", NewTimer("ssm_timer", nil))
+	return r
+}
+
+func TestPrometheusEndpoint(t *testing.T) {
+	server := httptest.NewServer(newMetricsMux(newPrometheusTestRegistry()))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("scrape failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != prometheusContentType {
+		t.Errorf("Content-Type = %q, want %q", ct, prometheusContentType)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+
+	text := string(body)
+	samples := parsePrometheus(t, text)
+	tests := []struct {
+		series string
+		want   float64
+	}{
+		{`ssm_commands_total{note="a \"quoted\"\nvalue",region="us-east-1"}`, 3},
+		{`ssm_active_sessions`, 2},
+		{`ssm_latency_seconds_bucket{le="0.01"}`, 1},
+		{`ssm_latency_seconds_bucket{le="0.25"}`, 1},
+		{`ssm_latency_seconds_bucket{le="0.5"}`, 3},
+		{`ssm_latency_seconds_bucket{le="5"}`, 4},
+		{`ssm_latency_seconds_bucket{le="+Inf"}`, 4},
+		{`ssm_latency_seconds_sum`, 4.61},
+		{`ssm_latency_seconds_count`, 4},
+	}
+	for _, tt := range tests {
+		if got, ok := samples[tt.series]; !ok || got != tt.want {
+			t.Errorf("%s = %v (present %v), want %v", tt.series, got, ok, tt.want)
+		}
+	}
+	for _, want := range []string{"# TYPE ssm_commands_total counter", "# TYPE ssm_active_sessions gauge", "# TYPE ssm_latency_seconds histogram"} {
+		if !strings.Contains(text, want+"\n") {
+			t.Errorf("missing %q", want)
+		}
+	}
+	if strings.Contains(text, "ssm_timer") {
+		t.Error("timers should not be exported")
+	}
+
+	// Buckets must be cumulative
+	previous := -1.0
+	for _, line := range strings.Split(text, "\n") {
+		if !strings.HasPrefix(line, "ssm_latency_seconds_bucket") {
+			continue
+		}
+		v, _ := strconv.ParseFloat(line[strings.LastIndex(line, " ")+1:], 64)
+		if v < previous {
+			t.Errorf("bucket counts decrease at %q", line)
+		}
+		previous = v
+	}
+}
+
+func TestPrometheusEndpointUnknownPath(t *testing.T) {
+	server := httptest.NewServer(newMetricsMux(NewRegistry()))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/other")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", resp.StatusCode)
+	}
+}
+
+func TestPrometheusReporter(t *testing.T) {
+	reporter := NewPrometheusReporter()
+	if err := reporter.Report(context.Background(), newPrometheusTestRegistry().CollectAll()); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	samples := parsePrometheus(t, reporter.Text())
+	if samples["ssm_active_sessions"] != 2 {
+		t.Errorf("ssm_active_sessions = %v, want 2", samples["ssm_active_sessions"])
+	}
+}
@@ -0,0 +1,39 @@
+package metrics
+
+import "math"
+
+// Quantile estimates the q-quantile (0 <= q <= 1) of the observations by linear
+// interpolation within the bucket holding the quantile's rank, like
+// Prometheus' histogram_quantile. The last bucket is a catch-all, so ranks
+// falling in it return its lower bound. Empty histograms yield NaN; q below 0
+// or above 1 yields -Inf or +Inf.
+func (d *HistogramData) Quantile(q float64) float64 {
+	switch {
+	case math.IsNaN(q) || d == nil || d.Count == 0 || len(d.Buckets) == 0:
+		return math.NaN()
+	case q < 0:
+		return math.Inf(-1)
+	case q > 1:
+		return math.Inf(1)
+	}
+
+	rank := q * float64(d.Count)
+	lower, below := 0.0, uint64(0)
+	last := len(d.Buckets) - 1
+	for i, b := range d.Buckets {
+		if b.Count > below && float64(b.Count) >= rank {
+			if i == last {
+				return lower
+			}
+			return lower + (b.UpperBound-lower)*(rank-float64(below))/float64(b.Count-below)
+		}
+		lower, below = b.UpperBound, b.Count
+	}
+	// Observations above the last bound are only counted in Count
+	return d.Buckets[last].UpperBound
+}
+
+// Quantile estimates the q-quantile of the observations; see HistogramData.Quantile
+func (h *Histogram) Quantile(q float64) float64 {
+	return h.ToMetric().Histogram.Quantile(q)
+}
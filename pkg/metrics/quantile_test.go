@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHistogramQuantile(t *testing.T) {
+	// 100 observations spread evenly over (0, 1]
+	uniform := NewHistogram("uniform", nil, nil)
+	for i := 1; i <= 100; i++ {
+		uniform.Observe(float64(i) / 100)
+	}
+	// Most observations fall in the catch-all bucket above 10
+	slow := NewHistogram("slow", nil, nil)
+	slow.Observe(0.2)
+	for i := 0; i < 9; i++ {
+		slow.Observe(30)
+	}
+
+	tests := []struct {
+		name string
+		h    *Histogram
+		q    float64
+		want float64
+	}{
+		{"p50", uniform, 0.5, 0.5},
+		{"p90", uniform, 0.9, 0.9},
+		{"p99", uniform, 0.99, 0.99},
+		{"p100", uniform, 1, 1},
+		{"p0 is the lower bound of the first non-empty bucket", uniform, 0, 0.005},
+		{"catch-all bucket returns its lower bound", slow, 0.99, 10},
+		{"below the catch-all bucket", slow, 0.05, 0.1 + 0.15*0.5},
+		{"empty histogram", NewHistogram("empty", nil, nil), 0.5, math.NaN()},
+		{"q below 0", uniform, -0.1, math.Inf(-1)},
+		{"q above 1", uniform, 1.1, math.Inf(1)},
+		{"q is NaN", uniform, math.NaN(), math.NaN()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.h.Quantile(tt.q)
+			if math.IsNaN(tt.want) {
+				if !math.IsNaN(got) {
+					t.Errorf("Quantile(%v) = %v, want NaN", tt.q, got)
+				}
+				return
+			}
+			if got != tt.want && math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Quantile(%v) = %v, want %v", tt.q, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHistogramDataQuantileNil(t *testing.T) {
+	var d *HistogramData
+	if got := d.Quantile(0.5); !math.IsNaN(got) {
+		t.Errorf("Quantile() on nil data = %v, want NaN", got)
+	}
+}
@@ -0,0 +1,140 @@
+// Package plugins discovers and runs external aws-ssm-<name> binaries as
+// subcommands, in the style of kubectl and git plugins.
+package plugins
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Prefix is the executable name prefix that marks an aws-ssm plugin
+const Prefix = "aws-ssm-"
+
+// Plugin is an external executable exposed as an aws-ssm subcommand
+type Plugin struct {
+	Name string // subcommand name, the executable name without Prefix
+	Path string // absolute path to the executable
+}
+
+// Context is the resolved CLI context handed to a plugin through its environment
+type Context struct {
+	Region     string
+	Profile    string
+	ConfigPath string
+}
+
+// Discover scans the directories in pathEnv (formatted like $PATH) for plugin
+// executables. When the same name appears more than once the first directory
+// wins, matching shell lookup order. Plugins are returned sorted by name.
+func Discover(pathEnv string) []Plugin {
+	found := make(map[string]Plugin)
+	for _, dir := range filepath.SplitList(pathEnv) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := pluginName(entry.Name())
+			if !ok {
+				continue
+			}
+			if _, exists := found[name]; exists {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			found[name] = Plugin{Name: name, Path: path}
+		}
+	}
+
+	plugins := make([]Plugin, 0, len(found))
+	for _, p := range found {
+		plugins = append(plugins, p)
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins
+}
+
+// pluginName extracts the subcommand name from an executable file name
+func pluginName(fileName string) (string, bool) {
+	if !strings.HasPrefix(fileName, Prefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(fileName, Prefix)
+	if runtime.GOOS == "windows" {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	if name == "" || strings.HasPrefix(name, "-") {
+		return "", false
+	}
+	return name, true
+}
+
+// isExecutable reports whether path is a regular file the current user can run
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(filepath.Ext(path), ".exe")
+	}
+	return info.Mode().Perm()&0o111 != 0
+}
+
+// Env returns base with the resolved context applied. Empty values leave the
+// inherited variables untouched. AWS_SSM_CONFIG is only set when a config
+// path was given explicitly.
+func Env(base []string, pctx Context) []string {
+	overrides := map[string]string{}
+	if pctx.Region != "" {
+		overrides["AWS_REGION"] = pctx.Region
+		overrides["AWS_DEFAULT_REGION"] = pctx.Region
+	}
+	if pctx.Profile != "" {
+		overrides["AWS_PROFILE"] = pctx.Profile
+	}
+	if pctx.ConfigPath != "" {
+		overrides["AWS_SSM_CONFIG"] = pctx.ConfigPath
+	}
+
+	env := make([]string, 0, len(base)+len(overrides))
+	for _, kv := range base {
+		key, _, _ := strings.Cut(kv, "=")
+		if _, replaced := overrides[key]; replaced {
+			continue
+		}
+		env = append(env, kv)
+	}
+	keys := make([]string, 0, len(overrides))
+	for key := range overrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		env = append(env, key+"="+overrides[key])
+	}
+	return env
+}
+
+// Run executes the plugin with args and env, wiring up the given streams
+func Run(ctx context.Context, p Plugin, args, env []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	// #nosec G204 - plugin paths come from PATH discovery and args are passed without a shell
+	cmd := exec.CommandContext(ctx, p.Path, args...)
+	cmd.Env = env
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	return cmd.Run()
+}
@@ -0,0 +1,137 @@
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func writeScript(t *testing.T, dir, name, body string, mode os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), mode); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+	return path
+}
+
+func TestDiscover(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin scripts require a POSIX shell")
+	}
+
+	first := t.TempDir()
+	second := t.TempDir()
+	hello := writeScript(t, first, "aws-ssm-hello", "echo first", 0o755)
+	writeScript(t, second, "aws-ssm-hello", "echo second", 0o755)
+	audit := writeScript(t, second, "aws-ssm-audit", "true", 0o755)
+	writeScript(t, first, "aws-ssm-notexec", "true", 0o644)
+	writeScript(t, first, "kubectl-hello", "true", 0o755)
+	writeScript(t, first, "aws-ssm-", "true", 0o755)
+	if err := os.Mkdir(filepath.Join(first, "aws-ssm-dir"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	pathEnv := strings.Join([]string{first, filepath.Join(first, "missing"), "", second}, string(os.PathListSeparator))
+	got := Discover(pathEnv)
+
+	want := []Plugin{
+		{Name: "audit", Path: audit},
+		{Name: "hello", Path: hello},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Discover() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Discover()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDiscoverEmptyPath(t *testing.T) {
+	if got := Discover(""); len(got) != 0 {
+		t.Errorf("Discover(\"\") = %+v, want none", got)
+	}
+}
+
+func TestEnv(t *testing.T) {
+	base := []string{"HOME=/home/me", "AWS_REGION=us-east-1", "AWS_PROFILE=old"}
+
+	tests := []struct {
+		name    string
+		ctx     Context
+		want    []string
+		missing []string
+	}{
+		{
+			name: "overrides region and profile",
+			ctx:  Context{Region: "eu-west-1", Profile: "prod"},
+			want: []string{"HOME=/home/me", "AWS_DEFAULT_REGION=eu-west-1", "AWS_PROFILE=prod", "AWS_REGION=eu-west-1"},
+		},
+		{
+			name:    "empty context keeps inherited values",
+			ctx:     Context{},
+			want:    []string{"HOME=/home/me", "AWS_REGION=us-east-1", "AWS_PROFILE=old"},
+			missing: []string{"AWS_SSM_CONFIG"},
+		},
+		{
+			name: "config path",
+			ctx:  Context{ConfigPath: "/tmp/config.yaml"},
+			want: []string{"AWS_SSM_CONFIG=/tmp/config.yaml"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := Env(base, tt.ctx)
+			for _, kv := range tt.want {
+				if !containsExact(env, kv) {
+					t.Errorf("Env() = %v, missing %q", env, kv)
+				}
+			}
+			for _, key := range tt.missing {
+				for _, kv := range env {
+					if strings.HasPrefix(kv, key+"=") {
+						t.Errorf("Env() unexpectedly set %q", kv)
+					}
+				}
+			}
+			if tt.ctx.Region != "" && containsExact(env, "AWS_REGION=us-east-1") {
+				t.Errorf("Env() kept the inherited AWS_REGION: %v", env)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin scripts require a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	path := writeScript(t, dir, "aws-ssm-echo", `echo "$AWS_REGION $*"`, 0o755)
+
+	var stdout bytes.Buffer
+	err := Run(context.Background(), Plugin{Name: "echo", Path: path}, []string{"a", "--flag"},
+		Env(nil, Context{Region: "ap-south-1"}), nil, &stdout, &stdout)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got := strings.TrimSpace(stdout.String()); got != "ap-south-1 a --flag" {
+		t.Errorf("Run() output = %q, want %q", got, "ap-south-1 a --flag")
+	}
+}
+
+func containsExact(values []string, want string) bool {
+	for _, v := range values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+package security
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AuditLogFileEnv names the environment variable that enables the audit file sink
+const AuditLogFileEnv = "AWS_SSM_AUDIT_LOG_FILE"
+
+// AuditEvent is one line of the audit log file
+type AuditEvent struct {
+	Time  time.Time              `json:"time"`
+	Event string                 `json:"event"`
+	Data  map[string]interface{} `json:"data,omitempty"`
+}
+
+// AuditFilter selects audit events; zero fields match everything
+type AuditFilter struct {
+	Since time.Time
+	Until time.Time
+	Types []string
+}
+
+// Matches reports whether event passes the filter. Since is inclusive, Until exclusive.
+func (f AuditFilter) Matches(event AuditEvent) bool {
+	if !f.Since.IsZero() && event.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !event.Time.Before(f.Until) {
+		return false
+	}
+	if len(f.Types) == 0 {
+		return true
+	}
+	for _, t := range f.Types {
+		if strings.EqualFold(t, event.Event) {
+			return true
+		}
+	}
+	return false
+}
+
+// appendAuditEvent writes event as a JSON line to the audit file at path
+func appendAuditEvent(path string, event AuditEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	// #nosec G304 - path comes from the user's own configuration
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return file.Close()
+}
+
+// ReadAuditEvents returns the events in the audit log at path that match
+// filter, oldest first. Rotated copies next to it (path.1, path.2, ...) are
+// read too, so a rotation does not hide recent history. A missing log yields
+// no events; lines that are not valid events, such as a line cut short by
+// rotation, are skipped and counted.
+func ReadAuditEvents(path string, filter AuditFilter) ([]AuditEvent, int, error) {
+	files, err := auditLogFiles(path)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		events  []AuditEvent
+		skipped int
+	)
+	for _, file := range files {
+		fileEvents, fileSkipped, err := readAuditFile(file, filter)
+		if err != nil {
+			return nil, 0, err
+		}
+		events = append(events, fileEvents...)
+		skipped += fileSkipped
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+	return events, skipped, nil
+}
+
+// auditLogFiles returns path and its numbered rotations that exist
+func auditLogFiles(path string) ([]string, error) {
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		return nil, fmt.Errorf("invalid audit log path %q: %w", path, err)
+	}
+
+	var files []string
+	for _, match := range matches {
+		if _, err := strconv.Atoi(strings.TrimPrefix(match, path+".")); err == nil {
+			files = append(files, match)
+		}
+	}
+	if _, err := os.Stat(path); err == nil {
+		files = append(files, path)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return files, nil
+}
+
+// readAuditFile reads the matching events of a single audit log file
+func readAuditFile(path string, filter AuditFilter) ([]AuditEvent, int, error) {
+	// #nosec G304 - path comes from the user's own configuration
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			// Rotated away between listing and reading
+			return nil, 0, nil
+		}
+		return nil, 0, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var (
+		events  []AuditEvent
+		skipped int
+	)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var event AuditEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil || event.Event == "" || event.Time.IsZero() {
+			skipped++
+			continue
+		}
+		if filter.Matches(event) {
+			events = append(events, event)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to read audit log %s: %w", path, err)
+	}
+	return events, skipped, nil
+}
@@ -0,0 +1,115 @@
+package security
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func seedAuditLog(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// The rotated file holds the oldest events and a line cut short by rotation
+	rotated := `{"time":"2026-01-01T00:00:00Z","event":"command_validated"}
+{"time":"2026-01-01T01:00:00Z","event":"command_rejected","data":{"command":"rm -rf /"}}
+{"time":"2026-01-01T02:00:00Z","ev
+`
+	if err := os.WriteFile(path+".1", []byte(rotated), 0o600); err != nil {
+		t.Fatalf("failed to seed rotated log: %v", err)
+	}
+	for i, event := range []string{"command_validated", "command_rejected"} {
+		if err := appendAuditEvent(path, AuditEvent{Time: base.Add(time.Duration(i+3) * time.Hour), Event: event}); err != nil {
+			t.Fatalf("appendAuditEvent() error = %v", err)
+		}
+	}
+	return path
+}
+
+func TestReadAuditEvents(t *testing.T) {
+	path := seedAuditLog(t)
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter AuditFilter
+		want   []string
+	}{
+		{
+			name: "no filter reads rotations oldest first",
+			want: []string{"00:00 command_validated", "01:00 command_rejected", "03:00 command_validated", "04:00 command_rejected"},
+		},
+		{
+			name:   "since is inclusive",
+			filter: AuditFilter{Since: base.Add(3 * time.Hour)},
+			want:   []string{"03:00 command_validated", "04:00 command_rejected"},
+		},
+		{
+			name:   "until is exclusive",
+			filter: AuditFilter{Since: base.Add(time.Hour), Until: base.Add(4 * time.Hour)},
+			want:   []string{"01:00 command_rejected", "03:00 command_validated"},
+		},
+		{
+			name:   "type filter",
+			filter: AuditFilter{Types: []string{"COMMAND_REJECTED"}},
+			want:   []string{"01:00 command_rejected", "04:00 command_rejected"},
+		},
+		{
+			name:   "type and time filter",
+			filter: AuditFilter{Since: base.Add(2 * time.Hour), Types: []string{"command_rejected"}},
+			want:   []string{"04:00 command_rejected"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, skipped, err := ReadAuditEvents(path, tt.filter)
+			if err != nil {
+				t.Fatalf("ReadAuditEvents() error = %v", err)
+			}
+			if skipped != 1 {
+				t.Errorf("skipped = %d, want 1", skipped)
+			}
+			var got []string
+			for _, e := range events {
+				got = append(got, e.Time.UTC().Format("15:04")+" "+e.Event)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("events = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("events = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestReadAuditEventsMissingLog(t *testing.T) {
+	events, skipped, err := ReadAuditEvents(filepath.Join(t.TempDir(), "audit.log"), AuditFilter{})
+	if err != nil {
+		t.Fatalf("ReadAuditEvents() error = %v", err)
+	}
+	if len(events) != 0 || skipped != 0 {
+		t.Errorf("got %d events, %d skipped; want none", len(events), skipped)
+	}
+}
+
+func TestReadAuditEventsOnlyRotatedLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	if err := appendAuditEvent(path+".2", AuditEvent{Time: time.Now(), Event: "command_validated"}); err != nil {
+		t.Fatalf("appendAuditEvent() error = %v", err)
+	}
+	events, _, err := ReadAuditEvents(path, AuditFilter{})
+	if err != nil {
+		t.Fatalf("ReadAuditEvents() error = %v", err)
+	}
+	if len(events) != 1 {
+		t.Errorf("got %d events, want 1", len(events))
+	}
+}
@@ -0,0 +1,78 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/johnlam90/aws-ssm/pkg/logging"
+)
+
+// DefaultCredentialExpiryWindow is how long before expiry credentials are flagged
+const DefaultCredentialExpiryWindow = 15 * time.Minute
+
+// credentialCheckTimeout bounds credential resolution during session validation
+const credentialCheckTimeout = 10 * time.Second
+
+// CredentialsProviderFunc returns the credentials provider of an AWS profile
+type CredentialsProviderFunc func(ctx context.Context, profile string) (aws.CredentialsProvider, error)
+
+// sharedConfigCredentials resolves a profile through the SDK's default chain
+func sharedConfigCredentials(ctx context.Context, profile string) (aws.CredentialsProvider, error) {
+	var opts []func(*config.LoadOptions) error
+	if profile != "" {
+		opts = append(opts, config.WithSharedConfigProfile(profile))
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	return cfg.Credentials, nil
+}
+
+// CheckExpiry resolves the credentials of profile and records when they expire.
+// Credentials expiring within the expiry window raise a warning event.
+func (cm *CredentialManager) CheckExpiry(ctx context.Context, profile string) (*CredentialInfo, error) {
+	provider, err := cm.providerFor(ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+	if provider == nil {
+		return nil, fmt.Errorf("no credentials configured for profile %q", profile)
+	}
+	creds, err := provider.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve credentials: %w", err)
+	}
+
+	now := cm.now()
+	cm.mu.Lock()
+	credential := cm.cache[profile]
+	if credential == nil {
+		credential = &CredentialInfo{Profile: profile, Hash: cm.hashProfile(profile), Permissions: []string{}}
+		cm.cache[profile] = credential
+	}
+	keyHash := cm.hashProfile(creds.AccessKeyID)
+	credential.Rotated = credential.keyHash != "" && credential.keyHash != keyHash
+	credential.keyHash = keyHash
+	credential.LastUsed = now
+	credential.ExpiresAt = time.Time{}
+	if creds.CanExpire {
+		credential.ExpiresAt = creds.Expires
+	}
+	credential.ExpiringSoon = creds.CanExpire && creds.Expires.Sub(now) < cm.expiryWindow
+	info := *credential
+	cm.mu.Unlock()
+
+	if info.ExpiringSoon {
+		cm.logger.Warn("Credentials expiring soon",
+			logging.String("profile", profile),
+			logging.String("expires_at", info.ExpiresAt.Format(time.RFC3339)))
+		cm.handleEvent(CreateSecurityEvent("credential_expiring", "warning", "credential_manager", info.Hash,
+			fmt.Sprintf("credentials expire in %s", info.ExpiresAt.Sub(now).Round(time.Second)),
+			map[string]interface{}{"expires_at": info.ExpiresAt}))
+	}
+	return &info, nil
+}
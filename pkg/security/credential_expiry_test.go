@@ -0,0 +1,119 @@
+package security
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// fakeCredentials serves fixed credentials for every profile
+type fakeCredentials struct {
+	creds aws.Credentials
+}
+
+func (f *fakeCredentials) Retrieve(context.Context) (aws.Credentials, error) {
+	return f.creds, nil
+}
+
+func newTestCredentialManager(now time.Time, provider *fakeCredentials) (*CredentialManager, *[]*Event) {
+	var events []*Event
+	cm := NewCredentialManager()
+	cm.now = func() time.Time { return now }
+	cm.providerFor = func(context.Context, string) (aws.CredentialsProvider, error) { return provider, nil }
+	cm.handleEvent = func(e *Event) { events = append(events, e) }
+	return cm, &events
+}
+
+func TestCheckExpiry(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		creds        aws.Credentials
+		wantExpires  time.Time
+		wantExpiring bool
+	}{
+		{
+			name:        "long-lived access key",
+			creds:       aws.Credentials{AccessKeyID: "AKIA1"},
+			wantExpires: time.Time{},
+		},
+		{
+			name:        "session well before expiry",
+			creds:       aws.Credentials{AccessKeyID: "ASIA1", CanExpire: true, Expires: now.Add(time.Hour)},
+			wantExpires: now.Add(time.Hour),
+		},
+		{
+			name:         "session about to expire",
+			creds:        aws.Credentials{AccessKeyID: "ASIA1", CanExpire: true, Expires: now.Add(5 * time.Minute)},
+			wantExpires:  now.Add(5 * time.Minute),
+			wantExpiring: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm, events := newTestCredentialManager(now, &fakeCredentials{creds: tt.creds})
+			info, err := cm.CheckExpiry(context.Background(), "dev")
+			if err != nil {
+				t.Fatalf("CheckExpiry() error = %v", err)
+			}
+			if !info.ExpiresAt.Equal(tt.wantExpires) || info.ExpiringSoon != tt.wantExpiring {
+				t.Errorf("ExpiresAt/ExpiringSoon = %v/%v, want %v/%v", info.ExpiresAt, info.ExpiringSoon, tt.wantExpires, tt.wantExpiring)
+			}
+			if gotEvent := len(*events) > 0; gotEvent != tt.wantExpiring {
+				t.Errorf("warning events = %d, want event %v", len(*events), tt.wantExpiring)
+			}
+			if tt.wantExpiring && (*events)[0].Severity != "warning" {
+				t.Errorf("event severity = %q, want warning", (*events)[0].Severity)
+			}
+		})
+	}
+}
+
+func TestCheckExpiryDetectsRotation(t *testing.T) {
+	provider := &fakeCredentials{creds: aws.Credentials{AccessKeyID: "AKIA1"}}
+	cm, _ := newTestCredentialManager(time.Now(), provider)
+
+	info, err := cm.CheckExpiry(context.Background(), "dev")
+	if err != nil || info.Rotated {
+		t.Fatalf("first check: Rotated = %v, err = %v; want false, nil", info.Rotated, err)
+	}
+	provider.creds.AccessKeyID = "AKIA2"
+	if info, _ = cm.CheckExpiry(context.Background(), "dev"); !info.Rotated {
+		t.Error("Rotated = false after the access key changed")
+	}
+	if cached, _ := cm.GetCredentialInfo("dev"); !cached.Rotated {
+		t.Error("GetCredentialInfo() does not report the rotation")
+	}
+}
+
+func TestValidateSessionCredentialExpiry(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider := &fakeCredentials{creds: aws.Credentials{AccessKeyID: "ASIA1", CanExpire: true, Expires: now.Add(5 * time.Minute)}}
+	m := NewManager(DefaultConfig())
+	cm, events := newTestCredentialManager(now, provider)
+	m.credentials = cm
+
+	// Near expiry only warns
+	if err := m.ValidateSession("s-1", "dev"); err != nil {
+		t.Fatalf("ValidateSession() error = %v", err)
+	}
+	if len(*events) != 1 {
+		t.Errorf("warning events = %d, want 1", len(*events))
+	}
+
+	provider.creds.Expires = now.Add(-time.Minute)
+	if err := m.ValidateSession("s-1", "dev"); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Errorf("ValidateSession() error = %v, want expired credentials", err)
+	}
+}
+
+func TestCredentialExpiryWindowFromConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.CredentialExpiryWindow = time.Hour
+	if got := NewManager(cfg).credentials.expiryWindow; got != time.Hour {
+		t.Errorf("expiryWindow = %v, want 1h", got)
+	}
+}
@@ -0,0 +1,91 @@
+package security
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PolicyFileEnv names the environment variable pointing at a security policy file
+const PolicyFileEnv = "AWS_SSM_SECURITY_POLICY"
+
+// policyFile is the on-disk security policy, in JSON or YAML
+type policyFile struct {
+	Level           string   `json:"level" yaml:"level"`
+	AllowedCommands []string `json:"allowed_commands" yaml:"allowed_commands"`
+	BlockedPatterns []string `json:"blocked_patterns" yaml:"blocked_patterns"`
+}
+
+// LoadConfigFromFile returns the default configuration with the policy file
+// at path applied. A level or allowed_commands list in the file replaces the
+// default; blocked_patterns are added to the default patterns so a policy can
+// only block more. Files ending in .json are read as JSON, others as YAML.
+func LoadConfigFromFile(path string) (*Config, error) {
+	config := DefaultConfig()
+	if err := applyPolicyFile(config, path); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// applyPolicyFile merges the policy file at path into config
+func applyPolicyFile(config *Config, path string) error {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return fmt.Errorf("failed to read security policy: %w", err)
+	}
+
+	var policy policyFile
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&policy)
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&policy)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse security policy %s: %w", path, err)
+	}
+
+	// Validate everything before touching config, so a bad file changes nothing
+	level := config.Level
+	if policy.Level != "" {
+		if level, err = ParseLevel(policy.Level); err != nil {
+			return fmt.Errorf("security policy %s: %w", path, err)
+		}
+	}
+	if _, failures := compileBlockedPatterns(policy.BlockedPatterns); len(failures) > 0 {
+		return fmt.Errorf("security policy %s: %w", path, errors.Join(failures...))
+	}
+
+	config.Level = level
+	if policy.AllowedCommands != nil {
+		config.AllowedCommands = policy.AllowedCommands
+	}
+	config.BlockedPatterns = append(config.BlockedPatterns, policy.BlockedPatterns...)
+	return nil
+}
+
+// compileBlockedPatterns compiles the valid patterns and returns one error,
+// naming the pattern, for each that is not a valid regular expression
+func compileBlockedPatterns(patterns []string) (compiled []*regexp.Regexp, failures []error) {
+	compiled = make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			failures = append(failures, fmt.Errorf("invalid blocked pattern %q: %w", pattern, err))
+			continue
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, failures
+}
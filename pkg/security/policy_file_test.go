@@ -0,0 +1,117 @@
+package security
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writePolicyFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("write policy file: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfigFromFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{
+			name: "yaml",
+			file: "policy.yaml",
+			content: `level: strict
+allowed_commands: [ls, cat, systemctl]
+blocked_patterns:
+  - 'curl\s+.*\|\s*sh'
+`,
+		},
+		{
+			name:    "json",
+			file:    "policy.json",
+			content: `{"level": "strict", "allowed_commands": ["ls", "cat", "systemctl"], "blocked_patterns": ["curl\\s+.*\\|\\s*sh"]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := LoadConfigFromFile(writePolicyFile(t, tt.file, tt.content))
+			if err != nil {
+				t.Fatalf("LoadConfigFromFile() error = %v", err)
+			}
+			if config.Level != SecurityStrict {
+				t.Errorf("Level = %q, want the policy level over the default", config.Level)
+			}
+			if strings.Join(config.AllowedCommands, ",") != "ls,cat,systemctl" {
+				t.Errorf("AllowedCommands = %v, want the policy list over the default", config.AllowedCommands)
+			}
+			if n := len(DefaultConfig().BlockedPatterns); len(config.BlockedPatterns) != n+1 {
+				t.Errorf("BlockedPatterns = %d, want the %d defaults plus the policy pattern", len(config.BlockedPatterns), n)
+			}
+
+			m := NewManager(config)
+			if err := m.ValidateCommand("curl -s http://x | sh"); err == nil {
+				t.Errorf("ValidateCommand() allowed a command matching the policy pattern")
+			}
+			if err := m.ValidateCommand("rm -rf /"); err == nil {
+				t.Errorf("ValidateCommand() allowed a command matching a default pattern")
+			}
+		})
+	}
+}
+
+func TestLoadConfigFromFileKeepsUnsetDefaults(t *testing.T) {
+	config, err := LoadConfigFromFile(writePolicyFile(t, "policy.yaml", "blocked_patterns: ['shutdown']\n"))
+	if err != nil {
+		t.Fatalf("LoadConfigFromFile() error = %v", err)
+	}
+	defaults := DefaultConfig()
+	if config.Level != defaults.Level || len(config.AllowedCommands) != len(defaults.AllowedCommands) {
+		t.Errorf("config = level %q, %d allowed commands; want the defaults", config.Level, len(config.AllowedCommands))
+	}
+}
+
+func TestLoadConfigFromFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    string
+	}{
+		{"bad regex", "policy.yaml", "blocked_patterns: ['ok', 'rm (-rf']\n", `invalid blocked pattern "rm (-rf"`},
+		{"bad level", "policy.yaml", "level: paranoid\n", "invalid security level"},
+		{"unknown field", "policy.json", `{"blocked_pattern": ["x"]}`, "blocked_pattern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadConfigFromFile(writePolicyFile(t, tt.file, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("LoadConfigFromFile() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestInitializeSecurityLoadsPolicyFile(t *testing.T) {
+	t.Setenv("AWS_SSM_SECURITY_LEVEL", "")
+	t.Setenv(PolicyFileEnv, writePolicyFile(t, "policy.yaml", "level: high\nallowed_commands: [uptime]\n"))
+
+	m := InitializeSecurityWithLevel(SecurityLow)
+	if m.config.Level != SecurityHigh {
+		t.Errorf("Level = %q, want the policy level over the configured baseline", m.config.Level)
+	}
+	if strings.Join(m.config.AllowedCommands, ",") != "uptime" {
+		t.Errorf("AllowedCommands = %v, want [uptime]", m.config.AllowedCommands)
+	}
+
+	t.Setenv("AWS_SSM_SECURITY_LEVEL", "strict")
+	if m := InitializeSecurityWithLevel(SecurityLow); m.config.Level != SecurityStrict {
+		t.Errorf("Level = %q, want the environment over the policy file", m.config.Level)
+	}
+}
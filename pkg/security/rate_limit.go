@@ -0,0 +1,87 @@
+package security
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// rateLimitWindow is the sliding window RateLimitPerIP is counted over
+const rateLimitWindow = time.Minute
+
+// rateLimiter allows each user at most limit requests in any sliding window.
+// Users with no request inside the window are pruned once per window so the
+// map does not grow without bound.
+type rateLimiter struct {
+	mu        sync.Mutex
+	limit     int
+	window    time.Duration
+	requests  map[string][]time.Time // Request times inside the window, oldest first
+	lastPrune time.Time
+
+	handleEvent func(*Event)
+	now         func() time.Time
+}
+
+// newRateLimiter creates a limiter allowing limit requests per window; a
+// non-positive limit disables it
+func newRateLimiter(limit int, window time.Duration) *rateLimiter {
+	return &rateLimiter{
+		limit:       limit,
+		window:      window,
+		requests:    make(map[string][]time.Time),
+		handleEvent: NewEventHandler().HandleEvent,
+		now:         time.Now,
+	}
+}
+
+// allow records a request by userID, failing when the user already made
+// limit requests within the window. Rejected requests are not counted.
+func (rl *rateLimiter) allow(userID string) error {
+	if rl.limit <= 0 {
+		return nil
+	}
+
+	now := rl.now()
+	rl.mu.Lock()
+	rl.pruneLocked(now)
+	recent := dropBefore(rl.requests[userID], now.Add(-rl.window))
+	if len(recent) >= rl.limit {
+		rl.requests[userID] = recent
+		retryAfter := recent[0].Add(rl.window).Sub(now)
+		rl.mu.Unlock()
+
+		rl.handleEvent(CreateSecurityEvent("rate_limited", "warning", "security_manager", userID,
+			fmt.Sprintf("rate limit of %d requests per %s exceeded", rl.limit, rl.window),
+			map[string]interface{}{"limit": rl.limit, "retry_after": retryAfter.String()}))
+		return fmt.Errorf("rate limit exceeded for %q: %d requests per %s, retry in %s",
+			userID, rl.limit, rl.window, retryAfter.Round(time.Second))
+	}
+	rl.requests[userID] = append(recent, now)
+	rl.mu.Unlock()
+	return nil
+}
+
+// pruneLocked drops users without requests inside the window, at most once
+// per window
+func (rl *rateLimiter) pruneLocked(now time.Time) {
+	if now.Sub(rl.lastPrune) < rl.window {
+		return
+	}
+	rl.lastPrune = now
+	cutoff := now.Add(-rl.window)
+	for userID, times := range rl.requests {
+		if len(times) == 0 || !times[len(times)-1].After(cutoff) {
+			delete(rl.requests, userID)
+		}
+	}
+}
+
+// dropBefore returns the times after cutoff; times must be sorted
+func dropBefore(times []time.Time, cutoff time.Time) []time.Time {
+	i := 0
+	for i < len(times) && !times[i].After(cutoff) {
+		i++
+	}
+	return times[i:]
+}
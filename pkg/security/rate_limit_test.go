@@ -0,0 +1,82 @@
+package security
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func newRateLimitedManager(limit int, now *time.Time) (*Manager, *[]*Event) {
+	cfg := DefaultConfig()
+	cfg.RateLimitPerIP = limit
+	cfg.CredentialRotationCheck = false
+	cfg.EnableAuditLogging = false
+	m := NewManager(cfg)
+
+	var events []*Event
+	m.limiter.now = func() time.Time { return *now }
+	m.limiter.handleEvent = func(e *Event) { events = append(events, e) }
+	return m, &events
+}
+
+func TestValidateSessionRateLimit(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m, events := newRateLimitedManager(3, &now)
+
+	for i := 0; i < 3; i++ {
+		if err := m.ValidateSession("s-1", "dev"); err != nil {
+			t.Fatalf("ValidateSession() call %d error = %v", i+1, err)
+		}
+		now = now.Add(10 * time.Second)
+	}
+	err := m.ValidateSession("s-1", "dev")
+	if err == nil || !strings.Contains(err.Error(), "rate limit exceeded") {
+		t.Fatalf("ValidateSession() call 4 error = %v, want rate limit exceeded", err)
+	}
+	if len(*events) != 1 || (*events)[0].Type != "rate_limited" || (*events)[0].Target != "dev" {
+		t.Errorf("events = %+v, want one rate_limited event for dev", *events)
+	}
+
+	// Other users have their own budget
+	if err := m.ValidateSession("s-2", "prod"); err != nil {
+		t.Errorf("ValidateSession() for another user error = %v", err)
+	}
+
+	// The first request leaves the window 60s after it was made
+	now = time.Date(2026, 1, 1, 12, 1, 0, 0, time.UTC).Add(time.Nanosecond)
+	if err := m.ValidateSession("s-1", "dev"); err != nil {
+		t.Errorf("ValidateSession() after the window error = %v", err)
+	}
+	if err := m.ValidateSession("s-1", "dev"); err == nil {
+		t.Errorf("ValidateSession() allowed a request over the limit inside the new window")
+	}
+}
+
+func TestRateLimitDisabled(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m, _ := newRateLimitedManager(0, &now)
+	for i := 0; i < 500; i++ {
+		if err := m.ValidateSession("s-1", "dev"); err != nil {
+			t.Fatalf("ValidateSession() with the limit disabled error = %v", err)
+		}
+	}
+}
+
+func TestRateLimiterPrunesIdleUsers(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	rl := newRateLimiter(5, time.Minute)
+	rl.now = func() time.Time { return now }
+
+	for _, user := range []string{"a", "b", "c"} {
+		if err := rl.allow(user); err != nil {
+			t.Fatalf("allow(%s) error = %v", user, err)
+		}
+	}
+	now = now.Add(2 * time.Minute)
+	if err := rl.allow("d"); err != nil {
+		t.Fatalf("allow(d) error = %v", err)
+	}
+	if len(rl.requests) != 1 {
+		t.Errorf("tracked users = %d, want idle users pruned", len(rl.requests))
+	}
+}
@@ -2,11 +2,13 @@
 package security
 
 import (
+	"context"
 	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -35,18 +37,32 @@ const (
 	SecurityStrict Level = "strict"
 )
 
+// ParseLevel converts a security level name into a Level
+func ParseLevel(s string) (Level, error) {
+	switch level := Level(strings.ToLower(strings.TrimSpace(s))); level {
+	case SecurityLow, SecurityMedium, SecurityHigh, SecurityStrict:
+		return level, nil
+	default:
+		return "", fmt.Errorf("invalid security level %q (valid: low, medium, high, strict)", s)
+	}
+}
+
 // Config represents security configuration
 type Config struct {
 	Level                    Level
 	CommandTimeout           time.Duration
 	MaxCommandLength         int
 	AllowedCommands          []string
+	AllowedCommandArgs       map[string][]string // Strict mode: subcommands allowed per command; unlisted commands are unrestricted
 	BlockedPatterns          []string
 	RequireCommandValidation bool
 	EnableAuditLogging       bool
+	AuditLogFile             string // Also append audit events to this file as JSON lines
 	CredentialRotationCheck  bool
+	CredentialExpiryWindow   time.Duration // Warn when credentials expire within this window
 	SessionTimeout           time.Duration
-	RateLimitPerIP           int
+	SessionIdleWarning       time.Duration
+	RateLimitPerIP           int // Session validations allowed per user per minute; 0 disables the limit
 	EnableTLSVerification    bool
 	CertPaths                []string
 }
@@ -60,7 +76,9 @@ func DefaultConfig() *Config {
 		RequireCommandValidation: true,
 		EnableAuditLogging:       true,
 		CredentialRotationCheck:  true,
+		CredentialExpiryWindow:   DefaultCredentialExpiryWindow,
 		SessionTimeout:           3600 * time.Second,
+		SessionIdleWarning:       60 * time.Second,
 		RateLimitPerIP:           100,
 		EnableTLSVerification:    true,
 		CertPaths:                []string{},
@@ -71,6 +89,9 @@ func DefaultConfig() *Config {
 			"systemctl", "service",
 			"curl", "wget", "ping", "ssh", "scp",
 		},
+		AllowedCommandArgs: map[string][]string{
+			"systemctl": {"status", "is-active", "is-enabled", "is-failed", "list-units", "show"},
+		},
 		BlockedPatterns: []string{
 			// Dangerous destructive operations - rm variants (consolidated pattern)
 			// Matches: rm -rf / or rm -rf /* or rm -rf /root, /home, /etc, /var, /usr, /boot, /lib, /sys, /proc, /dev
@@ -111,8 +132,14 @@ type Manager struct {
 	config          *Config
 	logger          logging.Logger
 	auditor         *AuditLogger
+	credentials     *CredentialManager
+	limiter         *rateLimiter
 	blockedPatterns []*regexp.Regexp
 	suspiciousRegex []*regexp.Regexp
+
+	sessionsMu sync.Mutex
+	sessions   map[string]time.Time // Start time of each registered session
+	now        func() time.Time
 }
 
 // NewManager creates a new security manager
@@ -121,15 +148,32 @@ func NewManager(config *Config) *Manager {
 		config = DefaultConfig()
 	}
 
-	// Compile blocked patterns as regex
-	blockedPatterns := make([]*regexp.Regexp, 0, len(config.BlockedPatterns))
-	for _, pattern := range config.BlockedPatterns {
-		re, compileErr := regexp.Compile(pattern)
-		if compileErr == nil {
-			blockedPatterns = append(blockedPatterns, re)
-		}
+	// Compile blocked patterns as regex. Invalid ones cannot be enforced, so
+	// each is logged; NewManagerStrict refuses them instead.
+	blockedPatterns, failures := compileBlockedPatterns(config.BlockedPatterns)
+	m := newManager(config, blockedPatterns)
+	for _, failure := range failures {
+		m.logger.Warn("Ignoring invalid blocked pattern", logging.String("error", failure.Error()))
 	}
+	return m
+}
+
+// NewManagerStrict creates a security manager like NewManager, but fails
+// with every invalid blocked pattern instead of running without them
+func NewManagerStrict(config *Config) (*Manager, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	blockedPatterns, failures := compileBlockedPatterns(config.BlockedPatterns)
+	if len(failures) > 0 {
+		return nil, errors.Join(failures...)
+	}
+	return newManager(config, blockedPatterns), nil
+}
 
+// newManager builds a manager enforcing the already compiled blocked patterns;
+// callers decide how to report the patterns that failed to compile
+func newManager(config *Config, blockedPatterns []*regexp.Regexp) *Manager {
 	// Compile suspicious patterns for high security mode
 	// Note: These patterns are used to flag potentially suspicious commands for review,
 	// not to block them outright. They help identify commands that may need additional scrutiny.
@@ -152,108 +196,196 @@ func NewManager(config *Config) *Manager {
 		}
 	}
 
+	credentials := NewCredentialManager()
+	if config.CredentialExpiryWindow > 0 {
+		credentials.expiryWindow = config.CredentialExpiryWindow
+	}
+
 	return &Manager{
 		config:          config,
 		logger:          logging.With(logging.String("component", "security_manager")),
 		auditor:         NewAuditLogger(config),
+		credentials:     credentials,
+		limiter:         newRateLimiter(config.RateLimitPerIP, rateLimitWindow),
 		blockedPatterns: blockedPatterns,
 		suspiciousRegex: suspiciousRegex,
+		sessions:        make(map[string]time.Time),
+		now:             time.Now,
 	}
 }
 
+// Rules reported in a Violation
+const (
+	RuleCommandValidation = "command_validation"
+	RuleBlockedPattern    = "blocked_pattern"
+	RuleSuspiciousPattern = "suspicious_pattern"
+	RuleCommandAllowlist  = "command_allowlist"
+	RuleCommandArgs       = "command_args"
+)
+
+// Violation is one security rule a command broke
+type Violation struct {
+	Rule     string
+	Pattern  string // The blocked pattern or command that triggered the rule, if any
+	Severity string
+	Message  string
+}
+
+// ValidationResult is the structured outcome of validating a command
+type ValidationResult struct {
+	Valid      bool
+	Violations []Violation
+}
+
+// Err returns nil for a valid command, otherwise an error with the first violation
+func (r *ValidationResult) Err() error {
+	if r.Valid || len(r.Violations) == 0 {
+		return nil
+	}
+	return errors.New(r.Violations[0].Message)
+}
+
 // ValidateCommand validates a command for security compliance
 func (sm *Manager) ValidateCommand(command string) error {
+	return sm.EvaluateCommand(command).Err()
+}
+
+// EvaluateCommand validates a command and reports every rule it breaks
+func (sm *Manager) EvaluateCommand(command string) *ValidationResult {
 	// Trim leading and trailing whitespace to avoid false negatives with trailing spaces
 	command = strings.TrimSpace(command)
 
+	var violations []Violation
+
 	// Use the standard command validator for basic validation
 	validator := validation.NewCommandValidator()
 	result := validator.Validate(command)
 	if !result.Valid {
-		reason := strings.Join(result.Errors, "; ")
-		sm.auditor.Log("command_rejected", map[string]interface{}{
-			"command": command,
-			"reason":  reason,
+		violations = append(violations, Violation{
+			Rule:     RuleCommandValidation,
+			Severity: "medium",
+			Message:  fmt.Sprintf("command validation failed: %s", strings.Join(result.Errors, "; ")),
 		})
-		return fmt.Errorf("command validation failed: %s", reason)
 	}
 
-	// Apply security-specific pattern validation
-	if err := sm.validatePatterns(command); err != nil {
-		sm.auditor.Log("command_rejected", map[string]interface{}{
-			"command": command,
-			"reason":  err.Error(),
-		})
-		return err
-	}
+	// Apply security-specific pattern and structure validation
+	violations = append(violations, sm.validatePatterns(command)...)
+	violations = append(violations, sm.validateCommandStructure(command)...)
 
-	// Apply security-specific structure validation
-	if err := sm.validateCommandStructure(command); err != nil {
+	if len(violations) > 0 {
+		rules := make([]string, 0, len(violations))
+		for _, v := range violations {
+			rules = append(rules, v.Rule)
+		}
 		sm.auditor.Log("command_rejected", map[string]interface{}{
 			"command": command,
-			"reason":  err.Error(),
+			"reason":  violations[0].Message,
+			"rules":   rules,
 		})
-		return err
+		return &ValidationResult{Violations: violations}
 	}
 
 	sm.auditor.Log("command_approved", map[string]interface{}{
 		"command": command,
 	})
 
-	return nil
+	return &ValidationResult{Valid: true}
 }
 
-func (sm *Manager) validatePatterns(command string) error {
+func (sm *Manager) validatePatterns(command string) []Violation {
+	var violations []Violation
+
 	// Check against compiled blocked patterns using regex
 	for i, re := range sm.blockedPatterns {
 		if re.MatchString(command) {
-			return fmt.Errorf("command contains blocked pattern: %s", sm.config.BlockedPatterns[i])
+			violations = append(violations, Violation{
+				Rule:     RuleBlockedPattern,
+				Pattern:  sm.config.BlockedPatterns[i],
+				Severity: "high",
+				Message:  fmt.Sprintf("command contains blocked pattern: %s", sm.config.BlockedPatterns[i]),
+			})
 		}
 	}
 
 	// Additional checks for high security levels
 	if sm.config.Level == SecurityHigh || sm.config.Level == SecurityStrict {
 		// Check for suspicious character sequences using regex
+		patterns := []string{
+			"command substitution",
+			"shell metacharacters",
+			"variable expansion",
+			"network tools",
+		}
 		for i, re := range sm.suspiciousRegex {
 			if re.MatchString(command) {
-				patterns := []string{
-					"command substitution",
-					"shell metacharacters",
-					"variable expansion",
-					"network tools",
-				}
+				message := "command contains suspicious pattern"
 				if i < len(patterns) {
-					return fmt.Errorf("command contains suspicious pattern: %s", patterns[i])
+					message = fmt.Sprintf("command contains suspicious pattern: %s", patterns[i])
 				}
-				return fmt.Errorf("command contains suspicious pattern")
+				violations = append(violations, Violation{
+					Rule:     RuleSuspiciousPattern,
+					Pattern:  re.String(),
+					Severity: "medium",
+					Message:  message,
+				})
 			}
 		}
 	}
 
-	return nil
+	return violations
 }
 
-func (sm *Manager) validateCommandStructure(command string) error {
-	if sm.config.Level == SecurityStrict {
-		// In strict mode, only allow whitelisted commands
-		parts := strings.Fields(command)
-		if len(parts) == 0 {
-			return fmt.Errorf("empty command")
-		}
+func (sm *Manager) validateCommandStructure(command string) []Violation {
+	if sm.config.Level != SecurityStrict {
+		return nil
+	}
 
-		baseCommand := parts[0]
-		if !containsString(sm.config.AllowedCommands, baseCommand) {
-			return fmt.Errorf("command not in whitelist: %s", baseCommand)
-		}
+	// In strict mode, only allow whitelisted commands
+	parts := strings.Fields(command)
+	if len(parts) == 0 {
+		return []Violation{{Rule: RuleCommandAllowlist, Severity: "high", Message: "empty command"}}
+	}
+
+	baseCommand := parts[0]
+	if !containsString(sm.config.AllowedCommands, baseCommand) {
+		return []Violation{{
+			Rule:     RuleCommandAllowlist,
+			Pattern:  baseCommand,
+			Severity: "high",
+			Message:  fmt.Sprintf("command not in whitelist: %s", baseCommand),
+		}}
+	}
+	if err := sm.validateCommandArgs(baseCommand, parts[1:]); err != nil {
+		return []Violation{{Rule: RuleCommandArgs, Pattern: baseCommand, Severity: "high", Message: err.Error()}}
 	}
 
 	return nil
 }
 
-// ValidateSession validates a session for security compliance
+// validateCommandArgs checks the subcommand of baseCommand against
+// AllowedCommandArgs; flags before the subcommand are skipped
+func (sm *Manager) validateCommandArgs(baseCommand string, args []string) error {
+	allowed, restricted := sm.config.AllowedCommandArgs[baseCommand]
+	if !restricted {
+		return nil
+	}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+		if !containsString(allowed, arg) {
+			return fmt.Errorf("argument %q not allowed for %s (allowed: %s)", arg, baseCommand, strings.Join(allowed, ", "))
+		}
+		return nil
+	}
+	return fmt.Errorf("%s requires one of these arguments: %s", baseCommand, strings.Join(allowed, ", "))
+}
+
+// ValidateSession validates a session for security compliance; userID is the
+// AWS profile whose credentials the session uses
 func (sm *Manager) ValidateSession(sessionID string, userID string) error {
 	// Check session timeout
-	if err := sm.checkSessionTimeout(sessionID); err != nil {
+	if err := sm.CheckSessionTimeout(sessionID); err != nil {
 		return err
 	}
 
@@ -277,26 +409,82 @@ func (sm *Manager) ValidateSession(sessionID string, userID string) error {
 	return nil
 }
 
-func (sm *Manager) checkSessionTimeout(_ string) error {
-	// This would check against actual session store
-	// For now, just return nil as placeholder
-	return nil
+// SessionIdlePolicy returns the session timeout and how long before it an idle
+// session should be warned. A zero warning disables it.
+func (sm *Manager) SessionIdlePolicy() (timeout, warnBefore time.Duration) {
+	return sm.config.SessionTimeout, sm.config.SessionIdleWarning
 }
 
-func (sm *Manager) checkRateLimit(_ string) error {
-	// This would check against a rate limiter
-	// For now, just return nil as placeholder
-	return nil
+// checkRateLimit enforces RateLimitPerIP session validations per user per minute
+func (sm *Manager) checkRateLimit(userID string) error {
+	return sm.limiter.allow(userID)
 }
 
-func (sm *Manager) checkCredentialRotation(_ string) error {
-	// This would check if credentials have been rotated recently
-	// For now, just return nil as placeholder
+// checkCredentialRotation fails for expired credentials; credentials close to
+// expiry only raise a warning event
+func (sm *Manager) checkCredentialRotation(profile string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), credentialCheckTimeout)
+	defer cancel()
+
+	info, err := sm.credentials.CheckExpiry(ctx, profile)
+	if err != nil {
+		return fmt.Errorf("credential check failed: %w", err)
+	}
+	if !info.ExpiresAt.IsZero() && !info.ExpiresAt.After(sm.credentials.now()) {
+		return fmt.Errorf("credentials for profile %q expired at %s", profile, info.ExpiresAt.Format(time.RFC3339))
+	}
 	return nil
 }
 
+// SanitizeMode selects how SanitizeInputMode neutralizes shell metacharacters
+type SanitizeMode int
+
+const (
+	// ModeStrip removes quotes and metacharacters; this is what SanitizeInput does
+	ModeStrip SanitizeMode = iota
+	// ModeEscape backslash-escapes metacharacters so the shell treats them literally
+	ModeEscape
+	// ModeQuoteAware keeps balanced quotes and removes only command substitution,
+	// expansion and command separators that the shell would act on
+	ModeQuoteAware
+)
+
+// shellEscapeChars are the characters ModeEscape escapes
+const shellEscapeChars = "$`\\\"';&|<>*?(){}!#~"
+
 // SanitizeInput sanitizes user input
 func (sm *Manager) SanitizeInput(input string) string {
+	return sm.SanitizeInputMode(input, ModeStrip)
+}
+
+// SanitizeInputMode sanitizes user input with the given mode. ModeEscape and
+// ModeQuoteAware limit the input to MaxCommandLength before sanitizing so a
+// quote or escape is never cut in half.
+func (sm *Manager) SanitizeInputMode(input string, mode SanitizeMode) string {
+	if mode == ModeStrip {
+		return sm.stripInput(input)
+	}
+
+	cleaned := strings.TrimSpace(input)
+	if len(cleaned) > sm.config.MaxCommandLength {
+		cleaned = cleaned[:sm.config.MaxCommandLength]
+	}
+	if mode == ModeEscape {
+		return escapeShell(cleaned)
+	}
+
+	// Removing a sequence can join its neighbours into a new one, e.g. "$$((",
+	// so repeat until nothing changes; every pass only removes characters
+	for {
+		next := sanitizeQuoteAware(cleaned)
+		if next == cleaned {
+			return strings.TrimSpace(next)
+		}
+		cleaned = next
+	}
+}
+
+func (sm *Manager) stripInput(input string) string {
 	// Remove dangerous characters
 	dangerous := []string{
 		"${", "}", "$(", "`", "\\", "\"", "'", ";", "&", "|", ">", "<", "*", "?",
@@ -318,6 +506,76 @@ func (sm *Manager) SanitizeInput(input string) string {
 	return cleaned
 }
 
+// escapeShell backslash-escapes every shell metacharacter in input
+func escapeShell(input string) string {
+	var b strings.Builder
+	for _, r := range input {
+		if strings.ContainsRune(shellEscapeChars, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// sanitizeQuoteAware makes one pass over input, keeping single-quoted text as
+// is, removing substitutions from double-quoted text and removing substitutions,
+// separators, redirects and escapes from unquoted text. Unbalanced quotes are
+// dropped.
+func sanitizeQuoteAware(input string) string {
+	var b strings.Builder
+	for i := 0; i < len(input); i++ {
+		c := input[i]
+		switch {
+		case c == '\'':
+			end := strings.IndexByte(input[i+1:], '\'')
+			if end < 0 {
+				continue
+			}
+			b.WriteString(input[i : i+end+2])
+			i += end + 1
+		case c == '"':
+			end := closingDoubleQuote(input, i+1)
+			if end < 0 {
+				continue
+			}
+			b.WriteByte('"')
+			b.WriteString(removeSubstitutions(input[i+1 : end]))
+			b.WriteByte('"')
+			i = end
+		case c == '$' && i+1 < len(input) && (input[i+1] == '(' || input[i+1] == '{'):
+			i++
+		case strings.IndexByte("`;&|<>\\\n", c) >= 0:
+			// Dropped
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// closingDoubleQuote returns the index of the unescaped '"' closing a double
+// quoted string that starts at start, or -1
+func closingDoubleQuote(input string, start int) int {
+	for i := start; i < len(input); i++ {
+		switch input[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// removeSubstitutions removes command substitution and parameter expansion
+func removeSubstitutions(text string) string {
+	for _, seq := range []string{"$(", "${", "`"} {
+		text = strings.ReplaceAll(text, seq, "")
+	}
+	return text
+}
+
 // AuditLogger logs security events
 type AuditLogger struct {
 	config *Config
@@ -346,6 +604,13 @@ func (al *AuditLogger) Log(event string, data map[string]interface{}) {
 	al.logger.Info("Security audit event",
 		logging.String("event", event),
 		logging.Any("data", data))
+
+	if al.config.AuditLogFile != "" {
+		entry := AuditEvent{Time: time.Now().UTC(), Event: event, Data: data}
+		if err := appendAuditEvent(al.config.AuditLogFile, entry); err != nil {
+			al.logger.Warn("Failed to write audit log file", logging.String("error", err.Error()))
+		}
+	}
 }
 
 // CredentialManager manages AWS credentials securely
@@ -354,22 +619,34 @@ type CredentialManager struct {
 	mu     sync.RWMutex
 	// In production, this would use proper credential storage
 	cache map[string]*CredentialInfo
+
+	expiryWindow time.Duration
+	providerFor  CredentialsProviderFunc
+	handleEvent  func(*Event)
+	now          func() time.Time
 }
 
 // CredentialInfo represents credential information
 type CredentialInfo struct {
-	Profile     string
-	LastUsed    time.Time
-	Rotated     bool
-	Hash        string
-	Permissions []string
+	Profile      string
+	LastUsed     time.Time
+	Rotated      bool      // The access key changed since the previous check
+	ExpiresAt    time.Time // Zero for credentials that do not expire
+	ExpiringSoon bool
+	Hash         string
+	Permissions  []string
+	keyHash      string
 }
 
 // NewCredentialManager creates a new credential manager
 func NewCredentialManager() *CredentialManager {
 	return &CredentialManager{
-		logger: logging.With(logging.String("component", "credential_manager")),
-		cache:  make(map[string]*CredentialInfo),
+		logger:       logging.With(logging.String("component", "credential_manager")),
+		cache:        make(map[string]*CredentialInfo),
+		expiryWindow: DefaultCredentialExpiryWindow,
+		providerFor:  sharedConfigCredentials,
+		handleEvent:  NewEventHandler().HandleEvent,
+		now:          time.Now,
 	}
 }
 
@@ -425,10 +702,12 @@ func (cm *CredentialManager) GetCredentialInfo(profile string) (*CredentialInfo,
 
 	// Return a copy without sensitive information
 	return &CredentialInfo{
-		Profile:  cred.Profile,
-		LastUsed: cred.LastUsed,
-		Rotated:  cred.Rotated,
-		Hash:     cred.Hash,
+		Profile:      cred.Profile,
+		LastUsed:     cred.LastUsed,
+		Rotated:      cred.Rotated,
+		ExpiresAt:    cred.ExpiresAt,
+		ExpiringSoon: cred.ExpiringSoon,
+		Hash:         cred.Hash,
 		// Don't expose Permissions
 	}, true
 }
@@ -570,12 +849,17 @@ func CreateSecurityEvent(eventType, severity, source, target, message string, da
 
 // SecureConnection creates a secure network connection
 func SecureConnection(network, addr string, timeout time.Duration) (net.Conn, error) {
+	return secureDial(context.Background(), network, addr, timeout, timeout)
+}
+
+// secureDial dials addr with separate connect and keepalive settings and wraps the result
+func secureDial(ctx context.Context, network, addr string, connectTimeout, keepAlive time.Duration) (net.Conn, error) {
 	dialer := &net.Dialer{
-		Timeout:   timeout,
-		KeepAlive: timeout,
+		Timeout:   connectTimeout,
+		KeepAlive: keepAlive,
 	}
 
-	conn, err := dialer.Dial(network, addr)
+	conn, err := dialer.DialContext(ctx, network, addr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create secure connection: %w", err)
 	}
@@ -584,7 +868,7 @@ func SecureConnection(network, addr string, timeout time.Duration) (net.Conn, er
 	return &SecureConn{
 		Conn:    conn,
 		logger:  logging.With(logging.String("component", "secure_connection")),
-		timeout: timeout,
+		timeout: connectTimeout,
 	}, nil
 }
 
@@ -621,20 +905,57 @@ func (sc *SecureConn) Write(b []byte) (n int, err error) {
 	return n, err
 }
 
+// HTTPClientOptions controls the timeouts and keepalives of a secure HTTP client
+type HTTPClientOptions struct {
+	ConnectTimeout  time.Duration // Maximum time to establish a TCP connection
+	RequestTimeout  time.Duration // Maximum time for a whole request (0 = no limit)
+	KeepAlive       time.Duration // Interval between TCP keepalive probes
+	IdleConnTimeout time.Duration // How long idle pooled connections are kept
+}
+
+// DefaultHTTPClientOptions returns options matching the AWS SDK transport defaults
+func DefaultHTTPClientOptions() HTTPClientOptions {
+	return HTTPClientOptions{
+		ConnectTimeout:  30 * time.Second,
+		RequestTimeout:  0,
+		KeepAlive:       30 * time.Second,
+		IdleConnTimeout: 90 * time.Second,
+	}
+}
+
 // SecureHTTPClient creates a secure HTTP client
 func SecureHTTPClient(timeout time.Duration, tlsConfig *TLSConfig) *http.Client {
+	return SecureHTTPClientWithOptions(HTTPClientOptions{
+		ConnectTimeout:  timeout,
+		RequestTimeout:  timeout,
+		KeepAlive:       timeout,
+		IdleConnTimeout: 90 * time.Second,
+	}, tlsConfig)
+}
+
+// SecureHTTPClientWithOptions creates a secure HTTP client with explicit timeout and keepalive settings
+func SecureHTTPClientWithOptions(opts HTTPClientOptions, tlsConfig *TLSConfig) *http.Client {
+	var clientTLS *tls.Config
+	if tlsConfig != nil {
+		clientTLS = tlsConfig.GetTLSConfig()
+	}
+
 	transport := &http.Transport{
-		TLSClientConfig:     tlsConfig.GetTLSConfig(),
-		Dial:                func(network, addr string) (net.Conn, error) { return SecureConnection(network, addr, timeout) },
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: clientTLS,
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return secureDial(ctx, network, addr, opts.ConnectTimeout, opts.KeepAlive)
+		},
 		MaxIdleConns:        10,
 		MaxIdleConnsPerHost: 5,
-		IdleConnTimeout:     90 * time.Second,
+		IdleConnTimeout:     opts.IdleConnTimeout,
+		TLSHandshakeTimeout: opts.ConnectTimeout,
 		DisableCompression:  false,
 	}
 
 	return &http.Client{
 		Transport: transport,
-		Timeout:   timeout,
+		Timeout:   opts.RequestTimeout,
 	}
 }
 
@@ -650,38 +971,64 @@ func NewScanner() *Scanner {
 	}
 }
 
-// ScanCommand scans a command for security issues
-func (ss *Scanner) ScanCommand(command string) []string {
-	issues := make([]string, 0)
+// Finding is a security issue found in a command by Scanner
+type Finding struct {
+	Pattern    string
+	Severity   string
+	Message    string
+	MatchIndex []int // Byte offsets [start, end) of the first match in the command
+}
 
-	// Check for common security issues
-	securityChecks := []struct {
-		pattern string
-		message string
-	}{
-		{"rm\\s+-rf", "Dangerous recursive delete command"},
-		{"chmod\\s+777", "Overly permissive file permissions"},
-		{"sudo\\s", "Privilege escalation detected"},
-		{">\\s*/etc/", "Modification of system files"},
-		{"\\$\\{", "Variable expansion - potential injection"},
-		{"`.*`", "Command substitution - potential injection"},
-		{"wget.*\\|", "Download and pipe - potential code injection"},
-		{"curl.*\\|", "Download and pipe - potential code injection"},
-	}
-
-	for _, check := range securityChecks {
-		if strings.Contains(command, check.pattern) {
-			issues = append(issues, check.message)
+// scanChecks are the patterns Scanner looks for, in reporting order
+var scanChecks = []struct {
+	re       *regexp.Regexp
+	severity string
+	message  string
+}{
+	{regexp.MustCompile(`rm\s+-rf`), "high", "Dangerous recursive delete command"},
+	{regexp.MustCompile(`chmod\s+777`), "medium", "Overly permissive file permissions"},
+	{regexp.MustCompile(`sudo\s`), "medium", "Privilege escalation detected"},
+	{regexp.MustCompile(`>\s*/etc/`), "high", "Modification of system files"},
+	{regexp.MustCompile(`\$\{`), "medium", "Variable expansion - potential injection"},
+	{regexp.MustCompile("`.*`"), "medium", "Command substitution - potential injection"},
+	{regexp.MustCompile(`wget.*\|`), "high", "Download and pipe - potential code injection"},
+	{regexp.MustCompile(`curl.*\|`), "high", "Download and pipe - potential code injection"},
+}
+
+// ScanCommand scans a command for security issues
+func (ss *Scanner) ScanCommand(command string) []Finding {
+	findings := make([]Finding, 0)
+	for _, check := range scanChecks {
+		if loc := check.re.FindStringIndex(command); loc != nil {
+			findings = append(findings, Finding{
+				Pattern:    check.re.String(),
+				Severity:   check.severity,
+				Message:    check.message,
+				MatchIndex: loc,
+			})
 		}
 	}
 
-	if len(issues) > 0 {
+	if len(findings) > 0 {
 		ss.logger.Warn("Security issues detected in command",
 			logging.String("command", command),
-			logging.Any("issues", issues))
+			logging.Any("issues", findingMessages(findings)))
 	}
 
-	return issues
+	return findings
+}
+
+// ScanCommandMessages returns only the messages of ScanCommand's findings
+func (ss *Scanner) ScanCommandMessages(command string) []string {
+	return findingMessages(ss.ScanCommand(command))
+}
+
+func findingMessages(findings []Finding) []string {
+	messages := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		messages = append(messages, finding.Message)
+	}
+	return messages
 }
 
 // GenerateSecurityReport generates a security report
@@ -750,7 +1097,24 @@ func (sm *Manager) CheckSecurityPolicy(policy string) error {
 
 // InitializeSecurity initializes security with environment-specific settings
 func InitializeSecurity() *Manager {
+	return InitializeSecurityWithLevel("")
+}
+
+// InitializeSecurityWithLevel initializes security using the given level as the
+// baseline (e.g. from the config file). A policy file named by
+// AWS_SSM_SECURITY_POLICY is applied on top; environment settings still take
+// precedence.
+func InitializeSecurityWithLevel(level Level) *Manager {
 	config := DefaultConfig()
+	if level != "" {
+		config.Level = level
+	}
+
+	if path := os.Getenv(PolicyFileEnv); path != "" {
+		if err := applyPolicyFile(config, path); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring security policy: %v\n", err)
+		}
+	}
 
 	// Load security level from environment
 	if level := os.Getenv("AWS_SSM_SECURITY_LEVEL"); level != "" {
@@ -764,9 +1128,23 @@ func InitializeSecurity() *Manager {
 		}
 	}
 
+	if warning := os.Getenv("AWS_SSM_SESSION_IDLE_WARNING"); warning != "" {
+		if d, err := time.ParseDuration(warning); err == nil {
+			config.SessionIdleWarning = d
+		}
+	}
+
 	if audit := os.Getenv("AWS_SSM_AUDIT_LOGGING"); audit != "" {
 		config.EnableAuditLogging = audit == "true"
 	}
 
-	return NewManager(config)
+	config.AuditLogFile = os.Getenv(AuditLogFileEnv)
+
+	// Report invalid blocked patterns once, on stderr, rather than also in
+	// NewManager's logs
+	blockedPatterns, failures := compileBlockedPatterns(config.BlockedPatterns)
+	if len(failures) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: some blocked command patterns are not enforced: %v\n", errors.Join(failures...))
+	}
+	return newManager(config, blockedPatterns)
 }
@@ -1,7 +1,10 @@
 package security
 
 import (
+	"fmt"
 	"net"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,6 +22,34 @@ func TestCommandValidation(t *testing.T) {
 	}
 }
 
+func TestNewManagerStrictRejectsInvalidPatterns(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.BlockedPatterns = append(cfg.BlockedPatterns, `rm\s+(-rf`, `mkfs\.[a-z]+`, `dd\s+if=[`)
+
+	_, err := NewManagerStrict(cfg)
+	if err == nil {
+		t.Fatalf("NewManagerStrict() accepted invalid blocked patterns")
+	}
+	for _, pattern := range []string{`rm\s+(-rf`, `dd\s+if=[`} {
+		if !strings.Contains(err.Error(), fmt.Sprintf("%q", pattern)) {
+			t.Errorf("NewManagerStrict() error = %v, want it to name %q", err, pattern)
+		}
+	}
+
+	// The lenient constructor still enforces the valid patterns
+	m := NewManager(cfg)
+	if len(m.blockedPatterns) != len(cfg.BlockedPatterns)-2 {
+		t.Errorf("compiled patterns = %d, want %d", len(m.blockedPatterns), len(cfg.BlockedPatterns)-2)
+	}
+	if err := m.ValidateCommand("mkfs.ext4 /dev/sda1"); err == nil {
+		t.Errorf("ValidateCommand() allowed a command matching a valid pattern")
+	}
+
+	if _, err := NewManagerStrict(DefaultConfig()); err != nil {
+		t.Errorf("NewManagerStrict() with the default patterns error = %v", err)
+	}
+}
+
 func TestCredentialManager(t *testing.T) {
 	cm := NewCredentialManager()
 	if err := cm.ValidateCredentials("dev-profile"); err != nil {
@@ -43,3 +74,185 @@ func TestSecureConnWrapper(t *testing.T) {
 		t.Fatalf("expected hello got %s", string(buf[:n]))
 	}
 }
+
+func TestStrictCommandArgsAllowlist(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Level = SecurityStrict
+	cfg.AuditLogFile = filepath.Join(t.TempDir(), "audit.log")
+	cfg.AllowedCommandArgs = map[string][]string{"systemctl": {"status", "is-active"}}
+	m := NewManager(cfg)
+
+	tests := []struct {
+		command string
+		wantErr string
+	}{
+		{command: "systemctl status nginx"},
+		{command: "systemctl --no-pager is-active nginx"},
+		{command: "ls -la"}, // no argument rules for ls
+		{command: "systemctl stop nginx", wantErr: `argument "stop" not allowed for systemctl (allowed: status, is-active)`},
+		{command: "systemctl --now restart nginx", wantErr: `argument "restart" not allowed for systemctl`},
+		{command: "systemctl", wantErr: "systemctl requires one of these arguments: status, is-active"},
+		{command: "reboot", wantErr: "command not in whitelist: reboot"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			err := m.ValidateCommand(tt.command)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateCommand(%q) error = %v", tt.command, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateCommand(%q) error = %v, want %q", tt.command, err, tt.wantErr)
+			}
+		})
+	}
+
+	events, _, err := ReadAuditEvents(cfg.AuditLogFile, AuditFilter{Types: []string{"command_rejected"}})
+	if err != nil {
+		t.Fatalf("ReadAuditEvents() error = %v", err)
+	}
+	found := false
+	for _, event := range events {
+		if event.Data["command"] == "systemctl stop nginx" && strings.Contains(fmt.Sprint(event.Data["reason"]), `"stop" not allowed`) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("rejected argument not audited: %+v", events)
+	}
+}
+
+func TestCommandArgsIgnoredOutsideStrictMode(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.EnableAuditLogging = false
+	cfg.AllowedCommandArgs = map[string][]string{"systemctl": {"status"}}
+	if err := NewManager(cfg).ValidateCommand("systemctl restart nginx"); err != nil {
+		t.Errorf("argument rules should only apply in strict mode: %v", err)
+	}
+}
+
+func TestEvaluateCommandReportsEachViolation(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Level = SecurityStrict
+	cfg.EnableAuditLogging = false
+	m := NewManager(cfg)
+
+	// Hits the allowlist, a blocked pattern and two suspicious patterns
+	result := m.EvaluateCommand("reboot; rm -rf / && chmod 000 /etc")
+	if result.Valid {
+		t.Fatal("expected invalid result")
+	}
+	rules := map[string]int{}
+	for _, v := range result.Violations {
+		rules[v.Rule]++
+		if v.Severity == "" || v.Message == "" {
+			t.Errorf("violation missing severity or message: %+v", v)
+		}
+	}
+	for _, want := range []string{RuleBlockedPattern, RuleSuspiciousPattern, RuleCommandAllowlist} {
+		if rules[want] == 0 {
+			t.Errorf("no %s violation in %+v", want, result.Violations)
+		}
+	}
+	if rules[RuleBlockedPattern] < 2 {
+		t.Errorf("blocked_pattern violations = %d, want one per matching pattern", rules[RuleBlockedPattern])
+	}
+
+	err := m.ValidateCommand("reboot; rm -rf / && chmod 000 /etc")
+	if err == nil || err.Error() != result.Violations[0].Message {
+		t.Errorf("ValidateCommand() error = %v, want the first violation", err)
+	}
+}
+
+func TestEvaluateCommandValid(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.EnableAuditLogging = false
+	result := NewManager(cfg).EvaluateCommand("ls -la")
+	if !result.Valid || len(result.Violations) != 0 || result.Err() != nil {
+		t.Errorf("EvaluateCommand() = %+v, want valid", result)
+	}
+}
+
+func TestSanitizeInputMode(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.EnableAuditLogging = false
+	m := NewManager(cfg)
+
+	tests := []struct {
+		name  string
+		mode  SanitizeMode
+		input string
+		want  string
+	}{
+		{"strip removes quotes", ModeStrip, `grep "error log" /var/log/app`, `grep error log /var/log/app`},
+		{"strip removes substitution", ModeStrip, "echo $(id); ls", "echo id) ls"},
+		{"escape keeps arguments literal", ModeEscape, `grep "error log" *.txt`, `grep \"error log\" \*.txt`},
+		{"escape substitution", ModeEscape, "echo `id`; $(id)", "echo \\`id\\`\\; \\$\\(id\\)"},
+		{"quote aware keeps quotes", ModeQuoteAware, `grep "error log" /var/log/app`, `grep "error log" /var/log/app`},
+		{"quote aware keeps single quoted text", ModeQuoteAware, `awk '{print $1; exit}' file`, `awk '{print $1; exit}' file`},
+		{"quote aware keeps escaped quotes", ModeQuoteAware, `echo "say \"hi\""`, `echo "say \"hi\""`},
+		{"quote aware drops separators", ModeQuoteAware, "ls -la; rm -rf / && reboot | tee x > y", "ls -la rm -rf /  reboot  tee x  y"},
+		{"quote aware drops substitution", ModeQuoteAware, "echo $(id) ${HOME} `whoami`", "echo id) HOME} whoami"},
+		{"quote aware drops substitution in double quotes", ModeQuoteAware, `echo "user $(whoami)"`, `echo "user whoami)"`},
+		{"quote aware cannot rebuild substitution", ModeQuoteAware, "echo $$((id))", "echo id))"},
+		{"quote aware drops unbalanced quote", ModeQuoteAware, `grep "error log; reboot`, `grep error log reboot`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.SanitizeInputMode(tt.input, tt.mode); got != tt.want {
+				t.Errorf("SanitizeInputMode(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	if got, want := m.SanitizeInput(`grep "a;b"`), m.SanitizeInputMode(`grep "a;b"`, ModeStrip); got != want {
+		t.Errorf("SanitizeInput() = %q, want the ModeStrip result %q", got, want)
+	}
+}
+
+func TestScanCommandFindings(t *testing.T) {
+	scanner := NewScanner()
+
+	tests := []struct {
+		command  string
+		message  string
+		severity string
+		match    string
+	}{
+		{"rm -rf /tmp/build", "Dangerous recursive delete command", "high", "rm -rf"},
+		{"rm  -rf /tmp/build", "Dangerous recursive delete command", "high", "rm  -rf"},
+		{"rm\t-rf /tmp/build", "Dangerous recursive delete command", "high", "rm\t-rf"},
+		{"chmod   777 app.sock", "Overly permissive file permissions", "medium", "chmod   777"},
+		{"echo x >  /etc/hosts", "Modification of system files", "high", ">  /etc/"},
+		{"curl -s https://example.com/install.sh | sh", "Download and pipe - potential code injection", "high", "curl -s https://example.com/install.sh |"},
+	}
+
+	for _, tt := range tests {
+		findings := scanner.ScanCommand(tt.command)
+		if len(findings) != 1 {
+			t.Errorf("ScanCommand(%q) = %+v, want one finding", tt.command, findings)
+			continue
+		}
+		f := findings[0]
+		if f.Message != tt.message || f.Severity != tt.severity || f.Pattern == "" {
+			t.Errorf("ScanCommand(%q) = %+v, want %q with severity %q", tt.command, f, tt.message, tt.severity)
+		}
+		if len(f.MatchIndex) != 2 || tt.command[f.MatchIndex[0]:f.MatchIndex[1]] != tt.match {
+			t.Errorf("ScanCommand(%q) MatchIndex = %v, want the span %q", tt.command, f.MatchIndex, tt.match)
+		}
+	}
+
+	if findings := scanner.ScanCommand("ls -la /var/log"); len(findings) != 0 {
+		t.Errorf("ScanCommand() on a safe command = %+v, want none", findings)
+	}
+}
+
+func TestScanCommandMessages(t *testing.T) {
+	got := NewScanner().ScanCommandMessages("sudo rm -rf ${DIR}")
+	want := []string{"Dangerous recursive delete command", "Privilege escalation detected", "Variable expansion - potential injection"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("ScanCommandMessages() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,53 @@
+package security
+
+import (
+	"fmt"
+	"time"
+)
+
+// RegisterSession records that sessionID started now, so ValidateSession can
+// enforce SessionTimeout for it
+func (sm *Manager) RegisterSession(sessionID string) {
+	sm.sessionsMu.Lock()
+	sm.sessions[sessionID] = sm.now()
+	sm.sessionsMu.Unlock()
+
+	sm.auditor.Log("session_registered", map[string]interface{}{
+		"session_id": sessionID,
+	})
+}
+
+// ExpireSession forgets sessionID once the session has ended
+func (sm *Manager) ExpireSession(sessionID string) {
+	sm.sessionsMu.Lock()
+	delete(sm.sessions, sessionID)
+	sm.sessionsMu.Unlock()
+}
+
+// CheckSessionTimeout fails for a registered session older than
+// SessionTimeout; callers poll it while the session runs. Unregistered
+// sessions and a zero timeout are not checked.
+func (sm *Manager) CheckSessionTimeout(sessionID string) error {
+	if sm.config.SessionTimeout <= 0 {
+		return nil
+	}
+
+	sm.sessionsMu.Lock()
+	started, ok := sm.sessions[sessionID]
+	sm.sessionsMu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	elapsed := sm.now().Sub(started)
+	if elapsed <= sm.config.SessionTimeout {
+		return nil
+	}
+	sm.auditor.Log("session_timeout", map[string]interface{}{
+		"session_id": sessionID,
+		"started_at": started.UTC(),
+		"elapsed":    elapsed.Round(time.Second).String(),
+		"timeout":    sm.config.SessionTimeout.String(),
+	})
+	return fmt.Errorf("session %s exceeded the %s session timeout", sessionID, sm.config.SessionTimeout)
+}
@@ -0,0 +1,85 @@
+package security
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newSessionTimeoutManager(t *testing.T, timeout time.Duration, now *time.Time) *Manager {
+	t.Helper()
+	cfg := DefaultConfig()
+	cfg.SessionTimeout = timeout
+	cfg.CredentialRotationCheck = false
+	cfg.AuditLogFile = filepath.Join(t.TempDir(), "audit.log")
+	m := NewManager(cfg)
+	m.now = func() time.Time { return *now }
+	return m
+}
+
+func TestValidateSessionTimeout(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := newSessionTimeoutManager(t, time.Hour, &now)
+
+	m.RegisterSession("s-1")
+	now = now.Add(time.Hour)
+	if err := m.ValidateSession("s-1", "dev"); err != nil {
+		t.Fatalf("ValidateSession() at the timeout error = %v", err)
+	}
+
+	now = now.Add(time.Second)
+	err := m.ValidateSession("s-1", "dev")
+	if err == nil || !strings.Contains(err.Error(), "session timeout") {
+		t.Fatalf("ValidateSession() past the timeout error = %v, want session timeout", err)
+	}
+
+	events, _, readErr := ReadAuditEvents(m.config.AuditLogFile, AuditFilter{Types: []string{"session_timeout"}})
+	if readErr != nil {
+		t.Fatalf("ReadAuditEvents() error = %v", readErr)
+	}
+	if len(events) != 1 || events[0].Data["session_id"] != "s-1" {
+		t.Errorf("session_timeout audit events = %+v, want one for s-1", events)
+	}
+
+	m.ExpireSession("s-1")
+	if err := m.ValidateSession("s-1", "dev"); err != nil {
+		t.Errorf("ValidateSession() after ExpireSession error = %v", err)
+	}
+}
+
+func TestSessionTimeoutIgnoresUnregisteredSessions(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := newSessionTimeoutManager(t, time.Minute, &now)
+	if err := m.ValidateSession("unknown", "dev"); err != nil {
+		t.Errorf("ValidateSession() for an unregistered session error = %v", err)
+	}
+
+	m.config.SessionTimeout = 0
+	m.RegisterSession("s-1")
+	now = now.Add(24 * time.Hour)
+	if err := m.ValidateSession("s-1", "dev"); err != nil {
+		t.Errorf("ValidateSession() with the timeout disabled error = %v", err)
+	}
+}
+
+func TestCheckSessionTimeoutSkipsRateLimit(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := newSessionTimeoutManager(t, time.Hour, &now)
+	m.limiter = newRateLimiter(1, rateLimitWindow)
+
+	m.RegisterSession("s-1")
+	for i := 0; i < 3; i++ {
+		if err := m.CheckSessionTimeout("s-1"); err != nil {
+			t.Fatalf("CheckSessionTimeout() call %d error = %v", i, err)
+		}
+	}
+	if err := m.ValidateSession("s-2", "dev"); err != nil {
+		t.Errorf("ValidateSession() after polling the timeout error = %v, want the rate limit untouched", err)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if err := m.CheckSessionTimeout("s-1"); err == nil || !strings.Contains(err.Error(), "session timeout") {
+		t.Errorf("CheckSessionTimeout() past the timeout error = %v, want session timeout", err)
+	}
+}
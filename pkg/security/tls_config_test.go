@@ -1,6 +1,10 @@
 package security
 
-import "testing"
+import (
+	"net/http"
+	"testing"
+	"time"
+)
 
 func TestNewTLSConfigInvalidPaths(t *testing.T) {
 	// Expect error when CA path provided but missing
@@ -15,3 +19,30 @@ func TestTLSConfigGetTLSConfig(t *testing.T) {
 		t.Fatalf("expected tls config")
 	}
 }
+
+func TestSecureHTTPClientWithOptions(t *testing.T) {
+	opts := HTTPClientOptions{
+		ConnectTimeout:  4 * time.Second,
+		RequestTimeout:  12 * time.Second,
+		KeepAlive:       6 * time.Second,
+		IdleConnTimeout: 20 * time.Second,
+	}
+	client := SecureHTTPClientWithOptions(opts, nil)
+
+	if client.Timeout != 12*time.Second {
+		t.Errorf("client timeout = %v, want 12s", client.Timeout)
+	}
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected *http.Transport, got %T", client.Transport)
+	}
+	if transport.IdleConnTimeout != 20*time.Second {
+		t.Errorf("idle timeout = %v, want 20s", transport.IdleConnTimeout)
+	}
+	if transport.TLSHandshakeTimeout != 4*time.Second {
+		t.Errorf("handshake timeout = %v, want 4s", transport.TLSHandshakeTimeout)
+	}
+	if transport.Proxy == nil {
+		t.Error("expected proxy settings from environment")
+	}
+}
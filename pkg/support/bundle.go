@@ -0,0 +1,54 @@
+// Package support assembles diagnostic bundles that users attach to issue reports.
+package support
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/johnlam90/aws-ssm/pkg/config"
+)
+
+// Redacted replaces secret values in bundle files
+const Redacted = "***REDACTED***"
+
+// File is one file of a support bundle
+type File struct {
+	Name    string
+	Content []byte
+}
+
+var (
+	// accessKeyPattern matches AWS access key IDs
+	accessKeyPattern = regexp.MustCompile(`\b(AKIA|ASIA)[A-Z0-9]{16}\b`)
+	// secretAssignmentPattern matches "key: value", "key=value" and JSON "key": "value"
+	// pairs whose key names a secret
+	secretAssignmentPattern = regexp.MustCompile(`(?i)("?[a-z0-9_.-]*(?:secret|password|passwd|token|credential|private[_-]?key|access[_-]?key)[a-z0-9_.-]*"?\s*[:=]\s*)("[^"]*"|'[^']*'|[^\s,}]+)`)
+)
+
+// Redact masks AWS access key IDs and the values assigned to secret-looking keys
+func Redact(text string) string {
+	text = secretAssignmentPattern.ReplaceAllString(text, "${1}"+Redacted)
+	return accessKeyPattern.ReplaceAllStringFunc(text, config.MaskSecret)
+}
+
+// WriteZip writes files to w as a zip archive, redacting every file's content
+func WriteZip(w io.Writer, files []File, modified time.Time) error {
+	archive := zip.NewWriter(w)
+	for _, f := range files {
+		header := &zip.FileHeader{Name: f.Name, Method: zip.Deflate, Modified: modified}
+		entry, err := archive.CreateHeader(header)
+		if err != nil {
+			return fmt.Errorf("failed to add %s to bundle: %w", f.Name, err)
+		}
+		if _, err := io.WriteString(entry, Redact(string(f.Content))); err != nil {
+			return fmt.Errorf("failed to write %s to bundle: %w", f.Name, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to finish bundle: %w", err)
+	}
+	return nil
+}
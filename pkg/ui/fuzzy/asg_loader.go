@@ -5,9 +5,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	awsconfig "github.com/aws/aws-sdk-go-v2/aws"
+	appconfig "github.com/johnlam90/aws-ssm/pkg/config"
 	fuzzyfinder "github.com/ktr0731/go-fuzzyfinder"
 	"golang.org/x/sync/errgroup"
 )
@@ -155,15 +157,17 @@ func (l *AWSASGLoader) convertToASGInfo(asgDetail *ASGDetail) ASGInfo {
 
 // ASGFinder handles ASG selection
 type ASGFinder struct {
-	loader ASGLoader
-	colors ColorManager
+	loader  ASGLoader
+	colors  ColorManager
+	tagMask appconfig.TagMask
 }
 
 // NewASGFinder creates a new ASG finder
-func NewASGFinder(loader ASGLoader, colors ColorManager) *ASGFinder {
+func NewASGFinder(loader ASGLoader, colors ColorManager, tagMask appconfig.TagMask) *ASGFinder {
 	return &ASGFinder{
-		loader: loader,
-		colors: colors,
+		loader:  loader,
+		colors:  colors,
+		tagMask: tagMask,
 	}
 }
 
@@ -180,7 +184,7 @@ func (f *ASGFinder) SelectASGInteractive(ctx context.Context) (*ASGInfo, error)
 	}
 
 	// Use fuzzyfinder to select
-	fuzzyfinder := NewASGFuzzyFinder(asgs, f.colors)
+	fuzzyfinder := NewASGFuzzyFinder(asgs, f.colors, f.tagMask)
 	selectedIndex, err := fuzzyfinder.Select(ctx)
 	if err != nil {
 		return nil, err
@@ -195,29 +199,42 @@ func (f *ASGFinder) SelectASGInteractive(ctx context.Context) (*ASGInfo, error)
 
 // ASGFuzzyFinder handles the actual fuzzy finding for ASGs
 type ASGFuzzyFinder struct {
-	asgs   []ASGInfo
-	colors ColorManager
+	asgs    []ASGInfo
+	colors  ColorManager
+	tagMask appconfig.TagMask
+	columns ColumnSpec[ASGInfo]
 }
 
 // NewASGFuzzyFinder creates a new ASG fuzzy finder
-func NewASGFuzzyFinder(asgs []ASGInfo, colors ColorManager) *ASGFuzzyFinder {
+func NewASGFuzzyFinder(asgs []ASGInfo, colors ColorManager, tagMask appconfig.TagMask) *ASGFuzzyFinder {
 	return &ASGFuzzyFinder{
-		asgs:   asgs,
-		colors: colors,
+		asgs:    asgs,
+		colors:  colors,
+		tagMask: tagMask,
+		columns: DefaultASGColumns(),
 	}
 }
 
+// WithColumns replaces the columns shown for each ASG
+func (f *ASGFuzzyFinder) WithColumns(columns ColumnSpec[ASGInfo]) *ASGFuzzyFinder {
+	f.columns = columns
+	return f
+}
+
 // Select displays the fuzzy finder and returns the selected ASG index
 func (f *ASGFuzzyFinder) Select(ctx context.Context) (int, error) {
 	// Create preview renderer
-	renderer := NewASGPreviewRenderer(f.colors)
+	renderer := NewASGPreviewRenderer(f.colors, f.tagMask)
+
+	table := f.columns.Render(f.asgs)
 
 	// Use fuzzyfinder to select with context support for Ctrl+C handling
 	selectedIndex, err := fuzzyfinder.Find(
 		f.asgs,
 		func(i int) string {
-			return f.formatASGRow(f.asgs[i])
+			return table.Rows[i]
 		},
+		fuzzyfinder.WithHeader(table.Header),
 		fuzzyfinder.WithPreviewWindow(func(i, width, height int) string {
 			if i < 0 || i >= len(f.asgs) {
 				return "Select an Auto Scaling Group to view details"
@@ -238,31 +255,27 @@ func (f *ASGFuzzyFinder) Select(ctx context.Context) (int, error) {
 	return selectedIndex, nil
 }
 
-// formatASGRow formats an ASG for display in the fuzzy finder
-// Format matches v0.2.0: name | desired/min/max | current | health-check
-func (f *ASGFuzzyFinder) formatASGRow(asg ASGInfo) string {
-	name := asg.Name
-	if name == "" {
-		name = "(no name)"
-	}
-
-	// Truncate name to fit nicely
-	if len(name) > 40 {
-		name = name[:37] + "..."
-	}
-
-	// Format: Name | Desired/Min/Max | Current | Health Check
-	scaling := fmt.Sprintf("%d/%d/%d", asg.DesiredCapacity, asg.MinSize, asg.MaxSize)
-
-	healthCheck := asg.HealthCheckType
-	if healthCheck == "" {
-		healthCheck = "EC2"
+// DefaultASGColumns returns the ASG finder columns:
+// name | desired/min/max | current | health check
+func DefaultASGColumns() ColumnSpec[ASGInfo] {
+	return ColumnSpec[ASGInfo]{
+		{Header: "NAME", MaxWidth: 40, Value: func(asg ASGInfo) string {
+			if asg.Name == "" {
+				return "(no name)"
+			}
+			return asg.Name
+		}},
+		{Header: "DESIRED/MIN/MAX", Value: func(asg ASGInfo) string {
+			return fmt.Sprintf("%d/%d/%d", asg.DesiredCapacity, asg.MinSize, asg.MaxSize)
+		}},
+		{Header: "CURRENT", Value: func(asg ASGInfo) string {
+			return strconv.Itoa(int(asg.CurrentSize))
+		}},
+		{Header: "HEALTH CHECK", Value: func(asg ASGInfo) string {
+			if asg.HealthCheckType == "" {
+				return "EC2"
+			}
+			return asg.HealthCheckType
+		}},
 	}
-
-	return fmt.Sprintf("%-40s | %-11s | %-7d | %s",
-		name,
-		scaling,
-		asg.CurrentSize,
-		healthCheck,
-	)
 }
@@ -4,16 +4,19 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	appconfig "github.com/johnlam90/aws-ssm/pkg/config"
 )
 
 // ASGPreviewRenderer renders ASG preview
 type ASGPreviewRenderer struct {
-	colors ColorManager
+	colors  ColorManager
+	tagMask appconfig.TagMask
 }
 
 // NewASGPreviewRenderer creates a new ASG preview renderer
-func NewASGPreviewRenderer(colors ColorManager) *ASGPreviewRenderer {
-	return &ASGPreviewRenderer{colors: colors}
+func NewASGPreviewRenderer(colors ColorManager, tagMask appconfig.TagMask) *ASGPreviewRenderer {
+	return &ASGPreviewRenderer{colors: colors, tagMask: tagMask}
 }
 
 // Render renders the preview for an Auto Scaling Group
@@ -103,7 +106,7 @@ func (r *ASGPreviewRenderer) Render(asg *ASGInfo, width, _ int) string {
 		preview.WriteString(r.colors.BoldColor("Tags:"))
 		preview.WriteString("\n")
 		for key, value := range asg.Tags {
-			fmt.Fprintf(&preview, "  %s\n", r.colors.TagColor(key, value))
+			fmt.Fprintf(&preview, "  %s\n", r.colors.TagColor(key, r.tagMask.Value(key, value)))
 		}
 	}
 
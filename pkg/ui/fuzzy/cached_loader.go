@@ -53,7 +53,7 @@ func (c *CachedInstanceLoader) LoadInstances(ctx context.Context, query *SearchQ
 	}
 
 	// Store in cache
-	if err := c.cacheService.Set(cacheKey, instances, c.region, c.queryToString(query)); err != nil {
+	if err := c.cacheService.SetWithResourceType(cacheKey, instances, cache.ResourceEC2, c.region, c.queryToString(query)); err != nil {
 		// Log error but don't fail - caching is optional
 		fmt.Printf("Warning: failed to cache instances: %v\n", err)
 	}
@@ -86,7 +86,7 @@ func (c *CachedInstanceLoader) LoadInstance(ctx context.Context, instanceID stri
 	}
 
 	// Store in cache
-	if err := c.cacheService.Set(cacheKey, instance, c.region, instanceID); err != nil {
+	if err := c.cacheService.SetWithResourceType(cacheKey, instance, cache.ResourceEC2, c.region, instanceID); err != nil {
 		// Log error but don't fail - caching is optional
 		fmt.Printf("Warning: failed to cache instance: %v\n", err)
 	}
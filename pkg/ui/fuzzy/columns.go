@@ -0,0 +1,75 @@
+package fuzzy
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// columnSeparator separates the columns of a finder row
+const columnSeparator = " | "
+
+// Column describes one column of finder rows
+type Column[T any] struct {
+	Header   string
+	Value    func(T) string
+	MaxWidth int // Longer values are truncated with "..."; 0 means no limit
+}
+
+// ColumnSpec lists the columns shown for each finder item, left to right
+type ColumnSpec[T any] []Column[T]
+
+// Table holds finder rows rendered from a ColumnSpec, with a matching header
+type Table struct {
+	Header string
+	Rows   []string
+}
+
+// Render formats items with every column padded to its widest value, so rows
+// line up in the finder. Each row keeps every column's text, which lets the
+// finder's search match on any of them.
+func (s ColumnSpec[T]) Render(items []T) Table {
+	cells := make([][]string, len(items))
+	widths := make([]int, len(s))
+	for c, col := range s {
+		widths[c] = runewidth.StringWidth(col.Header)
+	}
+	for i, item := range items {
+		cells[i] = make([]string, len(s))
+		for c, col := range s {
+			value := col.Value(item)
+			if col.MaxWidth > 0 && runewidth.StringWidth(value) > col.MaxWidth {
+				value = runewidth.Truncate(value, col.MaxWidth, "...")
+			}
+			cells[i][c] = value
+			widths[c] = max(widths[c], runewidth.StringWidth(value))
+		}
+	}
+
+	headers := make([]string, len(s))
+	for c, col := range s {
+		headers[c] = col.Header
+	}
+	table := Table{Header: joinColumns(headers, widths), Rows: make([]string, len(items))}
+	for i := range items {
+		table.Rows[i] = joinColumns(cells[i], widths)
+	}
+	return table
+}
+
+// joinColumns pads each value to its column width; the last column is not
+// padded so rows carry no trailing spaces
+func joinColumns(values []string, widths []int) string {
+	var b strings.Builder
+	for c, value := range values {
+		if c > 0 {
+			b.WriteString(columnSeparator)
+		}
+		if c == len(values)-1 {
+			b.WriteString(value)
+			break
+		}
+		b.WriteString(runewidth.FillRight(value, widths[c]))
+	}
+	return b.String()
+}
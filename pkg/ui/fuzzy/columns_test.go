@@ -0,0 +1,127 @@
+package fuzzy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ktr0731/go-fuzzyfinder/matching"
+	"github.com/mattn/go-runewidth"
+)
+
+var columnTestNodeGroups = []NodeGroupInfo{
+	{Name: "general", Status: "ACTIVE", DesiredSize: 3, MinSize: 1, MaxSize: 5, InstanceTypes: []string{"m5.large"}},
+	{Name: "gpu-workers-long-name", Status: "UPDATING", DesiredSize: 10, MinSize: 0, MaxSize: 20, InstanceTypes: []string{"p3.2xlarge", "p3.8xlarge"}},
+	{Name: "spot", Status: "", DesiredSize: 0, MinSize: 0, MaxSize: 2},
+}
+
+// separatorOffsets returns the display columns at which each separator starts
+func separatorOffsets(row string) []int {
+	var offsets []int
+	rest := row
+	consumed := 0
+	for {
+		idx := strings.Index(rest, columnSeparator)
+		if idx < 0 {
+			return offsets
+		}
+		consumed += runewidth.StringWidth(rest[:idx])
+		offsets = append(offsets, consumed)
+		consumed += len(columnSeparator)
+		rest = rest[idx+len(columnSeparator):]
+	}
+}
+
+func TestColumnSpecRendersAlignedColumns(t *testing.T) {
+	table := DefaultNodeGroupColumns().Render(columnTestNodeGroups)
+
+	if len(table.Rows) != len(columnTestNodeGroups) {
+		t.Fatalf("got %d rows, want %d", len(table.Rows), len(columnTestNodeGroups))
+	}
+	want := separatorOffsets(table.Header)
+	if len(want) != 3 {
+		t.Fatalf("header %q has %d separators, want 3", table.Header, len(want))
+	}
+	for _, row := range table.Rows {
+		if got := separatorOffsets(row); !equalInts(got, want) {
+			t.Errorf("row %q separators at %v, want %v (header %q)", row, got, want, table.Header)
+		}
+		if strings.HasSuffix(row, " ") {
+			t.Errorf("row %q has trailing spaces", row)
+		}
+	}
+
+	for _, want := range []string{"UPDATING", "10/0/20", "p3.2xlarge +1", "UNKNOWN", "Launch Template"} {
+		if !strings.Contains(strings.Join(table.Rows, "\n"), want) {
+			t.Errorf("rows missing %q:\n%s", want, strings.Join(table.Rows, "\n"))
+		}
+	}
+}
+
+func TestColumnSpecTruncatesToMaxWidth(t *testing.T) {
+	spec := ColumnSpec[string]{
+		{Header: "NAME", MaxWidth: 8, Value: func(s string) string { return s }},
+		{Header: "LEN", Value: func(s string) string { return "x" }},
+	}
+	table := spec.Render([]string{"short", "a-very-long-value", "日本語の名前です"})
+
+	for _, row := range table.Rows {
+		name := strings.SplitN(row, columnSeparator, 2)[0]
+		if w := runewidth.StringWidth(name); w != 8 {
+			t.Errorf("name cell %q has width %d, want 8", name, w)
+		}
+	}
+	if !strings.HasPrefix(table.Rows[1], "a-ver...") {
+		t.Errorf("long value row = %q, want truncated with ...", table.Rows[1])
+	}
+}
+
+func TestColumnRowsMatchSearchOnAnyColumn(t *testing.T) {
+	table := DefaultNodeGroupColumns().Render(columnTestNodeGroups)
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"general", 0},  // name column
+		{"UPDATING", 1}, // status column
+		{"0/0/2", 2},    // scaling column
+		{"m5.large", 0}, // instance type column
+	}
+	for _, tt := range tests {
+		matches := matching.FindAll(tt.query, table.Rows)
+		if len(matches) == 0 || matches[0].Idx != tt.want {
+			t.Errorf("query %q matched %+v, want row %d first", tt.query, matches, tt.want)
+		}
+	}
+}
+
+func TestInstanceColumnsFollowConfig(t *testing.T) {
+	instances := []Instance{
+		{Name: "web", InstanceID: "i-0123456789abcdef0", PrivateIP: "10.0.0.1", State: "running", InstanceType: "t3.micro", AvailabilityZone: "us-east-1a"},
+	}
+
+	defaults := InstanceColumns(ColumnConfig{}).Render(instances)
+	if defaults.Header != "NAME | INSTANCE ID         | PRIVATE IP | STATE" {
+		t.Errorf("default header = %q", defaults.Header)
+	}
+
+	custom := InstanceColumns(ColumnConfig{Name: true, Type: true, AZ: true}).Render(instances)
+	if custom.Rows[0] != "web  | t3.micro | us-east-1a" {
+		t.Errorf("custom row = %q", custom.Rows[0])
+	}
+	if matches := matching.FindAll("us-east-1a", custom.Rows); len(matches) != 1 {
+		t.Errorf("AZ search matched %d rows, want 1", len(matches))
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	appconfig "github.com/johnlam90/aws-ssm/pkg/config"
 	fuzzyfinder "github.com/ktr0731/go-fuzzyfinder"
 	"golang.org/x/sync/errgroup"
 )
@@ -334,7 +335,7 @@ func (f *EKSFinder) SelectClusterInteractive(ctx context.Context) (*EKSCluster,
 	}
 
 	// Use fuzzyfinder to select (pass loader for lazy loading)
-	fuzzyfinder := NewEKSFuzzyFinder(clusters, f.colors, f.loader)
+	fuzzyfinder := NewEKSFuzzyFinder(clusters, f.colors, f.loader, appconfig.NewTagMask(f.config.SensitiveTags))
 	selectedIndex, err := fuzzyfinder.Select(ctx)
 	if err != nil {
 		return nil, err
@@ -352,21 +353,23 @@ type EKSFuzzyFinder struct {
 	clusters []EKSCluster
 	colors   ColorManager
 	loader   EKSClusterLoader // For lazy loading cluster details
+	tagMask  appconfig.TagMask
 }
 
 // NewEKSFuzzyFinder creates a new EKS fuzzy finder
-func NewEKSFuzzyFinder(clusters []EKSCluster, colors ColorManager, loader EKSClusterLoader) *EKSFuzzyFinder {
+func NewEKSFuzzyFinder(clusters []EKSCluster, colors ColorManager, loader EKSClusterLoader, tagMask appconfig.TagMask) *EKSFuzzyFinder {
 	return &EKSFuzzyFinder{
 		clusters: clusters,
 		colors:   colors,
 		loader:   loader,
+		tagMask:  tagMask,
 	}
 }
 
 // Select displays the fuzzy finder and returns the selected cluster index
 func (f *EKSFuzzyFinder) Select(ctx context.Context) (int, error) {
 	// Create preview renderer with loader for lazy loading
-	renderer := NewEKSPreviewRenderer(f.colors, f.loader)
+	renderer := NewEKSPreviewRenderer(f.colors, f.loader, f.tagMask)
 
 	// Use fuzzyfinder to select with context support for Ctrl+C handling
 	selectedIndex, err := fuzzyfinder.Find(
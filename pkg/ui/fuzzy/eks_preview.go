@@ -4,19 +4,23 @@ import (
 	"context"
 	"fmt"
 	"strings"
+
+	appconfig "github.com/johnlam90/aws-ssm/pkg/config"
 )
 
 // EKSPreviewRenderer handles rendering EKS cluster preview information
 type EKSPreviewRenderer struct {
-	colors ColorManager
-	loader EKSClusterLoader // For lazy loading full cluster details
+	colors  ColorManager
+	loader  EKSClusterLoader // For lazy loading full cluster details
+	tagMask appconfig.TagMask
 }
 
 // NewEKSPreviewRenderer creates a new EKS preview renderer
-func NewEKSPreviewRenderer(colors ColorManager, loader EKSClusterLoader) *EKSPreviewRenderer {
+func NewEKSPreviewRenderer(colors ColorManager, loader EKSClusterLoader, tagMask appconfig.TagMask) *EKSPreviewRenderer {
 	return &EKSPreviewRenderer{
-		colors: colors,
-		loader: loader,
+		colors:  colors,
+		loader:  loader,
+		tagMask: tagMask,
 	}
 }
 
@@ -114,7 +118,7 @@ func (r *EKSPreviewRenderer) Render(cluster *EKSCluster, width, _ int) string {
 		preview.WriteString(r.colors.BoldColor("Tags:"))
 		preview.WriteString("\n")
 		for key, value := range cluster.Tags {
-			fmt.Fprintf(&preview, "  %s\n", r.colors.TagColor(key, value))
+			fmt.Fprintf(&preview, "  %s\n", r.colors.TagColor(key, r.tagMask.Value(key, value)))
 		}
 	}
 
@@ -184,7 +188,7 @@ func (r *EKSPreviewRenderer) RenderDetailed(cluster *EKSCluster, nodeGroups []st
 		preview.WriteString(r.colors.BoldColor("Tags:"))
 		preview.WriteString("\n")
 		for key, value := range cluster.Tags {
-			fmt.Fprintf(&preview, "  %s\n", r.colors.TagColor(key, value))
+			fmt.Fprintf(&preview, "  %s\n", r.colors.TagColor(key, r.tagMask.Value(key, value)))
 		}
 	}
 
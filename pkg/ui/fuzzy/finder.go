@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 
+	appconfig "github.com/johnlam90/aws-ssm/pkg/config"
 	fuzzyfinder "github.com/ktr0731/go-fuzzyfinder"
 )
 
@@ -16,13 +17,25 @@ type EnhancedFinder struct {
 	renderer PreviewRenderer
 	colors   ColorManager
 	config   Config
+	columns  ColumnSpec[Instance]
 	helpText string // Cached help text to avoid rebuilding every frame
 }
 
 // NewEnhancedFinder creates a new enhanced fuzzy finder
 func NewEnhancedFinder(loader InstanceLoader, config Config) *EnhancedFinder {
 	colors := NewDefaultColorManager(config.NoColor)
-	renderer := NewDefaultPreviewRenderer(colors)
+	tagMask := appconfig.NewTagMask(config.SensitiveTags)
+	renderer := NewDefaultPreviewRenderer(colors, tagMask)
+
+	columns := InstanceColumns(config.Columns)
+	if config.LabelTemplate != "" {
+		label, err := ParseLabelTemplate(config.LabelTemplate, tagMask)
+		if err != nil {
+			fmt.Printf("Warning: ignoring interactive label_template: %v\n", err)
+		} else {
+			columns = withLabel(columns, label)
+		}
+	}
 
 	state := &StateManager{
 		Config:        config,
@@ -58,10 +71,17 @@ func NewEnhancedFinder(loader InstanceLoader, config Config) *EnhancedFinder {
 		renderer: renderer,
 		colors:   colors,
 		config:   config,
+		columns:  columns,
 		helpText: strings.Join(help, "\n"),
 	}
 }
 
+// WithColumns replaces the columns shown for each instance
+func (f *EnhancedFinder) WithColumns(columns ColumnSpec[Instance]) *EnhancedFinder {
+	f.columns = columns
+	return f
+}
+
 // SelectInstanceInteractive displays the enhanced interactive fuzzy finder
 func (f *EnhancedFinder) SelectInstanceInteractive(ctx context.Context) ([]Instance, error) {
 	// Initialize state with empty query if needed
@@ -81,11 +101,14 @@ func (f *EnhancedFinder) SelectInstanceInteractive(ctx context.Context) ([]Insta
 	// Apply initial sort
 	f.sortInstances()
 
+	table := f.columns.Render(f.state.Filtered)
+
 	selectedIndices, err := fuzzyfinder.FindMulti(
 		f.state.Filtered,
 		func(i int) string {
-			return f.formatInstanceRow(f.state.Filtered[i])
+			return table.Rows[i]
 		},
+		fuzzyfinder.WithHeader(table.Header),
 		fuzzyfinder.WithPreviewWindow(func(i, width, height int) string {
 			if i < 0 || i >= len(f.state.Filtered) {
 				return f.formatHelp()
@@ -122,37 +145,71 @@ func (f *EnhancedFinder) loadAllInstances(ctx context.Context, query *SearchQuer
 	return instances, nil
 }
 
-// formatInstanceRow formats an instance for display in the list
-// Format matches v0.2.0: name | instance-id | private-ip | state
-func (f *EnhancedFinder) formatInstanceRow(instance Instance) string {
-	name := instance.Name
-	if name == "" {
-		name = "(no name)"
+// InstanceColumns returns the instance finder columns enabled in cfg, in the
+// order name | instance-id | private-ip | state | type | az. With no column
+// enabled the default columns are used.
+func InstanceColumns(cfg ColumnConfig) ColumnSpec[Instance] {
+	if cfg == (ColumnConfig{}) {
+		cfg = DefaultColumnConfig()
 	}
 
-	// Truncate name to fit nicely (30 chars like v0.2.0)
-	name = f.truncateString(name, 30)
-
-	// Format: name | instance-id | private-ip | state
-	// Using the same spacing as v0.2.0 for clean alignment
-	return fmt.Sprintf("%-30s | %-19s | %-15s | %s",
-		name,
-		instance.InstanceID,
-		instance.PrivateIP,
-		instance.State,
-	)
+	var columns ColumnSpec[Instance]
+	if cfg.Name {
+		columns = append(columns, Column[Instance]{Header: "NAME", MaxWidth: 30, Value: func(inst Instance) string {
+			if inst.Name == "" {
+				return "(no name)"
+			}
+			return inst.Name
+		}})
+	}
+	if cfg.InstanceID {
+		columns = append(columns, Column[Instance]{Header: "INSTANCE ID", Value: func(inst Instance) string { return inst.InstanceID }})
+	}
+	if cfg.PrivateIP {
+		columns = append(columns, Column[Instance]{Header: "PRIVATE IP", Value: func(inst Instance) string { return inst.PrivateIP }})
+	}
+	if cfg.State {
+		columns = append(columns, Column[Instance]{Header: "STATE", Value: func(inst Instance) string { return inst.State }})
+	}
+	if cfg.Type {
+		columns = append(columns, Column[Instance]{Header: "TYPE", Value: func(inst Instance) string { return inst.InstanceType }})
+	}
+	if cfg.AZ {
+		columns = append(columns, Column[Instance]{Header: "AZ", Value: func(inst Instance) string { return inst.AvailabilityZone }})
+	}
+	return columns
 }
 
-// truncateString truncates a string to the specified length (rune-aware to handle UTF-8)
-func (f *EnhancedFinder) truncateString(s string, maxLen int) string {
-	runes := []rune(s)
-	if len(runes) <= maxLen {
-		return s
+// MultipleMatchColumns returns the columns used to disambiguate instances
+// matching one identifier: instance-id | name | state | type | private-ip | az | launched
+func MultipleMatchColumns() ColumnSpec[Instance] {
+	return ColumnSpec[Instance]{
+		{Header: "INSTANCE ID", Value: func(inst Instance) string { return inst.InstanceID }},
+		{Header: "NAME", MaxWidth: 30, Value: func(inst Instance) string {
+			if inst.Name == "" {
+				return "(no name)"
+			}
+			return inst.Name
+		}},
+		{Header: "STATE", Value: func(inst Instance) string { return valueOrDash(inst.State) }},
+		{Header: "TYPE", Value: func(inst Instance) string { return valueOrDash(inst.InstanceType) }},
+		{Header: "PRIVATE IP", Value: func(inst Instance) string { return valueOrDash(inst.PrivateIP) }},
+		{Header: "AZ", Value: func(inst Instance) string { return valueOrDash(inst.AvailabilityZone) }},
+		{Header: "LAUNCHED", Value: func(inst Instance) string {
+			if inst.LaunchTime.IsZero() {
+				return "-"
+			}
+			return inst.LaunchTime.UTC().Format("2006-01-02 15:04")
+		}},
 	}
-	if maxLen <= 3 {
-		return string(runes[:maxLen])
+}
+
+// valueOrDash returns "-" for empty column values
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
 	}
-	return string(runes[:maxLen-3]) + "..."
+	return value
 }
 
 // formatPrompt formats the search prompt
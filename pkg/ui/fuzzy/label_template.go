@@ -0,0 +1,112 @@
+package fuzzy
+
+import (
+	"fmt"
+	"strings"
+
+	appconfig "github.com/johnlam90/aws-ssm/pkg/config"
+)
+
+// labelTemplateFields lists the instance fields a label template may reference
+var labelTemplateFields = map[string]func(Instance) string{
+	"name": func(i Instance) string {
+		if i.Name == "" {
+			return "(no name)"
+		}
+		return i.Name
+	},
+	"instance-id": func(i Instance) string { return i.InstanceID },
+	"state":       func(i Instance) string { return i.State },
+	"private-ip":  func(i Instance) string { return i.PrivateIP },
+	"public-ip":   func(i Instance) string { return i.PublicIP },
+	"private-dns": func(i Instance) string { return i.PrivateDNS },
+	"public-dns":  func(i Instance) string { return i.PublicDNS },
+	"type":        func(i Instance) string { return i.InstanceType },
+	"az":          func(i Instance) string { return i.AvailabilityZone },
+	"profile":     func(i Instance) string { return i.InstanceProfile },
+}
+
+// labelSegment is either literal text or a field placeholder
+type labelSegment struct {
+	literal string
+	field   string // field name, or "tag:<Key>"
+}
+
+// LabelTemplate renders the instance finder label from a template such as
+// "{name} [{tag:Environment}]". The label replaces the NAME column, so the
+// finder's search matches against the templated text.
+type LabelTemplate struct {
+	segments []labelSegment
+	tagMask  appconfig.TagMask
+}
+
+// ParseLabelTemplate parses a label template, rejecting unknown fields.
+// Sensitive tags in mask are rendered as ***.
+func ParseLabelTemplate(template string, mask appconfig.TagMask) (*LabelTemplate, error) {
+	if strings.TrimSpace(template) == "" {
+		return nil, fmt.Errorf("label template is empty")
+	}
+
+	var segments []labelSegment
+	hasField := false
+	rest := template
+	for rest != "" {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			segments = append(segments, labelSegment{literal: rest})
+			break
+		}
+		if open > 0 {
+			segments = append(segments, labelSegment{literal: rest[:open]})
+		}
+		closing := strings.IndexByte(rest[open:], '}')
+		if closing < 0 {
+			return nil, fmt.Errorf("unclosed placeholder in label template %q", template)
+		}
+		field := rest[open+1 : open+closing]
+		if key, ok := strings.CutPrefix(field, "tag:"); ok {
+			if key == "" {
+				return nil, fmt.Errorf("tag placeholder {%s} needs a key, e.g. {tag:Environment}", field)
+			}
+		} else if _, ok := labelTemplateFields[field]; !ok {
+			return nil, fmt.Errorf("unknown label template field %q", field)
+		}
+		segments = append(segments, labelSegment{field: field})
+		hasField = true
+		rest = rest[open+closing+1:]
+	}
+
+	if !hasField {
+		return nil, fmt.Errorf("label template %q references no fields", template)
+	}
+	return &LabelTemplate{segments: segments, tagMask: mask}, nil
+}
+
+// Render returns the label of inst; missing tags render as empty text
+func (t *LabelTemplate) Render(inst Instance) string {
+	var b strings.Builder
+	for _, segment := range t.segments {
+		if segment.field == "" {
+			b.WriteString(segment.literal)
+		} else if key, ok := strings.CutPrefix(segment.field, "tag:"); ok {
+			if value := inst.Tags[key]; value != "" {
+				b.WriteString(t.tagMask.Value(key, value))
+			}
+		} else {
+			b.WriteString(labelTemplateFields[segment.field](inst))
+		}
+	}
+	return b.String()
+}
+
+// withLabel returns columns with the NAME column replaced by a LABEL column
+// rendered from label, placed first
+func withLabel(columns ColumnSpec[Instance], label *LabelTemplate) ColumnSpec[Instance] {
+	labelled := ColumnSpec[Instance]{{Header: "LABEL", MaxWidth: 50, Value: label.Render}}
+	for _, col := range columns {
+		if col.Header != "NAME" {
+			labelled = append(labelled, col)
+		}
+	}
+	return labelled
+}
@@ -0,0 +1,63 @@
+package fuzzy
+
+import (
+	"strings"
+	"testing"
+
+	appconfig "github.com/johnlam90/aws-ssm/pkg/config"
+	"github.com/ktr0731/go-fuzzyfinder/matching"
+)
+
+var labelTestInstances = []Instance{
+	{Name: "web", InstanceID: "i-0aaa", PrivateIP: "10.0.0.1", State: "running", Tags: map[string]string{"Environment": "production", "Owner": "alice"}},
+	{Name: "web", InstanceID: "i-0bbb", PrivateIP: "10.0.0.2", State: "running", Tags: map[string]string{"Environment": "staging"}},
+	{InstanceID: "i-0ccc", PrivateIP: "10.0.0.3", State: "stopped"},
+}
+
+func TestFinderLabelsFollowTemplate(t *testing.T) {
+	finder := NewEnhancedFinder(nil, Config{LabelTemplate: "{name} [{tag:Environment}]"})
+	table := finder.columns.Render(labelTestInstances)
+
+	if !strings.HasPrefix(table.Header, "LABEL") || strings.Contains(table.Header, "NAME") {
+		t.Errorf("header = %q, want the LABEL column in place of NAME", table.Header)
+	}
+	for i, want := range []string{"web [production]", "web [staging]", "(no name) []"} {
+		if label := strings.SplitN(table.Rows[i], columnSeparator, 2)[0]; strings.TrimSpace(label) != want {
+			t.Errorf("row %d label = %q, want %q", i, label, want)
+		}
+	}
+
+	for query, want := range map[string]int{"production": 0, "staging": 1} {
+		if matches := matching.FindAll(query, table.Rows); len(matches) != 1 || matches[0].Idx != want {
+			t.Errorf("search for %q matched %+v, want only row %d", query, matches, want)
+		}
+	}
+}
+
+func TestLabelTemplateMasksSensitiveTags(t *testing.T) {
+	label, err := ParseLabelTemplate("{instance-id} {tag:Owner}", appconfig.NewTagMask([]string{"owner"}))
+	if err != nil {
+		t.Fatalf("ParseLabelTemplate() error = %v", err)
+	}
+	if got := label.Render(labelTestInstances[0]); got != "i-0aaa "+appconfig.MaskedTagValue {
+		t.Errorf("Render() = %q, want the owner tag masked", got)
+	}
+	if got := label.Render(labelTestInstances[1]); got != "i-0bbb " {
+		t.Errorf("Render() = %q, want a missing tag left empty", got)
+	}
+}
+
+func TestParseLabelTemplateErrors(t *testing.T) {
+	for _, template := range []string{"", "no fields", "{bogus}", "{tag:}", "{name"} {
+		if _, err := ParseLabelTemplate(template, appconfig.TagMask{}); err == nil {
+			t.Errorf("ParseLabelTemplate(%q) succeeded, want an error", template)
+		}
+	}
+}
+
+func TestFinderIgnoresInvalidLabelTemplate(t *testing.T) {
+	finder := NewEnhancedFinder(nil, Config{LabelTemplate: "{bogus}"})
+	if header := finder.columns.Render(labelTestInstances).Header; !strings.HasPrefix(header, "NAME") {
+		t.Errorf("header = %q, want the default columns", header)
+	}
+}
@@ -0,0 +1,79 @@
+package fuzzy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NodeGroupFilter narrows node groups by capacity type and instance family.
+// Empty fields match every node group.
+type NodeGroupFilter struct {
+	CapacityType   string // ON_DEMAND, SPOT or CAPACITY_BLOCK
+	InstanceFamily string // e.g. "m5" matches m5.large and m5.2xlarge
+}
+
+// ParseCapacityType normalizes a capacity type given on the command line,
+// accepting forms such as "spot" and "on-demand"
+func ParseCapacityType(value string) (string, error) {
+	normalized := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(value), "-", "_"))
+	switch normalized {
+	case "", "ON_DEMAND", "SPOT", "CAPACITY_BLOCK":
+		return normalized, nil
+	default:
+		return "", fmt.Errorf("invalid capacity type %q (expected ON_DEMAND, SPOT or CAPACITY_BLOCK)", value)
+	}
+}
+
+// IsEmpty reports whether the filter matches every node group
+func (f NodeGroupFilter) IsEmpty() bool {
+	return f.CapacityType == "" && f.InstanceFamily == ""
+}
+
+// Matches reports whether ng passes the filter. A node group matches the
+// instance family when any of its instance types belongs to it.
+func (f NodeGroupFilter) Matches(ng NodeGroupInfo) bool {
+	if f.CapacityType != "" && !strings.EqualFold(ng.CapacityType, f.CapacityType) {
+		return false
+	}
+	if f.InstanceFamily == "" {
+		return true
+	}
+	for _, instanceType := range ng.InstanceTypes {
+		if strings.EqualFold(instanceFamily(instanceType), f.InstanceFamily) {
+			return true
+		}
+	}
+	return false
+}
+
+// String describes the filter for messages, e.g. "capacity type SPOT, instance family m5"
+func (f NodeGroupFilter) String() string {
+	var parts []string
+	if f.CapacityType != "" {
+		parts = append(parts, "capacity type "+f.CapacityType)
+	}
+	if f.InstanceFamily != "" {
+		parts = append(parts, "instance family "+f.InstanceFamily)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// FilterNodeGroups returns the node groups that match filter, keeping their order
+func FilterNodeGroups(nodeGroups []NodeGroupInfo, filter NodeGroupFilter) []NodeGroupInfo {
+	if filter.IsEmpty() {
+		return nodeGroups
+	}
+	var matched []NodeGroupInfo
+	for _, ng := range nodeGroups {
+		if filter.Matches(ng) {
+			matched = append(matched, ng)
+		}
+	}
+	return matched
+}
+
+// instanceFamily returns the family of an instance type, e.g. "m5" for "m5.large"
+func instanceFamily(instanceType string) string {
+	family, _, _ := strings.Cut(instanceType, ".")
+	return family
+}
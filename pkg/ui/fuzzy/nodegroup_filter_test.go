@@ -0,0 +1,77 @@
+package fuzzy
+
+import (
+	"strings"
+	"testing"
+)
+
+var filterTestNodeGroups = []NodeGroupInfo{
+	{Name: "general", CapacityType: "ON_DEMAND", InstanceTypes: []string{"m5.large"}},
+	{Name: "spot-mixed", CapacityType: "SPOT", InstanceTypes: []string{"m5.xlarge", "m5a.xlarge", "c5.xlarge"}},
+	{Name: "spot-compute", CapacityType: "SPOT", InstanceTypes: []string{"c5.2xlarge"}},
+	{Name: "gpu", CapacityType: "ON_DEMAND", InstanceTypes: []string{"p3.2xlarge"}},
+	{Name: "launch-template", CapacityType: "ON_DEMAND"},
+}
+
+func TestFilterNodeGroups(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter NodeGroupFilter
+		want   []string
+	}{
+		{"empty filter", NodeGroupFilter{}, []string{"general", "spot-mixed", "spot-compute", "gpu", "launch-template"}},
+		{"spot", NodeGroupFilter{CapacityType: "SPOT"}, []string{"spot-mixed", "spot-compute"}},
+		{"on-demand", NodeGroupFilter{CapacityType: "ON_DEMAND"}, []string{"general", "gpu", "launch-template"}},
+		{"family m5", NodeGroupFilter{InstanceFamily: "m5"}, []string{"general", "spot-mixed"}},
+		{"family does not match prefix", NodeGroupFilter{InstanceFamily: "m5a"}, []string{"spot-mixed"}},
+		{"family case insensitive", NodeGroupFilter{InstanceFamily: "C5"}, []string{"spot-mixed", "spot-compute"}},
+		{"spot and c5", NodeGroupFilter{CapacityType: "SPOT", InstanceFamily: "c5"}, []string{"spot-mixed", "spot-compute"}},
+		{"on-demand and c5", NodeGroupFilter{CapacityType: "ON_DEMAND", InstanceFamily: "c5"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, ng := range FilterNodeGroups(filterTestNodeGroups, tt.filter) {
+				got = append(got, ng.Name)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("FilterNodeGroups(%+v) = %v, want %v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCapacityType(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"", "", false},
+		{"SPOT", "SPOT", false},
+		{"spot", "SPOT", false},
+		{"on-demand", "ON_DEMAND", false},
+		{"ON_DEMAND", "ON_DEMAND", false},
+		{"capacity-block", "CAPACITY_BLOCK", false},
+		{"reserved", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseCapacityType(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseCapacityType(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseCapacityType(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestNodeGroupFilterString(t *testing.T) {
+	f := NodeGroupFilter{CapacityType: "SPOT", InstanceFamily: "m5"}
+	if got := f.String(); got != "capacity type SPOT, instance family m5" {
+		t.Errorf("String() = %q", got)
+	}
+}
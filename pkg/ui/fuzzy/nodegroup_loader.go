@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	appconfig "github.com/johnlam90/aws-ssm/pkg/config"
 	fuzzyfinder "github.com/ktr0731/go-fuzzyfinder"
 )
 
@@ -15,6 +16,8 @@ type NodeGroupInfo struct {
 	ClusterName        string
 	Status             string
 	Version            string
+	ReleaseVersion     string
+	CapacityType       string
 	InstanceTypes      []string
 	DesiredSize        int32
 	MinSize            int32
@@ -43,7 +46,9 @@ type NodeGroupDetail interface {
 	GetName() string
 	GetStatus() string
 	GetVersion() string
+	GetReleaseVersion() string
 	GetInstanceTypes() []string
+	GetCapacityType() string
 	GetDesiredSize() int32
 	GetMinSize() int32
 	GetMaxSize() int32
@@ -121,7 +126,9 @@ func (l *AWSNodeGroupLoader) convertToNodeGroupInfo(ngDetail *NodeGroupDetail, c
 	ngInfo.Name = ng.GetName()
 	ngInfo.Status = ng.GetStatus()
 	ngInfo.Version = ng.GetVersion()
+	ngInfo.ReleaseVersion = ng.GetReleaseVersion()
 	ngInfo.InstanceTypes = ng.GetInstanceTypes()
+	ngInfo.CapacityType = ng.GetCapacityType()
 	ngInfo.DesiredSize = ng.GetDesiredSize()
 	ngInfo.MinSize = ng.GetMinSize()
 	ngInfo.MaxSize = ng.GetMaxSize()
@@ -135,18 +142,27 @@ func (l *AWSNodeGroupLoader) convertToNodeGroupInfo(ngDetail *NodeGroupDetail, c
 
 // NodeGroupFinder handles node group selection
 type NodeGroupFinder struct {
-	loader NodeGroupLoader
-	colors ColorManager
+	loader  NodeGroupLoader
+	colors  ColorManager
+	tagMask appconfig.TagMask
+	filter  NodeGroupFilter
 }
 
 // NewNodeGroupFinder creates a new node group finder
-func NewNodeGroupFinder(loader NodeGroupLoader, colors ColorManager) *NodeGroupFinder {
+func NewNodeGroupFinder(loader NodeGroupLoader, colors ColorManager, tagMask appconfig.TagMask) *NodeGroupFinder {
 	return &NodeGroupFinder{
-		loader: loader,
-		colors: colors,
+		loader:  loader,
+		colors:  colors,
+		tagMask: tagMask,
 	}
 }
 
+// WithFilter limits selection to node groups that match filter
+func (f *NodeGroupFinder) WithFilter(filter NodeGroupFilter) *NodeGroupFinder {
+	f.filter = filter
+	return f
+}
+
 // SelectNodeGroupInteractive displays the fuzzy finder for node group selection
 func (f *NodeGroupFinder) SelectNodeGroupInteractive(ctx context.Context, clusterName string) (*NodeGroupInfo, error) {
 	// Load node groups
@@ -159,8 +175,13 @@ func (f *NodeGroupFinder) SelectNodeGroupInteractive(ctx context.Context, cluste
 		return nil, fmt.Errorf("no node groups found in cluster %s", clusterName)
 	}
 
+	nodeGroups = FilterNodeGroups(nodeGroups, f.filter)
+	if len(nodeGroups) == 0 {
+		return nil, fmt.Errorf("no node groups with %s found in cluster %s", f.filter, clusterName)
+	}
+
 	// Use fuzzyfinder to select
-	fuzzyfinder := NewNodeGroupFuzzyFinder(nodeGroups, f.colors)
+	fuzzyfinder := NewNodeGroupFuzzyFinder(nodeGroups, f.colors, f.tagMask)
 	selectedIndex, err := fuzzyfinder.Select(ctx)
 	if err != nil {
 		return nil, err
@@ -177,27 +198,40 @@ func (f *NodeGroupFinder) SelectNodeGroupInteractive(ctx context.Context, cluste
 type NodeGroupFuzzyFinder struct {
 	nodeGroups []NodeGroupInfo
 	colors     ColorManager
+	tagMask    appconfig.TagMask
+	columns    ColumnSpec[NodeGroupInfo]
 }
 
 // NewNodeGroupFuzzyFinder creates a new node group fuzzy finder
-func NewNodeGroupFuzzyFinder(nodeGroups []NodeGroupInfo, colors ColorManager) *NodeGroupFuzzyFinder {
+func NewNodeGroupFuzzyFinder(nodeGroups []NodeGroupInfo, colors ColorManager, tagMask appconfig.TagMask) *NodeGroupFuzzyFinder {
 	return &NodeGroupFuzzyFinder{
 		nodeGroups: nodeGroups,
 		colors:     colors,
+		tagMask:    tagMask,
+		columns:    DefaultNodeGroupColumns(),
 	}
 }
 
+// WithColumns replaces the columns shown for each node group
+func (f *NodeGroupFuzzyFinder) WithColumns(columns ColumnSpec[NodeGroupInfo]) *NodeGroupFuzzyFinder {
+	f.columns = columns
+	return f
+}
+
 // Select displays the fuzzy finder and returns the selected node group index
 func (f *NodeGroupFuzzyFinder) Select(ctx context.Context) (int, error) {
 	// Create preview renderer
-	renderer := NewNodeGroupPreviewRenderer(f.colors)
+	renderer := NewNodeGroupPreviewRenderer(f.colors, f.tagMask)
+
+	table := f.columns.Render(f.nodeGroups)
 
 	// Use fuzzyfinder to select with context support for Ctrl+C handling
 	selectedIndex, err := fuzzyfinder.Find(
 		f.nodeGroups,
 		func(i int) string {
-			return f.formatNodeGroupRow(f.nodeGroups[i])
+			return table.Rows[i]
 		},
+		fuzzyfinder.WithHeader(table.Header),
 		fuzzyfinder.WithPreviewWindow(func(i, width, height int) string {
 			if i < 0 || i >= len(f.nodeGroups) {
 				return "Select a node group to view details"
@@ -218,39 +252,33 @@ func (f *NodeGroupFuzzyFinder) Select(ctx context.Context) (int, error) {
 	return selectedIndex, nil
 }
 
-// formatNodeGroupRow formats a node group for display in the fuzzy finder
-// Format matches v0.2.0: name | status | desired/min/max | instance-types
-func (f *NodeGroupFuzzyFinder) formatNodeGroupRow(ng NodeGroupInfo) string {
-	name := ng.Name
-	if name == "" {
-		name = "(no name)"
-	}
-
-	// Truncate name to fit nicely
-	if len(name) > 25 {
-		name = name[:22] + "..."
-	}
-
-	// Format: Name | Status | Desired/Min/Max | Instance Types
-	status := ng.Status
-	if status == "" {
-		status = "UNKNOWN"
-	}
-
-	scaling := fmt.Sprintf("%d/%d/%d", ng.DesiredSize, ng.MinSize, ng.MaxSize)
-
-	instanceTypes := "Launch Template"
-	if len(ng.InstanceTypes) > 0 {
-		instanceTypes = ng.InstanceTypes[0]
-		if len(ng.InstanceTypes) > 1 {
-			instanceTypes += fmt.Sprintf(" +%d", len(ng.InstanceTypes)-1)
-		}
+// DefaultNodeGroupColumns returns the node group finder columns:
+// name | status | desired/min/max | instance types
+func DefaultNodeGroupColumns() ColumnSpec[NodeGroupInfo] {
+	return ColumnSpec[NodeGroupInfo]{
+		{Header: "NAME", MaxWidth: 40, Value: func(ng NodeGroupInfo) string {
+			if ng.Name == "" {
+				return "(no name)"
+			}
+			return ng.Name
+		}},
+		{Header: "STATUS", Value: func(ng NodeGroupInfo) string {
+			if ng.Status == "" {
+				return "UNKNOWN"
+			}
+			return ng.Status
+		}},
+		{Header: "DESIRED/MIN/MAX", Value: func(ng NodeGroupInfo) string {
+			return fmt.Sprintf("%d/%d/%d", ng.DesiredSize, ng.MinSize, ng.MaxSize)
+		}},
+		{Header: "INSTANCE TYPES", Value: func(ng NodeGroupInfo) string {
+			if len(ng.InstanceTypes) == 0 {
+				return "Launch Template"
+			}
+			if len(ng.InstanceTypes) > 1 {
+				return fmt.Sprintf("%s +%d", ng.InstanceTypes[0], len(ng.InstanceTypes)-1)
+			}
+			return ng.InstanceTypes[0]
+		}},
 	}
-
-	return fmt.Sprintf("%-25s | %-12s | %-11s | %s",
-		name,
-		status,
-		scaling,
-		instanceTypes,
-	)
 }
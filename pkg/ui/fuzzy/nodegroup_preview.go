@@ -4,16 +4,19 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	appconfig "github.com/johnlam90/aws-ssm/pkg/config"
 )
 
 // NodeGroupPreviewRenderer renders node group preview
 type NodeGroupPreviewRenderer struct {
-	colors ColorManager
+	colors  ColorManager
+	tagMask appconfig.TagMask
 }
 
 // NewNodeGroupPreviewRenderer creates a new node group preview renderer
-func NewNodeGroupPreviewRenderer(colors ColorManager) *NodeGroupPreviewRenderer {
-	return &NodeGroupPreviewRenderer{colors: colors}
+func NewNodeGroupPreviewRenderer(colors ColorManager, tagMask appconfig.TagMask) *NodeGroupPreviewRenderer {
+	return &NodeGroupPreviewRenderer{colors: colors, tagMask: tagMask}
 }
 
 // Render renders the preview for a node group
@@ -37,6 +40,9 @@ func (r *NodeGroupPreviewRenderer) Render(ng *NodeGroupInfo, width, _ int) strin
 	fmt.Fprintf(&preview, "  Cluster:           %s\n", ng.ClusterName)
 	fmt.Fprintf(&preview, "  Status:            %s\n", r.formatStatus(ng.Status))
 	fmt.Fprintf(&preview, "  Version:           %s\n", ng.Version)
+	if ng.ReleaseVersion != "" {
+		fmt.Fprintf(&preview, "  AMI Release:       %s\n", ng.ReleaseVersion)
+	}
 
 	if !ng.CreatedAt.IsZero() {
 		age := time.Since(ng.CreatedAt)
@@ -78,6 +84,9 @@ func (r *NodeGroupPreviewRenderer) Render(ng *NodeGroupInfo, width, _ int) strin
 	// Instance types
 	preview.WriteString(r.colors.BoldColor("Instance Configuration:"))
 	preview.WriteString("\n")
+	if ng.CapacityType != "" {
+		fmt.Fprintf(&preview, "  Capacity Type:     %s\n", ng.CapacityType)
+	}
 	switch {
 	case len(ng.InstanceTypes) > 0:
 		preview.WriteString("  Instance Types:\n")
@@ -97,7 +106,7 @@ func (r *NodeGroupPreviewRenderer) Render(ng *NodeGroupInfo, width, _ int) strin
 		preview.WriteString(r.colors.BoldColor("Tags:"))
 		preview.WriteString("\n")
 		for key, value := range ng.Tags {
-			fmt.Fprintf(&preview, "  %s\n", r.colors.TagColor(key, value))
+			fmt.Fprintf(&preview, "  %s\n", r.colors.TagColor(key, r.tagMask.Value(key, value)))
 		}
 	}
 
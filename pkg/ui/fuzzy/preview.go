@@ -5,16 +5,19 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	appconfig "github.com/johnlam90/aws-ssm/pkg/config"
 )
 
 // DefaultPreviewRenderer implements PreviewRenderer interface
 type DefaultPreviewRenderer struct {
-	colors ColorManager
+	colors  ColorManager
+	tagMask appconfig.TagMask
 }
 
 // NewDefaultPreviewRenderer creates a new preview renderer
-func NewDefaultPreviewRenderer(colors ColorManager) *DefaultPreviewRenderer {
-	return &DefaultPreviewRenderer{colors: colors}
+func NewDefaultPreviewRenderer(colors ColorManager, tagMask appconfig.TagMask) *DefaultPreviewRenderer {
+	return &DefaultPreviewRenderer{colors: colors, tagMask: tagMask}
 }
 
 // Render renders the preview for an instance
@@ -90,7 +93,7 @@ func (r *DefaultPreviewRenderer) Render(instance *Instance, width, _ int) string
 		preview.WriteString("\n")
 		for key, value := range instance.Tags {
 			if key != "Name" { // Name already shown in basic info
-				fmt.Fprintf(&preview, "  %s\n", r.colors.TagColor(key, value))
+				fmt.Fprintf(&preview, "  %s\n", r.colors.TagColor(key, r.tagMask.Value(key, value)))
 			}
 		}
 	}
@@ -104,7 +107,9 @@ func (r *DefaultPreviewRenderer) RenderJSON(instance *Instance) string {
 		return ""
 	}
 
-	jsonData, err := json.MarshalIndent(instance, "", "  ")
+	masked := *instance
+	masked.Tags = r.tagMask.Apply(instance.Tags)
+	jsonData, err := json.MarshalIndent(masked, "", "  ")
 	if err != nil {
 		return fmt.Sprintf("Error rendering JSON: %v", err)
 	}
@@ -0,0 +1,33 @@
+package fuzzy
+
+import (
+	"strings"
+	"testing"
+
+	appconfig "github.com/johnlam90/aws-ssm/pkg/config"
+)
+
+func TestPreviewMasksSensitiveTags(t *testing.T) {
+	renderer := NewDefaultPreviewRenderer(NewDefaultColorManager(true), appconfig.NewTagMask([]string{"Owner"}))
+	instance := &Instance{
+		InstanceID: "i-111",
+		Name:       "web-1",
+		State:      "running",
+		Tags:       map[string]string{"Name": "web-1", "Owner": "alice@example.com", "Env": "prod"},
+	}
+
+	for name, out := range map[string]string{
+		"preview": renderer.Render(instance, 80, 40),
+		"json":    renderer.RenderJSON(instance),
+	} {
+		if strings.Contains(out, "alice@example.com") {
+			t.Errorf("%s leaks the masked tag value:\n%s", name, out)
+		}
+		if !strings.Contains(out, "***") || !strings.Contains(out, "prod") {
+			t.Errorf("%s should show Owner masked and Env unmasked:\n%s", name, out)
+		}
+	}
+	if instance.Tags["Owner"] != "alice@example.com" {
+		t.Error("rendering modified the instance tags")
+	}
+}
@@ -0,0 +1,30 @@
+package fuzzy
+
+import (
+	"context"
+
+	fuzzyfinder "github.com/ktr0731/go-fuzzyfinder"
+)
+
+// SelectReleaseVersion displays a fuzzy finder over EKS AMI release versions and
+// returns the chosen one, or "" if the user aborted
+func SelectReleaseVersion(ctx context.Context, versions []string, current string) (string, error) {
+	selectedIndex, err := fuzzyfinder.Find(
+		versions,
+		func(i int) string {
+			if versions[i] == current {
+				return versions[i] + " (current)"
+			}
+			return versions[i]
+		},
+		fuzzyfinder.WithPromptString("AMI Release Version > "),
+		fuzzyfinder.WithContext(ctx),
+	)
+	if err != nil {
+		if err == fuzzyfinder.ErrAbort {
+			return "", nil // User cancelled
+		}
+		return "", err
+	}
+	return versions[selectedIndex], nil
+}
@@ -139,6 +139,9 @@ type Config struct {
 	Width        int    // Terminal width override
 	Favorites    bool   // Show favorites only
 	ConfigPath   string // Path to config file
+
+	SensitiveTags []string // Tag keys whose values are shown as ***
+	LabelTemplate string   // Instance label template; see ParseLabelTemplate
 }
 
 // DefaultConfig returns the default configuration
@@ -0,0 +1,81 @@
+// Package termcolor decides whether CLI and TUI output should use color.
+package termcolor
+
+import (
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Inputs are the signals that determine whether color is enabled
+type Inputs struct {
+	NoColorFlag bool   // --no-color
+	NoColorEnv  string // NO_COLOR
+	ForceColor  string // FORCE_COLOR
+	IsTerminal  bool   // output is a TTY
+}
+
+// Decide applies the color precedence rules: --no-color wins, then FORCE_COLOR
+// (where "0" and "false" disable color), then NO_COLOR, then TTY detection.
+func Decide(in Inputs) bool {
+	if in.NoColorFlag {
+		return false
+	}
+	if force, ok := parseForceColor(in.ForceColor); ok {
+		return force
+	}
+	if in.NoColorEnv != "" {
+		return false
+	}
+	return in.IsTerminal
+}
+
+// parseForceColor interprets FORCE_COLOR; ok is false when it is unset
+func parseForceColor(value string) (force bool, ok bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return false, false
+	}
+	switch strings.ToLower(value) {
+	case "0", "false":
+		return false, true
+	default:
+		return true, true
+	}
+}
+
+// Enabled reports whether output written to out should be colored, reading
+// NO_COLOR and FORCE_COLOR from the environment
+func Enabled(noColorFlag bool, out *os.File) bool {
+	return Decide(Inputs{
+		NoColorFlag: noColorFlag,
+		NoColorEnv:  os.Getenv("NO_COLOR"),
+		ForceColor:  os.Getenv("FORCE_COLOR"),
+		IsTerminal:  IsTerminal(out),
+	})
+}
+
+// IsTerminal reports whether f is an interactive terminal
+func IsTerminal(f *os.File) bool {
+	if f == nil {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// ApplyProfile aligns lipgloss rendering with the color decision, so styled
+// output is plain when disabled and still colored when forced onto a pipe
+func ApplyProfile(enabled bool) {
+	switch {
+	case !enabled:
+		lipgloss.SetColorProfile(termenv.Ascii)
+	case lipgloss.ColorProfile() == termenv.Ascii:
+		lipgloss.SetColorProfile(termenv.ANSI256)
+	}
+}
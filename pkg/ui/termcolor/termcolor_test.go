@@ -0,0 +1,48 @@
+package termcolor
+
+import "testing"
+
+func TestDecide(t *testing.T) {
+	tests := []struct {
+		name string
+		in   Inputs
+		want bool
+	}{
+		{name: "terminal defaults to color", in: Inputs{IsTerminal: true}, want: true},
+		{name: "pipe defaults to plain", in: Inputs{}, want: false},
+		{name: "flag disables on terminal", in: Inputs{NoColorFlag: true, IsTerminal: true}, want: false},
+		{name: "flag beats FORCE_COLOR", in: Inputs{NoColorFlag: true, ForceColor: "1", IsTerminal: true}, want: false},
+		{name: "NO_COLOR disables on terminal", in: Inputs{NoColorEnv: "1", IsTerminal: true}, want: false},
+		{name: "FORCE_COLOR enables on pipe", in: Inputs{ForceColor: "1"}, want: true},
+		{name: "FORCE_COLOR level enables", in: Inputs{ForceColor: "3"}, want: true},
+		{name: "FORCE_COLOR beats NO_COLOR", in: Inputs{ForceColor: "true", NoColorEnv: "1"}, want: true},
+		{name: "FORCE_COLOR=0 disables on terminal", in: Inputs{ForceColor: "0", IsTerminal: true}, want: false},
+		{name: "FORCE_COLOR=false disables on terminal", in: Inputs{ForceColor: "false", IsTerminal: true}, want: false},
+		{name: "blank FORCE_COLOR is unset", in: Inputs{ForceColor: " ", IsTerminal: true}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Decide(tt.in); got != tt.want {
+				t.Errorf("Decide(%+v) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnabledReadsEnvironment(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "1")
+	if !Enabled(false, nil) {
+		t.Error("FORCE_COLOR should enable color for non-terminal output")
+	}
+	if Enabled(true, nil) {
+		t.Error("--no-color should disable color even with FORCE_COLOR")
+	}
+
+	t.Setenv("FORCE_COLOR", "")
+	t.Setenv("NO_COLOR", "1")
+	if Enabled(false, nil) {
+		t.Error("NO_COLOR should disable color")
+	}
+}
@@ -15,11 +15,20 @@ func (m Model) renderASGs() string {
 	cursor := clampIndex(m.cursor, len(asgs))
 	selected := asgs[cursor]
 	details := limitRenderedLines(m.renderASGDetails(selected), max(1, m.height-10))
-	visibleRows := calculateTableRows(m.height, 9, details)
+	warning := m.renderLoadWarning(ViewASGs)
+	reserved := 9
+	if warning != "" {
+		reserved++
+	}
+	visibleRows := calculateTableRows(m.height, reserved, details)
 
 	header := m.renderHeader("Auto Scaling Groups", fmt.Sprintf("%d ASGs", len(asgs)))
 	b.WriteString(header)
 	b.WriteString("\n\n")
+	if warning != "" {
+		b.WriteString(warning)
+		b.WriteString("\n")
+	}
 	b.WriteString(TableHeaderStyle().Render(fmt.Sprintf("  %-50s %8s %8s %8s %8s",
 		"NAME", "DESIRED", "MIN", "MAX", "CURRENT")))
 	b.WriteString("\n")
@@ -32,7 +41,7 @@ func (m Model) renderASGs() string {
 		}
 		row := fmt.Sprintf("  %-50s %8d %8d %8d %8d",
 			name, asg.DesiredCapacity, asg.MinSize, asg.MaxSize, asg.CurrentSize)
-		b.WriteString(RenderSelectableRow(row, i == cursor))
+		b.WriteString(RenderSelectableRow(m.highlightSearchMatches(row, ViewASGs), i == cursor))
 		b.WriteString("\n")
 	}
 	if endIdx-startIdx > 0 && len(asgs) > endIdx-startIdx {
@@ -82,6 +91,10 @@ func (m Model) renderASGState(asgs []ASG) string {
 		return b.String()
 	}
 	if len(asgs) == 0 {
+		if warning := m.renderLoadWarning(ViewASGs); warning != "" {
+			b.WriteString(warning)
+			b.WriteString("\n")
+		}
 		b.WriteString(SubtitleStyle().Render("No Auto Scaling Groups found"))
 		b.WriteString("\n\n")
 		b.WriteString(HelpStyle().Render("esc:back"))
@@ -98,27 +111,15 @@ func (m Model) calculateVisibleRange(total, cursor, visibleHeight int) (int, int
 
 // renderASGFooter renders the footer for ASG view
 func (m Model) renderASGFooter() string {
-	keys := []struct {
-		key  string
-		desc string
-	}{
-		{"↑/k", "up"},
-		{"↓/j", "down"},
-		{"g/G", "top/bottom"},
-		{"enter", "scale"},
-		{"r", "refresh"},
-		{"/", "search"},
-		{"esc", "back"},
-	}
-
-	var parts []string
-	for _, k := range keys {
-		keyStyle := StatusBarKeyStyle().Render(k.key)
-		descStyle := StatusBarValueStyle().Render(k.desc)
-		parts = append(parts, fmt.Sprintf("%s %s", keyStyle, descStyle))
-	}
-
-	return HelpStyle().Render(strings.Join(parts, " • "))
+	return m.renderFooterKeys([]footerKey{
+		{"↑/k", "up", false},
+		{"↓/j", "down", false},
+		{"g/G", "top/bottom", false},
+		{"enter", "scale", true},
+		{"r", "refresh", false},
+		{"/", "search", false},
+		{"esc", "back", false},
+	})
 }
 
 func (m Model) renderASGDetails(asg ASG) string {
@@ -172,7 +173,7 @@ func (m Model) renderASGDetails(asg ASG) string {
 		}
 	}
 
-	if lines := renderTagLines(asg.Tags); len(lines) > 0 {
+	if lines := renderTagLines(m.tagMask.Apply(asg.Tags)); len(lines) > 0 {
 		b.WriteString("\n  Tags:\n")
 		for _, line := range lines {
 			b.WriteString(line)
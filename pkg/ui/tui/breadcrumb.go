@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	breadcrumbSeparator = " › "
+	breadcrumbEllipsis  = "…"
+)
+
+// breadcrumb returns the navigation trail from the dashboard to the current
+// view, including the items selected on the way (e.g. a cluster name)
+func (m Model) breadcrumb() []string {
+	parts := make([]string, 0, len(m.viewStack)*2+3)
+	for i, view := range m.viewStack {
+		parts = append(parts, view.String())
+		if i < len(m.viewSelections) && m.viewSelections[i] != "" {
+			parts = append(parts, m.viewSelections[i])
+		}
+	}
+	parts = append(parts, m.currentView.String())
+
+	if m.ltUpdate != nil && m.currentView == ViewNodeGroups {
+		parts = append(parts, m.ltUpdate.NodeGroupName, "Launch Template Versions")
+	}
+	return parts
+}
+
+// renderBreadcrumb renders the trail within maxWidth cells, dropping the oldest
+// parts first and then shortening the last one. It returns "" on the dashboard.
+func (m Model) renderBreadcrumb(maxWidth int) string {
+	if len(m.viewStack) == 0 {
+		return ""
+	}
+	trail := fitBreadcrumb(m.breadcrumb(), maxWidth)
+	if trail == "" {
+		return ""
+	}
+	return SubtitleStyle().Render(trail)
+}
+
+// fitBreadcrumb joins parts with breadcrumbSeparator, truncating to maxWidth.
+// A non-positive maxWidth means unlimited.
+func fitBreadcrumb(parts []string, maxWidth int) string {
+	trail := strings.Join(parts, breadcrumbSeparator)
+	if maxWidth <= 0 || lipgloss.Width(trail) <= maxWidth {
+		return trail
+	}
+
+	// Replace leading parts with an ellipsis until the rest fits
+	for start := 1; start < len(parts); start++ {
+		trail = breadcrumbEllipsis + breadcrumbSeparator + strings.Join(parts[start:], breadcrumbSeparator)
+		if lipgloss.Width(trail) <= maxWidth {
+			return trail
+		}
+	}
+
+	// Even the last part alone is too wide; cut it down
+	return truncateRunes(parts[len(parts)-1], maxWidth)
+}
+
+// truncateRunes shortens s to at most maxWidth cells, ending with an ellipsis
+func truncateRunes(s string, maxWidth int) string {
+	if lipgloss.Width(s) <= maxWidth {
+		return s
+	}
+	if maxWidth <= 1 {
+		return ""
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > maxWidth {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + breadcrumbEllipsis
+}
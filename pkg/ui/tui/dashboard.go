@@ -30,7 +30,7 @@ func (m Model) renderDashboard() string {
 		return b.String()
 	}
 
-	b.WriteString(DashboardTableHeaderStyle().Render(fmt.Sprintf("  %-4s %-24s %s", "KEY", "SERVICE", "ACTION")))
+	b.WriteString(DashboardTableHeaderStyle().Render(fmt.Sprintf("  %-4s %-24s %-6s %s", "KEY", "SERVICE", "COUNT", "ACTION")))
 	b.WriteString("\n")
 	b.WriteString(DashboardSubtleRule(m.dashboardContentWidth()).Render(strings.Repeat("─", m.dashboardContentWidth())))
 	b.WriteString("\n")
@@ -64,6 +64,15 @@ func (m Model) renderHeader(title, subtitle string) string {
 		b.WriteString(subtitleText)
 	}
 
+	// Navigation trail on the same line, so view heights are unchanged
+	const gap = 3
+	if m.width > 0 {
+		if trail := m.renderBreadcrumb(m.width - lipgloss.Width(b.String()) - gap); trail != "" {
+			b.WriteString(strings.Repeat(" ", gap))
+			b.WriteString(trail)
+		}
+	}
+
 	return b.String()
 }
 
@@ -115,19 +124,21 @@ func (m Model) renderDashboardSeparator() string {
 func (m Model) renderDashboardMenuItem(index int, item MenuItem, isSelected bool) string {
 	normalizedDesc := m.normalizeServiceDescription(item.Description)
 	key := fmt.Sprintf("%d", index+1)
+	count := fmt.Sprintf("%-6s", m.dashboardCountLabel(item.View))
 
 	if isSelected {
-		return fmt.Sprintf("%s %s %s %s",
+		return fmt.Sprintf("%s %s %s %s %s",
 			DashboardSelectionBarStyle().Render("›"),
 			DashboardSelectedKeyStyle().Render(fmt.Sprintf("%-4s", key)),
 			DashboardSelectedNameStyle().Render(fmt.Sprintf("%-24s", item.Title)),
+			DashboardSelectedDescStyle().Render(count),
 			DashboardSelectedDescStyle().Render(normalizedDesc),
 		)
 	}
 
 	name := DashboardServiceNameStyle().Render(fmt.Sprintf("%-24s", item.Title))
 	desc := DashboardServiceDescStyle().Render(normalizedDesc)
-	return fmt.Sprintf("  %s %s %s", DashboardFooterKeyStyle().Render(fmt.Sprintf("%-4s", key)), name, desc)
+	return fmt.Sprintf("  %s %s %s %s", DashboardFooterKeyStyle().Render(fmt.Sprintf("%-4s", key)), name, DashboardMutedStyle().Render(count), desc)
 }
 
 // normalizeServiceDescription normalizes service descriptions for consistency
@@ -0,0 +1,162 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/johnlam90/aws-ssm/pkg/cache"
+)
+
+// dashboardCountViews are the menu items that show a live resource count
+var dashboardCountViews = []ViewMode{ViewEC2Instances, ViewEKSClusters, ViewASGs}
+
+// DashboardCountMsg carries a resource count for a dashboard menu item
+type DashboardCountMsg struct {
+	View   ViewMode
+	Count  int
+	Cached bool
+	Error  error
+}
+
+// dashboardCount is the count state of one dashboard menu item
+type dashboardCount struct {
+	count  int
+	loaded bool
+	err    error
+}
+
+// dashboardCountSource lists the resources counted on the dashboard; *aws.Client implements it
+type dashboardCountSource interface {
+	ListInstances(ctx context.Context, tagFilters map[string]string) ([]aws.Instance, error)
+	ListClusters(ctx context.Context) ([]string, error)
+	ListAutoScalingGroups(ctx context.Context) ([]string, error)
+}
+
+// PrefetchDashboardCountsCmd loads the dashboard resource counts concurrently,
+// as background loads of the scheduler (nil runs them unbounded). Each count
+// arrives as its own DashboardCountMsg; warm cache entries are used instead of
+// calling AWS. svc may be nil to disable caching.
+func PrefetchDashboardCountsCmd(ctx context.Context, src dashboardCountSource, svc *cache.Service, region, profile string, loads *loadScheduler) tea.Cmd {
+	cmds := make([]tea.Cmd, 0, len(dashboardCountViews))
+	for _, view := range dashboardCountViews {
+		cmds = append(cmds, loads.schedule(ctx, loadBackground, loadDashboardCountCmd(ctx, src, svc, view, region, profile)))
+	}
+	return tea.Batch(cmds...)
+}
+
+// loadDashboardCountCmd loads the count for a single dashboard view
+func loadDashboardCountCmd(ctx context.Context, src dashboardCountSource, svc *cache.Service, view ViewMode, region, profile string) tea.Cmd {
+	return func() tea.Msg {
+		key := dashboardCountCacheKey(view, region, profile)
+		if count, ok := cachedDashboardCount(svc, key); ok {
+			return DashboardCountMsg{View: view, Count: count, Cached: true}
+		}
+
+		count, err := countDashboardResources(ctx, src, view)
+		if err != nil {
+			return DashboardCountMsg{View: view, Error: err}
+		}
+		if svc != nil {
+			// Failure to cache is not fatal; the next start simply asks AWS again
+			_ = svc.SetWithResourceType(key, count, dashboardCountResourceType(view), region, "dashboard-count")
+		}
+		return DashboardCountMsg{View: view, Count: count}
+	}
+}
+
+// countDashboardResources counts the resources behind a dashboard view
+func countDashboardResources(ctx context.Context, src dashboardCountSource, view ViewMode) (int, error) {
+	switch view {
+	case ViewEC2Instances:
+		instances, err := src.ListInstances(ctx, nil)
+		return len(instances), err
+	case ViewEKSClusters:
+		clusters, err := src.ListClusters(ctx)
+		return len(clusters), err
+	case ViewASGs:
+		asgs, err := src.ListAutoScalingGroups(ctx)
+		return len(asgs), err
+	default:
+		return 0, fmt.Errorf("no dashboard count for %s", view)
+	}
+}
+
+// dashboardCountResourceType maps a view to the cache resource type whose TTL applies
+func dashboardCountResourceType(view ViewMode) cache.ResourceType {
+	switch view {
+	case ViewEKSClusters:
+		return cache.ResourceEKS
+	case ViewASGs:
+		return cache.ResourceASG
+	default:
+		return cache.ResourceEC2
+	}
+}
+
+// dashboardCountCacheKey returns the cache key for a view's count in a region/profile
+func dashboardCountCacheKey(view ViewMode, region, profile string) string {
+	return fmt.Sprintf("dashboard_count_%d_%s_%s", view, profile, region)
+}
+
+// cachedDashboardCount reads a count from the cache
+func cachedDashboardCount(svc *cache.Service, key string) (int, bool) {
+	if svc == nil {
+		return 0, false
+	}
+	data, ok := svc.Get(key)
+	if !ok {
+		return 0, false
+	}
+	// Cached data comes back as generic JSON, so numbers are float64
+	count, ok := data.(float64)
+	if !ok || count < 0 {
+		return 0, false
+	}
+	return int(count), true
+}
+
+// newDashboardCounts returns count state for the counted views, marked as
+// pending when a prefetch will run
+func newDashboardCounts(prefetch bool) map[ViewMode]dashboardCount {
+	counts := make(map[ViewMode]dashboardCount, len(dashboardCountViews))
+	if !prefetch {
+		return counts
+	}
+	for _, view := range dashboardCountViews {
+		counts[view] = dashboardCount{}
+	}
+	return counts
+}
+
+// handleDashboardCount records a prefetched count
+func (m Model) handleDashboardCount(msg DashboardCountMsg) (tea.Model, tea.Cmd) {
+	m.setDashboardCount(msg.View, dashboardCount{count: msg.Count, loaded: msg.Error == nil, err: msg.Error})
+	return m, nil
+}
+
+// setDashboardCount stores the count state for a view
+func (m *Model) setDashboardCount(view ViewMode, state dashboardCount) {
+	if m.dashboardCounts == nil {
+		m.dashboardCounts = make(map[ViewMode]dashboardCount, len(dashboardCountViews))
+	}
+	m.dashboardCounts[view] = state
+}
+
+// dashboardCountLabel returns the count column text for a menu item: the
+// count, "…" while loading, "?" on error, or "" for views without a count
+func (m Model) dashboardCountLabel(view ViewMode) string {
+	state, ok := m.dashboardCounts[view]
+	switch {
+	case !ok:
+		return ""
+	case state.err != nil:
+		return "?"
+	case !state.loaded:
+		return "…"
+	default:
+		return strconv.Itoa(state.count)
+	}
+}
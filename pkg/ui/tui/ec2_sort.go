@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"sort"
+
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+)
+
+// sortEC2Instances orders instances in place; the zero sort keeps the API order
+func sortEC2Instances(instances []EC2Instance, s aws.InstanceSort) {
+	if s.IsZero() {
+		return
+	}
+	sort.SliceStable(instances, func(i, j int) bool {
+		return s.Less(instances[i].sortKey(), instances[j].sortKey())
+	})
+}
+
+// sortKey returns the fields of e that aws.InstanceSort compares
+func (e EC2Instance) sortKey() aws.Instance {
+	return aws.Instance{
+		InstanceID:   e.InstanceID,
+		Name:         e.Name,
+		State:        e.State,
+		InstanceType: e.InstanceType,
+		LaunchTime:   e.LaunchTime,
+	}
+}
+
+// ec2ToggledSort returns the order the newest-first shortcut switches to: newest
+// first, or back to the configured order. When the configured order is already
+// newest first (or unset) it switches back to name order instead.
+func ec2ToggledSort(current, configured aws.InstanceSort) aws.InstanceSort {
+	if current != aws.NewestFirst {
+		return aws.NewestFirst
+	}
+	if !configured.IsZero() && configured != aws.NewestFirst {
+		return configured
+	}
+	return aws.InstanceSort{Field: aws.SortFieldName}
+}
@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+)
+
+func sortTestInstances() []EC2Instance {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []EC2Instance{
+		{InstanceID: "i-b", Name: "bravo", LaunchTime: base.Add(time.Hour)},
+		{InstanceID: "i-c", Name: "charlie", LaunchTime: base.Add(2 * time.Hour)},
+		{InstanceID: "i-a", Name: "alpha", LaunchTime: base},
+	}
+}
+
+func ec2IDs(instances []EC2Instance) string {
+	ids := make([]string, len(instances))
+	for i, inst := range instances {
+		ids[i] = inst.InstanceID
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestEC2SortDefaultAppliedOnLoad(t *testing.T) {
+	tests := []struct {
+		name string
+		sort aws.InstanceSort
+		want string
+	}{
+		{name: "unset keeps API order", want: "i-b,i-c,i-a"},
+		{name: "newest first", sort: aws.NewestFirst, want: "i-c,i-b,i-a"},
+		{name: "oldest first", sort: aws.InstanceSort{Field: aws.SortFieldLaunchTime}, want: "i-a,i-b,i-c"},
+		{name: "name", sort: aws.InstanceSort{Field: aws.SortFieldName}, want: "i-a,i-b,i-c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := NewModel(context.Background(), &aws.Client{}, Config{NoColor: true, EC2Sort: tt.sort})
+			updated, _ := model.Update(DataLoadedMsg{View: ViewEC2Instances, Instances: sortTestInstances()})
+			if got := ec2IDs(updated.(Model).ec2Instances); got != tt.want {
+				t.Errorf("order = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEC2NewestFirstShortcut(t *testing.T) {
+	model := NewModel(context.Background(), &aws.Client{}, Config{NoColor: true})
+	model.pushView(ViewEC2Instances)
+	updated, _ := model.Update(DataLoadedMsg{View: ViewEC2Instances, Instances: sortTestInstances()})
+	model = updated.(Model)
+	model.cursor = 2 // i-a
+
+	press := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}
+	updated, _ = model.Update(press)
+	model = updated.(Model)
+	if got := ec2IDs(model.getEC2Instances()); got != "i-c,i-b,i-a" {
+		t.Errorf("order after n = %s, want newest first", got)
+	}
+	if model.cursor != 2 || model.getEC2Instances()[model.cursor].InstanceID != "i-a" {
+		t.Errorf("cursor should stay on i-a, got %d", model.cursor)
+	}
+
+	// Pressing again leaves newest first; with no configured sort, name order is used
+	updated, _ = model.Update(press)
+	model = updated.(Model)
+	if got := ec2IDs(model.getEC2Instances()); got != "i-a,i-b,i-c" {
+		t.Errorf("order after second n = %s, want name order", got)
+	}
+	if model.getEC2Instances()[model.cursor].InstanceID != "i-a" {
+		t.Errorf("cursor should stay on i-a, got %d", model.cursor)
+	}
+}
+
+func TestEC2ToggledSort(t *testing.T) {
+	byType := aws.InstanceSort{Field: aws.SortFieldType}
+	byName := aws.InstanceSort{Field: aws.SortFieldName}
+	tests := []struct {
+		name                string
+		current, configured aws.InstanceSort
+		want                aws.InstanceSort
+	}{
+		{name: "switches to newest first", current: byType, configured: byType, want: aws.NewestFirst},
+		{name: "returns to configured", current: aws.NewestFirst, configured: byType, want: byType},
+		{name: "configured newest first falls back to name", current: aws.NewestFirst, configured: aws.NewestFirst, want: byName},
+		{name: "unset falls back to name", current: aws.NewestFirst, want: byName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ec2ToggledSort(tt.current, tt.configured); got != tt.want {
+				t.Errorf("ec2ToggledSort() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -48,19 +48,37 @@ func (m Model) renderEC2Instances() string {
 	selected := instances[cursor]
 	details := limitRenderedLines(m.renderEC2Details(selected), max(1, m.height-10))
 
+	if m.ec2RowTemplateWarning != "" {
+		b.WriteString(RenderStatusMessage(m.ec2RowTemplateWarning, "warning"))
+		b.WriteString("\n")
+	}
+
 	// Table header - clean and aligned
 	headerRow := fmt.Sprintf("  %-32s %-20s %-15s %-12s %-15s",
 		"NAME", "INSTANCE ID", "PRIVATE IP", "STATE", "TYPE")
+	if m.ec2RowTemplate != nil {
+		headerRow = "  " + m.ec2RowTemplate.Header(m.rowTemplateWidth())
+	}
 	b.WriteString(TableHeaderStyle().Render(headerRow))
 	b.WriteString("\n")
 
 	// Calculate visible range for pagination
-	visibleHeight := calculateTableRows(m.height, 9, details)
+	reserved := 9
+	if m.ec2RowTemplateWarning != "" {
+		reserved++
+	}
+	visibleHeight := calculateTableRows(m.height, reserved, details)
 	startIdx, endIdx := calculateBoundedVisibleRange(len(instances), cursor, visibleHeight)
 
 	// Render instances with proper alignment
 	for i := startIdx; i < endIdx; i++ {
 		inst := instances[i]
+		if m.ec2RowTemplate != nil {
+			row := "  " + m.ec2RowTemplate.Render(inst, m.rowTemplateWidth())
+			b.WriteString(RenderSelectableRow(m.highlightSearchMatches(row, ViewEC2Instances), i == cursor))
+			b.WriteString("\n")
+			continue
+		}
 		name := inst.Name
 		if name == "" {
 			name = "(no name)"
@@ -73,7 +91,7 @@ func (m Model) renderEC2Instances() string {
 		row := fmt.Sprintf("  %-32s %-20s %-15s %s %-15s",
 			name, inst.InstanceID, inst.PrivateIP, state, inst.InstanceType)
 
-		b.WriteString(RenderSelectableRow(row, i == cursor))
+		b.WriteString(RenderSelectableRow(m.highlightSearchMatches(row, ViewEC2Instances), i == cursor))
 		b.WriteString("\n")
 	}
 
@@ -107,29 +125,26 @@ func (m Model) renderEC2Instances() string {
 	return b.String()
 }
 
-// renderEC2Footer renders the footer for EC2 view
-func (m Model) renderEC2Footer() string {
-	keys := []struct {
-		key  string
-		desc string
-	}{
-		{"↑/k", "up"},
-		{"↓/j", "down"},
-		{"g/G", "top/bottom"},
-		{"enter", "connect"},
-		{"r", "refresh"},
-		{"/", "search"},
-		{"esc", "back"},
-	}
-
-	var parts []string
-	for _, k := range keys {
-		keyStyle := StatusBarKeyStyle().Render(k.key)
-		descStyle := StatusBarValueStyle().Render(k.desc)
-		parts = append(parts, fmt.Sprintf("%s %s", keyStyle, descStyle))
+// rowTemplateWidth returns the width available to templated rows after the indent
+func (m Model) rowTemplateWidth() int {
+	if m.width <= 2 {
+		return 0
 	}
+	return m.width - 2
+}
 
-	return HelpStyle().Render(strings.Join(parts, " • "))
+// renderEC2Footer renders the footer for EC2 view
+func (m Model) renderEC2Footer() string {
+	return m.renderFooterKeys([]footerKey{
+		{"↑/k", "up", false},
+		{"↓/j", "down", false},
+		{"g/G", "top/bottom", false},
+		{"enter", "connect", true},
+		{"r", "refresh", false},
+		{"/", "search", false},
+		{"n", "newest", false},
+		{"esc", "back", false},
+	})
 }
 
 func (m Model) renderEC2Details(inst EC2Instance) string {
@@ -138,6 +153,7 @@ func (m Model) renderEC2Details(inst EC2Instance) string {
 	b.WriteString("  Basic Info:\n")
 	fmt.Fprintf(&b, "    State:       %s\n", StateStyle(strings.ToLower(inst.State)))
 	fmt.Fprintf(&b, "    Type:        %s\n", normalizeValue(inst.InstanceType, "unknown", 0))
+	fmt.Fprintf(&b, "    Platform:    %s\n", normalizeValue(inst.Platform, "unknown", 0))
 	fmt.Fprintf(&b, "    AZ:          %s\n", normalizeValue(inst.AvailabilityZone, "unknown", 0))
 	if !inst.LaunchTime.IsZero() {
 		fmt.Fprintf(&b, "    Launch:      %s\n", formatRelativeTimestamp(inst.LaunchTime))
@@ -164,7 +180,7 @@ func (m Model) renderEC2Details(inst EC2Instance) string {
 		b.WriteString("    • no security groups detected\n")
 	}
 
-	if lines := renderTagLines(inst.Tags, "Name"); len(lines) > 0 {
+	if lines := renderTagLines(m.tagMask.Apply(inst.Tags), "Name"); len(lines) > 0 {
 		b.WriteString("\n  Tags:\n")
 		for _, line := range lines {
 			b.WriteString(line)
@@ -33,6 +33,12 @@ func (m Model) renderEKSClusters() string {
 		return b.String()
 	}
 
+	warning := m.renderLoadWarning(ViewEKSClusters)
+	if warning != "" {
+		b.WriteString(warning)
+		b.WriteString("\n")
+	}
+
 	// No clusters
 	if len(clusters) == 0 {
 		b.WriteString(SubtitleStyle().Render("No EKS clusters found"))
@@ -44,7 +50,11 @@ func (m Model) renderEKSClusters() string {
 	}
 
 	cursor := clampIndex(m.cursor, len(clusters))
-	visibleRows := calculateTableRows(m.height, 7, "")
+	reserved := 7
+	if warning != "" {
+		reserved++
+	}
+	visibleRows := calculateTableRows(m.height, reserved, "")
 	startIdx, endIdx := calculateBoundedVisibleRange(len(clusters), cursor, visibleRows)
 
 	// Table header - clean and aligned
@@ -64,7 +74,7 @@ func (m Model) renderEKSClusters() string {
 		status := StateStyle(cluster.Status)
 		row := fmt.Sprintf("  %-45s %-15s %-10s", name, status, cluster.Version)
 
-		b.WriteString(RenderSelectableRow(row, i == cursor))
+		b.WriteString(RenderSelectableRow(m.highlightSearchMatches(row, ViewEKSClusters), i == cursor))
 		b.WriteString("\n")
 	}
 
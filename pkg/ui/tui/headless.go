@@ -0,0 +1,151 @@
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/johnlam90/aws-ssm/pkg/aws"
+	appconfig "github.com/johnlam90/aws-ssm/pkg/config"
+)
+
+const (
+	// HeadlessEventLoaded reports a view's data finished loading
+	HeadlessEventLoaded = "loaded"
+	// HeadlessEventError reports a load that failed
+	HeadlessEventError = "error"
+)
+
+// HeadlessEvent is one line of the headless JSON event stream
+type HeadlessEvent struct {
+	Type    string `json:"type"`
+	View    string `json:"view,omitempty"`
+	Items   any    `json:"items,omitempty"`
+	Warning string `json:"warning,omitempty"` // Some items failed to load and are missing
+	Error   string `json:"error,omitempty"`
+}
+
+// HeadlessLoaders returns the data loaders run in headless mode
+func HeadlessLoaders(ctx context.Context, client *aws.Client) []tea.Cmd {
+	return []tea.Cmd{
+		LoadEC2InstancesCmd(ctx, client),
+		LoadEKSClustersCmd(ctx, client),
+		LoadASGsCmd(ctx, client),
+	}
+}
+
+// RunHeadless runs loaders concurrently and writes one JSON event per line to w
+// as each finishes. It returns an error when any load failed, after every
+// event has been written.
+func RunHeadless(w io.Writer, mask appconfig.TagMask, loaders ...tea.Cmd) error {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		failed   int
+		writeErr error
+	)
+	enc := json.NewEncoder(w)
+
+	for _, load := range loaders {
+		wg.Add(1)
+		go func(load tea.Cmd) {
+			defer wg.Done()
+			event, ok := headlessEventFromMsg(load(), mask)
+			if !ok {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if event.Type == HeadlessEventError {
+				failed++
+			}
+			if writeErr == nil {
+				writeErr = enc.Encode(event)
+			}
+		}(load)
+	}
+	wg.Wait()
+
+	if writeErr != nil {
+		return fmt.Errorf("failed to write event: %w", writeErr)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d loads failed", failed, len(loaders))
+	}
+	return nil
+}
+
+// headlessEventFromMsg converts a loader message into an event; ok is false for
+// messages that carry nothing to report
+func headlessEventFromMsg(msg tea.Msg, mask appconfig.TagMask) (HeadlessEvent, bool) {
+	switch msg := msg.(type) {
+	case DataLoadedMsg:
+		view := headlessViewName(msg.View)
+		if msg.Error != nil {
+			return HeadlessEvent{Type: HeadlessEventError, View: view, Error: msg.Error.Error()}, true
+		}
+		return HeadlessEvent{Type: HeadlessEventLoaded, View: view, Items: headlessItems(msg, mask), Warning: msg.Warning}, true
+	case ErrorMsg:
+		if msg.Err == nil {
+			return HeadlessEvent{}, false
+		}
+		return HeadlessEvent{Type: HeadlessEventError, Error: msg.Err.Error()}, true
+	default:
+		return HeadlessEvent{}, false
+	}
+}
+
+// headlessItems returns the loaded resources of msg's view with sensitive tags masked
+func headlessItems(msg DataLoadedMsg, mask appconfig.TagMask) any {
+	switch msg.View {
+	case ViewEC2Instances:
+		items := make([]EC2Instance, len(msg.Instances))
+		for i, inst := range msg.Instances {
+			inst.Tags = mask.Apply(inst.Tags)
+			items[i] = inst
+		}
+		return items
+	case ViewEKSClusters:
+		return append([]EKSCluster{}, msg.Clusters...)
+	case ViewASGs:
+		items := make([]ASG, len(msg.ASGs))
+		for i, asg := range msg.ASGs {
+			asg.Tags = mask.Apply(asg.Tags)
+			items[i] = asg
+		}
+		return items
+	case ViewNodeGroups:
+		items := make([]NodeGroup, len(msg.NodeGroups))
+		for i, ng := range msg.NodeGroups {
+			ng.Tags = mask.Apply(ng.Tags)
+			items[i] = ng
+		}
+		return items
+	case ViewNetworkInterfaces:
+		return append([]aws.InstanceInterfaces{}, msg.NetworkInstances...)
+	default:
+		return nil
+	}
+}
+
+// headlessViewName returns the stable view identifier used in events
+func headlessViewName(view ViewMode) string {
+	switch view {
+	case ViewEC2Instances:
+		return "instances"
+	case ViewEKSClusters:
+		return "clusters"
+	case ViewASGs:
+		return "asgs"
+	case ViewNodeGroups:
+		return "nodegroups"
+	case ViewNetworkInterfaces:
+		return "network"
+	default:
+		return "unknown"
+	}
+}
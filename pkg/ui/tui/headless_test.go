@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appconfig "github.com/johnlam90/aws-ssm/pkg/config"
+)
+
+// decodeHeadlessEvents parses the JSON lines written by RunHeadless, keyed by view
+func decodeHeadlessEvents(t *testing.T, out string) map[string]map[string]any {
+	t.Helper()
+	events := make(map[string]map[string]any)
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var event map[string]any
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("invalid JSON event %q: %v", line, err)
+		}
+		view, _ := event["view"].(string)
+		events[view] = event
+	}
+	return events
+}
+
+func loaderFor(msg tea.Msg) tea.Cmd {
+	return func() tea.Msg { return msg }
+}
+
+func TestRunHeadlessEmitsEventPerLoad(t *testing.T) {
+	loaders := []tea.Cmd{
+		loaderFor(DataLoadedMsg{View: ViewEC2Instances, Instances: []EC2Instance{
+			{InstanceID: "i-1", Tags: map[string]string{"Owner": "alice", "Env": "prod"}},
+		}}),
+		loaderFor(DataLoadedMsg{View: ViewEKSClusters, Clusters: []EKSCluster{{Name: "prod"}, {Name: "dev"}}}),
+		loaderFor(DataLoadedMsg{View: ViewASGs, ASGs: []ASG{}}),
+	}
+
+	var out bytes.Buffer
+	if err := RunHeadless(&out, appconfig.NewTagMask([]string{"owner"}), loaders...); err != nil {
+		t.Fatalf("RunHeadless() error = %v", err)
+	}
+
+	events := decodeHeadlessEvents(t, out.String())
+	wantItems := map[string]int{"instances": 1, "clusters": 2, "asgs": 0}
+	if len(events) != len(wantItems) {
+		t.Fatalf("got %d events, want %d:\n%s", len(events), len(wantItems), out.String())
+	}
+	for view, count := range wantItems {
+		event, ok := events[view]
+		if !ok {
+			t.Errorf("missing event for view %q", view)
+			continue
+		}
+		if event["type"] != HeadlessEventLoaded {
+			t.Errorf("%s event type = %v, want %q", view, event["type"], HeadlessEventLoaded)
+		}
+		items, ok := event["items"].([]any)
+		if !ok || len(items) != count {
+			t.Errorf("%s event items = %v, want %d items", view, event["items"], count)
+		}
+	}
+
+	if strings.Contains(out.String(), "alice") {
+		t.Errorf("sensitive tag value leaked into event stream:\n%s", out.String())
+	}
+}
+
+func TestRunHeadlessEmitsErrorEvents(t *testing.T) {
+	loaders := []tea.Cmd{
+		loaderFor(DataLoadedMsg{View: ViewEC2Instances, Instances: []EC2Instance{{InstanceID: "i-1"}}}),
+		loaderFor(DataLoadedMsg{View: ViewASGs, Error: errors.New("throttled")}),
+		loaderFor(ErrorMsg{Err: errors.New("no credentials")}),
+	}
+
+	var out bytes.Buffer
+	err := RunHeadless(&out, appconfig.TagMask{}, loaders...)
+	if err == nil || !strings.Contains(err.Error(), "2 of 3") {
+		t.Errorf("RunHeadless() error = %v, want 2 of 3 loads failed", err)
+	}
+
+	events := decodeHeadlessEvents(t, out.String())
+	if event := events["asgs"]; event["type"] != HeadlessEventError || event["error"] != "throttled" {
+		t.Errorf("asgs event = %v, want error event with message", event)
+	}
+	if _, ok := events["asgs"]["items"]; ok {
+		t.Errorf("error event should not carry items: %v", events["asgs"])
+	}
+	if event := events[""]; event["type"] != HeadlessEventError || event["error"] != "no credentials" {
+		t.Errorf("ErrorMsg event = %v, want error event without view", event)
+	}
+	if event := events["instances"]; event["type"] != HeadlessEventLoaded {
+		t.Errorf("instances event = %v, want loaded despite other failures", event)
+	}
+}
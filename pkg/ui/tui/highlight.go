@@ -0,0 +1,146 @@
+package tui
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ansiSequencePattern matches SGR escape sequences emitted by lipgloss
+var ansiSequencePattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// highlightSegment is a run of row text that either matched the search or not
+type highlightSegment struct {
+	Text  string
+	Match bool
+}
+
+// SearchMatchStyle returns the style used for matched search text within rows
+func SearchMatchStyle() lipgloss.Style {
+	theme := GetTheme()
+	return lipgloss.NewStyle().
+		Foreground(theme.AccentAmber()).
+		Bold(true).
+		Underline(true)
+}
+
+// HighlightMatches styles every occurrence of the query's search terms within
+// row. Existing escape sequences in row are preserved and never matched against.
+// The row is returned unchanged when colors are disabled or the query is empty.
+func HighlightMatches(row, query string) string {
+	if !GetTheme().IsColorEnabled() {
+		return row
+	}
+	terms := highlightTerms(query)
+	if len(terms) == 0 {
+		return row
+	}
+
+	style := SearchMatchStyle()
+	var b strings.Builder
+	last := 0
+	render := func(plain string) {
+		for _, seg := range highlightSegments(plain, terms) {
+			if seg.Match {
+				b.WriteString(style.Render(seg.Text))
+			} else {
+				b.WriteString(seg.Text)
+			}
+		}
+	}
+	for _, loc := range ansiSequencePattern.FindAllStringIndex(row, -1) {
+		render(row[last:loc[0]])
+		b.WriteString(row[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	render(row[last:])
+	return b.String()
+}
+
+// highlightSearchMatches highlights matches of a view's active search query in a row
+func (m Model) highlightSearchMatches(row string, view ViewMode) string {
+	return HighlightMatches(row, m.getSearchQuery(view))
+}
+
+// highlightTerms extracts the substrings worth highlighting from a search query.
+// key:value tokens contribute their value; otherwise the whole query is used,
+// mirroring how the view filters interpret the query.
+func highlightTerms(query string) []string {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+
+	tokens := parseTokens(query)
+	if len(tokens) == 0 {
+		return []string{query}
+	}
+
+	terms := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		terms = append(terms, t[1])
+	}
+	return terms
+}
+
+// highlightSegments splits text into matched and unmatched segments. Matching is
+// case-insensitive, and overlapping or adjacent matches are merged into one segment.
+func highlightSegments(text string, terms []string) []highlightSegment {
+	if text == "" {
+		return nil
+	}
+
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) {
+		// Case folding changed byte offsets; fall back to exact matching
+		lower = text
+	}
+
+	var ranges [][2]int
+	for _, term := range terms {
+		if term == "" {
+			continue
+		}
+		for offset := 0; offset < len(lower); {
+			idx := strings.Index(lower[offset:], term)
+			if idx < 0 {
+				break
+			}
+			start := offset + idx
+			ranges = append(ranges, [2]int{start, start + len(term)})
+			offset = start + 1
+		}
+	}
+	if len(ranges) == 0 {
+		return []highlightSegment{{Text: text}}
+	}
+
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+	merged := [][2]int{ranges[0]}
+	for _, r := range ranges[1:] {
+		lastRange := &merged[len(merged)-1]
+		if r[0] <= lastRange[1] {
+			if r[1] > lastRange[1] {
+				lastRange[1] = r[1]
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+
+	var segments []highlightSegment
+	pos := 0
+	for _, r := range merged {
+		if r[0] > pos {
+			segments = append(segments, highlightSegment{Text: text[pos:r[0]]})
+		}
+		segments = append(segments, highlightSegment{Text: text[r[0]:r[1]], Match: true})
+		pos = r[1]
+	}
+	if pos < len(text) {
+		segments = append(segments, highlightSegment{Text: text[pos:]})
+	}
+	return segments
+}
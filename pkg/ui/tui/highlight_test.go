@@ -0,0 +1,130 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestHighlightSegments(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		terms []string
+		want  []highlightSegment
+	}{
+		{
+			name:  "single match",
+			text:  "web-server",
+			terms: []string{"server"},
+			want:  []highlightSegment{{Text: "web-"}, {Text: "server", Match: true}},
+		},
+		{
+			name:  "case insensitive match keeps original casing",
+			text:  "Web-Server",
+			terms: []string{"web"},
+			want:  []highlightSegment{{Text: "Web", Match: true}, {Text: "-Server"}},
+		},
+		{
+			name:  "multiple occurrences",
+			text:  "api-api-db",
+			terms: []string{"api"},
+			want: []highlightSegment{
+				{Text: "api", Match: true}, {Text: "-"}, {Text: "api", Match: true}, {Text: "-db"},
+			},
+		},
+		{
+			name:  "overlapping occurrences are merged",
+			text:  "aaaa-b",
+			terms: []string{"aa"},
+			want:  []highlightSegment{{Text: "aaaa", Match: true}, {Text: "-b"}},
+		},
+		{
+			name:  "overlapping terms are merged",
+			text:  "production",
+			terms: []string{"prod", "duct"},
+			want:  []highlightSegment{{Text: "product", Match: true}, {Text: "ion"}},
+		},
+		{
+			name:  "multiple terms in different places",
+			text:  "web-1 running",
+			terms: []string{"web", "run"},
+			want: []highlightSegment{
+				{Text: "web", Match: true}, {Text: "-1 "}, {Text: "run", Match: true}, {Text: "ning"},
+			},
+		},
+		{
+			name:  "no match",
+			text:  "db-1",
+			terms: []string{"web"},
+			want:  []highlightSegment{{Text: "db-1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := highlightSegments(tt.text, tt.terms)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("highlightSegments(%q, %v) = %+v, want %+v", tt.text, tt.terms, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighlightTerms(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", nil},
+		{"  Web  ", []string{"web"}},
+		{"web server", []string{"web server"}},
+		{"name:web state:running", []string{"web", "running"}},
+	}
+
+	for _, tt := range tests {
+		if got := highlightTerms(tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("highlightTerms(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestHighlightMatches(t *testing.T) {
+	origProfile := lipgloss.ColorProfile()
+	origTheme := GetTheme()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	defer func() {
+		lipgloss.SetColorProfile(origProfile)
+		SetTheme(origTheme)
+	}()
+
+	SetTheme(NewModernTheme(true))
+	row := "  web-server i-123 " + RenderStateCell("running", 8)
+	got := HighlightMatches(row, "web")
+
+	want := "  " + SearchMatchStyle().Render("web") + "-server i-123 " + RenderStateCell("running", 8)
+	if got != want {
+		t.Errorf("HighlightMatches() = %q, want %q", got, want)
+	}
+
+	// Escape sequences in the row must never be matched (e.g. "38" in a color code)
+	if got := HighlightMatches(row, "38"); got != row {
+		t.Errorf("expected escape sequences to be left untouched, got %q", got)
+	}
+
+	if got := HighlightMatches(row, ""); got != row {
+		t.Errorf("expected empty query to leave row unchanged, got %q", got)
+	}
+
+	SetTheme(NewModernTheme(false))
+	plain := "  web-server i-123"
+	if got := HighlightMatches(plain, "web"); got != plain {
+		t.Errorf("expected no highlighting in no-color mode, got %q", got)
+	}
+	if strings.Contains(HighlightMatches(plain, "web"), "\x1b[") {
+		t.Error("no-color output must not contain escape sequences")
+	}
+}
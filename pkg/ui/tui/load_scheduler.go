@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"context"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DefaultMaxConcurrentLoads is how many data loads run at once when unconfigured
+const DefaultMaxConcurrentLoads = 2
+
+// loadPriority orders waiting loads
+type loadPriority int
+
+const (
+	// loadBackground is used for prefetches the user is not waiting on
+	loadBackground loadPriority = iota
+	// loadForeground is used for the data of the current view
+	loadForeground
+)
+
+// loadWaiter is a load waiting for a free slot
+type loadWaiter struct {
+	priority loadPriority
+	ready    chan struct{}
+}
+
+// loadScheduler bounds how many data loads run at once. When a slot frees up,
+// waiting foreground loads start before background ones, each in the order
+// they were queued.
+type loadScheduler struct {
+	mu      sync.Mutex
+	limit   int
+	active  int
+	waiting []*loadWaiter
+}
+
+// newLoadScheduler returns a scheduler running at most limit loads at once;
+// limit <= 0 uses DefaultMaxConcurrentLoads
+func newLoadScheduler(limit int) *loadScheduler {
+	if limit <= 0 {
+		limit = DefaultMaxConcurrentLoads
+	}
+	return &loadScheduler{limit: limit}
+}
+
+// schedule wraps cmd so it runs once a slot is free. A nil scheduler runs cmd
+// unbounded. When ctx ends while waiting, the wrapped command returns nil.
+func (s *loadScheduler) schedule(ctx context.Context, priority loadPriority, cmd tea.Cmd) tea.Cmd {
+	if s == nil || cmd == nil {
+		return cmd
+	}
+	return func() tea.Msg {
+		if !s.acquire(ctx, priority) {
+			return nil
+		}
+		defer s.release()
+		return cmd()
+	}
+}
+
+// acquire blocks until a slot is held, or returns false when ctx ends first
+func (s *loadScheduler) acquire(ctx context.Context, priority loadPriority) bool {
+	s.mu.Lock()
+	if s.active < s.limit {
+		s.active++
+		s.mu.Unlock()
+		return true
+	}
+	w := &loadWaiter{priority: priority, ready: make(chan struct{})}
+	s.waiting = append(s.waiting, w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return true
+	case <-ctx.Done():
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for i, queued := range s.waiting {
+			if queued == w {
+				s.waiting = append(s.waiting[:i], s.waiting[i+1:]...)
+				return false
+			}
+		}
+		// The slot was handed over just as ctx ended; use it
+		return true
+	}
+}
+
+// release frees a slot, handing it to the next waiting load if there is one
+func (s *loadScheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next := -1
+	for i, w := range s.waiting {
+		if next < 0 || w.priority > s.waiting[next].priority {
+			next = i
+		}
+	}
+	if next < 0 {
+		s.active--
+		return
+	}
+	w := s.waiting[next]
+	s.waiting = append(s.waiting[:next], s.waiting[next+1:]...)
+	close(w.ready)
+}
+
+// queued returns how many loads are waiting for a slot
+func (s *loadScheduler) queued() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.waiting)
+}
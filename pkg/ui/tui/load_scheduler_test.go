@@ -0,0 +1,160 @@
+package tui
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// waitForLoads waits until the scheduler has active running and queued waiting loads
+func waitForLoads(t *testing.T, s *loadScheduler, active, queued int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		s.mu.Lock()
+		gotActive, gotQueued := s.active, len(s.waiting)
+		s.mu.Unlock()
+		if gotActive == active && gotQueued == queued {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("active/queued = %d/%d, want %d/%d", gotActive, gotQueued, active, queued)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestLoadSchedulerBoundsConcurrency(t *testing.T) {
+	for _, limit := range []int{1, 2, 3} {
+		s := newLoadScheduler(limit)
+		var active, peak, done int32
+		load := func() tea.Msg {
+			n := atomic.AddInt32(&active, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(2 * time.Millisecond)
+			atomic.AddInt32(&active, -1)
+			atomic.AddInt32(&done, 1)
+			return nil
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			priority := loadBackground
+			if i%3 == 0 {
+				priority = loadForeground
+			}
+			cmd := s.schedule(context.Background(), priority, load)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				cmd()
+			}()
+		}
+		wg.Wait()
+
+		if peak > int32(limit) {
+			t.Errorf("limit %d: %d loads ran at once", limit, peak)
+		}
+		if done != 20 {
+			t.Errorf("limit %d: %d of 20 loads ran", limit, done)
+		}
+	}
+}
+
+func TestLoadSchedulerRunsForegroundFirst(t *testing.T) {
+	s := newLoadScheduler(1)
+	unblock := make(chan struct{})
+	order := make(chan string, 4)
+	load := func(name string) tea.Cmd {
+		return func() tea.Msg {
+			order <- name
+			return nil
+		}
+	}
+
+	var wg sync.WaitGroup
+	run := func(cmd tea.Cmd) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cmd()
+		}()
+	}
+
+	// Hold the only slot while the other loads queue up
+	run(s.schedule(context.Background(), loadBackground, func() tea.Msg {
+		<-unblock
+		return nil
+	}))
+	waitForLoads(t, s, 1, 0)
+	run(s.schedule(context.Background(), loadBackground, load("count-ec2")))
+	waitForLoads(t, s, 1, 1)
+	run(s.schedule(context.Background(), loadBackground, load("count-asg")))
+	waitForLoads(t, s, 1, 2)
+	run(s.schedule(context.Background(), loadForeground, load("view")))
+	waitForLoads(t, s, 1, 3)
+
+	close(unblock)
+	wg.Wait()
+	close(order)
+
+	var got []string
+	for name := range order {
+		got = append(got, name)
+	}
+	want := []string{"view", "count-ec2", "count-asg"}
+	for i := range want {
+		if i >= len(got) || got[i] != want[i] {
+			t.Fatalf("load order = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestLoadSchedulerCancelledWhileWaiting(t *testing.T) {
+	s := newLoadScheduler(1)
+	unblock := make(chan struct{})
+	go s.schedule(context.Background(), loadForeground, func() tea.Msg {
+		<-unblock
+		return nil
+	})()
+	defer close(unblock)
+	waitForLoads(t, s, 1, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ran := false
+	result := make(chan tea.Msg, 1)
+	go func() {
+		result <- s.schedule(ctx, loadBackground, func() tea.Msg {
+			ran = true
+			return "loaded"
+		})()
+	}()
+	waitForLoads(t, s, 1, 1)
+	cancel()
+
+	if msg := <-result; msg != nil || ran {
+		t.Errorf("cancelled load ran (msg %v)", msg)
+	}
+	if s.queued() != 0 {
+		t.Errorf("cancelled load still queued")
+	}
+}
+
+func TestNewLoadSchedulerDefault(t *testing.T) {
+	if got := newLoadScheduler(0).limit; got != DefaultMaxConcurrentLoads {
+		t.Errorf("limit = %d, want %d", got, DefaultMaxConcurrentLoads)
+	}
+	model := NewModel(context.Background(), nil, Config{NoColor: true, MaxConcurrentLoads: 4})
+	if model.loads == nil || model.loads.limit != 4 {
+		t.Errorf("model scheduler = %+v, want limit 4", model.loads)
+	}
+}
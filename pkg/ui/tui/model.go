@@ -9,6 +9,8 @@ import (
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/johnlam90/aws-ssm/pkg/cache"
+	appconfig "github.com/johnlam90/aws-ssm/pkg/config"
 )
 
 // Model represents the main TUI model
@@ -28,7 +30,8 @@ type Model struct {
 	spinner     spinner.Model
 
 	// Navigation
-	navigation *NavigationManager
+	navigation     *NavigationManager
+	viewSelections []string // Item selected in each stacked view, for the breadcrumb
 
 	// Data
 	ec2Instances       []EC2Instance
@@ -41,7 +44,9 @@ type Model struct {
 	filteredNodeGroups []NodeGroup
 	netInterfaces      []aws.InstanceInterfaces
 	filteredNetworks   []aws.InstanceInterfaces
+	orphanedENIs       []aws.OrphanedENI
 	selectedItems      map[ViewMode]string
+	loadWarnings       map[ViewMode]string // Per view, what failed in its last partial load
 
 	// Dashboard menu items
 	menuItems []MenuItem
@@ -59,6 +64,23 @@ type Model struct {
 	ltUpdate        *LaunchTemplateUpdateState
 	statusMessage   string
 	statusAnimation *StatusAnimation
+
+	// Current EC2 instance order, toggled with the newest-first shortcut
+	ec2Sort aws.InstanceSort
+
+	// Custom EC2 row layout; nil uses the default columns
+	ec2RowTemplate        *RowTemplate
+	ec2RowTemplateWarning string
+
+	// Redacts sensitive tag values in tables and details
+	tagMask appconfig.TagMask
+
+	// Dashboard resource counts, prefetched on startup
+	dashboardCounts map[ViewMode]dashboardCount
+	countCache      *cache.Service
+
+	// Bounds concurrent data loads, shared by every copy of the model
+	loads *loadScheduler
 }
 
 // NewModel creates a new TUI model
@@ -113,24 +135,57 @@ func NewModel(ctx context.Context, client *aws.Client, config Config) Model {
 	s.Spinner = spinner.Dot
 	s.Style = LoadingStyle()
 
-	return Model{
-		ctx:           ctx,
-		client:        client,
-		config:        config,
-		currentView:   ViewDashboard,
-		viewStack:     []ViewMode{},
-		cursor:        0,
-		menuItems:     menuItems,
-		spinner:       s,
-		searchQueries: map[ViewMode]string{},
-		navigation:    navigation,
-		selectedItems: map[ViewMode]string{},
+	model := Model{
+		ctx:             ctx,
+		client:          client,
+		config:          config,
+		currentView:     ViewDashboard,
+		viewStack:       []ViewMode{},
+		cursor:          0,
+		menuItems:       menuItems,
+		spinner:         s,
+		searchQueries:   map[ViewMode]string{},
+		navigation:      navigation,
+		selectedItems:   map[ViewMode]string{},
+		loadWarnings:    map[ViewMode]string{},
+		dashboardCounts: newDashboardCounts(client != nil),
+		ec2Sort:         config.EC2Sort,
+		loads:           newLoadScheduler(config.MaxConcurrentLoads),
+	}
+	if client != nil && client.AppConfig != nil && client.AppConfig.Cache.Enabled {
+		// Counts still load without the cache, just never from a warm entry
+		model.countCache, _ = cache.NewCacheServiceFromConfig(client.AppConfig)
+	}
+	model.tagMask = appconfig.NewTagMask(config.SensitiveTags)
+	model.ec2RowTemplate, model.ec2RowTemplateWarning = loadRowTemplate(config.EC2RowTemplate)
+	if model.ec2RowTemplate != nil {
+		model.ec2RowTemplate.tagMask = model.tagMask
 	}
+	return model
+}
+
+// loadRowTemplate parses a configured row template, returning a warning instead
+// of a template when it is invalid so the default layout is used
+func loadRowTemplate(template string) (*RowTemplate, string) {
+	if strings.TrimSpace(template) == "" {
+		return nil, ""
+	}
+	parsed, err := ParseRowTemplate(template)
+	if err != nil {
+		return nil, fmt.Sprintf("Invalid row template, using default layout: %v", err)
+	}
+	return parsed, ""
 }
 
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
-	return m.spinner.Tick
+	if m.client == nil {
+		return m.spinner.Tick
+	}
+	return tea.Batch(
+		m.spinner.Tick,
+		PrefetchDashboardCountsCmd(m.ctx, m.client, m.countCache, m.client.GetRegion(), m.getProfile(), m.loads),
+	)
 }
 
 // Update handles messages and updates the model
@@ -176,6 +231,8 @@ func (m Model) updateNonKeyMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	case DataLoadedMsg:
 		return m.handleDataLoaded(v)
+	case DashboardCountMsg:
+		return m.handleDashboardCount(v)
 	case ErrorMsg:
 		m.err = v.Err
 		m.loading = false
@@ -271,12 +328,26 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleRefresh()
 	}
 
+	if blocked, ok := m.blockViewOnly(navAction); ok {
+		return blocked, nil
+	}
 	return m.handleNavigation(navAction)
 }
 
 // pushView pushes the current view onto the stack and switches to a new view
 func (m *Model) pushView(view ViewMode) {
+	m.pushViewFrom(view, "")
+}
+
+// pushViewFrom is pushView for drilling into selected, the item chosen in the
+// current view, which is then shown in the breadcrumb
+func (m *Model) pushViewFrom(view ViewMode, selected string) {
+	// Keep selections aligned with the stack
+	for len(m.viewSelections) < len(m.viewStack) {
+		m.viewSelections = append(m.viewSelections, "")
+	}
 	m.viewStack = append(m.viewStack, m.currentView)
+	m.viewSelections = append(m.viewSelections, selected)
 	m.currentView = view
 	m.cursor = 0 // Reset cursor when changing views
 	m.scaling = nil
@@ -288,6 +359,9 @@ func (m Model) navigateBack() Model {
 	if len(m.viewStack) > 0 {
 		m.currentView = m.viewStack[len(m.viewStack)-1]
 		m.viewStack = m.viewStack[:len(m.viewStack)-1]
+		if len(m.viewSelections) > len(m.viewStack) {
+			m.viewSelections = m.viewSelections[:len(m.viewStack)]
+		}
 		m.cursor = 0
 		m.scaling = nil
 		m.statusMessage = ""
@@ -334,18 +408,24 @@ func (m Model) handleDataLoaded(msg DataLoadedMsg) (tea.Model, tea.Cmd) {
 		m.err = msg.Error
 		return m, nil
 	}
+	m = m.setLoadWarning(msg.View, msg.Warning)
 
 	switch msg.View {
 	case ViewEC2Instances:
 		m.ec2Instances = msg.Instances
+		sortEC2Instances(m.ec2Instances, m.ec2Sort)
+		m.setDashboardCount(msg.View, dashboardCount{count: len(msg.Instances), loaded: true})
 	case ViewEKSClusters:
 		m.eksClusters = msg.Clusters
+		m.setDashboardCount(msg.View, dashboardCount{count: len(msg.Clusters), loaded: true})
 	case ViewASGs:
 		m.asgs = msg.ASGs
+		m.setDashboardCount(msg.View, dashboardCount{count: len(msg.ASGs), loaded: true})
 	case ViewNodeGroups:
 		m.nodeGroups = msg.NodeGroups
 	case ViewNetworkInterfaces:
 		m.netInterfaces = msg.NetworkInstances
+		m.orphanedENIs = msg.OrphanedENIs
 	}
 
 	m = m.applyFiltersForView(msg.View)
@@ -368,8 +448,12 @@ func (m Model) getStatusBar() string {
 		profile = "default"
 	}
 
-	return fmt.Sprintf("%s | %s | %s",
+	status := fmt.Sprintf("%s | %s | %s",
 		region, profile, m.currentView.String())
+	if m.config.ViewOnly {
+		status += " | " + viewOnlyIndicator
+	}
+	return status
 }
 
 // GetError returns the current error (for external access)
@@ -429,29 +513,34 @@ func (m Model) handleDashboardNavigation(action NavigationKey) (tea.Model, tea.C
 		case ViewEC2Instances:
 			m.loading = true
 			m.loadingMsg = "Loading EC2 instances..."
-			cmd = LoadEC2InstancesCmd(m.ctx, m.client)
+			cmd = m.foregroundLoad(LoadEC2InstancesCmd(m.ctx, m.client))
 		case ViewEKSClusters:
 			m.loading = true
 			m.loadingMsg = "Loading EKS clusters..."
-			cmd = LoadEKSClustersCmd(m.ctx, m.client)
+			cmd = m.foregroundLoad(LoadEKSClustersCmd(m.ctx, m.client))
 		case ViewASGs:
 			m.loading = true
 			m.loadingMsg = "Loading Auto Scaling Groups..."
-			cmd = LoadASGsCmd(m.ctx, m.client)
+			cmd = m.foregroundLoad(LoadASGsCmd(m.ctx, m.client))
 		case ViewNodeGroups:
 			m.loading = true
 			m.loadingMsg = "Loading EKS node groups..."
-			cmd = LoadNodeGroupsCmd(m.ctx, m.client)
+			cmd = m.foregroundLoad(LoadNodeGroupsCmd(m.ctx, m.client))
 		case ViewNetworkInterfaces:
 			m.loading = true
 			m.loadingMsg = "Loading network interfaces..."
-			cmd = LoadNetworkInterfacesCmd(m.ctx, m.client)
+			cmd = m.foregroundLoad(LoadNetworkInterfacesCmd(m.ctx, m.client))
 		}
 		return m, cmd
 	}
 	return m, nil
 }
 
+// foregroundLoad schedules a load for the current view ahead of background loads
+func (m Model) foregroundLoad(cmd tea.Cmd) tea.Cmd {
+	return m.loads.schedule(m.ctx, loadForeground, cmd)
+}
+
 // handleRefresh handles refresh based on current view
 func (m Model) handleRefresh() (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
@@ -461,23 +550,23 @@ func (m Model) handleRefresh() (tea.Model, tea.Cmd) {
 	case ViewEC2Instances:
 		m.loading = true
 		m.loadingMsg = "Refreshing EC2 instances..."
-		cmd = LoadEC2InstancesCmd(m.ctx, m.client)
+		cmd = m.foregroundLoad(LoadEC2InstancesCmd(m.ctx, m.client))
 	case ViewEKSClusters:
 		m.loading = true
 		m.loadingMsg = "Refreshing EKS clusters..."
-		cmd = LoadEKSClustersCmd(m.ctx, m.client)
+		cmd = m.foregroundLoad(LoadEKSClustersCmd(m.ctx, m.client))
 	case ViewASGs:
 		m.loading = true
 		m.loadingMsg = "Refreshing Auto Scaling Groups..."
-		cmd = LoadASGsCmd(m.ctx, m.client)
+		cmd = m.foregroundLoad(LoadASGsCmd(m.ctx, m.client))
 	case ViewNodeGroups:
 		m.loading = true
 		m.loadingMsg = "Refreshing EKS node groups..."
-		cmd = LoadNodeGroupsCmd(m.ctx, m.client)
+		cmd = m.foregroundLoad(LoadNodeGroupsCmd(m.ctx, m.client))
 	case ViewNetworkInterfaces:
 		m.loading = true
 		m.loadingMsg = "Refreshing network interfaces..."
-		cmd = LoadNetworkInterfacesCmd(m.ctx, m.client)
+		cmd = m.foregroundLoad(LoadNetworkInterfacesCmd(m.ctx, m.client))
 	default:
 		m.statusMessage = "Refresh not available for this view"
 	}
@@ -499,6 +588,8 @@ func (m Model) handleEC2Navigation(action NavigationKey) (tea.Model, tea.Cmd) {
 		return m.ec2ScaleNotice(), nil
 	case NavFilter:
 		return m.ec2FilterHint(), nil
+	case NavSort:
+		return m.toggleEC2NewestFirst(), nil
 	}
 	return m, nil
 }
@@ -556,6 +647,23 @@ func (m Model) ec2ScaleNotice() Model {
 	return m
 }
 
+// toggleEC2NewestFirst switches between newest-first and the configured sort,
+// keeping the selected instance under the cursor
+func (m Model) toggleEC2NewestFirst() Model {
+	m.captureSelection(ViewEC2Instances)
+	next := ec2ToggledSort(m.ec2Sort, m.config.EC2Sort)
+	m.ec2Sort = next
+	sortEC2Instances(m.ec2Instances, next)
+	m = m.applyFiltersForView(ViewEC2Instances)
+	m = m.restoreSelection(ViewEC2Instances)
+	if next == aws.NewestFirst {
+		m.statusMessage = "Sorted by launch time, newest first"
+	} else {
+		m.statusMessage = fmt.Sprintf("Sorted by %s", next)
+	}
+	return m
+}
+
 func (m Model) ec2FilterHint() Model {
 	m.statusMessage = "Filter by: running, stopped, terminated"
 	return m
@@ -571,10 +679,10 @@ func (m Model) handleEKSNavigation(action NavigationKey) (tea.Model, tea.Cmd) {
 	case NavSelect:
 		if m.cursor >= 0 && m.cursor < len(clusters) {
 			clusterName := clusters[m.cursor].Name
-			m.pushView(ViewNodeGroups)
+			m.pushViewFrom(ViewNodeGroups, clusterName)
 			m.loading = true
 			m.loadingMsg = fmt.Sprintf("Loading node groups for %s...", clusterName)
-			return m, LoadNodeGroupsCmd(m.ctx, m.client)
+			return m, m.foregroundLoad(LoadNodeGroupsCmd(m.ctx, m.client))
 		}
 	case NavDetails:
 		if m.cursor >= 0 && m.cursor < len(clusters) {
@@ -642,6 +750,9 @@ func (m Model) handleNodeGroupLaunchTemplateShortcut(msg tea.KeyMsg) (tea.Model,
 
 	switch msg.String() {
 	case "u", "U":
+		if blocked, ok := m.blockViewOnly(NavSelect); ok {
+			return blocked, nil, true
+		}
 		groups := m.getNodeGroups()
 		if len(groups) == 0 || m.cursor < 0 || m.cursor >= len(groups) {
 			return m, nil, true
@@ -7,7 +7,9 @@ import (
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/johnlam90/aws-ssm/pkg/aws"
+	"github.com/johnlam90/aws-ssm/pkg/cache"
 )
 
 func TestNewModel(t *testing.T) {
@@ -413,3 +415,288 @@ func contains(s, substr string) bool {
 	}
 	return false
 }
+
+func TestOrphanedENINotice(t *testing.T) {
+	if got := orphanedENINotice(nil); got != "" {
+		t.Errorf("orphanedENINotice(nil) = %q, want empty", got)
+	}
+
+	orphans := []aws.OrphanedENI{
+		{NetworkInterfaceID: "eni-1"}, {NetworkInterfaceID: "eni-2"},
+		{NetworkInterfaceID: "eni-3"}, {NetworkInterfaceID: "eni-4"},
+	}
+	got := orphanedENINotice(orphans)
+	for _, want := range []string{"4 orphaned", "eni-1, eni-2, eni-3, ...", "eni orphans"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("notice %q missing %q", got, want)
+		}
+	}
+	if strings.Contains(got, "eni-4") {
+		t.Errorf("notice %q should list at most three IDs", got)
+	}
+}
+
+// fakeCountSource is a dashboardCountSource with canned results
+type fakeCountSource struct {
+	instances int
+	clusters  int
+	asgs      int
+	err       error
+	calls     int
+}
+
+func (f *fakeCountSource) ListInstances(context.Context, map[string]string) ([]aws.Instance, error) {
+	f.calls++
+	return make([]aws.Instance, f.instances), f.err
+}
+
+func (f *fakeCountSource) ListClusters(context.Context) ([]string, error) {
+	f.calls++
+	return make([]string, f.clusters), f.err
+}
+
+func (f *fakeCountSource) ListAutoScalingGroups(context.Context) ([]string, error) {
+	f.calls++
+	return make([]string, f.asgs), f.err
+}
+
+func TestDashboardShowsPrefetchedCounts(t *testing.T) {
+	model := NewModel(context.Background(), &aws.Client{}, Config{NoColor: true})
+	model.width = 120
+	model.ready = true
+
+	view := model.renderDashboard()
+	if !strings.Contains(view, "COUNT") {
+		t.Fatalf("dashboard should have a COUNT column:\n%s", view)
+	}
+	if strings.Count(view, "…") != len(dashboardCountViews) {
+		t.Errorf("counted items should show a pending marker before loads finish:\n%s", view)
+	}
+
+	src := &fakeCountSource{instances: 42, clusters: 3, asgs: 7}
+	for _, v := range dashboardCountViews {
+		msg := loadDashboardCountCmd(context.Background(), src, nil, v, "us-east-1", "default")()
+		updated, _ := model.Update(msg)
+		model = updated.(Model)
+	}
+
+	view = model.renderDashboard()
+	for _, want := range []struct{ title, count string }{
+		{"EC2 Instances", "42"},
+		{"EKS Clusters", "3"},
+		{"Auto Scaling Groups", "7"},
+	} {
+		if !lineContains(view, want.title, want.count) {
+			t.Errorf("dashboard line for %s should show count %s:\n%s", want.title, want.count, view)
+		}
+	}
+	if strings.Contains(view, "…") {
+		t.Errorf("no pending markers expected after all counts loaded:\n%s", view)
+	}
+}
+
+func TestDashboardCountErrorAndDataLoaded(t *testing.T) {
+	model := NewModel(context.Background(), &aws.Client{}, Config{NoColor: true})
+
+	updated, _ := model.Update(DashboardCountMsg{View: ViewEKSClusters, Error: fmt.Errorf("denied")})
+	model = updated.(Model)
+	if got := model.dashboardCountLabel(ViewEKSClusters); got != "?" {
+		t.Errorf("count label after error = %q, want ?", got)
+	}
+
+	// Loading a view refreshes its dashboard count
+	updated, _ = model.Update(DataLoadedMsg{View: ViewEKSClusters, Clusters: []EKSCluster{{Name: "a"}, {Name: "b"}}})
+	model = updated.(Model)
+	if got := model.dashboardCountLabel(ViewEKSClusters); got != "2" {
+		t.Errorf("count label after data load = %q, want 2", got)
+	}
+	if got := model.dashboardCountLabel(ViewHelp); got != "" {
+		t.Errorf("views without counts should have an empty label, got %q", got)
+	}
+}
+
+func TestDashboardCountUsesWarmCache(t *testing.T) {
+	svc, err := cache.NewCacheService(t.TempDir(), 5)
+	if err != nil {
+		t.Fatalf("NewCacheService() error = %v", err)
+	}
+	src := &fakeCountSource{instances: 5}
+
+	first := loadDashboardCountCmd(context.Background(), src, svc, ViewEC2Instances, "us-east-1", "dev")().(DashboardCountMsg)
+	if first.Cached || first.Count != 5 {
+		t.Fatalf("first load = %+v, want uncached count 5", first)
+	}
+
+	src.instances = 9
+	second := loadDashboardCountCmd(context.Background(), src, svc, ViewEC2Instances, "us-east-1", "dev")().(DashboardCountMsg)
+	if !second.Cached || second.Count != 5 {
+		t.Errorf("second load = %+v, want cached count 5", second)
+	}
+	if src.calls != 1 {
+		t.Errorf("AWS calls = %d, want 1", src.calls)
+	}
+
+	other := loadDashboardCountCmd(context.Background(), src, svc, ViewEC2Instances, "eu-west-1", "dev")().(DashboardCountMsg)
+	if other.Cached || other.Count != 9 {
+		t.Errorf("other region load = %+v, want uncached count 9", other)
+	}
+}
+
+// lineContains reports whether a single line of s contains every part
+func lineContains(s string, parts ...string) bool {
+	for _, line := range strings.Split(s, "\n") {
+		all := true
+		for _, p := range parts {
+			if !strings.Contains(line, p) {
+				all = false
+				break
+			}
+		}
+		if all {
+			return true
+		}
+	}
+	return false
+}
+
+func TestBreadcrumbFollowsViewStack(t *testing.T) {
+	model := NewModel(context.Background(), &aws.Client{}, Config{NoColor: true})
+	model.ready = true
+	model.width = 200
+	model.height = 40
+
+	if got := model.renderBreadcrumb(model.width); got != "" {
+		t.Errorf("dashboard breadcrumb = %q, want empty", got)
+	}
+
+	model.pushView(ViewEKSClusters)
+	model.eksClusters = []EKSCluster{{Name: "dev-cluster"}, {Name: "prod-cluster"}}
+	model.cursor = 1
+
+	updated, _ := model.handleEKSNavigation(NavSelect)
+	model = updated.(Model)
+	model.loading = false
+	model.nodeGroups = []NodeGroup{{ClusterName: "prod-cluster", Name: "workers"}}
+
+	want := "Dashboard › EKS Clusters › prod-cluster › EKS Node Groups"
+	if got := strings.Join(model.breadcrumb(), breadcrumbSeparator); got != want {
+		t.Errorf("breadcrumb = %q, want %q", got, want)
+	}
+	if view := model.renderNodeGroups(); !strings.Contains(view, want) {
+		t.Errorf("node group view should render the breadcrumb %q", want)
+	}
+
+	model.ltUpdate = &LaunchTemplateUpdateState{ClusterName: "prod-cluster", NodeGroupName: "workers"}
+	if got := model.breadcrumb(); got[len(got)-2] != "workers" || got[len(got)-1] != "Launch Template Versions" {
+		t.Errorf("breadcrumb with launch template prompt = %v", got)
+	}
+	model.ltUpdate = nil
+
+	model = model.navigateBack()
+	if got := strings.Join(model.breadcrumb(), breadcrumbSeparator); got != "Dashboard › EKS Clusters" {
+		t.Errorf("breadcrumb after back = %q", got)
+	}
+}
+
+func TestFitBreadcrumbTruncates(t *testing.T) {
+	parts := []string{"Dashboard", "EKS Clusters", "prod-cluster", "EKS Node Groups"}
+
+	tests := []struct {
+		name     string
+		maxWidth int
+		want     string
+	}{
+		{name: "fits", maxWidth: 0, want: "Dashboard › EKS Clusters › prod-cluster › EKS Node Groups"},
+		{name: "drops leading parts", maxWidth: 40, want: "… › prod-cluster › EKS Node Groups"},
+		{name: "truncates last part", maxWidth: 8, want: "EKS Nod…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fitBreadcrumb(parts, tt.maxWidth)
+			if got != tt.want {
+				t.Errorf("fitBreadcrumb() = %q, want %q", got, tt.want)
+			}
+			if tt.maxWidth > 0 && lipgloss.Width(got) > tt.maxWidth {
+				t.Errorf("width %d exceeds %d", lipgloss.Width(got), tt.maxWidth)
+			}
+		})
+	}
+}
+
+func TestViewOnlyDisablesMutatingKeys(t *testing.T) {
+	keys := map[string]tea.KeyMsg{
+		"enter": {Type: tea.KeyEnter},
+		"u":     {Type: tea.KeyRunes, Runes: []rune{'u'}},
+	}
+	tests := []struct {
+		name string
+		view ViewMode
+		key  string
+	}{
+		{name: "connect to instance", view: ViewEC2Instances, key: "enter"},
+		{name: "scale ASG", view: ViewASGs, key: "enter"},
+		{name: "scale node group", view: ViewNodeGroups, key: "enter"},
+		{name: "update launch template", view: ViewNodeGroups, key: "u"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := NewModel(context.Background(), &aws.Client{}, Config{ViewOnly: true})
+			model.currentView = tt.view
+			model.ec2Instances = []EC2Instance{{InstanceID: "i-1", Name: "web", State: "running"}}
+			model.asgs = []ASG{{Name: "web-asg", DesiredCapacity: 2, MinSize: 1, MaxSize: 4}}
+			model.nodeGroups = []NodeGroup{{ClusterName: "cluster", Name: "ng", DesiredSize: 2, MinSize: 1, MaxSize: 4, LaunchTemplateID: "lt-1"}}
+
+			updated, cmd := model.Update(keys[tt.key])
+			m := updated.(Model)
+			if m.scaling != nil || m.ltUpdate != nil || m.pendingSSMSession != nil || cmd != nil {
+				t.Fatalf("%s should be inert in view-only mode (scaling=%v ltUpdate=%v session=%v cmd=%v)",
+					tt.key, m.scaling, m.ltUpdate, m.pendingSSMSession, cmd != nil)
+			}
+			if !strings.Contains(m.statusMessage, "View-only") {
+				t.Errorf("status message = %q, want a view-only notice", m.statusMessage)
+			}
+		})
+	}
+}
+
+func TestViewOnlyAllowsNavigationAndDetails(t *testing.T) {
+	model := NewModel(context.Background(), &aws.Client{}, Config{ViewOnly: true})
+	model.pushView(ViewASGs)
+	model.asgs = []ASG{{Name: "web-asg", DesiredCapacity: 2}, {Name: "api-asg", DesiredCapacity: 3}}
+
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m := updated.(Model)
+	if m.cursor != 1 {
+		t.Fatalf("cursor = %d, want 1 after moving down", m.cursor)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m = updated.(Model)
+	if !strings.Contains(m.statusMessage, "api-asg") {
+		t.Errorf("status message = %q, want details for api-asg", m.statusMessage)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m = updated.(Model); m.currentView != ViewDashboard {
+		t.Errorf("current view = %s, want back on the dashboard", m.currentView)
+	}
+}
+
+func TestViewOnlyIndicatorAndFooter(t *testing.T) {
+	model := NewModel(context.Background(), &aws.Client{}, Config{ViewOnly: true})
+	model.currentView = ViewNodeGroups
+	if !strings.Contains(model.getStatusBar(), viewOnlyIndicator) {
+		t.Errorf("status bar = %q, want the view-only indicator", model.getStatusBar())
+	}
+
+	normal := NewModel(context.Background(), &aws.Client{}, Config{})
+	normal.currentView = ViewNodeGroups
+	if strings.Contains(normal.getStatusBar(), viewOnlyIndicator) {
+		t.Error("view-only indicator shown without --view-only")
+	}
+	if got, want := lipgloss.Width(model.renderNodeGroupFooter()), lipgloss.Width(normal.renderNodeGroupFooter()); got != want {
+		t.Errorf("footer width = %d, want %d with the same hints greyed out", got, want)
+	}
+}
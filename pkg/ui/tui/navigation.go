@@ -47,6 +47,8 @@ const (
 	NavDetails
 	// NavFilter opens the filter dialog
 	NavFilter
+	// NavSort toggles newest-first sorting
+	NavSort
 )
 
 // KeyBinding represents a keyboard shortcut
@@ -107,6 +109,7 @@ var viewKeyBindings = map[ViewMode][]KeyBinding{
 		{Key: "d", Description: "Show details", Action: NavDetails},
 		{Key: "s", Description: "Scale instance", Action: NavScale},
 		{Key: "f", Description: "Filter by state", Action: NavFilter},
+		{Key: "n", Description: "Toggle newest first", Action: NavSort},
 	},
 	ViewEKSClusters: {
 		{Key: "up, k", Description: "Move up", Action: NavUp},
@@ -297,6 +300,7 @@ func GetQuickReference() string {
 				{Key: "s", Description: "Scale"},
 				{Key: "/", Description: "Search"},
 				{Key: "f", Description: "Filter"},
+				{Key: "n", Description: "Newest first (EC2)"},
 			},
 		},
 		{
@@ -17,11 +17,28 @@ func (m Model) renderNetworkInterfaces() string {
 	cursor := clampIndex(m.cursor, len(instances))
 	selected := instances[cursor]
 	details := limitRenderedLines(renderNetworkDetails(selected, m.width), max(1, m.height-8))
-	visibleRows := calculateTableRows(m.height, 7, details)
+	notice := orphanedENINotice(m.orphanedENIs)
+	warning := m.renderLoadWarning(ViewNetworkInterfaces)
+	reserved := 7
+	if notice != "" {
+		reserved++
+	}
+	if warning != "" {
+		reserved++
+	}
+	visibleRows := calculateTableRows(m.height, reserved, details)
 
 	header := m.renderHeader("Network Interfaces", fmt.Sprintf("%d instances", len(instances)))
 	b.WriteString(header)
 	b.WriteString("\n\n")
+	if warning != "" {
+		b.WriteString(warning)
+		b.WriteString("\n")
+	}
+	if notice != "" {
+		b.WriteString(RenderStatusMessage(notice, "warning"))
+		b.WriteString("\n")
+	}
 	headerRow := fmt.Sprintf("  %-28s %-20s %-32s %6s", "NAME", "INSTANCE ID", "DNS NAME", "IFACES")
 	b.WriteString(TableHeaderStyle().Render(headerRow))
 	b.WriteString("\n")
@@ -35,7 +52,7 @@ func (m Model) renderNetworkInterfaces() string {
 		}
 		dns := normalizeValue(inst.DNSName, "n/a", 32)
 		row := fmt.Sprintf("  %-28s %-20s %-32s %6d", name, id, dns, len(inst.Interfaces))
-		b.WriteString(RenderSelectableRow(row, i == cursor))
+		b.WriteString(RenderSelectableRow(m.highlightSearchMatches(row, ViewNetworkInterfaces), i == cursor))
 		b.WriteString("\n")
 	}
 	b.WriteString("\n")
@@ -92,6 +109,10 @@ func (m Model) renderNetworkState(instances []aws.InstanceInterfaces) string {
 		return b.String()
 	}
 	if len(instances) == 0 {
+		if warning := m.renderLoadWarning(ViewNetworkInterfaces); warning != "" {
+			b.WriteString(warning)
+			b.WriteString("\n")
+		}
 		b.WriteString(SubtitleStyle().Render("No instances with network interfaces"))
 		b.WriteString("\n\n")
 		b.WriteString(HelpStyle().Render("esc:back"))
@@ -102,6 +123,25 @@ func (m Model) renderNetworkState(instances []aws.InstanceInterfaces) string {
 	return ""
 }
 
+// orphanedENINotice summarizes unattached ENIs, which the per-instance table cannot show
+func orphanedENINotice(orphans []aws.OrphanedENI) string {
+	if len(orphans) == 0 {
+		return ""
+	}
+	ids := make([]string, 0, 3)
+	for _, eni := range orphans {
+		if len(ids) == cap(ids) {
+			break
+		}
+		ids = append(ids, eni.NetworkInterfaceID)
+	}
+	notice := fmt.Sprintf("%d orphaned ENI(s) available and unattached: %s", len(orphans), strings.Join(ids, ", "))
+	if len(orphans) > len(ids) {
+		notice += ", ..."
+	}
+	return notice + " (see aws-ssm eni orphans)"
+}
+
 func calculateNetworkVisibleRange(total, cursor, visibleHeight int) (int, int) {
 	return calculateBoundedVisibleRange(total, cursor, visibleHeight)
 }
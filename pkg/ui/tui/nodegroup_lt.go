@@ -9,7 +9,7 @@ import (
 	"github.com/johnlam90/aws-ssm/pkg/aws"
 )
 
-// LaunchTemplateUpdateState tracks inline launch template updates
+// LaunchTemplateUpdateState tracks the launch template update modal
 type LaunchTemplateUpdateState struct {
 	ClusterName        string
 	NodeGroupName      string
@@ -17,8 +17,10 @@ type LaunchTemplateUpdateState struct {
 	LaunchTemplateName string
 	CurrentVersion     string
 	Options            []launchTemplateVersionOption
+	Versions           []aws.LaunchTemplateVersion
 	Cursor             int
 	Loading            bool
+	Confirming         bool
 	Submitting         bool
 	RequestedVersion   string
 	Error              error
@@ -50,7 +52,7 @@ func (m Model) startNodeGroupLaunchTemplateUpdate(ng NodeGroup) (Model, tea.Cmd)
 	m.statusMessage = ""
 	m.searchActive = false
 
-	return m, LoadLaunchTemplateVersionsCmd(m.ctx, m.client, ng.LaunchTemplateID, ng.ClusterName, ng.Name)
+	return m, m.foregroundLoad(LoadLaunchTemplateVersionsCmd(m.ctx, m.client, ng.LaunchTemplateID, ng.ClusterName, ng.Name))
 }
 
 // handleLaunchTemplateKeys processes keybindings for the launch template overlay
@@ -68,6 +70,10 @@ func (m Model) handleLaunchTemplateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	if state.Confirming {
+		return m.handleLaunchTemplateConfirmKeys(msg)
+	}
+
 	switch msg.String() {
 	case "esc":
 		return m.ltEsc(), nil
@@ -78,12 +84,25 @@ func (m Model) handleLaunchTemplateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "r":
 		return m.ltReload()
 	case "enter":
-		return m.ltApply()
+		return m.ltSelect(), nil
 	}
 
 	return m, nil
 }
 
+// handleLaunchTemplateConfirmKeys processes keys on the confirmation step
+func (m Model) handleLaunchTemplateConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "y", "Y":
+		return m.ltApply()
+	case "esc", "n", "N", "b":
+		// Return to the version list rather than closing the modal
+		m.ltUpdate.Confirming = false
+		return m, nil
+	}
+	return m, nil
+}
+
 func (m Model) ltEsc() Model {
 	m.ltUpdate = nil
 	return m
@@ -108,7 +127,23 @@ func (m Model) ltReload() (tea.Model, tea.Cmd) {
 	s := m.ltUpdate
 	s.Loading = true
 	s.Error = nil
-	return m, LoadLaunchTemplateVersionsCmd(m.ctx, m.client, s.LaunchTemplateID, s.ClusterName, s.NodeGroupName)
+	return m, m.foregroundLoad(LoadLaunchTemplateVersionsCmd(m.ctx, m.client, s.LaunchTemplateID, s.ClusterName, s.NodeGroupName))
+}
+
+// ltSelect moves from the version list to the confirmation step
+func (m Model) ltSelect() Model {
+	s := m.ltUpdate
+	if s.Loading || len(s.Options) == 0 {
+		return m
+	}
+	selected := s.Options[s.Cursor]
+	if selected.Value == s.CurrentVersion {
+		s.Error = fmt.Errorf("node group already uses version %s", selected.Value)
+		return m
+	}
+	s.Confirming = true
+	s.Error = nil
+	return m
 }
 
 func (m Model) ltApply() (tea.Model, tea.Cmd) {
@@ -117,6 +152,7 @@ func (m Model) ltApply() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 	selected := s.Options[s.Cursor]
+	s.Confirming = false
 	s.Submitting = true
 	s.RequestedVersion = selected.Value
 	s.Error = nil
@@ -135,12 +171,15 @@ func (m Model) handleLaunchTemplateVersions(msg LaunchTemplateVersionsMsg) (tea.
 	}
 
 	state.Loading = false
+	state.Confirming = false
 	if msg.Error != nil {
 		state.Options = nil
+		state.Versions = nil
 		state.Error = msg.Error
 		return m, nil
 	}
 
+	state.Versions = msg.Versions
 	state.Options = buildLaunchTemplateOptions(state.CurrentVersion, msg.Versions)
 	if len(state.Options) == 0 {
 		state.Cursor = 0
@@ -172,7 +211,7 @@ func (m Model) handleLaunchTemplateUpdateResult(msg LaunchTemplateUpdateResultMs
 
 	m.loading = true
 	m.loadingMsg = "Refreshing node groups..."
-	return m, LoadNodeGroupsCmd(m.ctx, m.client)
+	return m, m.foregroundLoad(LoadNodeGroupsCmd(m.ctx, m.client))
 }
 
 func findLaunchTemplateCursor(options []launchTemplateVersionOption, current string) int {
@@ -230,6 +269,9 @@ func buildLaunchTemplateOptions(current string, versions []aws.LaunchTemplateVer
 		if desc := strings.TrimSpace(v.VersionDescription); desc != "" {
 			details = append(details, desc)
 		}
+		if v.ImageID != "" {
+			details = append(details, v.ImageID)
+		}
 		if created := strings.TrimSpace(v.CreateTime); created != "" {
 			details = append(details, created)
 		}
@@ -244,7 +286,49 @@ func buildLaunchTemplateOptions(current string, versions []aws.LaunchTemplateVer
 	return opts
 }
 
-// renderLaunchTemplatePrompt renders the inline update overlay
+// resolveLaunchTemplateVersion finds the concrete version behind a version value,
+// resolving $Latest and $Default against the loaded versions
+func resolveLaunchTemplateVersion(value string, versions []aws.LaunchTemplateVersion) *aws.LaunchTemplateVersion {
+	var match *aws.LaunchTemplateVersion
+	for i := range versions {
+		v := &versions[i]
+		switch value {
+		case "$Latest":
+			if match == nil || v.VersionNumber > match.VersionNumber {
+				match = v
+			}
+		case "$Default":
+			if v.DefaultVersion {
+				return v
+			}
+		default:
+			if fmt.Sprintf("%d", v.VersionNumber) == value {
+				return v
+			}
+		}
+	}
+	return match
+}
+
+// launchTemplateChanges describes what differs between the current and target versions
+func launchTemplateChanges(current, target *aws.LaunchTemplateVersion) []string {
+	if current == nil || target == nil {
+		return nil
+	}
+
+	var changes []string
+	if current.ImageID != target.ImageID {
+		changes = append(changes, fmt.Sprintf("AMI: %s → %s",
+			normalizeValue(current.ImageID, "n/a", 0), normalizeValue(target.ImageID, "n/a", 0)))
+	}
+	if current.InstanceType != target.InstanceType {
+		changes = append(changes, fmt.Sprintf("Instance type: %s → %s",
+			normalizeValue(current.InstanceType, "n/a", 0), normalizeValue(target.InstanceType, "n/a", 0)))
+	}
+	return changes
+}
+
+// renderLaunchTemplatePrompt renders the launch template update modal
 func (m Model) renderLaunchTemplatePrompt() string {
 	if m.ltUpdate == nil {
 		return ""
@@ -253,9 +337,10 @@ func (m Model) renderLaunchTemplatePrompt() string {
 	state := m.ltUpdate
 	var b strings.Builder
 
-	title := fmt.Sprintf("Update Launch Template • %s / %s", state.ClusterName, state.NodeGroupName)
-	b.WriteString(TitleStyle().Render(title))
+	b.WriteString(ModalTitleStyle().Render("Update Launch Template"))
 	b.WriteString("\n")
+	b.WriteString(SubtitleStyle().Render(fmt.Sprintf("%s / %s", state.ClusterName, state.NodeGroupName)))
+	b.WriteString("\n\n")
 
 	ltName := normalizeValue(state.LaunchTemplateName, "n/a", 0)
 	fmt.Fprintf(&b, "Template: %s\n", ltName)
@@ -272,39 +357,80 @@ func (m Model) renderLaunchTemplatePrompt() string {
 	case len(state.Options) == 0:
 		b.WriteString(ErrorStyle().Render("No launch template versions available"))
 		b.WriteString("\n")
+	case state.Confirming:
+		m.renderLaunchTemplateConfirm(&b)
 	default:
-		start := state.Cursor - 3
+		m.renderLaunchTemplateOptions(&b)
+	}
+
+	if state.Error != nil {
+		b.WriteString("\n")
+		b.WriteString(ErrorStyle().Render(fmt.Sprintf("Error: %v", state.Error)))
+		b.WriteString("\n")
+	}
+
+	modalWidth := calculateModalWidth(m.width)
+	modal := ModalStyle().Width(modalWidth).Render(b.String())
+	return centerModal(modal, m.width)
+}
+
+// renderLaunchTemplateOptions renders the selectable version list
+func (m Model) renderLaunchTemplateOptions(b *strings.Builder) {
+	state := m.ltUpdate
+	start := state.Cursor - 3
+	if start < 0 {
+		start = 0
+	}
+	end := start + 7
+	if end > len(state.Options) {
+		end = len(state.Options)
+		start = end - 7
 		if start < 0 {
 			start = 0
 		}
-		end := start + 7
-		if end > len(state.Options) {
-			end = len(state.Options)
-			start = end - 7
-			if start < 0 {
-				start = 0
-			}
-		}
+	}
 
-		for i := start; i < end; i++ {
-			option := state.Options[i]
-			line := fmt.Sprintf("  %s", option.Label)
-			if option.Detail != "" {
-				line = fmt.Sprintf("%s — %s", line, option.Detail)
-			}
-			b.WriteString(RenderSelectableRow(line, i == state.Cursor))
-			b.WriteString("\n")
+	for i := start; i < end; i++ {
+		option := state.Options[i]
+		line := fmt.Sprintf("  %s", option.Label)
+		if option.Detail != "" {
+			line = fmt.Sprintf("%s — %s", line, option.Detail)
 		}
-
-		b.WriteString("\n")
-		b.WriteString(HelpStyle().Render("↑/k ↓/j select  enter:apply  r:reload  esc:cancel"))
+		b.WriteString(RenderSelectableRow(line, i == state.Cursor))
 		b.WriteString("\n")
 	}
 
-	if state.Error != nil {
-		b.WriteString(ErrorStyle().Render(fmt.Sprintf("Error: %v", state.Error)))
-		b.WriteString("\n")
+	b.WriteString("\n")
+	b.WriteString(ModalHelpStyle().Render("↑/k ↓/j select   enter:review   r:reload   esc:cancel"))
+	b.WriteString("\n")
+}
+
+// renderLaunchTemplateConfirm renders the review step before applying an update
+func (m Model) renderLaunchTemplateConfirm(b *strings.Builder) {
+	state := m.ltUpdate
+	selected := state.Options[state.Cursor]
+
+	b.WriteString(ModalLabelStyle().Render("Target configuration"))
+	b.WriteString("\n")
+	fmt.Fprintf(b, "  New version: %s\n\n", selected.Value)
+
+	current := resolveLaunchTemplateVersion(state.CurrentVersion, state.Versions)
+	target := resolveLaunchTemplateVersion(selected.Value, state.Versions)
+	b.WriteString(ModalLabelStyle().Render("Changes"))
+	b.WriteString("\n")
+	changes := launchTemplateChanges(current, target)
+	switch {
+	case current == nil || target == nil:
+		b.WriteString("  Version details unavailable\n")
+	case len(changes) == 0:
+		b.WriteString("  No AMI or instance type changes\n")
+	default:
+		for _, change := range changes {
+			fmt.Fprintf(b, "  %s\n", change)
+		}
 	}
 
-	return PanelStyle().Width(m.width).Render(b.String())
+	b.WriteString("\n")
+	b.WriteString(ModalHelpStyle().Render("Update the launch template version?   y/enter:apply   n/esc:back"))
+	b.WriteString("\n")
 }
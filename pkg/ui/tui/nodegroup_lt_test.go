@@ -2,6 +2,8 @@ package tui
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -85,3 +87,122 @@ func TestNavigationBindingForLaunchTemplateUpdate(t *testing.T) {
 		})
 	}
 }
+
+func newLaunchTemplateModalModel(t *testing.T) Model {
+	t.Helper()
+	model := NewModel(context.Background(), &aws.Client{}, Config{})
+	model.currentView = ViewNodeGroups
+	model.nodeGroups = []NodeGroup{{
+		ClusterName:           "cluster",
+		Name:                  "nodegroup",
+		LaunchTemplateID:      "lt-123",
+		LaunchTemplateName:    "lt-name",
+		LaunchTemplateVersion: "1",
+	}}
+
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	updated, _ = updated.(Model).Update(LaunchTemplateVersionsMsg{
+		ClusterName:   "cluster",
+		NodeGroupName: "nodegroup",
+		Versions: []aws.LaunchTemplateVersion{
+			{VersionNumber: 1, ImageID: "ami-old", InstanceType: "t3.large", DefaultVersion: true},
+			{VersionNumber: 2, ImageID: "ami-new", InstanceType: "t3.large", IsLatest: true},
+		},
+	})
+	return updated.(Model)
+}
+
+func TestLaunchTemplateModalSelectConfirmAndApply(t *testing.T) {
+	m := newLaunchTemplateModalModel(t)
+	if m.ltUpdate.Loading {
+		t.Fatal("expected versions to be loaded")
+	}
+	// Options: $Latest, $Default, 2, 1 with the cursor on the current version
+	if got := m.ltUpdate.Options[m.ltUpdate.Cursor].Value; got != "1" {
+		t.Fatalf("cursor starts on %q, want current version 1", got)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m = updated.(Model)
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if cmd != nil || !m.ltUpdate.Confirming {
+		t.Fatalf("enter should open the confirmation step without applying (confirming=%v)", m.ltUpdate.Confirming)
+	}
+
+	view := m.renderLaunchTemplatePrompt()
+	if !strings.Contains(view, "ami-old → ami-new") {
+		t.Errorf("confirmation should show the AMI change, got:\n%s", view)
+	}
+
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = updated.(Model)
+	if cmd == nil || !m.ltUpdate.Submitting || m.ltUpdate.RequestedVersion != "2" {
+		t.Fatalf("confirm should submit version 2, got state %+v", m.ltUpdate)
+	}
+
+	updated, _ = m.Update(LaunchTemplateUpdateResultMsg{ClusterName: "cluster", NodeGroupName: "nodegroup", Version: "2"})
+	m = updated.(Model)
+	if m.ltUpdate != nil {
+		t.Fatal("modal should close after a successful update")
+	}
+	if m.currentView != ViewNodeGroups {
+		t.Errorf("view = %v, want node group list", m.currentView)
+	}
+	if !strings.Contains(m.statusMessage, "to 2") {
+		t.Errorf("status message = %q", m.statusMessage)
+	}
+}
+
+func TestLaunchTemplateModalBackFromConfirm(t *testing.T) {
+	m := newLaunchTemplateModalModel(t)
+	m.ltUpdate.Cursor = 0 // $Latest
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.ltUpdate == nil || m.ltUpdate.Confirming {
+		t.Fatal("esc on the confirmation step should return to the version list")
+	}
+}
+
+func TestLaunchTemplateModalRejectsCurrentVersion(t *testing.T) {
+	m := newLaunchTemplateModalModel(t)
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if cmd != nil || m.ltUpdate.Confirming || m.ltUpdate.Error == nil {
+		t.Fatal("selecting the current version should report an error instead of confirming")
+	}
+}
+
+func TestLaunchTemplateModalUpdateErrorKeepsModalOpen(t *testing.T) {
+	m := newLaunchTemplateModalModel(t)
+	m.ltUpdate.Submitting = true
+	m.ltUpdate.RequestedVersion = "2"
+
+	updated, _ := m.Update(LaunchTemplateUpdateResultMsg{ClusterName: "cluster", NodeGroupName: "nodegroup", Version: "2", Error: errors.New("boom")})
+	m = updated.(Model)
+	if m.ltUpdate == nil || m.ltUpdate.Submitting || m.ltUpdate.Error == nil {
+		t.Fatalf("failed update should keep the modal open with the error, got %+v", m.ltUpdate)
+	}
+}
+
+func TestResolveLaunchTemplateVersion(t *testing.T) {
+	versions := []aws.LaunchTemplateVersion{
+		{VersionNumber: 1, DefaultVersion: true},
+		{VersionNumber: 3},
+		{VersionNumber: 2},
+	}
+	tests := map[string]int64{"$Latest": 3, "$Default": 1, "2": 2}
+	for value, want := range tests {
+		got := resolveLaunchTemplateVersion(value, versions)
+		if got == nil || got.VersionNumber != want {
+			t.Errorf("resolveLaunchTemplateVersion(%q) = %v, want version %d", value, got, want)
+		}
+	}
+	if got := resolveLaunchTemplateVersion("9", versions); got != nil {
+		t.Errorf("unknown version resolved to %v", got)
+	}
+}
@@ -3,6 +3,8 @@ package tui
 import (
 	"fmt"
 	"strings"
+
+	appconfig "github.com/johnlam90/aws-ssm/pkg/config"
 )
 
 // renderNodeGroups renders the EKS node groups view
@@ -14,12 +16,20 @@ func (m Model) renderNodeGroups() string {
 	var b strings.Builder
 	cursor := clampIndex(m.cursor, len(groups))
 	selected := groups[cursor]
-	details := renderNodeGroupDetails(selected)
+	details := renderNodeGroupDetails(selected, m.tagMask)
 	visibleRows := calculateNodeGroupTableRows(m.height, details)
+	warning := m.renderLoadWarning(ViewNodeGroups)
+	if warning != "" {
+		visibleRows = max(1, visibleRows-1)
+	}
 
 	header := m.renderHeader("EKS Node Groups", fmt.Sprintf("%d node groups", len(groups)))
 	b.WriteString(header)
 	b.WriteString("\n\n")
+	if warning != "" {
+		b.WriteString(warning)
+		b.WriteString("\n")
+	}
 	b.WriteString(TableHeaderStyle().Render(fmt.Sprintf("  %-24s %-28s %-10s %8s %8s %8s %8s",
 		"CLUSTER", "NODE GROUP", "STATUS", "DESIRED", "MIN", "MAX", "CURRENT")))
 	b.WriteString("\n")
@@ -31,7 +41,7 @@ func (m Model) renderNodeGroups() string {
 		status := RenderStateCell(ng.Status, 10)
 		row := fmt.Sprintf("  %-24s %-28s %s %8d %8d %8d %8d",
 			cluster, name, status, ng.DesiredSize, ng.MinSize, ng.MaxSize, ng.CurrentSize)
-		b.WriteString(RenderSelectableRow(row, i == cursor))
+		b.WriteString(RenderSelectableRow(m.highlightSearchMatches(row, ViewNodeGroups), i == cursor))
 		b.WriteString("\n")
 	}
 	b.WriteString("\n")
@@ -63,7 +73,7 @@ func (m Model) renderNodeGroups() string {
 	return b.String()
 }
 
-func renderNodeGroupDetails(selected NodeGroup) string {
+func renderNodeGroupDetails(selected NodeGroup, mask appconfig.TagMask) string {
 	var b strings.Builder
 	instanceTypes := strings.Join(selected.InstanceTypes, ", ")
 	if instanceTypes == "" {
@@ -84,7 +94,7 @@ func renderNodeGroupDetails(selected NodeGroup) string {
 		b.WriteString("  Launch template: n/a\n")
 	}
 	fmt.Fprintf(&b, "  Created:   %s\n", normalizeValue(selected.CreatedAt, "unknown", 0))
-	if lines := renderTagLines(selected.Tags); len(lines) > 0 {
+	if lines := renderTagLines(mask.Apply(selected.Tags)); len(lines) > 0 {
 		b.WriteString("  Tags:\n")
 		for _, line := range lines {
 			b.WriteString(line)
@@ -128,6 +138,10 @@ func (m Model) renderNodeGroupState(groups []NodeGroup) string {
 		return b.String()
 	}
 	if len(groups) == 0 {
+		if warning := m.renderLoadWarning(ViewNodeGroups); warning != "" {
+			b.WriteString(warning)
+			b.WriteString("\n")
+		}
 		b.WriteString(SubtitleStyle().Render("No EKS node groups found"))
 		b.WriteString("\n\n")
 		b.WriteString(HelpStyle().Render("esc:back"))
@@ -144,28 +158,16 @@ func calculateNodeGroupVisibleRange(total, cursor, visibleHeight int) (int, int)
 
 // renderNodeGroupFooter renders footer controls for node group view
 func (m Model) renderNodeGroupFooter() string {
-	keys := []struct {
-		key  string
-		desc string
-	}{
-		{"↑/k", "up"},
-		{"↓/j", "down"},
-		{"g/G", "top/bottom"},
-		{"enter", "scale"},
-		{"u/U", "update LT"},
-		{"r", "refresh"},
-		{"/", "search"},
-		{"esc", "back"},
-	}
-
-	var parts []string
-	for _, k := range keys {
-		keyStyle := StatusBarKeyStyle().Render(k.key)
-		descStyle := StatusBarValueStyle().Render(k.desc)
-		parts = append(parts, fmt.Sprintf("%s %s", keyStyle, descStyle))
-	}
-
-	return HelpStyle().Render(strings.Join(parts, " • "))
+	return m.renderFooterKeys([]footerKey{
+		{"↑/k", "up", false},
+		{"↓/j", "down", false},
+		{"g/G", "top/bottom", false},
+		{"enter", "scale", true},
+		{"u/U", "update LT", true},
+		{"r", "refresh", false},
+		{"/", "search", false},
+		{"esc", "back", false},
+	})
 }
 
 // clampIndex ensures cursor stays within list bounds
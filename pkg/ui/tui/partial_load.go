@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// describeAll calls describe for every target with at most limit calls in
+// flight. Results keep the order of targets; targets whose describe call
+// failed are left out and reported in failures. err is set only when ctx is
+// done, since a partial result is still worth showing otherwise.
+func describeAll[T, R any](ctx context.Context, targets []T, limit int, describe func(context.Context, T) (R, error)) (results []R, failures []error, err error) {
+	if len(targets) == 0 {
+		return []R{}, nil, nil
+	}
+	if limit > len(targets) {
+		limit = len(targets)
+	}
+	if limit < 1 {
+		limit = 1
+	}
+
+	var (
+		g   errgroup.Group
+		mu  sync.Mutex
+		out = make([]*R, len(targets))
+		sem = make(chan struct{}, limit)
+	)
+	for i, target := range targets {
+		g.Go(func() error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case sem <- struct{}{}:
+			}
+			defer func() { <-sem }()
+
+			result, describeErr := describe(ctx, target)
+			mu.Lock()
+			defer mu.Unlock()
+			if describeErr != nil {
+				failures = append(failures, describeErr)
+				return nil
+			}
+			out[i] = &result
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	results = make([]R, 0, len(targets))
+	for _, result := range out {
+		if result != nil {
+			results = append(results, *result)
+		}
+	}
+	return results, failures, nil
+}
+
+// loadWarning describes the failed sub-calls of a load, e.g.
+// "2 of 5 node groups failed to load: <first error>", or "" when none failed
+func loadWarning(what string, failures []error, total int) string {
+	if len(failures) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d of %d %s failed to load: %v", len(failures), total, what, failures[0])
+}
+
+// joinLoadWarnings combines the non-empty warnings of one load
+func joinLoadWarnings(warnings ...string) string {
+	nonEmpty := warnings[:0:0]
+	for _, w := range warnings {
+		if w != "" {
+			nonEmpty = append(nonEmpty, w)
+		}
+	}
+	return strings.Join(nonEmpty, "; ")
+}
+
+// setLoadWarning records the warning of view's latest load, clearing any
+// warning left by an earlier load when warning is empty
+func (m Model) setLoadWarning(view ViewMode, warning string) Model {
+	if warning == "" {
+		delete(m.loadWarnings, view)
+		return m
+	}
+	if m.loadWarnings == nil {
+		m.loadWarnings = map[ViewMode]string{}
+	}
+	m.loadWarnings[view] = warning
+	return m
+}
+
+// renderLoadWarning renders the banner for a view whose last load only
+// partially succeeded, or "" when everything loaded
+func (m Model) renderLoadWarning(view ViewMode) string {
+	warning := m.loadWarnings[view]
+	if warning == "" {
+		return ""
+	}
+	return RenderStatusMessage("Partial results: "+warning, "warning")
+}
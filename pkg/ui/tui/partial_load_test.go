@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	appconfig "github.com/johnlam90/aws-ssm/pkg/config"
+)
+
+func TestDescribeAllKeepsSuccessfulItems(t *testing.T) {
+	names := []string{"web", "broken", "batch", "api"}
+	results, failures, err := describeAll(context.Background(), names, 2, func(_ context.Context, name string) (ASG, error) {
+		if name == "broken" {
+			return ASG{}, fmt.Errorf("%s: %w", name, errors.New("throttled"))
+		}
+		return ASG{Name: name}, nil
+	})
+	if err != nil {
+		t.Fatalf("describeAll() error = %v", err)
+	}
+
+	var got []string
+	for _, asg := range results {
+		got = append(got, asg.Name)
+	}
+	if strings.Join(got, ",") != "web,batch,api" {
+		t.Errorf("results = %v, want the successful items in input order", got)
+	}
+	if len(failures) != 1 {
+		t.Fatalf("failures = %v, want 1", failures)
+	}
+	if want := "1 of 4 Auto Scaling Groups failed to load: broken: throttled"; loadWarning("Auto Scaling Groups", failures, len(names)) != want {
+		t.Errorf("loadWarning() = %q, want %q", loadWarning("Auto Scaling Groups", failures, len(names)), want)
+	}
+}
+
+func TestDescribeAllStopsOnCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err := describeAll(ctx, []string{"a", "b"}, 1, func(ctx context.Context, name string) (string, error) {
+		return name, ctx.Err()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("describeAll() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestLoadWarningsJoin(t *testing.T) {
+	if got := loadWarning("node groups", nil, 3); got != "" {
+		t.Errorf("loadWarning() without failures = %q, want empty", got)
+	}
+	got := joinLoadWarnings("", "1 of 2 clusters failed to load: x", "", "1 of 5 node groups failed to load: y")
+	if got != "1 of 2 clusters failed to load: x; 1 of 5 node groups failed to load: y" {
+		t.Errorf("joinLoadWarnings() = %q", got)
+	}
+}
+
+func TestPartialLoadRendersItemsWithWarning(t *testing.T) {
+	model := newScrolledRenderModel(ViewNodeGroups)
+	model.cursor = 0
+
+	updated, _ := model.Update(DataLoadedMsg{
+		View: ViewNodeGroups,
+		NodeGroups: []NodeGroup{
+			{ClusterName: "prod", Name: "workers", Status: "ACTIVE"},
+			{ClusterName: "prod", Name: "system", Status: "ACTIVE"},
+		},
+		Warning: "1 of 3 node groups failed to load: prod/gpu: AccessDenied",
+	})
+	model = updated.(Model)
+
+	if model.GetError() != nil {
+		t.Fatalf("partial load set a fatal error: %v", model.GetError())
+	}
+	view := model.renderNodeGroups()
+	for _, want := range []string{"workers", "system", "1 of 3 node groups failed to load"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("node group view missing %q:\n%s", want, view)
+		}
+	}
+	if lines := strings.Split(strings.TrimSuffix(view, "\n"), "\n"); len(lines) > model.height {
+		t.Errorf("node group view rendered %d lines for height %d", len(lines), model.height)
+	}
+
+	// A clean reload clears the banner
+	updated, _ = model.Update(DataLoadedMsg{View: ViewNodeGroups, NodeGroups: model.nodeGroups})
+	model = updated.(Model)
+	if view := model.renderNodeGroups(); strings.Contains(view, "failed to load") {
+		t.Errorf("warning still shown after a complete load:\n%s", view)
+	}
+}
+
+func TestPartialLoadWarningInHeadlessEvent(t *testing.T) {
+	event, ok := headlessEventFromMsg(DataLoadedMsg{
+		View:     ViewEKSClusters,
+		Clusters: []EKSCluster{{Name: "prod"}},
+		Warning:  "1 of 2 clusters failed to load: dev: AccessDenied",
+	}, appconfig.TagMask{})
+	if !ok || event.Type != HeadlessEventLoaded || event.Warning == "" {
+		t.Errorf("headless event = %+v, want a loaded event carrying the warning", event)
+	}
+}
@@ -0,0 +1,178 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	appconfig "github.com/johnlam90/aws-ssm/pkg/config"
+)
+
+// rowTemplateFields lists the EC2 fields a row template may reference
+var rowTemplateFields = map[string]func(EC2Instance) string{
+	"name":        func(i EC2Instance) string { return normalizeValue(i.Name, "(no name)", 0) },
+	"instance-id": func(i EC2Instance) string { return i.InstanceID },
+	"state":       func(i EC2Instance) string { return strings.ToLower(strings.TrimSpace(i.State)) },
+	"private-ip":  func(i EC2Instance) string { return i.PrivateIP },
+	"public-ip":   func(i EC2Instance) string { return i.PublicIP },
+	"private-dns": func(i EC2Instance) string { return i.PrivateDNS },
+	"public-dns":  func(i EC2Instance) string { return i.PublicDNS },
+	"type":        func(i EC2Instance) string { return i.InstanceType },
+	"platform":    func(i EC2Instance) string { return i.Platform },
+	"az":          func(i EC2Instance) string { return i.AvailabilityZone },
+	"profile":     func(i EC2Instance) string { return i.InstanceProfile },
+	"launch-time": func(i EC2Instance) string {
+		if i.LaunchTime.IsZero() {
+			return ""
+		}
+		return i.LaunchTime.Format(time.DateTime)
+	},
+}
+
+// rowTemplateSegment is either literal text or a field placeholder
+type rowTemplateSegment struct {
+	literal string
+	field   string // field name, or "tag:<Key>"
+	width   int    // 0 renders the value at its natural width
+}
+
+// RowTemplate renders EC2 table rows from a template such as
+// "{name:30} {instance-id:20} {state:10} {tag:Team:12}". A placeholder's
+// optional width pads or truncates the value to exactly that many characters.
+type RowTemplate struct {
+	segments []rowTemplateSegment
+	tagMask  appconfig.TagMask
+}
+
+// ParseRowTemplate parses a row template, rejecting unknown fields and bad widths
+func ParseRowTemplate(template string) (*RowTemplate, error) {
+	if strings.TrimSpace(template) == "" {
+		return nil, fmt.Errorf("row template is empty")
+	}
+
+	var segments []rowTemplateSegment
+	hasField := false
+	rest := template
+	for rest != "" {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			segments = append(segments, rowTemplateSegment{literal: rest})
+			break
+		}
+		if open > 0 {
+			segments = append(segments, rowTemplateSegment{literal: rest[:open]})
+		}
+		closing := strings.IndexByte(rest[open:], '}')
+		if closing < 0 {
+			return nil, fmt.Errorf("unclosed placeholder in row template %q", template)
+		}
+		segment, err := parseRowTemplatePlaceholder(rest[open+1 : open+closing])
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, segment)
+		hasField = true
+		rest = rest[open+closing+1:]
+	}
+
+	if !hasField {
+		return nil, fmt.Errorf("row template %q references no fields", template)
+	}
+	return &RowTemplate{segments: segments}, nil
+}
+
+// parseRowTemplatePlaceholder parses "field", "field:width", "tag:Key" or "tag:Key:width"
+func parseRowTemplatePlaceholder(placeholder string) (rowTemplateSegment, error) {
+	parts := strings.Split(placeholder, ":")
+	field := parts[0]
+	if field == "tag" {
+		if len(parts) < 2 || parts[1] == "" {
+			return rowTemplateSegment{}, fmt.Errorf("tag placeholder %q needs a key, e.g. {tag:Team}", placeholder)
+		}
+		field = "tag:" + parts[1]
+		parts = parts[1:]
+	} else if _, ok := rowTemplateFields[field]; !ok {
+		return rowTemplateSegment{}, fmt.Errorf("unknown row template field %q", field)
+	}
+
+	segment := rowTemplateSegment{field: field}
+	switch len(parts) {
+	case 1:
+	case 2:
+		width, err := strconv.Atoi(parts[1])
+		if err != nil || width <= 0 {
+			return rowTemplateSegment{}, fmt.Errorf("invalid width in placeholder {%s}", placeholder)
+		}
+		segment.width = width
+	default:
+		return rowTemplateSegment{}, fmt.Errorf("malformed placeholder {%s}", placeholder)
+	}
+	return segment, nil
+}
+
+// value returns the instance value for the segment's field, masking sensitive tags
+func (s rowTemplateSegment) value(inst EC2Instance, mask appconfig.TagMask) string {
+	if key, ok := strings.CutPrefix(s.field, "tag:"); ok {
+		return mask.Value(key, inst.Tags[key])
+	}
+	return rowTemplateFields[s.field](inst)
+}
+
+// Render renders a row for inst, bounded to maxWidth characters when positive.
+// The state field keeps its color.
+func (t *RowTemplate) Render(inst EC2Instance, maxWidth int) string {
+	return t.render(maxWidth, func(s rowTemplateSegment) string { return s.value(inst, t.tagMask) }, true)
+}
+
+// Header renders the column header row, bounded to maxWidth characters when positive
+func (t *RowTemplate) Header(maxWidth int) string {
+	return t.render(maxWidth, func(s rowTemplateSegment) string {
+		return strings.ToUpper(strings.TrimPrefix(s.field, "tag:"))
+	}, false)
+}
+
+func (t *RowTemplate) render(maxWidth int, valueFn func(rowTemplateSegment) string, colorState bool) string {
+	var b strings.Builder
+	used := 0
+	for _, segment := range t.segments {
+		text := segment.literal
+		if segment.field != "" {
+			text = fitWidth(valueFn(segment), segment.width)
+		}
+
+		truncated := false
+		if maxWidth > 0 {
+			if remaining := maxWidth - used; len([]rune(text)) > remaining {
+				text = string([]rune(text)[:remaining])
+				truncated = true
+			}
+		}
+		used += len([]rune(text))
+
+		if colorState && segment.field == "state" && text != "" {
+			b.WriteString(RenderStateCell(text, len([]rune(text))))
+		} else {
+			b.WriteString(text)
+		}
+		if truncated {
+			break
+		}
+	}
+	return b.String()
+}
+
+// fitWidth pads or truncates value to width characters; width 0 leaves it unchanged
+func fitWidth(value string, width int) string {
+	if width <= 0 {
+		return value
+	}
+	runes := []rune(value)
+	if len(runes) > width {
+		if width <= 3 {
+			return string(runes[:width])
+		}
+		return string(runes[:width-3]) + "..."
+	}
+	return value + strings.Repeat(" ", width-len(runes))
+}
@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestParseRowTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		wantErr  bool
+	}{
+		{name: "fields with widths", template: "{name:30} {instance-id:20} {state:10}"},
+		{name: "natural width", template: "{name} ({private-ip})"},
+		{name: "tag field", template: "{instance-id} {tag:Team:12}"},
+		{name: "empty", template: "  ", wantErr: true},
+		{name: "literal only", template: "instances", wantErr: true},
+		{name: "unknown field", template: "{hostname:10}", wantErr: true},
+		{name: "bad width", template: "{name:abc}", wantErr: true},
+		{name: "zero width", template: "{name:0}", wantErr: true},
+		{name: "unclosed placeholder", template: "{name:10", wantErr: true},
+		{name: "tag without key", template: "{tag}", wantErr: true},
+		{name: "too many parts", template: "{name:10:20}", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRowTemplate(tt.template)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRowTemplate(%q) error = %v, wantErr %v", tt.template, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRowTemplateRender(t *testing.T) {
+	inst := EC2Instance{
+		InstanceID:   "i-0123456789abcdef0",
+		Name:         "payments-api-production-blue",
+		State:        "running",
+		PrivateIP:    "10.0.1.15",
+		InstanceType: "m5.large",
+		Tags:         map[string]string{"Team": "payments"},
+	}
+
+	tests := []struct {
+		name      string
+		template  string
+		maxWidth  int
+		want      []string
+		wantWidth int
+	}{
+		{
+			name:     "field substitution",
+			template: "{instance-id} {private-ip} {type} team={tag:Team}",
+			want:     []string{"i-0123456789abcdef0", "10.0.1.15", "m5.large", "team=payments"},
+		},
+		{
+			name:      "widths pad and truncate",
+			template:  "{name:12}|{type:10}|",
+			want:      []string{"payments-...|", "m5.large  |"},
+			wantWidth: 24,
+		},
+		{
+			name:      "row bounded by max width",
+			template:  "{name:30} {instance-id:20} {state:10}",
+			maxWidth:  40,
+			wantWidth: 40,
+		},
+		{
+			name:     "missing tag renders empty",
+			template: "[{tag:Owner}]",
+			want:     []string{"[]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ParseRowTemplate(tt.template)
+			if err != nil {
+				t.Fatalf("ParseRowTemplate() error = %v", err)
+			}
+			row := tmpl.Render(inst, tt.maxWidth)
+			for _, want := range tt.want {
+				if !strings.Contains(row, want) {
+					t.Errorf("row %q missing %q", row, want)
+				}
+			}
+			if tt.wantWidth > 0 && lipgloss.Width(row) != tt.wantWidth {
+				t.Errorf("row width = %d, want %d (%q)", lipgloss.Width(row), tt.wantWidth, row)
+			}
+			if tt.maxWidth > 0 && lipgloss.Width(row) > tt.maxWidth {
+				t.Errorf("row width %d exceeds max %d", lipgloss.Width(row), tt.maxWidth)
+			}
+		})
+	}
+}
+
+func TestRowTemplateHeader(t *testing.T) {
+	tmpl, err := ParseRowTemplate("{name:8} {tag:Team:6}")
+	if err != nil {
+		t.Fatalf("ParseRowTemplate() error = %v", err)
+	}
+	if got, want := tmpl.Header(0), "NAME     TEAM  "; got != want {
+		t.Errorf("Header() = %q, want %q", got, want)
+	}
+}
+
+func TestNewModelRowTemplateFallback(t *testing.T) {
+	valid := NewModel(context.Background(), nil, Config{EC2RowTemplate: "{name:20} {state}"})
+	if valid.ec2RowTemplate == nil || valid.ec2RowTemplateWarning != "" {
+		t.Errorf("valid template not loaded: warning %q", valid.ec2RowTemplateWarning)
+	}
+
+	invalid := NewModel(context.Background(), nil, Config{EC2RowTemplate: "{bogus}"})
+	if invalid.ec2RowTemplate != nil {
+		t.Error("invalid template should fall back to the default layout")
+	}
+	if !strings.Contains(invalid.ec2RowTemplateWarning, "bogus") {
+		t.Errorf("warning = %q, want mention of the bad field", invalid.ec2RowTemplateWarning)
+	}
+
+	defaults := NewModel(context.Background(), nil, Config{})
+	if defaults.ec2RowTemplate != nil || defaults.ec2RowTemplateWarning != "" {
+		t.Error("no template should use the default layout without a warning")
+	}
+}
+
+func TestSensitiveTagsMaskedInTableAndDetails(t *testing.T) {
+	model := NewModel(context.Background(), nil, Config{
+		EC2RowTemplate: "{instance-id} owner={tag:Owner} team={tag:Team}",
+		SensitiveTags:  []string{"Owner"},
+	})
+	inst := EC2Instance{
+		InstanceID: "i-0123456789abcdef0",
+		State:      "running",
+		Tags:       map[string]string{"Owner": "alice@example.com", "Team": "payments"},
+	}
+
+	row := model.ec2RowTemplate.Render(inst, 0)
+	if !strings.Contains(row, "owner=***") || !strings.Contains(row, "team=payments") {
+		t.Errorf("row = %q, want Owner masked and Team shown", row)
+	}
+
+	details := model.renderEC2Details(inst)
+	if strings.Contains(details, "alice@example.com") {
+		t.Errorf("details leak the masked tag value:\n%s", details)
+	}
+	if !strings.Contains(details, "Owner=***") || !strings.Contains(details, "Team=payments") {
+		t.Errorf("details missing masked Owner or visible Team:\n%s", details)
+	}
+}
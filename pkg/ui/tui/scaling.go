@@ -182,14 +182,14 @@ func (m Model) handleScalingResult(msg ScalingResultMsg) (tea.Model, tea.Cmd) {
 		}
 		m.loading = true
 		m.loadingMsg = "Refreshing Auto Scaling Groups..."
-		return m, LoadASGsCmd(m.ctx, m.client)
+		return m, m.foregroundLoad(LoadASGsCmd(m.ctx, m.client))
 	case ViewNodeGroups:
 		if m.currentView == ViewNodeGroups {
 			m.captureSelection(ViewNodeGroups)
 		}
 		m.loading = true
 		m.loadingMsg = "Refreshing node groups..."
-		return m, LoadNodeGroupsCmd(m.ctx, m.client)
+		return m, m.foregroundLoad(LoadNodeGroupsCmd(m.ctx, m.client))
 	default:
 		return m, nil
 	}
@@ -342,30 +342,38 @@ func ec2TokenMatches(inst EC2Instance, key, value string) bool {
 		return strings.Contains(strings.ToLower(inst.InstanceType), value)
 	case "state":
 		return strings.Contains(strings.ToLower(inst.State), value)
+	case "platform", "os":
+		return strings.ToLower(inst.Platform) == value
 	case "tag":
-		tags := inst.cachedTagsString
-		if tags == "" {
-			var pairs []string
-			for k, v := range inst.Tags {
-				pairs = append(pairs, strings.ToLower(fmt.Sprintf("%s:%s", k, v)))
-				pairs = append(pairs, strings.ToLower(fmt.Sprintf("%s=%s", k, v)))
-			}
-			tags = strings.Join(pairs, " ")
-		}
-		if strings.Contains(value, "=") || strings.Contains(value, ":") {
-			return strings.Contains(tags, value)
-		}
-		for k := range inst.Tags {
-			if strings.Contains(strings.ToLower(k), value) {
-				return true
-			}
-		}
-		return false
+		return ec2TagMatches(inst, value)
 	default:
 		return strings.Contains(inst.cachedNameLower, key+":"+value)
 	}
 }
 
+// ec2TagMatches matches a tag: token, either against key:value and key=value
+// pairs or, without a separator, against the tag keys alone
+func ec2TagMatches(inst EC2Instance, value string) bool {
+	tags := inst.cachedTagsString
+	if tags == "" {
+		var pairs []string
+		for k, v := range inst.Tags {
+			pairs = append(pairs, strings.ToLower(fmt.Sprintf("%s:%s", k, v)))
+			pairs = append(pairs, strings.ToLower(fmt.Sprintf("%s=%s", k, v)))
+		}
+		tags = strings.Join(pairs, " ")
+	}
+	if strings.Contains(value, "=") || strings.Contains(value, ":") {
+		return strings.Contains(tags, value)
+	}
+	for k := range inst.Tags {
+		if strings.Contains(strings.ToLower(k), value) {
+			return true
+		}
+	}
+	return false
+}
+
 func ec2TokensMatch(inst EC2Instance, tokens [][2]string) bool {
 	for _, t := range tokens {
 		if !ec2TokenMatches(inst, strings.ToLower(t[0]), strings.ToLower(t[1])) {
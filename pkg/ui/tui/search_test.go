@@ -35,6 +35,7 @@ func TestEC2MatchesQuery(t *testing.T) {
 		PrivateIP:    "10.0.1.100",
 		PublicIP:     "54.123.45.67",
 		InstanceType: "t3.medium",
+		Platform:     "windows",
 		State:        "running",
 		Tags: map[string]string{
 			"Environment": "production",
@@ -71,6 +72,12 @@ func TestEC2MatchesQuery(t *testing.T) {
 		{"name:web state:running", true},
 		{"name:web state:stopped", false},
 		{"type:t3 state:running", true},
+		{"platform:windows", true},
+		{"platform:linux", false},
+		{"os:windows", true},
+		{"platform:windows state:running tag:Team=backend", true},
+		{"platform:windows state:stopped", false},
+		{"platform:linux tag:Team=backend", false},
 		{"unknown", false},
 	}
 
@@ -4,12 +4,10 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/johnlam90/aws-ssm/pkg/aws"
-	"golang.org/x/sync/errgroup"
 )
 
 // ViewMode represents the current view in the TUI
@@ -72,6 +70,7 @@ type EC2Instance struct {
 	PrivateDNS       string
 	PublicDNS        string
 	InstanceType     string
+	Platform         string
 	AvailabilityZone string
 	Tags             map[string]string
 	LaunchTime       time.Time
@@ -157,6 +156,8 @@ type DataLoadedMsg struct {
 	ASGs             []ASG
 	NodeGroups       []NodeGroup
 	NetworkInstances []aws.InstanceInterfaces
+	OrphanedENIs     []aws.OrphanedENI
+	Warning          string // Set when some items failed to load; the rest are still shown
 	Error            error
 }
 
@@ -204,6 +205,16 @@ type Config struct {
 	Profile    string
 	ConfigPath string
 	NoColor    bool
+	// EC2RowTemplate customizes EC2 table rows; see ParseRowTemplate
+	EC2RowTemplate string
+	// SensitiveTags lists tag keys whose values are shown as ***
+	SensitiveTags []string
+	// ViewOnly disables actions that connect to or change resources
+	ViewOnly bool
+	// EC2Sort is the default EC2 instance order; the zero value keeps the API order
+	EC2Sort aws.InstanceSort
+	// MaxConcurrentLoads bounds simultaneous data loads; 0 uses DefaultMaxConcurrentLoads
+	MaxConcurrentLoads int
 }
 
 // PrecomputeSearchFields precomputes searchable fields for performance
@@ -264,6 +275,7 @@ func LoadEC2InstancesCmd(ctx context.Context, client *aws.Client) tea.Cmd {
 				PrivateDNS:       inst.PrivateDNS,
 				PublicDNS:        inst.PublicDNS,
 				InstanceType:     inst.InstanceType,
+				Platform:         inst.Platform,
 				AvailabilityZone: inst.AvailabilityZone,
 				Tags:             inst.Tags,
 				LaunchTime:       inst.LaunchTime,
@@ -281,7 +293,8 @@ func LoadEC2InstancesCmd(ctx context.Context, client *aws.Client) tea.Cmd {
 	}
 }
 
-// LoadEKSClustersCmd loads EKS clusters asynchronously
+// LoadEKSClustersCmd loads EKS clusters asynchronously. Clusters that fail to
+// describe are left out and reported as a warning.
 func LoadEKSClustersCmd(ctx context.Context, client *aws.Client) tea.Cmd {
 	return func() tea.Msg {
 		clusterNames, err := client.ListClusters(ctx)
@@ -293,31 +306,35 @@ func LoadEKSClustersCmd(ctx context.Context, client *aws.Client) tea.Cmd {
 		}
 
 		// Convert to TUI clusters - fetch basic details for each
-		tuiClusters := make([]EKSCluster, 0, len(clusterNames))
-		for _, name := range clusterNames {
-			// Fetch basic cluster details
+		tuiClusters, failures, err := describeAll(ctx, clusterNames, 1, func(ctx context.Context, name string) (EKSCluster, error) {
 			cluster, err := client.DescribeClusterBasic(ctx, name)
 			if err != nil {
-				// Skip clusters that fail to describe
-				continue
+				return EKSCluster{}, fmt.Errorf("%s: %w", name, err)
 			}
-
-			tuiClusters = append(tuiClusters, EKSCluster{
+			return EKSCluster{
 				Name:    cluster.Name,
 				Status:  cluster.Status,
 				Version: cluster.Version,
 				Arn:     cluster.ARN,
-			})
+			}, nil
+		})
+		if err != nil {
+			return DataLoadedMsg{
+				View:  ViewEKSClusters,
+				Error: err,
+			}
 		}
 
 		return DataLoadedMsg{
 			View:     ViewEKSClusters,
 			Clusters: tuiClusters,
+			Warning:  loadWarning("clusters", failures, len(clusterNames)),
 		}
 	}
 }
 
-// LoadASGsCmd loads Auto Scaling Groups asynchronously
+// LoadASGsCmd loads Auto Scaling Groups asynchronously. ASGs that fail to
+// describe are left out and reported as a warning.
 func LoadASGsCmd(ctx context.Context, client *aws.Client) tea.Cmd {
 	return func() tea.Msg {
 		asgNames, err := client.ListAutoScalingGroups(ctx)
@@ -328,53 +345,14 @@ func LoadASGsCmd(ctx context.Context, client *aws.Client) tea.Cmd {
 			}
 		}
 
-		if len(asgNames) == 0 {
-			return DataLoadedMsg{
-				View: ViewASGs,
-				ASGs: []ASG{},
+		tuiASGs, failures, err := describeAll(ctx, asgNames, asgDescribeWorkerLimit, func(ctx context.Context, name string) (ASG, error) {
+			asg, err := client.DescribeAutoScalingGroup(ctx, name)
+			if err != nil {
+				return ASG{}, fmt.Errorf("%s: %w", name, err)
 			}
-		}
-
-		var (
-			mu      sync.Mutex
-			tuiASGs = make([]ASG, 0, len(asgNames))
-		)
-		g, gCtx := errgroup.WithContext(ctx)
-		concurrency := asgDescribeWorkerLimit
-		if len(asgNames) < concurrency {
-			concurrency = len(asgNames)
-		}
-		if concurrency == 0 {
-			concurrency = 1
-		}
-		sem := make(chan struct{}, concurrency)
-
-		for _, name := range asgNames {
-			name := name
-			g.Go(func() error {
-				select {
-				case <-gCtx.Done():
-					return gCtx.Err()
-				default:
-				}
-
-				sem <- struct{}{}
-				defer func() { <-sem }()
-
-				asg, err := client.DescribeAutoScalingGroup(gCtx, name)
-				if err != nil {
-					// Skip ASGs that fail to describe but keep loading others
-					return nil
-				}
-
-				mu.Lock()
-				tuiASGs = append(tuiASGs, convertToTUIASG(asg))
-				mu.Unlock()
-				return nil
-			})
-		}
-
-		if err := g.Wait(); err != nil {
+			return convertToTUIASG(asg), nil
+		})
+		if err != nil {
 			return DataLoadedMsg{
 				View:  ViewASGs,
 				Error: err,
@@ -382,13 +360,22 @@ func LoadASGsCmd(ctx context.Context, client *aws.Client) tea.Cmd {
 		}
 
 		return DataLoadedMsg{
-			View: ViewASGs,
-			ASGs: tuiASGs,
+			View:    ViewASGs,
+			ASGs:    tuiASGs,
+			Warning: loadWarning("Auto Scaling Groups", failures, len(asgNames)),
 		}
 	}
 }
 
-// LoadNodeGroupsCmd loads EKS node groups asynchronously
+// nodeGroupTarget identifies one node group to describe
+type nodeGroupTarget struct {
+	clusterName   string
+	nodeGroupName string
+}
+
+// LoadNodeGroupsCmd loads EKS node groups asynchronously. Clusters whose node
+// groups cannot be listed, and node groups that fail to describe, are left
+// out and reported as a warning.
 func LoadNodeGroupsCmd(ctx context.Context, client *aws.Client) tea.Cmd {
 	return func() tea.Msg {
 		clusterNames, err := client.ListClusters(ctx)
@@ -399,16 +386,15 @@ func LoadNodeGroupsCmd(ctx context.Context, client *aws.Client) tea.Cmd {
 			}
 		}
 
-		type nodeGroupTarget struct {
-			clusterName   string
-			nodeGroupName string
-		}
-
-		var targets []nodeGroupTarget
+		var (
+			targets      []nodeGroupTarget
+			listFailures []error
+		)
 		for _, clusterName := range clusterNames {
 			ngNames, err := client.ListNodeGroupsForCluster(ctx, clusterName)
 			if err != nil {
 				// Skip problematic clusters but continue loading others
+				listFailures = append(listFailures, fmt.Errorf("%s: %w", clusterName, err))
 				continue
 			}
 
@@ -420,53 +406,14 @@ func LoadNodeGroupsCmd(ctx context.Context, client *aws.Client) tea.Cmd {
 			}
 		}
 
-		if len(targets) == 0 {
-			return DataLoadedMsg{
-				View:       ViewNodeGroups,
-				NodeGroups: []NodeGroup{},
+		nodeGroups, failures, err := describeAll(ctx, targets, nodeGroupDescribeWorkerLimit, func(ctx context.Context, target nodeGroupTarget) (NodeGroup, error) {
+			ng, err := client.DescribeNodeGroupPublic(ctx, target.clusterName, target.nodeGroupName)
+			if err != nil {
+				return NodeGroup{}, fmt.Errorf("%s/%s: %w", target.clusterName, target.nodeGroupName, err)
 			}
-		}
-
-		var (
-			nodeGroups = make([]NodeGroup, 0, len(targets))
-			mu         sync.Mutex
-		)
-
-		g, gCtx := errgroup.WithContext(ctx)
-		concurrency := nodeGroupDescribeWorkerLimit
-		if len(targets) < concurrency {
-			concurrency = len(targets)
-		}
-		if concurrency == 0 {
-			concurrency = 1
-		}
-		sem := make(chan struct{}, concurrency)
-
-		for _, target := range targets {
-			target := target
-			g.Go(func() error {
-				select {
-				case <-gCtx.Done():
-					return gCtx.Err()
-				default:
-				}
-
-				sem <- struct{}{}
-				defer func() { <-sem }()
-
-				ng, err := client.DescribeNodeGroupPublic(gCtx, target.clusterName, target.nodeGroupName)
-				if err != nil {
-					return nil
-				}
-
-				mu.Lock()
-				nodeGroups = append(nodeGroups, convertToTUINodeGroup(target.clusterName, ng))
-				mu.Unlock()
-				return nil
-			})
-		}
-
-		if err := g.Wait(); err != nil {
+			return convertToTUINodeGroup(target.clusterName, ng), nil
+		})
+		if err != nil {
 			return DataLoadedMsg{
 				View:  ViewNodeGroups,
 				Error: err,
@@ -476,6 +423,10 @@ func LoadNodeGroupsCmd(ctx context.Context, client *aws.Client) tea.Cmd {
 		return DataLoadedMsg{
 			View:       ViewNodeGroups,
 			NodeGroups: nodeGroups,
+			Warning: joinLoadWarnings(
+				loadWarning("clusters", listFailures, len(clusterNames)),
+				loadWarning("node groups", failures, len(targets)),
+			),
 		}
 	}
 }
@@ -491,9 +442,18 @@ func LoadNetworkInterfacesCmd(ctx context.Context, client *aws.Client) tea.Cmd {
 			}
 		}
 
+		// Orphan detection is informational; the view still loads without it
+		var warning string
+		orphans, err := client.ListOrphanedENIs(ctx)
+		if err != nil {
+			warning = fmt.Sprintf("orphaned ENI detection failed: %v", err)
+		}
+
 		return DataLoadedMsg{
 			View:             ViewNetworkInterfaces,
 			NetworkInstances: interfaces,
+			OrphanedENIs:     orphans,
+			Warning:          warning,
 		}
 	}
 }
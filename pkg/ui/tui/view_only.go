@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// viewOnlyIndicator marks the status bar when mutating actions are disabled
+const viewOnlyIndicator = "VIEW-ONLY"
+
+// mutatingAction returns a description of the change action triggers in view,
+// and whether it is one that view-only mode disables
+func mutatingAction(view ViewMode, action NavigationKey) (string, bool) {
+	switch {
+	case view == ViewEC2Instances && action == NavSSH:
+		return "connecting to instances", true
+	case (view == ViewASGs || view == ViewNodeGroups) && action == NavScale:
+		return "scaling", true
+	case view == ViewNodeGroups && action == NavSelect:
+		return "launch template updates", true
+	}
+	return "", false
+}
+
+// blockViewOnly reports whether action must be ignored because the TUI runs in
+// view-only mode, leaving a status message explaining why
+func (m Model) blockViewOnly(action NavigationKey) (Model, bool) {
+	if !m.config.ViewOnly {
+		return m, false
+	}
+	desc, ok := mutatingAction(m.currentView, action)
+	if !ok {
+		return m, false
+	}
+	m.statusMessage = fmt.Sprintf("View-only mode: %s is disabled", desc)
+	return m, true
+}
+
+// footerKey is a footer hint; mutating hints are greyed out in view-only mode
+type footerKey struct {
+	key      string
+	desc     string
+	mutating bool
+}
+
+// renderFooterKeys joins footer hints, dimming those view-only mode disables
+func (m Model) renderFooterKeys(keys []footerKey) string {
+	disabled := lipgloss.NewStyle().Foreground(GetTheme().Muted()).Strikethrough(true)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if m.config.ViewOnly && k.mutating {
+			parts = append(parts, disabled.Render(k.key+" "+k.desc))
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s %s", StatusBarKeyStyle().Render(k.key), StatusBarValueStyle().Render(k.desc)))
+	}
+	return HelpStyle().Render(strings.Join(parts, " • "))
+}
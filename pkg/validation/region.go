@@ -0,0 +1,92 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// KnownRegions lists the AWS regions recognized without relying on the region pattern
+var KnownRegions = []string{
+	"af-south-1",
+	"ap-east-1", "ap-east-2",
+	"ap-northeast-1", "ap-northeast-2", "ap-northeast-3",
+	"ap-south-1", "ap-south-2",
+	"ap-southeast-1", "ap-southeast-2", "ap-southeast-3", "ap-southeast-4", "ap-southeast-5", "ap-southeast-7",
+	"ca-central-1", "ca-west-1",
+	"cn-north-1", "cn-northwest-1",
+	"eu-central-1", "eu-central-2",
+	"eu-north-1",
+	"eu-south-1", "eu-south-2",
+	"eu-west-1", "eu-west-2", "eu-west-3",
+	"il-central-1",
+	"me-central-1", "me-south-1",
+	"mx-central-1",
+	"sa-east-1",
+	"us-east-1", "us-east-2",
+	"us-gov-east-1", "us-gov-west-1",
+	"us-west-1", "us-west-2",
+}
+
+// knownRegionPattern accepts well-formed regions newer than KnownRegions:
+// a two-letter area, an optional partition marker, a compass direction and a number
+var knownRegionPattern = regexp.MustCompile(`^[a-z]{2}-(gov-|iso[a-z]?-)?(central|north|south|east|west|northeast|northwest|southeast|southwest)-\d{1,2}$`)
+
+// maxRegionSuggestionDistance is the largest edit distance offered as a did-you-mean
+const maxRegionSuggestionDistance = 3
+
+// NormalizeRegion trims and lowercases an AWS region and checks it is a known
+// region or follows the region format. Typos produce an error suggesting the
+// closest known region.
+func NormalizeRegion(value string) (string, error) {
+	region := strings.ToLower(strings.TrimSpace(value))
+	if region == "" {
+		return "", fmt.Errorf("region cannot be empty")
+	}
+	for _, known := range KnownRegions {
+		if region == known {
+			return region, nil
+		}
+	}
+	if knownRegionPattern.MatchString(region) {
+		return region, nil
+	}
+
+	if suggestion := SuggestRegion(region); suggestion != "" {
+		return "", fmt.Errorf("invalid AWS region %q (did you mean %q?)", value, suggestion)
+	}
+	return "", fmt.Errorf("invalid AWS region %q (expected a region such as us-east-1)", value)
+}
+
+// SuggestRegion returns the known region closest to value, or "" when none is close
+func SuggestRegion(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	best, bestDistance := "", maxRegionSuggestionDistance+1
+	for _, known := range KnownRegions {
+		if d := editDistance(value, known); d < bestDistance {
+			best, bestDistance = known, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,74 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeRegion(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"known region", "us-east-1", "us-east-1"},
+		{"uppercase", "US-EAST-1", "us-east-1"},
+		{"surrounding spaces", "  eu-west-2 ", "eu-west-2"},
+		{"gov cloud", "us-gov-west-1", "us-gov-west-1"},
+		{"newer region by format", "ap-southeast-9", "ap-southeast-9"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeRegion(tt.input)
+			if err != nil {
+				t.Fatalf("NormalizeRegion(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeRegion(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeRegionRejectsInvalid(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          string
+		wantSuggestion string
+	}{
+		{"missing dash", "us-east1", "us-east-1"},
+		{"uppercase typo", "US-EAST1", "us-east-1"},
+		{"transposed letters", "eu-wset-1", "eu-west-1"},
+		{"missing number", "ap-southeast", "ap-southeast-1"},
+		{"underscores", "us_west_2", "us-west-2"},
+		{"not a region", "production", ""},
+		{"empty", "  ", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NormalizeRegion(tt.input)
+			if err == nil {
+				t.Fatalf("NormalizeRegion(%q) succeeded, want an error", tt.input)
+			}
+			hasSuggestion := strings.Contains(err.Error(), "did you mean")
+			if tt.wantSuggestion == "" {
+				if hasSuggestion {
+					t.Errorf("error = %v, want no suggestion", err)
+				}
+				return
+			}
+			if !strings.Contains(err.Error(), `did you mean "`+tt.wantSuggestion+`"`) {
+				t.Errorf("error = %v, want suggestion %q", err, tt.wantSuggestion)
+			}
+		})
+	}
+}
+
+func TestSuggestRegion(t *testing.T) {
+	if got := SuggestRegion("us-wst-2"); got != "us-west-2" {
+		t.Errorf("SuggestRegion(us-wst-2) = %q, want us-west-2", got)
+	}
+	if got := SuggestRegion("kubernetes"); got != "" {
+		t.Errorf("SuggestRegion(kubernetes) = %q, want no suggestion", got)
+	}
+}
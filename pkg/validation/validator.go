@@ -49,9 +49,6 @@ var (
 	// DNS name pattern
 	dnsPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9-]*(\.[a-zA-Z0-9][a-zA-Z0-9-]*)*$`)
 
-	// AWS region pattern
-	regionPattern = regexp.MustCompile(`^[a-z]{2}-[a-z]+-\d+$`)
-
 	// AWS profile pattern
 	profilePattern = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
 
@@ -211,21 +208,13 @@ func (v *RegionValidator) Validate(value interface{}) *Result {
 		return result
 	}
 
-	// Common valid regions (subset for validation)
-	validRegions := map[string]bool{
-		"us-east-1": true, "us-east-2": true, "us-west-1": true, "us-west-2": true,
-		"eu-west-1": true, "eu-west-2": true, "eu-west-3": true, "eu-central-1": true,
-		"ap-southeast-1": true, "ap-southeast-2": true, "ap-northeast-1": true,
-		"ap-northeast-2": true, "ap-south-1": true, "ca-central-1": true,
-		"sa-east-1": true, "us-gov-west-1": true, "us-gov-east-1": true,
-	}
-
-	if !regionPattern.MatchString(region) && !validRegions[region] {
-		result.AddError("region", "invalid AWS region format")
+	normalized, err := NormalizeRegion(region)
+	if err != nil {
+		result.AddError("region", err.Error())
 		return result
 	}
 
-	result.AddField("region", region)
+	result.AddField("region", normalized)
 	return result
 }
 